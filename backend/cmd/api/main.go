@@ -5,7 +5,8 @@ import (
 	"os"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/routes"
-	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/architecture" // Register AWS architecture generator
+	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/architecture"   // Register AWS architecture generator
+	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/architecture" // Register Azure architecture generator
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/database"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/logger"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server"
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/database"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/services/pricing_importer"
@@ -13,20 +14,35 @@ import (
 
 func main() {
 	var filePath string
+	var priceListFiles string
 	flag.StringVar(&filePath, "file", "", "Path to the scraper EC2 instances JSON file (e.g., www/instances.json)")
+	flag.StringVar(&priceListFiles, "price-list", "", "Comma-separated AWS Price List offer files (AmazonEC2, AmazonS3, AWSELB, AWSLambda, AmazonRDS, AWSDataTransfer) to import as the region pricing catalog")
 	flag.Parse()
 
-	if filePath == "" {
-		fmt.Fprintf(os.Stderr, "Error: -file flag is required\n")
-		fmt.Fprintf(os.Stderr, "Usage: %s -file <path-to-instances.json>\n", os.Args[0])
+	if filePath == "" && priceListFiles == "" {
+		fmt.Fprintf(os.Stderr, "Error: -file or -price-list flag is required\n")
+		fmt.Fprintf(os.Stderr, "Usage: %s [-file <path-to-instances.json>] [-price-list <offer.json>[,<offer.json>...]]\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Example: %s -file ../../scripts/scraper/www/instances.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Example: %s -price-list AmazonEC2-eu-west-1.json,AmazonS3-eu-west-1.json\n", os.Args[0])
 		os.Exit(1)
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: File not found: %s\n", filePath)
-		os.Exit(1)
+	var offerFiles []string
+	for _, f := range strings.Split(priceListFiles, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			offerFiles = append(offerFiles, f)
+		}
+	}
+
+	// Check if files exist
+	for _, f := range append(offerFiles, filePath) {
+		if f == "" {
+			continue
+		}
+		if _, err := os.Stat(f); os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: File not found: %s\n", f)
+			os.Exit(1)
+		}
 	}
 
 	// Connect to database
@@ -41,21 +57,26 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Import pricing data
 	ctx := context.Background()
+
+	if filePath != "" {
+		importEC2Pricing(ctx, importer, filePath)
+	}
+	for _, f := range offerFiles {
+		importPriceList(ctx, importer, f)
+	}
+
+	fmt.Println("\n✨ Pricing data is now available in the database!")
+}
+
+// importEC2Pricing imports the scraper's EC2 instance rates
+func importEC2Pricing(ctx context.Context, importer *pricing_importer.Importer, filePath string) {
 	fmt.Printf("Importing EC2 pricing data from: %s\n", filePath)
 	fmt.Println("This may take a few minutes...")
 
 	stats, err := importer.ImportEC2Pricing(ctx, filePath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Import failed: %v\n", err)
-		if len(stats.Errors) > 0 {
-			fmt.Fprintf(os.Stderr, "Errors encountered:\n")
-			for _, e := range stats.Errors {
-				fmt.Fprintf(os.Stderr, "  - %s\n", e)
-			}
-		}
-		os.Exit(1)
+		exitWithImportError(stats, err)
 	}
 
 	// Print statistics
@@ -63,6 +84,7 @@ func main() {
 	fmt.Printf("\n📊 Import Statistics:\n")
 	fmt.Printf("  Total Instances Processed: %d\n", stats.TotalInstances)
 	fmt.Printf("  Total Rates Imported: %d\n", stats.TotalRates)
+	fmt.Printf("  Reserved/Savings Plan Rates: %d\n", stats.CommitmentRates)
 
 	if len(stats.RegionsProcessed) > 0 {
 		fmt.Printf("\n  Regions Processed:\n")
@@ -78,12 +100,55 @@ func main() {
 		}
 	}
 
+	printWarnings(stats)
+}
+
+// importPriceList imports the region catalog rates of a price list offer file
+func importPriceList(ctx context.Context, importer *pricing_importer.Importer, filePath string) {
+	fmt.Printf("\nImporting region pricing catalog from: %s\n", filePath)
+
+	stats, err := importer.ImportPriceList(ctx, filePath)
+	if err != nil {
+		exitWithImportError(stats, err)
+	}
+
+	fmt.Println("\n✅ Import completed successfully!")
+	fmt.Printf("\n📊 Import Statistics:\n")
+	fmt.Printf("  Total Rates Imported: %d\n", stats.TotalRates)
+
+	if len(stats.ResourceTypesProcessed) > 0 {
+		fmt.Printf("\n  Resource Types Processed:\n")
+		for resourceType, count := range stats.ResourceTypesProcessed {
+			fmt.Printf("    %s: %d rates\n", resourceType, count)
+		}
+	}
+
+	if len(stats.RegionsProcessed) > 0 {
+		fmt.Printf("\n  Regions Processed:\n")
+		for region, count := range stats.RegionsProcessed {
+			fmt.Printf("    %s: %d rates\n", region, count)
+		}
+	}
+
+	printWarnings(stats)
+}
+
+func exitWithImportError(stats *pricing_importer.ImportStats, err error) {
+	fmt.Fprintf(os.Stderr, "Error: Import failed: %v\n", err)
+	if stats != nil && len(stats.Errors) > 0 {
+		fmt.Fprintf(os.Stderr, "Errors encountered:\n")
+		for _, e := range stats.Errors {
+			fmt.Fprintf(os.Stderr, "  - %s\n", e)
+		}
+	}
+	os.Exit(1)
+}
+
+func printWarnings(stats *pricing_importer.ImportStats) {
 	if len(stats.Errors) > 0 {
 		fmt.Printf("\n⚠️  Warnings:\n")
 		for _, e := range stats.Errors {
 			fmt.Printf("  - %s\n", e)
 		}
 	}
-
-	fmt.Println("\n✨ Pricing data is now available in the database!")
 }
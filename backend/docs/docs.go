@@ -24,6 +24,61 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/login": {
+            "post": {
+                "description": "Exchange an email and password for a bearer token. Send it as \"Authorization: Bearer \u003ctoken\u003e\" on protected routes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/aws/networking/schemas": {
             "get": {
                 "description": "Get structured schema definitions for all resources under a provider/service",
@@ -289,6 +344,29 @@ const docTemplate = `{
                 }
             }
         },
+        "/compliance/standards": {
+            "get": {
+                "description": "List the built-in compliance control sets. Slugs match the compliance standards of marketplace templates.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "List compliance standards",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceStandardInfo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/diagrams/process": {
             "post": {
                 "description": "Process a diagram JSON and create/update a project",
@@ -317,8 +395,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Cloud Provider (default: aws)",
+                        "name": "provider",
                         "in": "query"
                     },
                     {
@@ -346,6 +424,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -378,6 +463,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "object"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cloud Provider (default: aws)",
+                        "name": "provider",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -460,82 +551,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/iam/policies": {
-            "get": {
-                "description": "Get a list of AWS managed policies, optionally filtered by service",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "iam"
-                ],
-                "summary": "List AWS Managed Policies",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by service (e.g. s3, lambda)",
-                        "name": "service",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.PolicyOutput"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/iam/policies/between": {
+        "/guardrails": {
             "get": {
-                "description": "Get a list of policies that allow source service to access destination service",
+                "description": "List the authenticated user's guardrails, and the instance-wide guardrails that apply to every user. Both are checked whenever an architecture's rules are validated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "iam"
-                ],
-                "summary": "List Policies Between Services",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Source service (e.g. lambda)",
-                        "name": "source",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Destination service (e.g. s3)",
-                        "name": "destination",
-                        "in": "query",
-                        "required": true
-                    }
+                    "guardrails"
                 ],
+                "summary": "List guardrails",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.PolicyOutput"
-                            }
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -549,11 +583,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/iam/roles": {
+            },
             "post": {
-                "description": "Create a new IAM role, potentially virtual",
+                "description": "Save a policy document as a guardrail of the authenticated user. The source must parse; syntax errors are reported with their line and column.",
                 "consumes": [
                     "application/json"
                 ],
@@ -561,17 +593,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "iam"
+                    "guardrails"
                 ],
-                "summary": "Create IAM Role",
+                "summary": "Create a guardrail",
                 "parameters": [
                     {
-                        "description": "Role creation request",
-                        "name": "role",
+                        "description": "Guardrail",
+                        "name": "guardrail",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMRoleRequest"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateGuardrailRequest"
                         }
                     }
                 ],
@@ -579,7 +611,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.RoleOutput"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailResponse"
                         }
                     },
                     "400": {
@@ -589,6 +621,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -599,35 +638,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/iam/users": {
-            "post": {
-                "description": "Create a new IAM user, potentially virtual",
-                "consumes": [
-                    "application/json"
-                ],
+        "/guardrails/{id}": {
+            "get": {
+                "description": "Get one of the authenticated user's guardrails by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "iam"
+                    "guardrails"
                 ],
-                "summary": "Create IAM User",
+                "summary": "Get a guardrail",
                 "parameters": [
                     {
-                        "description": "User creation request",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Guardrail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.UserOutput"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailResponse"
                         }
                     },
                     "400": {
@@ -637,6 +671,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -645,62 +686,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects": {
-            "get": {
-                "description": "Get a list of projects with pagination, sorting, and searching",
+            },
+            "put": {
+                "description": "Change the name, description, source or enabled flag of one of the authenticated user's guardrails. Omitted fields are left unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "projects"
+                    "guardrails"
                 ],
-                "summary": "List projects",
+                "summary": "Update a guardrail",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort field",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Guardrail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "User ID filter",
-                        "name": "user_id",
-                        "in": "query"
+                        "description": "Guardrail changes",
+                        "name": "guardrail",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateGuardrailRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailResponse"
                         }
                     },
                     "400": {
@@ -710,6 +731,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -719,35 +747,24 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a new project for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Delete one of the authenticated user's guardrails",
                 "tags": [
-                    "projects"
+                    "guardrails"
                 ],
-                "summary": "Create a new project",
+                "summary": "Delete a guardrail",
                 "parameters": [
                     {
-                        "description": "Project creation request",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateProjectRequest"
-                        }
+                        "type": "string",
+                        "description": "Guardrail ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -756,6 +773,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -766,44 +790,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}": {
+        "/iam/policies": {
             "get": {
-                "description": "Get a project snapshot by its ID",
+                "description": "Get a list of AWS managed policies, optionally filtered by service",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "projects"
+                    "iam"
                 ],
-                "summary": "Get a project",
+                "summary": "List AWS Managed Policies",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by service (e.g. s3, lambda)",
+                        "name": "service",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.PolicyOutput"
+                            }
                         }
                     },
                     "500": {
@@ -814,42 +826,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "In-place update of project metadata (name, description, cloud_provider, region, iac_tool_id). Does NOT create a new version.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/iam/policies/between": {
+            "get": {
+                "description": "Get a list of policies that allow source service to access destination service",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "projects"
+                    "iam"
                 ],
-                "summary": "Update project metadata",
+                "summary": "List Policies Between Services",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Source service (e.g. lambda)",
+                        "name": "source",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Project update request",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateProjectRequest"
-                        }
+                        "type": "string",
+                        "description": "Destination service (e.g. s3)",
+                        "name": "destination",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.PolicyOutput"
+                            }
                         }
                     },
                     "400": {
@@ -859,13 +871,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -874,25 +879,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a project by its ID",
+            }
+        },
+        "/iam/roles": {
+            "post": {
+                "description": "Create a new IAM role, potentially virtual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "projects"
+                    "iam"
                 ],
-                "summary": "Delete a project",
+                "summary": "Create IAM Role",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Role creation request",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.RoleOutput"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -911,30 +929,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/architecture": {
-            "get": {
-                "description": "Get the full architecture for a project snapshot. For a specific historical state, use GET /projects/{id}/versions/{version_id}.",
+        "/iam/users": {
+            "post": {
+                "description": "Create a new IAM user, potentially virtual",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "projects"
+                    "iam"
                 ],
-                "summary": "Get architecture",
+                "summary": "Create IAM User",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User creation request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMUserRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.UserOutput"
                         }
                     },
                     "400": {
@@ -954,191 +977,198 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/cost/estimate": {
+        "/projects": {
             "get": {
-                "description": "Calculate the estimated cost for the entire project architecture",
+                "description": "Get a list of the authenticated user's projects with pagination, sorting, and searching",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cost"
+                    "projects"
                 ],
-                "summary": "Get project cost estimate",
+                "summary": "List projects",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc/desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ArchitectureCostEstimate"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/cost/estimate/resources/{resourceName}": {
-            "get": {
-                "description": "Calculate the estimated cost for a specific resource in the project",
+            },
+            "post": {
+                "description": "Create a new project for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cost"
+                    "projects"
                 ],
-                "summary": "Get resource cost estimate",
+                "summary": "Create a new project",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Resource Name",
-                        "name": "resourceName",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "Project creation request",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceCostEstimate"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/projects/{id}/cost/optimize": {
-            "get": {
-                "description": "Get suggestions for cost optimization based on architectural patterns",
+        "/projects/import/tfstate": {
+            "post": {
+                "description": "Create a new project from a terraform.tfstate document (format version 4). Managed resources are drawn with the IDs, ARNs and lifecycle state recorded in the state, containment is derived from vpc_id and subnet_id, and nodes are laid out automatically. No cloud credentials are needed.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cost"
+                    "import"
                 ],
-                "summary": "Get project cost optimization suggestions",
+                "summary": "Import Terraform state",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Project details and the state document",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ImportStateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationWithSavings"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.StateImportResult"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/projects/{id}/download": {
+        "/projects/{id}": {
             "get": {
-                "description": "Generates and downloads the ZIP file of the code",
+                "description": "Get a project snapshot by its ID",
                 "produces": [
-                    "application/zip"
+                    "application/json"
                 ],
                 "tags": [
-                    "Code Generation"
+                    "projects"
                 ],
-                "summary": "Download generated code",
+                "summary": "Get a project",
                 "parameters": [
                     {
                         "type": "string",
@@ -1146,46 +1176,40 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "IaC Tool (terraform, pulumi, etc)",
-                        "name": "tool",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/duplicate": {
-            "post": {
-                "description": "Create an independent copy of an existing project and its architecture (new root, version 1)",
+            },
+            "put": {
+                "description": "In-place update of project metadata (name, description, cloud_provider, region, iac_tool_id). Does NOT create a new version.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1195,7 +1219,7 @@ const docTemplate = `{
                 "tags": [
                     "projects"
                 ],
-                "summary": "Duplicate a project",
+                "summary": "Update project metadata",
                 "parameters": [
                     {
                         "type": "string",
@@ -1205,25 +1229,63 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Requires 'name'",
-                        "name": "body",
+                        "description": "Project update request",
+                        "name": "project",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateProjectRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a project by its ID",
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Delete a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1242,19 +1304,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/generate": {
-            "post": {
-                "description": "Generates IaC files for the specified project and tool",
-                "consumes": [
-                    "application/json"
-                ],
+        "/projects/{id}/architecture": {
+            "get": {
+                "description": "Get the full architecture for a project snapshot. For a specific historical state, use GET /projects/{id}/versions/{version_id}.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Code Generation"
+                    "projects"
                 ],
-                "summary": "Generate Infrastructure-as-Code",
+                "summary": "Get architecture",
                 "parameters": [
                     {
                         "type": "string",
@@ -1262,59 +1321,46 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Generation options",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerateCodeRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationResponse"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/projects/{id}/versions": {
+        "/projects/{id}/backend": {
             "get": {
-                "description": "Returns the full ordered version chain. Any project_id in the lineage may be used.",
+                "description": "Get the Terraform state backend saved with the project. A null backend means local state.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "versioning"
+                    "projects"
                 ],
-                "summary": "List versions",
+                "summary": "Get state backend",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (any version in the lineage)",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1324,10 +1370,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionSummary"
-                            }
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ProjectBackendResponse"
                         }
                     },
                     "400": {
@@ -1346,8 +1389,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Save a full architecture state as a new immutable snapshot. Returns the version metadata including the new project_id that encodes this snapshot.",
+            "put": {
+                "description": "Save the S3 (with optional DynamoDB locking), local or HTTP state backend used when generating Terraform. Later versions inherit it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1355,32 +1398,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "versioning"
+                    "projects"
                 ],
-                "summary": "Create new version",
+                "summary": "Update state backend",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (any version in the lineage)",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Architecture state and optional message",
-                        "name": "body",
+                        "description": "State backend",
+                        "name": "backend",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CreateVersionRequest"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.StateBackendConfig"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ProjectBackendResponse"
                         }
                     },
                     "400": {
@@ -1390,13 +1433,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1405,33 +1441,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/versions/latest": {
-            "get": {
-                "description": "Returns the latest version in the chain including full architecture state.",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Remove the saved state backend so generated Terraform uses local state.",
                 "tags": [
-                    "versioning"
+                    "projects"
                 ],
-                "summary": "Get latest version",
+                "summary": "Reset state backend",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID (any version in the lineage)",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1440,15 +1468,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1457,16 +1478,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/versions/{version_id}": {
+        "/projects/{id}/budget": {
             "get": {
-                "description": "Returns a specific version entry including its full architecture snapshot.",
+                "description": "Get the monthly budget versions of the project are compared against: a total limit, limits per resource category (e.g. compute, networking) and whether versions over budget are flagged or blocked.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "versioning"
+                    "projects"
                 ],
-                "summary": "Get specific version",
+                "summary": "Get budget",
                 "parameters": [
                     {
                         "type": "string",
@@ -1474,20 +1495,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Version ID",
-                        "name": "version_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Budget"
                         }
                     },
                     "400": {
@@ -1513,12 +1527,18 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Removes a version entry from the chain. The underlying project snapshot is preserved.",
+            "put": {
+                "description": "Save the monthly budget in USD. Each new version and each version cost estimate is compared against it and the comparison is recorded with the project pricing. With action \"block\", versions over budget are refused with 409; with \"flag\" (default) they are saved and the overages reported. Later versions inherit it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "versioning"
+                    "projects"
                 ],
-                "summary": "Delete version",
+                "summary": "Update budget",
                 "parameters": [
                     {
                         "type": "string",
@@ -1528,26 +1548,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Version ID",
-                        "name": "version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Budget",
+                        "name": "budget",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Budget"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Budget"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1561,18 +1579,13 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/versions/{version_id}/architecture": {
-            "get": {
-                "description": "Returns the full architecture state captured in a specific version.",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Remove the budget so versions are no longer compared against it.",
                 "tags": [
-                    "versioning"
+                    "projects"
                 ],
-                "summary": "Get architecture for version",
+                "summary": "Remove budget",
                 "parameters": [
                     {
                         "type": "string",
@@ -1580,21 +1593,11 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Version ID",
-                        "name": "version_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1603,13 +1606,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1620,16 +1616,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/versions/{version_id}/estimate-cost": {
-            "post": {
-                "description": "Calculate the estimated monthly cost for the architecture of a specific version",
+        "/projects/{id}/cost/estimate": {
+            "get": {
+                "description": "Calculate the estimated cost for the entire project architecture. With purchase_option, EC2 instances are priced as Reserved Instances or under a Compute Savings Plan instead of On-Demand.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "versioning"
+                    "cost"
                 ],
-                "summary": "Estimate cost for a version",
+                "summary": "Get project cost estimate",
                 "parameters": [
                     {
                         "type": "string",
@@ -1640,10 +1636,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Version ID",
-                        "name": "version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "on_demand (default), reserved_{1yr,3yr}_{no,partial,all}_upfront or savings_plan_{1yr,3yr}_{no,partial,all}_upfront",
+                        "name": "purchase_option",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1683,19 +1678,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/versions/{version_id}/export/terraform": {
-            "post": {
-                "description": "Generates IaC files for the architecture captured in a specific version",
-                "consumes": [
-                    "application/json"
-                ],
+        "/projects/{id}/cost/estimate/resources/{resourceName}": {
+            "get": {
+                "description": "Calculate the estimated cost for a specific resource in the project",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "versioning"
+                    "cost"
                 ],
-                "summary": "Generate IaC for version",
+                "summary": "Get resource cost estimate",
                 "parameters": [
                     {
                         "type": "string",
@@ -1706,25 +1698,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Version ID",
-                        "name": "version_id",
+                        "description": "Resource Name",
+                        "name": "resourceName",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Generation options",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerateCodeRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationResponse"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceCostEstimate"
                         }
                     },
                     "400": {
@@ -1736,6 +1720,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1748,16 +1741,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/versions/{version_id}/validate": {
-            "post": {
-                "description": "Validates the architecture state captured in the specified version.",
+        "/projects/{id}/cost/optimize": {
+            "get": {
+                "description": "Get cost optimization suggestions for the project architecture: instance rightsizing across families, Graviton alternatives, gp2 to gp3 volumes, NAT gateway consolidation, VPC gateway endpoints, S3 storage classes and unattached Elastic IPs. Savings are monthly price differences computed from the pricing rates used for cost estimates.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "versioning"
+                    "cost"
                 ],
-                "summary": "Validate version architecture",
+                "summary": "Get project cost optimization suggestions",
                 "parameters": [
                     {
                         "type": "string",
@@ -1765,70 +1758,91 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Version ID",
-                        "name": "version_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationResponse"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationWithSavings"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/static/cloud-config": {
+        "/projects/{id}/download": {
             "get": {
-                "description": "Get global cloud configurations like regions, instance types, etc.",
+                "description": "Generates and downloads the ZIP file of the code",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "static"
+                    "Code Generation"
                 ],
-                "summary": "Get cloud configurations",
+                "summary": "Download generated code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider name (e.g. aws)",
-                        "name": "provider",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IaC Tool (terraform, pulumi, etc)",
+                        "name": "tool",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Output modularity (low, medium, high); high writes each VPC as a local module",
+                        "name": "modularity",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -1843,64 +1857,109 @@ const docTemplate = `{
                 }
             }
         },
-        "/static/providers": {
-            "get": {
-                "description": "Get a list of all supported cloud providers (AWS, GCP, etc.)",
+        "/projects/{id}/duplicate": {
+            "post": {
+                "description": "Create an independent copy of an existing project and its architecture (new root, version 1)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "static"
+                    "projects"
                 ],
-                "summary": "List supported cloud providers",
-                "responses": {
-                    "200": {
-                        "description": "List of providers",
+                "summary": "Duplicate a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Requires 'name'",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
+                            "type": "object",
+                            "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/static/resource-models": {
-            "get": {
-                "description": "Get the JSON structure of resource output models grouped by service category with default mock data",
+        "/projects/{id}/generate": {
+            "post": {
+                "description": "Generates IaC files for the specified project and tool",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "static"
+                    "Code Generation"
                 ],
-                "summary": "Get resource output models",
+                "summary": "Generate Infrastructure-as-Code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider name (e.g. aws)",
-                        "name": "provider",
-                        "in": "query"
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Generation options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerateCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -1916,50 +1975,105 @@ const docTemplate = `{
                 }
             }
         },
-        "/static/resource-types": {
+        "/projects/{id}/import/terraform": {
+            "post": {
+                "description": "Parse Terraform files (resources, providers, variables and outputs) and save the recognised resources as a new version of the project. Containment is rebuilt from references such as vpc_id and subnet_id, and nodes are laid out automatically. Constructs with no diagram equivalent are listed in issues.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import Terraform",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (any version in the lineage)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Terraform files keyed by file name",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ImportTerraformRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/usage-profile": {
             "get": {
-                "description": "Get a list of all supported resource types, grouped by service category (e.g. Compute, Storage)",
+                "description": "Get the usage profile saved with the project: uptime schedules, requests, storage, capacity and traffic per resource (by name) and edge. An empty profile means resources run 24/7 with no traffic.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "static"
+                    "projects"
                 ],
-                "summary": "List resource types",
+                "summary": "Get usage profile",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider name (e.g. aws)",
-                        "name": "provider",
-                        "in": "query"
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UsageProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "post": {
-                "description": "Create a new user account",
+            },
+            "put": {
+                "description": "Save the workload cost estimates assume. Monthly amounts are scaled to the estimate duration; schedules scale hourly On-Demand charges; edge traffic is priced as data transfer out to the internet (no target) or between availability zones. Later versions inherit it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1967,26 +2081,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "projects"
                 ],
-                "summary": "Create a new user",
+                "summary": "Update usage profile",
                 "parameters": [
                     {
-                        "description": "User creation request",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Usage profile",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateUserRequest"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UsageProfile"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.UserResponse"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UsageProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the saved usage profile so cost estimates assume resources run 24/7 with no traffic.",
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Remove usage profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2005,20 +2161,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/projects/{id}/versions": {
             "get": {
-                "description": "Get a user by ID",
+                "description": "Returns the full ordered version chain. Any project_id in the lineage may be used.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "versioning"
                 ],
-                "summary": "Get a user",
+                "summary": "List versions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Project ID (any version in the lineage)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2028,7 +2184,63 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.UserResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionSummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Save a full architecture state as a new immutable snapshot. Returns the version metadata including the new project_id that encodes this snapshot. When the project has a budget, the snapshot's monthly estimate is compared against it: the comparison is returned in budget, and a budget with action \"block\" refuses versions over it with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Create new version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (any version in the lineage)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Architecture state and optional message",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CreateVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
                         }
                     },
                     "400": {
@@ -2045,6 +2257,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2054,90 +2273,3733 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureEdge": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
+        },
+        "/projects/{id}/versions/latest": {
+            "get": {
+                "description": "Returns the latest version in the chain including full architecture state.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Get latest version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID (any version in the lineage)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}": {
+            "get": {
+                "description": "Returns a specific version entry including its full architecture snapshot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Get specific version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a version entry from the chain. The underlying project snapshot is preserved.",
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Delete version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/architecture": {
+            "get": {
+                "description": "Returns the full architecture state captured in a specific version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Get architecture for version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/compliance/{standard}": {
+            "get": {
+                "description": "Check a version's architecture against the controls of a compliance standard (cis-aws-foundations, soc-2 or hipaa). Each control passes, fails with the IDs of the offending resources and remediation text, or is not applicable when the design has no resource it checks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Evaluate compliance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Standard slug",
+                        "name": "standard",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/cost/forecast": {
+            "post": {
+                "description": "Project the monthly cost of a version's architecture over 12 to 36 months. Instance hours, stored data and traffic grow by their linear or percentage (compounded) monthly rate; while the account has free_tier_months left, 12 month AWS Free Tier allowances are credited. Returns one entry per month broken down by service.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Forecast cost over time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Horizon, growth assumptions, free tier months and purchase option",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostForecastRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostForecast"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/cost/purchase-options": {
+            "get": {
+                "description": "Calculate the monthly cost of a version's architecture On-Demand, with 1 and 3 year Reserved Instances and with 1 and 3 year Compute Savings Plans, for each payment option. Commitments apply to EC2 instances; upfront payments are amortized over the term.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Compare purchase options",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.PurchaseOptionComparison"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/diff/{other_version_id}": {
+            "get": {
+                "description": "Returns the resources added, removed and modified (per configuration field) between two versions, the containment and dependency edges added or removed, and the change in estimated monthly cost. Resources are matched across snapshots through the node IDs they were saved from, then by type and name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Diff versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version to compare from",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version to compare to",
+                        "name": "other_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.VersionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/drift": {
+            "post": {
+                "description": "Compare the architecture of a version with an uploaded terraform.tfstate (format version 4). Resources are matched by the Terraform addresses code generation writes. The report lists resources designed but not deployed, resources deployed but not designed, and per-attribute mismatches (e.g. instance_type, cidr_block, ingress rules) for the attributes the design sets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Detect drift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The state document",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DetectDriftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DriftResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/estimate-cost": {
+            "post": {
+                "description": "Calculate the estimated monthly cost for the architecture of a specific version. With purchase_option, EC2 instances are priced as Reserved Instances or under a Compute Savings Plan instead of On-Demand. When the project has a budget, the estimate is compared against it, returned in budget and recorded with the version's pricing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Estimate cost for a version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "on_demand (default), reserved_{1yr,3yr}_{no,partial,all}_upfront or savings_plan_{1yr,3yr}_{no,partial,all}_upfront",
+                        "name": "purchase_option",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ArchitectureCostEstimate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/export/terraform": {
+            "post": {
+                "description": "Generates IaC files for the architecture captured in a specific version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Generate IaC for version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Generation options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerateCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/merge": {
+            "post": {
+                "description": "Three-way merges the changes of theirs_version_id into the path version against their common ancestor (the nearest shared parent unless base_version_id is set). Resources are merged by ID per field, dependencies as edge sets, and variables and outputs by name. When fields changed differently on both sides, responds 409 with the conflicts; resend with a resolution per conflict ID to create the merged version as a child of the path version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Merge versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version to merge into",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Version to merge and conflict resolutions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeVersionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeVersionsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeVersionsResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/publish": {
+            "post": {
+                "description": "Publish a version of one of the caller's projects to the marketplace. The version's architecture is stored with the template, each resource becomes a component priced on-demand, and the estimated monthly cost ranges from the cheapest purchase option to on-demand. Category, technologies, compliance standards and IaC formats are slugs of existing entries. The template's parameters are its region, its number of availability zones and the version's input variables; parameters can be given a description and a list of accepted options, and sensitive variable values are not published.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Publish a version as a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Marketplace listing",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.PublishTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/reachability": {
+            "get": {
+                "description": "Work out, from security group rules, route tables, internet and NAT gateways and subnet placement, which resources of a version are reachable from 0.0.0.0/0 and on which ports, and which resources can open connections to each other. Findings flag exposures such as a database reachable from the internet or SSH open to the world. Network ACLs are not considered.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Analyze reachability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ReachabilityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/restore": {
+            "post": {
+                "description": "Creates a new head version whose architecture, variables, outputs and UI state are copied from the specified version. No history is lost: the new version's parent is the current head and its message records the restored version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Restore version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version to restore",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional message",
+                        "name": "body",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.RestoreVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/versions/{version_id}/validate": {
+            "post": {
+                "description": "Validates the architecture state captured in the specified version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versioning"
+                ],
+                "summary": "Validate version architecture",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/static/cloud-config": {
+            "get": {
+                "description": "Get global cloud configurations like regions, instance types, etc.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "static"
+                ],
+                "summary": "Get cloud configurations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name (e.g. aws)",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/static/providers": {
+            "get": {
+                "description": "Get a list of all supported cloud providers (AWS, GCP, etc.)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "static"
+                ],
+                "summary": "List supported cloud providers",
+                "responses": {
+                    "200": {
+                        "description": "List of providers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/static/resource-models": {
+            "get": {
+                "description": "Get the JSON structure of resource output models grouped by service category with default mock data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "static"
+                ],
+                "summary": "Get resource output models",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name (e.g. aws)",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/static/resource-types": {
+            "get": {
+                "description": "Get a list of all supported resource types, grouped by service category (e.g. Compute, Storage)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "static"
+                ],
+                "summary": "List resource types",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name (e.g. aws)",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "description": "List marketplace templates, filtered by text, category, technology, compliance standard, cloud provider and estimated monthly cost. A cost range keeps the templates whose estimated range overlaps it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Search templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text in the title or description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Technology slug",
+                        "name": "technology",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compliance standard slug",
+                        "name": "compliance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cloud provider (AWS, Azure, GCP or Multi-Cloud)",
+                        "name": "cloud_provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum monthly cost (USD)",
+                        "name": "min_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum monthly cost (USD)",
+                        "name": "max_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "popular (default), rating, downloads, newest or cost",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "default": 1
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query",
+                        "default": 20
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "description": "Get a marketplace template with its author, features, use cases, components and parameters. Components are the template's resources with their monthly on-demand cost; parameters are the inputs chosen when the template is instantiated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Get a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/compliance": {
+            "get": {
+                "description": "Check that a project version built from a marketplace template satisfies every compliance standard the template claims. A claim is verified when no control of the standard fails, failed otherwise, and unsupported when there is no built-in control set for the standard.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "compliance"
+                ],
+                "summary": "Verify template compliance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.TemplateComplianceVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/instantiate": {
+            "post": {
+                "description": "Create a new project for the caller from a template: the template's stored architecture becomes version 1, in the template's cloud provider. Parameter values are substituted into the architecture: variables take the chosen values and resource configuration referencing them (var.name) is resolved again, the region replaces the template's region and az_count repeats or removes subnets and their NAT gateways per availability zone, placing new subnets in free CIDR blocks of their VPC. The new project's monthly cost range is estimated with current prices, the template's download count is increased and the caller can then review the template.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Instantiate a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New project",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.InstantiateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.TemplateInstance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/reviews": {
+            "get": {
+                "description": "List the reviews of a marketplace template, verified deployments first and then newest first. Reviews hidden by moderation are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "List template reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "default": 1
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query",
+                        "default": 20
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Review a marketplace template as the caller. A user reviews a template once, and only after creating a project from it; the review is marked as a verified deployment once the user generated code from such a project. The template's rating and review count are recalculated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Review a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/reviews/{review_id}": {
+            "put": {
+                "description": "Edit one of the caller's template reviews. Omitted fields are left unchanged. The template's rating is recalculated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Edit a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete one of the caller's template reviews. The template's rating and review count are recalculated.",
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Delete a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/reviews/{review_id}/flag": {
+            "post": {
+                "description": "Flag another user's template review for moderation. Each user flags a review once; a review flagged by 3 users is hidden and no longer counts towards the template's rating.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Flag a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "flag",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.FlagReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Create a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User creation request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get the authenticated user by ID; other users' profiles are forbidden",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureEdge": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "traffic_gb_per_month": {
+                    "description": "data sent source → target, priced by cost estimates",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "\"contains\" or \"depends_on\" (or default for generic)",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNode": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNodeData"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "string"
+                },
+                "position": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodePosition"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "uiState": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodeUIState"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNodeData": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "isVisualOnly": {
+                    "type": "boolean"
+                },
                 "label": {
                     "type": "string"
                 },
-                "source": {
+                "resourceType": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureOutput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sensitive": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "description": "Expression",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse": {
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNode"
+                    }
+                },
+                "outputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureOutput"
+                    }
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureVariable"
+                    }
+                },
+                "version_id": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureVariable": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sensitive": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Current value or default"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerateCodeRequest": {
+            "type": "object",
+            "required": [
+                "tool"
+            ],
+            "properties": {
+                "options": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationOptions"
+                },
+                "tool": {
+                    "description": "terraform, pulumi, cdk",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GeneratedFileResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Included in immediate generation response, excluded in history",
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationOptions": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "description": "Backend overrides the project's saved state backend for this generation only.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.StateBackendConfig"
+                        }
+                    ]
+                },
+                "format": {
+                    "description": "hcl, json, yaml",
+                    "type": "string"
+                },
+                "includeOutputs": {
+                    "type": "boolean"
+                },
+                "includeVariables": {
+                    "type": "boolean"
+                },
+                "modularity": {
+                    "description": "low, medium, high (high writes each VPC as a local module)",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "downloadUrl": {
+                    "type": "string"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GeneratedFileResponse"
+                    }
+                },
+                "generationId": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "alias for generationId",
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tool": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.HTTPBackendConfig": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "lock_address": {
+                    "type": "string"
+                },
+                "lock_method": {
+                    "type": "string"
+                },
+                "unlock_address": {
+                    "type": "string"
+                },
+                "unlock_method": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.LocalBackendConfig": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string",
+                    "example": "terraform.tfstate"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodePosition": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodeUIState": {
+            "type": "object",
+            "properties": {
+                "dragging": {
+                    "type": "boolean"
+                },
+                "focusable": {
+                    "type": "boolean"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "measured": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "resizing": {
+                    "type": "boolean"
+                },
+                "selectable": {
+                    "type": "boolean"
+                },
+                "selected": {
+                    "type": "boolean"
+                },
+                "style": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "width": {
+                    "type": "number"
+                },
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                },
+                "zIndex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ProjectBackendResponse": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.StateBackendConfig"
+                },
+                "projectId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.S3BackendConfig": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "acme-terraform-state"
+                },
+                "dynamodb_table": {
+                    "type": "string",
+                    "example": "acme-terraform-locks"
+                },
+                "encrypt": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "network/terraform.tfstate"
+                },
+                "region": {
+                    "description": "defaults to the project region",
+                    "type": "string",
+                    "example": "us-east-1"
+                },
+                "workspace_key_prefix": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.StateBackendConfig": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "bootstrap": {
+                    "description": "Bootstrap also generates bootstrap/ with the state bucket and lock table (s3 only).",
+                    "type": "boolean"
+                },
+                "http": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.HTTPBackendConfig"
+                },
+                "local": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.LocalBackendConfig"
+                },
+                "s3": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.S3BackendConfig"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "s3",
+                        "local",
+                        "http"
+                    ],
+                    "example": "s3"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "nodeId": {
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "\"error\", \"warning\"",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "e.g., \"cost\", \"security\", \"structural\"",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateGuardrailRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "source"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMRoleRequest": {
+            "type": "object",
+            "required": [
+                "assume_role_policy",
+                "name"
+            ],
+            "properties": {
+                "assume_role_policy": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_virtual": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMUserRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "is_virtual": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateProjectRequest": {
+            "type": "object",
+            "required": [
+                "cloud_provider",
+                "iac_tool_id",
+                "name",
+                "region"
+            ],
+            "properties": {
+                "cloud_provider": {
+                    "type": "string",
+                    "enum": [
+                        "aws",
+                        "azure",
+                        "gcp"
+                    ]
+                },
+                "iac_tool_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "rating",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "deployment_time": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "team_size": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "use_case": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.FlagReviewRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.InstantiateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "iac_tool_id",
+                "name"
+            ],
+            "properties": {
+                "iac_tool_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "parameters": {
+                    "description": "Parameters holds the chosen parameter values by name; parameters left out take their default",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.PublishTemplateRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "description",
+                "title"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "compliance_standards": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deployment_time": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "iac_formats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "parameters": {
+                    "description": "Parameters describe or restrict the parameters derived from the version",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.TemplateParameterRequest"
+                    }
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "use_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.TemplateUseCaseRequest"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.TemplateParameterRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.TemplateUseCaseRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateGuardrailRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "cloud_provider": {
+                    "type": "string",
+                    "enum": [
+                        "aws",
+                        "azure",
+                        "gcp"
+                    ]
+                },
+                "iac_tool_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "deployment_time": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "team_size": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "use_case": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailListResponse": {
+            "type": "object",
+            "properties": {
+                "guardrails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailResponse"
+                    }
+                },
+                "instance": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.InstanceGuardrailResponse"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.GuardrailResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.InstanceGuardrailResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "cloud_provider": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "iac_tool_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateAuthorResponse"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creator_response": {
+                    "type": "string"
+                },
+                "deployment_time": {
+                    "type": "string"
+                },
+                "flag_count": {
+                    "type": "integer"
+                },
+                "helpful_count": {
+                    "type": "integer"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "team_size": {
+                    "type": "string"
+                },
+                "template_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "use_case": {
+                    "type": "string"
+                },
+                "verified_deployment": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.RuleDetail": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ServiceRuleResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.RuleDetail"
+                    }
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "valid_children": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valid_parents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateAuthorResponse": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateComponentResponse": {
+            "type": "object",
+            "properties": {
+                "configuration": {
+                    "type": "string"
+                },
+                "monthly_cost": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateDetailResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateAuthorResponse"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                },
+                "cloud_provider": {
+                    "type": "string"
+                },
+                "compliance_standards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                    }
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateComponentResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deployment_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "downloads": {
+                    "type": "integer"
+                },
+                "estimated_cost_max": {
+                    "type": "number"
+                },
+                "estimated_cost_min": {
+                    "type": "number"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "iac_formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "instantiable": {
+                    "type": "boolean"
+                },
+                "is_new": {
+                    "type": "boolean"
+                },
+                "is_popular": {
+                    "type": "boolean"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateParameterResponse"
+                    }
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "regions": {
+                    "type": "string"
+                },
+                "resources": {
+                    "type": "integer"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "source_version_id": {
+                    "type": "string"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "use_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateUseCaseResponse"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateSummaryResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateParameterResponse": {
+            "type": "object",
+            "properties": {
+                "default": {},
+                "description": {
+                    "type": "string"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "sensitive": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                },
+                "cloud_provider": {
+                    "type": "string"
+                },
+                "compliance_standards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deployment_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "downloads": {
+                    "type": "integer"
+                },
+                "estimated_cost_max": {
+                    "type": "number"
+                },
+                "estimated_cost_min": {
+                    "type": "number"
+                },
+                "iac_formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_new": {
+                    "type": "boolean"
+                },
+                "is_popular": {
+                    "type": "boolean"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "regions": {
+                    "type": "string"
+                },
+                "resources": {
+                    "type": "integer"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateTagResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TemplateUseCaseResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.UserResponse": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.PolicyOutput": {
+            "type": "object",
+            "properties": {
+                "arn": {
+                    "description": "AWS-generated identifiers",
+                    "type": "string"
+                },
+                "attachment_count": {
+                    "type": "integer"
+                },
+                "create_date": {
+                    "description": "AWS-specific output fields",
+                    "type": "string"
+                },
+                "default_version_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Same as ARN for policies",
+                    "type": "string"
+                },
+                "is_attachable": {
+                    "type": "boolean"
+                },
+                "is_aws_managed": {
+                    "description": "Policy type information",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Configuration (from input)",
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "policy_document": {
+                    "description": "JSON string",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag"
+                    }
+                },
+                "update_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.RoleOutput": {
+            "type": "object",
+            "properties": {
+                "arn": {
+                    "description": "AWS-generated identifiers",
+                    "type": "string"
+                },
+                "assume_role_policy": {
+                    "description": "JSON string",
+                    "type": "string"
+                },
+                "create_date": {
+                    "description": "AWS-specific output fields",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Configuration (from input)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Same as name for roles",
+                    "type": "string"
+                },
+                "is_virtual": {
+                    "type": "boolean"
+                },
+                "max_session_duration": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "permissions_boundary": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag"
+                    }
+                },
+                "unique_id": {
+                    "description": "Stable unique identifier",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.UserOutput": {
+            "type": "object",
+            "properties": {
+                "arn": {
+                    "description": "AWS-generated identifiers",
+                    "type": "string"
+                },
+                "create_date": {
+                    "description": "AWS-specific output fields",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Same as name for users",
+                    "type": "string"
+                },
+                "is_virtual": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password_last_used": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Configuration (from input)",
+                    "type": "string"
+                },
+                "permissions_boundary": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag"
+                    }
+                },
+                "unique_id": {
+                    "description": "Stable unique identifier",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_mapper.ImportIssue": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "e.g. \"aws_instance.web\" or \"aws_instance.web.ami\"",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.AttributeDrift": {
+            "type": "object",
+            "properties": {
+                "attribute": {
+                    "description": "e.g. \"instance_type\" or \"ingress\"",
+                    "type": "string"
+                },
+                "deployed": {},
+                "designed": {}
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.DriftResource": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "cloud ID, for deployed resources",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.ResourceDrift": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.AttributeDrift"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ArchitectureCostEstimate": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "description": "Budget is the comparison against the project budget, omitted when the project has none",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetStatus"
+                        }
+                    ]
+                },
+                "currency": {
+                    "description": "Currency is the currency used",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration is the duration used for the calculation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "period": {
+                    "description": "Period is the time period for the estimate",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Provider is the cloud provider",
+                    "type": "string"
+                },
+                "purchase_option": {
+                    "description": "PurchaseOption is how compute is paid for in this estimate",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "Region is the region (if applicable)",
+                    "type": "string"
+                },
+                "resource_estimates": {
+                    "description": "ResourceEstimates contains individual resource cost estimates",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceCostEstimate"
+                    }
+                },
+                "total_cost": {
+                    "description": "TotalCost is the total estimated cost for the architecture",
+                    "type": "number"
+                },
+                "warnings": {
+                    "description": "Warnings lists resources left out of the estimate because their region has no pricing data",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceControlResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "remediation": {
+                    "type": "string"
+                },
+                "resource_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severity": {
+                    "type": "string",
+                    "description": "critical, high, medium or low"
+                },
+                "status": {
+                    "type": "string",
+                    "description": "pass, fail or not_applicable"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceReport": {
+            "type": "object",
+            "properties": {
+                "controls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceControlResult"
+                    }
+                },
+                "fail_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "not_applicable_count": {
+                    "type": "integer"
+                },
+                "pass_count": {
+                    "type": "integer"
+                },
+                "passed": {
+                    "type": "boolean",
+                    "description": "no control failed"
+                },
+                "standard": {
+                    "type": "string"
+                },
+                "version_id": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceStandardInfo": {
+            "type": "object",
+            "properties": {
+                "control_count": {
+                    "type": "integer"
                 },
-                "target": {
+                "description": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "\"contains\" or \"depends_on\" (or default for generic)",
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNode": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostBreakdownComponent": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNodeData"
+                "component_name": {
+                    "description": "ComponentName is the name of the cost component",
+                    "type": "string"
                 },
-                "id": {
+                "currency": {
+                    "description": "Currency is the currency used",
                     "type": "string"
                 },
-                "parentId": {
+                "model": {
+                    "description": "Model is the pricing model used",
                     "type": "string"
                 },
-                "position": {
-                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodePosition"
+                "quantity": {
+                    "description": "Quantity is the amount consumed",
+                    "type": "number"
                 },
-                "type": {
-                    "type": "string"
+                "subtotal": {
+                    "description": "Subtotal is the calculated cost for this component",
+                    "type": "number"
                 },
-                "uiState": {
-                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodeUIState"
+                "unit_rate": {
+                    "description": "UnitRate is the rate per unit",
+                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNodeData": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostDelta": {
             "type": "object",
             "properties": {
-                "config": {
-                    "type": "object",
-                    "additionalProperties": true
+                "currency": {
+                    "type": "string"
                 },
-                "isVisualOnly": {
-                    "type": "boolean"
+                "delta": {
+                    "type": "number"
                 },
-                "label": {
-                    "type": "string"
+                "from": {
+                    "type": "number"
                 },
-                "resourceType": {
+                "period": {
                     "type": "string"
+                },
+                "to": {
+                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureOutput": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostForecast": {
             "type": "object",
             "properties": {
-                "description": {
+                "currency": {
+                    "description": "Currency is the currency used",
                     "type": "string"
                 },
-                "name": {
+                "free_tier_savings": {
+                    "description": "FreeTierSavings is the part of the cost covered by free tier allowances over the horizon",
+                    "type": "number"
+                },
+                "months": {
+                    "description": "Months is the forecast horizon",
+                    "type": "integer"
+                },
+                "purchase_option": {
+                    "description": "PurchaseOption is how compute is paid for in this forecast",
                     "type": "string"
                 },
-                "sensitive": {
-                    "type": "boolean"
+                "series": {
+                    "description": "Series contains one entry per month, in order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ForecastMonth"
+                    }
                 },
-                "value": {
-                    "description": "Expression",
+                "services": {
+                    "description": "Services lists the services (resource types) of the series, sorted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_cost": {
+                    "description": "TotalCost is the cost over the whole horizon",
+                    "type": "number"
+                },
+                "warnings": {
+                    "description": "Warnings lists resources left out of the forecast because their region has no pricing data",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostForecastRequest": {
+            "type": "object",
+            "properties": {
+                "free_tier_months": {
+                    "description": "FreeTierMonths is how many months of the 12 month AWS Free Tier the account has left (0 to 12)",
+                    "type": "integer"
+                },
+                "growth": {
+                    "description": "Growth holds the monthly growth of instances, storage and traffic",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.GrowthAssumptions"
+                        }
+                    ]
+                },
+                "months": {
+                    "description": "Months is the forecast horizon, 12 to 36 (default 12)",
+                    "type": "integer"
+                },
+                "purchase_option": {
+                    "description": "PurchaseOption is how compute is paid for (default on_demand)",
+                    "type": "string"
+                },
+                "start_month": {
+                    "description": "StartMonth is the first month of the forecast as YYYY-MM (default the current month)",
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CreateVersionRequest": {
             "type": "object",
             "properties": {
                 "edges": {
@@ -2146,6 +6008,9 @@ const docTemplate = `{
                         "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureEdge"
                     }
                 },
+                "message": {
+                    "type": "string"
+                },
                 "nodes": {
                     "type": "array",
                     "items": {
@@ -2163,872 +6028,1078 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureVariable"
                     }
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DetectDriftRequest": {
+            "type": "object",
+            "required": [
+                "state"
+            ],
+            "properties": {
+                "state": {
+                    "type": "object"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DiffEdge": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "type": "string"
+                },
+                "source_name": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "target_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DiffResource": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DriftResult": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.ResourceDrift"
+                    }
                 },
-                "version_id": {
+                "in_sync": {
+                    "description": "resources present in both with no attribute drift",
+                    "type": "integer"
+                },
+                "issues": {
+                    "description": "state entries that were not compared",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_mapper.ImportIssue"
+                    }
+                },
+                "lineage": {
+                    "description": "state lineage and serial identify the snapshot compared",
                     "type": "string"
                 },
-                "warnings": {
+                "not_deployed": {
+                    "description": "designed but absent from the state",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.DriftResource"
+                    }
+                },
+                "not_designed": {
+                    "description": "recorded in the state but not designed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_state.DriftResource"
                     }
+                },
+                "serial": {
+                    "type": "integer"
+                },
+                "version_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureVariable": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.FieldDescriptorDTO": {
             "type": "object",
             "properties": {
+                "default": {},
                 "description": {
                     "type": "string"
                 },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
-                "sensitive": {
+                "required": {
                     "type": "boolean"
                 },
                 "type": {
                     "type": "string"
-                },
-                "value": {
-                    "description": "Current value or default"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerateCodeRequest": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ForecastMonth": {
             "type": "object",
-            "required": [
-                "tool"
-            ],
             "properties": {
-                "options": {
-                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationOptions"
+                "free_tier_credit": {
+                    "description": "FreeTierCredit is the cost covered by free tier allowances this month",
+                    "type": "number"
                 },
-                "tool": {
-                    "description": "terraform, pulumi, cdk",
+                "month": {
+                    "description": "Month is the month as YYYY-MM",
                     "type": "string"
+                },
+                "services": {
+                    "description": "Services holds the cost of the month by service (resource type)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "total_cost": {
+                    "description": "TotalCost is the cost of the month, after free tier credits",
+                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GeneratedFileResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ImportResult": {
             "type": "object",
             "properties": {
-                "content": {
-                    "description": "Included in immediate generation response, excluded in history",
-                    "type": "string"
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_mapper.ImportIssue"
+                    }
                 },
-                "language": {
+                "region": {
                     "type": "string"
                 },
+                "version": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ImportStateRequest": {
+            "type": "object",
+            "required": [
+                "iac_tool_id",
+                "name",
+                "state"
+            ],
+            "properties": {
+                "iac_tool_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
                 "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "region": {
+                    "description": "defaults to the region recorded in the state",
                     "type": "string"
                 },
-                "size": {
-                    "type": "integer"
+                "state": {
+                    "type": "object"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationOptions": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ImportTerraformRequest": {
             "type": "object",
+            "required": [
+                "files"
+            ],
             "properties": {
-                "format": {
-                    "description": "hcl, json, yaml",
-                    "type": "string"
-                },
-                "includeOutputs": {
-                    "type": "boolean"
-                },
-                "includeVariables": {
-                    "type": "boolean"
+                "files": {
+                    "description": "file name -\u003e HCL source",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "modularity": {
-                    "description": "low, medium, high",
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GenerationResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeConflict": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "downloadUrl": {
-                    "type": "string"
-                },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "expiresAt": {
-                    "type": "string"
-                },
-                "files": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.GeneratedFileResponse"
-                    }
-                },
-                "generationId": {
+                "base": {},
+                "field": {
                     "type": "string"
                 },
                 "id": {
-                    "description": "alias for generationId",
                     "type": "string"
                 },
-                "projectId": {
+                "key": {
                     "type": "string"
                 },
-                "status": {
+                "kind": {
+                    "description": "\"resource\", \"variable\" or \"output\"",
                     "type": "string"
                 },
-                "tool": {
+                "name": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodePosition": {
-            "type": "object",
-            "properties": {
-                "x": {
-                    "type": "number"
                 },
-                "y": {
-                    "type": "number"
-                }
+                "ours": {},
+                "theirs": {}
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.NodeUIState": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeResolution": {
             "type": "object",
+            "required": [
+                "conflict_id"
+            ],
             "properties": {
-                "dragging": {
-                    "type": "boolean"
-                },
-                "focusable": {
-                    "type": "boolean"
-                },
-                "height": {
-                    "type": "number"
-                },
-                "measured": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "resizing": {
-                    "type": "boolean"
-                },
-                "selectable": {
-                    "type": "boolean"
-                },
-                "selected": {
-                    "type": "boolean"
-                },
-                "style": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "width": {
-                    "type": "number"
-                },
-                "x": {
-                    "type": "number"
+                "conflict_id": {
+                    "type": "string"
                 },
-                "y": {
-                    "type": "number"
+                "take": {
+                    "type": "string"
                 },
-                "zIndex": {
-                    "type": "integer"
-                }
+                "value": {}
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeVersionsRequest": {
             "type": "object",
+            "required": [
+                "theirs_version_id"
+            ],
             "properties": {
-                "message": {
+                "base_version_id": {
+                    "description": "BaseVersionID is the common ancestor; defaults to the nearest shared parent.",
                     "type": "string"
                 },
-                "nodeId": {
+                "message": {
                     "type": "string"
                 },
-                "severity": {
-                    "description": "\"error\", \"warning\"",
-                    "type": "string"
+                "resolutions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeResolution"
+                    }
                 },
-                "type": {
-                    "description": "e.g., \"cost\", \"security\", \"structural\"",
+                "theirs_version_id": {
+                    "description": "TheirsVersionID is the version whose changes are merged into the path version.",
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeVersionsResult": {
             "type": "object",
             "properties": {
-                "errors": {
+                "base_version_id": {
+                    "type": "string"
+                },
+                "conflicts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.MergeConflict"
                     }
                 },
-                "valid": {
-                    "type": "boolean"
+                "ours_version_id": {
+                    "type": "string"
                 },
-                "warnings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ValidationIssue"
-                    }
+                "theirs_version_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMRoleRequest": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ModifiedResource": {
             "type": "object",
-            "required": [
-                "assume_role_policy",
-                "name"
-            ],
             "properties": {
-                "assume_role_policy": {
-                    "type": "string"
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.FieldChange"
+                    }
                 },
-                "description": {
+                "from_id": {
                     "type": "string"
                 },
-                "is_virtual": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
                 },
-                "path": {
+                "to_id": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateIAMUserRequest": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationSuggestion": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "is_virtual": {
-                    "type": "boolean"
+                "current_monthly_cost": {
+                    "type": "number"
                 },
-                "name": {
+                "description": {
                     "type": "string"
                 },
-                "path": {
+                "estimated_savings": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateProjectRequest": {
-            "type": "object",
-            "required": [
-                "cloud_provider",
-                "iac_tool_id",
-                "name",
-                "region"
-            ],
-            "properties": {
-                "cloud_provider": {
-                    "type": "string",
-                    "enum": [
-                        "aws",
-                        "azure",
-                        "gcp"
-                    ]
                 },
-                "iac_tool_id": {
-                    "type": "integer",
-                    "minimum": 1
+                "optimized_monthly_cost": {
+                    "type": "number"
                 },
-                "name": {
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "type": "string"
+                },
+                "rule": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "description": "e.g. \"rightsizing\", \"graviton\", \"ebs_gp3\""
                 },
-                "region": {
+                "severity": {
+                    "description": "\"high\", \"medium\", \"low\"",
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "Temporary for testing without auth",
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.CreateUserRequest": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationWithSavings": {
             "type": "object",
-            "required": [
-                "auth0_id",
-                "email",
-                "name"
-            ],
             "properties": {
-                "auth0_id": {
+                "currency": {
                     "type": "string"
                 },
-                "avatar_url": {
-                    "type": "string"
+                "period": {
+                    "type": "string",
+                    "description": "savings are \"monthly\""
                 },
-                "email": {
-                    "type": "string"
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationSuggestion"
+                    }
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "total_potential_savings": {
+                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_request.UpdateProjectRequest": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail": {
             "type": "object",
             "properties": {
-                "cloud_provider": {
-                    "type": "string",
-                    "enum": [
-                        "aws",
-                        "azure",
-                        "gcp"
+                "budget": {
+                    "description": "set on creation when the project has a budget",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetStatus"
+                        }
                     ]
                 },
-                "iac_tool_id": {
-                    "type": "integer",
-                    "minimum": 1
+                "created_at": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                "created_by": {
+                    "type": "string"
                 },
-                "region": {
+                "id": {
+                    "type": "string"
+                },
+                "merge_parent_version_id": {
+                    "description": "merge versions: the version merged in",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "parent_version_id": {
                     "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse"
+                },
+                "version_number": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ProjectResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionSummary": {
             "type": "object",
             "properties": {
-                "cloud_provider": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "iac_tool_id": {
-                    "type": "integer"
+                "created_by": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "merge_parent_version_id": {
+                    "description": "merge versions: the version merged in",
                     "type": "string"
                 },
-                "region": {
+                "message": {
                     "type": "string"
                 },
-                "updated_at": {
+                "parent_version_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "project_id": {
                     "type": "string"
+                },
+                "version_number": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.RuleDetail": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.PurchaseOptionComparison": {
             "type": "object",
             "properties": {
-                "description": {
+                "currency": {
+                    "description": "Currency is the currency used",
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "on_demand_cost": {
+                    "description": "OnDemandCost is the total cost with everything paid on-demand",
+                    "type": "number"
                 },
-                "value": {
+                "period": {
+                    "description": "Period is the time period of the costs",
                     "type": "string"
+                },
+                "scenarios": {
+                    "description": "Scenarios contains one entry per purchase option, on-demand first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.PurchaseOptionScenario"
+                    }
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.ServiceRuleResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.PurchaseOptionScenario": {
             "type": "object",
             "properties": {
-                "description": {
+                "compute_cost": {
+                    "description": "ComputeCost is the part of TotalCost spent on EC2 instances",
+                    "type": "number"
+                },
+                "model": {
+                    "description": "Model is the pricing model of the compute rates (per_hour, reserved or savings_plan)",
                     "type": "string"
                 },
-                "rules": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.RuleDetail"
-                    }
+                "name": {
+                    "description": "Name is a human readable name (e.g., \"Reserved 1yr All Upfront\")",
+                    "type": "string"
                 },
-                "service_name": {
+                "payment_option": {
+                    "description": "PaymentOption is no_upfront, partial_upfront or all_upfront, empty for on-demand",
                     "type": "string"
                 },
-                "valid_children": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "purchase_option": {
+                    "description": "PurchaseOption identifies the option (e.g., \"reserved_1yr_all_upfront\")",
+                    "type": "string"
                 },
-                "valid_parents": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "savings": {
+                    "description": "Savings is the difference with the on-demand cost",
+                    "type": "number"
+                },
+                "savings_percent": {
+                    "description": "SavingsPercent is Savings as a percentage of the on-demand cost",
+                    "type": "number"
+                },
+                "term": {
+                    "description": "Term is the commitment term (\"1yr\" or \"3yr\"), empty for on-demand",
+                    "type": "string"
+                },
+                "total_cost": {
+                    "description": "TotalCost is the total cost of the architecture, with upfront payments amortized over the term",
+                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto_response.UserResponse": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ReachabilityFinding": {
             "type": "object",
             "properties": {
-                "avatar_url": {
+                "code": {
                     "type": "string"
                 },
-                "created_at": {
+                "message": {
                     "type": "string"
                 },
-                "email": {
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "resource_id": {
                     "type": "string"
                 },
-                "id": {
+                "resource_name": {
                     "type": "string"
                 },
-                "name": {
+                "resource_type": {
                     "type": "string"
                 },
-                "updated_at": {
+                "severity": {
+                    "description": "critical, high, medium or low",
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ReachabilityReport": {
             "type": "object",
             "properties": {
-                "key": {
-                    "type": "string"
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ReachabilityFinding"
+                    }
                 },
-                "value": {
+                "matrix": {
+                    "description": "Matrix maps a source resource ID, or \"internet\", to the target resource IDs it can\nopen connections to and the ports it can reach them on (\"tcp/443\", \"tcp/8000-8080\",\n\"all\").",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ReachabilityResource"
+                    }
+                },
+                "version_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.PolicyOutput": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ReachabilityResource": {
             "type": "object",
             "properties": {
-                "arn": {
-                    "description": "AWS-generated identifiers",
+                "id": {
                     "type": "string"
                 },
-                "attachment_count": {
-                    "type": "integer"
+                "internet_egress": {
+                    "description": "can reach the internet, directly or through a NAT gateway",
+                    "type": "boolean"
                 },
-                "create_date": {
-                    "description": "AWS-specific output fields",
+                "name": {
                     "type": "string"
                 },
-                "default_version_id": {
-                    "type": "string"
+                "public": {
+                    "description": "reachable from the internet when its security groups allow it",
+                    "type": "boolean"
                 },
-                "description": {
-                    "type": "string"
+                "security_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
-                    "description": "Same as ARN for policies",
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceCostEstimate": {
+            "type": "object",
+            "properties": {
+                "breakdown": {
+                    "description": "Breakdown contains the cost breakdown components",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostBreakdownComponent"
+                    }
                 },
-                "is_attachable": {
-                    "type": "boolean"
+                "category": {
+                    "description": "Category is the resource category, e.g. \"Compute\" (empty when unknown)",
+                    "type": "string"
                 },
-                "is_aws_managed": {
-                    "description": "Policy type information",
-                    "type": "boolean"
+                "currency": {
+                    "description": "Currency is the currency used",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "Configuration (from input)",
+                "resource_id": {
+                    "description": "ResourceID is the domain resource ID",
                     "type": "string"
                 },
-                "path": {
+                "resource_name": {
+                    "description": "ResourceName is the name of the resource",
                     "type": "string"
                 },
-                "policy_document": {
-                    "description": "JSON string",
+                "resource_type": {
+                    "description": "ResourceType is the type of resource",
                     "type": "string"
                 },
-                "tags": {
+                "total_cost": {
+                    "description": "TotalCost is the total estimated cost for this resource",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceSchemaDTO": {
+            "type": "object",
+            "properties": {
+                "fields": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.FieldDescriptorDTO"
                     }
                 },
-                "update_date": {
+                "label": {
                     "type": "string"
+                },
+                "outputs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.RoleOutput": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.RestoreVersionRequest": {
             "type": "object",
             "properties": {
-                "arn": {
-                    "description": "AWS-generated identifiers",
-                    "type": "string"
-                },
-                "assume_role_policy": {
-                    "description": "JSON string",
-                    "type": "string"
-                },
-                "create_date": {
-                    "description": "AWS-specific output fields",
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.StateImportResult": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_iac_terraform_mapper.ImportIssue"
+                    }
                 },
-                "description": {
-                    "description": "Configuration (from input)",
+                "project_id": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Same as name for roles",
+                "region": {
                     "type": "string"
                 },
-                "is_virtual": {
-                    "type": "boolean"
-                },
-                "max_session_duration": {
-                    "type": "integer"
-                },
+                "version": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.TemplateComplianceClaim": {
+            "type": "object",
+            "properties": {
                 "name": {
                     "type": "string"
                 },
-                "path": {
-                    "type": "string"
+                "report": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ComplianceReport"
                 },
-                "permissions_boundary": {
+                "standard": {
                     "type": "string"
                 },
-                "tags": {
+                "status": {
+                    "type": "string",
+                    "description": "verified, failed or unsupported"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.TemplateComplianceVerification": {
+            "type": "object",
+            "properties": {
+                "claims": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.TemplateComplianceClaim"
                     }
                 },
-                "unique_id": {
-                    "description": "Stable unique identifier",
+                "template_id": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean",
+                    "description": "every claim is verified"
+                },
+                "version_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_models_iam_outputs.UserOutput": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.TemplateInstance": {
             "type": "object",
             "properties": {
-                "arn": {
-                    "description": "AWS-generated identifiers",
-                    "type": "string"
+                "estimated_cost_max": {
+                    "type": "number"
                 },
-                "create_date": {
-                    "description": "AWS-specific output fields",
+                "estimated_cost_min": {
+                    "type": "number"
+                },
+                "parameters": {
+                    "description": "Parameters holds the parameter values the project was created with; sensitive values are left out",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "project_id": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Same as name for users",
+                "template_id": {
                     "type": "string"
                 },
-                "is_virtual": {
-                    "type": "boolean"
+                "version": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail"
+                }
+            }
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.VersionDiff": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DiffResource"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "added_edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DiffEdge"
+                    }
+                },
+                "cost": {
+                    "description": "omitted when pricing is unavailable",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostDelta"
+                        }
+                    ]
                 },
-                "password_last_used": {
+                "from_version_id": {
                     "type": "string"
                 },
-                "path": {
-                    "description": "Configuration (from input)",
-                    "type": "string"
+                "modified": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ModifiedResource"
+                    }
                 },
-                "permissions_boundary": {
-                    "type": "string"
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DiffResource"
+                    }
                 },
-                "tags": {
+                "removed_edges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_cloud_aws_configs.Tag"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.DiffEdge"
                     }
                 },
-                "unique_id": {
-                    "description": "Stable unique identifier",
+                "to_version_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ArchitectureCostEstimate": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Budget": {
             "type": "object",
             "properties": {
-                "currency": {
-                    "description": "Currency is the currency used",
-                    "type": "string"
-                },
-                "duration": {
-                    "description": "Duration is the duration used for the calculation",
+                "action": {
+                    "description": "Action is flag (default) or block",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/time.Duration"
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetAction"
                         }
                     ]
                 },
-                "period": {
-                    "description": "Period is the time period for the estimate",
-                    "type": "string"
-                },
-                "provider": {
-                    "description": "Provider is the cloud provider",
-                    "type": "string"
-                },
-                "region": {
-                    "description": "Region is the region (if applicable)",
-                    "type": "string"
-                },
-                "resource_estimates": {
-                    "description": "ResourceEstimates contains individual resource cost estimates",
+                "category_limits": {
+                    "description": "CategoryLimits holds limits by resource category, e.g. \"compute\" or \"networking\"",
                     "type": "object",
                     "additionalProperties": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceCostEstimate"
+                        "type": "number",
+                        "format": "float64"
                     }
                 },
-                "total_cost": {
-                    "description": "TotalCost is the total estimated cost for the architecture",
+                "monthly_limit": {
+                    "description": "MonthlyLimit is the limit of the whole architecture",
                     "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostBreakdownComponent": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetAction": {
+            "type": "string",
+            "enum": [
+                "flag",
+                "block"
+            ],
+            "x-enum-comments": {
+                "BudgetBlock": "refuses to save the version",
+                "BudgetFlag": "saves the version and reports the overages"
+            },
+            "x-enum-varnames": [
+                "BudgetFlag",
+                "BudgetBlock"
+            ]
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetOverage": {
             "type": "object",
             "properties": {
-                "component_name": {
-                    "description": "ComponentName is the name of the cost component",
-                    "type": "string"
-                },
-                "currency": {
-                    "description": "Currency is the currency used",
-                    "type": "string"
-                },
-                "model": {
-                    "description": "Model is the pricing model used",
+                "category": {
+                    "description": "Category is a resource category, or \"total\" for the monthly limit",
                     "type": "string"
                 },
-                "quantity": {
-                    "description": "Quantity is the amount consumed",
+                "cost": {
                     "type": "number"
                 },
-                "subtotal": {
-                    "description": "Subtotal is the calculated cost for this component",
+                "limit": {
                     "type": "number"
                 },
-                "unit_rate": {
-                    "description": "UnitRate is the rate per unit",
+                "overage": {
                     "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CreateVersionRequest": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetStatus": {
             "type": "object",
             "properties": {
-                "edges": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureEdge"
-                    }
+                "action": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetAction"
                 },
-                "message": {
-                    "type": "string"
+                "blocked": {
+                    "description": "Blocked is true when the estimate is over a budget that blocks versions, or leaves resources unpriced under one",
+                    "type": "boolean"
                 },
-                "nodes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureNode"
+                "category_costs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
                     }
                 },
-                "outputs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureOutput"
-                    }
+                "monthly_cost": {
+                    "type": "number"
                 },
-                "variables": {
+                "monthly_limit": {
+                    "type": "number"
+                },
+                "overages": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureVariable"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.BudgetOverage"
                     }
-                }
-            }
-        },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.FieldDescriptorDTO": {
-            "type": "object",
-            "properties": {
-                "default": {},
-                "description": {
+                },
+                "status": {
+                    "description": "Status is within_budget or over_budget",
                     "type": "string"
                 },
-                "enum": {
+                "unpriced": {
+                    "description": "Unpriced lists resources left out of the estimate because their region has no pricing data",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                },
-                "name": {
-                    "type": "string"
-                },
-                "required": {
-                    "type": "boolean"
-                },
-                "type": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationSuggestion": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.EdgeTraffic": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "estimated_savings": {
+                "gb_per_month": {
+                    "description": "GBPerMonth is the data sent each month",
                     "type": "number"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "resource_id": {
-                    "type": "string"
-                },
-                "resource_type": {
+                "source": {
+                    "description": "Source is the name of the sending resource",
                     "type": "string"
                 },
-                "severity": {
-                    "description": "\"high\", \"medium\", \"low\"",
+                "target": {
+                    "description": "Target is the name of the receiving resource, empty for the internet",
                     "type": "string"
                 },
-                "title": {
+                "transfer_type": {
+                    "description": "TransferType is internet, inter_az or same_az. When empty it is internet without a target, otherwise inferred from the availability zones of the resources.",
                     "type": "string"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationWithSavings": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Growth": {
             "type": "object",
             "properties": {
-                "currency": {
-                    "type": "string"
-                },
-                "suggestions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.OptimizationSuggestion"
-                    }
+                "model": {
+                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.GrowthModel"
                 },
-                "total_potential_savings": {
+                "rate": {
                     "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionDetail": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.GrowthAssumptions": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "parent_version_id": {
-                    "type": "string"
-                },
-                "project_id": {
-                    "type": "string"
+                "instances": {
+                    "description": "Instances grows the hours of instances: EC2, Auto Scaling groups, containers and databases",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Growth"
+                        }
+                    ]
                 },
-                "state": {
-                    "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_api_dto.ArchitectureResponse"
+                "storage": {
+                    "description": "Storage grows stored data: volumes, buckets and database storage",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Growth"
+                        }
+                    ]
                 },
-                "version_number": {
-                    "type": "integer"
+                "traffic": {
+                    "description": "Traffic grows requests, data transfer, data processing and load balancer capacity units",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.Growth"
+                        }
+                    ]
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ProjectVersionSummary": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.GrowthModel": {
+            "type": "string",
+            "enum": [
+                "linear",
+                "percentage"
+            ],
+            "x-enum-comments": {
+                "LinearGrowth": "adds Rate percent of the first month's amount every month",
+                "PercentageGrowth": "grows the amount by Rate percent every month, compounded"
+            },
+            "x-enum-varnames": [
+                "LinearGrowth",
+                "PercentageGrowth"
+            ]
+        },
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.ResourceUsage": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "average_capacity": {
+                    "description": "AverageCapacity is the average number of instances of an Auto Scaling group",
+                    "type": "number"
                 },
-                "created_by": {
-                    "type": "string"
+                "average_duration_ms": {
+                    "description": "AverageDurationMs is the average Lambda invocation duration",
+                    "type": "number"
                 },
-                "id": {
-                    "type": "string"
+                "data_processed_gb": {
+                    "description": "DataProcessedGB is the data processed by a NAT gateway or VPC endpoint",
+                    "type": "number"
                 },
-                "message": {
-                    "type": "string"
+                "data_transfer_out_gb": {
+                    "description": "DataTransferOutGB is the data sent to the internet by an S3 bucket or Lambda function",
+                    "type": "number"
                 },
-                "parent_version_id": {
-                    "type": "string"
+                "get_requests_per_month": {
+                    "type": "number"
                 },
-                "project_id": {
-                    "type": "string"
+                "lcus": {
+                    "description": "LCUs is the average number of load balancer capacity units used per hour",
+                    "type": "number"
                 },
-                "version_number": {
-                    "type": "integer"
+                "put_requests_per_month": {
+                    "description": "PutRequestsPerMonth and GetRequestsPerMonth are S3 request counts",
+                    "type": "number"
+                },
+                "read_requests_per_month": {
+                    "description": "ReadRequestsPerMonth and WriteRequestsPerMonth are the request units of an on-demand DynamoDB table",
+                    "type": "number"
+                },
+                "requests_per_month": {
+                    "description": "RequestsPerMonth is the number of Lambda invocations",
+                    "type": "number"
+                },
+                "schedule": {
+                    "description": "Schedule is the resource's uptime, overriding the profile schedule",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UptimeSchedule"
+                        }
+                    ]
+                },
+                "storage_gb": {
+                    "description": "StorageGB is the average amount of data stored in an S3 bucket or DynamoDB table",
+                    "type": "number"
+                },
+                "write_requests_per_month": {
+                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceCostEstimate": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UptimeSchedule": {
             "type": "object",
             "properties": {
-                "breakdown": {
-                    "description": "Breakdown contains the cost breakdown components",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.CostBreakdownComponent"
-                    }
-                },
-                "currency": {
-                    "description": "Currency is the currency used",
-                    "type": "string"
-                },
-                "resource_id": {
-                    "description": "ResourceID is the domain resource ID",
-                    "type": "string"
-                },
-                "resource_name": {
-                    "description": "ResourceName is the name of the resource",
-                    "type": "string"
+                "hours_per_week": {
+                    "type": "number"
                 },
-                "resource_type": {
-                    "description": "ResourceType is the type of resource",
+                "preset": {
                     "type": "string"
-                },
-                "total_cost": {
-                    "description": "TotalCost is the total estimated cost for this resource",
-                    "type": "number"
                 }
             }
         },
-        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.ResourceSchemaDTO": {
+        "github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UsageProfile": {
             "type": "object",
             "properties": {
-                "fields": {
+                "edges": {
+                    "description": "Edges is the traffic between resources, or from a resource to the internet",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_platform_server_interfaces.FieldDescriptorDTO"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.EdgeTraffic"
                     }
                 },
-                "label": {
-                    "type": "string"
-                },
-                "outputs": {
+                "resources": {
+                    "description": "Resources holds the usage of resources by resource name",
                     "type": "object",
                     "additionalProperties": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.ResourceUsage"
                     }
+                },
+                "schedule": {
+                    "description": "Schedule is the uptime of every hourly billed resource without its own schedule",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_mo7amedgom3a_arch-visualizer_backend_internal_pricing.UptimeSchedule"
+                        }
+                    ]
                 }
             }
         },
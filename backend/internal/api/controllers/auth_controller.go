@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/request"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/response"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// AuthController handles authentication requests
+type AuthController struct {
+	userService   serverinterfaces.UserService
+	authenticator *auth.Authenticator
+}
+
+// NewAuthController creates a new AuthController
+func NewAuthController(userService serverinterfaces.UserService, authenticator *auth.Authenticator) *AuthController {
+	return &AuthController{
+		userService:   userService,
+		authenticator: authenticator,
+	}
+}
+
+// Login exchanges an email and password for an access token
+// @Summary      Log in
+// @Description  Exchange an email and password for a bearer token. Send it as "Authorization: Bearer <token>" on protected routes.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      request.LoginRequest  true  "User credentials"
+// @Success      200          {object}  response.TokenResponse
+// @Failure      400          {object}  map[string]interface{}
+// @Failure      401          {object}  map[string]interface{}
+// @Failure      500          {object}  map[string]interface{}
+// @Router       /auth/login [post]
+func (ctrl *AuthController) Login(c *gin.Context) {
+	var req request.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !ctrl.authenticator.CanIssue() {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Token issuing is not configured"})
+		return
+	}
+
+	user, err := ctrl.userService.Authenticate(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		if apperrors.IsKind(err, apperrors.KindUnauthorized) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in: " + err.Error()})
+		return
+	}
+
+	token, _, err := ctrl.authenticator.Issue(user.ID, user.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ctrl.authenticator.TTL().Seconds()),
+	})
+}
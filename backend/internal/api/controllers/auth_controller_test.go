@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/response"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/config"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthController_Login(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{JWTSecret: "test-secret", TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	user := &models.User{ID: uuid.New(), Name: "Ada", Email: "ada@example.com"}
+	svc := &mockUserService{
+		users:     map[uuid.UUID]*models.User{user.ID: user},
+		passwords: map[uuid.UUID]string{user.ID: "correct-horse"},
+	}
+	r := gin.Default()
+	r.POST("/auth/login", NewAuthController(svc, authenticator).Login)
+
+	login := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBufferString(body))
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, login(`{"email": "ada@example.com"}`).Code, "a password is required")
+	for _, body := range []string{
+		`{"email": "ada@example.com", "password": "wrong-horse"}`,
+		`{"email": "bob@example.com", "password": "correct-horse"}`,
+	} {
+		w := login(body)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, body)
+		assert.Contains(t, w.Body.String(), "Invalid email or password")
+	}
+
+	w := login(`{"email": "ada@example.com", "password": "correct-horse"}`)
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+	var token response.TokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &token); err != nil {
+		t.Fatalf("decode token response: %v", err)
+	}
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, int64(3600), token.ExpiresIn)
+	claims, err := authenticator.Verify(token.AccessToken)
+	if err != nil {
+		t.Fatalf("Verify issued token: %v", err)
+	}
+	userID, _ := claims.UserID()
+	assert.Equal(t, user.ID, userID)
+}
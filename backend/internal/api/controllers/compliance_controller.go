@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// ComplianceController handles compliance checks of designs and templates
+type ComplianceController struct {
+	complianceService serverinterfaces.ComplianceService
+}
+
+// NewComplianceController creates a new compliance controller
+func NewComplianceController(complianceService serverinterfaces.ComplianceService) *ComplianceController {
+	return &ComplianceController{complianceService: complianceService}
+}
+
+// ListStandards lists the compliance standards that can be evaluated
+// @Summary      List compliance standards
+// @Description  List the built-in compliance control sets. Slugs match the compliance standards of marketplace templates.
+// @Tags         compliance
+// @Produce      json
+// @Success      200  {array}   serverinterfaces.ComplianceStandardInfo
+// @Router       /compliance/standards [get]
+func (ctrl *ComplianceController) ListStandards(c *gin.Context) {
+	c.JSON(http.StatusOK, ctrl.complianceService.ListStandards(c.Request.Context()))
+}
+
+// EvaluateVersion checks a version against a compliance standard
+// @Summary      Evaluate compliance
+// @Description  Check a version's architecture against the controls of a compliance standard (cis-aws-foundations, soc-2 or hipaa). Each control passes, fails with the IDs of the offending resources and remediation text, or is not applicable when the design has no resource it checks.
+// @Tags         versioning
+// @Produce      json
+// @Param        id          path      string  true  "Project ID"
+// @Param        version_id  path      string  true  "Version ID"
+// @Param        standard    path      string  true  "Standard slug"
+// @Success      200         {object}  serverinterfaces.ComplianceReport
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/compliance/{standard} [get]
+func (ctrl *ComplianceController) EvaluateVersion(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	report, err := ctrl.complianceService.EvaluateVersion(c.Request.Context(), id, versionID, c.Param("standard"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to evaluate compliance: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// VerifyTemplate checks a template's compliance claims against a version
+// @Summary      Verify template compliance
+// @Description  Check that a project version built from a marketplace template satisfies every compliance standard the template claims. A claim is verified when no control of the standard fails, failed otherwise, and unsupported when there is no built-in control set for the standard.
+// @Tags         compliance
+// @Produce      json
+// @Param        id          path      string  true  "Template ID"
+// @Param        project_id  query     string  true  "Project ID"
+// @Param        version_id  query     string  true  "Version ID"
+// @Success      200         {object}  serverinterfaces.TemplateComplianceVerification
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /templates/{id}/compliance [get]
+func (ctrl *ComplianceController) VerifyTemplate(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	projectID, err := uuid.Parse(c.Query("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project_id"})
+		return
+	}
+	versionID, err := uuid.Parse(c.Query("version_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version_id"})
+		return
+	}
+	result, err := ctrl.complianceService.VerifyTemplate(c.Request.Context(), id, projectID, versionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to verify template compliance: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 )
 
 type CostController struct {
@@ -30,10 +31,11 @@ func NewCostController(
 
 // GetProjectEstimate godoc
 // @Summary      Get project cost estimate
-// @Description  Calculate the estimated cost for the entire project architecture
+// @Description  Calculate the estimated cost for the entire project architecture. With purchase_option, EC2 instances are priced as Reserved Instances or under a Compute Savings Plan instead of On-Demand.
 // @Tags         cost
 // @Produce      json
-// @Param        id   path      string  true  "Project ID"
+// @Param        id               path      string  true   "Project ID"
+// @Param        purchase_option  query     string  false  "on_demand (default), reserved_{1yr,3yr}_{no,partial,all}_upfront or savings_plan_{1yr,3yr}_{no,partial,all}_upfront"
 // @Success      200  {object}  serverinterfaces.ArchitectureCostEstimate
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
@@ -46,6 +48,10 @@ func (cc *CostController) GetProjectEstimate(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
 		return
 	}
+	option, ok := parsePurchaseOption(c)
+	if !ok {
+		return
+	}
 
 	// Load architecture from database (returns domain model *architecture.Architecture)
 	arch, err := cc.projectService.LoadArchitecture(c.Request.Context(), projectID)
@@ -57,7 +63,7 @@ func (cc *CostController) GetProjectEstimate(c *gin.Context) {
 	// 720 hours = 30 days * 24 hours (standard monthly estimation)
 	duration := 720 * time.Hour
 
-	estimate, err := cc.pricingService.CalculateArchitectureCost(c.Request.Context(), arch, duration)
+	estimate, err := cc.pricingService.CalculateArchitectureCostWithPurchaseOption(c.Request.Context(), arch, duration, option)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to estimate cost: %v", err)})
 		return
@@ -119,7 +125,7 @@ func (cc *CostController) GetResourceEstimate(c *gin.Context) {
 
 // GetProjectOptimization godoc
 // @Summary      Get project cost optimization suggestions
-// @Description  Get suggestions for cost optimization based on architectural patterns
+// @Description  Get cost optimization suggestions for the project architecture: instance rightsizing across families, Graviton alternatives, gp2 to gp3 volumes, NAT gateway consolidation, VPC gateway endpoints, S3 storage classes and unattached Elastic IPs. Savings are monthly price differences computed from the pricing rates used for cost estimates.
 // @Tags         cost
 // @Produce      json
 // @Param        id   path      string  true  "Project ID"
@@ -154,11 +160,12 @@ func (cc *CostController) GetProjectOptimization(c *gin.Context) {
 
 // EstimateVersionCost estimates the cost for a specific version snapshot.
 // @Summary      Estimate cost for a version
-// @Description  Calculate the estimated monthly cost for the architecture of a specific version
+// @Description  Calculate the estimated monthly cost for the architecture of a specific version. With purchase_option, EC2 instances are priced as Reserved Instances or under a Compute Savings Plan instead of On-Demand. When the project has a budget, the estimate is compared against it, returned in budget and recorded with the version's pricing.
 // @Tags         versioning
 // @Produce      json
-// @Param        id          path      string  true  "Project ID"
-// @Param        version_id  path      string  true  "Version ID"
+// @Param        id               path      string  true   "Project ID"
+// @Param        version_id       path      string  true   "Version ID"
+// @Param        purchase_option  query     string  false  "on_demand (default), reserved_{1yr,3yr}_{no,partial,all}_upfront or savings_plan_{1yr,3yr}_{no,partial,all}_upfront"
 // @Success      200         {object}  serverinterfaces.ArchitectureCostEstimate
 // @Failure      400         {object}  map[string]string
 // @Failure      404         {object}  map[string]string
@@ -170,15 +177,134 @@ func (cc *CostController) EstimateVersionCost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
 		return
 	}
-	arch, err := cc.projectService.LoadArchitecture(c.Request.Context(), projectID)
+	versionID, err := uuid.Parse(c.Param("version_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version ID"})
+		return
+	}
+	option, ok := parsePurchaseOption(c)
+	if !ok {
+		return
+	}
+	version, err := cc.projectService.GetVersionByID(c.Request.Context(), projectID, versionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get version: " + err.Error()})
+		return
+	}
+	arch, err := cc.projectService.LoadArchitecture(c.Request.Context(), version.ProjectID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Architecture not found for this version"})
 		return
 	}
-	estimate, err := cc.pricingService.CalculateArchitectureCost(c.Request.Context(), arch, 720*time.Hour)
+	estimate, err := cc.pricingService.CalculateArchitectureCostWithPurchaseOption(c.Request.Context(), arch, 720*time.Hour, option)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to estimate cost: %v", err)})
 		return
 	}
+	if _, err := cc.projectService.EvaluateBudget(c.Request.Context(), version.ProjectID, estimate); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to compare with budget: " + err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, estimate)
 }
+
+// ComparePurchaseOptions compares the cost of a version under every purchase option.
+// @Summary      Compare purchase options
+// @Description  Calculate the monthly cost of a version's architecture On-Demand, with 1 and 3 year Reserved Instances and with 1 and 3 year Compute Savings Plans, for each payment option. Commitments apply to EC2 instances; upfront payments are amortized over the term.
+// @Tags         versioning
+// @Produce      json
+// @Param        id          path      string  true  "Project ID"
+// @Param        version_id  path      string  true  "Version ID"
+// @Success      200         {object}  serverinterfaces.PurchaseOptionComparison
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /projects/{id}/versions/{version_id}/cost/purchase-options [get]
+func (cc *CostController) ComparePurchaseOptions(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	versionID, err := uuid.Parse(c.Param("version_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version ID"})
+		return
+	}
+	version, err := cc.projectService.GetVersionByID(c.Request.Context(), projectID, versionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get version: " + err.Error()})
+		return
+	}
+	arch, err := cc.projectService.LoadArchitecture(c.Request.Context(), version.ProjectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Architecture not found for this version"})
+		return
+	}
+	comparison, err := cc.pricingService.ComparePurchaseOptions(c.Request.Context(), arch, 720*time.Hour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to compare purchase options: %v", err)})
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
+}
+
+// ForecastVersionCost projects the monthly cost of a version over time.
+// @Summary      Forecast cost over time
+// @Description  Project the monthly cost of a version's architecture over 12 to 36 months. Instance hours, stored data and traffic grow by their linear or percentage (compounded) monthly rate; while the account has free_tier_months left, 12 month AWS Free Tier allowances are credited. Returns one entry per month broken down by service.
+// @Tags         versioning
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                                 true   "Project ID"
+// @Param        version_id  path      string                                 true   "Version ID"
+// @Param        request     body      serverinterfaces.CostForecastRequest  false  "Horizon, growth assumptions, free tier months and purchase option"
+// @Success      200         {object}  serverinterfaces.CostForecast
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /projects/{id}/versions/{version_id}/cost/forecast [post]
+func (cc *CostController) ForecastVersionCost(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	versionID, err := uuid.Parse(c.Param("version_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version ID"})
+		return
+	}
+	var req serverinterfaces.CostForecastRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	version, err := cc.projectService.GetVersionByID(c.Request.Context(), projectID, versionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get version: " + err.Error()})
+		return
+	}
+	arch, err := cc.projectService.LoadArchitecture(c.Request.Context(), version.ProjectID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Architecture not found for this version"})
+		return
+	}
+	forecast, err := cc.pricingService.ForecastArchitectureCost(c.Request.Context(), arch, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": fmt.Sprintf("Failed to forecast cost: %v", err)})
+		return
+	}
+	c.JSON(http.StatusOK, forecast)
+}
+
+// parsePurchaseOption reads the purchase_option query parameter, writing a 400 response when it is invalid
+func parsePurchaseOption(c *gin.Context) (domainpricing.PurchaseOption, bool) {
+	option, err := domainpricing.ParsePurchaseOption(c.Query("purchase_option"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return option, true
+}
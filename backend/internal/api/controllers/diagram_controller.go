@@ -7,8 +7,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/validator"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
 
 // DiagramController handles diagram-related requests
@@ -42,10 +43,11 @@ func NewDiagramController(
 // @Produce      json
 // @Param        project_name  query     string  false  "Project Name"
 // @Param        iac_tool_id   query     int     false  "IaC Tool ID (1=Terraform)"
-// @Param        user_id       query     string  false  "User ID"
+// @Param        provider      query     string  false  "Cloud Provider (default: aws)"
 // @Param        diagram       body      object  true   "Diagram JSON"
 // @Success      200           {object}  map[string]interface{}
 // @Failure      400           {object}  map[string]interface{}
+// @Failure      401           {object}  map[string]interface{}
 // @Failure      500           {object}  map[string]interface{}
 // @Router       /diagrams/process [post]
 func (ctrl *DiagramController) ProcessDiagram(c *gin.Context) {
@@ -76,15 +78,8 @@ func (ctrl *DiagramController) ProcessDiagram(c *gin.Context) {
 		}
 	}
 
-	userIDStr := c.Query("user_id")
-	// TODO: Get from auth middleware
-	if userIDStr == "" {
-		userIDStr = "00000000-0000-0000-0000-000000000001"
-	}
-
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id format"})
+	userID, ok := callerID(c)
+	if !ok {
 		return
 	}
 
@@ -94,8 +89,8 @@ func (ctrl *DiagramController) ProcessDiagram(c *gin.Context) {
 		UserID:      userID,
 		ProjectName: projectName,
 		IACToolID:   iacToolID,
-		// CloudProvider and Region are currently default in pipeline or extracted from diagram,
-		// they can be enhanced to be passed in query params too
+		// Region is extracted from the diagram; an empty provider defaults to AWS in the pipeline
+		CloudProvider: c.Query("provider"),
 	}
 
 	result, err := ctrl.pipelineOrchestrator.ProcessDiagram(c.Request.Context(), req)
@@ -118,6 +113,7 @@ func (ctrl *DiagramController) ProcessDiagram(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        diagram  body      object  true   "Diagram JSON"
+// @Param        provider query     string  false  "Cloud Provider (default: aws)"
 // @Success      200      {object}  map[string]interface{}
 // @Failure      400      {object}  map[string]interface{}
 // @Failure      500      {object}  map[string]interface{}
@@ -143,8 +139,9 @@ func (ctrl *DiagramController) ValidateDiagram(c *gin.Context) {
 		return
 	}
 
-	// Validate
-	result, err := ctrl.diagramService.Validate(c.Request.Context(), graph, nil)
+	// Validate config against the provider's resource schemas
+	opts := &validator.ValidationOptions{Provider: c.Query("provider")}
+	result, err := ctrl.diagramService.Validate(c.Request.Context(), graph, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate diagram: " + err.Error()})
 		return
@@ -192,14 +189,14 @@ func (ctrl *DiagramController) ValidateDomainRules(c *gin.Context) {
 	}
 
 	// Map to Architecture
-	arch, err := ctrl.architectureService.MapFromDiagram(c.Request.Context(), graph, "aws") // Hardcoding aws for now as primary support
+	arch, err := ctrl.architectureService.MapFromDiagram(c.Request.Context(), graph, resource.CloudProvider(providerStr))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to map diagram to architecture: " + err.Error()})
 		return
 	}
 
 	// Validate Rules
-	result, err := ctrl.architectureService.ValidateRules(c.Request.Context(), arch, "aws")
+	result, err := ctrl.architectureService.ValidateRules(c.Request.Context(), arch, resource.CloudProvider(providerStr))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate rules: " + err.Error()})
 		return
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// DriftController handles comparing designs with deployed infrastructure
+type DriftController struct {
+	driftService serverinterfaces.DriftService
+}
+
+// NewDriftController creates a new drift controller
+func NewDriftController(driftService serverinterfaces.DriftService) *DriftController {
+	return &DriftController{driftService: driftService}
+}
+
+// DetectDrift compares a version with a Terraform state file.
+// @Summary      Detect drift
+// @Description  Compare the architecture of a version with an uploaded terraform.tfstate (format version 4). Resources are matched by the Terraform addresses code generation writes. The report lists resources designed but not deployed, resources deployed but not designed, and per-attribute mismatches (e.g. instance_type, cidr_block, ingress rules) for the attributes the design sets.
+// @Tags         versioning
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                                true  "Project ID"
+// @Param        version_id  path      string                                true  "Version ID"
+// @Param        body        body      serverinterfaces.DetectDriftRequest  true  "The state document"
+// @Success      200         {object}  serverinterfaces.DriftResult
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/drift [post]
+func (ctrl *DriftController) DetectDrift(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	var req serverinterfaces.DetectDriftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := ctrl.driftService.DetectDrift(c.Request.Context(), id, versionID, &req)
+	if err != nil {
+		if apperrors.IsKind(err, apperrors.KindValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to detect drift: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
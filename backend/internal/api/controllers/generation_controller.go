@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"log/slog"
@@ -12,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 )
 
@@ -53,12 +55,17 @@ func (ctrl *GenerationController) GenerateCode(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	opts, err := generationOptions(req.Options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid generation options: " + err.Error()})
+		return
+	}
 
 	// Call orchestrator to generate code
 	out, err := ctrl.orchestrator.GenerateCode(c.Request.Context(), &serverinterfaces.GenerateCodeRequest{
-		ProjectID:     projectID,
-		Engine:        req.Tool,
-		CloudProvider: "aws", // TODO: Get from project or request? Assuming stored in project or inferred.
+		ProjectID: projectID,
+		Engine:    req.Tool,
+		Options:   opts,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate code: " + err.Error()})
@@ -96,6 +103,7 @@ func (ctrl *GenerationController) GenerateCode(c *gin.Context) {
 // @Produce application/zip
 // @Param id path string true "Project ID"
 // @Param tool query string true "IaC Tool (terraform, pulumi, etc)"
+// @Param modularity query string false "Output modularity (low, medium, high); high writes each VPC as a local module"
 // @Success 200 {file} file
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -112,12 +120,13 @@ func (ctrl *GenerationController) DownloadCode(c *gin.Context) {
 	if tool == "" {
 		tool = "terraform" // default
 	}
+	opts := iac.Options{Modules: strings.EqualFold(c.Query("modularity"), dto.ModularityHigh)}
 
 	// Generate code
 	out, err := ctrl.orchestrator.GenerateCode(c.Request.Context(), &serverinterfaces.GenerateCodeRequest{
-		ProjectID:     projectID,
-		Engine:        tool,
-		CloudProvider: "aws",
+		ProjectID: projectID,
+		Engine:    tool,
+		Options:   opts,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate code: " + err.Error()})
@@ -181,10 +190,15 @@ func (ctrl *GenerationController) GenerateCodeForVersion(c *gin.Context) {
 	if req.Tool == "" {
 		req.Tool = "terraform"
 	}
+	opts, err := generationOptions(req.Options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid generation options: " + err.Error()})
+		return
+	}
 	out, err := ctrl.orchestrator.GenerateCode(c.Request.Context(), &serverinterfaces.GenerateCodeRequest{
-		ProjectID:     projectID,
-		Engine:        req.Tool,
-		CloudProvider: "aws",
+		ProjectID: projectID,
+		Engine:    req.Tool,
+		Options:   opts,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate code: " + err.Error()})
@@ -205,3 +219,85 @@ func (ctrl *GenerationController) GenerateCodeForVersion(c *gin.Context) {
 	resp.ID = resp.GenerationID
 	c.JSON(http.StatusOK, resp)
 }
+
+// generationOptions converts request options into engine options.
+// It rejects an incomplete backend override.
+func generationOptions(opts *dto.GenerationOptions) (iac.Options, error) {
+	if opts == nil {
+		return iac.Options{}, nil
+	}
+	out := iac.Options{
+		Modules: strings.EqualFold(opts.Modularity, dto.ModularityHigh),
+		Backend: stateBackendFromDTO(opts.Backend),
+	}
+	if out.Backend != nil {
+		if err := out.Backend.Validate(); err != nil {
+			return iac.Options{}, err
+		}
+	}
+	return out, nil
+}
+
+// stateBackendFromDTO converts API backend settings into the engine type.
+func stateBackendFromDTO(b *dto.StateBackendConfig) *iac.StateBackend {
+	if b == nil {
+		return nil
+	}
+	out := &iac.StateBackend{Type: b.Type, Bootstrap: b.Bootstrap}
+	if b.S3 != nil {
+		out.S3 = &iac.S3Backend{
+			Bucket:             b.S3.Bucket,
+			Key:                b.S3.Key,
+			Region:             b.S3.Region,
+			DynamoDBTable:      b.S3.DynamoDBTable,
+			Encrypt:            b.S3.Encrypt,
+			WorkspaceKeyPrefix: b.S3.WorkspaceKeyPrefix,
+		}
+	}
+	if b.Local != nil {
+		out.Local = &iac.LocalBackend{Path: b.Local.Path}
+	}
+	if b.HTTP != nil {
+		out.HTTP = &iac.HTTPBackend{
+			Address:       b.HTTP.Address,
+			LockAddress:   b.HTTP.LockAddress,
+			UnlockAddress: b.HTTP.UnlockAddress,
+			LockMethod:    b.HTTP.LockMethod,
+			UnlockMethod:  b.HTTP.UnlockMethod,
+			Username:      b.HTTP.Username,
+		}
+	}
+	return out
+}
+
+// stateBackendToDTO converts engine backend settings into the API type.
+func stateBackendToDTO(b *iac.StateBackend) *dto.StateBackendConfig {
+	if b == nil {
+		return nil
+	}
+	out := &dto.StateBackendConfig{Type: b.Type, Bootstrap: b.Bootstrap}
+	if b.S3 != nil {
+		out.S3 = &dto.S3BackendConfig{
+			Bucket:             b.S3.Bucket,
+			Key:                b.S3.Key,
+			Region:             b.S3.Region,
+			DynamoDBTable:      b.S3.DynamoDBTable,
+			Encrypt:            b.S3.Encrypt,
+			WorkspaceKeyPrefix: b.S3.WorkspaceKeyPrefix,
+		}
+	}
+	if b.Local != nil {
+		out.Local = &dto.LocalBackendConfig{Path: b.Local.Path}
+	}
+	if b.HTTP != nil {
+		out.HTTP = &dto.HTTPBackendConfig{
+			Address:       b.HTTP.Address,
+			LockAddress:   b.HTTP.LockAddress,
+			UnlockAddress: b.HTTP.UnlockAddress,
+			LockMethod:    b.HTTP.LockMethod,
+			UnlockMethod:  b.HTTP.UnlockMethod,
+			Username:      b.HTTP.Username,
+		}
+	}
+	return out
+}
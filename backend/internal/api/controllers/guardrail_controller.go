@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/request"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/response"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// GuardrailController handles the caller's policy-as-code guardrails
+type GuardrailController struct {
+	guardrailService serverinterfaces.GuardrailService
+}
+
+// NewGuardrailController creates a new guardrail controller
+func NewGuardrailController(guardrailService serverinterfaces.GuardrailService) *GuardrailController {
+	return &GuardrailController{guardrailService: guardrailService}
+}
+
+// ListGuardrails lists the caller's guardrails and the instance-wide ones
+// @Summary      List guardrails
+// @Description  List the authenticated user's guardrails, and the instance-wide guardrails that apply to every user. Both are checked whenever an architecture's rules are validated.
+// @Tags         guardrails
+// @Produce      json
+// @Success      200  {object}  response.GuardrailListResponse
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /guardrails [get]
+func (ctrl *GuardrailController) ListGuardrails(c *gin.Context) {
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+	guardrails, err := ctrl.guardrailService.List(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to list guardrails: " + err.Error()})
+		return
+	}
+
+	resp := response.GuardrailListResponse{
+		Guardrails: make([]response.GuardrailResponse, 0, len(guardrails)),
+		Instance:   make([]response.InstanceGuardrailResponse, 0),
+	}
+	for _, g := range guardrails {
+		resp.Guardrails = append(resp.Guardrails, guardrailToResponse(g))
+	}
+	for _, g := range ctrl.guardrailService.InstanceGuardrails() {
+		resp.Instance = append(resp.Instance, response.InstanceGuardrailResponse{
+			Name:     g.Name,
+			Source:   g.Source,
+			Policies: g.Policies,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateGuardrail creates a guardrail for the caller
+// @Summary      Create a guardrail
+// @Description  Save a policy document as a guardrail of the authenticated user. The source must parse; syntax errors are reported with their line and column.
+// @Tags         guardrails
+// @Accept       json
+// @Produce      json
+// @Param        guardrail  body      request.CreateGuardrailRequest  true  "Guardrail"
+// @Success      201        {object}  response.GuardrailResponse
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      401        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /guardrails [post]
+func (ctrl *GuardrailController) CreateGuardrail(c *gin.Context) {
+	var req request.CreateGuardrailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	guardrail, err := ctrl.guardrailService.Create(c.Request.Context(), userID, &serverinterfaces.GuardrailRequest{
+		Name:        &req.Name,
+		Description: &req.Description,
+		Source:      &req.Source,
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to create guardrail: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, guardrailToResponse(guardrail))
+}
+
+// GetGuardrail retrieves one of the caller's guardrails
+// @Summary      Get a guardrail
+// @Description  Get one of the authenticated user's guardrails by ID
+// @Tags         guardrails
+// @Produce      json
+// @Param        id   path      string  true  "Guardrail ID"
+// @Success      200  {object}  response.GuardrailResponse
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /guardrails/{id} [get]
+func (ctrl *GuardrailController) GetGuardrail(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+	guardrail, err := ctrl.guardrailService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to fetch guardrail: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, guardrailToResponse(guardrail))
+}
+
+// UpdateGuardrail updates one of the caller's guardrails
+// @Summary      Update a guardrail
+// @Description  Change the name, description, source or enabled flag of one of the authenticated user's guardrails. Omitted fields are left unchanged.
+// @Tags         guardrails
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                          true  "Guardrail ID"
+// @Param        guardrail  body      request.UpdateGuardrailRequest  true  "Guardrail changes"
+// @Success      200        {object}  response.GuardrailResponse
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /guardrails/{id} [put]
+func (ctrl *GuardrailController) UpdateGuardrail(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req request.UpdateGuardrailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	guardrail, err := ctrl.guardrailService.Update(c.Request.Context(), userID, id, &serverinterfaces.GuardrailRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Source:      req.Source,
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to update guardrail: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, guardrailToResponse(guardrail))
+}
+
+// DeleteGuardrail deletes one of the caller's guardrails
+// @Summary      Delete a guardrail
+// @Description  Delete one of the authenticated user's guardrails
+// @Tags         guardrails
+// @Param        id   path      string  true  "Guardrail ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /guardrails/{id} [delete]
+func (ctrl *GuardrailController) DeleteGuardrail(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.guardrailService.Delete(c.Request.Context(), userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to delete guardrail: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func guardrailToResponse(g *models.Guardrail) response.GuardrailResponse {
+	return response.GuardrailResponse{
+		ID:          g.ID.String(),
+		Name:        g.Name,
+		Description: g.Description,
+		Source:      g.Source,
+		Enabled:     g.Enabled,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
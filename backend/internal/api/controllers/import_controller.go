@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// ImportController handles importing existing infrastructure code into projects
+type ImportController struct {
+	importService serverinterfaces.ImportService
+}
+
+// NewImportController creates a new import controller
+func NewImportController(importService serverinterfaces.ImportService) *ImportController {
+	return &ImportController{importService: importService}
+}
+
+// ImportTerraform imports Terraform HCL as a new project version.
+// @Summary      Import Terraform
+// @Description  Parse Terraform files (resources, providers, variables and outputs) and save the recognised resources as a new version of the project. Containment is rebuilt from references such as vpc_id and subnet_id, and nodes are laid out automatically. Constructs with no diagram equivalent are listed in issues.
+// @Tags         import
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                   true  "Project ID (any version in the lineage)"
+// @Param        body  body      serverinterfaces.ImportTerraformRequest  true  "Terraform files keyed by file name"
+// @Success      201   {object}  serverinterfaces.ImportResult
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
+// @Router       /projects/{id}/import/terraform [post]
+func (ctrl *ImportController) ImportTerraform(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req serverinterfaces.ImportTerraformRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := ctrl.importService.ImportTerraform(c.Request.Context(), id, &req)
+	if err != nil {
+		if apperrors.IsKind(err, apperrors.KindValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import terraform: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// ImportState creates a project from a Terraform state file.
+// @Summary      Import Terraform state
+// @Description  Create a new project from a terraform.tfstate document (format version 4). Managed resources are drawn with the IDs, ARNs and lifecycle state recorded in the state, containment is derived from vpc_id and subnet_id, and nodes are laid out automatically. No cloud credentials are needed.
+// @Tags         import
+// @Accept       json
+// @Produce      json
+// @Param        body  body      serverinterfaces.ImportStateRequest  true  "Project details and the state document"
+// @Success      201   {object}  serverinterfaces.StateImportResult
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      401   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
+// @Router       /projects/import/tfstate [post]
+func (ctrl *ImportController) ImportState(c *gin.Context) {
+	var req serverinterfaces.ImportStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+	result, err := ctrl.importService.ImportState(c.Request.Context(), userID, &req)
+	if err != nil {
+		if apperrors.IsKind(err, apperrors.KindValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import terraform state: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,331 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/request"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/response"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// MarketplaceController handles publishing, browsing and instantiating marketplace templates
+type MarketplaceController struct {
+	marketplaceService serverinterfaces.MarketplaceService
+}
+
+// NewMarketplaceController creates a new marketplace controller
+func NewMarketplaceController(marketplaceService serverinterfaces.MarketplaceService) *MarketplaceController {
+	return &MarketplaceController{marketplaceService: marketplaceService}
+}
+
+// ListTemplates searches the marketplace
+// @Summary      Search templates
+// @Description  List marketplace templates, filtered by text, category, technology, compliance standard, cloud provider and estimated monthly cost. A cost range keeps the templates whose estimated range overlaps it.
+// @Tags         marketplace
+// @Produce      json
+// @Param        search          query     string  false  "Text in the title or description"
+// @Param        category        query     string  false  "Category slug"
+// @Param        technology      query     string  false  "Technology slug"
+// @Param        compliance      query     string  false  "Compliance standard slug"
+// @Param        cloud_provider  query     string  false  "Cloud provider (AWS, Azure, GCP or Multi-Cloud)"
+// @Param        min_cost        query     number  false  "Minimum monthly cost (USD)"
+// @Param        max_cost        query     number  false  "Maximum monthly cost (USD)"
+// @Param        sort            query     string  false  "popular (default), rating, downloads, newest or cost"
+// @Param        page            query     int     false  "Page number"  default(1)
+// @Param        limit           query     int     false  "Page size (max 100)"  default(20)
+// @Success      200             {object}  response.TemplateListResponse
+// @Failure      400             {object}  map[string]interface{}
+// @Failure      500             {object}  map[string]interface{}
+// @Router       /templates [get]
+func (ctrl *MarketplaceController) ListTemplates(c *gin.Context) {
+	var query struct {
+		Search        string   `form:"search"`
+		Category      string   `form:"category"`
+		Technology    string   `form:"technology"`
+		Compliance    string   `form:"compliance"`
+		CloudProvider string   `form:"cloud_provider"`
+		MinCost       *float64 `form:"min_cost"`
+		MaxCost       *float64 `form:"max_cost"`
+		Sort          string   `form:"sort"`
+		Page          int      `form:"page,default=1"`
+		Limit         int      `form:"limit,default=20"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	filter := models.TemplateFilter{
+		Search:        query.Search,
+		Category:      query.Category,
+		Technology:    query.Technology,
+		Compliance:    query.Compliance,
+		CloudProvider: query.CloudProvider,
+		MinCost:       query.MinCost,
+		MaxCost:       query.MaxCost,
+		Sort:          query.Sort,
+		Page:          query.Page,
+		Limit:         query.Limit,
+	}
+	templates, total, err := ctrl.marketplaceService.Search(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to search templates: " + err.Error()})
+		return
+	}
+
+	resp := response.TemplateListResponse{
+		Templates: make([]response.TemplateSummaryResponse, 0, len(templates)),
+		Total:     total,
+		Page:      query.Page,
+		Limit:     query.Limit,
+	}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, templateToSummary(t))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTemplate retrieves a template
+// @Summary      Get a template
+// @Description  Get a marketplace template with its author, features, use cases, components and parameters. Components are the template's resources with their monthly on-demand cost; parameters are the inputs chosen when the template is instantiated.
+// @Tags         marketplace
+// @Produce      json
+// @Param        id   path      string  true  "Template ID"
+// @Success      200  {object}  response.TemplateDetailResponse
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /templates/{id} [get]
+func (ctrl *MarketplaceController) GetTemplate(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	template, err := ctrl.marketplaceService.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get template: " + err.Error()})
+		return
+	}
+	resp, err := templateToDetail(template)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read template parameters: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// PublishVersion publishes a version as a template
+// @Summary      Publish a version as a template
+// @Description  Publish a version of one of the caller's projects to the marketplace. The version's architecture is stored with the template, each resource becomes a component priced on-demand, and the estimated monthly cost ranges from the cheapest purchase option to on-demand. Category, technologies, compliance standards and IaC formats are slugs of existing entries. The template's parameters are its region, its number of availability zones and the version's input variables; parameters can be given a description and a list of accepted options, and sensitive variable values are not published.
+// @Tags         marketplace
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                          true  "Project ID"
+// @Param        version_id  path      string                          true  "Version ID"
+// @Param        template    body      request.PublishTemplateRequest  true  "Marketplace listing"
+// @Success      201         {object}  response.TemplateDetailResponse
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      401         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/publish [post]
+func (ctrl *MarketplaceController) PublishVersion(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	var req request.PublishTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	publish := &serverinterfaces.PublishTemplateRequest{
+		ProjectID:           id,
+		VersionID:           versionID,
+		Title:               req.Title,
+		Description:         req.Description,
+		Category:            req.Category,
+		Technologies:        req.Technologies,
+		ComplianceStandards: req.ComplianceStandards,
+		IACFormats:          req.IACFormats,
+		Features:            req.Features,
+		ImageURL:            req.ImageURL,
+		DeploymentTime:      req.DeploymentTime,
+	}
+	for _, useCase := range req.UseCases {
+		publish.UseCases = append(publish.UseCases, serverinterfaces.TemplateUseCaseInput{
+			Title:       useCase.Title,
+			Description: useCase.Description,
+			Icon:        useCase.Icon,
+		})
+	}
+	for _, param := range req.Parameters {
+		publish.Parameters = append(publish.Parameters, serverinterfaces.TemplateParameterInput{
+			Name:        param.Name,
+			Description: param.Description,
+			Options:     param.Options,
+		})
+	}
+	template, err := ctrl.marketplaceService.Publish(c.Request.Context(), userID, publish)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to publish template: " + err.Error()})
+		return
+	}
+	resp, err := templateToDetail(template)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read template parameters: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// InstantiateTemplate creates a project from a template
+// @Summary      Instantiate a template
+// @Description  Create a new project for the caller from a template: the template's stored architecture becomes version 1, in the template's cloud provider. Parameter values are substituted into the architecture: variables take the chosen values and resource configuration referencing them (var.name) is resolved again, the region replaces the template's region and az_count repeats or removes subnets and their NAT gateways per availability zone, placing new subnets in free CIDR blocks of their VPC. The new project's monthly cost range is estimated with current prices, the template's download count is increased and the caller can then review the template.
+// @Tags         marketplace
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                              true  "Template ID"
+// @Param        project  body      request.InstantiateTemplateRequest  true  "New project"
+// @Success      201      {object}  serverinterfaces.TemplateInstance
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      401      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /templates/{id}/instantiate [post]
+func (ctrl *MarketplaceController) InstantiateTemplate(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req request.InstantiateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	instance, err := ctrl.marketplaceService.Instantiate(c.Request.Context(), userID, id, &serverinterfaces.InstantiateTemplateRequest{
+		Name:       req.Name,
+		IACToolID:  req.IACToolID,
+		Parameters: req.Parameters,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to instantiate template: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, instance)
+}
+
+func templateToSummary(t *models.Template) response.TemplateSummaryResponse {
+	resp := response.TemplateSummaryResponse{
+		ID:                  t.ID.String(),
+		Title:               t.Title,
+		Description:         t.Description,
+		Category:            response.TemplateTagResponse{Name: t.Category.Name, Slug: t.Category.Slug},
+		CloudProvider:       t.CloudProvider,
+		Rating:              t.Rating,
+		ReviewCount:         t.ReviewCount,
+		Downloads:           t.Downloads,
+		Price:               t.Price,
+		EstimatedCostMin:    t.EstimatedCostMin,
+		EstimatedCostMax:    t.EstimatedCostMax,
+		ImageURL:            t.ImageURL,
+		IsPopular:           t.IsPopular,
+		IsNew:               t.IsNew,
+		Resources:           t.Resources,
+		DeploymentTime:      t.DeploymentTime,
+		Regions:             t.Regions,
+		Technologies:        make([]response.TemplateTagResponse, 0, len(t.Technologies)),
+		ComplianceStandards: make([]response.TemplateTagResponse, 0, len(t.ComplianceStandards)),
+		IACFormats:          make([]response.TemplateTagResponse, 0, len(t.IACFormats)),
+		LastUpdated:         t.LastUpdated,
+		CreatedAt:           t.CreatedAt,
+	}
+	for _, tech := range t.Technologies {
+		resp.Technologies = append(resp.Technologies, response.TemplateTagResponse{Name: tech.Name, Slug: tech.Slug})
+	}
+	for _, std := range t.ComplianceStandards {
+		resp.ComplianceStandards = append(resp.ComplianceStandards, response.TemplateTagResponse{Name: std.Name, Slug: std.Slug})
+	}
+	for _, format := range t.IACFormats {
+		resp.IACFormats = append(resp.IACFormats, response.TemplateTagResponse{Name: format.Name, Slug: format.Slug})
+	}
+	return resp
+}
+
+func templateToDetail(t *models.Template) (response.TemplateDetailResponse, error) {
+	params, err := t.UnmarshalParameters()
+	if err != nil {
+		return response.TemplateDetailResponse{}, err
+	}
+	resp := response.TemplateDetailResponse{
+		TemplateSummaryResponse: templateToSummary(t),
+		Author: response.TemplateAuthorResponse{
+			ID:     t.AuthorID.String(),
+			Name:   t.Author.Name,
+			Avatar: t.Author.Avatar,
+		},
+		Instantiable: len(t.Architecture) > 0 && string(t.Architecture) != "null",
+		Features:     make([]string, 0, len(t.Features)),
+		UseCases:     make([]response.TemplateUseCaseResponse, 0, len(t.UseCases)),
+		Components:   make([]response.TemplateComponentResponse, 0, len(t.Components)),
+		Parameters:   make([]response.TemplateParameterResponse, 0, len(params)),
+	}
+	if t.SourceVersionID != nil {
+		id := t.SourceVersionID.String()
+		resp.SourceVersionID = &id
+	}
+
+	sort.SliceStable(t.Features, func(i, j int) bool { return t.Features[i].DisplayOrder < t.Features[j].DisplayOrder })
+	for _, f := range t.Features {
+		resp.Features = append(resp.Features, f.Feature)
+	}
+	sort.SliceStable(t.UseCases, func(i, j int) bool { return t.UseCases[i].DisplayOrder < t.UseCases[j].DisplayOrder })
+	for _, u := range t.UseCases {
+		resp.UseCases = append(resp.UseCases, response.TemplateUseCaseResponse{
+			Title:       u.Title,
+			Description: u.Description,
+			Icon:        u.Icon,
+		})
+	}
+	sort.SliceStable(t.Components, func(i, j int) bool { return t.Components[i].DisplayOrder < t.Components[j].DisplayOrder })
+	for _, comp := range t.Components {
+		resp.Components = append(resp.Components, response.TemplateComponentResponse{
+			Name:          comp.Name,
+			Service:       comp.Service,
+			Configuration: comp.Configuration,
+			MonthlyCost:   comp.MonthlyCost,
+			Purpose:       comp.Purpose,
+		})
+	}
+	for _, p := range params {
+		resp.Parameters = append(resp.Parameters, response.TemplateParameterResponse{
+			Name:        p.Name,
+			Type:        p.Type,
+			Description: p.Description,
+			Default:     p.Default,
+			Options:     p.Options,
+			Min:         p.Min,
+			Max:         p.Max,
+			Required:    p.Required,
+			Sensitive:   p.Sensitive,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// NetworkController handles network analysis of designs
+type NetworkController struct {
+	networkService serverinterfaces.NetworkService
+}
+
+// NewNetworkController creates a new network controller
+func NewNetworkController(networkService serverinterfaces.NetworkService) *NetworkController {
+	return &NetworkController{networkService: networkService}
+}
+
+// AnalyzeReachability reports which resources of a version are reachable, and from where.
+// @Summary      Analyze reachability
+// @Description  Work out, from security group rules, route tables, internet and NAT gateways and subnet placement, which resources of a version are reachable from 0.0.0.0/0 and on which ports, and which resources can open connections to each other. Findings flag exposures such as a database reachable from the internet or SSH open to the world. Network ACLs are not considered.
+// @Tags         versioning
+// @Produce      json
+// @Param        id          path      string  true  "Project ID"
+// @Param        version_id  path      string  true  "Version ID"
+// @Success      200         {object}  serverinterfaces.ReachabilityReport
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/reachability [get]
+func (ctrl *NetworkController) AnalyzeReachability(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	report, err := ctrl.networkService.AnalyzeReachability(c.Request.Context(), id, versionID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to analyze reachability: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -8,8 +8,11 @@ import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/request"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/response"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/middleware"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 )
 
 // Ensure dto is imported for swag annotations
@@ -36,6 +39,7 @@ func NewProjectController(projectService serverinterfaces.ProjectService) *Proje
 // @Param        project  body      request.CreateProjectRequest  true  "Project creation request"
 // @Success      201      {object}  response.ProjectResponse
 // @Failure      400      {object}  map[string]interface{}
+// @Failure      401      {object}  map[string]interface{}
 // @Failure      500      {object}  map[string]interface{}
 // @Router       /projects [post]
 func (ctrl *ProjectController) CreateProject(c *gin.Context) {
@@ -45,13 +49,8 @@ func (ctrl *ProjectController) CreateProject(c *gin.Context) {
 		return
 	}
 
-	if req.UserID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
-		return
-	}
-	userID, err := uuid.Parse(req.UserID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+	userID, ok := callerID(c)
+	if !ok {
 		return
 	}
 
@@ -63,7 +62,7 @@ func (ctrl *ProjectController) CreateProject(c *gin.Context) {
 		Region:        req.Region,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to create project: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, projectToResponse(project))
@@ -87,7 +86,7 @@ func (ctrl *ProjectController) GetProject(c *gin.Context) {
 	}
 	project, err := ctrl.projectService.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to fetch project: " + err.Error()})
 		return
 	}
 	if project == nil {
@@ -123,8 +122,8 @@ func (ctrl *ProjectController) UpdateProject(c *gin.Context) {
 	}
 
 	project, err := ctrl.projectService.GetByID(c.Request.Context(), id)
-	if err != nil || project == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to fetch project: " + err.Error()})
 		return
 	}
 
@@ -143,7 +142,7 @@ func (ctrl *ProjectController) UpdateProject(c *gin.Context) {
 
 	updated, err := ctrl.projectService.UpdateMetadata(c.Request.Context(), project)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to update project: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, projectToResponse(updated))
@@ -164,7 +163,7 @@ func (ctrl *ProjectController) DeleteProject(c *gin.Context) {
 		return
 	}
 	if err := ctrl.projectService.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to delete project: " + err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -172,7 +171,7 @@ func (ctrl *ProjectController) DeleteProject(c *gin.Context) {
 
 // ListProjects lists all projects with pagination and filtering
 // @Summary      List projects
-// @Description  Get a list of projects with pagination, sorting, and searching
+// @Description  Get a list of the authenticated user's projects with pagination, sorting, and searching
 // @Tags         projects
 // @Produce      json
 // @Param        page     query     int     false  "Page number"
@@ -180,9 +179,9 @@ func (ctrl *ProjectController) DeleteProject(c *gin.Context) {
 // @Param        sort     query     string  false  "Sort field"
 // @Param        order    query     string  false  "Sort order (asc/desc)"
 // @Param        search   query     string  false  "Search term"
-// @Param        user_id  query     string  false  "User ID filter"
 // @Success      200      {object}  map[string]interface{}
 // @Failure      400      {object}  map[string]interface{}
+// @Failure      401      {object}  map[string]interface{}
 // @Failure      500      {object}  map[string]interface{}
 // @Router       /projects [get]
 func (ctrl *ProjectController) ListProjects(c *gin.Context) {
@@ -192,26 +191,20 @@ func (ctrl *ProjectController) ListProjects(c *gin.Context) {
 		Sort   string `form:"sort"`
 		Order  string `form:"order"`
 		Search string `form:"search"`
-		UserID string `form:"user_id"`
 	}
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
 		return
 	}
 
-	var userID uuid.UUID
-	if query.UserID != "" {
-		parsed, err := uuid.Parse(query.UserID)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id filter"})
-			return
-		}
-		userID = parsed
+	userID, ok := callerID(c)
+	if !ok {
+		return
 	}
 
 	projects, total, err := ctrl.projectService.List(c.Request.Context(), userID, query.Page, query.Limit, query.Sort, query.Order, query.Search)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list projects: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to list projects: " + err.Error()})
 		return
 	}
 
@@ -228,9 +221,15 @@ func (ctrl *ProjectController) ListUserProjects(c *gin.Context) {
 	if !ok {
 		return
 	}
+	if caller, ok := callerID(c); !ok {
+		return
+	} else if caller != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot list another user's projects"})
+		return
+	}
 	projects, total, err := ctrl.projectService.List(c.Request.Context(), userID, 1, 100, "", "", "")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list projects: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to list projects: " + err.Error()})
 		return
 	}
 	resps := make([]response.ProjectResponse, len(projects))
@@ -266,7 +265,7 @@ func (ctrl *ProjectController) DuplicateProject(c *gin.Context) {
 	}
 	project, version, err := ctrl.projectService.Duplicate(c.Request.Context(), id, req.Name)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to duplicate project: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to duplicate project: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{
@@ -277,6 +276,245 @@ func (ctrl *ProjectController) DuplicateProject(c *gin.Context) {
 	})
 }
 
+// ── State backend (non-versioned) ─────────────────────────────────────────────
+
+// GetBackend returns the project's Terraform state backend.
+// @Summary      Get state backend
+// @Description  Get the Terraform state backend saved with the project. A null backend means local state.
+// @Tags         projects
+// @Produce      json
+// @Param        id   path      string  true  "Project ID"
+// @Success      200  {object}  dto.ProjectBackendResponse
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /projects/{id}/backend [get]
+func (ctrl *ProjectController) GetBackend(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backend, err := ctrl.projectService.GetBackend(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get backend: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.ProjectBackendResponse{ProjectID: id, Backend: stateBackendToDTO(backend)})
+}
+
+// UpdateBackend saves the project's Terraform state backend in place (no new snapshot).
+// @Summary      Update state backend
+// @Description  Save the S3 (with optional DynamoDB locking), local or HTTP state backend used when generating Terraform. Later versions inherit it.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Project ID"
+// @Param        backend  body      dto.StateBackendConfig  true  "State backend"
+// @Success      200      {object}  dto.ProjectBackendResponse
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /projects/{id}/backend [put]
+func (ctrl *ProjectController) UpdateBackend(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.StateBackendConfig
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	backend := stateBackendFromDTO(&req)
+	if err := backend.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	saved, err := ctrl.projectService.UpdateBackend(c.Request.Context(), id, backend)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to update backend: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.ProjectBackendResponse{ProjectID: id, Backend: stateBackendToDTO(saved)})
+}
+
+// DeleteBackend resets the project to local state.
+// @Summary      Reset state backend
+// @Description  Remove the saved state backend so generated Terraform uses local state.
+// @Tags         projects
+// @Param        id   path      string  true  "Project ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /projects/{id}/backend [delete]
+func (ctrl *ProjectController) DeleteBackend(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := ctrl.projectService.UpdateBackend(c.Request.Context(), id, nil); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to reset backend: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ── Usage profile (non-versioned) ─────────────────────────────────────────────
+
+// GetUsageProfile returns the workload the project's cost estimates assume.
+// @Summary      Get usage profile
+// @Description  Get the usage profile saved with the project: uptime schedules, requests, storage, capacity and traffic per resource (by name) and edge. An empty profile means resources run 24/7 with no traffic.
+// @Tags         projects
+// @Produce      json
+// @Param        id   path      string  true  "Project ID"
+// @Success      200  {object}  pricing.UsageProfile
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /projects/{id}/usage-profile [get]
+func (ctrl *ProjectController) GetUsageProfile(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	profile, err := ctrl.projectService.GetUsageProfile(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get usage profile: " + err.Error()})
+		return
+	}
+	if profile == nil {
+		profile = &pricing.UsageProfile{}
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateUsageProfile saves the project's usage profile in place (no new snapshot).
+// @Summary      Update usage profile
+// @Description  Save the workload cost estimates assume. Monthly amounts are scaled to the estimate duration; schedules scale hourly On-Demand charges; edge traffic is priced as data transfer out to the internet (no target) or between availability zones. Later versions inherit it.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                true  "Project ID"
+// @Param        profile  body      pricing.UsageProfile  true  "Usage profile"
+// @Success      200      {object}  pricing.UsageProfile
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /projects/{id}/usage-profile [put]
+func (ctrl *ProjectController) UpdateUsageProfile(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req pricing.UsageProfile
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	saved, err := ctrl.projectService.UpdateUsageProfile(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to update usage profile: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteUsageProfile removes the project's usage profile.
+// @Summary      Remove usage profile
+// @Description  Remove the saved usage profile so cost estimates assume resources run 24/7 with no traffic.
+// @Tags         projects
+// @Param        id   path      string  true  "Project ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /projects/{id}/usage-profile [delete]
+func (ctrl *ProjectController) DeleteUsageProfile(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := ctrl.projectService.UpdateUsageProfile(c.Request.Context(), id, nil); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to remove usage profile: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetBudget returns the project's monthly budget.
+// @Summary      Get budget
+// @Description  Get the monthly budget versions of the project are compared against: a total limit, limits per resource category (e.g. compute, networking) and whether versions over budget are flagged or blocked.
+// @Tags         projects
+// @Produce      json
+// @Param        id   path      string  true  "Project ID"
+// @Success      200  {object}  pricing.Budget
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /projects/{id}/budget [get]
+func (ctrl *ProjectController) GetBudget(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	budget, err := ctrl.projectService.GetBudget(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get budget: " + err.Error()})
+		return
+	}
+	if budget == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project has no budget"})
+		return
+	}
+	c.JSON(http.StatusOK, budget)
+}
+
+// UpdateBudget saves the project's monthly budget in place (no new snapshot).
+// @Summary      Update budget
+// @Description  Save the monthly budget in USD. Each new version and each version cost estimate is compared against it and the comparison is recorded with the project pricing. With action "block", versions over budget are refused with 409; with "flag" (default) they are saved and the overages reported. Later versions inherit it.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string          true  "Project ID"
+// @Param        budget  body      pricing.Budget  true  "Budget"
+// @Success      200     {object}  pricing.Budget
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /projects/{id}/budget [put]
+func (ctrl *ProjectController) UpdateBudget(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req pricing.Budget
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	saved, err := ctrl.projectService.UpdateBudget(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to update budget: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteBudget removes the project's budget.
+// @Summary      Remove budget
+// @Description  Remove the budget so versions are no longer compared against it.
+// @Tags         projects
+// @Param        id   path      string  true  "Project ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /projects/{id}/budget [delete]
+func (ctrl *ProjectController) DeleteBudget(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := ctrl.projectService.UpdateBudget(c.Request.Context(), id, nil); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to remove budget: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // ── Architecture (read-only) ──────────────────────────────────────────────────
 
 // GetArchitecture retrieves a project's latest architecture (read-only).
@@ -296,7 +534,7 @@ func (ctrl *ProjectController) GetArchitecture(c *gin.Context) {
 	}
 	arch, err := ctrl.projectService.GetArchitecture(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get architecture: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get architecture: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, arch)
@@ -306,7 +544,7 @@ func (ctrl *ProjectController) GetArchitecture(c *gin.Context) {
 
 // CreateVersion creates a new immutable architecture snapshot (version).
 // @Summary      Create new version
-// @Description  Save a full architecture state as a new immutable snapshot. Returns the version metadata including the new project_id that encodes this snapshot.
+// @Description  Save a full architecture state as a new immutable snapshot. Returns the version metadata including the new project_id that encodes this snapshot. When the project has a budget, the snapshot's monthly estimate is compared against it: the comparison is returned in budget, and a budget with action "block" refuses versions over it with 409.
 // @Tags         versioning
 // @Accept       json
 // @Produce      json
@@ -315,6 +553,7 @@ func (ctrl *ProjectController) GetArchitecture(c *gin.Context) {
 // @Success      201   {object}  serverinterfaces.ProjectVersionDetail
 // @Failure      400   {object}  map[string]interface{}
 // @Failure      404   {object}  map[string]interface{}
+// @Failure      409   {object}  map[string]interface{}
 // @Failure      500   {object}  map[string]interface{}
 // @Router       /projects/{id}/versions [post]
 func (ctrl *ProjectController) CreateVersion(c *gin.Context) {
@@ -329,7 +568,7 @@ func (ctrl *ProjectController) CreateVersion(c *gin.Context) {
 	}
 	detail, err := ctrl.projectService.CreateVersion(c.Request.Context(), id, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create version: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to create version: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, detail)
@@ -352,7 +591,7 @@ func (ctrl *ProjectController) ListVersions(c *gin.Context) {
 	}
 	versions, err := ctrl.projectService.GetVersions(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch versions: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to fetch versions: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, versions)
@@ -376,7 +615,7 @@ func (ctrl *ProjectController) GetLatestVersion(c *gin.Context) {
 	}
 	detail, err := ctrl.projectService.GetLatestVersion(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get latest version: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get latest version: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, detail)
@@ -405,7 +644,7 @@ func (ctrl *ProjectController) GetVersionDetail(c *gin.Context) {
 	}
 	detail, err := ctrl.projectService.GetVersionByID(c.Request.Context(), id, versionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get version: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get version: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, detail)
@@ -436,12 +675,121 @@ func (ctrl *ProjectController) GetVersionArchitecture(c *gin.Context) {
 	// GetVersionDetail already returns the version along with the State field (dto.ArchitectureResponse)
 	detail, err := ctrl.projectService.GetVersionByID(c.Request.Context(), id, versionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get version: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to get version: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, detail.State)
 }
 
+// RestoreVersion rolls the project back to an earlier version.
+// @Summary      Restore version
+// @Description  Creates a new head version whose architecture, variables, outputs and UI state are copied from the specified version. No history is lost: the new version's parent is the current head and its message records the restored version.
+// @Tags         versioning
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                                  true   "Project ID"
+// @Param        version_id  path      string                                  true   "Version to restore"
+// @Param        body        body      serverinterfaces.RestoreVersionRequest  false  "Optional message"
+// @Success      201         {object}  serverinterfaces.ProjectVersionDetail
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/restore [post]
+func (ctrl *ProjectController) RestoreVersion(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	var req serverinterfaces.RestoreVersionRequest
+	_ = c.ShouldBindJSON(&req)
+	detail, err := ctrl.projectService.RestoreVersion(c.Request.Context(), id, versionID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to restore version: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, detail)
+}
+
+// DiffVersions compares two versions of a project.
+// @Summary      Diff versions
+// @Description  Returns the resources added, removed and modified (per configuration field) between two versions, the containment and dependency edges added or removed, and the change in estimated monthly cost. Resources are matched across snapshots through the node IDs they were saved from, then by type and name.
+// @Tags         versioning
+// @Produce      json
+// @Param        id                path      string  true  "Project ID"
+// @Param        version_id        path      string  true  "Version to compare from"
+// @Param        other_version_id  path      string  true  "Version to compare to"
+// @Success      200               {object}  serverinterfaces.VersionDiff
+// @Failure      400               {object}  map[string]interface{}
+// @Failure      500               {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/diff/{other_version_id} [get]
+func (ctrl *ProjectController) DiffVersions(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	fromID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	toID, ok := parseID(c, "other_version_id")
+	if !ok {
+		return
+	}
+	diff, err := ctrl.projectService.DiffVersions(c.Request.Context(), id, fromID, toID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to diff versions: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// MergeVersions merges another version into a version.
+// @Summary      Merge versions
+// @Description  Three-way merges the changes of theirs_version_id into the path version against their common ancestor (the nearest shared parent unless base_version_id is set). Resources are merged by ID per field, dependencies as edge sets, and variables and outputs by name. When fields changed differently on both sides, responds 409 with the conflicts; resend with a resolution per conflict ID to create the merged version as a child of the path version.
+// @Tags         versioning
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                                 true  "Project ID"
+// @Param        version_id  path      string                                 true  "Version to merge into"
+// @Param        request     body      serverinterfaces.MergeVersionsRequest  true  "Version to merge and conflict resolutions"
+// @Success      201         {object}  serverinterfaces.MergeVersionsResult
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      409         {object}  serverinterfaces.MergeVersionsResult
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /projects/{id}/versions/{version_id}/merge [post]
+func (ctrl *ProjectController) MergeVersions(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseID(c, "version_id")
+	if !ok {
+		return
+	}
+	var req serverinterfaces.MergeVersionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := ctrl.projectService.MergeVersions(c.Request.Context(), id, versionID, &req)
+	if err != nil {
+		if apperrors.IsKind(err, apperrors.KindValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to merge versions: " + err.Error()})
+		return
+	}
+	if result.Version == nil {
+		c.JSON(http.StatusConflict, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
 // DeleteVersion removes a version entry (does not delete the snapshot itself).
 // @Summary      Delete version
 // @Description  Removes a version entry from the chain. The underlying project snapshot is preserved.
@@ -463,7 +811,7 @@ func (ctrl *ProjectController) DeleteVersion(c *gin.Context) {
 		return
 	}
 	if err := ctrl.projectService.DeleteVersion(c.Request.Context(), id, versionID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete version: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to delete version: " + err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -494,7 +842,7 @@ func (ctrl *ProjectController) ValidateVersion(c *gin.Context) {
 	}
 	res, err := ctrl.projectService.ValidateVersionArchitecture(c.Request.Context(), versionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate: " + err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to validate: " + err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, res)
@@ -511,6 +859,39 @@ func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
 	return id, true
 }
 
+// callerID returns the authenticated user's ID set by middleware.AuthRequired.
+func callerID(c *gin.Context) (uuid.UUID, bool) {
+	if v, ok := c.Get(middleware.UserIDKey); ok {
+		if id, ok := v.(uuid.UUID); ok && id != uuid.Nil {
+			return id, true
+		}
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+	return uuid.Nil, false
+}
+
+// errorStatus maps a service error to its HTTP status. Errors of unknown kind are
+// reported as internal errors.
+func errorStatus(err error) int {
+	appErr := apperrors.AsAppError(err)
+	if appErr == nil {
+		return http.StatusInternalServerError
+	}
+	switch appErr.Kind {
+	case apperrors.KindValidation:
+		return http.StatusBadRequest
+	case apperrors.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperrors.KindForbidden:
+		return http.StatusForbidden
+	case apperrors.KindNotFound:
+		return http.StatusNotFound
+	case apperrors.KindConflict:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
 func projectToResponse(p *models.Project) response.ProjectResponse {
 	return response.ProjectResponse{
 		ID:            p.ID.String(),
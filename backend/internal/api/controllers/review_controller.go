@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/request"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto/response"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// ReviewController handles the reviews of marketplace templates
+type ReviewController struct {
+	reviewService serverinterfaces.ReviewService
+}
+
+// NewReviewController creates a new review controller
+func NewReviewController(reviewService serverinterfaces.ReviewService) *ReviewController {
+	return &ReviewController{reviewService: reviewService}
+}
+
+// ListReviews lists the reviews of a template
+// @Summary      List template reviews
+// @Description  List the reviews of a marketplace template, verified deployments first and then newest first. Reviews hidden by moderation are left out.
+// @Tags         marketplace
+// @Produce      json
+// @Param        id     path      string  true   "Template ID"
+// @Param        page   query     int     false  "Page number"  default(1)
+// @Param        limit  query     int     false  "Page size (max 100)"  default(20)
+// @Success      200    {object}  response.ReviewListResponse
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /templates/{id}/reviews [get]
+func (ctrl *ReviewController) ListReviews(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var query struct {
+		Page  int `form:"page,default=1"`
+		Limit int `form:"limit,default=20"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+
+	reviews, total, err := ctrl.reviewService.List(c.Request.Context(), id, query.Limit, (query.Page-1)*query.Limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to list reviews: " + err.Error()})
+		return
+	}
+
+	resp := response.ReviewListResponse{
+		Reviews: make([]response.ReviewResponse, 0, len(reviews)),
+		Total:   total,
+		Page:    query.Page,
+		Limit:   query.Limit,
+	}
+	for _, r := range reviews {
+		resp.Reviews = append(resp.Reviews, reviewToResponse(r))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateReview reviews a template
+// @Summary      Review a template
+// @Description  Review a marketplace template as the caller. A user reviews a template once, and only after creating a project from it; the review is marked as a verified deployment once the user generated code from such a project. The template's rating and review count are recalculated.
+// @Tags         marketplace
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                       true  "Template ID"
+// @Param        review  body      request.CreateReviewRequest  true  "Review"
+// @Success      201     {object}  response.ReviewResponse
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      401     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]interface{}
+// @Failure      409     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /templates/{id}/reviews [post]
+func (ctrl *ReviewController) CreateReview(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req request.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	review, err := ctrl.reviewService.Create(c.Request.Context(), userID, id, &serverinterfaces.ReviewRequest{
+		Rating:         &req.Rating,
+		Title:          &req.Title,
+		Content:        &req.Content,
+		UseCase:        req.UseCase,
+		TeamSize:       req.TeamSize,
+		DeploymentTime: req.DeploymentTime,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to create review: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, reviewToResponse(review))
+}
+
+// UpdateReview edits a review
+// @Summary      Edit a review
+// @Description  Edit one of the caller's template reviews. Omitted fields are left unchanged. The template's rating is recalculated.
+// @Tags         marketplace
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                       true  "Template ID"
+// @Param        review_id  path      string                       true  "Review ID"
+// @Param        review     body      request.UpdateReviewRequest  true  "Fields to change"
+// @Success      200        {object}  response.ReviewResponse
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      401        {object}  map[string]interface{}
+// @Failure      403        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /templates/{id}/reviews/{review_id} [put]
+func (ctrl *ReviewController) UpdateReview(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	reviewID, ok := parseID(c, "review_id")
+	if !ok {
+		return
+	}
+	var req request.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	review, err := ctrl.reviewService.Update(c.Request.Context(), userID, id, reviewID, &serverinterfaces.ReviewRequest{
+		Rating:         req.Rating,
+		Title:          req.Title,
+		Content:        req.Content,
+		UseCase:        req.UseCase,
+		TeamSize:       req.TeamSize,
+		DeploymentTime: req.DeploymentTime,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to update review: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reviewToResponse(review))
+}
+
+// DeleteReview deletes a review
+// @Summary      Delete a review
+// @Description  Delete one of the caller's template reviews. The template's rating and review count are recalculated.
+// @Tags         marketplace
+// @Param        id         path  string  true  "Template ID"
+// @Param        review_id  path  string  true  "Review ID"
+// @Success      204
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      401        {object}  map[string]interface{}
+// @Failure      403        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /templates/{id}/reviews/{review_id} [delete]
+func (ctrl *ReviewController) DeleteReview(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	reviewID, ok := parseID(c, "review_id")
+	if !ok {
+		return
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.reviewService.Delete(c.Request.Context(), userID, id, reviewID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to delete review: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// FlagReview flags a review for moderation
+// @Summary      Flag a review
+// @Description  Flag another user's template review for moderation. Each user flags a review once; a review flagged by 3 users is hidden and no longer counts towards the template's rating.
+// @Tags         marketplace
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                     true   "Template ID"
+// @Param        review_id  path      string                     true   "Review ID"
+// @Param        flag       body      request.FlagReviewRequest  false  "Reason"
+// @Success      200        {object}  response.ReviewResponse
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      401        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      409        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /templates/{id}/reviews/{review_id}/flag [post]
+func (ctrl *ReviewController) FlagReview(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	reviewID, ok := parseID(c, "review_id")
+	if !ok {
+		return
+	}
+	var req request.FlagReviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	userID, ok := callerID(c)
+	if !ok {
+		return
+	}
+
+	review, err := ctrl.reviewService.Flag(c.Request.Context(), userID, id, reviewID, req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": "Failed to flag review: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reviewToResponse(review))
+}
+
+func reviewToResponse(r *models.Review) response.ReviewResponse {
+	return response.ReviewResponse{
+		ID:         r.ID.String(),
+		TemplateID: r.TemplateID.String(),
+		Author: response.TemplateAuthorResponse{
+			ID:     r.UserID.String(),
+			Name:   r.User.Name,
+			Avatar: r.User.Avatar,
+		},
+		Rating:             r.Rating,
+		Title:              r.Title,
+		Content:            r.Content,
+		UseCase:            r.UseCase,
+		TeamSize:           r.TeamSize,
+		DeploymentTime:     r.DeploymentTime,
+		HelpfulCount:       r.HelpfulCount,
+		CreatorResponse:    r.CreatorResponse,
+		VerifiedDeployment: r.VerifiedDeployment,
+		FlagCount:          r.FlagCount,
+		Hidden:             r.Hidden,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+}
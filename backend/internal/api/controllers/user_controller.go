@@ -43,7 +43,7 @@ func (ctrl *UserController) CreateUser(c *gin.Context) {
 	svcReq := &serverinterfaces.CreateUserRequest{
 		Name:      req.Name,
 		Email:     req.Email,
-		Auth0ID:   req.Auth0ID,
+		Password:  req.Password,
 		AvatarURL: req.AvatarURL,
 	}
 
@@ -69,12 +69,14 @@ func (ctrl *UserController) CreateUser(c *gin.Context) {
 
 // GetUser retrieves a user by ID
 // @Summary      Get a user
-// @Description  Get a user by ID
+// @Description  Get the authenticated user by ID; other users' profiles are forbidden
 // @Tags         users
 // @Produce      json
 // @Param        id   path      string  true  "User ID"
 // @Success      200  {object}  response.UserResponse
 // @Failure      400  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
 // @Failure      404  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /users/{id} [get]
@@ -85,6 +87,12 @@ func (ctrl *UserController) GetUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
+	if caller, ok := callerID(c); !ok {
+		return
+	} else if caller != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot read another user's profile"})
+		return
+	}
 
 	user, err := ctrl.userService.GetByID(c.Request.Context(), id)
 	if err != nil {
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/middleware"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockUserService keeps users and their passwords in memory and records creation requests
+type mockUserService struct {
+	users     map[uuid.UUID]*models.User
+	passwords map[uuid.UUID]string
+	created   []*serverinterfaces.CreateUserRequest
+}
+
+func (m *mockUserService) Create(ctx context.Context, req *serverinterfaces.CreateUserRequest) (*models.User, error) {
+	m.created = append(m.created, req)
+	user := &models.User{ID: uuid.New(), Name: req.Name, Email: req.Email}
+	m.users[user.ID] = user
+	return user, nil
+}
+
+func (m *mockUserService) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return m.users[id], nil
+}
+
+func (m *mockUserService) Authenticate(ctx context.Context, email, password string) (*models.User, error) {
+	for _, user := range m.users {
+		if user.Email == email && m.passwords[user.ID] == password {
+			return user, nil
+		}
+	}
+	return nil, platformerrors.NewAuthInvalidCredentials()
+}
+
+// setupUserRouter serves the user routes, authenticating requests as caller
+func setupUserRouter(svc *mockUserService, caller uuid.UUID) *gin.Engine {
+	r := gin.Default()
+	ctrl := NewUserController(svc)
+	r.POST("/users", ctrl.CreateUser)
+	r.GET("/users/:id", func(c *gin.Context) { c.Set(middleware.UserIDKey, caller) }, ctrl.GetUser)
+	return r
+}
+
+func TestUserController_GetUserOnlyServesCaller(t *testing.T) {
+	caller := &models.User{ID: uuid.New(), Name: "Ada", Email: "ada@example.com"}
+	other := &models.User{ID: uuid.New(), Name: "Bob", Email: "bob@example.com"}
+	svc := &mockUserService{users: map[uuid.UUID]*models.User{caller.ID: caller, other.ID: other}}
+	r := setupUserRouter(svc, caller.ID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/"+caller.ID.String(), nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "ada@example.com")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/users/"+other.ID.String(), nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "bob@example.com")
+}
+
+func TestUserController_CreateUserIgnoresAuth0ID(t *testing.T) {
+	svc := &mockUserService{users: map[uuid.UUID]*models.User{}}
+	r := setupUserRouter(svc, uuid.Nil)
+
+	w := httptest.NewRecorder()
+	body := `{"name": "Ada", "email": "ada@example.com", "auth0_id": "google-oauth2|123"}`
+	req, _ := http.NewRequest("POST", "/users", bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "a password is required")
+
+	w = httptest.NewRecorder()
+	body = `{"name": "Ada", "email": "ada@example.com", "auth0_id": "google-oauth2|123", "password": "correct-horse"}`
+	req, _ = http.NewRequest("POST", "/users", bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if assert.Len(t, svc.created, 1) {
+		assert.Empty(t, svc.created[0].Auth0ID)
+		assert.Equal(t, "correct-horse", svc.created[0].Password)
+	}
+}
@@ -54,11 +54,12 @@ type ArchitectureNodeData struct {
 
 // ArchitectureEdge represents a connection between nodes
 type ArchitectureEdge struct {
-	ID     string `json:"id"`
-	Source string `json:"source"`
-	Target string `json:"target"`
-	Type   string `json:"type"` // "contains" or "depends_on" (or default for generic)
-	Label  string `json:"label,omitempty"`
+	ID                string  `json:"id"`
+	Source            string  `json:"source"`
+	Target            string  `json:"target"`
+	Type              string  `json:"type"` // "contains" or "depends_on" (or default for generic)
+	Label             string  `json:"label,omitempty"`
+	TrafficGBPerMonth float64 `json:"traffic_gb_per_month,omitempty"` // data sent source → target, priced by cost estimates
 }
 
 // ArchitectureVariable represents an input variable
@@ -16,7 +16,57 @@ type GenerationOptions struct {
 	Format           string `json:"format"` // hcl, json, yaml
 	IncludeOutputs   bool   `json:"includeOutputs"`
 	IncludeVariables bool   `json:"includeVariables"`
-	Modularity       string `json:"modularity"` // low, medium, high
+	Modularity       string `json:"modularity"` // low, medium, high (high writes each VPC as a local module)
+
+	// Backend overrides the project's saved state backend for this generation only.
+	Backend *StateBackendConfig `json:"backend,omitempty"`
+}
+
+// ModularityHigh splits each network subtree into its own local module.
+const ModularityHigh = "high"
+
+// StateBackendConfig is where generated Terraform keeps its state (rendered to backend.tf).
+// Only the settings block matching Type is used.
+type StateBackendConfig struct {
+	Type  string              `json:"type" binding:"required,oneof=s3 local http" example:"s3"`
+	S3    *S3BackendConfig    `json:"s3,omitempty"`
+	Local *LocalBackendConfig `json:"local,omitempty"`
+	HTTP  *HTTPBackendConfig  `json:"http,omitempty"`
+	// Bootstrap also generates bootstrap/ with the state bucket and lock table (s3 only).
+	Bootstrap bool `json:"bootstrap"`
+}
+
+// S3BackendConfig stores state in S3 with optional DynamoDB locking.
+type S3BackendConfig struct {
+	Bucket             string `json:"bucket" example:"acme-terraform-state"`
+	Key                string `json:"key" example:"network/terraform.tfstate"`
+	Region             string `json:"region,omitempty" example:"us-east-1"` // defaults to the project region
+	DynamoDBTable      string `json:"dynamodb_table,omitempty" example:"acme-terraform-locks"`
+	Encrypt            bool   `json:"encrypt"`
+	WorkspaceKeyPrefix string `json:"workspace_key_prefix,omitempty"`
+}
+
+// LocalBackendConfig stores state on disk.
+type LocalBackendConfig struct {
+	Path string `json:"path,omitempty" example:"terraform.tfstate"`
+}
+
+// HTTPBackendConfig stores state behind a REST endpoint. The password is never stored;
+// supply it at init time through TF_HTTP_PASSWORD.
+type HTTPBackendConfig struct {
+	Address       string `json:"address"`
+	LockAddress   string `json:"lock_address,omitempty"`
+	UnlockAddress string `json:"unlock_address,omitempty"`
+	LockMethod    string `json:"lock_method,omitempty"`
+	UnlockMethod  string `json:"unlock_method,omitempty"`
+	Username      string `json:"username,omitempty"`
+}
+
+// ProjectBackendResponse is returned by the project backend endpoints.
+// A null backend means the project uses local state.
+type ProjectBackendResponse struct {
+	ProjectID uuid.UUID           `json:"projectId"`
+	Backend   *StateBackendConfig `json:"backend"`
 }
 
 // GeneratedFileResponse represents a single file in the response
@@ -0,0 +1,7 @@
+package request
+
+// LoginRequest represents the credentials exchanged for an access token.
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
@@ -0,0 +1,18 @@
+package request
+
+// CreateGuardrailRequest represents the request payload for creating a guardrail.
+type CreateGuardrailRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source" binding:"required"`
+	Enabled     *bool  `json:"enabled,omitempty"`
+}
+
+// UpdateGuardrailRequest represents the request payload for updating a guardrail.
+// Omitted fields are left unchanged.
+type UpdateGuardrailRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=255"`
+	Description *string `json:"description,omitempty"`
+	Source      *string `json:"source,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+}
@@ -6,7 +6,6 @@ type CreateProjectRequest struct {
 	CloudProvider string `json:"cloud_provider" binding:"required,oneof=aws azure gcp"`
 	Region        string `json:"region" binding:"required"`
 	IACToolID     uint   `json:"iac_tool_id" binding:"required,min=1"`
-	UserID        string `json:"user_id"` // Temporary for testing without auth
 }
 
 // UpdateProjectRequest represents the request payload for updating an existing project.
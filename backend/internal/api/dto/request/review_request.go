@@ -0,0 +1,27 @@
+package request
+
+// CreateReviewRequest represents the request payload for reviewing a template.
+type CreateReviewRequest struct {
+	Rating         int     `json:"rating" binding:"required,min=1,max=5"`
+	Title          string  `json:"title" binding:"required,max=255"`
+	Content        string  `json:"content" binding:"required"`
+	UseCase        *string `json:"use_case,omitempty" binding:"omitempty,max=255"`
+	TeamSize       *string `json:"team_size,omitempty" binding:"omitempty,max=50"`
+	DeploymentTime *string `json:"deployment_time,omitempty" binding:"omitempty,max=50"`
+}
+
+// UpdateReviewRequest represents the request payload for editing a review.
+// Omitted fields are left unchanged.
+type UpdateReviewRequest struct {
+	Rating         *int    `json:"rating,omitempty" binding:"omitempty,min=1,max=5"`
+	Title          *string `json:"title,omitempty" binding:"omitempty,max=255"`
+	Content        *string `json:"content,omitempty"`
+	UseCase        *string `json:"use_case,omitempty" binding:"omitempty,max=255"`
+	TeamSize       *string `json:"team_size,omitempty" binding:"omitempty,max=50"`
+	DeploymentTime *string `json:"deployment_time,omitempty" binding:"omitempty,max=50"`
+}
+
+// FlagReviewRequest represents the request payload for flagging a review for moderation.
+type FlagReviewRequest struct {
+	Reason *string `json:"reason,omitempty" binding:"omitempty,max=1000"`
+}
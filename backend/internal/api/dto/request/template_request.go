@@ -0,0 +1,42 @@
+package request
+
+// PublishTemplateRequest represents the request payload for publishing a project version
+// as a marketplace template. Category, technologies, compliance standards and IaC formats
+// are slugs of existing entries.
+type PublishTemplateRequest struct {
+	Title               string                   `json:"title" binding:"required,max=255"`
+	Description         string                   `json:"description" binding:"required"`
+	Category            string                   `json:"category" binding:"required"`
+	Technologies        []string                 `json:"technologies,omitempty"`
+	ComplianceStandards []string                 `json:"compliance_standards,omitempty"`
+	IACFormats          []string                 `json:"iac_formats,omitempty"`
+	Features            []string                 `json:"features,omitempty"`
+	UseCases            []TemplateUseCaseRequest `json:"use_cases,omitempty" binding:"dive"`
+	ImageURL            *string                  `json:"image_url,omitempty" binding:"omitempty,max=500"`
+	DeploymentTime      *string                  `json:"deployment_time,omitempty" binding:"omitempty,max=50"`
+	// Parameters describe or restrict the parameters derived from the version
+	Parameters []TemplateParameterRequest `json:"parameters,omitempty" binding:"dive"`
+}
+
+// TemplateUseCaseRequest represents a use case of a template to publish.
+type TemplateUseCaseRequest struct {
+	Title       string  `json:"title" binding:"required,max=255"`
+	Description *string `json:"description,omitempty"`
+	Icon        *string `json:"icon,omitempty" binding:"omitempty,max=100"`
+}
+
+// TemplateParameterRequest describes a parameter of a template to publish. Options, when
+// set, are the only values accepted on instantiation.
+type TemplateParameterRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description *string  `json:"description,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// InstantiateTemplateRequest represents the request payload for creating a project from a template.
+type InstantiateTemplateRequest struct {
+	Name      string `json:"name" binding:"required,min=3,max=100"`
+	IACToolID uint   `json:"iac_tool_id" binding:"required,min=1"`
+	// Parameters holds the chosen parameter values by name; parameters left out take their default
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
@@ -4,7 +4,7 @@ package request
 type CreateUserRequest struct {
 	Name      string `json:"name" binding:"required,min=2,max=100"`
 	Email     string `json:"email" binding:"required,email"`
-	Auth0ID   string `json:"auth0_id" binding:"required"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
 	AvatarURL string `json:"avatar_url,omitempty" binding:"omitempty,url"`
 }
 
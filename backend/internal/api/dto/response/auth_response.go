@@ -0,0 +1,8 @@
+package response
+
+// TokenResponse represents an issued access token.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+// GuardrailResponse represents a user's guardrail returned to the client.
+type GuardrailResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Source      string    `json:"source"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// InstanceGuardrailResponse represents an instance-wide guardrail, which applies to
+// every user and cannot be changed through the API.
+type InstanceGuardrailResponse struct {
+	Name     string   `json:"name"`
+	Source   string   `json:"source"`
+	Policies []string `json:"policies"`
+}
+
+// GuardrailListResponse lists the caller's guardrails and the instance-wide ones.
+type GuardrailListResponse struct {
+	Guardrails []GuardrailResponse         `json:"guardrails"`
+	Instance   []InstanceGuardrailResponse `json:"instance"`
+}
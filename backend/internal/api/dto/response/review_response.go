@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+// ReviewResponse represents a review of a marketplace template.
+type ReviewResponse struct {
+	ID                 string                 `json:"id"`
+	TemplateID         string                 `json:"template_id"`
+	Author             TemplateAuthorResponse `json:"author"`
+	Rating             int                    `json:"rating"`
+	Title              string                 `json:"title"`
+	Content            string                 `json:"content"`
+	UseCase            *string                `json:"use_case,omitempty"`
+	TeamSize           *string                `json:"team_size,omitempty"`
+	DeploymentTime     *string                `json:"deployment_time,omitempty"`
+	HelpfulCount       int                    `json:"helpful_count"`
+	CreatorResponse    *string                `json:"creator_response,omitempty"`
+	VerifiedDeployment bool                   `json:"verified_deployment"`
+	FlagCount          int                    `json:"flag_count"`
+	Hidden             bool                   `json:"hidden"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+}
+
+// ReviewListResponse represents a page of template reviews.
+type ReviewListResponse struct {
+	Reviews []ReviewResponse `json:"reviews"`
+	Total   int64            `json:"total"`
+	Page    int              `json:"page"`
+	Limit   int              `json:"limit"`
+}
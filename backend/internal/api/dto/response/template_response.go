@@ -0,0 +1,91 @@
+package response
+
+import "time"
+
+// TemplateTagResponse represents a category, technology, compliance standard or IaC format of a template.
+type TemplateTagResponse struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// TemplateAuthorResponse represents the author of a template.
+type TemplateAuthorResponse struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Avatar *string `json:"avatar,omitempty"`
+}
+
+// TemplateSummaryResponse represents a marketplace template in search results.
+type TemplateSummaryResponse struct {
+	ID                  string                `json:"id"`
+	Title               string                `json:"title"`
+	Description         string                `json:"description"`
+	Category            TemplateTagResponse   `json:"category"`
+	CloudProvider       string                `json:"cloud_provider"`
+	Rating              float64               `json:"rating"`
+	ReviewCount         int                   `json:"review_count"`
+	Downloads           int                   `json:"downloads"`
+	Price               float64               `json:"price"`
+	EstimatedCostMin    float64               `json:"estimated_cost_min"`
+	EstimatedCostMax    float64               `json:"estimated_cost_max"`
+	ImageURL            *string               `json:"image_url,omitempty"`
+	IsPopular           bool                  `json:"is_popular"`
+	IsNew               bool                  `json:"is_new"`
+	Resources           int                   `json:"resources"`
+	DeploymentTime      *string               `json:"deployment_time,omitempty"`
+	Regions             *string               `json:"regions,omitempty"`
+	Technologies        []TemplateTagResponse `json:"technologies"`
+	ComplianceStandards []TemplateTagResponse `json:"compliance_standards"`
+	IACFormats          []TemplateTagResponse `json:"iac_formats"`
+	LastUpdated         time.Time             `json:"last_updated"`
+	CreatedAt           time.Time             `json:"created_at"`
+}
+
+// TemplateUseCaseResponse represents a use case of a template.
+type TemplateUseCaseResponse struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+}
+
+// TemplateComponentResponse represents a resource of a template with its monthly on-demand cost.
+type TemplateComponentResponse struct {
+	Name          string  `json:"name"`
+	Service       string  `json:"service"`
+	Configuration *string `json:"configuration,omitempty"`
+	MonthlyCost   float64 `json:"monthly_cost"`
+	Purpose       *string `json:"purpose,omitempty"`
+}
+
+// TemplateParameterResponse represents an input chosen when a template is instantiated.
+type TemplateParameterResponse struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Options     []string    `json:"options,omitempty"`
+	Min         *int        `json:"min,omitempty"`
+	Max         *int        `json:"max,omitempty"`
+	Required    bool        `json:"required"`
+	Sensitive   bool        `json:"sensitive"`
+}
+
+// TemplateDetailResponse represents a marketplace template with everything shown on its page.
+type TemplateDetailResponse struct {
+	TemplateSummaryResponse
+	Author          TemplateAuthorResponse      `json:"author"`
+	SourceVersionID *string                     `json:"source_version_id,omitempty"`
+	Instantiable    bool                        `json:"instantiable"`
+	Features        []string                    `json:"features"`
+	UseCases        []TemplateUseCaseResponse   `json:"use_cases"`
+	Components      []TemplateComponentResponse `json:"components"`
+	Parameters      []TemplateParameterResponse `json:"parameters"`
+}
+
+// TemplateListResponse represents a page of marketplace templates.
+type TemplateListResponse struct {
+	Templates []TemplateSummaryResponse `json:"templates"`
+	Total     int64                     `json:"total"`
+	Page      int                       `json:"page"`
+	Limit     int                       `json:"limit"`
+}
@@ -1,15 +1,20 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
 )
 
-// AuthRequired is a placeholder middleware that checks for the Authorization header.
-// In a real application, this would validate a JWT token.
-func AuthRequired() gin.HandlerFunc {
+// UserIDKey is the gin context key holding the authenticated user's ID.
+const UserIDKey = "user_id"
+
+// AuthRequired verifies the bearer token of each request and stores the authenticated
+// user's ID in the gin context and in the request context, where services pick it up.
+func AuthRequired(authenticator *auth.Authenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -25,13 +30,25 @@ func AuthRequired() gin.HandlerFunc {
 			return
 		}
 
-		// Token validation logic would go here.
-		// For now, we trust any bearer token.
-		token := parts[1]
+		claims, err := authenticator.Verify(parts[1])
+		if err != nil {
+			msg := "Invalid token"
+			if errors.Is(err, auth.ErrExpiredToken) {
+				msg = "Token expired"
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+			c.Abort()
+			return
+		}
+		userID, err := claims.UserID()
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
 
-		// Extract user info from token (mock)
-		// c.Set("user_id", "extracted-uuid")
-		_ = token
+		c.Set(UserIDKey, userID)
+		c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), userID))
 
 		c.Next()
 	}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAuthenticator(t *testing.T, secret string) *auth.Authenticator {
+	t.Helper()
+	a, err := auth.NewAuthenticator(config.AuthConfig{JWTSecret: secret, Issuer: "arch-visualizer", Audience: "arch-visualizer-api", TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	return a
+}
+
+// setupAuthRouter serves a protected route answering with the user IDs the middleware
+// stored in the gin context and in the request context
+func setupAuthRouter(authenticator *auth.Authenticator) *gin.Engine {
+	r := gin.New()
+	r.GET("/protected", AuthRequired(authenticator), func(c *gin.Context) {
+		fromRequest, _ := auth.UserIDFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"user_id": c.MustGet(UserIDKey), "request_user_id": fromRequest})
+	})
+	return r
+}
+
+func TestAuthRequired(t *testing.T) {
+	authenticator := testAuthenticator(t, "test-secret")
+	r := setupAuthRouter(authenticator)
+	userID := uuid.New()
+	valid, _, err := authenticator.Issue(userID, "ada@example.com")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	forged, _, err := testAuthenticator(t, "other-secret").Issue(userID, "ada@example.com")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"missing token", "", http.StatusUnauthorized, "Authorization header required"},
+		{"not a bearer token", "Basic " + valid, http.StatusUnauthorized, "Invalid authorization header format"},
+		{"malformed token", "Bearer not-a-jwt", http.StatusUnauthorized, "Invalid token"},
+		{"token signed with another key", "Bearer " + forged, http.StatusUnauthorized, "Invalid token"},
+		{"valid token", "Bearer " + valid, http.StatusOK, `{"request_user_id":"` + userID.String() + `","user_id":"` + userID.String() + `"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/protected", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
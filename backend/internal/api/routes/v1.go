@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/controllers"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/middleware"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server"
 )
 
@@ -26,30 +27,58 @@ func setupV1Routes(api *gin.RouterGroup, srv *server.Server) {
 		// Controllers
 		projectCtrl := controllers.NewProjectController(srv.ProjectService)
 		userCtrl := controllers.NewUserController(srv.UserService)
+		authCtrl := controllers.NewAuthController(srv.UserService, srv.Authenticator)
 		diagramCtrl := controllers.NewDiagramController(srv.PipelineOrchestrator, srv.DiagramService, srv.ArchitectureService, slog.Default())
 		iamCtrl := controllers.NewIAMController(srv.IAMService)
 		generationCtrl := controllers.NewGenerationController(srv.PipelineOrchestrator, slog.Default())
+		importCtrl := controllers.NewImportController(srv.ImportService)
+		driftCtrl := controllers.NewDriftController(srv.DriftService)
+		networkCtrl := controllers.NewNetworkController(srv.NetworkService)
+		guardrailCtrl := controllers.NewGuardrailController(srv.GuardrailService)
+		complianceCtrl := controllers.NewComplianceController(srv.ComplianceService)
+		marketplaceCtrl := controllers.NewMarketplaceController(srv.MarketplaceService)
+		reviewCtrl := controllers.NewReviewController(srv.ReviewService)
 
 		// Cost Controller
 		costCtrl := controllers.NewCostController(srv.PricingService, srv.ProjectService, srv.OptimizationService)
 
+		// Authentication: every route below that uses requireAuth acts on behalf of the
+		// token's user rather than a user_id supplied by the client.
+		requireAuth := middleware.AuthRequired(srv.Authenticator)
+
+		// Auth Routes
+		authGroup := v1.Group("/auth")
+		{
+			authGroup.POST("/login", authCtrl.Login)
+		}
+
 		// Users Routes
 		users := v1.Group("/users")
 		{
 			users.POST("", userCtrl.CreateUser)
-			users.GET("/:id", userCtrl.GetUser)
-			users.GET("/:id/projects", projectCtrl.ListUserProjects)
+			users.GET("/:id", requireAuth, userCtrl.GetUser)
+			users.GET("/:id/projects", requireAuth, projectCtrl.ListUserProjects)
 		}
 
 		// Projects Routes
-		projects := v1.Group("/projects")
+		projects := v1.Group("/projects", requireAuth)
 		{
 			projects.POST("", projectCtrl.CreateProject)
 			projects.GET("", projectCtrl.ListProjects)
+			projects.POST("/import/tfstate", importCtrl.ImportState)
 			projects.GET("/:id", projectCtrl.GetProject)
 			projects.PUT("/:id", projectCtrl.UpdateProject)
 			projects.DELETE("/:id", projectCtrl.DeleteProject)
 			projects.POST("/:id/duplicate", projectCtrl.DuplicateProject)
+			projects.GET("/:id/backend", projectCtrl.GetBackend)
+			projects.PUT("/:id/backend", projectCtrl.UpdateBackend)
+			projects.DELETE("/:id/backend", projectCtrl.DeleteBackend)
+			projects.GET("/:id/usage-profile", projectCtrl.GetUsageProfile)
+			projects.PUT("/:id/usage-profile", projectCtrl.UpdateUsageProfile)
+			projects.DELETE("/:id/usage-profile", projectCtrl.DeleteUsageProfile)
+			projects.GET("/:id/budget", projectCtrl.GetBudget)
+			projects.PUT("/:id/budget", projectCtrl.UpdateBudget)
+			projects.DELETE("/:id/budget", projectCtrl.DeleteBudget)
 
 			// Architecture (read-only snapshot lookup)
 			projects.GET("/:id/architecture", projectCtrl.GetArchitecture)
@@ -57,6 +86,10 @@ func setupV1Routes(api *gin.RouterGroup, srv *server.Server) {
 			// Non-version generation and cost endpoints
 			projects.POST("/:id/generate", generationCtrl.GenerateCode)
 			projects.GET("/:id/cost/estimate", costCtrl.GetProjectEstimate)
+			projects.GET("/:id/cost/optimize", costCtrl.GetProjectOptimization)
+
+			// Import existing infrastructure code as a new version
+			projects.POST("/:id/import/terraform", importCtrl.ImportTerraform)
 
 			// ── Version CRUD ──────────────────────────────────────────────
 			versions := projects.Group("/:id/versions")
@@ -66,20 +99,56 @@ func setupV1Routes(api *gin.RouterGroup, srv *server.Server) {
 				versions.GET("/latest", projectCtrl.GetLatestVersion)
 				versions.GET("/:version_id", projectCtrl.GetVersionDetail)
 				versions.GET("/:version_id/architecture", projectCtrl.GetVersionArchitecture)
+				versions.GET("/:version_id/diff/:other_version_id", projectCtrl.DiffVersions)
+				versions.POST("/:version_id/merge", projectCtrl.MergeVersions)
+				versions.POST("/:version_id/restore", projectCtrl.RestoreVersion)
 				versions.DELETE("/:version_id", projectCtrl.DeleteVersion)
 
 				// Version-scoped utility actions
 				versions.POST("/:version_id/validate", projectCtrl.ValidateVersion)
 				versions.POST("/:version_id/export/terraform", generationCtrl.GenerateCodeForVersion)
 				versions.POST("/:version_id/estimate-cost", costCtrl.EstimateVersionCost)
+				versions.GET("/:version_id/cost/purchase-options", costCtrl.ComparePurchaseOptions)
+				versions.POST("/:version_id/cost/forecast", costCtrl.ForecastVersionCost)
+				versions.POST("/:version_id/drift", driftCtrl.DetectDrift)
+				versions.GET("/:version_id/reachability", networkCtrl.AnalyzeReachability)
+				versions.GET("/:version_id/compliance/:standard", complianceCtrl.EvaluateVersion)
+				versions.POST("/:version_id/publish", marketplaceCtrl.PublishVersion)
 			}
 
 			// Code Generation (kept for non-version-scoped download convenience)
 			projects.GET("/:id/download", generationCtrl.DownloadCode)
 		}
 
+		// Guardrails Routes
+		guardrails := v1.Group("/guardrails", requireAuth)
+		{
+			guardrails.GET("", guardrailCtrl.ListGuardrails)
+			guardrails.POST("", guardrailCtrl.CreateGuardrail)
+			guardrails.GET("/:id", guardrailCtrl.GetGuardrail)
+			guardrails.PUT("/:id", guardrailCtrl.UpdateGuardrail)
+			guardrails.DELETE("/:id", guardrailCtrl.DeleteGuardrail)
+		}
+
+		// Compliance Routes
+		v1.GET("/compliance/standards", complianceCtrl.ListStandards)
+
+		// Marketplace Routes: browsing is public, acting on a template requires a user
+		v1.GET("/templates", marketplaceCtrl.ListTemplates)
+		v1.GET("/templates/:id", marketplaceCtrl.GetTemplate)
+		v1.GET("/templates/:id/reviews", reviewCtrl.ListReviews)
+		templates := v1.Group("/templates", requireAuth)
+		{
+			templates.POST("/:id/instantiate", marketplaceCtrl.InstantiateTemplate)
+			templates.GET("/:id/compliance", complianceCtrl.VerifyTemplate)
+			templates.POST("/:id/reviews", reviewCtrl.CreateReview)
+			templates.PUT("/:id/reviews/:review_id", reviewCtrl.UpdateReview)
+			templates.DELETE("/:id/reviews/:review_id", reviewCtrl.DeleteReview)
+			templates.POST("/:id/reviews/:review_id/flag", reviewCtrl.FlagReview)
+		}
+
 		// IAM Routes
-		iam := v1.Group("/iam")
+		iam := v1.Group("/iam", requireAuth)
 		{
 			iam.GET("/policies", iamCtrl.ListPolicies)
 			iam.GET("/policies/between", iamCtrl.ListPoliciesBetweenServices)
@@ -90,7 +159,7 @@ func setupV1Routes(api *gin.RouterGroup, srv *server.Server) {
 		// Diagrams Routes
 		diagrams := v1.Group("/diagrams")
 		{
-			diagrams.POST("/process", diagramCtrl.ProcessDiagram)
+			diagrams.POST("/process", requireAuth, diagramCtrl.ProcessDiagram)
 			diagrams.POST("/validate", diagramCtrl.ValidateDiagram)
 			diagrams.POST("/validate-rules", diagramCtrl.ValidateDomainRules)
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/graph"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
 
@@ -31,6 +32,9 @@ type Architecture struct {
 
 	// Warnings encountered during architecture generation/validation
 	Warnings []Warning
+
+	// Usage is the workload the architecture is priced for; nil means 24/7 with no traffic
+	Usage *pricing.UsageProfile
 }
 
 // Warning represents a non-fatal issue or auto-correction
@@ -0,0 +1,266 @@
+package architecture
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// Edge types reported by Diff, matching the edge types of the architecture API.
+const (
+	EdgeContains  = "contains"
+	EdgeDependsOn = "depends_on"
+)
+
+// Diff is the semantic difference between two architectures.
+type Diff struct {
+	Added    []*resource.Resource // resources only in the newer architecture
+	Removed  []*resource.Resource // resources only in the older architecture
+	Modified []ResourceChange
+
+	// Edges use the IDs of the architecture they belong to: AddedEdges the newer one's,
+	// RemovedEdges the older one's.
+	AddedEdges   []Edge
+	RemovedEdges []Edge
+}
+
+// ResourceChange describes a resource present in both architectures whose fields differ.
+type ResourceChange struct {
+	From    *resource.Resource
+	To      *resource.Resource
+	Changes []FieldChange
+}
+
+// FieldChange is one changed field. Field is "name" or the dotted path of a metadata
+// entry prefixed with "config." (e.g. "config.instanceType", "config.tags.Env").
+// From is nil for added entries and To is nil for removed ones.
+type FieldChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// Edge is a containment (parent -> child) or dependency (dependent -> dependency) edge.
+type Edge struct {
+	Type   string
+	Source string
+	Target string
+}
+
+// IsEmpty reports whether the two architectures were found to be the same.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0
+}
+
+// ignoredMetadata are metadata entries that carry canvas layout or loader bookkeeping
+// rather than configuration. Keys starting with "_" are ignored as well.
+var ignoredMetadata = map[string]bool{
+	"ui":       true,
+	"position": true,
+}
+
+// DiffArchitectures compares two architectures. Resources are paired, in order of
+// preference, through aliases (newer ID -> older ID, for snapshots whose resources were
+// re-keyed when saved), by equal ID, and finally by type and name when that pair is unique.
+func DiffArchitectures(from, to *Architecture, aliases map[string]string) *Diff {
+	toFrom := PairResources(from, to, aliases)
+	fromTo := make(map[string]string, len(toFrom))
+	for t, f := range toFrom {
+		fromTo[f] = t
+	}
+
+	d := &Diff{
+		Added:        []*resource.Resource{},
+		Removed:      []*resource.Resource{},
+		Modified:     []ResourceChange{},
+		AddedEdges:   []Edge{},
+		RemovedEdges: []Edge{},
+	}
+	fromByID := resourcesByID(from)
+	for _, res := range from.Resources {
+		if _, ok := fromTo[res.ID]; !ok {
+			d.Removed = append(d.Removed, res)
+		}
+	}
+	for _, res := range to.Resources {
+		fromID, ok := toFrom[res.ID]
+		if !ok {
+			d.Added = append(d.Added, res)
+			continue
+		}
+		old := fromByID[fromID]
+		if changes := resourceChanges(old, res); len(changes) > 0 {
+			d.Modified = append(d.Modified, ResourceChange{From: old, To: res, Changes: changes})
+		}
+	}
+
+	// Compare edges in the older architecture's ID space.
+	toKey := func(id string) string {
+		if fromID, ok := toFrom[id]; ok {
+			return fromID
+		}
+		return "\x00" + id // unpaired IDs never collide with the older architecture's
+	}
+	oldEdges := make(map[Edge]bool)
+	for _, e := range from.Edges() {
+		oldEdges[e] = true
+	}
+	newEdges := make(map[Edge]bool)
+	for _, e := range to.Edges() {
+		key := Edge{Type: e.Type, Source: toKey(e.Source), Target: toKey(e.Target)}
+		newEdges[key] = true
+		if !oldEdges[key] {
+			d.AddedEdges = append(d.AddedEdges, e)
+		}
+	}
+	for _, e := range from.Edges() {
+		if !newEdges[e] {
+			d.RemovedEdges = append(d.RemovedEdges, e)
+		}
+	}
+	return d
+}
+
+// PairResources matches the resources of two architectures the way DiffArchitectures
+// does and returns newer ID -> older ID for every pair.
+func PairResources(from, to *Architecture, aliases map[string]string) map[string]string {
+	fromByID := resourcesByID(from)
+	pairs := make(map[string]string)
+	taken := make(map[string]bool)
+	pair := func(toID, fromID string) {
+		pairs[toID] = fromID
+		taken[fromID] = true
+	}
+
+	for _, res := range to.Resources {
+		if fromID, ok := aliases[res.ID]; ok {
+			if old, found := fromByID[fromID]; found && !taken[fromID] && old.Type.Name == res.Type.Name {
+				pair(res.ID, fromID)
+			}
+		}
+	}
+	for _, res := range to.Resources {
+		if _, done := pairs[res.ID]; done {
+			continue
+		}
+		if old, found := fromByID[res.ID]; found && !taken[res.ID] && old.Type.Name == res.Type.Name {
+			pair(res.ID, res.ID)
+		}
+	}
+
+	// Type and name, for resources that are unambiguous on both sides.
+	nameKey := func(r *resource.Resource) string { return r.Type.Name + "\x00" + r.Name }
+	oldByName := make(map[string][]string)
+	for _, res := range from.Resources {
+		if !taken[res.ID] {
+			oldByName[nameKey(res)] = append(oldByName[nameKey(res)], res.ID)
+		}
+	}
+	newByName := make(map[string][]string)
+	for _, res := range to.Resources {
+		if _, done := pairs[res.ID]; !done {
+			newByName[nameKey(res)] = append(newByName[nameKey(res)], res.ID)
+		}
+	}
+	for key, ids := range newByName {
+		if old := oldByName[key]; len(ids) == 1 && len(old) == 1 {
+			pair(ids[0], old[0])
+		}
+	}
+	return pairs
+}
+
+// Edges returns the containment and dependency edges of the architecture, sorted.
+func (a *Architecture) Edges() []Edge {
+	var edges []Edge
+	for parent, children := range a.Containments {
+		for _, child := range children {
+			edges = append(edges, Edge{Type: EdgeContains, Source: parent, Target: child})
+		}
+	}
+	for id, deps := range a.Dependencies {
+		for _, dep := range deps {
+			edges = append(edges, Edge{Type: EdgeDependsOn, Source: id, Target: dep})
+		}
+	}
+	sortEdges(edges)
+	return edges
+}
+
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Type != edges[j].Type {
+			return edges[i].Type < edges[j].Type
+		}
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		return edges[i].Target < edges[j].Target
+	})
+}
+
+func resourcesByID(a *Architecture) map[string]*resource.Resource {
+	byID := make(map[string]*resource.Resource, len(a.Resources))
+	for _, res := range a.Resources {
+		byID[res.ID] = res
+	}
+	return byID
+}
+
+// resourceChanges compares the name and configuration of two versions of a resource.
+// Containment and dependencies are compared as edges instead.
+func resourceChanges(from, to *resource.Resource) []FieldChange {
+	var changes []FieldChange
+	if from.Name != to.Name {
+		changes = append(changes, FieldChange{Field: "name", From: from.Name, To: to.Name})
+	}
+	return append(changes, mapChanges("config", ConfigOf(from), ConfigOf(to))...)
+}
+
+// ConfigOf returns the resource metadata without layout and bookkeeping entries.
+func ConfigOf(res *resource.Resource) map[string]interface{} {
+	cfg := make(map[string]interface{}, len(res.Metadata))
+	for k, v := range res.Metadata {
+		if ignoredMetadata[k] || strings.HasPrefix(k, "_") {
+			continue
+		}
+		cfg[k] = v
+	}
+	return cfg
+}
+
+// mapChanges reports differing entries of two maps, descending into nested maps so a
+// changed tag shows as config.tags.<key> rather than as the whole tag map.
+func mapChanges(prefix string, from, to map[string]interface{}) []FieldChange {
+	keys := make(map[string]bool, len(from)+len(to))
+	for k := range from {
+		keys[k] = true
+	}
+	for k := range to {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []FieldChange
+	for _, k := range sorted {
+		path := prefix + "." + k
+		a, b := from[k], to[k]
+		am, aIsMap := a.(map[string]interface{})
+		bm, bIsMap := b.(map[string]interface{})
+		if aIsMap && bIsMap {
+			changes = append(changes, mapChanges(path, am, bm)...)
+			continue
+		}
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, FieldChange{Field: path, From: a, To: b})
+		}
+	}
+	return changes
+}
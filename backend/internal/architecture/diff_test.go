@@ -0,0 +1,94 @@
+package architecture
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestDiffArchitectures(t *testing.T) {
+	from := NewArchitecture()
+	vpc := createTestResource("a-vpc", "main", "VPC")
+	subnet := createTestResource("a-subnet", "public", "Subnet")
+	ec2 := createTestResource("a-ec2", "web", "EC2")
+	ec2.Metadata = map[string]interface{}{
+		"instanceType": "t3.micro",
+		"tags":         map[string]interface{}{"Env": "dev", "Team": "web"},
+		"position":     map[string]interface{}{"x": 10.0, "y": 20.0},
+	}
+	bucket := createTestResource("a-bucket", "logs", "S3")
+	from.Resources = []*resource.Resource{vpc, subnet, ec2, bucket}
+	from.Containments = map[string][]string{"a-vpc": {"a-subnet"}, "a-subnet": {"a-ec2"}}
+	from.Dependencies = map[string][]string{"a-ec2": {"a-bucket"}}
+
+	// The newer snapshot re-keyed its resources: the subnet through an alias,
+	// the VPC and instance by type and name.
+	to := NewArchitecture()
+	vpc2 := createTestResource("b-vpc", "main", "VPC")
+	subnet2 := createTestResource("b-subnet", "public-renamed", "Subnet")
+	ec22 := createTestResource("b-ec2", "web", "EC2")
+	ec22.Metadata = map[string]interface{}{
+		"instanceType": "t3.large",
+		"tags":         map[string]interface{}{"Env": "prod", "Team": "web"},
+		"position":     map[string]interface{}{"x": 300.0, "y": 20.0},
+		"_internal":    true,
+	}
+	rds := createTestResource("b-rds", "db", "RDS")
+	to.Resources = []*resource.Resource{vpc2, subnet2, ec22, rds}
+	to.Containments = map[string][]string{"b-vpc": {"b-subnet", "b-rds"}, "b-subnet": {"b-ec2"}}
+	to.Dependencies = map[string][]string{"b-ec2": {"b-rds"}}
+
+	d := DiffArchitectures(from, to, map[string]string{"b-subnet": "a-subnet"})
+
+	if len(d.Added) != 1 || d.Added[0].ID != "b-rds" {
+		t.Errorf("Added = %v, want b-rds", ids(d.Added))
+	}
+	if len(d.Removed) != 1 || d.Removed[0].ID != "a-bucket" {
+		t.Errorf("Removed = %v, want a-bucket", ids(d.Removed))
+	}
+
+	changes := make(map[string][]FieldChange)
+	for _, m := range d.Modified {
+		changes[m.To.ID] = m.Changes
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Modified = %+v, want the subnet and the instance", d.Modified)
+	}
+	if c := changes["b-subnet"]; len(c) != 1 || c[0].Field != "name" || c[0].To != "public-renamed" {
+		t.Errorf("subnet changes = %+v, want the rename", c)
+	}
+	want := []FieldChange{
+		{Field: "config.instanceType", From: "t3.micro", To: "t3.large"},
+		{Field: "config.tags.Env", From: "dev", To: "prod"},
+	}
+	if c := changes["b-ec2"]; len(c) != len(want) || c[0] != want[0] || c[1] != want[1] {
+		t.Errorf("instance changes = %+v, want %+v (position and internal keys ignored)", c, want)
+	}
+
+	wantAdded := []Edge{
+		{Type: EdgeContains, Source: "b-vpc", Target: "b-rds"},
+		{Type: EdgeDependsOn, Source: "b-ec2", Target: "b-rds"},
+	}
+	if len(d.AddedEdges) != 2 || d.AddedEdges[0] != wantAdded[0] || d.AddedEdges[1] != wantAdded[1] {
+		t.Errorf("AddedEdges = %+v, want %+v", d.AddedEdges, wantAdded)
+	}
+	if len(d.RemovedEdges) != 1 || d.RemovedEdges[0] != (Edge{Type: EdgeDependsOn, Source: "a-ec2", Target: "a-bucket"}) {
+		t.Errorf("RemovedEdges = %+v, want the dependency on the bucket", d.RemovedEdges)
+	}
+}
+
+func TestDiffArchitectures_Identical(t *testing.T) {
+	arch := NewArchitecture()
+	arch.Resources = []*resource.Resource{createTestResource("vpc-1", "main", "VPC")}
+	if d := DiffArchitectures(arch, arch, nil); !d.IsEmpty() {
+		t.Errorf("diff of an architecture with itself = %+v, want empty", d)
+	}
+}
+
+func ids(resources []*resource.Resource) []string {
+	out := make([]string, len(resources))
+	for i, r := range resources {
+		out[i] = r.ID
+	}
+	return out
+}
@@ -0,0 +1,458 @@
+package architecture
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// Merge conflict kinds.
+const (
+	ConflictResource = "resource"
+	ConflictVariable = "variable"
+	ConflictOutput   = "output"
+)
+
+// Sides a conflict can be resolved to.
+const (
+	TakeOurs   = "ours"
+	TakeTheirs = "theirs"
+)
+
+// MergeConflict is a change made differently on both sides of a merge.
+//
+// Field is "name", "parent" or a "config.<path>" for conflicting resource fields, and
+// empty when a whole entry conflicts: a resource, variable or output deleted on one
+// side and changed on the other, or a variable or output changed differently on both.
+// Base, Ours and Theirs are nil where the field or entry is absent.
+type MergeConflict struct {
+	Kind   string
+	Key    string // base resource ID (or the side's own ID for added ones), variable or output name
+	Name   string
+	Field  string
+	Base   interface{}
+	Ours   interface{}
+	Theirs interface{}
+}
+
+// ID identifies the conflict in resolutions: "<kind>/<key>" followed by "/<field>" for
+// field conflicts, e.g. "resource/<id>/config.instanceType" or "variable/region".
+func (c MergeConflict) ID() string {
+	id := c.Kind + "/" + c.Key
+	if c.Field != "" {
+		id += "/" + c.Field
+	}
+	return id
+}
+
+// Resolution settles a conflict by taking one side, or by Value when Take is empty.
+// Whole-entry conflicts can only be resolved by taking a side.
+type Resolution struct {
+	Take  string
+	Value interface{}
+}
+
+// MergeResult is the outcome of a three-way merge. Architecture is nil while
+// conflicts remain unresolved.
+type MergeResult struct {
+	Architecture *Architecture
+	Conflicts    []MergeConflict
+}
+
+// MergeInput is one side of a three-way merge with its resources paired to the base
+// (side ID -> base ID), as returned by PairResources.
+type MergeInput struct {
+	Architecture *Architecture
+	Pairs        map[string]string
+}
+
+// absent marks a map entry, field or parent missing on one side of a merge.
+type absentValue struct{}
+
+var absent = absentValue{}
+
+// MergeArchitectures merges the changes ours and theirs made to base.
+//
+// Resources are merged by their base ID: a field changed on one side takes that side's
+// value and a field changed differently on both is a conflict. Metadata is merged per
+// configuration path, so different tags changed on each side merge cleanly; canvas
+// layout follows ours unless only theirs moved the resource. Dependencies are merged as
+// edge sets and containment follows the merged parents. Variables and outputs are
+// merged by name. Conflicts with an entry in resolutions (keyed by MergeConflict.ID)
+// are settled by it; when any remain, no architecture is returned.
+//
+// Merged resources keep ours' IDs, or theirs' for resources only theirs has.
+func MergeArchitectures(base *Architecture, ours, theirs MergeInput, resolutions map[string]Resolution) *MergeResult {
+	m := &merger{resolutions: resolutions}
+	merged := NewArchitecture()
+	merged.Region = ours.Architecture.Region
+	merged.Provider = ours.Architecture.Provider
+
+	baseByKey := resourcesByID(base)
+	oursByKey := keyedResources(ours)
+	theirsByKey := keyedResources(theirs)
+
+	// Base resources first, then additions, each in their architecture's order.
+	var keys []string
+	seen := make(map[string]bool)
+	addKey := func(k string) {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	for _, res := range base.Resources {
+		addKey(res.ID)
+	}
+	for _, in := range []MergeInput{ours, theirs} {
+		for _, res := range in.Architecture.Resources {
+			addKey(resourceKey(in, res.ID))
+		}
+	}
+
+	// Merge resources in the base key space; parents are rewritten to merged IDs below.
+	outID := make(map[string]string)
+	parentKeys := make(map[string]interface{})
+	var keptKeys []string
+	for _, k := range keys {
+		b, o, t := baseByKey[k], oursByKey[k], theirsByKey[k]
+		res, parent, ok := m.mergeResource(k, b, o, t, ours, theirs)
+		if !ok {
+			continue
+		}
+		outID[k] = res.ID
+		parentKeys[k] = parent
+		keptKeys = append(keptKeys, k)
+		merged.Resources = append(merged.Resources, res)
+	}
+
+	for i, k := range keptKeys {
+		res := merged.Resources[i]
+		res.ParentID = nil
+		if pk, ok := parentKeys[k].(string); ok {
+			if pid, kept := outID[pk]; kept {
+				res.ParentID = &pid
+				merged.Containments[pid] = append(merged.Containments[pid], res.ID)
+			}
+		}
+	}
+
+	// An edge in base survives only if both sides kept it; added edges are kept.
+	baseEdges := keyedDependencies(base, nil)
+	oursEdges := keyedDependencies(ours.Architecture, ours.Pairs)
+	theirsEdges := keyedDependencies(theirs.Architecture, theirs.Pairs)
+	var edges []Edge
+	for _, e := range base.Edges() {
+		if e.Type == EdgeDependsOn && oursEdges[e] && theirsEdges[e] {
+			edges = append(edges, e)
+		}
+	}
+	for _, side := range []map[Edge]bool{oursEdges, theirsEdges} {
+		for e := range side {
+			if !baseEdges[e] {
+				edges = append(edges, e)
+			}
+		}
+	}
+	sortEdges(edges)
+	resByID := resourcesByID(merged)
+	added := make(map[Edge]bool)
+	for _, e := range edges {
+		from, okFrom := outID[e.Source]
+		to, okTo := outID[e.Target]
+		if !okFrom || !okTo {
+			continue
+		}
+		out := Edge{Type: EdgeDependsOn, Source: from, Target: to}
+		if added[out] {
+			continue
+		}
+		added[out] = true
+		merged.Dependencies[from] = append(merged.Dependencies[from], to)
+		resByID[from].DependsOn = append(resByID[from].DependsOn, to)
+	}
+
+	merged.Variables = mergeNamed(m, ConflictVariable, variablesByName(base), variablesByName(ours.Architecture), variablesByName(theirs.Architecture),
+		variableNames(base, ours.Architecture, theirs.Architecture), func(v interface{}) Variable { return v.(Variable) })
+	merged.Outputs = mergeNamed(m, ConflictOutput, outputsByName(base), outputsByName(ours.Architecture), outputsByName(theirs.Architecture),
+		outputNames(base, ours.Architecture, theirs.Architecture), func(v interface{}) Output { return v.(Output) })
+
+	if len(m.conflicts) > 0 {
+		return &MergeResult{Conflicts: m.conflicts}
+	}
+	return &MergeResult{Architecture: merged, Conflicts: []MergeConflict{}}
+}
+
+type merger struct {
+	resolutions map[string]Resolution
+	conflicts   []MergeConflict
+}
+
+// pick merges one value three ways. Values are compared deeply; absent stands for a
+// missing value. Unresolved conflicts are recorded and resolve to ours for now.
+func (m *merger) pick(c MergeConflict, base, ours, theirs interface{}) interface{} {
+	switch {
+	case reflect.DeepEqual(ours, theirs), reflect.DeepEqual(theirs, base):
+		return ours
+	case reflect.DeepEqual(ours, base):
+		return theirs
+	}
+	if r, ok := m.resolutions[c.ID()]; ok {
+		switch r.Take {
+		case TakeOurs:
+			return ours
+		case TakeTheirs:
+			return theirs
+		case "":
+			if c.Field != "" {
+				return r.Value
+			}
+		}
+	}
+	c.Base, c.Ours, c.Theirs = present(base), present(ours), present(theirs)
+	m.conflicts = append(m.conflicts, c)
+	return ours
+}
+
+// mergeResource merges the three versions of one resource (any may be nil) and returns
+// the merged resource with its parent as a base key, or ok false when it is deleted.
+func (m *merger) mergeResource(key string, b, o, t *resource.Resource, ours, theirs MergeInput) (*resource.Resource, interface{}, bool) {
+	switch {
+	case o == nil && t == nil:
+		return nil, nil, false
+	case b == nil && o != nil:
+		return cloneResource(o), parentKey(ours, o), true
+	case b == nil:
+		return cloneResource(t), parentKey(theirs, t), true
+	case o == nil || t == nil:
+		// Deleted on one side: that wins unless the other side changed the resource's
+		// name or configuration.
+		kept, in := o, ours
+		if kept == nil {
+			kept, in = t, theirs
+		}
+		if len(resourceChanges(b, kept)) == 0 {
+			return nil, nil, false
+		}
+		c := MergeConflict{Kind: ConflictResource, Key: key, Name: kept.Name}
+		choice := m.pick(c, resourceSummary(b), summaryOrAbsent(o), summaryOrAbsent(t))
+		if choice == absent {
+			return nil, nil, false
+		}
+		return cloneResource(kept), parentKey(in, kept), true
+	}
+
+	c := MergeConflict{Kind: ConflictResource, Key: key, Name: o.Name}
+	res := cloneResource(o)
+	if name, ok := m.pick(withField(c, "name"), b.Name, o.Name, t.Name).(string); ok {
+		res.Name = name
+	}
+	parent := m.pick(withField(c, "parent"), parentKey(MergeInput{}, b), parentKey(ours, o), parentKey(theirs, t))
+
+	config := m.mergeMaps(c, "config", ConfigOf(b), ConfigOf(o), ConfigOf(t))
+	// Layout and bookkeeping entries are not merged.
+	layout := o
+	if reflect.DeepEqual(layoutOf(o), layoutOf(b)) && !reflect.DeepEqual(layoutOf(t), layoutOf(b)) {
+		layout = t
+	}
+	for k, v := range layoutOf(layout) {
+		config[k] = v
+	}
+	for k, v := range o.Metadata {
+		if strings.HasPrefix(k, "_") {
+			config[k] = v
+		}
+	}
+	res.Metadata = config
+	return res, parent, true
+}
+
+// mergeMaps merges metadata maps per key, descending into maps present on all sides
+// that have them.
+func (m *merger) mergeMaps(c MergeConflict, prefix string, base, ours, theirs map[string]interface{}) map[string]interface{} {
+	keys := make(map[string]bool)
+	for _, side := range []map[string]interface{}{base, ours, theirs} {
+		for k := range side {
+			keys[k] = true
+		}
+	}
+	out := make(map[string]interface{}, len(keys))
+	for _, k := range sortedKeys(keys) {
+		path := prefix + "." + k
+		b, o, t := lookup(base, k), lookup(ours, k), lookup(theirs, k)
+		bm, bIsMap := b.(map[string]interface{})
+		om, oIsMap := o.(map[string]interface{})
+		tm, tIsMap := t.(map[string]interface{})
+		if oIsMap && tIsMap && (bIsMap || b == absent) {
+			out[k] = m.mergeMaps(c, path, bm, om, tm)
+			continue
+		}
+		if v := m.pick(withField(c, path), b, o, t); v != absent {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// mergeNamed merges variables or outputs by name, comparing whole entries.
+func mergeNamed[T any](m *merger, kind string, base, ours, theirs map[string]interface{}, names []string, cast func(interface{}) T) []T {
+	out := make([]T, 0, len(names))
+	for _, name := range names {
+		v := m.pick(MergeConflict{Kind: kind, Key: name, Name: name}, lookup(base, name), lookup(ours, name), lookup(theirs, name))
+		if v != absent {
+			out = append(out, cast(v))
+		}
+	}
+	return out
+}
+
+func withField(c MergeConflict, field string) MergeConflict {
+	c.Field = field
+	return c
+}
+
+func lookup(m map[string]interface{}, k string) interface{} {
+	if v, ok := m[k]; ok {
+		return v
+	}
+	return absent
+}
+
+// present reports absent values as nil.
+func present(v interface{}) interface{} {
+	if v == absent {
+		return nil
+	}
+	return v
+}
+
+// resourceKey maps a side's resource ID into the base key space.
+func resourceKey(in MergeInput, id string) string {
+	if k, ok := in.Pairs[id]; ok {
+		return k
+	}
+	return id
+}
+
+func keyedResources(in MergeInput) map[string]*resource.Resource {
+	byKey := make(map[string]*resource.Resource, len(in.Architecture.Resources))
+	for _, res := range in.Architecture.Resources {
+		byKey[resourceKey(in, res.ID)] = res
+	}
+	return byKey
+}
+
+// parentKey returns the resource's parent as a base key, or absent.
+func parentKey(in MergeInput, res *resource.Resource) interface{} {
+	if res.ParentID == nil {
+		return absent
+	}
+	return resourceKey(in, *res.ParentID)
+}
+
+// keyedDependencies returns the dependency edges of an architecture in the base key space.
+func keyedDependencies(a *Architecture, pairs map[string]string) map[Edge]bool {
+	in := MergeInput{Pairs: pairs}
+	edges := make(map[Edge]bool)
+	for id, deps := range a.Dependencies {
+		for _, dep := range deps {
+			edges[Edge{Type: EdgeDependsOn, Source: resourceKey(in, id), Target: resourceKey(in, dep)}] = true
+		}
+	}
+	return edges
+}
+
+func cloneResource(res *resource.Resource) *resource.Resource {
+	c := *res
+	c.ParentID = nil
+	c.DependsOn = []string{}
+	c.Metadata = make(map[string]interface{}, len(res.Metadata))
+	for k, v := range res.Metadata {
+		c.Metadata[k] = v
+	}
+	return &c
+}
+
+// layoutOf returns the canvas layout entries of a resource's metadata.
+func layoutOf(res *resource.Resource) map[string]interface{} {
+	layout := make(map[string]interface{})
+	for k := range ignoredMetadata {
+		if v, ok := res.Metadata[k]; ok {
+			layout[k] = v
+		}
+	}
+	return layout
+}
+
+// resourceSummary is how a resource appears in a whole-entry conflict.
+func resourceSummary(res *resource.Resource) interface{} {
+	return map[string]interface{}{
+		"name":   res.Name,
+		"type":   res.Type.Name,
+		"config": ConfigOf(res),
+	}
+}
+
+func summaryOrAbsent(res *resource.Resource) interface{} {
+	if res == nil {
+		return absent
+	}
+	return resourceSummary(res)
+}
+
+func variablesByName(a *Architecture) map[string]interface{} {
+	out := make(map[string]interface{}, len(a.Variables))
+	for _, v := range a.Variables {
+		out[v.Name] = v
+	}
+	return out
+}
+
+func outputsByName(a *Architecture) map[string]interface{} {
+	out := make(map[string]interface{}, len(a.Outputs))
+	for _, o := range a.Outputs {
+		out[o.Name] = o
+	}
+	return out
+}
+
+// variableNames lists variable names in order of first appearance in base, ours, theirs.
+func variableNames(archs ...*Architecture) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, a := range archs {
+		for _, v := range a.Variables {
+			if !seen[v.Name] {
+				seen[v.Name] = true
+				names = append(names, v.Name)
+			}
+		}
+	}
+	return names
+}
+
+// outputNames lists output names in order of first appearance in base, ours, theirs.
+func outputNames(archs ...*Architecture) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, a := range archs {
+		for _, o := range a.Outputs {
+			if !seen[o.Name] {
+				seen[o.Name] = true
+				names = append(names, o.Name)
+			}
+		}
+	}
+	return names
+}
+
+func sortedKeys(keys map[string]bool) []string {
+	out := make([]string, 0, len(keys))
+	for k := range keys {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
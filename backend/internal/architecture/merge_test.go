@@ -0,0 +1,152 @@
+package architecture
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// mergeBase is a VPC with a subnet holding an instance that depends on a bucket.
+func mergeBase() *Architecture {
+	a := NewArchitecture()
+	vpc := createTestResource("vpc", "main", "VPC")
+	subnet := createTestResource("subnet", "public", "Subnet")
+	ec2 := createTestResource("ec2", "web", "EC2")
+	ec2.Metadata = map[string]interface{}{
+		"instanceType": "t3.micro",
+		"tags":         map[string]interface{}{"Env": "dev", "Team": "web"},
+	}
+	bucket := createTestResource("bucket", "logs", "S3")
+	a.Resources = []*resource.Resource{vpc, subnet, ec2, bucket}
+	setParent(subnet, "vpc")
+	setParent(ec2, "subnet")
+	a.Containments = map[string][]string{"vpc": {"subnet"}, "subnet": {"ec2"}}
+	a.Dependencies = map[string][]string{"ec2": {"bucket"}}
+	return a
+}
+
+// branch copies mergeBase under new IDs, the way a saved version re-keys resources.
+func branch(prefix string) (*Architecture, map[string]string) {
+	a := mergeBase()
+	pairs := make(map[string]string)
+	rekey := func(id string) string { return prefix + "-" + id }
+	for _, res := range a.Resources {
+		pairs[rekey(res.ID)] = res.ID
+		res.ID = rekey(res.ID)
+		if res.ParentID != nil {
+			setParent(res, rekey(*res.ParentID))
+		}
+	}
+	a.Containments = map[string][]string{rekey("vpc"): {rekey("subnet")}, rekey("subnet"): {rekey("ec2")}}
+	a.Dependencies = map[string][]string{rekey("ec2"): {rekey("bucket")}}
+	return a, pairs
+}
+
+func setParent(res *resource.Resource, parentID string) {
+	res.ParentID = &parentID
+}
+
+func find(a *Architecture, id string) *resource.Resource {
+	for _, res := range a.Resources {
+		if res.ID == id {
+			return res
+		}
+	}
+	return nil
+}
+
+func TestMergeArchitectures(t *testing.T) {
+	base := mergeBase()
+
+	// Ours retags the instance and adds a database it depends on.
+	ours, oursPairs := branch("o")
+	ours.Resources[2].Metadata["tags"] = map[string]interface{}{"Env": "prod", "Team": "web"}
+	rds := createTestResource("o-rds", "db", "RDS")
+	setParent(rds, "o-vpc")
+	ours.Resources = append(ours.Resources, rds)
+	ours.Dependencies["o-ec2"] = append(ours.Dependencies["o-ec2"], "o-rds")
+
+	// Theirs resizes the instance, changes another tag, drops the bucket and adds a variable.
+	theirs, theirsPairs := branch("t")
+	theirs.Resources[2].Metadata["instanceType"] = "t3.large"
+	theirs.Resources[2].Metadata["tags"] = map[string]interface{}{"Env": "dev", "Team": "ops"}
+	theirs.Resources = theirs.Resources[:3]
+	theirs.Dependencies = map[string][]string{}
+	theirs.Variables = []Variable{{Name: "env", Type: "string", Default: "dev"}}
+
+	res := MergeArchitectures(base, MergeInput{ours, oursPairs}, MergeInput{theirs, theirsPairs}, nil)
+	if len(res.Conflicts) != 0 {
+		t.Fatalf("Conflicts = %+v, want none", res.Conflicts)
+	}
+	merged := res.Architecture
+
+	if got := ids(merged.Resources); len(got) != 4 || got[0] != "o-vpc" || got[3] != "o-rds" {
+		t.Fatalf("resources = %v, want ours' IDs without the bucket", got)
+	}
+	ec2 := find(merged, "o-ec2")
+	if ec2.Metadata["instanceType"] != "t3.large" {
+		t.Errorf("instanceType = %v, want theirs' t3.large", ec2.Metadata["instanceType"])
+	}
+	tags := ec2.Metadata["tags"].(map[string]interface{})
+	if tags["Env"] != "prod" || tags["Team"] != "ops" {
+		t.Errorf("tags = %v, want both sides' tag changes", tags)
+	}
+	if ec2.ParentID == nil || *ec2.ParentID != "o-subnet" {
+		t.Errorf("instance parent = %v, want o-subnet", ec2.ParentID)
+	}
+	if deps := merged.Dependencies["o-ec2"]; len(deps) != 1 || deps[0] != "o-rds" {
+		t.Errorf("dependencies = %v, want only the database", deps)
+	}
+	if kids := merged.Containments["o-vpc"]; len(kids) != 2 {
+		t.Errorf("VPC children = %v, want the subnet and the database", kids)
+	}
+	if len(merged.Variables) != 1 || merged.Variables[0].Name != "env" {
+		t.Errorf("variables = %+v, want theirs' variable", merged.Variables)
+	}
+}
+
+func TestMergeArchitectures_Conflicts(t *testing.T) {
+	base := mergeBase()
+
+	ours, oursPairs := branch("o")
+	ours.Resources[2].Metadata["instanceType"] = "t3.large"
+	ours.Resources = ours.Resources[:3] // deletes the bucket
+	ours.Dependencies = map[string][]string{}
+
+	theirs, theirsPairs := branch("t")
+	theirs.Resources[2].Metadata["instanceType"] = "m5.large"
+	theirs.Resources[3].Name = "audit-logs"
+
+	res := MergeArchitectures(base, MergeInput{ours, oursPairs}, MergeInput{theirs, theirsPairs}, nil)
+	if res.Architecture != nil {
+		t.Fatal("expected no architecture while conflicts are unresolved")
+	}
+	got := make(map[string]MergeConflict)
+	for _, c := range res.Conflicts {
+		got[c.ID()] = c
+	}
+	typeConflict, ok := got["resource/ec2/config.instanceType"]
+	if !ok || typeConflict.Base != "t3.micro" || typeConflict.Ours != "t3.large" || typeConflict.Theirs != "m5.large" {
+		t.Errorf("instanceType conflict = %+v (all: %v)", typeConflict, res.Conflicts)
+	}
+	if c, ok := got["resource/bucket"]; !ok || c.Ours != nil || c.Theirs == nil {
+		t.Errorf("bucket conflict = %+v, want deleted by ours and modified by theirs", c)
+	}
+	if len(got) != 2 {
+		t.Errorf("Conflicts = %+v, want 2", res.Conflicts)
+	}
+
+	res = MergeArchitectures(base, MergeInput{ours, oursPairs}, MergeInput{theirs, theirsPairs}, map[string]Resolution{
+		"resource/ec2/config.instanceType": {Value: "t3.xlarge"},
+		"resource/bucket":                  {Take: TakeTheirs},
+	})
+	if len(res.Conflicts) != 0 {
+		t.Fatalf("Conflicts = %+v, want all resolved", res.Conflicts)
+	}
+	if v := find(res.Architecture, "o-ec2").Metadata["instanceType"]; v != "t3.xlarge" {
+		t.Errorf("instanceType = %v, want the resolved value", v)
+	}
+	if b := find(res.Architecture, "t-bucket"); b == nil || b.Name != "audit-logs" {
+		t.Errorf("bucket = %+v, want theirs' renamed bucket", b)
+	}
+}
@@ -0,0 +1,906 @@
+package architecture
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource/networking"
+)
+
+// Severities of reachability findings, most severe first.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// Codes of reachability findings.
+const (
+	FindingDatabaseExposed     = "database_exposed"
+	FindingSSHOpenToWorld      = "ssh_open_to_world"
+	FindingRDPOpenToWorld      = "rdp_open_to_world"
+	FindingAllPortsOpenToWorld = "all_ports_open_to_world"
+	FindingDatabasePortExposed = "database_port_exposed"
+	FindingLatentWorldRule     = "world_open_rule_not_exposed"
+)
+
+// Internet is the source ID of traffic from 0.0.0.0/0 in a reachability matrix.
+const Internet = "internet"
+
+// worldCIDRs are the CIDR blocks meaning "anywhere".
+var worldCIDRs = map[string]bool{"0.0.0.0/0": true, "::/0": true}
+
+// databaseTypes are resource types holding data that must never face the internet.
+var databaseTypes = map[string]bool{"RDS": true}
+
+// databasePorts are well-known database ports, by port.
+var databasePorts = map[int]string{
+	1433:  "SQL Server",
+	1521:  "Oracle",
+	3306:  "MySQL",
+	5432:  "PostgreSQL",
+	6379:  "Redis",
+	9200:  "Elasticsearch",
+	11211: "Memcached",
+	27017: "MongoDB",
+}
+
+// endpointTypes are resource types with network interfaces in a subnet. Other resources
+// take part in the analysis only when security groups are attached to them.
+var endpointTypes = map[string]bool{"EC2": true, "RDS": true, "LoadBalancer": true, "ECSService": true}
+
+// PortRange is a protocol and an inclusive port range. Protocol "-1" means every protocol
+// and port.
+type PortRange struct {
+	Protocol string
+	From     int
+	To       int
+}
+
+// String formats the range as "tcp/22", "tcp/8000-8080", "icmp" or "all".
+func (p PortRange) String() string {
+	switch {
+	case p.Protocol == string(networking.ProtocolAll):
+		return "all"
+	case p.Protocol == string(networking.ProtocolICMP):
+		return "icmp"
+	case p.From == 0 && p.To == 65535:
+		return p.Protocol + "/all"
+	case p.From == p.To:
+		return fmt.Sprintf("%s/%d", p.Protocol, p.From)
+	}
+	return fmt.Sprintf("%s/%d-%d", p.Protocol, p.From, p.To)
+}
+
+func (p PortRange) contains(port int) bool {
+	if p.Protocol == string(networking.ProtocolAll) {
+		return true
+	}
+	return p.Protocol == string(networking.ProtocolTCP) && p.From <= port && port <= p.To
+}
+
+func (p PortRange) isAll() bool {
+	return p.Protocol == string(networking.ProtocolAll)
+}
+
+// Endpoint is a resource that takes part in network traffic.
+type Endpoint struct {
+	Resource       *resource.Resource
+	SecurityGroups []*resource.Resource
+	Subnets        []*resource.Resource
+	VPC            *resource.Resource
+	Public         bool // in a subnet routed to an internet gateway, with a public address
+	InternetEgress bool // can open connections to the internet, directly or through a NAT gateway
+}
+
+// Finding is a reachability issue of one resource.
+type Finding struct {
+	Severity string
+	Code     string
+	Resource *resource.Resource
+	Ports    []PortRange
+	Message  string
+}
+
+// Reachability is the result of AnalyzeReachability.
+type Reachability struct {
+	Endpoints []*Endpoint
+	// Matrix maps a source resource ID, or Internet, to the target resource IDs it can
+	// open connections to and the ports it can reach them on.
+	Matrix   map[string]map[string][]PortRange
+	Findings []Finding
+}
+
+// SubnetRoute is the internet routing of a subnet's route tables.
+type SubnetRoute struct {
+	InternetGateway bool // routes to an internet gateway, making the subnet public
+	NATGateway      bool // routes to a NAT gateway
+}
+
+// SubnetRoutes reads the routes and subnet associations of the route tables. The result
+// is keyed by subnet reference as written in the associations; a subnet associated with
+// several route tables gets the union of their routes.
+func SubnetRoutes(resources []*resource.Resource) map[string]SubnetRoute {
+	result := make(map[string]SubnetRoute)
+	for _, res := range resources {
+		if res.Type.Name != "RouteTable" {
+			continue
+		}
+		var route SubnetRoute
+		for _, r := range metadataMaps(res.Metadata, "routes") {
+			target, _ := r["target"].(map[string]interface{})
+			switch target["type"] {
+			case "InternetGateway":
+				route.InternetGateway = true
+			case "NATGateway":
+				route.NATGateway = true
+			}
+		}
+		for _, assoc := range metadataMaps(res.Metadata, "associations") {
+			assocType, _ := assoc["associationType"].(string)
+			subnetID, _ := assoc["associatedResourceId"].(string)
+			if assocType != "Subnet" || subnetID == "" {
+				continue
+			}
+			existing := result[subnetID]
+			result[subnetID] = SubnetRoute{
+				InternetGateway: existing.InternetGateway || route.InternetGateway,
+				NATGateway:      existing.NATGateway || route.NATGateway,
+			}
+		}
+	}
+	return result
+}
+
+// SecurityGroupRules reads the rules of a security group: "ingressRules" and
+// "egressRules", or "rules" entries with a "type". Rules without a protocol are skipped.
+func SecurityGroupRules(sg *resource.Resource) []networking.SecurityGroupRule {
+	var rules []networking.SecurityGroupRule
+	add := func(entries []map[string]interface{}, ruleType string) {
+		for _, m := range entries {
+			t := ruleType
+			if t == "" {
+				t, _ = m["type"].(string)
+			}
+			protocol := normalizeProtocol(m["protocol"])
+			if (t != "ingress" && t != "egress") || protocol == "" {
+				continue
+			}
+			rule := networking.SecurityGroupRule{
+				Type:     t,
+				Protocol: networking.Protocol(protocol),
+			}
+			rule.FromPort, rule.ToPort = rulePorts(m)
+			rule.CIDRBlocks = append(metadataStrings(m, "cidrBlocks", "cidr_blocks", "ipv6CidrBlocks"), metadataStrings(m, "cidr", "cidrBlock", "ipv6Cidr")...)
+			rule.SourceGroupIDs = metadataStrings(m, "sourceSecurityGroupId", "source_security_group_id", "sourceGroupIds", "securityGroups")
+			rule.Description, _ = m["description"].(string)
+			rules = append(rules, rule)
+		}
+	}
+	add(metadataMaps(sg.Metadata, "rules"), "")
+	add(metadataMaps(sg.Metadata, "ingressRules"), "ingress")
+	add(metadataMaps(sg.Metadata, "egressRules"), "egress")
+	return rules
+}
+
+// AnalyzeReachability works out which resources can be reached from the internet, and
+// which resources can open connections to each other, from security group rules, route
+// tables, internet and NAT gateways and subnet placement. References to other resources
+// in configuration (security groups, subnets, route targets) may use resource IDs, the
+// "id" a node was configured with, or the node IDs a loaded snapshot was saved from.
+//
+// A resource is reachable from the internet on a port when it is in a public subnet of a
+// VPC with an internet gateway (RDS instances must also be publicly accessible and load
+// balancers internet-facing) and a security group allows the port from 0.0.0.0/0 or ::/0.
+// A resource can reach another in the same VPC when its egress rules and the target's
+// ingress rules both allow the traffic, by security group or by CIDR covering the
+// subnets involved. Security groups without egress rules allow all egress, as generated
+// Terraform does. Network ACLs are not considered.
+func AnalyzeReachability(arch *Architecture) *Reachability {
+	a := newReachabilityAnalyzer(arch)
+	endpoints := a.endpoints()
+
+	result := &Reachability{
+		Endpoints: endpoints,
+		Matrix:    make(map[string]map[string][]PortRange),
+	}
+	addFlow := func(source, target string, ports []PortRange) {
+		if len(ports) == 0 {
+			return
+		}
+		if result.Matrix[source] == nil {
+			result.Matrix[source] = make(map[string][]PortRange)
+		}
+		result.Matrix[source][target] = ports
+	}
+
+	for _, target := range endpoints {
+		worldPorts := a.ingressPorts(target, func(rule networking.SecurityGroupRule) bool {
+			return anyWorldCIDR(rule.CIDRBlocks)
+		})
+		if target.Public {
+			addFlow(Internet, target.Resource.ID, worldPorts)
+		}
+		result.Findings = append(result.Findings, exposureFindings(target, worldPorts)...)
+
+		for _, source := range endpoints {
+			if source == target || source.VPC == nil || source.VPC != target.VPC {
+				continue
+			}
+			addFlow(source.Resource.ID, target.Resource.ID, a.flowPorts(source, target))
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		return severityRank(result.Findings[i].Severity) < severityRank(result.Findings[j].Severity)
+	})
+	return result
+}
+
+// exposureFindings flags a resource's security group rules that admit the world on
+// sensitive ports: as exposures when the resource is public, as latent otherwise.
+func exposureFindings(ep *Endpoint, worldPorts []PortRange) []Finding {
+	if len(worldPorts) == 0 {
+		return nil
+	}
+	res := ep.Resource
+	if !ep.Public {
+		var sensitive []PortRange
+		for _, p := range worldPorts {
+			if p.isAll() || p.contains(22) || p.contains(3389) || databaseTypes[res.Type.Name] || containsDatabasePort(p) {
+				sensitive = append(sensitive, p)
+			}
+		}
+		if len(sensitive) == 0 {
+			return nil
+		}
+		return []Finding{{
+			Severity: SeverityLow,
+			Code:     FindingLatentWorldRule,
+			Resource: res,
+			Ports:    sensitive,
+			Message:  fmt.Sprintf("%s %q allows %s from anywhere; it is not reachable from the internet now, but would be in a public subnet", res.Type.Name, res.Name, formatPorts(sensitive)),
+		}}
+	}
+
+	var findings []Finding
+	if databaseTypes[res.Type.Name] {
+		findings = append(findings, Finding{
+			Severity: SeverityCritical,
+			Code:     FindingDatabaseExposed,
+			Resource: res,
+			Ports:    worldPorts,
+			Message:  fmt.Sprintf("Database %q is reachable from the internet on %s", res.Name, formatPorts(worldPorts)),
+		})
+	}
+	for _, p := range worldPorts {
+		switch {
+		case p.isAll() || (p.From == 0 && p.To == 65535):
+			findings = append(findings, Finding{
+				Severity: SeverityHigh,
+				Code:     FindingAllPortsOpenToWorld,
+				Resource: res,
+				Ports:    []PortRange{p},
+				Message:  fmt.Sprintf("%s %q is reachable from the internet on every port (%s)", res.Type.Name, res.Name, p),
+			})
+			continue
+		case p.contains(22):
+			findings = append(findings, Finding{
+				Severity: SeverityHigh,
+				Code:     FindingSSHOpenToWorld,
+				Resource: res,
+				Ports:    []PortRange{p},
+				Message:  fmt.Sprintf("SSH on %s %q is open to the world", res.Type.Name, res.Name),
+			})
+		case p.contains(3389):
+			findings = append(findings, Finding{
+				Severity: SeverityHigh,
+				Code:     FindingRDPOpenToWorld,
+				Resource: res,
+				Ports:    []PortRange{p},
+				Message:  fmt.Sprintf("RDP on %s %q is open to the world", res.Type.Name, res.Name),
+			})
+		}
+		if !databaseTypes[res.Type.Name] && containsDatabasePort(p) {
+			findings = append(findings, Finding{
+				Severity: SeverityMedium,
+				Code:     FindingDatabasePortExposed,
+				Resource: res,
+				Ports:    []PortRange{p},
+				Message:  fmt.Sprintf("%s %q is reachable from the internet on database port %s", res.Type.Name, res.Name, p),
+			})
+		}
+	}
+	return findings
+}
+
+type reachabilityAnalyzer struct {
+	arch     *Architecture
+	byRef    map[string]*resource.Resource
+	routes   map[string]SubnetRoute // by subnet resource ID
+	igwVPCs  map[string]bool        // VPC resource IDs with an internet gateway
+	sgRules  map[string][]networking.SecurityGroupRule
+	sgByRule map[string][]*resource.Resource // resolved source groups, by SG ID and rule index
+}
+
+func newReachabilityAnalyzer(arch *Architecture) *reachabilityAnalyzer {
+	a := &reachabilityAnalyzer{
+		arch:     arch,
+		byRef:    make(map[string]*resource.Resource),
+		routes:   make(map[string]SubnetRoute),
+		igwVPCs:  make(map[string]bool),
+		sgRules:  make(map[string][]networking.SecurityGroupRule),
+		sgByRule: make(map[string][]*resource.Resource),
+	}
+
+	// Index references: names from snapshot bookkeeping first, so that the configured
+	// "id" and the resource ID, which are more specific, win.
+	byName := make(map[string]*resource.Resource)
+	dupNames := make(map[string]bool)
+	for _, res := range arch.Resources {
+		if _, ok := byName[res.Name]; ok {
+			dupNames[res.Name] = true
+		}
+		byName[res.Name] = res
+	}
+	for _, res := range arch.Resources {
+		if names, ok := res.Metadata["_originalIDToName"].(map[string]string); ok {
+			for original, name := range names {
+				if r, ok := byName[name]; ok && !dupNames[name] {
+					a.byRef[original] = r
+				}
+			}
+			break
+		}
+	}
+	for _, res := range arch.Resources {
+		if id, ok := res.Metadata["id"].(string); ok && id != "" {
+			a.byRef[id] = res
+		}
+	}
+	for _, res := range arch.Resources {
+		a.byRef[res.ID] = res
+	}
+
+	for ref, route := range SubnetRoutes(arch.Resources) {
+		if subnet := a.resolve(ref); subnet != nil {
+			existing := a.routes[subnet.ID]
+			a.routes[subnet.ID] = SubnetRoute{
+				InternetGateway: existing.InternetGateway || route.InternetGateway,
+				NATGateway:      existing.NATGateway || route.NATGateway,
+			}
+		}
+	}
+	for _, res := range arch.Resources {
+		if res.Type.Name != "InternetGateway" {
+			continue
+		}
+		if vpc := a.vpcOf(res); vpc != nil {
+			a.igwVPCs[vpc.ID] = true
+		}
+	}
+	for _, res := range arch.Resources {
+		if res.Type.Name != "SecurityGroup" {
+			continue
+		}
+		rules := SecurityGroupRules(res)
+		a.sgRules[res.ID] = rules
+		for i, rule := range rules {
+			a.sgByRule[ruleKey(res.ID, i)] = a.resolveAll(rule.SourceGroupIDs, "SecurityGroup")
+		}
+	}
+	return a
+}
+
+func ruleKey(sgID string, index int) string {
+	return sgID + "#" + strconv.Itoa(index)
+}
+
+func (a *reachabilityAnalyzer) resolve(ref string) *resource.Resource {
+	return a.byRef[ref]
+}
+
+// resolveAll resolves references to resources of one type, skipping unknown ones.
+func (a *reachabilityAnalyzer) resolveAll(refs []string, typeName string) []*resource.Resource {
+	var out []*resource.Resource
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		if res := a.resolve(ref); res != nil && res.Type.Name == typeName && !seen[res.ID] {
+			seen[res.ID] = true
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+func (a *reachabilityAnalyzer) parent(res *resource.Resource) *resource.Resource {
+	if res.ParentID == nil {
+		return nil
+	}
+	return a.resolve(*res.ParentID)
+}
+
+// vpcOf returns the VPC containing a resource, or the VPC it names in its configuration.
+func (a *reachabilityAnalyzer) vpcOf(res *resource.Resource) *resource.Resource {
+	for r, depth := res, 0; r != nil && depth < 32; r, depth = a.parent(r), depth+1 {
+		if r.Type.Name == "VPC" {
+			return r
+		}
+		for _, ref := range metadataStrings(r.Metadata, "vpcId", "vpc_id") {
+			if vpc := a.resolve(ref); vpc != nil && vpc.Type.Name == "VPC" {
+				return vpc
+			}
+		}
+	}
+	return nil
+}
+
+// subnetsOf returns the subnet containing a resource, or the subnets named in the
+// configuration of the resource or of its nearest container that names any.
+func (a *reachabilityAnalyzer) subnetsOf(res *resource.Resource) []*resource.Resource {
+	for r, depth := res, 0; r != nil && depth < 32; r, depth = a.parent(r), depth+1 {
+		if r.Type.Name == "Subnet" {
+			return []*resource.Resource{r}
+		}
+		refs := metadataStrings(r.Metadata, "subnets", "subnetIds", "subnet_ids", "subnetId", "subnet_id")
+		if subnets := a.resolveAll(refs, "Subnet"); len(subnets) > 0 {
+			return subnets
+		}
+	}
+	return nil
+}
+
+// securityGroupsOf returns the security groups attached to a resource through its
+// configuration or its dependencies.
+func (a *reachabilityAnalyzer) securityGroupsOf(res *resource.Resource) []*resource.Resource {
+	refs := metadataStrings(res.Metadata, "securityGroups", "securityGroupIds", "vpc_security_group_ids", "vpcSecurityGroupIds", "security_group_ids", "security_groups")
+	refs = append(refs, res.DependsOn...)
+	refs = append(refs, a.arch.Dependencies[res.ID]...)
+	return a.resolveAll(refs, "SecurityGroup")
+}
+
+// subnetIsPublic decides like the Terraform generator does: route tables first, then
+// explicit configuration.
+func (a *reachabilityAnalyzer) subnetIsPublic(subnet *resource.Resource) bool {
+	if route, ok := a.routes[subnet.ID]; ok {
+		return route.InternetGateway
+	}
+	if v, ok := subnet.Metadata["isPublic"].(bool); ok {
+		return v
+	}
+	v, _ := subnet.Metadata["map_public_ip_on_launch"].(bool)
+	return v
+}
+
+func (a *reachabilityAnalyzer) endpoints() []*Endpoint {
+	var out []*Endpoint
+	for _, res := range a.arch.Resources {
+		sgs := a.securityGroupsOf(res)
+		if len(sgs) == 0 && !endpointTypes[res.Type.Name] {
+			continue
+		}
+		ep := &Endpoint{Resource: res, SecurityGroups: sgs, Subnets: a.subnetsOf(res), VPC: a.vpcOf(res)}
+		if ep.VPC == nil && len(ep.Subnets) > 0 {
+			ep.VPC = a.vpcOf(ep.Subnets[0])
+		}
+
+		publicSubnet, natRoute := false, false
+		for _, subnet := range ep.Subnets {
+			publicSubnet = publicSubnet || a.subnetIsPublic(subnet)
+			natRoute = natRoute || a.routes[subnet.ID].NATGateway
+		}
+		hasIGW := ep.VPC != nil && a.igwVPCs[ep.VPC.ID]
+		ep.Public = publicSubnet && hasIGW && publiclyAddressable(res)
+		egressAllowed := len(a.egressPorts(ep, func(rule networking.SecurityGroupRule) bool {
+			return anyWorldCIDR(rule.CIDRBlocks)
+		})) > 0
+		ep.InternetEgress = egressAllowed && ((publicSubnet && hasIGW) || natRoute)
+		out = append(out, ep)
+	}
+	return out
+}
+
+// publiclyAddressable reports whether a resource in a public subnet gets a public address.
+func publiclyAddressable(res *resource.Resource) bool {
+	switch res.Type.Name {
+	case "RDS":
+		v, _ := res.Metadata["publiclyAccessible"].(bool)
+		return v
+	case "LoadBalancer":
+		internal, _ := res.Metadata["internal"].(bool)
+		return !internal
+	case "EC2":
+		if v, ok := res.Metadata["associatePublicIpAddress"].(bool); ok {
+			return v
+		}
+	}
+	return true
+}
+
+// ingressPorts returns the ports the endpoint's security groups admit for rules
+// matching allow.
+func (a *reachabilityAnalyzer) ingressPorts(ep *Endpoint, allow func(networking.SecurityGroupRule) bool) []PortRange {
+	return a.rulePorts(ep, "ingress", allow)
+}
+
+// egressPorts returns the ports the endpoint's security groups let out for rules
+// matching allow. Security groups without egress rules allow everything.
+func (a *reachabilityAnalyzer) egressPorts(ep *Endpoint, allow func(networking.SecurityGroupRule) bool) []PortRange {
+	return a.rulePorts(ep, "egress", allow)
+}
+
+func (a *reachabilityAnalyzer) rulePorts(ep *Endpoint, ruleType string, allow func(networking.SecurityGroupRule) bool) []PortRange {
+	var ports []PortRange
+	for _, sg := range ep.SecurityGroups {
+		rules := a.sgRules[sg.ID]
+		if ruleType == "egress" && !hasRuleType(rules, "egress") {
+			return []PortRange{{Protocol: string(networking.ProtocolAll), From: 0, To: 65535}}
+		}
+		for i, rule := range rules {
+			if rule.Type != ruleType {
+				continue
+			}
+			matched := allow(rule)
+			if !matched {
+				// Rules naming security groups admit members of those groups.
+				matched = allowBySourceGroup(a.sgByRule[ruleKey(sg.ID, i)], rule, allow)
+			}
+			if matched {
+				ports = append(ports, portRange(rule))
+			}
+		}
+	}
+	return normalizePorts(ports)
+}
+
+// allowBySourceGroup lets allow functions inspect the resolved source groups of a rule by
+// passing them in SourceGroupIDs as resource IDs.
+func allowBySourceGroup(groups []*resource.Resource, rule networking.SecurityGroupRule, allow func(networking.SecurityGroupRule) bool) bool {
+	if len(groups) == 0 {
+		return false
+	}
+	resolved := rule
+	resolved.CIDRBlocks = nil
+	resolved.SourceGroupIDs = make([]string, len(groups))
+	for i, g := range groups {
+		resolved.SourceGroupIDs[i] = g.ID
+	}
+	return allow(resolved)
+}
+
+// flowPorts returns the ports source can open connections to target on.
+func (a *reachabilityAnalyzer) flowPorts(source, target *Endpoint) []PortRange {
+	in := a.ingressPorts(target, func(rule networking.SecurityGroupRule) bool {
+		return sharesGroup(rule.SourceGroupIDs, source.SecurityGroups) || a.cidrsCover(rule.CIDRBlocks, source)
+	})
+	if len(in) == 0 {
+		return nil
+	}
+	out := a.egressPorts(source, func(rule networking.SecurityGroupRule) bool {
+		return sharesGroup(rule.SourceGroupIDs, target.SecurityGroups) || a.cidrsCover(rule.CIDRBlocks, target)
+	})
+	return intersectPorts(in, out)
+}
+
+// cidrsCover reports whether one of the CIDR blocks covers the address range of an
+// endpoint: its subnets' CIDR blocks, or its VPC's when the subnets have none.
+func (a *reachabilityAnalyzer) cidrsCover(cidrs []string, ep *Endpoint) bool {
+	if len(cidrs) == 0 {
+		return false
+	}
+	var ranges []string
+	for _, subnet := range ep.Subnets {
+		ranges = append(ranges, metadataStrings(subnet.Metadata, "cidr", "cidrBlock", "cidr_block")...)
+	}
+	if len(ranges) == 0 && ep.VPC != nil {
+		ranges = metadataStrings(ep.VPC.Metadata, "cidr", "cidrBlock", "cidr_block")
+	}
+	for _, block := range cidrs {
+		if worldCIDRs[block] {
+			return true
+		}
+		for _, r := range ranges {
+			if cidrContains(block, r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sharesGroup(ids []string, groups []*resource.Resource) bool {
+	for _, id := range ids {
+		for _, g := range groups {
+			if g.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasRuleType(rules []networking.SecurityGroupRule, ruleType string) bool {
+	for _, r := range rules {
+		if r.Type == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+func anyWorldCIDR(cidrs []string) bool {
+	for _, c := range cidrs {
+		if worldCIDRs[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// cidrContains reports whether CIDR block outer contains block inner.
+func cidrContains(outer, inner string) bool {
+	_, o, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false
+	}
+	_, i, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false
+	}
+	oOnes, _ := o.Mask.Size()
+	iOnes, _ := i.Mask.Size()
+	return oOnes <= iOnes && o.Contains(i.IP)
+}
+
+func portRange(rule networking.SecurityGroupRule) PortRange {
+	if rule.Protocol == networking.ProtocolAll {
+		return PortRange{Protocol: string(networking.ProtocolAll), From: 0, To: 65535}
+	}
+	p := PortRange{Protocol: string(rule.Protocol), From: 0, To: 65535}
+	if rule.FromPort != nil {
+		p.From = *rule.FromPort
+	}
+	if rule.ToPort != nil {
+		p.To = *rule.ToPort
+	}
+	if p.Protocol == string(networking.ProtocolICMP) {
+		p.From, p.To = -1, -1
+	}
+	return p
+}
+
+// normalizePorts merges overlapping and adjacent ranges per protocol and sorts them.
+func normalizePorts(ports []PortRange) []PortRange {
+	if len(ports) == 0 {
+		return nil
+	}
+	byProtocol := make(map[string][]PortRange)
+	for _, p := range ports {
+		if p.isAll() {
+			return []PortRange{p}
+		}
+		byProtocol[p.Protocol] = append(byProtocol[p.Protocol], p)
+	}
+	protocols := make([]string, 0, len(byProtocol))
+	for proto := range byProtocol {
+		protocols = append(protocols, proto)
+	}
+	sort.Strings(protocols)
+
+	var out []PortRange
+	for _, proto := range protocols {
+		ranges := byProtocol[proto]
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].From < ranges[j].From })
+		merged := []PortRange{ranges[0]}
+		for _, r := range ranges[1:] {
+			last := &merged[len(merged)-1]
+			if r.From <= last.To+1 {
+				if r.To > last.To {
+					last.To = r.To
+				}
+				continue
+			}
+			merged = append(merged, r)
+		}
+		out = append(out, merged...)
+	}
+	return out
+}
+
+// intersectPorts returns the traffic allowed by both port lists.
+func intersectPorts(a, b []PortRange) []PortRange {
+	var out []PortRange
+	for _, x := range a {
+		for _, y := range b {
+			proto := x.Protocol
+			switch {
+			case x.isAll():
+				proto = y.Protocol
+			case y.isAll():
+			case x.Protocol != y.Protocol:
+				continue
+			}
+			from, to := max(x.From, y.From), min(x.To, y.To)
+			if proto == string(networking.ProtocolAll) {
+				from, to = 0, 65535
+			} else if proto == string(networking.ProtocolICMP) {
+				from, to = -1, -1
+			} else if from > to {
+				continue
+			}
+			out = append(out, PortRange{Protocol: proto, From: from, To: to})
+		}
+	}
+	return normalizePorts(out)
+}
+
+func containsDatabasePort(p PortRange) bool {
+	if p.isAll() {
+		return true
+	}
+	for port := range databasePorts {
+		if p.contains(port) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatPorts(ports []PortRange) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	case SeverityMedium:
+		return 2
+	}
+	return 3
+}
+
+// normalizeProtocol maps the protocol spellings of the editor and of AWS to the
+// networking.Protocol values.
+func normalizeProtocol(v interface{}) string {
+	switch p := v.(type) {
+	case string:
+		switch strings.ToLower(strings.TrimSpace(p)) {
+		case "":
+			return ""
+		case "-1", "all", "any":
+			return string(networking.ProtocolAll)
+		case "6":
+			return string(networking.ProtocolTCP)
+		case "17":
+			return string(networking.ProtocolUDP)
+		case "1":
+			return string(networking.ProtocolICMP)
+		default:
+			return strings.ToLower(strings.TrimSpace(p))
+		}
+	case float64:
+		return normalizeProtocol(strconv.Itoa(int(p)))
+	case int:
+		return normalizeProtocol(strconv.Itoa(p))
+	}
+	return ""
+}
+
+// rulePorts reads "fromPort"/"toPort" or a "portRange" such as "80" or "8000-8080".
+// Missing ports mean all ports.
+func rulePorts(m map[string]interface{}) (from, to *int) {
+	if f, ok := metadataInt(m, "fromPort", "from_port"); ok {
+		from = &f
+	}
+	if t, ok := metadataInt(m, "toPort", "to_port"); ok {
+		to = &t
+	}
+	if from != nil || to != nil {
+		if from == nil {
+			from = to
+		}
+		if to == nil {
+			to = from
+		}
+		return from, to
+	}
+	pr, _ := m["portRange"].(string)
+	if pr == "" {
+		return nil, nil
+	}
+	parts := strings.SplitN(pr, "-", 2)
+	f, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, nil
+	}
+	t := f
+	if len(parts) == 2 {
+		if t, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+			return nil, nil
+		}
+	}
+	return &f, &t
+}
+
+func metadataInt(m map[string]interface{}, keys ...string) (int, bool) {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), true
+			}
+		case int:
+			return v, true
+		case string:
+			if n, err := strconv.Atoi(v); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// metadataMaps returns the object entries of a metadata list.
+func metadataMaps(m map[string]interface{}, key string) []map[string]interface{} {
+	var out []map[string]interface{}
+	switch list := m[key].(type) {
+	case []interface{}:
+		for _, item := range list {
+			if entry, ok := item.(map[string]interface{}); ok {
+				out = append(out, entry)
+			}
+		}
+	case []map[string]interface{}:
+		out = list
+	}
+	return out
+}
+
+// metadataStrings collects references from metadata entries that hold a string, a list
+// of strings, or a list of objects with an "id", "subnetId" or "securityGroupId".
+func metadataStrings(m map[string]interface{}, keys ...string) []string {
+	var out []string
+	add := func(v interface{}) {
+		switch x := v.(type) {
+		case string:
+			if x != "" {
+				out = append(out, x)
+			}
+		case map[string]interface{}:
+			for _, k := range []string{"id", "subnetId", "securityGroupId"} {
+				if s, ok := x[k].(string); ok && s != "" {
+					out = append(out, s)
+					return
+				}
+			}
+		}
+	}
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case []interface{}:
+			for _, item := range v {
+				add(item)
+			}
+		case []string:
+			for _, item := range v {
+				add(item)
+			}
+		default:
+			add(v)
+		}
+	}
+	return out
+}
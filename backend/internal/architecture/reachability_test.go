@@ -0,0 +1,177 @@
+package architecture
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// reachabilityFixture is a VPC with an internet gateway, a public subnet routed to it
+// holding a web server, and a private subnet routed to a NAT gateway holding a database.
+// Route tables and instances refer to subnets and security groups by their configured
+// "id", as the editor does.
+func reachabilityFixture() *Architecture {
+	a := NewArchitecture()
+	vpc := createTestResource("vpc", "main", "VPC")
+	vpc.Metadata = map[string]interface{}{"cidr": "10.0.0.0/16"}
+	igw := createTestResource("igw", "igw", "InternetGateway")
+	public := createTestResource("subnet-1", "public", "Subnet")
+	public.Metadata = map[string]interface{}{"id": "subnet-pub", "cidr": "10.0.1.0/24"}
+	private := createTestResource("subnet-2", "private", "Subnet")
+	private.Metadata = map[string]interface{}{"id": "subnet-priv", "cidr": "10.0.2.0/24"}
+	publicRT := createTestResource("rt-1", "public-rt", "RouteTable")
+	publicRT.Metadata = map[string]interface{}{
+		"routes":       []interface{}{map[string]interface{}{"destination": map[string]interface{}{"cidr": "0.0.0.0/0"}, "target": map[string]interface{}{"type": "InternetGateway", "resourceId": "igw"}}},
+		"associations": []interface{}{map[string]interface{}{"associationType": "Subnet", "associatedResourceId": "subnet-pub"}},
+	}
+	privateRT := createTestResource("rt-2", "private-rt", "RouteTable")
+	privateRT.Metadata = map[string]interface{}{
+		"routes":       []interface{}{map[string]interface{}{"destination": map[string]interface{}{"cidr": "0.0.0.0/0"}, "target": map[string]interface{}{"type": "NATGateway", "resourceId": "nat"}}},
+		"associations": []interface{}{map[string]interface{}{"associationType": "Subnet", "associatedResourceId": "subnet-priv"}},
+	}
+
+	webSG := createTestResource("sg-1", "web-sg", "SecurityGroup")
+	webSG.Metadata = map[string]interface{}{
+		"id": "web-sg",
+		"ingressRules": []interface{}{
+			map[string]interface{}{"protocol": "tcp", "fromPort": 80.0, "toPort": 80.0, "cidr": "0.0.0.0/0"},
+			map[string]interface{}{"protocol": "tcp", "portRange": "22", "cidr": "0.0.0.0/0"},
+		},
+	}
+	dbSG := createTestResource("sg-2", "db-sg", "SecurityGroup")
+	dbSG.Metadata = map[string]interface{}{
+		"id": "db-sg",
+		"rules": []interface{}{
+			map[string]interface{}{"type": "ingress", "protocol": "tcp", "fromPort": 5432.0, "toPort": 5432.0, "sourceSecurityGroupId": "web-sg"},
+			map[string]interface{}{"type": "ingress", "protocol": "tcp", "fromPort": 3306.0, "toPort": 3306.0, "cidr": "0.0.0.0/0"},
+			map[string]interface{}{"type": "egress", "protocol": "-1", "cidr": "0.0.0.0/0"},
+		},
+	}
+
+	web := createTestResource("ec2", "web", "EC2")
+	web.Metadata = map[string]interface{}{"securityGroups": []interface{}{map[string]interface{}{"id": "web-sg"}}}
+	db := createTestResource("rds", "db", "RDS")
+	db.Metadata = map[string]interface{}{"securityGroupIds": []interface{}{"db-sg"}, "publiclyAccessible": false}
+
+	for _, child := range []*resource.Resource{igw, public, private, publicRT, privateRT, webSG, dbSG} {
+		setParent(child, "vpc")
+	}
+	setParent(web, "subnet-1")
+	setParent(db, "subnet-2")
+	a.Resources = []*resource.Resource{vpc, igw, public, private, publicRT, privateRT, webSG, dbSG, web, db}
+	return a
+}
+
+func portStrings(ports []PortRange) []string {
+	out := make([]string, len(ports))
+	for i, p := range ports {
+		out[i] = p.String()
+	}
+	return out
+}
+
+func endpoint(r *Reachability, id string) *Endpoint {
+	for _, ep := range r.Endpoints {
+		if ep.Resource.ID == id {
+			return ep
+		}
+	}
+	return nil
+}
+
+func TestAnalyzeReachability(t *testing.T) {
+	r := AnalyzeReachability(reachabilityFixture())
+
+	if len(r.Endpoints) != 2 {
+		t.Fatalf("Endpoints = %d, want the instance and the database", len(r.Endpoints))
+	}
+	web, db := endpoint(r, "ec2"), endpoint(r, "rds")
+	if !web.Public || !web.InternetEgress {
+		t.Errorf("web: public=%v egress=%v, want both", web.Public, web.InternetEgress)
+	}
+	if db.Public || !db.InternetEgress {
+		t.Errorf("db: public=%v egress=%v, want private with egress through the NAT gateway", db.Public, db.InternetEgress)
+	}
+
+	if got := portStrings(r.Matrix[Internet]["ec2"]); len(got) != 2 || got[0] != "tcp/22" || got[1] != "tcp/80" {
+		t.Errorf("internet -> web = %v, want [tcp/22 tcp/80]", got)
+	}
+	if _, ok := r.Matrix[Internet]["rds"]; ok {
+		t.Error("the private database should not be reachable from the internet")
+	}
+	// 5432 through the source group, 3306 through the world-open rule.
+	if got := portStrings(r.Matrix["ec2"]["rds"]); len(got) != 2 || got[0] != "tcp/3306" || got[1] != "tcp/5432" {
+		t.Errorf("web -> db = %v, want [tcp/3306 tcp/5432]", got)
+	}
+
+	codes := make(map[string]string)
+	for _, f := range r.Findings {
+		codes[f.Code] = f.Resource.ID
+	}
+	if codes[FindingSSHOpenToWorld] != "ec2" {
+		t.Errorf("findings = %+v, want SSH open to the world on the web server", r.Findings)
+	}
+	if codes[FindingLatentWorldRule] != "rds" {
+		t.Errorf("findings = %+v, want the database's world-open rule flagged as latent", r.Findings)
+	}
+	if _, ok := codes[FindingDatabaseExposed]; ok {
+		t.Errorf("findings = %+v, the private database is not exposed", r.Findings)
+	}
+}
+
+func TestAnalyzeReachability_PublicDatabase(t *testing.T) {
+	a := reachabilityFixture()
+	db := find(a, "rds")
+	setParent(db, "subnet-1")
+	db.Metadata["publiclyAccessible"] = true
+
+	r := AnalyzeReachability(a)
+	if got := portStrings(r.Matrix[Internet]["rds"]); len(got) != 1 || got[0] != "tcp/3306" {
+		t.Errorf("internet -> db = %v, want [tcp/3306]", got)
+	}
+	if len(r.Findings) == 0 || r.Findings[0].Code != FindingDatabaseExposed || r.Findings[0].Severity != SeverityCritical {
+		t.Errorf("findings = %+v, want the exposed database first", r.Findings)
+	}
+}
+
+func TestAnalyzeReachability_NoInternetGateway(t *testing.T) {
+	a := reachabilityFixture()
+	a.Resources = append(a.Resources[:1], a.Resources[2:]...) // drop the internet gateway
+
+	r := AnalyzeReachability(a)
+	if len(r.Matrix[Internet]) != 0 {
+		t.Errorf("internet row = %v, want nothing reachable without an internet gateway", r.Matrix[Internet])
+	}
+	if endpoint(r, "ec2").InternetEgress {
+		t.Error("web should have no internet egress without an internet gateway")
+	}
+}
+
+func TestSubnetRoutes(t *testing.T) {
+	routes := SubnetRoutes(reachabilityFixture().Resources)
+	if !routes["subnet-pub"].InternetGateway || routes["subnet-pub"].NATGateway {
+		t.Errorf("subnet-pub = %+v, want an internet gateway route", routes["subnet-pub"])
+	}
+	if routes["subnet-priv"].InternetGateway || !routes["subnet-priv"].NATGateway {
+		t.Errorf("subnet-priv = %+v, want a NAT gateway route", routes["subnet-priv"])
+	}
+}
+
+func TestPortRangeString(t *testing.T) {
+	cases := map[string]PortRange{
+		"all":          {Protocol: "-1", From: 0, To: 65535},
+		"tcp/all":      {Protocol: "tcp", From: 0, To: 65535},
+		"tcp/22":       {Protocol: "tcp", From: 22, To: 22},
+		"udp/500-4500": {Protocol: "udp", From: 500, To: 4500},
+		"icmp":         {Protocol: "icmp", From: -1, To: -1},
+	}
+	for want, p := range cases {
+		if got := p.String(); got != want {
+			t.Errorf("%+v.String() = %q, want %q", p, got, want)
+		}
+	}
+	merged := normalizePorts([]PortRange{{"tcp", 80, 80}, {"tcp", 81, 90}, {"tcp", 22, 22}})
+	if got := portStrings(merged); len(got) != 2 || got[0] != "tcp/22" || got[1] != "tcp/80-90" {
+		t.Errorf("normalizePorts = %v, want [tcp/22 tcp/80-90]", got)
+	}
+}
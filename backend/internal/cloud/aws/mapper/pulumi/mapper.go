@@ -0,0 +1,145 @@
+package pulumi
+
+import (
+	pmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+)
+
+// AWSMapper translates the Terraform resource types emitted by the AWS Terraform
+// mapper into @pulumi/aws resource classes.
+//
+// Property names follow the bridged Terraform schema (snake_case -> camelCase),
+// so each spec only lists the exceptions: pluralised list properties, nested
+// blocks that are single objects, and file-path attributes that become assets.
+type AWSMapper struct {
+	specs map[string]pmapper.ResourceSpec
+}
+
+func New() *AWSMapper {
+	return &AWSMapper{specs: awsResourceSpecs()}
+}
+
+func (m *AWSMapper) Provider() string { return "aws" }
+
+func (m *AWSMapper) Package() pmapper.Package {
+	return pmapper.Package{
+		Name:      "@pulumi/aws",
+		Alias:     "aws",
+		Version:   "^6.0.0",
+		RegionKey: "aws:region",
+	}
+}
+
+func (m *AWSMapper) Spec(terraformType string) (pmapper.ResourceSpec, bool) {
+	spec, ok := m.specs[terraformType]
+	return spec, ok
+}
+
+func awsResourceSpecs() map[string]pmapper.ResourceSpec {
+	return map[string]pmapper.ResourceSpec{
+		// Networking
+		"aws_vpc":                     {Class: "aws.ec2.Vpc"},
+		"aws_subnet":                  {Class: "aws.ec2.Subnet"},
+		"aws_internet_gateway":        {Class: "aws.ec2.InternetGateway"},
+		"aws_nat_gateway":             {Class: "aws.ec2.NatGateway"},
+		"aws_eip":                     {Class: "aws.ec2.Eip"},
+		"aws_route_table":             {Class: "aws.ec2.RouteTable"},
+		"aws_route":                   {Class: "aws.ec2.Route"},
+		"aws_route_table_association": {Class: "aws.ec2.RouteTableAssociation"},
+		"aws_security_group":          {Class: "aws.ec2.SecurityGroup"},
+		"aws_vpc_endpoint":            {Class: "aws.ec2.VpcEndpoint"},
+
+		// Compute
+		"aws_instance":        {Class: "aws.ec2.Instance"},
+		"aws_launch_template": {Class: "aws.ec2.LaunchTemplate"},
+		"aws_autoscaling_group": {
+			Class: "aws.autoscaling.Group",
+			Renames: map[string]string{
+				"vpc_zone_identifier": "vpcZoneIdentifiers",
+				"tag":                 "tags",
+			},
+			SingleBlocks: map[string]bool{"launch_template": true},
+		},
+		"aws_lambda_function": {
+			Class: "aws.lambda.Function",
+			Renames: map[string]string{
+				"function_name": "name",
+				"filename":      "code",
+			},
+			Archives: map[string]bool{"filename": true},
+		},
+		"aws_lb": {Class: "aws.lb.LoadBalancer"},
+		"aws_lb_listener": {
+			Class:   "aws.lb.Listener",
+			Renames: map[string]string{"default_action": "defaultActions"},
+		},
+		"aws_lb_target_group": {
+			Class:        "aws.lb.TargetGroup",
+			SingleBlocks: map[string]bool{"health_check": true},
+		},
+
+		// Storage
+		"aws_s3_bucket": {Class: "aws.s3.BucketV2"},
+
+		// Database
+		"aws_db_instance":     {Class: "aws.rds.Instance"},
+		"aws_db_subnet_group": {Class: "aws.rds.SubnetGroup"},
+		"aws_dynamodb_table": {
+			Class: "aws.dynamodb.Table",
+			Renames: map[string]string{
+				"attribute":              "attributes",
+				"global_secondary_index": "globalSecondaryIndexes",
+				"local_secondary_index":  "localSecondaryIndexes",
+			},
+			SingleBlocks: map[string]bool{
+				"ttl":                    true,
+				"point_in_time_recovery": true,
+			},
+		},
+
+		// IAM
+		"aws_iam_role":                   {Class: "aws.iam.Role"},
+		"aws_iam_policy":                 {Class: "aws.iam.Policy"},
+		"aws_iam_user":                   {Class: "aws.iam.User"},
+		"aws_iam_role_policy_attachment": {Class: "aws.iam.RolePolicyAttachment"},
+		"aws_iam_instance_profile":       {Class: "aws.iam.InstanceProfile"},
+
+		// Containers
+		"aws_ecs_cluster": {
+			Class:        "aws.ecs.Cluster",
+			Renames:      map[string]string{"setting": "settings"},
+			SingleBlocks: map[string]bool{"configuration": true},
+		},
+		"aws_ecs_task_definition": {Class: "aws.ecs.TaskDefinition"},
+		"aws_ecs_service": {
+			Class: "aws.ecs.Service",
+			Renames: map[string]string{
+				"load_balancer":              "loadBalancers",
+				"capacity_provider_strategy": "capacityProviderStrategies",
+			},
+			SingleBlocks: map[string]bool{
+				"network_configuration":      true,
+				"deployment_circuit_breaker": true,
+			},
+		},
+		"aws_ecs_capacity_provider": {
+			Class: "aws.ecs.CapacityProvider",
+			SingleBlocks: map[string]bool{
+				"auto_scaling_group_provider": true,
+				"managed_scaling":             true,
+			},
+		},
+		"aws_ecs_cluster_capacity_providers": {
+			Class: "aws.ecs.ClusterCapacityProviders",
+			Renames: map[string]string{
+				"default_capacity_provider_strategy": "defaultCapacityProviderStrategies",
+			},
+		},
+		"aws_ecr_repository": {
+			Class:        "aws.ecr.Repository",
+			Renames:      map[string]string{"encryption_configuration": "encryptionConfigurations"},
+			SingleBlocks: map[string]bool{"image_scanning_configuration": true},
+		},
+	}
+}
+
+var _ pmapper.ResourceMapper = (*AWSMapper)(nil)
@@ -0,0 +1,137 @@
+package pulumi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/terraform"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	pulumigen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/generator"
+	pmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+	tfgen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPulumiEngine(t *testing.T) *pulumigen.Engine {
+	t.Helper()
+	tfRegistry := tfmapper.NewRegistry()
+	require.NoError(t, tfRegistry.Register(terraform.New()))
+	pRegistry := pmapper.NewRegistry()
+	require.NoError(t, pRegistry.Register(New()))
+	return pulumigen.NewEngine(tfgen.NewEngine(tfRegistry), pRegistry)
+}
+
+func TestAWSMapper_Spec(t *testing.T) {
+	m := New()
+
+	spec, ok := m.Spec("aws_vpc")
+	assert.True(t, ok)
+	assert.Equal(t, "aws.ec2.Vpc", spec.Class)
+
+	spec, ok = m.Spec("aws_autoscaling_group")
+	assert.True(t, ok)
+	assert.Equal(t, "vpcZoneIdentifiers", spec.Renames["vpc_zone_identifier"])
+	assert.True(t, spec.SingleBlocks["launch_template"])
+
+	_, ok = m.Spec("aws_unknown_thing")
+	assert.False(t, ok)
+}
+
+func TestAWSMapper_GenerateVPCProgram(t *testing.T) {
+	vpcID := "vpc-1"
+	subnetID := "subnet-1"
+	sgID := "sg-1"
+
+	vpc := &resource.Resource{
+		ID:       vpcID,
+		Name:     "main-vpc",
+		Type:     resource.ResourceType{Name: "VPC"},
+		Provider: resource.AWS,
+		Metadata: map[string]interface{}{
+			"cidr":     "10.0.0.0/16",
+			"_varRefs": map[string]interface{}{"cidr": "var.vpc_cidr"},
+		},
+	}
+	subnet := &resource.Resource{
+		ID:       subnetID,
+		Name:     "public-subnet",
+		Type:     resource.ResourceType{Name: "Subnet"},
+		Provider: resource.AWS,
+		ParentID: &vpcID,
+		Metadata: map[string]interface{}{
+			"cidr":               "10.0.1.0/24",
+			"availabilityZoneId": "us-east-1a",
+		},
+	}
+	sg := &resource.Resource{
+		ID:       sgID,
+		Name:     "web-sg",
+		Type:     resource.ResourceType{Name: "SecurityGroup"},
+		Provider: resource.AWS,
+		ParentID: &vpcID,
+		Metadata: map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{"type": "ingress", "protocol": "tcp", "portRange": "443", "cidr": "0.0.0.0/0"},
+			},
+		},
+	}
+	ec2 := &resource.Resource{
+		ID:        "ec2-1",
+		Name:      "web",
+		Type:      resource.ResourceType{Name: "EC2"},
+		Provider:  resource.AWS,
+		ParentID:  &subnetID,
+		DependsOn: []string{sgID},
+		Metadata: map[string]interface{}{
+			"ami":            "ami-123",
+			"instanceType":   "t3.micro",
+			"securityGroups": []interface{}{map[string]interface{}{"id": sgID}},
+		},
+	}
+
+	resources := []*resource.Resource{vpc, subnet, sg, ec2}
+	arch := &architecture.Architecture{
+		Resources: resources,
+		Region:    "us-east-1",
+		Provider:  resource.AWS,
+		Variables: []architecture.Variable{
+			{Name: "vpc_cidr", Type: "string", Default: "10.0.0.0/16"},
+		},
+		Outputs: []architecture.Output{
+			{Name: "vpc_id", Value: "aws_vpc.main_vpc.id"},
+		},
+	}
+
+	out, err := newPulumiEngine(t).Generate(context.Background(), arch, resources, iac.Options{})
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range out.Files {
+		files[f.Path] = f.Content
+	}
+	require.Contains(t, files, "index.ts")
+	require.Contains(t, files, "Pulumi.yaml")
+	require.Contains(t, files, "Pulumi.dev.yaml")
+	require.Contains(t, files, "package.json")
+
+	index := files["index.ts"]
+	assert.Contains(t, index, `import * as aws from "@pulumi/aws";`)
+	assert.Contains(t, index, `const vpcCidr = config.get("vpc_cidr") ?? "10.0.0.0/16";`)
+	assert.Contains(t, index, `const mainVpc = new aws.ec2.Vpc("main_vpc", {`)
+	assert.Contains(t, index, "cidrBlock: vpcCidr,")
+	assert.Contains(t, index, "vpcId: mainVpc.id,")
+	assert.Contains(t, index, "vpcSecurityGroupIds: [webSg.id],")
+	assert.Contains(t, index, "}, { dependsOn: [webSg] });")
+	assert.Contains(t, index, "ingress: [")
+	assert.Contains(t, index, "cidrBlocks: [\"0.0.0.0/0\"],")
+	assert.Contains(t, index, "export const vpc_id = mainVpc.id;")
+
+	assert.Contains(t, files["Pulumi.dev.yaml"], "aws:region: us-east-1")
+	assert.Contains(t, files["package.json"], `"@pulumi/aws": "^6.0.0"`)
+	assert.True(t, strings.Contains(files["Pulumi.yaml"], "typescript: true"))
+}
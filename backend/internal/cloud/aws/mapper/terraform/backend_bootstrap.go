@@ -0,0 +1,94 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+)
+
+const (
+	stateBucketName = "terraform_state"
+	lockTableName   = "terraform_locks"
+)
+
+// BootstrapBackend returns the resources an S3 state backend relies on: a versioned,
+// encrypted, private bucket and, when locking is configured, a DynamoDB lock table.
+func (m *AWSMapper) BootstrapBackend(backend iac.StateBackend) ([]tfmapper.TerraformBlock, error) {
+	if backend.Type != iac.BackendS3 || backend.S3 == nil {
+		return nil, fmt.Errorf("aws can only bootstrap the %s backend, got %q", iac.BackendS3, backend.Type)
+	}
+	if backend.S3.Bucket == "" {
+		return nil, fmt.Errorf("s3 backend bucket is required")
+	}
+
+	bucketID := tfExpr(tfmapper.Reference{ResourceType: "aws_s3_bucket", ResourceName: stateBucketName, Attribute: "id"}.Expr())
+
+	blocks := []tfmapper.TerraformBlock{
+		{
+			Kind:   "resource",
+			Labels: []string{"aws_s3_bucket", stateBucketName},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"bucket": tfString(backend.S3.Bucket),
+				"tags":   tfTags(backend.S3.Bucket),
+			},
+			NestedBlocks: map[string][]tfmapper.NestedBlock{
+				"lifecycle": {{Attributes: map[string]tfmapper.TerraformValue{"prevent_destroy": tfBool(true)}}},
+			},
+		},
+		{
+			Kind:       "resource",
+			Labels:     []string{"aws_s3_bucket_versioning", stateBucketName},
+			Attributes: map[string]tfmapper.TerraformValue{"bucket": bucketID},
+			NestedBlocks: map[string][]tfmapper.NestedBlock{
+				"versioning_configuration": {{Attributes: map[string]tfmapper.TerraformValue{"status": tfString("Enabled")}}},
+			},
+		},
+		{
+			Kind:       "resource",
+			Labels:     []string{"aws_s3_bucket_server_side_encryption_configuration", stateBucketName},
+			Attributes: map[string]tfmapper.TerraformValue{"bucket": bucketID},
+			NestedBlocks: map[string][]tfmapper.NestedBlock{
+				"rule": {{
+					NestedBlocks: map[string][]tfmapper.NestedBlock{
+						"apply_server_side_encryption_by_default": {{
+							Attributes: map[string]tfmapper.TerraformValue{"sse_algorithm": tfString("AES256")},
+						}},
+					},
+				}},
+			},
+		},
+		{
+			Kind:   "resource",
+			Labels: []string{"aws_s3_bucket_public_access_block", stateBucketName},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"bucket":                  bucketID,
+				"block_public_acls":       tfBool(true),
+				"block_public_policy":     tfBool(true),
+				"ignore_public_acls":      tfBool(true),
+				"restrict_public_buckets": tfBool(true),
+			},
+		},
+	}
+
+	if backend.S3.DynamoDBTable != "" {
+		blocks = append(blocks, tfmapper.TerraformBlock{
+			Kind:   "resource",
+			Labels: []string{"aws_dynamodb_table", lockTableName},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"name":         tfString(backend.S3.DynamoDBTable),
+				"billing_mode": tfString("PAY_PER_REQUEST"),
+				"hash_key":     tfString("LockID"),
+				"tags":         tfTags(backend.S3.DynamoDBTable),
+			},
+			NestedBlocks: map[string][]tfmapper.NestedBlock{
+				"attribute": {{Attributes: map[string]tfmapper.TerraformValue{
+					"name": tfString("LockID"),
+					"type": tfString("S"),
+				}}},
+			},
+		})
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,52 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrapBackend_S3WithLockTable(t *testing.T) {
+	m := New()
+	blocks, err := m.BootstrapBackend(iac.StateBackend{
+		Type: iac.BackendS3,
+		S3:   &iac.S3Backend{Bucket: "acme-state", Key: "app.tfstate", DynamoDBTable: "acme-locks"},
+	})
+	require.NoError(t, err)
+
+	types := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		types = append(types, b.Labels[0])
+	}
+	assert.Equal(t, []string{
+		"aws_s3_bucket",
+		"aws_s3_bucket_versioning",
+		"aws_s3_bucket_server_side_encryption_configuration",
+		"aws_s3_bucket_public_access_block",
+		"aws_dynamodb_table",
+	}, types)
+
+	assert.Equal(t, "acme-state", *blocks[0].Attributes["bucket"].String)
+	assert.Equal(t, "aws_s3_bucket.terraform_state.id", string(*blocks[1].Attributes["bucket"].Expr))
+
+	table := blocks[4]
+	assert.Equal(t, "acme-locks", *table.Attributes["name"].String)
+	assert.Equal(t, "LockID", *table.Attributes["hash_key"].String)
+	assert.Equal(t, "PAY_PER_REQUEST", *table.Attributes["billing_mode"].String)
+	assert.Equal(t, "S", *table.NestedBlocks["attribute"][0].Attributes["type"].String)
+}
+
+func TestBootstrapBackend_WithoutLockTable(t *testing.T) {
+	blocks, err := New().BootstrapBackend(iac.StateBackend{Type: iac.BackendS3, S3: &iac.S3Backend{Bucket: "b", Key: "k"}})
+	require.NoError(t, err)
+	for _, b := range blocks {
+		assert.NotEqual(t, "aws_dynamodb_table", b.Labels[0])
+	}
+}
+
+func TestBootstrapBackend_RejectsNonS3(t *testing.T) {
+	_, err := New().BootstrapBackend(iac.StateBackend{Type: iac.BackendLocal})
+	assert.Error(t, err)
+}
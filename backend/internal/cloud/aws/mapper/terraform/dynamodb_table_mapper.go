@@ -0,0 +1,232 @@
+package terraform
+
+import (
+	"fmt"
+
+	awsdatabase "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/models/database"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// MapDynamoDBTable maps a DynamoDB resource to an aws_dynamodb_table block. The table is
+// validated first so that invalid key, index or capacity settings fail generation.
+func MapDynamoDBTable(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	if res == nil {
+		return nil, fmt.Errorf("resource is nil")
+	}
+
+	table := dynamoDBTableFromMetadata(res)
+	if err := table.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid dynamodb table %q: %w", res.Name, err)
+	}
+
+	attrs := map[string]tfmapper.TerraformValue{
+		"name":         tfStringOrVar(res.Metadata, "name", table.Name),
+		"billing_mode": tfString(string(table.BillingMode)),
+		"hash_key":     tfString(table.HashKey),
+		"tags":         tfTags(res.Name),
+	}
+	if table.RangeKey != "" {
+		attrs["range_key"] = tfString(table.RangeKey)
+	}
+	if table.BillingMode == awsdatabase.DynamoDBBillingModeProvisioned {
+		attrs["read_capacity"] = tfNumber(float64(*table.ReadCapacity))
+		attrs["write_capacity"] = tfNumber(float64(*table.WriteCapacity))
+	}
+	if table.StreamEnabled {
+		attrs["stream_enabled"] = tfBool(true)
+		attrs["stream_view_type"] = tfString(string(table.StreamViewType))
+	}
+
+	nestedBlocks := map[string][]tfmapper.NestedBlock{}
+	for _, attr := range table.Attributes {
+		nestedBlocks["attribute"] = append(nestedBlocks["attribute"], tfmapper.NestedBlock{
+			Attributes: map[string]tfmapper.TerraformValue{
+				"name": tfString(attr.Name),
+				"type": tfString(string(attr.Type)),
+			},
+		})
+	}
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		block := map[string]tfmapper.TerraformValue{
+			"name":            tfString(gsi.Name),
+			"hash_key":        tfString(gsi.HashKey),
+			"projection_type": tfString(string(gsi.ProjectionType)),
+		}
+		if gsi.RangeKey != "" {
+			block["range_key"] = tfString(gsi.RangeKey)
+		}
+		if len(gsi.NonKeyAttributes) > 0 {
+			block["non_key_attributes"] = tfStringList(gsi.NonKeyAttributes)
+		}
+		if table.BillingMode == awsdatabase.DynamoDBBillingModeProvisioned {
+			block["read_capacity"] = tfNumber(float64(*gsi.ReadCapacity))
+			block["write_capacity"] = tfNumber(float64(*gsi.WriteCapacity))
+		}
+		nestedBlocks["global_secondary_index"] = append(nestedBlocks["global_secondary_index"], tfmapper.NestedBlock{Attributes: block})
+	}
+	for _, lsi := range table.LocalSecondaryIndexes {
+		block := map[string]tfmapper.TerraformValue{
+			"name":            tfString(lsi.Name),
+			"range_key":       tfString(lsi.RangeKey),
+			"projection_type": tfString(string(lsi.ProjectionType)),
+		}
+		if len(lsi.NonKeyAttributes) > 0 {
+			block["non_key_attributes"] = tfStringList(lsi.NonKeyAttributes)
+		}
+		nestedBlocks["local_secondary_index"] = append(nestedBlocks["local_secondary_index"], tfmapper.NestedBlock{Attributes: block})
+	}
+	if table.TTLAttribute != "" {
+		nestedBlocks["ttl"] = []tfmapper.NestedBlock{{
+			Attributes: map[string]tfmapper.TerraformValue{
+				"attribute_name": tfString(table.TTLAttribute),
+				"enabled":        tfBool(true),
+			},
+		}}
+	}
+	if table.PointInTimeRecovery {
+		pitr := map[string]tfmapper.TerraformValue{
+			"enabled": tfBool(true),
+		}
+		if table.RecoveryPeriodInDays != nil {
+			pitr["recovery_period_in_days"] = tfNumber(float64(*table.RecoveryPeriodInDays))
+		}
+		nestedBlocks["point_in_time_recovery"] = []tfmapper.NestedBlock{{Attributes: pitr}}
+	}
+
+	addDependsOn(attrs, res)
+
+	return []tfmapper.TerraformBlock{
+		{
+			Kind:         "resource",
+			Labels:       []string{"aws_dynamodb_table", tfBlockName(res)},
+			Attributes:   attrs,
+			NestedBlocks: nestedBlocks,
+		},
+	}, nil
+}
+
+// dynamoDBTableFromMetadata reads the table configuration of a diagram node. Key types
+// default to S; index keys that are not table keys may be typed in "attributes".
+func dynamoDBTableFromMetadata(res *resource.Resource) *awsdatabase.DynamoDBTable {
+	m := res.Metadata
+	table := &awsdatabase.DynamoDBTable{}
+
+	table.Name, _ = getString(m, "name")
+	if table.Name == "" {
+		table.Name = tfBlockName(res)
+	}
+	table.HashKey, _ = getString(m, "hashKey")
+	table.RangeKey, _ = getString(m, "rangeKey")
+	if v, ok := getString(m, "billingMode"); ok {
+		table.BillingMode = awsdatabase.DynamoDBBillingMode(v)
+	}
+	table.ReadCapacity = getIntPtr(m, "readCapacity")
+	table.WriteCapacity = getIntPtr(m, "writeCapacity")
+
+	// Explicit attribute types win over the defaults below
+	types := map[string]awsdatabase.DynamoDBAttributeType{}
+	if list, ok := getArray(m, "attributes"); ok {
+		for _, item := range list {
+			if a, ok := item.(map[string]interface{}); ok {
+				name, _ := getString(a, "name")
+				typ, _ := getString(a, "type")
+				if name != "" && typ != "" {
+					types[name] = awsdatabase.DynamoDBAttributeType(typ)
+				}
+			}
+		}
+	}
+	if v, ok := getString(m, "hashKeyType"); ok && v != "" {
+		types[table.HashKey] = awsdatabase.DynamoDBAttributeType(v)
+	}
+	if v, ok := getString(m, "rangeKeyType"); ok && v != "" && table.RangeKey != "" {
+		types[table.RangeKey] = awsdatabase.DynamoDBAttributeType(v)
+	}
+
+	if list, ok := getArray(m, "globalSecondaryIndexes"); ok {
+		for _, item := range list {
+			idx, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			gsi := awsdatabase.DynamoDBGlobalSecondaryIndex{
+				ReadCapacity:  getIntPtr(idx, "readCapacity"),
+				WriteCapacity: getIntPtr(idx, "writeCapacity"),
+			}
+			gsi.Name, _ = getString(idx, "name")
+			gsi.HashKey, _ = getString(idx, "hashKey")
+			gsi.RangeKey, _ = getString(idx, "rangeKey")
+			if v, ok := getString(idx, "projectionType"); ok {
+				gsi.ProjectionType = awsdatabase.DynamoDBProjectionType(v)
+			}
+			gsi.NonKeyAttributes, _ = getStringSlice(idx, "nonKeyAttributes")
+			table.GlobalSecondaryIndexes = append(table.GlobalSecondaryIndexes, gsi)
+		}
+	}
+	if list, ok := getArray(m, "localSecondaryIndexes"); ok {
+		for _, item := range list {
+			idx, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			lsi := awsdatabase.DynamoDBLocalSecondaryIndex{}
+			lsi.Name, _ = getString(idx, "name")
+			lsi.RangeKey, _ = getString(idx, "rangeKey")
+			if v, ok := getString(idx, "projectionType"); ok {
+				lsi.ProjectionType = awsdatabase.DynamoDBProjectionType(v)
+			}
+			lsi.NonKeyAttributes, _ = getStringSlice(idx, "nonKeyAttributes")
+			table.LocalSecondaryIndexes = append(table.LocalSecondaryIndexes, lsi)
+		}
+	}
+
+	// Define every key once, in table then index order
+	keys := []string{table.HashKey, table.RangeKey}
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		keys = append(keys, gsi.HashKey, gsi.RangeKey)
+	}
+	for _, lsi := range table.LocalSecondaryIndexes {
+		keys = append(keys, lsi.RangeKey)
+	}
+	defined := map[string]bool{}
+	for _, key := range keys {
+		if key == "" || defined[key] {
+			continue
+		}
+		defined[key] = true
+		typ, ok := types[key]
+		if !ok {
+			typ = awsdatabase.DynamoDBAttributeTypeString
+		}
+		table.Attributes = append(table.Attributes, awsdatabase.DynamoDBAttribute{Name: key, Type: typ})
+	}
+
+	table.TTLAttribute, _ = getString(m, "ttlAttribute")
+	table.StreamEnabled, _ = getBool(m, "streamEnabled")
+	if v, ok := getString(m, "streamViewType"); ok {
+		table.StreamViewType = awsdatabase.DynamoDBStreamViewType(v)
+	}
+	table.PointInTimeRecovery, _ = getBool(m, "pointInTimeRecovery")
+	table.RecoveryPeriodInDays = getIntPtr(m, "recoveryPeriodInDays")
+
+	return table
+}
+
+// getIntPtr returns a pointer to an integer configuration value, nil when not set
+func getIntPtr(m map[string]interface{}, key string) *int {
+	v, ok := getInt(m, key)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// tfStringList converts strings to a Terraform list of string literals
+func tfStringList(items []string) tfmapper.TerraformValue {
+	vals := make([]tfmapper.TerraformValue, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, tfString(item))
+	}
+	return tfList(vals)
+}
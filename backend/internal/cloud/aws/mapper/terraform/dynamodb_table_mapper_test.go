@@ -0,0 +1,153 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapDynamoDBTable_OnDemand(t *testing.T) {
+	res := &resource.Resource{
+		Name:     "sessions",
+		Type:     resource.ResourceType{Name: "DynamoDB"},
+		Provider: "aws",
+		Metadata: map[string]interface{}{
+			"hashKey":      "session_id",
+			"ttlAttribute": "expires_at",
+		},
+	}
+
+	blocks, err := MapDynamoDBTable(res)
+
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 1)
+
+	block := blocks[0]
+	assert.Equal(t, []string{"aws_dynamodb_table", "sessions"}, block.Labels)
+	assert.Equal(t, "PAY_PER_REQUEST", *block.Attributes["billing_mode"].String)
+	assert.Equal(t, "session_id", *block.Attributes["hash_key"].String)
+	assert.NotContains(t, block.Attributes, "read_capacity")
+	assert.NotContains(t, block.Attributes, "range_key")
+
+	// The key defaults to a string attribute
+	assert.Len(t, block.NestedBlocks["attribute"], 1)
+	assert.Equal(t, "S", *block.NestedBlocks["attribute"][0].Attributes["type"].String)
+
+	assert.Len(t, block.NestedBlocks["ttl"], 1)
+	assert.Equal(t, "expires_at", *block.NestedBlocks["ttl"][0].Attributes["attribute_name"].String)
+	assert.NotContains(t, block.NestedBlocks, "point_in_time_recovery")
+}
+
+func TestMapDynamoDBTable_ProvisionedWithIndexes(t *testing.T) {
+	res := &resource.Resource{
+		Name:     "orders",
+		Type:     resource.ResourceType{Name: "DynamoDB"},
+		Provider: "aws",
+		Metadata: map[string]interface{}{
+			"name":          "orders-prod",
+			"hashKey":       "customer_id",
+			"rangeKey":      "created_at",
+			"rangeKeyType":  "N",
+			"billingMode":   "PROVISIONED",
+			"readCapacity":  float64(10),
+			"writeCapacity": float64(5),
+			"attributes": []interface{}{
+				map[string]interface{}{"name": "total", "type": "N"},
+			},
+			"globalSecondaryIndexes": []interface{}{
+				map[string]interface{}{
+					"name":             "by-status",
+					"hashKey":          "status",
+					"projectionType":   "INCLUDE",
+					"nonKeyAttributes": []interface{}{"customer_id"},
+					"readCapacity":     float64(2),
+					"writeCapacity":    float64(2),
+				},
+			},
+			"localSecondaryIndexes": []interface{}{
+				map[string]interface{}{"name": "by-total", "rangeKey": "total", "projectionType": "KEYS_ONLY"},
+			},
+			"streamEnabled":       true,
+			"streamViewType":      "NEW_IMAGE",
+			"pointInTimeRecovery": true,
+		},
+	}
+
+	blocks, err := MapDynamoDBTable(res)
+
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 1)
+
+	block := blocks[0]
+	assert.Equal(t, "orders-prod", *block.Attributes["name"].String)
+	assert.Equal(t, float64(10), *block.Attributes["read_capacity"].Number)
+	assert.Equal(t, float64(5), *block.Attributes["write_capacity"].Number)
+	assert.Equal(t, "created_at", *block.Attributes["range_key"].String)
+	assert.Equal(t, true, *block.Attributes["stream_enabled"].Bool)
+	assert.Equal(t, "NEW_IMAGE", *block.Attributes["stream_view_type"].String)
+
+	// Table keys first, then index keys; explicit types win
+	attributes := block.NestedBlocks["attribute"]
+	assert.Len(t, attributes, 4)
+	types := map[string]string{}
+	for _, a := range attributes {
+		types[*a.Attributes["name"].String] = *a.Attributes["type"].String
+	}
+	assert.Equal(t, map[string]string{"customer_id": "S", "created_at": "N", "status": "S", "total": "N"}, types)
+
+	gsi := block.NestedBlocks["global_secondary_index"]
+	assert.Len(t, gsi, 1)
+	assert.Equal(t, "INCLUDE", *gsi[0].Attributes["projection_type"].String)
+	assert.Len(t, gsi[0].Attributes["non_key_attributes"].List, 1)
+	assert.Equal(t, float64(2), *gsi[0].Attributes["read_capacity"].Number)
+
+	lsi := block.NestedBlocks["local_secondary_index"]
+	assert.Len(t, lsi, 1)
+	assert.Equal(t, "total", *lsi[0].Attributes["range_key"].String)
+
+	assert.Len(t, block.NestedBlocks["point_in_time_recovery"], 1)
+}
+
+func TestMapDynamoDBTable_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		errMsg   string
+	}{
+		{
+			name:     "missing hash key",
+			metadata: map[string]interface{}{},
+			errMsg:   "hash_key is required",
+		},
+		{
+			name:     "provisioned without capacity",
+			metadata: map[string]interface{}{"hashKey": "id", "billingMode": "PROVISIONED"},
+			errMsg:   "read_capacity and write_capacity are required",
+		},
+		{
+			name: "lsi without range key",
+			metadata: map[string]interface{}{
+				"hashKey": "id",
+				"localSecondaryIndexes": []interface{}{
+					map[string]interface{}{"name": "by-date", "rangeKey": "date"},
+				},
+			},
+			errMsg: "local secondary indexes require the table to have a range_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &resource.Resource{
+				Name:     "table",
+				Type:     resource.ResourceType{Name: "DynamoDB"},
+				Provider: "aws",
+				Metadata: tt.metadata,
+			}
+			_, err := MapDynamoDBTable(res)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,515 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/inventory"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// importSpec describes how a Terraform resource type maps back onto a diagram resource.
+// Metadata keys are the ones the map* functions in mapper.go read, so an imported
+// resource regenerates the same block.
+type importSpec struct {
+	resourceName string            // inventory resource name, e.g. "Subnet"
+	parent       string            // attribute referencing the containing resource
+	attributes   map[string]string // Terraform attribute -> metadata key
+}
+
+var importSpecs = map[string]importSpec{
+	"aws_vpc": {resourceName: "VPC", attributes: map[string]string{
+		"cidr_block":           "cidr",
+		"enable_dns_hostnames": "enable_dns_hostnames",
+		"enable_dns_support":   "enable_dns_support",
+		"instance_tenancy":     "instance_tenancy",
+	}},
+	"aws_subnet": {resourceName: "Subnet", parent: "vpc_id", attributes: map[string]string{
+		"cidr_block":              "cidr",
+		"availability_zone":       "availabilityZoneId",
+		"map_public_ip_on_launch": "map_public_ip_on_launch",
+	}},
+	"aws_instance": {resourceName: "EC2", parent: "subnet_id", attributes: map[string]string{
+		"ami":                         "ami",
+		"instance_type":               "instanceType",
+		"key_name":                    "keyName",
+		"iam_instance_profile":        "iamInstanceProfile",
+		"user_data":                   "userData",
+		"associate_public_ip_address": "associate_public_ip_address",
+	}},
+	"aws_security_group":   {resourceName: "SecurityGroup", parent: "vpc_id", attributes: map[string]string{"description": "description"}},
+	"aws_route_table":      {resourceName: "RouteTable", parent: "vpc_id"},
+	"aws_internet_gateway": {resourceName: "InternetGateway", parent: "vpc_id"},
+	"aws_nat_gateway":      {resourceName: "NATGateway", parent: "subnet_id"},
+	"aws_eip":              {resourceName: "ElasticIP", attributes: map[string]string{"domain": "domain"}},
+	"aws_vpc_endpoint": {resourceName: "VPCEndpoint", parent: "vpc_id", attributes: map[string]string{
+		"service_name":      "service_name",
+		"vpc_endpoint_type": "vpc_endpoint_type",
+		"policy":            "policy",
+	}},
+	"aws_s3_bucket": {resourceName: "S3", attributes: map[string]string{"bucket": "name"}},
+	"aws_lambda_function": {resourceName: "Lambda", attributes: map[string]string{
+		"runtime":     "runtime",
+		"handler":     "handler",
+		"role":        "role",
+		"memory_size": "memory",
+	}},
+	"aws_db_instance": {resourceName: "RDS", attributes: map[string]string{
+		"instance_class":          "instance_class",
+		"engine":                  "engine",
+		"engine_version":          "engine_version",
+		"allocated_storage":       "allocated_storage",
+		"db_name":                 "db_name",
+		"username":                "username",
+		"password":                "password",
+		"multi_az":                "multi_az",
+		"publicly_accessible":     "publicly_accessible",
+		"backup_retention_period": "backup_retention_period",
+	}},
+	"aws_lb": {resourceName: "LoadBalancer", attributes: map[string]string{
+		"load_balancer_type":         "load_balancer_type",
+		"internal":                   "internal",
+		"enable_deletion_protection": "enable_deletion_protection",
+	}},
+	"aws_lb_target_group": {resourceName: "TargetGroup", parent: "vpc_id", attributes: map[string]string{
+		"port":        "port",
+		"protocol":    "protocol",
+		"target_type": "targetType",
+	}},
+	"aws_lb_listener": {resourceName: "Listener", parent: "load_balancer_arn", attributes: map[string]string{
+		"port":     "port",
+		"protocol": "protocol",
+	}},
+	"aws_launch_template": {resourceName: "LaunchTemplate", attributes: map[string]string{
+		"image_id":      "image_id",
+		"instance_type": "instance_type",
+		"key_name":      "key_name",
+		"user_data":     "user_data",
+	}},
+	"aws_autoscaling_group": {resourceName: "AutoScalingGroup", attributes: map[string]string{
+		"min_size":         "minSize",
+		"max_size":         "maxSize",
+		"desired_capacity": "desiredCapacity",
+	}},
+}
+
+// varRefPattern matches a bare input variable reference; the diagram resolves these
+// against variable defaults and keeps the reference for regeneration.
+var varRefPattern = regexp.MustCompile(`^var\.[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// awsImport carries the state of one ImportResources call.
+type awsImport struct {
+	resolve func(string) (string, bool)
+	blocks  map[string]tfmapper.TerraformBlock // address -> block
+	issues  []tfmapper.ImportIssue
+
+	// folded blocks are merged into another resource instead of becoming nodes.
+	folded       map[string]bool
+	routes       map[string][]interface{} // route table address -> routes
+	routed       map[string]bool          // "<route table address>|<destination cidr>" of folded routes
+	associations map[string][]interface{} // route table address -> associations
+}
+
+// ImportResources maps aws_* resource blocks back to domain resources. Standalone routes
+// and route table associations are folded into their route table, DB subnet groups into
+// their instance and NAT gateway EIPs into the gateway, mirroring what MapResource emits.
+func (m *AWSMapper) ImportResources(blocks []tfmapper.TerraformBlock, resolve func(string) (string, bool)) ([]tfmapper.ImportedResource, []tfmapper.ImportIssue, error) {
+	if resolve == nil {
+		return nil, nil, fmt.Errorf("resolve is nil")
+	}
+	imp := &awsImport{
+		resolve:      resolve,
+		blocks:       make(map[string]tfmapper.TerraformBlock, len(blocks)),
+		folded:       make(map[string]bool),
+		routes:       make(map[string][]interface{}),
+		routed:       make(map[string]bool),
+		associations: make(map[string][]interface{}),
+	}
+	for _, b := range blocks {
+		if b.Kind != "resource" || len(b.Labels) != 2 {
+			return nil, nil, fmt.Errorf("expected a resource block, got %s %v", b.Kind, b.Labels)
+		}
+		imp.blocks[blockAddress(b)] = b
+	}
+	imp.foldDependents(blocks)
+
+	inv := inventory.GetDefaultInventory()
+	out := make([]tfmapper.ImportedResource, 0, len(blocks))
+	for _, b := range blocks {
+		address := blockAddress(b)
+		if imp.folded[address] {
+			continue
+		}
+		spec, ok := importSpecs[b.Labels[0]]
+		if !ok {
+			imp.issue(address, "resource type %s has no diagram equivalent", b.Labels[0])
+			continue
+		}
+		classification, ok := inv.GetResourceClassification(spec.resourceName)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: resource %q is not in the inventory", address, spec.resourceName)
+		}
+		res, err := imp.importBlock(address, b, spec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", address, err)
+		}
+		res.Type = resource.ResourceType{
+			ID:       classification.ResourceName,
+			Name:     classification.ResourceName,
+			Category: classification.Category,
+		}
+		out = append(out, tfmapper.ImportedResource{Resource: res, IRType: classification.IRType})
+	}
+	return out, imp.issues, nil
+}
+
+func (imp *awsImport) importBlock(address string, b tfmapper.TerraformBlock, spec importSpec) (*resource.Resource, error) {
+	id, ok := imp.resolve(address)
+	if !ok {
+		return nil, fmt.Errorf("no id assigned")
+	}
+	res := &resource.Resource{
+		ID:        id,
+		Name:      b.Labels[1],
+		Provider:  resource.AWS,
+		DependsOn: []string{},
+		Metadata:  make(map[string]interface{}),
+	}
+
+	names := make([]string, 0, len(spec.attributes))
+	for attr := range spec.attributes {
+		names = append(names, attr)
+	}
+	sort.Strings(names)
+	for _, attr := range names {
+		v, ok := b.Attributes[attr]
+		if !ok {
+			continue
+		}
+		if val, ok := metadataValue(v); ok {
+			res.Metadata[spec.attributes[attr]] = val
+		} else {
+			imp.issue(address+"."+attr, "expression %s cannot be represented in the diagram", describe(v))
+		}
+	}
+
+	if spec.parent != "" {
+		if v, ok := b.Attributes[spec.parent]; ok {
+			if parentID, ok := imp.reference(address+"."+spec.parent, v); ok {
+				res.ParentID = &parentID
+			}
+		}
+	}
+
+	if deps, ok := b.Attributes["depends_on"]; ok {
+		for _, dep := range deps.List {
+			if depID, ok := imp.reference(address+".depends_on", dep); ok {
+				res.DependsOn = append(res.DependsOn, depID)
+			}
+		}
+	}
+
+	switch b.Labels[0] {
+	case "aws_instance":
+		imp.referenceList(res, address, b, "vpc_security_group_ids", "securityGroups", "id")
+	case "aws_lb":
+		imp.referenceList(res, address, b, "security_groups", "securityGroups", "id")
+		imp.referenceList(res, address, b, "subnets", "subnets", "subnetId")
+	case "aws_autoscaling_group":
+		imp.referenceList(res, address, b, "vpc_zone_identifier", "subnets", "subnetId")
+		imp.referenceList(res, address, b, "target_group_arns", "targetGroupIds", "")
+		if lt := b.NestedBlocks["launch_template"]; len(lt) > 0 {
+			if v, ok := lt[0].Attributes["id"]; ok {
+				if ltID, ok := imp.reference(address+".launch_template.id", v); ok {
+					res.Metadata["launchTemplateId"] = ltID
+				}
+			}
+		}
+	case "aws_security_group":
+		imp.securityGroupRules(res, address, b)
+	case "aws_route_table":
+		imp.routeTable(res, address, b)
+	case "aws_nat_gateway":
+		imp.natGateway(res, address, b)
+	case "aws_db_instance":
+		imp.dbSubnetGroup(res, address, b)
+	}
+
+	return res, nil
+}
+
+// reference resolves a resource reference expression to the referenced resource's ID.
+func (imp *awsImport) reference(at string, v tfmapper.TerraformValue) (string, bool) {
+	if v.Expr == nil {
+		imp.issue(at, "expected a resource reference, got %s", describe(v))
+		return "", false
+	}
+	ref, ok := tfmapper.ParseReference(*v.Expr)
+	if !ok {
+		imp.issue(at, "expression %s is not a resource reference", *v.Expr)
+		return "", false
+	}
+	id, ok := imp.resolve(ref.Address())
+	if !ok {
+		imp.issue(at, "%s is not defined in the imported files", ref.Address())
+		return "", false
+	}
+	return id, true
+}
+
+// referenceList stores a list of resource references as IDs. With idField set each entry
+// becomes an object ({"id": ...}), matching how the canvas stores selections.
+func (imp *awsImport) referenceList(res *resource.Resource, address string, b tfmapper.TerraformBlock, attr, key, idField string) {
+	v, ok := b.Attributes[attr]
+	if !ok {
+		return
+	}
+	items := make([]interface{}, 0, len(v.List))
+	for _, item := range v.List {
+		id, ok := imp.reference(address+"."+attr, item)
+		if !ok {
+			continue
+		}
+		if idField == "" {
+			items = append(items, id)
+		} else {
+			items = append(items, map[string]interface{}{idField: id})
+		}
+	}
+	if len(items) > 0 {
+		res.Metadata[key] = items
+	}
+}
+
+func (imp *awsImport) securityGroupRules(res *resource.Resource, address string, b tfmapper.TerraformBlock) {
+	for _, direction := range []string{"ingress", "egress"} {
+		var rules []interface{}
+		for _, nb := range b.NestedBlocks[direction] {
+			rule := map[string]interface{}{}
+			for attr, key := range map[string]string{"protocol": "protocol", "from_port": "fromPort", "to_port": "toPort", "description": "description"} {
+				if v, ok := nb.Attributes[attr]; ok {
+					if val, ok := metadataValue(v); ok {
+						rule[key] = val
+					}
+				}
+			}
+			if sgs, ok := nb.Attributes["security_groups"]; ok && len(sgs.List) > 0 {
+				if id, ok := imp.reference(address+"."+direction+".security_groups", sgs.List[0]); ok {
+					rule["sourceSecurityGroupId"] = id
+				}
+			}
+			// The mapper writes one CIDR per rule, so a rule with several CIDRs is split.
+			cidrs := nb.Attributes["cidr_blocks"]
+			if len(cidrs.List) == 0 {
+				rules = append(rules, rule)
+				continue
+			}
+			for _, c := range cidrs.List {
+				if c.String == nil {
+					imp.issue(address+"."+direction+".cidr_blocks", "expression %s cannot be represented in the diagram", describe(c))
+					continue
+				}
+				split := make(map[string]interface{}, len(rule)+1)
+				for k, v := range rule {
+					split[k] = v
+				}
+				split["cidr"] = *c.String
+				rules = append(rules, split)
+			}
+		}
+		if len(rules) > 0 {
+			res.Metadata[direction+"Rules"] = rules
+		}
+	}
+}
+
+// foldDependents finds blocks that MapResource emits on behalf of another resource:
+// standalone routes and associations of a route table, the EIP of a NAT gateway and
+// the subnet group of a DB instance. They are merged into that resource, not drawn.
+func (imp *awsImport) foldDependents(blocks []tfmapper.TerraformBlock) {
+	for _, b := range blocks {
+		address := blockAddress(b)
+		switch b.Labels[0] {
+		case "aws_route":
+			table, ok := imp.referencedBlock(b, "route_table_id", "aws_route_table")
+			if !ok {
+				continue
+			}
+			if route, ok := imp.route(address, b.Attributes, "destination_cidr_block"); ok {
+				imp.routes[table] = append(imp.routes[table], route)
+				imp.routed[table+"|"+*b.Attributes["destination_cidr_block"].String] = true
+				imp.folded[address] = true
+			}
+		case "aws_route_table_association":
+			table, ok := imp.referencedBlock(b, "route_table_id", "aws_route_table")
+			if !ok {
+				continue
+			}
+			if v, ok := b.Attributes["subnet_id"]; ok {
+				if subnetID, ok := imp.reference(address+".subnet_id", v); ok {
+					imp.associations[table] = append(imp.associations[table], map[string]interface{}{
+						"associationType":      "Subnet",
+						"associatedResourceId": subnetID,
+					})
+					imp.folded[address] = true
+				}
+			}
+		case "aws_nat_gateway":
+			if eip, ok := imp.referencedBlock(b, "allocation_id", "aws_eip"); ok {
+				imp.folded[eip] = true
+			}
+		case "aws_db_instance":
+			if group, ok := imp.referencedBlock(b, "db_subnet_group_name", "aws_db_subnet_group"); ok {
+				imp.folded[group] = true
+			}
+		}
+	}
+}
+
+func (imp *awsImport) routeTable(res *resource.Resource, address string, b tfmapper.TerraformBlock) {
+	routes := imp.routes[address]
+	for _, nb := range b.NestedBlocks["route"] {
+		// Terraform state lists standalone aws_route entries in the table's routes too.
+		if cidr := nb.Attributes["cidr_block"].String; cidr != nil && imp.routed[address+"|"+*cidr] {
+			continue
+		}
+		if route, ok := imp.route(address+".route", nb.Attributes, "cidr_block"); ok {
+			routes = append(routes, route)
+		}
+	}
+	if len(routes) > 0 {
+		res.Metadata["routes"] = routes
+	}
+	if assoc := imp.associations[address]; len(assoc) > 0 {
+		res.Metadata["associations"] = assoc
+	}
+}
+
+// route converts a route's destination and gateway into the canvas route format.
+func (imp *awsImport) route(at string, attrs map[string]tfmapper.TerraformValue, cidrAttr string) (map[string]interface{}, bool) {
+	cidr, ok := attrs[cidrAttr]
+	if !ok || cidr.String == nil {
+		imp.issue(at, "only IPv4 CIDR routes are imported")
+		return nil, false
+	}
+	targets := []struct{ attr, targetType string }{
+		{"gateway_id", "InternetGateway"},
+		{"nat_gateway_id", "NATGateway"},
+	}
+	for _, t := range targets {
+		v, ok := attrs[t.attr]
+		if !ok {
+			continue
+		}
+		id, ok := imp.reference(at+"."+t.attr, v)
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{
+			"destination": map[string]interface{}{"cidr": *cidr.String},
+			"target":      map[string]interface{}{"type": t.targetType, "resourceId": id},
+		}, true
+	}
+	imp.issue(at, "only internet and NAT gateway routes are imported")
+	return nil, false
+}
+
+// natGateway folds an EIP that only serves as the gateway's allocation; the mapper
+// creates one for every NAT gateway without an explicit allocation ID.
+func (imp *awsImport) natGateway(res *resource.Resource, address string, b tfmapper.TerraformBlock) {
+	v, ok := b.Attributes["allocation_id"]
+	if !ok {
+		return
+	}
+	if v.String != nil {
+		res.Metadata["allocationId"] = *v.String
+		return
+	}
+	if _, ok := imp.referencedBlock(b, "allocation_id", "aws_eip"); ok {
+		return // folded; the mapper recreates it
+	}
+	imp.issue(address+".allocation_id", "expression %s cannot be represented in the diagram", describe(v))
+}
+
+// dbSubnetGroup folds the instance's aws_db_subnet_group into its subnet list.
+func (imp *awsImport) dbSubnetGroup(res *resource.Resource, address string, b tfmapper.TerraformBlock) {
+	group, ok := imp.referencedBlock(b, "db_subnet_group_name", "aws_db_subnet_group")
+	if !ok {
+		return
+	}
+	imp.referenceList(res, group, imp.blocks[group], "subnet_ids", "subnets", "subnetId")
+}
+
+// referencedBlock returns the address of the block of the given type an attribute refers to.
+func (imp *awsImport) referencedBlock(b tfmapper.TerraformBlock, attr, resourceType string) (string, bool) {
+	v, ok := b.Attributes[attr]
+	if !ok || v.Expr == nil {
+		return "", false
+	}
+	ref, ok := tfmapper.ParseReference(*v.Expr)
+	if !ok || ref.ResourceType != resourceType {
+		return "", false
+	}
+	if _, ok := imp.blocks[ref.Address()]; !ok {
+		return "", false
+	}
+	return ref.Address(), true
+}
+
+func (imp *awsImport) issue(address, format string, args ...interface{}) {
+	imp.issues = append(imp.issues, tfmapper.ImportIssue{Address: address, Message: fmt.Sprintf(format, args...)})
+}
+
+// metadataValue converts a literal (or a bare var.* reference) into a metadata value.
+func metadataValue(v tfmapper.TerraformValue) (interface{}, bool) {
+	switch {
+	case v.String != nil:
+		return *v.String, true
+	case v.Number != nil:
+		return *v.Number, true
+	case v.Bool != nil:
+		return *v.Bool, true
+	case v.Expr != nil:
+		if varRefPattern.MatchString(string(*v.Expr)) {
+			return string(*v.Expr), true
+		}
+		return nil, false
+	case v.Map != nil:
+		m := make(map[string]interface{}, len(v.Map))
+		for k, item := range v.Map {
+			val, ok := metadataValue(item)
+			if !ok {
+				return nil, false
+			}
+			m[k] = val
+		}
+		return m, true
+	case v.List != nil:
+		list := make([]interface{}, 0, len(v.List))
+		for _, item := range v.List {
+			val, ok := metadataValue(item)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, val)
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+func describe(v tfmapper.TerraformValue) string {
+	if v.Expr != nil {
+		return string(*v.Expr)
+	}
+	if val, ok := metadataValue(v); ok {
+		return fmt.Sprintf("%v", val)
+	}
+	return "value"
+}
+
+func blockAddress(b tfmapper.TerraformBlock) string {
+	return b.Labels[0] + "." + b.Labels[1]
+}
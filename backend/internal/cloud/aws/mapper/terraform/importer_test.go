@@ -0,0 +1,254 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/parser"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/importer"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importFixture = `
+provider "aws" {
+  region = var.region
+}
+
+variable "region" {
+  type    = string
+  default = "eu-west-1"
+}
+
+variable "vpc_cidr" {
+  type    = string
+  default = "10.0.0.0/16"
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = var.vpc_cidr
+}
+
+resource "aws_subnet" "public" {
+  vpc_id            = aws_vpc.main.id
+  cidr_block        = "10.0.1.0/24"
+  availability_zone = "eu-west-1a"
+}
+
+resource "aws_internet_gateway" "gw" {
+  vpc_id = aws_vpc.main.id
+}
+
+resource "aws_route_table" "public" {
+  vpc_id = aws_vpc.main.id
+}
+
+resource "aws_route" "internet" {
+  route_table_id         = aws_route_table.public.id
+  destination_cidr_block = "0.0.0.0/0"
+  gateway_id             = aws_internet_gateway.gw.id
+}
+
+resource "aws_route_table_association" "public" {
+  route_table_id = aws_route_table.public.id
+  subnet_id      = aws_subnet.public.id
+}
+
+resource "aws_security_group" "web" {
+  vpc_id      = aws_vpc.main.id
+  description = "web"
+
+  ingress {
+    protocol    = "tcp"
+    from_port   = 443
+    to_port     = 443
+    cidr_blocks = ["0.0.0.0/0", "10.0.0.0/8"]
+  }
+}
+
+resource "aws_instance" "web" {
+  ami                    = data.aws_ami.ubuntu.id
+  instance_type          = "t3.micro"
+  subnet_id              = aws_subnet.public.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+  depends_on             = [aws_internet_gateway.gw]
+}
+
+resource "aws_cloudwatch_log_group" "app" {
+  name = "app"
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+
+output "vpc_id" {
+  value = aws_vpc.main.id
+}
+`
+
+func importFixtureResult(t *testing.T) *importer.Result {
+	t.Helper()
+	mod, err := importer.ParseFiles(map[string][]byte{"main.tf": []byte(importFixture)})
+	require.NoError(t, err)
+	result, err := importer.Import(mod, New())
+	require.NoError(t, err)
+	return result
+}
+
+func nodesByID(d *parser.IRDiagram) map[string]parser.IRNode {
+	out := make(map[string]parser.IRNode, len(d.Nodes))
+	for _, n := range d.Nodes {
+		out[n.ID] = n
+	}
+	return out
+}
+
+func TestImportResources_RebuildsContainment(t *testing.T) {
+	result := importFixtureResult(t)
+	assert.Equal(t, "eu-west-1", result.Region)
+
+	nodes := nodesByID(result.Diagram)
+	// Routes, associations and the unsupported log group are not drawn.
+	assert.Len(t, nodes, 7)
+
+	parentOf := func(id string) string {
+		n, ok := nodes[id]
+		require.True(t, ok, "node %s missing", id)
+		require.NotNil(t, n.ParentID, "node %s has no parent", id)
+		return *n.ParentID
+	}
+	assert.Equal(t, "region", parentOf("main"))
+	assert.Equal(t, "main", parentOf("aws_subnet_public"))
+	assert.Equal(t, "main", parentOf("gw"))
+	assert.Equal(t, "main", parentOf("aws_route_table_public"))
+	assert.Equal(t, "aws_subnet_public", parentOf("aws_instance_web"))
+
+	assert.Equal(t, "vpc", nodes["main"].Data.ResourceType)
+	assert.Equal(t, "containerNode", nodes["main"].Type)
+	assert.Equal(t, "ec2", nodes["aws_instance_web"].Data.ResourceType)
+	assert.Equal(t, "resourceNode", nodes["aws_instance_web"].Type)
+	assert.Equal(t, "var.vpc_cidr", nodes["main"].Data.Config["cidr"])
+
+	require.Len(t, result.Diagram.Edges, 1)
+	assert.Equal(t, "aws_instance_web", result.Diagram.Edges[0].Source)
+	assert.Equal(t, "gw", result.Diagram.Edges[0].Target)
+	assert.Equal(t, "aws_vpc.main.id", result.Diagram.Outputs[0].Value)
+}
+
+func TestImportResources_FoldsRoutesAndRules(t *testing.T) {
+	nodes := nodesByID(importFixtureResult(t).Diagram)
+
+	rt := nodes["aws_route_table_public"].Data.Config
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"destination": map[string]interface{}{"cidr": "0.0.0.0/0"},
+		"target":      map[string]interface{}{"type": "InternetGateway", "resourceId": "gw"},
+	}}, rt["routes"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"associationType":      "Subnet",
+		"associatedResourceId": "aws_subnet_public",
+	}}, rt["associations"])
+
+	sg := nodes["aws_security_group_web"].Data.Config
+	rules, ok := sg["ingressRules"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, rules, 2, "one rule per CIDR")
+	assert.Equal(t, "10.0.0.0/8", rules[1].(map[string]interface{})["cidr"])
+
+	ec2 := nodes["aws_instance_web"].Data.Config
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "aws_security_group_web"}}, ec2["securityGroups"])
+}
+
+func TestImportResources_ReportsIssues(t *testing.T) {
+	result := importFixtureResult(t)
+
+	byAddress := make(map[string]string)
+	for _, issue := range result.Issues {
+		byAddress[issue.Address] = issue.Message
+	}
+	assert.Contains(t, byAddress, "data.aws_ami.ubuntu")
+	assert.Contains(t, byAddress, "aws_cloudwatch_log_group.app")
+	assert.Contains(t, byAddress["aws_instance.web.ami"], "data.aws_ami.ubuntu.id")
+}
+
+func TestImportResources_RegeneratesSameAddresses(t *testing.T) {
+	mod, err := importer.ParseFiles(map[string][]byte{"main.tf": []byte(`
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_subnet" "app" {
+  vpc_id            = aws_vpc.main.id
+  cidr_block        = "10.0.1.0/24"
+  availability_zone = "us-east-1a"
+}
+`)})
+	require.NoError(t, err)
+
+	ids := map[string]string{"aws_vpc.main": "main", "aws_subnet.app": "app"}
+	imported, issues, err := New().ImportResources(mod.Resources, func(a string) (string, bool) {
+		id, ok := ids[a]
+		return id, ok
+	})
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	require.Len(t, imported, 2)
+
+	blocks, err := New().MapResource(imported[1].Resource)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, []string{"aws_subnet", "app"}, blocks[0].Labels)
+	assert.Equal(t, tfmapper.TerraformExpr("aws_vpc.main.id"), *blocks[0].Attributes["vpc_id"].Expr)
+}
+
+const stateFixture = `{
+  "version": 4,
+  "terraform_version": "1.7.5",
+  "serial": 3,
+  "lineage": "a1",
+  "resources": [
+    {"mode": "managed", "type": "aws_vpc", "name": "main", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "vpc-1", "arn": "arn:aws:ec2:us-west-2:1:vpc/vpc-1", "cidr_block": "10.0.0.0/16"}}]},
+    {"mode": "managed", "type": "aws_subnet", "name": "public", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "subnet-1", "vpc_id": "vpc-1", "cidr_block": "10.0.1.0/24", "availability_zone": "us-west-2a"}}]},
+    {"mode": "managed", "type": "aws_internet_gateway", "name": "gw", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "igw-1", "vpc_id": "vpc-1"}}]},
+    {"mode": "managed", "type": "aws_route_table", "name": "public", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "rtb-1", "vpc_id": "vpc-1",
+       "route": [{"cidr_block": "0.0.0.0/0", "gateway_id": "igw-1", "nat_gateway_id": ""}]}}]},
+    {"mode": "managed", "type": "aws_route", "name": "internet", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "r-1", "route_table_id": "rtb-1", "destination_cidr_block": "0.0.0.0/0", "gateway_id": "igw-1"}}]},
+    {"mode": "managed", "type": "aws_instance", "name": "web", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"index_key": 0, "attributes": {"id": "i-1", "arn": "arn:aws:ec2:us-west-2:1:instance/i-1",
+       "instance_state": "running", "ami": "ami-1", "instance_type": "t3.small", "subnet_id": "subnet-1", "key_name": ""}}]}
+  ]
+}`
+
+func TestImportState_DrawsDeployedResources(t *testing.T) {
+	st, err := state.Parse([]byte(stateFixture))
+	require.NoError(t, err)
+	result, err := state.Import(st, New())
+	require.NoError(t, err)
+	assert.Equal(t, "us-west-2", result.Region)
+
+	nodes := nodesByID(result.Diagram)
+	require.Contains(t, nodes, "web_0")
+	web := nodes["web_0"]
+	require.NotNil(t, web.ParentID)
+	assert.Equal(t, "aws_subnet_public", *web.ParentID)
+	assert.Equal(t, "t3.small", web.Data.Config["instanceType"])
+	assert.NotContains(t, web.Data.Config, "keyName")
+	assert.Equal(t, map[string]interface{}{
+		"id":      "i-1",
+		"arn":     "arn:aws:ec2:us-west-2:1:instance/i-1",
+		"state":   "running",
+		"region":  "us-west-2",
+		"address": "aws_instance.web[0]",
+	}, web.Data.Config[state.DeployedKey])
+
+	// The inline copy of the standalone aws_route is not imported twice.
+	routes, ok := nodes["aws_route_table_public"].Data.Config["routes"].([]interface{})
+	require.True(t, ok)
+	assert.Len(t, routes, 1)
+}
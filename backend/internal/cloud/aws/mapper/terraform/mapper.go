@@ -40,12 +40,27 @@ func New() *AWSMapper {
 	inv.SetTerraformMapper("Listener", mapper.mapListener)
 	inv.SetTerraformMapper("TargetGroup", mapper.mapTargetGroup)
 	inv.SetTerraformMapper("VPCEndpoint", mapper.mapVPCEndpoint)
+	inv.SetTerraformMapper("DynamoDB", mapper.mapDynamoDBTable)
 
 	return mapper
 }
 
 func (m *AWSMapper) Provider() string { return "aws" }
 
+// RequiredProvider pins the hashicorp/aws provider major version the mappers target.
+func (m *AWSMapper) RequiredProvider() tfmapper.ProviderRequirement {
+	return tfmapper.ProviderRequirement{Source: "hashicorp/aws", Version: "~> 5.0"}
+}
+
+// ResourceCategory looks up the inventory classification of a resource type.
+func (m *AWSMapper) ResourceCategory(resourceType string) (string, bool) {
+	classification, ok := inventory.GetDefaultInventory().GetResourceClassification(resourceType)
+	if !ok || classification.Category == "" {
+		return "", false
+	}
+	return classification.Category, true
+}
+
 func (m *AWSMapper) SupportsResource(resourceType string) bool {
 	inv := inventory.GetDefaultInventory()
 	return inv.SupportsResource(resourceType)
@@ -108,6 +123,8 @@ func (m *AWSMapper) mapResourceFallback(res *resource.Resource) ([]tfmapper.Terr
 		return m.mapTargetGroup(res)
 	case "VPCEndpoint":
 		return m.mapVPCEndpoint(res)
+	case "DynamoDB":
+		return m.mapDynamoDBTable(res)
 	default:
 		return nil, fmt.Errorf("unsupported resource type %q", res.Type.Name)
 	}
@@ -830,6 +847,10 @@ func (m *AWSMapper) mapS3(res *resource.Resource) ([]tfmapper.TerraformBlock, er
 }
 
 var _ tfmapper.ResourceMapper = (*AWSMapper)(nil)
+var _ tfmapper.ProviderRequirer = (*AWSMapper)(nil)
+var _ tfmapper.ResourceCategorizer = (*AWSMapper)(nil)
+var _ tfmapper.BackendBootstrapper = (*AWSMapper)(nil)
+var _ tfmapper.ResourceImporter = (*AWSMapper)(nil)
 
 var tfNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
 
@@ -1624,6 +1645,8 @@ func getTerraformType(domainType string) string {
 		return "aws_s3_bucket"
 	case "RDS":
 		return "aws_db_instance"
+	case "DynamoDB":
+		return "aws_dynamodb_table"
 	case "AutoScalingGroup":
 		return "aws_autoscaling_group"
 	case "LoadBalancer":
@@ -1893,3 +1916,7 @@ func (m *AWSMapper) mapTargetGroup(res *resource.Resource) ([]tfmapper.Terraform
 func (m *AWSMapper) mapVPCEndpoint(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
 	return MapVPCEndpoint(res)
 }
+
+func (m *AWSMapper) mapDynamoDBTable(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	return MapDynamoDBTable(res)
+}
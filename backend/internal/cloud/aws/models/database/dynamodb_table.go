@@ -0,0 +1,368 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/configs"
+)
+
+// DynamoDBAttributeType is the scalar type of a key attribute
+type DynamoDBAttributeType string
+
+const (
+	DynamoDBAttributeTypeString DynamoDBAttributeType = "S"
+	DynamoDBAttributeTypeNumber DynamoDBAttributeType = "N"
+	DynamoDBAttributeTypeBinary DynamoDBAttributeType = "B"
+)
+
+// DynamoDBBillingMode controls how read and write throughput is charged
+type DynamoDBBillingMode string
+
+const (
+	DynamoDBBillingModePayPerRequest DynamoDBBillingMode = "PAY_PER_REQUEST"
+	DynamoDBBillingModeProvisioned   DynamoDBBillingMode = "PROVISIONED"
+)
+
+// DynamoDBProjectionType selects the attributes copied into a secondary index
+type DynamoDBProjectionType string
+
+const (
+	DynamoDBProjectionTypeAll      DynamoDBProjectionType = "ALL"
+	DynamoDBProjectionTypeKeysOnly DynamoDBProjectionType = "KEYS_ONLY"
+	DynamoDBProjectionTypeInclude  DynamoDBProjectionType = "INCLUDE"
+)
+
+// DynamoDBStreamViewType selects what is written to the table's stream
+type DynamoDBStreamViewType string
+
+const (
+	DynamoDBStreamViewTypeKeysOnly        DynamoDBStreamViewType = "KEYS_ONLY"
+	DynamoDBStreamViewTypeNewImage        DynamoDBStreamViewType = "NEW_IMAGE"
+	DynamoDBStreamViewTypeOldImage        DynamoDBStreamViewType = "OLD_IMAGE"
+	DynamoDBStreamViewTypeNewAndOldImages DynamoDBStreamViewType = "NEW_AND_OLD_IMAGES"
+)
+
+const (
+	// maxGlobalSecondaryIndexes is the default per-table quota of GSIs
+	maxGlobalSecondaryIndexes = 20
+	// maxLocalSecondaryIndexes is the per-table limit of LSIs
+	maxLocalSecondaryIndexes = 5
+	// maxProjectedAttributes is the limit of non-key attributes projected into all indexes of a table
+	maxProjectedAttributes = 100
+)
+
+var dynamoDBNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,255}$`)
+
+// DynamoDBAttribute defines a key attribute of the table or of one of its indexes
+type DynamoDBAttribute struct {
+	Name string                `json:"name"`
+	Type DynamoDBAttributeType `json:"type"`
+}
+
+// DynamoDBGlobalSecondaryIndex represents a global secondary index
+type DynamoDBGlobalSecondaryIndex struct {
+	// +required
+	Name string `json:"name"`
+	// +required
+	HashKey string `json:"hash_key"`
+	// +optional
+	RangeKey string `json:"range_key,omitempty"`
+	// +optional
+	ProjectionType DynamoDBProjectionType `json:"projection_type,omitempty"` // defaults to ALL
+	// +optional
+	NonKeyAttributes []string `json:"non_key_attributes,omitempty"` // INCLUDE only
+	// +optional
+	ReadCapacity *int `json:"read_capacity,omitempty"` // PROVISIONED only
+	// +optional
+	WriteCapacity *int `json:"write_capacity,omitempty"` // PROVISIONED only
+}
+
+// DynamoDBLocalSecondaryIndex represents a local secondary index; it shares the table's
+// partition key and needs a different sort key
+type DynamoDBLocalSecondaryIndex struct {
+	// +required
+	Name string `json:"name"`
+	// +required
+	RangeKey string `json:"range_key"`
+	// +optional
+	ProjectionType DynamoDBProjectionType `json:"projection_type,omitempty"` // defaults to ALL
+	// +optional
+	NonKeyAttributes []string `json:"non_key_attributes,omitempty"` // INCLUDE only
+}
+
+// DynamoDBTable represents an AWS DynamoDB table
+type DynamoDBTable struct {
+	Name string `json:"name"`
+	// +required
+	HashKey string `json:"hash_key"`
+	// +optional
+	RangeKey string `json:"range_key,omitempty"`
+	// Attributes defines every key attribute of the table and its indexes, and only those
+	// +required
+	Attributes []DynamoDBAttribute `json:"attributes"`
+	// +optional
+	BillingMode DynamoDBBillingMode `json:"billing_mode,omitempty"` // defaults to PAY_PER_REQUEST
+	// +optional
+	ReadCapacity *int `json:"read_capacity,omitempty"` // PROVISIONED only
+	// +optional
+	WriteCapacity *int `json:"write_capacity,omitempty"` // PROVISIONED only
+	// +optional
+	GlobalSecondaryIndexes []DynamoDBGlobalSecondaryIndex `json:"global_secondary_indexes,omitempty"`
+	// +optional
+	LocalSecondaryIndexes []DynamoDBLocalSecondaryIndex `json:"local_secondary_indexes,omitempty"`
+	// +optional
+	TTLAttribute string `json:"ttl_attribute,omitempty"` // TTL is enabled when set
+	// +optional
+	StreamEnabled bool `json:"stream_enabled,omitempty"`
+	// +optional
+	StreamViewType DynamoDBStreamViewType `json:"stream_view_type,omitempty"`
+	// +optional
+	PointInTimeRecovery bool `json:"point_in_time_recovery,omitempty"`
+	// +optional
+	RecoveryPeriodInDays *int `json:"recovery_period_in_days,omitempty"` // 1-35, PITR only
+	// +optional
+	Tags []configs.Tag `json:"tags,omitempty"`
+}
+
+// Validate performs AWS-specific validation. An empty billing mode defaults to
+// PAY_PER_REQUEST and an empty index projection type to ALL.
+func (t *DynamoDBTable) Validate() error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if !dynamoDBNamePattern.MatchString(t.Name) {
+		return errors.New("name must be 3-255 characters long and contain only letters, numbers, underscores, hyphens and periods")
+	}
+
+	if t.HashKey == "" {
+		return errors.New("hash_key is required")
+	}
+
+	attributeTypes, err := t.validateAttributes()
+	if err != nil {
+		return err
+	}
+
+	// Keys: every key must be a defined attribute, and every defined attribute a key
+	used := make(map[string]bool, len(attributeTypes))
+	useKey := func(owner, role, name string) error {
+		if _, ok := attributeTypes[name]; !ok {
+			return fmt.Errorf("%s %s %q is not defined in attributes", owner, role, name)
+		}
+		used[name] = true
+		return nil
+	}
+
+	if err := useKey("table", "hash_key", t.HashKey); err != nil {
+		return err
+	}
+	if t.RangeKey != "" {
+		if t.RangeKey == t.HashKey {
+			return errors.New("range_key must differ from hash_key")
+		}
+		if err := useKey("table", "range_key", t.RangeKey); err != nil {
+			return err
+		}
+	}
+
+	// Billing mode and table throughput
+	if t.BillingMode == "" {
+		t.BillingMode = DynamoDBBillingModePayPerRequest
+	}
+	provisioned := false
+	switch t.BillingMode {
+	case DynamoDBBillingModePayPerRequest:
+	case DynamoDBBillingModeProvisioned:
+		provisioned = true
+	default:
+		return fmt.Errorf("billing_mode must be %s or %s, got %q", DynamoDBBillingModePayPerRequest, DynamoDBBillingModeProvisioned, t.BillingMode)
+	}
+	if err := validateCapacity("table", provisioned, t.ReadCapacity, t.WriteCapacity); err != nil {
+		return err
+	}
+
+	// Secondary indexes
+	if len(t.GlobalSecondaryIndexes) > maxGlobalSecondaryIndexes {
+		return fmt.Errorf("a table can have at most %d global secondary indexes", maxGlobalSecondaryIndexes)
+	}
+	if len(t.LocalSecondaryIndexes) > maxLocalSecondaryIndexes {
+		return fmt.Errorf("a table can have at most %d local secondary indexes", maxLocalSecondaryIndexes)
+	}
+
+	indexNames := make(map[string]bool)
+	projected := 0
+	for i := range t.GlobalSecondaryIndexes {
+		gsi := &t.GlobalSecondaryIndexes[i]
+		owner := fmt.Sprintf("global secondary index %q", gsi.Name)
+		if err := validateIndexName(gsi.Name, indexNames); err != nil {
+			return err
+		}
+		if gsi.HashKey == "" {
+			return fmt.Errorf("%s: hash_key is required", owner)
+		}
+		if err := useKey(owner, "hash_key", gsi.HashKey); err != nil {
+			return err
+		}
+		if gsi.RangeKey != "" {
+			if gsi.RangeKey == gsi.HashKey {
+				return fmt.Errorf("%s: range_key must differ from hash_key", owner)
+			}
+			if err := useKey(owner, "range_key", gsi.RangeKey); err != nil {
+				return err
+			}
+		}
+		if gsi.ProjectionType == "" {
+			gsi.ProjectionType = DynamoDBProjectionTypeAll
+		}
+		if err := validateProjection(owner, gsi.ProjectionType, gsi.NonKeyAttributes); err != nil {
+			return err
+		}
+		projected += len(gsi.NonKeyAttributes)
+		if err := validateCapacity(owner, provisioned, gsi.ReadCapacity, gsi.WriteCapacity); err != nil {
+			return err
+		}
+	}
+
+	if len(t.LocalSecondaryIndexes) > 0 && t.RangeKey == "" {
+		return errors.New("local secondary indexes require the table to have a range_key")
+	}
+	for i := range t.LocalSecondaryIndexes {
+		lsi := &t.LocalSecondaryIndexes[i]
+		owner := fmt.Sprintf("local secondary index %q", lsi.Name)
+		if err := validateIndexName(lsi.Name, indexNames); err != nil {
+			return err
+		}
+		if lsi.RangeKey == "" {
+			return fmt.Errorf("%s: range_key is required", owner)
+		}
+		if lsi.RangeKey == t.HashKey || lsi.RangeKey == t.RangeKey {
+			return fmt.Errorf("%s: range_key must differ from the table's keys", owner)
+		}
+		if err := useKey(owner, "range_key", lsi.RangeKey); err != nil {
+			return err
+		}
+		if lsi.ProjectionType == "" {
+			lsi.ProjectionType = DynamoDBProjectionTypeAll
+		}
+		if err := validateProjection(owner, lsi.ProjectionType, lsi.NonKeyAttributes); err != nil {
+			return err
+		}
+		projected += len(lsi.NonKeyAttributes)
+	}
+	if projected > maxProjectedAttributes {
+		return fmt.Errorf("indexes can project at most %d non-key attributes in total, got %d", maxProjectedAttributes, projected)
+	}
+
+	for _, attr := range t.Attributes {
+		if !used[attr.Name] {
+			return fmt.Errorf("attribute %q is not a key of the table or of an index; only key attributes can be defined", attr.Name)
+		}
+	}
+
+	// TTL: expiry times are epoch seconds, so a key attribute used for TTL must be a number
+	if t.TTLAttribute != "" {
+		if typ, ok := attributeTypes[t.TTLAttribute]; ok && typ != DynamoDBAttributeTypeNumber {
+			return fmt.Errorf("ttl_attribute %q must be of type %s", t.TTLAttribute, DynamoDBAttributeTypeNumber)
+		}
+	}
+
+	// Streams
+	if t.StreamEnabled {
+		switch t.StreamViewType {
+		case DynamoDBStreamViewTypeKeysOnly, DynamoDBStreamViewTypeNewImage, DynamoDBStreamViewTypeOldImage, DynamoDBStreamViewTypeNewAndOldImages:
+		case "":
+			return errors.New("stream_view_type is required when stream_enabled is true")
+		default:
+			return fmt.Errorf("invalid stream_view_type %q", t.StreamViewType)
+		}
+	} else if t.StreamViewType != "" {
+		return errors.New("stream_view_type requires stream_enabled")
+	}
+
+	// Point-in-time recovery
+	if t.RecoveryPeriodInDays != nil {
+		if !t.PointInTimeRecovery {
+			return errors.New("recovery_period_in_days requires point_in_time_recovery")
+		}
+		if *t.RecoveryPeriodInDays < 1 || *t.RecoveryPeriodInDays > 35 {
+			return errors.New("recovery_period_in_days must be between 1 and 35")
+		}
+	}
+
+	return nil
+}
+
+// validateAttributes checks the attribute definitions and returns their types by name
+func (t *DynamoDBTable) validateAttributes() (map[string]DynamoDBAttributeType, error) {
+	if len(t.Attributes) == 0 {
+		return nil, errors.New("attributes are required")
+	}
+	types := make(map[string]DynamoDBAttributeType, len(t.Attributes))
+	for _, attr := range t.Attributes {
+		if attr.Name == "" {
+			return nil, errors.New("attribute name is required")
+		}
+		if _, dup := types[attr.Name]; dup {
+			return nil, fmt.Errorf("attribute %q is defined more than once", attr.Name)
+		}
+		switch attr.Type {
+		case DynamoDBAttributeTypeString, DynamoDBAttributeTypeNumber, DynamoDBAttributeTypeBinary:
+		default:
+			return nil, fmt.Errorf("attribute %q must be of type S, N or B, got %q", attr.Name, attr.Type)
+		}
+		types[attr.Name] = attr.Type
+	}
+	return types, nil
+}
+
+// validateCapacity checks the read and write capacity of the table or of a global secondary
+// index against the billing mode
+func validateCapacity(owner string, provisioned bool, read, write *int) error {
+	if !provisioned {
+		if read != nil || write != nil {
+			return fmt.Errorf("%s: read_capacity and write_capacity are only allowed with %s billing", owner, DynamoDBBillingModeProvisioned)
+		}
+		return nil
+	}
+	if read == nil || write == nil {
+		return fmt.Errorf("%s: read_capacity and write_capacity are required with %s billing", owner, DynamoDBBillingModeProvisioned)
+	}
+	if *read < 1 || *write < 1 {
+		return fmt.Errorf("%s: read_capacity and write_capacity must be at least 1", owner)
+	}
+	return nil
+}
+
+// validateIndexName checks that an index is named and that the name is unique within the table
+func validateIndexName(name string, seen map[string]bool) error {
+	if name == "" {
+		return errors.New("index name is required")
+	}
+	if !dynamoDBNamePattern.MatchString(name) {
+		return fmt.Errorf("index name %q must be 3-255 characters long and contain only letters, numbers, underscores, hyphens and periods", name)
+	}
+	if seen[name] {
+		return fmt.Errorf("index name %q is used more than once", name)
+	}
+	seen[name] = true
+	return nil
+}
+
+// validateProjection checks that non-key attributes are listed for, and only for, INCLUDE projections
+func validateProjection(owner string, projection DynamoDBProjectionType, nonKeyAttributes []string) error {
+	switch projection {
+	case DynamoDBProjectionTypeInclude:
+		if len(nonKeyAttributes) == 0 {
+			return fmt.Errorf("%s: non_key_attributes are required with projection_type %s", owner, DynamoDBProjectionTypeInclude)
+		}
+	case DynamoDBProjectionTypeAll, DynamoDBProjectionTypeKeysOnly:
+		if len(nonKeyAttributes) > 0 {
+			return fmt.Errorf("%s: non_key_attributes are only allowed with projection_type %s", owner, DynamoDBProjectionTypeInclude)
+		}
+	default:
+		return fmt.Errorf("%s: projection_type must be ALL, KEYS_ONLY or INCLUDE, got %q", owner, projection)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+func validTable() DynamoDBTable {
+	return DynamoDBTable{
+		Name:     "orders",
+		HashKey:  "customer_id",
+		RangeKey: "order_id",
+		Attributes: []DynamoDBAttribute{
+			{Name: "customer_id", Type: DynamoDBAttributeTypeString},
+			{Name: "order_id", Type: DynamoDBAttributeTypeString},
+		},
+	}
+}
+
+func TestDynamoDBTable_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(t *DynamoDBTable)
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:   "valid-on-demand",
+			modify: func(t *DynamoDBTable) {},
+		},
+		{
+			name: "valid-provisioned-with-indexes-ttl-stream-pitr",
+			modify: func(t *DynamoDBTable) {
+				t.BillingMode = DynamoDBBillingModeProvisioned
+				t.ReadCapacity = intPtr(5)
+				t.WriteCapacity = intPtr(5)
+				t.Attributes = append(t.Attributes,
+					DynamoDBAttribute{Name: "status", Type: DynamoDBAttributeTypeString},
+					DynamoDBAttribute{Name: "created_at", Type: DynamoDBAttributeTypeNumber},
+				)
+				t.GlobalSecondaryIndexes = []DynamoDBGlobalSecondaryIndex{
+					{Name: "by-status", HashKey: "status", RangeKey: "created_at", ProjectionType: DynamoDBProjectionTypeInclude, NonKeyAttributes: []string{"total"}, ReadCapacity: intPtr(2), WriteCapacity: intPtr(2)},
+				}
+				t.LocalSecondaryIndexes = []DynamoDBLocalSecondaryIndex{
+					{Name: "by-created-at", RangeKey: "created_at"},
+				}
+				t.TTLAttribute = "expires_at"
+				t.StreamEnabled = true
+				t.StreamViewType = DynamoDBStreamViewTypeNewAndOldImages
+				t.PointInTimeRecovery = true
+				t.RecoveryPeriodInDays = intPtr(7)
+			},
+		},
+		{
+			name:    "invalid-name",
+			modify:  func(t *DynamoDBTable) { t.Name = "a b" },
+			wantErr: true,
+			errMsg:  "name must be 3-255 characters",
+		},
+		{
+			name:    "missing-hash-key",
+			modify:  func(t *DynamoDBTable) { t.HashKey = "" },
+			wantErr: true,
+			errMsg:  "hash_key is required",
+		},
+		{
+			name:    "hash-key-not-defined",
+			modify:  func(t *DynamoDBTable) { t.HashKey = "tenant_id" },
+			wantErr: true,
+			errMsg:  `table hash_key "tenant_id" is not defined in attributes`,
+		},
+		{
+			name: "invalid-attribute-type",
+			modify: func(t *DynamoDBTable) {
+				t.Attributes[1].Type = "M"
+			},
+			wantErr: true,
+			errMsg:  "must be of type S, N or B",
+		},
+		{
+			name: "attribute-not-a-key",
+			modify: func(t *DynamoDBTable) {
+				t.Attributes = append(t.Attributes, DynamoDBAttribute{Name: "total", Type: DynamoDBAttributeTypeNumber})
+			},
+			wantErr: true,
+			errMsg:  `attribute "total" is not a key`,
+		},
+		{
+			name:    "invalid-billing-mode",
+			modify:  func(t *DynamoDBTable) { t.BillingMode = "RESERVED" },
+			wantErr: true,
+			errMsg:  "billing_mode must be",
+		},
+		{
+			name:    "provisioned-without-capacity",
+			modify:  func(t *DynamoDBTable) { t.BillingMode = DynamoDBBillingModeProvisioned },
+			wantErr: true,
+			errMsg:  "table: read_capacity and write_capacity are required",
+		},
+		{
+			name:    "on-demand-with-capacity",
+			modify:  func(t *DynamoDBTable) { t.ReadCapacity = intPtr(5) },
+			wantErr: true,
+			errMsg:  "only allowed with PROVISIONED billing",
+		},
+		{
+			name: "gsi-include-without-attributes",
+			modify: func(t *DynamoDBTable) {
+				t.GlobalSecondaryIndexes = []DynamoDBGlobalSecondaryIndex{
+					{Name: "by-order", HashKey: "order_id", ProjectionType: DynamoDBProjectionTypeInclude},
+				}
+			},
+			wantErr: true,
+			errMsg:  "non_key_attributes are required",
+		},
+		{
+			name: "duplicate-index-name",
+			modify: func(t *DynamoDBTable) {
+				t.Attributes = append(t.Attributes, DynamoDBAttribute{Name: "created_at", Type: DynamoDBAttributeTypeNumber})
+				t.GlobalSecondaryIndexes = []DynamoDBGlobalSecondaryIndex{{Name: "by-order", HashKey: "order_id"}}
+				t.LocalSecondaryIndexes = []DynamoDBLocalSecondaryIndex{{Name: "by-order", RangeKey: "created_at"}}
+			},
+			wantErr: true,
+			errMsg:  `index name "by-order" is used more than once`,
+		},
+		{
+			name: "lsi-without-table-range-key",
+			modify: func(t *DynamoDBTable) {
+				t.RangeKey = ""
+				t.LocalSecondaryIndexes = []DynamoDBLocalSecondaryIndex{{Name: "by-order", RangeKey: "order_id"}}
+			},
+			wantErr: true,
+			errMsg:  "local secondary indexes require the table to have a range_key",
+		},
+		{
+			name:    "ttl-on-string-key",
+			modify:  func(t *DynamoDBTable) { t.TTLAttribute = "order_id" },
+			wantErr: true,
+			errMsg:  `ttl_attribute "order_id" must be of type N`,
+		},
+		{
+			name:    "stream-without-view-type",
+			modify:  func(t *DynamoDBTable) { t.StreamEnabled = true },
+			wantErr: true,
+			errMsg:  "stream_view_type is required",
+		},
+		{
+			name:    "view-type-without-stream",
+			modify:  func(t *DynamoDBTable) { t.StreamViewType = DynamoDBStreamViewTypeKeysOnly },
+			wantErr: true,
+			errMsg:  "stream_view_type requires stream_enabled",
+		},
+		{
+			name: "recovery-period-out-of-range",
+			modify: func(t *DynamoDBTable) {
+				t.PointInTimeRecovery = true
+				t.RecoveryPeriodInDays = intPtr(36)
+			},
+			wantErr: true,
+			errMsg:  "recovery_period_in_days must be between 1 and 35",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := validTable()
+			tt.modify(&table)
+			err := table.Validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				if tt.errMsg != "" && !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error to contain %q, got %q", tt.errMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDynamoDBTable_ValidateDefaults(t *testing.T) {
+	table := validTable()
+	table.GlobalSecondaryIndexes = []DynamoDBGlobalSecondaryIndex{{Name: "by-order", HashKey: "order_id"}}
+	if err := table.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if table.BillingMode != DynamoDBBillingModePayPerRequest {
+		t.Errorf("Expected billing mode %s, got %s", DynamoDBBillingModePayPerRequest, table.BillingMode)
+	}
+	if table.GlobalSecondaryIndexes[0].ProjectionType != DynamoDBProjectionTypeAll {
+		t.Errorf("Expected projection type %s, got %s", DynamoDBProjectionTypeAll, table.GlobalSecondaryIndexes[0].ProjectionType)
+	}
+}
@@ -2,6 +2,7 @@ package pricing
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"time"
@@ -50,8 +51,15 @@ func NewAWSPricingCalculatorWithRepos(
 	}
 }
 
-// CalculateResourceCost calculates the cost for a single resource over a given duration
+// CalculateResourceCost calculates the On-Demand cost for a single resource over a given duration
 func (c *AWSPricingCalculator) CalculateResourceCost(ctx context.Context, res *resource.Resource, duration time.Duration) (*domainpricing.CostEstimate, error) {
+	return c.CalculateResourceCostWithPurchaseOption(ctx, res, duration, domainpricing.OnDemand)
+}
+
+// CalculateResourceCostWithPurchaseOption calculates the cost for a single resource over a given
+// duration, with EC2 instance hours paid under the purchase option (Reserved Instance or Savings Plan).
+// Other resources, and hidden dependencies, are priced On-Demand.
+func (c *AWSPricingCalculator) CalculateResourceCostWithPurchaseOption(ctx context.Context, res *resource.Resource, duration time.Duration, option domainpricing.PurchaseOption) (*domainpricing.CostEstimate, error) {
 	if res.Provider != "aws" {
 		return nil, fmt.Errorf("unsupported provider: %s", res.Provider)
 	}
@@ -60,6 +68,9 @@ func (c *AWSPricingCalculator) CalculateResourceCost(ctx context.Context, res *r
 	inv := inventory.GetDefaultInventory()
 	if functions, ok := inv.GetFunctions(res.Type.Name); ok && functions.PricingCalculator != nil {
 		estimate, err := functions.PricingCalculator(res, duration)
+		if err == nil {
+			applyUptime(res, estimate)
+		}
 		if err == nil && c.useDBRates {
 			// Enhance with hidden dependencies if using DB rates
 			return c.enhanceWithHiddenDependencies(ctx, res, estimate, duration)
@@ -67,17 +78,58 @@ func (c *AWSPricingCalculator) CalculateResourceCost(ctx context.Context, res *r
 		return estimate, err
 	}
 
+	// Resources of the region catalog are priced with the region's rates
+	if catalogResourceTypes[c.mapToPricingResourceType(res.Type.Name)] {
+		estimate, err := c.calculateWithRegionCatalog(ctx, res, duration, option)
+		if err != nil {
+			return nil, err
+		}
+		applyUptime(res, estimate)
+		estimate.PurchaseOption = option
+		return estimate, nil
+	}
+
 	// Use DB rates if available, otherwise fallback
+	var estimate *domainpricing.CostEstimate
+	var err error
 	if c.useDBRates && c.pricingRateRepo != nil {
-		return c.calculateWithDBRates(ctx, res, duration)
+		estimate, err = c.calculateWithDBRates(ctx, res, duration, option)
+	} else {
+		// Fallback to switch-based calculation
+		estimate, err = c.calculateResourceCostFallback(ctx, res, duration, option)
+	}
+	if err != nil {
+		return nil, err
 	}
+	applyUptime(res, estimate)
+	estimate.PurchaseOption = option
+	return estimate, nil
+}
 
-	// Fallback to switch-based calculation
-	return c.calculateResourceCostFallback(ctx, res, duration)
+// applyUptime scales the On-Demand hourly components of an estimate to the share of the
+// time the resource runs, as set by its usage profile schedule. Commitments are paid for
+// every hour of the term and are not scaled.
+func applyUptime(res *resource.Resource, estimate *domainpricing.CostEstimate) {
+	fraction := metadataFloat(res, "uptime_fraction")
+	if fraction <= 0 || fraction >= 1 {
+		return
+	}
+	var delta float64
+	for i := range estimate.Breakdown {
+		component := &estimate.Breakdown[i]
+		if component.Model != domainpricing.PerHour {
+			continue
+		}
+		scaled := component.Subtotal * fraction
+		delta += scaled - component.Subtotal
+		component.Quantity *= fraction
+		component.Subtotal = scaled
+	}
+	estimate.TotalCost += delta
 }
 
 // calculateWithDBRates calculates cost using database rates and hidden dependencies
-func (c *AWSPricingCalculator) calculateWithDBRates(ctx context.Context, res *resource.Resource, duration time.Duration) (*domainpricing.CostEstimate, error) {
+func (c *AWSPricingCalculator) calculateWithDBRates(ctx context.Context, res *resource.Resource, duration time.Duration, option domainpricing.PurchaseOption) (*domainpricing.CostEstimate, error) {
 	// Map resource type to pricing resource type
 	pricingResourceType := c.mapToPricingResourceType(res.Type.Name)
 
@@ -109,9 +161,12 @@ func (c *AWSPricingCalculator) calculateWithDBRates(ctx context.Context, res *re
 		}
 
 		dbRates, err = c.pricingRateRepo.FindByInstanceType(ctx, "aws", instanceType, region, operatingSystem)
+		if err == nil {
+			dbRates = ratesForPurchaseOption(dbRates, option)
+		}
 		if err != nil || len(dbRates) == 0 {
 			// Fallback to hardcoded rates if DB rates not found
-			return c.calculateResourceCostFallback(ctx, res, duration)
+			return c.calculateResourceCostFallback(ctx, res, duration, option)
 		}
 	} else {
 		// For other resource types, use standard lookup
@@ -123,7 +178,7 @@ func (c *AWSPricingCalculator) calculateWithDBRates(ctx context.Context, res *re
 		dbRates, err = c.pricingRateRepo.FindActiveRates(ctx, "aws", pricingResourceType, region)
 		if err != nil || len(dbRates) == 0 {
 			// Fallback to hardcoded rates if DB rates not found
-			return c.calculateResourceCostFallback(ctx, res, duration)
+			return c.calculateResourceCostFallback(ctx, res, duration, option)
 		}
 	}
 
@@ -185,6 +240,47 @@ func (c *AWSPricingCalculator) calculateWithDBRates(ctx context.Context, res *re
 	}, nil
 }
 
+// calculateWithRegionCatalog calculates the static estimate of a resource and re-prices
+// it with the region catalog, adding hidden dependencies when using DB rates
+func (c *AWSPricingCalculator) calculateWithRegionCatalog(ctx context.Context, res *resource.Resource, duration time.Duration, option domainpricing.PurchaseOption) (*domainpricing.CostEstimate, error) {
+	estimate, err := c.calculateResourceCostFallback(ctx, res, duration, option)
+	if err != nil {
+		return nil, err
+	}
+	catalog := c.loadCatalog(ctx, c.mapToPricingResourceType(res.Type.Name), res.Region)
+	if err := priceForRegion(res, estimate, catalog); err != nil {
+		return nil, err
+	}
+	if c.useDBRates {
+		return c.enhanceWithHiddenDependencies(ctx, res, estimate, duration)
+	}
+	return estimate, nil
+}
+
+// ratesForPurchaseOption selects the EC2 instance rates of a purchase option: the On-Demand
+// rates, or the imported commitment rates recording the option in their metadata
+func ratesForPurchaseOption(rates []*models.PricingRate, option domainpricing.PurchaseOption) []*models.PricingRate {
+	var selected []*models.PricingRate
+	for _, rate := range rates {
+		model := domainpricing.PricingModel(rate.PricingModel)
+		isCommitment := model == domainpricing.Reserved || model == domainpricing.SavingsPlan
+		if !option.IsCommitment() {
+			if !isCommitment {
+				selected = append(selected, rate)
+			}
+			continue
+		}
+		if !isCommitment {
+			continue
+		}
+		var meta map[string]interface{}
+		if json.Unmarshal(rate.Metadata, &meta) == nil && meta["purchase_option"] == string(option) {
+			selected = append(selected, rate)
+		}
+	}
+	return selected
+}
+
 // enhanceWithHiddenDependencies enhances an existing estimate with hidden dependency costs
 func (c *AWSPricingCalculator) enhanceWithHiddenDependencies(ctx context.Context, res *resource.Resource, estimate *domainpricing.CostEstimate, duration time.Duration) (*domainpricing.CostEstimate, error) {
 	if c.hiddenDepResolver == nil {
@@ -226,7 +322,7 @@ func (c *AWSPricingCalculator) calculateComponentCost(rate *models.PricingRate,
 	var subtotal float64
 
 	switch rate.PricingModel {
-	case "per_hour":
+	case "per_hour", "reserved", "savings_plan":
 		quantity = duration.Hours()
 		subtotal = rate.Rate * quantity
 	case "per_gb":
@@ -303,8 +399,24 @@ func (c *AWSPricingCalculator) mapToPricingResourceType(domainType string) strin
 	return domainType
 }
 
+// metadataFloat reads a numeric configuration value, 0 when not set
+func metadataFloat(res *resource.Resource, key string) float64 {
+	return mapFloat(res.Metadata, key)
+}
+
+// mapFloat reads a numeric value of a configuration object, 0 when not set
+func mapFloat(m map[string]interface{}, key string) float64 {
+	switch v := m[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
 // calculateResourceCostFallback provides backward compatibility with switch-based calculation
-func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context, res *resource.Resource, duration time.Duration) (*domainpricing.CostEstimate, error) {
+func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context, res *resource.Resource, duration time.Duration, option domainpricing.PurchaseOption) (*domainpricing.CostEstimate, error) {
 	// Get pricing information for the resource type
 	pricingInfo, err := c.GetResourcePricing(ctx, res.Type.Name, "aws", res.Region)
 	if err != nil {
@@ -317,10 +429,8 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 
 	switch res.Type.Name {
 	case "nat_gateway":
-		// For NAT Gateway, we need to estimate data processing
-		// In a real scenario, this would come from usage metrics
-		// For now, we default to 0 - this can be extended to accept usage metrics
-		estimatedDataGB := 0.0
+		// Data processed comes from the usage profile, defaulting to none
+		estimatedDataGB := metadataFloat(res, "data_processed_gb")
 		cost := networking.CalculateNATGatewayCost(duration, estimatedDataGB, res.Region)
 		totalCost = cost
 		breakdown = []domainpricing.CostComponent{
@@ -398,11 +508,24 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 		hourlyRate := instancePricing.Components[0].Rate
 
 		cost := compute.CalculateEC2InstanceCost(duration, instanceType, res.Region)
+		componentName := "EC2 Instance Hourly"
+		model := domainpricing.PerHour
+
+		// Commitments are priced from the On-Demand rate when no imported rate exists
+		if option.IsCommitment() {
+			if rate, ok := compute.GetEC2InstanceCommitmentRate(instanceType, res.Region, option); ok {
+				hourlyRate = rate
+				cost = rate * duration.Hours()
+				componentName = "EC2 Instance " + option.DisplayName()
+				model = option.Model()
+			}
+		}
+
 		totalCost = cost
 		breakdown = []domainpricing.CostComponent{
 			{
-				ComponentName: "EC2 Instance Hourly",
-				Model:         domainpricing.PerHour,
+				ComponentName: componentName,
+				Model:         model,
 				Quantity:      duration.Hours(),
 				UnitRate:      hourlyRate,
 				Subtotal:      cost,
@@ -460,6 +583,34 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 			},
 		}
 
+	case "dynamodb_table":
+		// Capacity and table settings come from the diagram, requests and size from the usage profile
+		usage := database.DynamoDBTableUsage{
+			BillingMode:   metadataString(res, "billingMode", database.DynamoDBOnDemand),
+			ReadCapacity:  metadataFloat(res, "readCapacity"),
+			WriteCapacity: metadataFloat(res, "writeCapacity"),
+			ReadRequests:  metadataFloat(res, "read_requests"),
+			WriteRequests: metadataFloat(res, "write_requests"),
+			StorageGB:     metadataFloat(res, "size_gb"),
+		}
+		// Global secondary indexes have their own provisioned capacity
+		if indexes, ok := res.Metadata["globalSecondaryIndexes"].([]interface{}); ok {
+			for _, item := range indexes {
+				if index, ok := item.(map[string]interface{}); ok {
+					usage.ReadCapacity += mapFloat(index, "readCapacity")
+					usage.WriteCapacity += mapFloat(index, "writeCapacity")
+				}
+			}
+		}
+		if pitr, ok := res.Metadata["pointInTimeRecovery"].(bool); ok {
+			usage.PointInTimeRecovery = pitr
+		}
+
+		breakdown = database.DynamoDBTableCostComponents(duration, usage, res.Region)
+		for _, component := range breakdown {
+			totalCost += component.Subtotal
+		}
+
 	case "ebs_volume":
 		// Extract size and volume type from metadata
 		sizeGB := 0.0
@@ -527,6 +678,21 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 			},
 		}
 
+		// Capacity units come from the usage profile
+		if lcus := metadataFloat(res, "lcus"); lcus > 0 && len(lbPricing.Components) > 1 {
+			lcuRate := lbPricing.Components[1].Rate
+			lcuCost := compute.CalculateLoadBalancerLCUCost(duration, lbType, lcus, res.Region)
+			totalCost += lcuCost
+			breakdown = append(breakdown, domainpricing.CostComponent{
+				ComponentName: "Load Balancer LCU",
+				Model:         domainpricing.PerHour,
+				Quantity:      lcus * duration.Hours(),
+				UnitRate:      lcuRate,
+				Subtotal:      lcuCost,
+				Currency:      domainpricing.USD,
+			})
+		}
+
 	case "auto_scaling_group":
 		// Extract instance type and capacity from metadata
 		instanceType := "t3.micro" // Default
@@ -553,6 +719,13 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 		hourlyRate := asgPricing.Components[0].Rate
 
 		cost := compute.CalculateAutoScalingGroupCost(duration, instanceType, minSize, maxSize, res.Region)
+
+		// The usage profile's average capacity replaces the (min+max)/2 estimate
+		if avgCapacity := metadataFloat(res, "average_capacity"); avgCapacity > 0 {
+			instanceRate, _ := asgPricing.Metadata["instance_hourly_rate"].(float64)
+			hourlyRate = avgCapacity * instanceRate
+			cost = hourlyRate * duration.Hours()
+		}
 		totalCost = cost
 
 		breakdown = []domainpricing.CostComponent{
@@ -862,6 +1035,31 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 			}
 		}
 
+	case DataTransferResourceType:
+		// Traffic along an edge of the usage profile, out to the internet or between zones
+		direction := networking.Outbound
+		componentName := DataTransferOutComponent
+		if metadataString(res, "direction", "") == string(networking.InterAZ) {
+			direction = networking.InterAZ
+			componentName = DataTransferInterAZComponent
+		}
+		amountGB := metadataFloat(res, "data_transfer_gb")
+		rate := networking.DataTransferPricing[direction]
+
+		cost := networking.CalculateDataTransferCost(amountGB, direction, res.Region)
+		totalCost = cost
+		breakdown = []domainpricing.CostComponent{}
+		if cost > 0 {
+			breakdown = append(breakdown, domainpricing.CostComponent{
+				ComponentName: componentName,
+				Model:         domainpricing.PerGB,
+				Quantity:      cost / rate,
+				UnitRate:      rate,
+				Subtotal:      cost,
+				Currency:      domainpricing.USD,
+			})
+		}
+
 	default:
 		// For other resource types, use generic calculation
 		// This can be extended for other resource types
@@ -893,11 +1091,18 @@ func (c *AWSPricingCalculator) calculateResourceCostFallback(ctx context.Context
 
 // CalculateArchitectureCost calculates the total cost for multiple resources over a given duration
 func (c *AWSPricingCalculator) CalculateArchitectureCost(ctx context.Context, resources []*resource.Resource, duration time.Duration) (*domainpricing.CostEstimate, error) {
+	return c.CalculateArchitectureCostWithUsage(ctx, resources, duration, nil)
+}
+
+// CalculateArchitectureCostWithUsage calculates the total cost for multiple resources over a
+// given duration under a usage profile: request counts, data processed, capacity and uptime
+// schedules of the resources, and the data transferred along the edges of the diagram
+func (c *AWSPricingCalculator) CalculateArchitectureCostWithUsage(ctx context.Context, resources []*resource.Resource, duration time.Duration, usage *domainpricing.UsageProfile) (*domainpricing.CostEstimate, error) {
 	var totalCost float64
 	var allBreakdown []domainpricing.CostComponent
 	var allHiddenDepCosts []domainpricing.HiddenDependencyCost
 
-	for _, res := range resources {
+	for _, res := range domainpricing.ApplyUsageProfile(resources, usage, duration) {
 		estimate, err := c.CalculateResourceCost(ctx, res, duration)
 		if err != nil {
 			// Log error but continue with other resources
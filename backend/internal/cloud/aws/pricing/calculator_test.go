@@ -2,10 +2,12 @@ package pricing
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
 
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
@@ -312,6 +314,27 @@ func TestAWSPricingCalculator_CalculateResourceCost(t *testing.T) {
 			expectError:  false,
 			expectedCost: 0.0000166667*(512.0/1024.0)*(300.0/1000.0)*5000000.0 + (0.20/1000000.0)*4000000.0 + 0.09*19.0,
 		},
+		{
+			name: "dynamodb-provisioned-720-hours",
+			resource: &resource.Resource{
+				Type: resource.ResourceType{
+					Name: "dynamodb_table",
+				},
+				Provider: "aws",
+				Region:   "us-east-1",
+				Metadata: map[string]interface{}{
+					"billingMode":   "PROVISIONED",
+					"readCapacity":  50.0,
+					"writeCapacity": 30.0,
+					"globalSecondaryIndexes": []interface{}{
+						map[string]interface{}{"name": "by-status", "readCapacity": 25.0, "writeCapacity": 0.0},
+					},
+				},
+			},
+			duration:     720 * time.Hour,
+			expectError:  false,
+			expectedCost: 0.00013*50*720 + 0.00065*5*720, // 25 RCUs and 25 WCUs free
+		},
 		{
 			name: "unsupported-resource-type",
 			resource: &resource.Resource{
@@ -418,6 +441,183 @@ func TestAWSPricingCalculator_CalculateArchitectureCost(t *testing.T) {
 	}
 }
 
+func TestAWSPricingCalculator_CalculateResourceCostWithPurchaseOption(t *testing.T) {
+	calculator := NewAWSPricingCalculator(NewAWSPricingService())
+	ctx := context.Background()
+	duration := 720 * time.Hour
+
+	ec2 := &resource.Resource{
+		Type:     resource.ResourceType{Name: "ec2_instance"},
+		Provider: "aws",
+		Region:   "us-east-1",
+		Metadata: map[string]interface{}{"instance_type": "m5.large"},
+	}
+
+	onDemand, err := calculator.CalculateResourceCost(ctx, ec2, duration)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if onDemand.PurchaseOption != domainpricing.OnDemand {
+		t.Errorf("Expected on-demand purchase option, got %q", onDemand.PurchaseOption)
+	}
+
+	for _, option := range domainpricing.PurchaseOptions[1:] {
+		estimate, err := calculator.CalculateResourceCostWithPurchaseOption(ctx, ec2, duration, option)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", option, err)
+		}
+		if estimate.TotalCost >= onDemand.TotalCost {
+			t.Errorf("%s: expected less than on-demand %.2f, got %.2f", option, onDemand.TotalCost, estimate.TotalCost)
+		}
+		if estimate.Breakdown[0].Model != option.Model() {
+			t.Errorf("%s: expected model %s, got %s", option, option.Model(), estimate.Breakdown[0].Model)
+		}
+	}
+
+	// 3 year all upfront commitments are the cheapest
+	reserved, _ := calculator.CalculateResourceCostWithPurchaseOption(ctx, ec2, duration, domainpricing.Reserved3YrAllUpfront)
+	expected := 0.096 * 0.40 * 720
+	if math.Abs(reserved.TotalCost-expected) > 0.001 {
+		t.Errorf("Expected reserved 3yr all upfront cost %.4f, got %.4f", expected, reserved.TotalCost)
+	}
+
+	// Commitments do not change the price of other resources
+	nat := &resource.Resource{Type: resource.ResourceType{Name: "nat_gateway"}, Provider: "aws", Region: "us-east-1"}
+	natEstimate, err := calculator.CalculateResourceCostWithPurchaseOption(ctx, nat, duration, domainpricing.Reserved1YrNoUpfront)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(natEstimate.TotalCost-32.40) > 0.001 {
+		t.Errorf("Expected NAT gateway cost 32.40, got %.2f", natEstimate.TotalCost)
+	}
+}
+
+func TestAWSPricingCalculator_RegionCatalog(t *testing.T) {
+	calculator := NewAWSPricingCalculator(NewAWSPricingService())
+	ctx := context.Background()
+	duration := 720 * time.Hour
+
+	// Static rates price us-east-1 only
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		nat := &resource.Resource{Type: resource.ResourceType{Name: "nat_gateway"}, Provider: "aws", Region: region}
+		_, err := calculator.CalculateResourceCost(ctx, nat, duration)
+		var regionErr *RegionPricingError
+		if region == StaticPricingRegion && err != nil {
+			t.Errorf("%s: unexpected error: %v", region, err)
+		}
+		if region != StaticPricingRegion && !errors.As(err, &regionErr) {
+			t.Errorf("%s: expected a region pricing error, got %v", region, err)
+		}
+	}
+
+	// Catalog rates replace the static rates of each component
+	region := "eu-west-1"
+	rate := func(resourceType, component string, value float64, rateRegion *string) *models.PricingRate {
+		return &models.PricingRate{ResourceType: resourceType, ComponentName: component, Rate: value, Region: rateRegion}
+	}
+	s3 := &resource.Resource{
+		Type:     resource.ResourceType{Name: "s3_bucket"},
+		Provider: "aws",
+		Region:   region,
+		Metadata: map[string]interface{}{"size_gb": 100.0, "storage_class": "standard-ia", "data_transfer_gb": 11.0},
+	}
+	estimate, err := calculator.calculateResourceCostFallback(ctx, s3, duration, domainpricing.OnDemand)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	catalog := map[string][]*models.PricingRate{
+		"s3_bucket": {
+			rate("s3_bucket", "S3 Storage (standard)", 0.023, &region),
+			rate("s3_bucket", "S3 Storage (standard-ia)", 0.0128, nil),
+			rate("s3_bucket", "S3 Storage (standard-ia)", 0.0135, &region),
+		},
+		DataTransferResourceType: {rate(DataTransferResourceType, DataTransferOutComponent, 0.085, &region)},
+	}
+	if err := priceForRegion(s3, estimate, catalog); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := 100*0.0135 + 10*0.085
+	if math.Abs(estimate.TotalCost-expected) > 0.0001 {
+		t.Errorf("Expected cost %.4f, got %.4f", expected, estimate.TotalCost)
+	}
+
+	// A component without a catalog rate is reported
+	estimate, _ = calculator.calculateResourceCostFallback(ctx, s3, duration, domainpricing.OnDemand)
+	err = priceForRegion(s3, estimate, map[string][]*models.PricingRate{"s3_bucket": catalog["s3_bucket"]})
+	var regionErr *RegionPricingError
+	if !errors.As(err, &regionErr) || len(regionErr.Components) != 1 || regionErr.Components[0] != "S3 Data Transfer Out" {
+		t.Errorf("Expected the data transfer component to be reported, got %v", err)
+	}
+}
+
+func TestAWSPricingCalculator_CalculateArchitectureCostWithUsage(t *testing.T) {
+	calculator := NewAWSPricingCalculator(NewAWSPricingService())
+	ctx := context.Background()
+	duration := 720 * time.Hour
+
+	newResource := func(name, resourceType string, metadata map[string]interface{}) *resource.Resource {
+		return &resource.Resource{ID: name, Name: name, Type: resource.ResourceType{Name: resourceType}, Provider: "aws", Region: "us-east-1", Metadata: metadata}
+	}
+	resources := []*resource.Resource{
+		newResource("nat", "nat_gateway", map[string]interface{}{"availabilityZone": "us-east-1a"}),
+		newResource("alb", "load_balancer", map[string]interface{}{"load_balancer_type": "application"}),
+		newResource("fn", "lambda_function", nil),
+		newResource("web", "load_balancer", map[string]interface{}{"availabilityZone": "us-east-1a"}),
+	}
+	usage := &domainpricing.UsageProfile{
+		Resources: map[string]domainpricing.ResourceUsage{
+			"nat": {DataProcessedGB: 100},
+			"alb": {LCUs: 2, Schedule: &domainpricing.UptimeSchedule{Preset: domainpricing.BusinessHours}},
+			"fn":  {RequestsPerMonth: 3000000, AverageDurationMs: 200},
+		},
+		Edges: []domainpricing.EdgeTraffic{
+			{Source: "fn", GBPerMonth: 101},
+			{Source: "alb", Target: "nat", GBPerMonth: 50},
+			{Source: "web", Target: "nat", GBPerMonth: 500}, // same zone, free
+		},
+	}
+
+	withoutUsage, err := calculator.CalculateArchitectureCost(ctx, resources, duration)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	estimate, err := calculator.CalculateArchitectureCostWithUsage(ctx, resources, duration, usage)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	subtotals := make(map[string]float64)
+	for _, component := range estimate.Breakdown {
+		subtotals[component.ComponentName] += component.Subtotal
+	}
+	expected := map[string]float64{
+		"NAT Gateway Data Processing": 0.045 * 100,
+		"Load Balancer LCU":           0.008 * 2 * 720 * 50 / 168,
+		"Lambda Requests":             0.20 * 2,
+		DataTransferOutComponent:      0.09 * 100,
+		DataTransferInterAZComponent:  0.01 * 50,
+		"Load Balancer Hourly":        0.0225*720*50/168 + 0.0225*720,
+		"Lambda Compute":              0.0000166667 * 0.125 * 0.2 * 3000000,
+		"NAT Gateway Hourly":          0.045 * 720,
+	}
+	for name, subtotal := range expected {
+		if math.Abs(subtotals[name]-subtotal) > 0.01 {
+			t.Errorf("%s: expected %.4f, got %.4f", name, subtotal, subtotals[name])
+		}
+	}
+
+	var total float64
+	for _, subtotal := range expected {
+		total += subtotal
+	}
+	if math.Abs(estimate.TotalCost-total) > 0.01 {
+		t.Errorf("Expected total %.4f, got %.4f", total, estimate.TotalCost)
+	}
+	if estimate.TotalCost <= withoutUsage.TotalCost {
+		t.Errorf("Expected usage to add cost to %.2f, got %.2f", withoutUsage.TotalCost, estimate.TotalCost)
+	}
+}
+
 func TestAWSPricingCalculator_GetResourcePricing(t *testing.T) {
 	service := NewAWSPricingService()
 	calculator := NewAWSPricingCalculator(service)
@@ -537,3 +737,29 @@ func TestAWSPricingCalculator_GetResourcePricing(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFreeTierOffer(t *testing.T) {
+	res := func(metadata map[string]interface{}) *resource.Resource {
+		return &resource.Resource{Provider: resource.AWS, Region: "us-east-1", Metadata: metadata}
+	}
+
+	offer, ok := GetFreeTierOffer(res(map[string]interface{}{"instance_type": "t2.micro"}), "EC2 Instance Hourly")
+	if !ok || offer.Amount != 750 || offer.ExpiresAfterMonths != 12 {
+		t.Errorf("expected 750 free hours for 12 months on a t2.micro, got %+v", offer)
+	}
+	if _, ok := GetFreeTierOffer(res(map[string]interface{}{"instance_type": "m5.large"}), "EC2 Instance Hourly"); ok {
+		t.Error("expected an m5.large not to be eligible")
+	}
+	if _, ok := GetFreeTierOffer(res(nil), "RDS Instance Hourly"); !ok {
+		t.Error("expected the default db.t3.micro to be eligible")
+	}
+	if _, ok := GetFreeTierOffer(res(map[string]interface{}{"storage_class": "glacier"}), "S3 Storage"); ok {
+		t.Error("expected S3 Glacier storage not to be eligible")
+	}
+	if offer, ok := GetFreeTierOffer(res(nil), "EBS Volume Storage"); !ok || offer.Amount != 30 {
+		t.Errorf("expected 30 free GB of volumes, got %+v", offer)
+	}
+	if _, ok := GetFreeTierOffer(res(nil), "NAT Gateway Hourly"); ok {
+		t.Error("expected NAT gateways to have no free tier")
+	}
+}
@@ -9,7 +9,13 @@ import (
 // EC2InstanceRates contains static pricing rates for AWS EC2 instance types
 // These rates are based on AWS public pricing as of 2024 (On-Demand pricing)
 var EC2InstanceRates = map[string]float64{
-	// General Purpose instances
+	// Burstable instances
+	"t2.micro":   0.0116, // $0.0116 per hour
+	"t2.small":   0.023,  // $0.023 per hour
+	"t2.medium":  0.0464, // $0.0464 per hour
+	"t2.large":   0.0928, // $0.0928 per hour
+	"t2.xlarge":  0.1856, // $0.1856 per hour
+	"t2.2xlarge": 0.3712, // $0.3712 per hour
 	"t3.micro":   0.0104, // $0.0104 per hour
 	"t3.small":   0.0208, // $0.0208 per hour
 	"t3.medium":  0.0416, // $0.0416 per hour
@@ -30,6 +36,106 @@ var EC2InstanceRates = map[string]float64{
 	"r5.xlarge":  0.252, // $0.252 per hour
 	"r5.2xlarge": 0.504, // $0.504 per hour
 	"r5.4xlarge": 1.008, // $1.008 per hour
+	// Graviton (arm64) instances
+	"t4g.micro":   0.0084, // $0.0084 per hour
+	"t4g.small":   0.0168, // $0.0168 per hour
+	"t4g.medium":  0.0336, // $0.0336 per hour
+	"t4g.large":   0.0672, // $0.0672 per hour
+	"t4g.xlarge":  0.1344, // $0.1344 per hour
+	"t4g.2xlarge": 0.2688, // $0.2688 per hour
+	"m6g.large":   0.077,  // $0.077 per hour
+	"m6g.xlarge":  0.154,  // $0.154 per hour
+	"m6g.2xlarge": 0.308,  // $0.308 per hour
+	"m6g.4xlarge": 0.616,  // $0.616 per hour
+	"c6g.large":   0.068,  // $0.068 per hour
+	"c6g.xlarge":  0.136,  // $0.136 per hour
+	"c6g.2xlarge": 0.272,  // $0.272 per hour
+	"c6g.4xlarge": 0.544,  // $0.544 per hour
+	"r6g.large":   0.1008, // $0.1008 per hour
+	"r6g.xlarge":  0.2016, // $0.2016 per hour
+	"r6g.2xlarge": 0.4032, // $0.4032 per hour
+	"r6g.4xlarge": 0.8064, // $0.8064 per hour
+}
+
+// EC2InstanceSpec is the size of an EC2 instance type.
+type EC2InstanceSpec struct {
+	VCPU      float64
+	MemoryGiB float64
+	Arm       bool // Graviton (arm64) processor
+	Burstable bool // T family, with CPU credits
+}
+
+// EC2InstanceSpecs contains the vCPU and memory of the instance types in EC2InstanceRates
+var EC2InstanceSpecs = map[string]EC2InstanceSpec{
+	"t2.micro":    {VCPU: 1, MemoryGiB: 1, Burstable: true},
+	"t2.small":    {VCPU: 1, MemoryGiB: 2, Burstable: true},
+	"t2.medium":   {VCPU: 2, MemoryGiB: 4, Burstable: true},
+	"t2.large":    {VCPU: 2, MemoryGiB: 8, Burstable: true},
+	"t2.xlarge":   {VCPU: 4, MemoryGiB: 16, Burstable: true},
+	"t2.2xlarge":  {VCPU: 8, MemoryGiB: 32, Burstable: true},
+	"t3.micro":    {VCPU: 2, MemoryGiB: 1, Burstable: true},
+	"t3.small":    {VCPU: 2, MemoryGiB: 2, Burstable: true},
+	"t3.medium":   {VCPU: 2, MemoryGiB: 4, Burstable: true},
+	"t3.large":    {VCPU: 2, MemoryGiB: 8, Burstable: true},
+	"t3.xlarge":   {VCPU: 4, MemoryGiB: 16, Burstable: true},
+	"t3.2xlarge":  {VCPU: 8, MemoryGiB: 32, Burstable: true},
+	"m5.large":    {VCPU: 2, MemoryGiB: 8},
+	"m5.xlarge":   {VCPU: 4, MemoryGiB: 16},
+	"m5.2xlarge":  {VCPU: 8, MemoryGiB: 32},
+	"m5.4xlarge":  {VCPU: 16, MemoryGiB: 64},
+	"c5.large":    {VCPU: 2, MemoryGiB: 4},
+	"c5.xlarge":   {VCPU: 4, MemoryGiB: 8},
+	"c5.2xlarge":  {VCPU: 8, MemoryGiB: 16},
+	"c5.4xlarge":  {VCPU: 16, MemoryGiB: 32},
+	"r5.large":    {VCPU: 2, MemoryGiB: 16},
+	"r5.xlarge":   {VCPU: 4, MemoryGiB: 32},
+	"r5.2xlarge":  {VCPU: 8, MemoryGiB: 64},
+	"r5.4xlarge":  {VCPU: 16, MemoryGiB: 128},
+	"t4g.micro":   {VCPU: 2, MemoryGiB: 1, Arm: true, Burstable: true},
+	"t4g.small":   {VCPU: 2, MemoryGiB: 2, Arm: true, Burstable: true},
+	"t4g.medium":  {VCPU: 2, MemoryGiB: 4, Arm: true, Burstable: true},
+	"t4g.large":   {VCPU: 2, MemoryGiB: 8, Arm: true, Burstable: true},
+	"t4g.xlarge":  {VCPU: 4, MemoryGiB: 16, Arm: true, Burstable: true},
+	"t4g.2xlarge": {VCPU: 8, MemoryGiB: 32, Arm: true, Burstable: true},
+	"m6g.large":   {VCPU: 2, MemoryGiB: 8, Arm: true},
+	"m6g.xlarge":  {VCPU: 4, MemoryGiB: 16, Arm: true},
+	"m6g.2xlarge": {VCPU: 8, MemoryGiB: 32, Arm: true},
+	"m6g.4xlarge": {VCPU: 16, MemoryGiB: 64, Arm: true},
+	"c6g.large":   {VCPU: 2, MemoryGiB: 4, Arm: true},
+	"c6g.xlarge":  {VCPU: 4, MemoryGiB: 8, Arm: true},
+	"c6g.2xlarge": {VCPU: 8, MemoryGiB: 16, Arm: true},
+	"c6g.4xlarge": {VCPU: 16, MemoryGiB: 32, Arm: true},
+	"r6g.large":   {VCPU: 2, MemoryGiB: 16, Arm: true},
+	"r6g.xlarge":  {VCPU: 4, MemoryGiB: 32, Arm: true},
+	"r6g.2xlarge": {VCPU: 8, MemoryGiB: 64, Arm: true},
+	"r6g.4xlarge": {VCPU: 16, MemoryGiB: 128, Arm: true},
+}
+
+// GravitonFamilies maps x86 instance families to the Graviton family of the same shape
+var GravitonFamilies = map[string]string{
+	"t2": "t4g",
+	"t3": "t4g",
+	"m5": "m6g",
+	"c5": "c6g",
+	"r5": "r6g",
+}
+
+// EC2CommitmentRateRatios contains the effective hourly rate of commitments as a fraction of
+// the On-Demand rate, used when no imported rate exists for the instance type. The ratios
+// are typical for current-generation Linux instances; upfront payments are amortized over the term.
+var EC2CommitmentRateRatios = map[domainpricing.PurchaseOption]float64{
+	domainpricing.Reserved1YrNoUpfront:         0.64,
+	domainpricing.Reserved1YrPartialUpfront:    0.61,
+	domainpricing.Reserved1YrAllUpfront:        0.60,
+	domainpricing.Reserved3YrNoUpfront:         0.45,
+	domainpricing.Reserved3YrPartialUpfront:    0.42,
+	domainpricing.Reserved3YrAllUpfront:        0.40,
+	domainpricing.SavingsPlan1YrNoUpfront:      0.72,
+	domainpricing.SavingsPlan1YrPartialUpfront: 0.69,
+	domainpricing.SavingsPlan1YrAllUpfront:     0.68,
+	domainpricing.SavingsPlan3YrNoUpfront:      0.52,
+	domainpricing.SavingsPlan3YrPartialUpfront: 0.49,
+	domainpricing.SavingsPlan3YrAllUpfront:     0.47,
 }
 
 // EC2RegionalMultipliers contains regional pricing multipliers for EC2 instances
@@ -70,6 +176,23 @@ func CalculateEC2InstanceCost(duration time.Duration, instanceType, region strin
 	return rate * hours
 }
 
+// GetEC2InstanceCommitmentRate returns the effective hourly rate for an EC2 instance type
+// under a purchase option, derived from the On-Demand rate
+func GetEC2InstanceCommitmentRate(instanceType, region string, option domainpricing.PurchaseOption) (float64, bool) {
+	rate, exists := getEC2InstanceRate(instanceType, region)
+	if !exists {
+		return 0, false
+	}
+	if !option.IsCommitment() {
+		return rate, true
+	}
+	ratio, ok := EC2CommitmentRateRatios[option]
+	if !ok {
+		return 0, false
+	}
+	return rate * ratio, true
+}
+
 // GetEC2InstancePricing returns the pricing information for EC2 instances
 func GetEC2InstancePricing(instanceType, region string) *domainpricing.ResourcePricing {
 	rate, exists := getEC2InstanceRate(instanceType, region)
@@ -23,6 +23,14 @@ var LoadBalancerRates = map[LoadBalancerType]float64{
 	LoadBalancerTypeCLB: 0.025,  // $0.025 per hour
 }
 
+// LoadBalancerLCURates contains static pricing rates per Load Balancer Capacity Unit hour
+// (LCU for Application, NLCU for Network Load Balancers). Classic Load Balancers charge
+// per GB processed instead and have no LCU rate.
+var LoadBalancerLCURates = map[LoadBalancerType]float64{
+	LoadBalancerTypeALB: 0.008, // $0.008 per LCU-hour
+	LoadBalancerTypeNLB: 0.006, // $0.006 per NLCU-hour
+}
+
 // LoadBalancerRegionalMultipliers contains regional pricing multipliers for Load Balancers
 var LoadBalancerRegionalMultipliers = map[string]float64{
 	"us-east-1":      1.0, // Base rate multiplier
@@ -46,6 +54,34 @@ func getLoadBalancerRate(lbType LoadBalancerType, region string) (float64, bool)
 	return baseRate * multiplier, true
 }
 
+// getLoadBalancerLCURate returns the LCU-hour rate for a load balancer type
+func getLoadBalancerLCURate(lbType LoadBalancerType, region string) (float64, bool) {
+	baseRate, exists := LoadBalancerLCURates[lbType]
+	if !exists {
+		return 0, false
+	}
+
+	multiplier := 1.0
+	if m, ok := LoadBalancerRegionalMultipliers[region]; ok {
+		multiplier = m
+	}
+
+	return baseRate * multiplier, true
+}
+
+// CalculateLoadBalancerLCUCost calculates the capacity unit cost for a load balancer
+// duration: time duration for the cost calculation
+// lbType: Load balancer type (application, network)
+// lcus: average number of capacity units used per hour
+// region: AWS region
+func CalculateLoadBalancerLCUCost(duration time.Duration, lbType string, lcus float64, region string) float64 {
+	rate, exists := getLoadBalancerLCURate(LoadBalancerType(lbType), region)
+	if !exists {
+		return 0.0
+	}
+	return rate * lcus * duration.Hours()
+}
+
 // CalculateLoadBalancerCost calculates the cost for a load balancer
 // duration: time duration for the cost calculation
 // lbType: Load balancer type (application, network, classic)
@@ -83,6 +119,18 @@ func GetLoadBalancerPricing(lbType, region string) *domainpricing.ResourcePricin
 		},
 	}
 
+	if lcuRate, ok := getLoadBalancerLCURate(lbTypeEnum, region); ok {
+		components = append(components, domainpricing.PriceComponent{
+			Name:        "Load Balancer LCU",
+			Model:       domainpricing.PerHour,
+			Unit:        "LCU-hour",
+			Rate:        lcuRate,
+			Currency:    domainpricing.USD,
+			Region:      &region,
+			Description: "Charge per Load Balancer Capacity Unit hour",
+		})
+	}
+
 	metadata := map[string]interface{}{
 		"load_balancer_type": lbType,
 		"hourly_rate":        rate,
@@ -197,3 +197,44 @@ func TestGetLoadBalancerRate(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateLoadBalancerLCUCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		lbType   string
+		lcus     float64
+		region   string
+		expected float64
+	}{
+		{
+			name:     "ALB-2-LCUs-1-month",
+			lbType:   "application",
+			lcus:     2,
+			region:   "us-east-1",
+			expected: 11.52, // 0.008 * 2 * 720
+		},
+		{
+			name:     "NLB-1-LCU-1-month",
+			lbType:   "network",
+			lcus:     1,
+			region:   "us-east-1",
+			expected: 4.32, // 0.006 * 720
+		},
+		{
+			name:     "CLB-no-LCU-rate",
+			lbType:   "classic",
+			lcus:     5,
+			region:   "us-east-1",
+			expected: 0.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost := CalculateLoadBalancerLCUCost(30*24*time.Hour, tt.lbType, tt.lcus, tt.region)
+			if math.Abs(cost-tt.expected) > epsilon {
+				t.Errorf("Expected cost %.4f, got %.4f", tt.expected, cost)
+			}
+		})
+	}
+}
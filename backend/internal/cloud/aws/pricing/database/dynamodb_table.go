@@ -0,0 +1,181 @@
+package database
+
+import (
+	"math"
+	"time"
+
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+)
+
+// DynamoDB billing modes
+const (
+	DynamoDBOnDemand    = "PAY_PER_REQUEST"
+	DynamoDBProvisioned = "PROVISIONED"
+)
+
+// DynamoDB rates for the Standard table class (us-east-1)
+const (
+	DynamoDBWriteRequestRate  = 0.625   // per million write request units (on-demand)
+	DynamoDBReadRequestRate   = 0.125   // per million read request units (on-demand)
+	DynamoDBWriteCapacityRate = 0.00065 // per WCU-hour (provisioned)
+	DynamoDBReadCapacityRate  = 0.00013 // per RCU-hour (provisioned)
+	DynamoDBStorageRate       = 0.25    // per GB-month
+	DynamoDBPITRStorageRate   = 0.20    // per GB-month of continuous backups
+)
+
+// DynamoDB Always Free allowances; unlike the 12 month Free Tier they do not expire
+const (
+	DynamoDBFreeStorageGB       = 25.0 // GB-month of table storage
+	DynamoDBFreeCapacityUnits   = 25.0 // provisioned RCUs and WCUs each
+	dynamoDBRequestUnitsPerRate = 1000000.0
+)
+
+// DynamoDB cost component names
+const (
+	DynamoDBWriteRequestComponent  = "DynamoDB Write Request Units"
+	DynamoDBReadRequestComponent   = "DynamoDB Read Request Units"
+	DynamoDBWriteCapacityComponent = "DynamoDB Write Capacity Units"
+	DynamoDBReadCapacityComponent  = "DynamoDB Read Capacity Units"
+	DynamoDBStorageComponent       = "DynamoDB Storage"
+	DynamoDBPITRStorageComponent   = "DynamoDB PITR Backup Storage"
+)
+
+// DynamoDBTableUsage is the configuration and usage of a DynamoDB table over the priced duration
+type DynamoDBTableUsage struct {
+	BillingMode         string  // PAY_PER_REQUEST (default) or PROVISIONED
+	ReadCapacity        float64 // provisioned RCUs of the table and its global secondary indexes
+	WriteCapacity       float64 // provisioned WCUs of the table and its global secondary indexes
+	ReadRequests        float64 // read request units consumed, on-demand only
+	WriteRequests       float64 // write request units consumed, on-demand only
+	StorageGB           float64 // average table size
+	PointInTimeRecovery bool
+}
+
+// CalculateDynamoDBTableCost calculates the total cost of a DynamoDB table
+func CalculateDynamoDBTableCost(duration time.Duration, usage DynamoDBTableUsage, region string) float64 {
+	total := 0.0
+	for _, component := range DynamoDBTableCostComponents(duration, usage, region) {
+		total += component.Subtotal
+	}
+	return total
+}
+
+// DynamoDBTableCostComponents breaks the cost of a DynamoDB table down into its request or
+// capacity, storage and backup charges, after the Always Free allowances. Components without
+// a charge are left out.
+func DynamoDBTableCostComponents(duration time.Duration, usage DynamoDBTableUsage, region string) []domainpricing.CostComponent {
+	months := duration.Hours() / 720.0
+	components := []domainpricing.CostComponent{}
+	add := func(name string, model domainpricing.PricingModel, quantity, rate float64) {
+		if quantity <= 0 {
+			return
+		}
+		components = append(components, domainpricing.CostComponent{
+			ComponentName: name,
+			Model:         model,
+			Quantity:      quantity,
+			UnitRate:      rate,
+			Subtotal:      quantity * rate,
+			Currency:      domainpricing.USD,
+		})
+	}
+
+	if usage.BillingMode == DynamoDBProvisioned {
+		// Capacity is billed per unit-hour for every hour it is provisioned
+		writeUnits := math.Max(0, usage.WriteCapacity-DynamoDBFreeCapacityUnits)
+		readUnits := math.Max(0, usage.ReadCapacity-DynamoDBFreeCapacityUnits)
+		add(DynamoDBWriteCapacityComponent, domainpricing.PerHour, writeUnits*duration.Hours(), DynamoDBWriteCapacityRate)
+		add(DynamoDBReadCapacityComponent, domainpricing.PerHour, readUnits*duration.Hours(), DynamoDBReadCapacityRate)
+	} else {
+		add(DynamoDBWriteRequestComponent, domainpricing.PerRequest, usage.WriteRequests, DynamoDBWriteRequestRate/dynamoDBRequestUnitsPerRate)
+		add(DynamoDBReadRequestComponent, domainpricing.PerRequest, usage.ReadRequests, DynamoDBReadRequestRate/dynamoDBRequestUnitsPerRate)
+	}
+
+	storageGB := math.Max(0, usage.StorageGB-DynamoDBFreeStorageGB)
+	add(DynamoDBStorageComponent, domainpricing.PerGB, storageGB*months, DynamoDBStorageRate)
+	if usage.PointInTimeRecovery {
+		// Continuous backups are charged on the full table size
+		add(DynamoDBPITRStorageComponent, domainpricing.PerGB, usage.StorageGB*months, DynamoDBPITRStorageRate)
+	}
+
+	return components
+}
+
+// GetDynamoDBTablePricing returns the pricing information for a DynamoDB table
+func GetDynamoDBTablePricing(billingMode, region string) *domainpricing.ResourcePricing {
+	var components []domainpricing.PriceComponent
+	if billingMode == DynamoDBProvisioned {
+		components = []domainpricing.PriceComponent{
+			{
+				Name:        DynamoDBWriteCapacityComponent,
+				Model:       domainpricing.PerHour,
+				Unit:        "WCU-hour",
+				Rate:        DynamoDBWriteCapacityRate,
+				Currency:    domainpricing.USD,
+				Region:      &region,
+				Description: "Hourly charge per provisioned write capacity unit beyond the 25 free WCUs",
+			},
+			{
+				Name:        DynamoDBReadCapacityComponent,
+				Model:       domainpricing.PerHour,
+				Unit:        "RCU-hour",
+				Rate:        DynamoDBReadCapacityRate,
+				Currency:    domainpricing.USD,
+				Region:      &region,
+				Description: "Hourly charge per provisioned read capacity unit beyond the 25 free RCUs",
+			},
+		}
+	} else {
+		billingMode = DynamoDBOnDemand
+		components = []domainpricing.PriceComponent{
+			{
+				Name:        DynamoDBWriteRequestComponent,
+				Model:       domainpricing.PerRequest,
+				Unit:        "million WRUs",
+				Rate:        DynamoDBWriteRequestRate,
+				Currency:    domainpricing.USD,
+				Region:      &region,
+				Description: "On-demand charge per million write request units",
+			},
+			{
+				Name:        DynamoDBReadRequestComponent,
+				Model:       domainpricing.PerRequest,
+				Unit:        "million RRUs",
+				Rate:        DynamoDBReadRequestRate,
+				Currency:    domainpricing.USD,
+				Region:      &region,
+				Description: "On-demand charge per million read request units",
+			},
+		}
+	}
+
+	components = append(components,
+		domainpricing.PriceComponent{
+			Name:        DynamoDBStorageComponent,
+			Model:       domainpricing.PerGB,
+			Unit:        "GB-month",
+			Rate:        DynamoDBStorageRate,
+			Currency:    domainpricing.USD,
+			Region:      &region,
+			Description: "Table storage beyond the first 25 GB, which are free",
+		},
+		domainpricing.PriceComponent{
+			Name:        DynamoDBPITRStorageComponent,
+			Model:       domainpricing.PerGB,
+			Unit:        "GB-month",
+			Rate:        DynamoDBPITRStorageRate,
+			Currency:    domainpricing.USD,
+			Region:      &region,
+			Description: "Continuous backups for point-in-time recovery, charged on the table size",
+		},
+	)
+
+	return &domainpricing.ResourcePricing{
+		ResourceType: "dynamodb_table",
+		Provider:     domainpricing.AWS,
+		Components:   components,
+		Metadata: map[string]interface{}{
+			"billing_mode": billingMode,
+		},
+	}
+}
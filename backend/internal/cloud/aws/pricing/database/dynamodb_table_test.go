@@ -0,0 +1,101 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateDynamoDBTableCost(t *testing.T) {
+	month := 720 * time.Hour
+
+	tests := []struct {
+		name  string
+		usage DynamoDBTableUsage
+		want  float64
+	}{
+		{
+			name:  "On-demand without usage",
+			usage: DynamoDBTableUsage{BillingMode: DynamoDBOnDemand},
+			want:  0,
+		},
+		{
+			name: "On-demand requests and storage",
+			usage: DynamoDBTableUsage{
+				BillingMode:   DynamoDBOnDemand,
+				ReadRequests:  10000000, // 10M RRUs
+				WriteRequests: 2000000,  // 2M WRUs
+				StorageGB:     45,       // 20 GB beyond the free 25 GB
+			},
+			want: 10*DynamoDBReadRequestRate + 2*DynamoDBWriteRequestRate + 20*DynamoDBStorageRate,
+		},
+		{
+			name: "Provisioned within the free capacity",
+			usage: DynamoDBTableUsage{
+				BillingMode:   DynamoDBProvisioned,
+				ReadCapacity:  25,
+				WriteCapacity: 10,
+			},
+			want: 0,
+		},
+		{
+			name: "Provisioned capacity ignores requests",
+			usage: DynamoDBTableUsage{
+				BillingMode:   DynamoDBProvisioned,
+				ReadCapacity:  125,
+				WriteCapacity: 75,
+				ReadRequests:  10000000,
+			},
+			want: 100*720*DynamoDBReadCapacityRate + 50*720*DynamoDBWriteCapacityRate,
+		},
+		{
+			name: "Point-in-time recovery on the full table size",
+			usage: DynamoDBTableUsage{
+				BillingMode:         DynamoDBOnDemand,
+				StorageGB:           10,
+				PointInTimeRecovery: true,
+			},
+			want: 10 * DynamoDBPITRStorageRate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateDynamoDBTableCost(month, tt.usage, "us-east-1")
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestDynamoDBTableCostComponents(t *testing.T) {
+	components := DynamoDBTableCostComponents(720*time.Hour, DynamoDBTableUsage{
+		BillingMode:   DynamoDBProvisioned,
+		ReadCapacity:  50,
+		WriteCapacity: 50,
+		StorageGB:     100,
+	}, "us-east-1")
+
+	names := make([]string, 0, len(components))
+	for _, c := range components {
+		names = append(names, c.ComponentName)
+	}
+	assert.Equal(t, []string{DynamoDBWriteCapacityComponent, DynamoDBReadCapacityComponent, DynamoDBStorageComponent}, names)
+	assert.Equal(t, domainpricing.PerHour, components[0].Model)
+	assert.Equal(t, 25.0*720, components[0].Quantity)
+	assert.Equal(t, domainpricing.PerGB, components[2].Model)
+	assert.Equal(t, 75.0, components[2].Quantity)
+}
+
+func TestGetDynamoDBTablePricing(t *testing.T) {
+	onDemand := GetDynamoDBTablePricing("", "us-east-1")
+	assert.Equal(t, "dynamodb_table", onDemand.ResourceType)
+	assert.Equal(t, DynamoDBOnDemand, onDemand.Metadata["billing_mode"])
+	assert.Len(t, onDemand.Components, 4)
+	assert.Equal(t, DynamoDBWriteRequestComponent, onDemand.Components[0].Name)
+
+	provisioned := GetDynamoDBTablePricing(DynamoDBProvisioned, "us-east-1")
+	assert.Equal(t, DynamoDBWriteCapacityComponent, provisioned.Components[0].Name)
+	assert.Equal(t, domainpricing.PerHour, provisioned.Components[0].Model)
+}
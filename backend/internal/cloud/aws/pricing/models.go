@@ -26,6 +26,10 @@ type FreeTierAllowance struct {
 	Unit string `json:"unit"`
 	// Period is the period for the free tier (monthly, yearly)
 	Period pricing.Period `json:"period"`
+	// ExpiresAfterMonths is how long a new account gets the allowance, 0 when it never expires
+	ExpiresAfterMonths int `json:"expires_after_months,omitempty"`
+	// Eligible lists the instance, load balancer or storage types the allowance applies to, empty for all
+	Eligible []string `json:"eligible,omitempty"`
 }
 
 // AWSResourcePricing extends domain ResourcePricing with AWS-specific fields
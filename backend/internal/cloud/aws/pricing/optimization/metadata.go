@@ -0,0 +1,97 @@
+package optimization
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// Configuration is read under the camelCase keys of the diagram editor and the
+// snake_case keys of imported Terraform.
+
+// str returns the first of the keys set to a non-empty string.
+func str(res *resource.Resource, keys ...string) (string, bool) {
+	for _, k := range keys {
+		if s, ok := res.Metadata[k].(string); ok && s != "" {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// number returns the first of the keys set to a number or a numeric string.
+func number(res *resource.Resource, keys ...string) (float64, bool) {
+	for _, k := range keys {
+		switch v := res.Metadata[k].(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func operatingSystem(res *resource.Resource) string {
+	os, _ := str(res, "operatingSystem", "operating_system", "platform")
+	if strings.EqualFold(os, "windows") {
+		return "windows"
+	}
+	return "linux"
+}
+
+// production reports whether any of the resources is tagged, or configured, as a
+// production environment.
+func production(resources ...*resource.Resource) bool {
+	for _, res := range resources {
+		env, _ := str(res, "environment")
+		for _, key := range []string{"Environment", "environment", "Env", "env"} {
+			if v, ok := tag(res, key); ok {
+				env = v
+				break
+			}
+		}
+		switch strings.ToLower(env) {
+		case "prod", "production", "prd":
+			return true
+		}
+	}
+	return false
+}
+
+// tag reads a tag from "tags", given either as an object or as a list of {key, value}
+// entries.
+func tag(res *resource.Resource, key string) (string, bool) {
+	switch tags := res.Metadata["tags"].(type) {
+	case map[string]interface{}:
+		v, ok := tags[key].(string)
+		return v, ok
+	case map[string]string:
+		v, ok := tags[key]
+		return v, ok
+	case []interface{}:
+		for _, item := range tags {
+			entry, _ := item.(map[string]interface{})
+			k, _ := entry["key"].(string)
+			if k == "" {
+				k, _ = entry["Key"].(string)
+			}
+			if k != key {
+				continue
+			}
+			v, ok := entry["value"].(string)
+			if !ok {
+				v, ok = entry["Value"].(string)
+			}
+			return v, ok
+		}
+	}
+	return "", false
+}
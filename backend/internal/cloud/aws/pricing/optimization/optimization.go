@@ -0,0 +1,183 @@
+// Package optimization finds cost savings in AWS architectures. Each rule prices the
+// resource as designed and a cheaper alternative with the same unit rates used for cost
+// estimates, so savings are real price differences rather than rules of thumb.
+package optimization
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// HoursPerMonth is the month used for monthly costs, as in cost estimates.
+const HoursPerMonth = 720.0
+
+// Rules.
+const (
+	RuleRightsizing      = "rightsizing"
+	RuleGraviton         = "graviton"
+	RuleEBSGP3           = "ebs_gp3"
+	RuleNATConsolidation = "nat_consolidation"
+	RuleGatewayEndpoint  = "vpc_gateway_endpoint"
+	RuleS3StorageClass   = "s3_storage_class"
+	RuleUnattachedEIP    = "unattached_eip"
+)
+
+// Rates are the unit prices the rules compare. *pricing.AWSPricingCalculator provides
+// them from imported pricing rates, or from its static rate tables. A rate is not ok when
+// the region has no pricing data; rules skip those resources.
+type Rates interface {
+	InstanceHourlyRate(ctx context.Context, instanceType, region, operatingSystem string) (float64, bool)
+	EBSVolumeRate(ctx context.Context, volumeType, region string) (float64, bool)
+	S3StorageRate(ctx context.Context, storageClass, region string) (float64, bool)
+	NATGatewayRates(ctx context.Context, region string) (hourly, perGB float64, ok bool)
+	InterAZTransferRate(ctx context.Context, region string) (float64, bool)
+	ElasticIPHourlyRate(ctx context.Context, region string) (float64, bool)
+}
+
+// Suggestion is a cost saving for one resource, or for a group of resources such as
+// the NAT gateways of a VPC. Costs are monthly, in USD.
+type Suggestion struct {
+	ID                   string
+	Rule                 string
+	Severity             string // high, medium or low, by the size of the saving
+	Title                string
+	Description          string
+	ResourceType         string
+	ResourceID           string
+	CurrentMonthlyCost   float64
+	OptimizedMonthlyCost float64
+	MonthlySavings       float64
+}
+
+// Analyze runs every rule over the architecture and returns its suggestions, largest
+// saving first. Suggestions for the same resource are cumulative: a Graviton suggestion
+// is priced after the instance's rightsizing. Visual-only resources are ignored.
+func Analyze(ctx context.Context, arch *architecture.Architecture, rates Rates) []Suggestion {
+	a := newAnalysis(ctx, arch, rates)
+	var out []Suggestion
+	for _, rule := range []func(*analysis) []Suggestion{
+		instanceRules,
+		ebsGP3,
+		s3StorageClass,
+		natConsolidation,
+		gatewayEndpoints,
+		unattachedElasticIPs,
+	} {
+		out = append(out, rule(a)...)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].MonthlySavings != out[j].MonthlySavings {
+			return out[i].MonthlySavings > out[j].MonthlySavings
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// analysis is the state shared by the rules of one Analyze call.
+type analysis struct {
+	ctx       context.Context
+	arch      *architecture.Architecture
+	rates     Rates
+	resources []*resource.Resource // without visual-only resources
+	byID      map[string]*resource.Resource
+	instance  map[string]instanceRate
+}
+
+type instanceRate struct {
+	rate float64
+	ok   bool
+}
+
+func newAnalysis(ctx context.Context, arch *architecture.Architecture, rates Rates) *analysis {
+	a := &analysis{
+		ctx:      ctx,
+		arch:     arch,
+		rates:    rates,
+		byID:     make(map[string]*resource.Resource, len(arch.Resources)),
+		instance: make(map[string]instanceRate),
+	}
+	for _, res := range arch.Resources {
+		a.byID[res.ID] = res
+		if visualOnly, _ := res.Metadata["isVisualOnly"].(bool); visualOnly {
+			continue
+		}
+		a.resources = append(a.resources, res)
+	}
+	return a
+}
+
+func (a *analysis) ofType(typeName string) []*resource.Resource {
+	var out []*resource.Resource
+	for _, res := range a.resources {
+		if res.Type.Name == typeName {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+func (a *analysis) region(res *resource.Resource) string {
+	if res.Region != "" {
+		return res.Region
+	}
+	return a.arch.Region
+}
+
+// instanceRate caches hourly instance rates, as rightsizing compares every instance
+// type of the catalog.
+func (a *analysis) instanceRate(instanceType, region, operatingSystem string) (float64, bool) {
+	key := instanceType + "|" + region + "|" + operatingSystem
+	if r, ok := a.instance[key]; ok {
+		return r.rate, r.ok
+	}
+	rate, ok := a.rates.InstanceHourlyRate(a.ctx, instanceType, region, operatingSystem)
+	a.instance[key] = instanceRate{rate: rate, ok: ok}
+	return rate, ok
+}
+
+// vpcOf returns the ID of the VPC containing a resource, or named in its configuration.
+func (a *analysis) vpcOf(res *resource.Resource) string {
+	for r, depth := res, 0; r != nil && depth < 32; depth++ {
+		if r.Type.Name == "VPC" {
+			return r.ID
+		}
+		if id, ok := str(r, "vpcId", "vpc_id"); ok {
+			if vpc, found := a.byID[id]; found && vpc.Type.Name == "VPC" {
+				return vpc.ID
+			}
+		}
+		if r.ParentID == nil {
+			break
+		}
+		r = a.byID[*r.ParentID]
+	}
+	return ""
+}
+
+func severityFor(monthlySavings float64) string {
+	switch {
+	case monthlySavings >= 100:
+		return "high"
+	case monthlySavings >= 20:
+		return "medium"
+	}
+	return "low"
+}
+
+func newSuggestion(rule string, res *resource.Resource, current, optimized float64) Suggestion {
+	savings := current - optimized
+	return Suggestion{
+		ID:                   "opt-" + rule + "-" + res.ID,
+		Rule:                 rule,
+		Severity:             severityFor(savings),
+		ResourceType:         res.Type.Name,
+		ResourceID:           res.ID,
+		CurrentMonthlyCost:   current,
+		OptimizedMonthlyCost: optimized,
+		MonthlySavings:       savings,
+	}
+}
@@ -0,0 +1,200 @@
+package optimization
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	awspricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func res(id, typeName string, parent string, metadata map[string]interface{}) *resource.Resource {
+	r := &resource.Resource{ID: id, Name: id, Type: resource.ResourceType{Name: typeName}, Provider: "aws", Metadata: metadata}
+	if parent != "" {
+		r.ParentID = &parent
+	}
+	return r
+}
+
+func analyze(resources ...*resource.Resource) []Suggestion {
+	arch := &architecture.Architecture{
+		Resources:    resources,
+		Region:       "us-east-1",
+		Provider:     "aws",
+		Dependencies: map[string][]string{},
+	}
+	calculator := awspricing.NewAWSPricingCalculator(awspricing.NewAWSPricingService())
+	return Analyze(context.Background(), arch, calculator)
+}
+
+func find(suggestions []Suggestion, rule, resourceID string) *Suggestion {
+	for i := range suggestions {
+		if suggestions[i].Rule == rule && suggestions[i].ResourceID == resourceID {
+			return &suggestions[i]
+		}
+	}
+	return nil
+}
+
+func assertSavings(t *testing.T, s *Suggestion, want float64) {
+	t.Helper()
+	if s == nil {
+		t.Fatalf("expected a suggestion saving %.2f", want)
+	}
+	if math.Abs(s.MonthlySavings-want) > 0.001 {
+		t.Errorf("%s: savings = %.4f, want %.4f", s.ID, s.MonthlySavings, want)
+	}
+	if math.Abs(s.CurrentMonthlyCost-s.OptimizedMonthlyCost-s.MonthlySavings) > 0.001 {
+		t.Errorf("%s: savings are not the cost difference", s.ID)
+	}
+}
+
+func TestInstanceRules(t *testing.T) {
+	t.Run("rightsizing from utilization", func(t *testing.T) {
+		out := analyze(res("web", "EC2", "", map[string]interface{}{
+			"instanceType": "m5.xlarge", "cpuUtilization": 20.0, "memoryUtilization": 30.0,
+		}))
+		rightsize := find(out, RuleRightsizing, "web")
+		assertSavings(t, rightsize, (0.192-0.096)*HoursPerMonth) // m5.large
+		// Graviton is priced after rightsizing.
+		assertSavings(t, find(out, RuleGraviton, "web"), (0.096-0.077)*HoursPerMonth) // m6g.large
+	})
+
+	t.Run("no utilization keeps the size", func(t *testing.T) {
+		out := analyze(res("web", "EC2", "", map[string]interface{}{"instance_type": "m5.xlarge"}))
+		if find(out, RuleRightsizing, "web") != nil {
+			t.Error("unexpected rightsizing without utilization data")
+		}
+		assertSavings(t, find(out, RuleGraviton, "web"), (0.192-0.154)*HoursPerMonth)
+	})
+
+	t.Run("previous generation", func(t *testing.T) {
+		out := analyze(res("web", "EC2", "", map[string]interface{}{"instanceType": "t2.large"}))
+		assertSavings(t, find(out, RuleRightsizing, "web"), (0.0928-0.0832)*HoursPerMonth) // t3.large
+		assertSavings(t, find(out, RuleGraviton, "web"), (0.0832-0.0672)*HoursPerMonth)    // t4g.large
+	})
+
+	t.Run("windows and arm", func(t *testing.T) {
+		out := analyze(
+			res("win", "EC2", "", map[string]interface{}{"instanceType": "m5.large", "operatingSystem": "windows"}),
+			res("arm", "EC2", "", map[string]interface{}{"instanceType": "m6g.large"}),
+		)
+		if len(out) != 0 {
+			t.Errorf("expected no suggestions, got %+v", out)
+		}
+	})
+}
+
+func TestEBSGP3(t *testing.T) {
+	out := analyze(
+		res("small", "EBS", "", map[string]interface{}{"volumeType": "gp2", "size": 100}),
+		res("large", "EBS", "", map[string]interface{}{"volume_type": "gp2", "size_gb": 2000.0}),
+		res("gp3", "EBS", "", map[string]interface{}{"volumeType": "gp3", "size": 100}),
+	)
+	assertSavings(t, find(out, RuleEBSGP3, "small"), 100*(0.10-0.08))
+	// 2,000 GB of gp2 has 6,000 IOPS; gp3 charges for the 3,000 above its baseline.
+	assertSavings(t, find(out, RuleEBSGP3, "large"), 2000*0.10-(2000*0.08+3000*0.005))
+	if find(out, RuleEBSGP3, "gp3") != nil {
+		t.Error("unexpected suggestion for a gp3 volume")
+	}
+}
+
+func TestNATConsolidation(t *testing.T) {
+	nats := func(env string) []*resource.Resource {
+		tags := map[string]interface{}{"Environment": env}
+		return []*resource.Resource{
+			res("vpc", "VPC", "", map[string]interface{}{"tags": tags}),
+			res("subnet-a", "Subnet", "vpc", nil),
+			res("subnet-b", "Subnet", "vpc", nil),
+			res("nat-a", "NATGateway", "subnet-a", map[string]interface{}{"dataProcessedGb": 100.0}),
+			res("nat-b", "NATGateway", "subnet-b", map[string]interface{}{"dataProcessedGb": 100.0}),
+		}
+	}
+
+	out := analyze(nats("staging")...)
+	// One NAT gateway fewer; the other AZ's 100 GB now crosses AZs both ways.
+	assertSavings(t, find(out, RuleNATConsolidation, "vpc"), 0.045*HoursPerMonth-2*100*0.01)
+
+	if s := find(analyze(nats("production")...), RuleNATConsolidation, "vpc"); s != nil {
+		t.Error("unexpected consolidation of production NAT gateways")
+	}
+}
+
+func TestGatewayEndpoints(t *testing.T) {
+	base := []*resource.Resource{
+		res("vpc", "VPC", "", nil),
+		res("nat", "NATGateway", "vpc", nil),
+		res("bucket", "S3", "", map[string]interface{}{"dataTransferGb": 500.0}),
+	}
+
+	out := analyze(base...)
+	assertSavings(t, find(out, RuleGatewayEndpoint, "vpc"), 500*0.045)
+
+	withEndpoint := append(base, res("s3-endpoint", "VPCEndpoint", "vpc", map[string]interface{}{
+		"serviceName": "com.amazonaws.us-east-1.s3", "vpcEndpointType": "Gateway",
+	}))
+	if find(analyze(withEndpoint...), RuleGatewayEndpoint, "vpc") != nil {
+		t.Error("unexpected suggestion for a VPC with an S3 gateway endpoint")
+	}
+}
+
+func TestGatewayEndpointsSplitsTrafficAcrossVPCs(t *testing.T) {
+	app := res("app", "EC2", "subnet-a", map[string]interface{}{"instanceType": "t3.micro"})
+	app.DependsOn = []string{"logs"}
+	out := analyze(
+		res("vpc-a", "VPC", "", nil),
+		res("subnet-a", "Subnet", "vpc-a", nil),
+		res("nat-a", "NATGateway", "subnet-a", nil),
+		app,
+		res("vpc-b", "VPC", "", nil),
+		res("nat-b", "NATGateway", "vpc-b", nil),
+		res("shared", "S3", "", map[string]interface{}{"dataTransferGb": 500.0}),
+		res("logs", "S3", "", map[string]interface{}{"dataTransferGb": 200.0}),
+	)
+
+	// "shared" has no users and is split across both VPCs; "logs" is only used from vpc-a
+	assertSavings(t, find(out, RuleGatewayEndpoint, "vpc-a"), (250+200)*0.045)
+	assertSavings(t, find(out, RuleGatewayEndpoint, "vpc-b"), 250*0.045)
+}
+
+func TestS3StorageClass(t *testing.T) {
+	out := analyze(
+		res("logs", "S3", "", map[string]interface{}{"accessFrequency": "infrequent", "size_gb": 1000.0}),
+		res("assets", "S3", "", map[string]interface{}{"size_gb": 1000.0}),
+	)
+	s := find(out, RuleS3StorageClass, "logs")
+	if s == nil || s.MonthlySavings <= 0 {
+		t.Fatalf("expected a storage class saving for an infrequently accessed bucket, got %+v", s)
+	}
+	if find(out, RuleS3StorageClass, "assets") != nil {
+		t.Error("unexpected suggestion for a bucket without an access frequency")
+	}
+}
+
+func TestUnattachedElasticIPs(t *testing.T) {
+	out := analyze(
+		res("eip-free", "ElasticIP", "", nil),
+		res("eip-nat", "ElasticIP", "", nil),
+		res("nat", "NATGateway", "", map[string]interface{}{"allocationId": "eip-nat"}),
+	)
+	if find(out, RuleUnattachedEIP, "eip-free") == nil {
+		t.Error("expected the unattached Elastic IP to be flagged")
+	}
+	if find(out, RuleUnattachedEIP, "eip-nat") != nil {
+		t.Error("unexpected suggestion for the NAT gateway's Elastic IP")
+	}
+}
+
+func TestAnalyze_OrderedBySavings(t *testing.T) {
+	out := analyze(
+		res("vol", "EBS", "", map[string]interface{}{"volumeType": "gp2", "size": 100}),
+		res("web", "EC2", "", map[string]interface{}{"instanceType": "m5.xlarge"}),
+	)
+	for i := 1; i < len(out); i++ {
+		if out[i].MonthlySavings > out[i-1].MonthlySavings {
+			t.Fatalf("suggestions not ordered by savings: %+v", out)
+		}
+	}
+}
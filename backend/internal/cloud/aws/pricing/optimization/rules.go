@@ -0,0 +1,404 @@
+package optimization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/compute"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/storage"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// targetUtilization is the average CPU and memory utilization rightsizing aims for,
+// leaving headroom for peaks.
+const targetUtilization = 70.0
+
+// instanceRules rightsizes EC2 instances to the cheapest instance type, of any family,
+// that provides the vCPUs and memory they need, then suggests the Graviton equivalent of
+// the result. An instance needs its full size unless its average utilization is known
+// ("cpuUtilization" and "memoryUtilization", in percent), so without utilization data
+// only cheaper types of at least the same size, such as a newer generation, are found.
+func instanceRules(a *analysis) []Suggestion {
+	var out []Suggestion
+	for _, res := range a.ofType("EC2") {
+		current, _ := str(res, "instanceType", "instance_type")
+		spec, ok := compute.EC2InstanceSpecs[current]
+		if !ok {
+			continue
+		}
+		region := a.region(res)
+		os := operatingSystem(res)
+		currentRate, ok := a.instanceRate(current, region, os)
+		if !ok {
+			continue
+		}
+
+		needCPU, cpuKnown := need(res, spec.VCPU, "cpuUtilization", "cpu_utilization")
+		needMem, memKnown := need(res, spec.MemoryGiB, "memoryUtilization", "memory_utilization")
+		target, targetRate := current, currentRate
+		for _, candidate := range instanceTypes() {
+			cs := compute.EC2InstanceSpecs[candidate]
+			if cs.Arm != spec.Arm || (cs.Burstable && !spec.Burstable) || cs.VCPU < needCPU || cs.MemoryGiB < needMem {
+				continue
+			}
+			if rate, ok := a.instanceRate(candidate, region, os); ok && rate < targetRate {
+				target, targetRate = candidate, rate
+			}
+		}
+		if target != current {
+			s := newSuggestion(RuleRightsizing, res, currentRate*HoursPerMonth, targetRate*HoursPerMonth)
+			s.Title = "Rightsize instance"
+			basis := "its current size"
+			if cpuKnown || memKnown {
+				basis = fmt.Sprintf("%.1f vCPUs and %.1f GiB at its measured utilization", needCPU, needMem)
+			}
+			s.Description = fmt.Sprintf("'%s' runs on %s. %s is the cheapest instance type providing %s (%g vCPUs, %g GiB).",
+				res.Name, current, target, basis, compute.EC2InstanceSpecs[target].VCPU, compute.EC2InstanceSpecs[target].MemoryGiB)
+			out = append(out, s)
+		}
+
+		if spec.Arm || os == "windows" {
+			continue
+		}
+		graviton := gravitonEquivalent(target)
+		if graviton == "" {
+			continue
+		}
+		if rate, ok := a.instanceRate(graviton, region, os); ok && rate < targetRate {
+			s := newSuggestion(RuleGraviton, res, targetRate*HoursPerMonth, rate*HoursPerMonth)
+			s.Title = "Move to Graviton"
+			s.Description = fmt.Sprintf("%s is the Graviton (arm64) equivalent of %s for '%s'. The AMI and software must support arm64.",
+				graviton, target, res.Name)
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// need returns the amount of a resource (vCPUs or GiB) an instance needs at the target
+// utilization, and whether its utilization is known.
+func need(res *resource.Resource, size float64, keys ...string) (float64, bool) {
+	utilization, ok := number(res, keys...)
+	if !ok || utilization <= 0 || utilization > 100 {
+		return size, false
+	}
+	return size * utilization / targetUtilization, true
+}
+
+// instanceTypes returns the instance types of the catalog in a stable order.
+func instanceTypes() []string {
+	types := make([]string, 0, len(compute.EC2InstanceSpecs))
+	for t := range compute.EC2InstanceSpecs {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func gravitonEquivalent(instanceType string) string {
+	family, size, ok := strings.Cut(instanceType, ".")
+	if !ok {
+		return ""
+	}
+	arm, ok := compute.GravitonFamilies[family]
+	if !ok {
+		return ""
+	}
+	if _, ok := compute.EC2InstanceSpecs[arm+"."+size]; !ok {
+		return ""
+	}
+	return arm + "." + size
+}
+
+// ebsGP3 moves gp2 volumes to gp3, which costs less per GB and includes 3,000 IOPS.
+// Volumes larger than 1 TB get more than that from gp2, so the extra IOPS are priced in.
+func ebsGP3(a *analysis) []Suggestion {
+	var out []Suggestion
+	for _, res := range a.ofType("EBS") {
+		if volumeType, _ := str(res, "volumeType", "volume_type"); volumeType != "gp2" {
+			continue
+		}
+		size, ok := number(res, "size", "size_gb", "sizeGb")
+		if !ok || size <= 0 {
+			continue
+		}
+		region := a.region(res)
+		gp2, ok2 := a.rates.EBSVolumeRate(a.ctx, "gp2", region)
+		gp3, ok3 := a.rates.EBSVolumeRate(a.ctx, "gp3", region)
+		if !ok2 || !ok3 {
+			continue
+		}
+		iops := min(max(3*size, 100), 16000) // gp2 baseline: 3 IOPS per GB
+		extraIOPS := max(0, iops-storage.GP3BaselineIOPS)
+		current := size * gp2
+		optimized := size*gp3 + extraIOPS*storage.GP3IOPSRate
+		if optimized >= current {
+			continue
+		}
+		s := newSuggestion(RuleEBSGP3, res, current, optimized)
+		s.Title = "Migrate EBS volume to gp3"
+		s.Description = fmt.Sprintf("'%s' is a %g GB gp2 volume. gp3 provides the same %g baseline IOPS for less and can be changed in place without downtime.",
+			res.Name, size, iops)
+		out = append(out, s)
+	}
+	return out
+}
+
+// accessClasses maps a bucket's declared access frequency to the storage class suiting it.
+var accessClasses = map[string]string{
+	"infrequent": "standard-ia",
+	"rare":       "glacier",
+	"archive":    "glacier",
+}
+
+// s3StorageClass moves buckets whose objects are rarely read ("accessFrequency":
+// "infrequent", "rare" or "archive") to a cheaper storage class.
+func s3StorageClass(a *analysis) []Suggestion {
+	var out []Suggestion
+	for _, res := range a.ofType("S3") {
+		access, _ := str(res, "accessFrequency", "access_frequency")
+		class, ok := accessClasses[strings.ToLower(access)]
+		if !ok {
+			continue
+		}
+		size, ok := number(res, "size_gb", "sizeGb", "sizeGB")
+		if !ok || size <= 0 {
+			continue
+		}
+		currentClass, ok := str(res, "storageClass", "storage_class")
+		if !ok {
+			currentClass = "standard"
+		}
+		currentClass = strings.ToLower(currentClass)
+		region := a.region(res)
+		currentRate, ok := a.rates.S3StorageRate(a.ctx, currentClass, region)
+		if !ok {
+			continue
+		}
+		rate, ok := a.rates.S3StorageRate(a.ctx, class, region)
+		if !ok || rate >= currentRate {
+			continue
+		}
+		s := newSuggestion(RuleS3StorageClass, res, size*currentRate, size*rate)
+		s.Title = "Change S3 storage class"
+		s.Description = fmt.Sprintf("'%s' stores %g GB in %s but is accessed %s. Move it to %s with a lifecycle rule; mind the minimum storage duration and the retrieval fees of that class.",
+			res.Name, size, currentClass, strings.ToLower(access), class)
+		out = append(out, s)
+	}
+	return out
+}
+
+// natConsolidation replaces the per-AZ NAT gateways of a non-production VPC with one.
+// Traffic from the other AZs then crosses AZs, which is charged in both directions.
+func natConsolidation(a *analysis) []Suggestion {
+	byVPC := make(map[string][]*resource.Resource)
+	var vpcs []string
+	for _, nat := range a.ofType("NATGateway") {
+		vpc := a.vpcOf(nat)
+		if vpc == "" {
+			continue
+		}
+		if _, ok := byVPC[vpc]; !ok {
+			vpcs = append(vpcs, vpc)
+		}
+		byVPC[vpc] = append(byVPC[vpc], nat)
+	}
+
+	var out []Suggestion
+	for _, id := range vpcs {
+		nats := byVPC[id]
+		vpc := a.byID[id]
+		if len(nats) < 2 || production(vpc) || production(nats...) {
+			continue
+		}
+		region := a.region(vpc)
+		hourly, perGB, ok := a.rates.NATGatewayRates(a.ctx, region)
+		interAZ, ok2 := a.rates.InterAZTransferRate(a.ctx, region)
+		if !ok || !ok2 {
+			continue
+		}
+		var data, movedData float64
+		for i, nat := range nats {
+			gb, _ := number(nat, "dataProcessedGb", "data_processed_gb")
+			data += gb
+			if i > 0 {
+				movedData += gb
+			}
+		}
+		current := float64(len(nats))*hourly*HoursPerMonth + data*perGB
+		crossAZ := 2 * movedData * interAZ
+		optimized := hourly*HoursPerMonth + data*perGB + crossAZ
+		if optimized >= current {
+			continue
+		}
+		s := newSuggestion(RuleNATConsolidation, vpc, current, optimized)
+		s.Title = "Consolidate NAT gateways"
+		s.Description = fmt.Sprintf("VPC '%s' has %d NAT gateways. Outside production one can serve every AZ; private subnets lose internet access if its AZ fails.",
+			vpc.Name, len(nats))
+		out = append(out, s)
+	}
+	return out
+}
+
+// gatewayEndpoints adds free S3 and DynamoDB gateway endpoints to VPCs with NAT gateways,
+// so traffic to those services stops paying NAT data processing. The traffic is the
+// declared monthly transfer ("dataTransferGb") of the architecture's buckets and tables,
+// counted once: see trafficShare.
+func gatewayEndpoints(a *analysis) []Suggestion {
+	services := []struct {
+		name, resourceType, endpoint string
+	}{
+		{"S3", "S3", "s3"},
+		{"DynamoDB", "DynamoDB", "dynamodb"},
+	}
+
+	var natVPCs []string
+	seen := make(map[string]bool)
+	for _, nat := range a.ofType("NATGateway") {
+		vpcID := a.vpcOf(nat)
+		if vpcID == "" || seen[vpcID] {
+			continue
+		}
+		seen[vpcID] = true
+		natVPCs = append(natVPCs, vpcID)
+	}
+
+	var out []Suggestion
+	for _, vpcID := range natVPCs {
+		vpc := a.byID[vpcID]
+		_, perGB, ok := a.rates.NATGatewayRates(a.ctx, a.region(vpc))
+		if !ok {
+			continue
+		}
+
+		for _, svc := range services {
+			if a.hasGatewayEndpoint(vpcID, svc.endpoint) {
+				continue
+			}
+			var data float64
+			used := false
+			for _, t := range a.ofType(svc.resourceType) {
+				share := a.trafficShare(t, vpcID, natVPCs)
+				if share == 0 {
+					continue
+				}
+				gb, _ := number(t, "dataTransferGb", "data_transfer_gb")
+				data += gb * share
+				used = true
+			}
+			if !used {
+				continue
+			}
+			s := newSuggestion(RuleGatewayEndpoint, vpc, data*perGB, 0)
+			s.ID += "-" + svc.endpoint
+			s.Title = "Add a " + svc.name + " gateway endpoint"
+			s.Description = fmt.Sprintf("Traffic from VPC '%s' to %s goes through a NAT gateway at $%.3f per GB. A gateway endpoint is free and keeps that traffic off the NAT gateway.",
+				vpc.Name, svc.name, perGB)
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// trafficShare returns the part of a bucket's or table's traffic coming from a VPC. The
+// traffic is split evenly across the VPCs of the resources it has dependencies with, or,
+// when nothing in a VPC has, across the VPCs with NAT gateways.
+func (a *analysis) trafficShare(target *resource.Resource, vpcID string, natVPCs []string) float64 {
+	users := a.dependentVPCs(target)
+	if len(users) == 0 {
+		return 1 / float64(len(natVPCs))
+	}
+	if !users[vpcID] {
+		return 0
+	}
+	return 1 / float64(len(users))
+}
+
+// dependentVPCs returns the VPCs of the resources depending on res or that res depends on.
+func (a *analysis) dependentVPCs(res *resource.Resource) map[string]bool {
+	vpcs := make(map[string]bool)
+	link := func(id string) {
+		if other, ok := a.byID[id]; ok && other.ID != res.ID {
+			if vpc := a.vpcOf(other); vpc != "" {
+				vpcs[vpc] = true
+			}
+		}
+	}
+	for _, dep := range res.DependsOn {
+		link(dep)
+	}
+	for id, deps := range a.arch.Dependencies {
+		for _, dep := range deps {
+			if id == res.ID {
+				link(dep)
+			} else if dep == res.ID {
+				link(id)
+			}
+		}
+	}
+	for _, other := range a.resources {
+		for _, dep := range other.DependsOn {
+			if dep == res.ID {
+				link(other.ID)
+			}
+		}
+	}
+	return vpcs
+}
+
+func (a *analysis) hasGatewayEndpoint(vpcID, service string) bool {
+	for _, ep := range a.ofType("VPCEndpoint") {
+		if a.vpcOf(ep) != vpcID {
+			continue
+		}
+		name, _ := str(ep, "serviceName", "service_name")
+		endpointType, _ := str(ep, "vpcEndpointType", "vpc_endpoint_type", "endpointType")
+		if strings.HasSuffix(strings.ToLower(name), "."+service) || strings.EqualFold(name, service) {
+			if endpointType == "" || strings.EqualFold(endpointType, "Gateway") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unattachedElasticIPs flags Elastic IPs nothing uses, which are charged hourly.
+func unattachedElasticIPs(a *analysis) []Suggestion {
+	used := make(map[string]bool)
+	for id, deps := range a.arch.Dependencies {
+		if len(deps) > 0 {
+			used[id] = true
+		}
+		for _, dep := range deps {
+			used[dep] = true
+		}
+	}
+	for _, res := range a.resources {
+		for _, dep := range res.DependsOn {
+			used[dep] = true
+		}
+		if id, ok := str(res, "allocationId", "allocation_id"); ok {
+			used[id] = true
+		}
+	}
+
+	var out []Suggestion
+	for _, eip := range a.ofType("ElasticIP") {
+		if used[eip.ID] || len(eip.DependsOn) > 0 {
+			continue
+		}
+		if _, ok := str(eip, "instance", "instanceId", "instance_id", "networkInterfaceId", "network_interface"); ok {
+			continue
+		}
+		hourly, ok := a.rates.ElasticIPHourlyRate(a.ctx, a.region(eip))
+		if !ok {
+			continue
+		}
+		s := newSuggestion(RuleUnattachedEIP, eip, hourly*HoursPerMonth, 0)
+		s.Title = "Release unattached Elastic IP"
+		s.Description = fmt.Sprintf("Elastic IP '%s' is not attached to an instance, network interface or NAT gateway. Unattached Elastic IPs are charged hourly.", eip.Name)
+		out = append(out, s)
+	}
+	return out
+}
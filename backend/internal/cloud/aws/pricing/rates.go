@@ -2,6 +2,7 @@ package pricing
 
 import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
 
 // NetworkingPricingRates contains static pricing rates for AWS networking resources
@@ -52,6 +53,75 @@ var NetworkingPricingRates = map[string]AWSPricingRate{
 	},
 }
 
+// FreeTierOffers are the 12 month AWS Free Tier allowances of new accounts, by cost
+// component. Always free allowances (Lambda requests, the first GB of data transfer out)
+// are deducted by the calculators and are not listed.
+var FreeTierOffers = map[string]FreeTierAllowance{
+	"EC2 Instance Hourly": {
+		Amount:             750, // 750 hours of eligible instances per month
+		Unit:               "hours",
+		Period:             pricing.Monthly,
+		ExpiresAfterMonths: 12,
+		Eligible:           []string{"t2.micro", "t3.micro"},
+	},
+	"RDS Instance Hourly": {
+		Amount:             750,
+		Unit:               "hours",
+		Period:             pricing.Monthly,
+		ExpiresAfterMonths: 12,
+		Eligible:           []string{"db.t2.micro", "db.t3.micro", "db.t4g.micro"},
+	},
+	"Load Balancer Hourly": {
+		Amount:             750,
+		Unit:               "hours",
+		Period:             pricing.Monthly,
+		ExpiresAfterMonths: 12,
+		Eligible:           []string{"application"},
+	},
+	"EBS Volume Storage": {
+		Amount:             30, // 30 GB of volumes
+		Unit:               "GB",
+		Period:             pricing.Monthly,
+		ExpiresAfterMonths: 12,
+	},
+	"S3 Storage": {
+		Amount:             5, // 5 GB of S3 Standard
+		Unit:               "GB",
+		Period:             pricing.Monthly,
+		ExpiresAfterMonths: 12,
+		Eligible:           []string{"standard"},
+	},
+}
+
+// GetFreeTierOffer returns the expiring Free Tier allowance of a cost component of a resource,
+// when the resource is eligible for it
+func GetFreeTierOffer(res *resource.Resource, componentName string) (*FreeTierAllowance, bool) {
+	offer, ok := FreeTierOffers[componentName]
+	if !ok {
+		return nil, false
+	}
+	if len(offer.Eligible) == 0 {
+		return &offer, true
+	}
+	var attribute string
+	switch componentName {
+	case "EC2 Instance Hourly":
+		attribute = metadataString(res, "instance_type", "t3.micro")
+	case "RDS Instance Hourly":
+		attribute = metadataString(res, "instance_class", "db.t3.micro")
+	case "Load Balancer Hourly":
+		attribute = metadataString(res, "load_balancer_type", "application")
+	case "S3 Storage":
+		attribute = metadataString(res, "storage_class", "standard")
+	}
+	for _, eligible := range offer.Eligible {
+		if eligible == attribute {
+			return &offer, true
+		}
+	}
+	return nil, false
+}
+
 // GetNetworkingPricingRates returns the pricing rates for networking resources
 func GetNetworkingPricingRates() map[string]AWSPricingRate {
 	return NetworkingPricingRates
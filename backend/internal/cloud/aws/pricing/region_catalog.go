@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// StaticPricingRegion is the region the static rate tables are priced for. Other regions
+// are priced with the region catalog imported from the AWS Price List.
+const StaticPricingRegion = "us-east-1"
+
+// DataTransferResourceType is the catalog resource type of data transfer rates, shared by
+// every resource sending data out of AWS and by the edge traffic of usage profiles
+const DataTransferResourceType = domainpricing.DataTransferResourceType
+
+// Catalog component names that are not component names of a static estimate
+const (
+	DataTransferOutComponent     = "Data Transfer Out"
+	DataTransferInterAZComponent = "Data Transfer Inter-AZ"
+)
+
+// catalogResourceTypes are the resource types priced with the region catalog
+var catalogResourceTypes = map[string]bool{
+	"nat_gateway":            true,
+	"elastic_ip":             true,
+	"ebs_volume":             true,
+	"s3_bucket":              true,
+	"load_balancer":          true,
+	"lambda_function":        true,
+	"rds_instance":           true,
+	DataTransferResourceType: true,
+}
+
+// RegionPricingError reports that the region catalog has no rates for a resource in a
+// region, so it cannot be priced there
+type RegionPricingError struct {
+	ResourceType string
+	Region       string
+	Components   []string
+}
+
+func (e *RegionPricingError) Error() string {
+	return fmt.Sprintf("no %s pricing for region %s (%s): import the AWS Price List for the region with cmd/import_pricing -price-list",
+		e.ResourceType, e.Region, strings.Join(e.Components, ", "))
+}
+
+// CatalogComponentName returns the catalog component name of a rate that varies with
+// resource configuration (e.g., "EBS Volume Storage (gp3)")
+func CatalogComponentName(component string, attributes ...string) string {
+	if len(attributes) == 0 {
+		return component
+	}
+	return component + " (" + strings.Join(attributes, ", ") + ")"
+}
+
+// catalogComponent is the catalog rate pricing a component of a static estimate
+type catalogComponent struct {
+	resourceType  string
+	componentName string
+	instanceType  string
+}
+
+// catalogComponentFor returns the catalog rate of a component of a static estimate,
+// reading the same configuration, with the same defaults, as the static calculation
+func catalogComponentFor(res *resource.Resource, component string) (catalogComponent, bool) {
+	resourceType := res.Type.Name
+	switch component {
+	case "S3 Data Transfer Out", "Lambda Data Transfer Out", DataTransferOutComponent:
+		return catalogComponent{resourceType: DataTransferResourceType, componentName: DataTransferOutComponent}, true
+	case DataTransferInterAZComponent:
+		return catalogComponent{resourceType: DataTransferResourceType, componentName: DataTransferInterAZComponent}, true
+	case "EBS Volume Storage":
+		return catalogComponent{resourceType: resourceType, componentName: CatalogComponentName(component, metadataString(res, "volume_type", "gp3"))}, true
+	case "S3 Storage":
+		return catalogComponent{resourceType: resourceType, componentName: CatalogComponentName(component, metadataString(res, "storage_class", "standard"))}, true
+	case "Load Balancer Hourly", "Load Balancer LCU":
+		return catalogComponent{resourceType: resourceType, componentName: CatalogComponentName(component, metadataString(res, "load_balancer_type", "application"))}, true
+	case "RDS Instance Hourly":
+		deployment := "single-az"
+		if multiAZ, ok := res.Metadata["multi_az"].(bool); ok && multiAZ {
+			deployment = "multi-az"
+		}
+		return catalogComponent{
+			resourceType:  resourceType,
+			componentName: CatalogComponentName(component, metadataString(res, "engine", "mysql"), deployment),
+			instanceType:  metadataString(res, "instance_class", "db.t3.micro"),
+		}, true
+	case "NAT Gateway Hourly", "NAT Gateway Data Processing", "Elastic IP Hourly (Unattached)",
+		"S3 PUT Requests", "S3 GET Requests", "Lambda Compute", "Lambda Requests":
+		return catalogComponent{resourceType: resourceType, componentName: component}, true
+	}
+	return catalogComponent{}, false
+}
+
+func metadataString(res *resource.Resource, key, defaultValue string) string {
+	if s, ok := res.Metadata[key].(string); ok && s != "" {
+		return s
+	}
+	return defaultValue
+}
+
+// priceForRegion re-prices the static estimate of a resource with the catalog rates of its
+// region. Components without a catalog rate keep their static rate in StaticPricingRegion;
+// in any other region they make the resource unpriceable.
+func priceForRegion(res *resource.Resource, estimate *domainpricing.CostEstimate, catalog map[string][]*models.PricingRate) error {
+	var missing []string
+	var delta float64
+	for i := range estimate.Breakdown {
+		component := &estimate.Breakdown[i]
+		cc, ok := catalogComponentFor(res, component.ComponentName)
+		if !ok {
+			continue
+		}
+		rate, ok := pickCatalogRate(catalog[cc.resourceType], cc, res.Region)
+		if !ok {
+			if res.Region != "" && res.Region != StaticPricingRegion {
+				missing = append(missing, component.ComponentName)
+			}
+			continue
+		}
+		subtotal := component.Quantity * rate
+		delta += subtotal - component.Subtotal
+		component.UnitRate = rate
+		component.Subtotal = subtotal
+	}
+	if len(missing) > 0 {
+		return &RegionPricingError{ResourceType: res.Type.Name, Region: res.Region, Components: missing}
+	}
+	estimate.TotalCost += delta
+	return nil
+}
+
+// pickCatalogRate selects the catalog rate of a component, preferring the region's rate
+// over a rate without a region
+func pickCatalogRate(rates []*models.PricingRate, cc catalogComponent, region string) (float64, bool) {
+	var best *models.PricingRate
+	for _, r := range rates {
+		if r.ComponentName != cc.componentName {
+			continue
+		}
+		if cc.instanceType != "" && (r.InstanceType == nil || *r.InstanceType != cc.instanceType) {
+			continue
+		}
+		if r.Region != nil && *r.Region != region {
+			continue
+		}
+		if best == nil || (best.Region == nil && r.Region != nil) {
+			best = r
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.Rate, true
+}
+
+// loadCatalog loads the catalog rates of a resource in its region, including the data
+// transfer rates
+func (c *AWSPricingCalculator) loadCatalog(ctx context.Context, resourceType, region string) map[string][]*models.PricingRate {
+	catalog := make(map[string][]*models.PricingRate)
+	if !c.useDBRates || c.pricingRateRepo == nil {
+		return catalog
+	}
+	var regionPtr *string
+	if region != "" {
+		regionPtr = &region
+	}
+	resourceTypes := []string{resourceType}
+	if resourceType != DataTransferResourceType {
+		resourceTypes = append(resourceTypes, DataTransferResourceType)
+	}
+	for _, rt := range resourceTypes {
+		if rates, err := c.pricingRateRepo.FindActiveRates(ctx, "aws", rt, regionPtr); err == nil {
+			catalog[rt] = rates
+		}
+	}
+	return catalog
+}
+
+// catalogRate returns the region's catalog rate of a component, or the static rate in
+// StaticPricingRegion. ok is false when the region has no rate.
+func (c *AWSPricingCalculator) catalogRate(ctx context.Context, resourceType, componentName, region string, staticRate func() (float64, bool)) (float64, bool) {
+	cc := catalogComponent{resourceType: resourceType, componentName: componentName}
+	if rate, ok := pickCatalogRate(c.loadCatalog(ctx, resourceType, region)[resourceType], cc, region); ok {
+		return rate, true
+	}
+	if region != "" && region != StaticPricingRegion {
+		return 0, false
+	}
+	return staticRate()
+}
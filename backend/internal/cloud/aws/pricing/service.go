@@ -7,6 +7,7 @@ import (
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/inventory"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/compute"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/database"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/networking"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/storage"
 	pricingrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/pricing"
@@ -147,6 +148,9 @@ func (s *AWSPricingService) getPricingFallback(resourceType string, region strin
 		// Default to 128 MB memory if not provided
 		memorySizeMB := 128.0
 		return compute.GetLambdaFunctionPricing(memorySizeMB, region), nil
+	case "dynamodb_table":
+		// Default to on-demand capacity if billing mode not provided
+		return database.GetDynamoDBTablePricing(database.DynamoDBOnDemand, region), nil
 	default:
 		return nil, fmt.Errorf("pricing not available for resource type: %s", resourceType)
 	}
@@ -164,6 +168,7 @@ func mapPricingTypeToResourceName(pricingType string) string {
 		"load_balancer":      "LoadBalancer",
 		"auto_scaling_group": "AutoScalingGroup",
 		"lambda_function":    "Lambda",
+		"dynamodb_table":     "DynamoDB",
 	}
 
 	if mapped, ok := mapping[pricingType]; ok {
@@ -199,5 +204,6 @@ func (s *AWSPricingService) ListSupportedResources(ctx context.Context, provider
 		"load_balancer",
 		"auto_scaling_group",
 		"lambda_function",
+		"dynamodb_table",
 	}, nil
 }
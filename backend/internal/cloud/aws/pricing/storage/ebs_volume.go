@@ -18,6 +18,12 @@ var EBSVolumeRates = map[string]float64{
 	"standard": 0.05,  // $0.05 per GB-month
 }
 
+// gp3 volumes include a baseline of 3,000 IOPS; more are provisioned per IOPS-month
+const (
+	GP3BaselineIOPS = 3000
+	GP3IOPSRate     = 0.005 // $0.005 per provisioned IOPS-month above the baseline
+)
+
 // EBSRegionalMultipliers contains regional pricing multipliers for EBS volumes
 var EBSRegionalMultipliers = map[string]float64{
 	"us-east-1":      1.0, // Base rate multiplier
@@ -10,9 +10,11 @@ import (
 // S3StorageRates contains static pricing rates for AWS S3 storage classes (per GB-month)
 // These rates are based on AWS public pricing as of 2024
 var S3StorageRates = map[string]float64{
-	"standard":    0.023,  // $0.023 per GB-month (Standard storage)
-	"standard-ia": 0.0125, // $0.0125 per GB-month (Standard-IA storage)
-	"glacier":     0.004,  // $0.004 per GB-month (Glacier storage)
+	"standard":     0.023,   // $0.023 per GB-month (Standard storage)
+	"standard-ia":  0.0125,  // $0.0125 per GB-month (Standard-IA storage)
+	"onezone-ia":   0.01,    // $0.01 per GB-month (One Zone-IA storage)
+	"glacier":      0.004,   // $0.004 per GB-month (Glacier storage)
+	"deep-archive": 0.00099, // $0.00099 per GB-month (Glacier Deep Archive storage)
 }
 
 // S3RequestRates contains static pricing rates for AWS S3 requests (per 1,000 requests)
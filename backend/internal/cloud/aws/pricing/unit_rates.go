@@ -0,0 +1,103 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/compute"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/networking"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/storage"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+)
+
+// Unit rates let callers compare alternatives (another instance type, volume type or
+// storage class) without building resources. Imported pricing rates are used when the
+// calculator has them; the static rate tables price StaticPricingRegion otherwise.
+
+// InstanceHourlyRate returns the On-Demand hourly rate of an EC2 instance type.
+func (c *AWSPricingCalculator) InstanceHourlyRate(ctx context.Context, instanceType, region, operatingSystem string) (float64, bool) {
+	if operatingSystem == "" {
+		operatingSystem = "linux"
+	}
+	if c.useDBRates && c.pricingRateRepo != nil {
+		rates, err := c.pricingRateRepo.FindByInstanceType(ctx, "aws", instanceType, region, operatingSystem)
+		if err == nil {
+			if rate, ok := pickRate(rates, "per_hour"); ok {
+				return rate, true
+			}
+		}
+	}
+	if _, ok := compute.EC2InstanceRates[instanceType]; !ok {
+		return 0, false
+	}
+	return compute.GetEC2InstancePricing(instanceType, region).Components[0].Rate, true
+}
+
+// EBSVolumeRate returns the storage rate of an EBS volume type, per GB-month.
+func (c *AWSPricingCalculator) EBSVolumeRate(ctx context.Context, volumeType, region string) (float64, bool) {
+	return c.catalogRate(ctx, "ebs_volume", CatalogComponentName("EBS Volume Storage", volumeType), region, func() (float64, bool) {
+		if _, ok := storage.EBSVolumeRates[volumeType]; !ok {
+			return 0, false
+		}
+		return storage.GetEBSVolumePricing(volumeType, region).Components[0].Rate, true
+	})
+}
+
+// S3StorageRate returns the storage rate of an S3 storage class, per GB-month.
+func (c *AWSPricingCalculator) S3StorageRate(ctx context.Context, storageClass, region string) (float64, bool) {
+	return c.catalogRate(ctx, "s3_bucket", CatalogComponentName("S3 Storage", storageClass), region, func() (float64, bool) {
+		if _, ok := storage.S3StorageRates[storageClass]; !ok {
+			return 0, false
+		}
+		return storage.GetS3BucketPricing(storageClass, region).Components[0].Rate, true
+	})
+}
+
+// NATGatewayRates returns the hourly rate of a NAT gateway and its data processing rate
+// per GB.
+func (c *AWSPricingCalculator) NATGatewayRates(ctx context.Context, region string) (hourly, perGB float64, ok bool) {
+	static := networking.GetNATGatewayPricing(region)
+	hourly, ok = c.catalogRate(ctx, "nat_gateway", "NAT Gateway Hourly", region, func() (float64, bool) {
+		return static.Components[0].Rate, true
+	})
+	if !ok {
+		return 0, 0, false
+	}
+	perGB, ok = c.catalogRate(ctx, "nat_gateway", "NAT Gateway Data Processing", region, func() (float64, bool) {
+		return static.Components[1].Rate, true
+	})
+	return hourly, perGB, ok
+}
+
+// InterAZTransferRate returns the rate of data transfer between availability zones, per
+// GB in each direction.
+func (c *AWSPricingCalculator) InterAZTransferRate(ctx context.Context, region string) (float64, bool) {
+	return c.catalogRate(ctx, DataTransferResourceType, DataTransferInterAZComponent, region, func() (float64, bool) {
+		return networking.DataTransferPricing[networking.InterAZ], true
+	})
+}
+
+// ElasticIPHourlyRate returns the hourly rate of an Elastic IP that is not attached to a
+// running instance.
+func (c *AWSPricingCalculator) ElasticIPHourlyRate(ctx context.Context, region string) (float64, bool) {
+	return c.catalogRate(ctx, "elastic_ip", "Elastic IP Hourly (Unattached)", region, func() (float64, bool) {
+		return networking.GetElasticIPPricing(region).Components[0].Rate, true
+	})
+}
+
+// pickRate selects the rate with the pricing model, preferring a regional rate over the
+// default one.
+func pickRate(rates []*models.PricingRate, pricingModel string) (float64, bool) {
+	var best *models.PricingRate
+	for _, r := range rates {
+		if r.PricingModel != pricingModel {
+			continue
+		}
+		if best == nil || (best.Region == nil && r.Region != nil) {
+			best = r
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.Rate, true
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// cidrKeys are the configuration keys holding a resource's CIDR block
+var cidrKeys = []string{"cidr", "cidrBlock", "cidr_block", "ipv6CidrBlock", "ipv6_cidr_block"}
+
+// CIDRConstraintRule validates that a resource's CIDR blocks lie within one of the
+// allowed ranges
+type CIDRConstraintRule struct {
+	ResourceType string
+	Allowed      []*net.IPNet
+}
+
+func (r *CIDRConstraintRule) GetType() RuleType {
+	return RuleTypeCIDRConstraint
+}
+
+func (r *CIDRConstraintRule) GetResourceType() string {
+	return r.ResourceType
+}
+
+func (r *CIDRConstraintRule) GetValue() string {
+	parts := make([]string, len(r.Allowed))
+	for i, n := range r.Allowed {
+		parts[i] = n.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+func (r *CIDRConstraintRule) Evaluate(ctx context.Context, evalCtx *EvaluationContext) error {
+	if evalCtx.Resource == nil {
+		return fmt.Errorf("resource is required for evaluation")
+	}
+
+	// Resources without a configured CIDR block have nothing to check.
+	for _, key := range cidrKeys {
+		block, ok := evalCtx.Resource.Metadata[key].(string)
+		if !ok || block == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(block)
+		if err != nil {
+			return r.violation(evalCtx, fmt.Sprintf("resource has an invalid CIDR block %q", block))
+		}
+		if !r.allows(network) {
+			return r.violation(evalCtx, fmt.Sprintf("resource CIDR block %s is not within the allowed ranges: %s", block, r.GetValue()))
+		}
+	}
+	return nil
+}
+
+// allows reports whether one of the allowed ranges contains the whole network
+func (r *CIDRConstraintRule) allows(network *net.IPNet) bool {
+	ones, bits := network.Mask.Size()
+	for _, allowed := range r.Allowed {
+		allowedOnes, allowedBits := allowed.Mask.Size()
+		if allowedBits == bits && allowedOnes <= ones && allowed.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CIDRConstraintRule) violation(evalCtx *EvaluationContext, message string) *RuleError {
+	return &RuleError{
+		RuleType:     RuleTypeCIDRConstraint,
+		ResourceID:   evalCtx.Resource.ID,
+		ResourceName: evalCtx.Resource.Name,
+		ResourceType: r.ResourceType,
+		Message:      message,
+		Value:        r.GetValue(),
+	}
+}
+
+// NewCIDRConstraintRule creates a new CIDRConstraintRule
+func NewCIDRConstraintRule(resourceType string, allowed []*net.IPNet) *CIDRConstraintRule {
+	return &CIDRConstraintRule{
+		ResourceType: resourceType,
+		Allowed:      allowed,
+	}
+}
+
+// parseCIDRs parses a cidr_constraint value: comma-separated CIDR blocks
+func parseCIDRs(value string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, block := range parseCommaSeparated(value) {
+		_, network, err := net.ParseCIDR(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr_constraint value %q: %w", value, err)
+		}
+		networks = append(networks, network)
+	}
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("cidr_constraint needs at least one CIDR block")
+	}
+	return networks, nil
+}
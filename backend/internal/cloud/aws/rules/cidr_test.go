@@ -0,0 +1,41 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestCIDRConstraintRule(t *testing.T) {
+	rule, err := NewRuleFactory().CreateRule("VPC", "cidr_constraint", "10.0.0.0/8, 172.16.0.0/12")
+	if err != nil {
+		t.Fatalf("CreateRule: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		metadata      map[string]interface{}
+		expectedError bool
+	}{
+		{"within first range", map[string]interface{}{"cidr": "10.20.0.0/16"}, false},
+		{"within second range", map[string]interface{}{"cidr_block": "172.16.4.0/22"}, false},
+		{"outside", map[string]interface{}{"cidr": "192.168.0.0/16"}, true},
+		{"wider than the range", map[string]interface{}{"cidr": "10.0.0.0/7"}, true},
+		{"invalid block", map[string]interface{}{"cidrBlock": "10.0.0.0/33"}, true},
+		{"no cidr configured", map[string]interface{}{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &resource.Resource{ID: "vpc-1", Name: "main", Type: resource.ResourceType{Name: "VPC"}, Metadata: tt.metadata}
+			err := rule.Evaluate(context.Background(), &EvaluationContext{Resource: res})
+			if (err != nil) != tt.expectedError {
+				t.Errorf("Evaluate = %v, expected error: %v", err, tt.expectedError)
+			}
+		})
+	}
+
+	if _, err := NewRuleFactory().CreateRule("VPC", "cidr_constraint", "10.0.0.0"); err == nil {
+		t.Error("CreateRule accepted a value that is not a CIDR block")
+	}
+}
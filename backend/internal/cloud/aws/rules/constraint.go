@@ -15,6 +15,9 @@ const (
 	ConstraintTypeMinChildren           ConstraintType = "min_children"
 	ConstraintTypeAllowedDependencies   ConstraintType = "allowed_dependencies"
 	ConstraintTypeForbiddenDependencies ConstraintType = "forbidden_dependencies"
+	ConstraintTypeRequiresTag           ConstraintType = "requires_tag"
+	ConstraintTypeCIDRConstraint        ConstraintType = "cidr_constraint"
+	ConstraintTypePortRange             ConstraintType = "port_range"
 )
 
 // Constraint represents a constraint definition
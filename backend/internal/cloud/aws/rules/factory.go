@@ -42,6 +42,12 @@ func (f *AWSRuleFactory) CreateRule(resourceType string, constraintType string,
 		return f.createForbiddenDependenciesRule(resourceType, constraintValue)
 	case RuleTypeRequiresDependency:
 		return f.createRequiresDependencyRule(resourceType, constraintValue)
+	case RuleTypeRequiresTag:
+		return f.createRequiresTagRule(resourceType, constraintValue)
+	case RuleTypeCIDRConstraint:
+		return f.createCIDRConstraintRule(resourceType, constraintValue)
+	case RuleTypePortRange:
+		return f.createPortRangeRule(resourceType, constraintValue)
 	default:
 		// Fall back to default factory for unknown types
 		return f.RuleFactory.CreateRule(resourceType, constraintType, constraintValue)
@@ -98,6 +104,31 @@ func (f *AWSRuleFactory) createRequiresDependencyRule(resourceType, constraintVa
 	return NewRequiresDependencyRule(resourceType, requiredType), nil
 }
 
+func (f *AWSRuleFactory) createRequiresTagRule(resourceType, constraintValue string) (Rule, error) {
+	// Tag keys are case-sensitive in AWS, so they are kept as written.
+	tags, values, err := parseRequiredTags(constraintValue)
+	if err != nil {
+		return nil, err
+	}
+	return NewRequiresTagRule(resourceType, tags, values), nil
+}
+
+func (f *AWSRuleFactory) createCIDRConstraintRule(resourceType, constraintValue string) (Rule, error) {
+	allowed, err := parseCIDRs(constraintValue)
+	if err != nil {
+		return nil, err
+	}
+	return NewCIDRConstraintRule(resourceType, allowed), nil
+}
+
+func (f *AWSRuleFactory) createPortRangeRule(resourceType, constraintValue string) (Rule, error) {
+	allowed, err := parsePortRanges(constraintValue)
+	if err != nil {
+		return nil, err
+	}
+	return NewPortRangeRule(resourceType, allowed), nil
+}
+
 // mapResourceTypeToAWS maps domain resource types to AWS-specific types
 // This allows AWS to implement rules using its own naming conventions
 func (f *AWSRuleFactory) mapResourceTypeToAWS(domainType string) string {
@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource/networking"
+)
+
+// PortRange is an inclusive range of ports
+type PortRange struct {
+	From int
+	To   int
+}
+
+func (p PortRange) String() string {
+	if p.From == p.To {
+		return strconv.Itoa(p.From)
+	}
+	return fmt.Sprintf("%d-%d", p.From, p.To)
+}
+
+// PortRangeRule validates that the ingress rules of a security group only open ports
+// within the allowed ranges. Egress rules are not checked.
+type PortRangeRule struct {
+	ResourceType string
+	Allowed      []PortRange
+}
+
+func (r *PortRangeRule) GetType() RuleType {
+	return RuleTypePortRange
+}
+
+func (r *PortRangeRule) GetResourceType() string {
+	return r.ResourceType
+}
+
+func (r *PortRangeRule) GetValue() string {
+	parts := make([]string, len(r.Allowed))
+	for i, p := range r.Allowed {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+func (r *PortRangeRule) Evaluate(ctx context.Context, evalCtx *EvaluationContext) error {
+	if evalCtx.Resource == nil {
+		return fmt.Errorf("resource is required for evaluation")
+	}
+
+	var disallowed []string
+	for _, rule := range architecture.SecurityGroupRules(evalCtx.Resource) {
+		if rule.Type != "ingress" || rule.Protocol == networking.ProtocolICMP {
+			continue
+		}
+		opened := PortRange{From: 0, To: 65535}
+		if rule.Protocol != networking.ProtocolAll {
+			if rule.FromPort != nil {
+				opened.From = *rule.FromPort
+			}
+			if rule.ToPort != nil {
+				opened.To = *rule.ToPort
+			}
+		}
+		if !r.allows(opened) {
+			disallowed = append(disallowed, fmt.Sprintf("%s/%s", ruleProtocolName(rule.Protocol), opened))
+		}
+	}
+	if len(disallowed) == 0 {
+		return nil
+	}
+	return &RuleError{
+		RuleType:     RuleTypePortRange,
+		ResourceID:   evalCtx.Resource.ID,
+		ResourceName: evalCtx.Resource.Name,
+		ResourceType: r.ResourceType,
+		Message:      fmt.Sprintf("security group opens ports outside the allowed ranges (%s): %s", r.GetValue(), strings.Join(disallowed, ", ")),
+		Value:        r.GetValue(),
+	}
+}
+
+// allows reports whether the allowed ranges together cover the opened range
+func (r *PortRangeRule) allows(opened PortRange) bool {
+	next := opened.From
+	for next <= opened.To {
+		covered := false
+		for _, p := range r.Allowed {
+			if p.From <= next && next <= p.To {
+				next = p.To + 1
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func ruleProtocolName(protocol networking.Protocol) string {
+	if protocol == networking.ProtocolAll {
+		return "all"
+	}
+	return string(protocol)
+}
+
+// NewPortRangeRule creates a new PortRangeRule
+func NewPortRangeRule(resourceType string, allowed []PortRange) *PortRangeRule {
+	return &PortRangeRule{
+		ResourceType: resourceType,
+		Allowed:      allowed,
+	}
+}
+
+// parsePortRanges parses a port_range value: comma-separated ports and port ranges,
+// e.g. "80,443,1024-65535"
+func parsePortRanges(value string) ([]PortRange, error) {
+	var ranges []PortRange
+	for _, entry := range parseCommaSeparated(value) {
+		fromStr, toStr, isRange := strings.Cut(entry, "-")
+		from, err := strconv.Atoi(strings.TrimSpace(fromStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port_range value %q: %w", value, err)
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(strings.TrimSpace(toStr)); err != nil {
+				return nil, fmt.Errorf("invalid port_range value %q: %w", value, err)
+			}
+		}
+		if from < 0 || to > 65535 || from > to {
+			return nil, fmt.Errorf("invalid port_range value %q: %s is not a range within 0-65535", value, entry)
+		}
+		ranges = append(ranges, PortRange{From: from, To: to})
+	}
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("port_range needs at least one port or range")
+	}
+	return ranges, nil
+}
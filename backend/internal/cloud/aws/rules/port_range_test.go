@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestPortRangeRule(t *testing.T) {
+	rule, err := NewAWSRuleFactory().CreateRule("SecurityGroup", "port_range", "80,443,1024-65535")
+	if err != nil {
+		t.Fatalf("CreateRule: %v", err)
+	}
+
+	ingress := func(protocol string, from, to float64) map[string]interface{} {
+		return map[string]interface{}{"protocol": protocol, "fromPort": from, "toPort": to, "cidr": "0.0.0.0/0"}
+	}
+	tests := []struct {
+		name    string
+		rules   []interface{}
+		wantErr string
+	}{
+		{"allowed ports", []interface{}{ingress("tcp", 443, 443), ingress("tcp", 8000, 8080)}, ""},
+		{"adjacent allowed ranges", []interface{}{ingress("tcp", 1024, 2048)}, ""},
+		{"ssh", []interface{}{ingress("tcp", 80, 80), ingress("tcp", 22, 22)}, "tcp/22"},
+		{"range reaching outside", []interface{}{ingress("tcp", 80, 443)}, "tcp/80-443"},
+		{"all traffic", []interface{}{map[string]interface{}{"protocol": "-1", "cidr": "10.0.0.0/8"}}, "all/0-65535"},
+		{"icmp is not checked", []interface{}{ingress("icmp", -1, -1)}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &resource.Resource{
+				ID:       "sg-1",
+				Name:     "web-sg",
+				Type:     resource.ResourceType{Name: "SecurityGroup"},
+				Metadata: map[string]interface{}{"ingressRules": tt.rules, "egressRules": []interface{}{map[string]interface{}{"protocol": "-1", "cidr": "0.0.0.0/0"}}},
+			}
+			err := rule.Evaluate(context.Background(), &EvaluationContext{Resource: res})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Evaluate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Evaluate = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "ssh", "443-80", "70000"} {
+		if _, err := NewAWSRuleFactory().CreateRule("SecurityGroup", "port_range", value); err == nil {
+			t.Errorf("CreateRule(%q) succeeded, want an error", value)
+		}
+	}
+}
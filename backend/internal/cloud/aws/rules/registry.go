@@ -125,6 +125,24 @@ func (f *DefaultRuleFactory) CreateRule(resourceType string, constraintType stri
 	case RuleTypeForbiddenDependencies:
 		forbiddenTypes := parseCommaSeparated(constraintValue)
 		return NewForbiddenDependenciesRule(resourceType, forbiddenTypes), nil
+	case RuleTypeRequiresTag:
+		tags, values, err := parseRequiredTags(constraintValue)
+		if err != nil {
+			return nil, err
+		}
+		return NewRequiresTagRule(resourceType, tags, values), nil
+	case RuleTypeCIDRConstraint:
+		allowed, err := parseCIDRs(constraintValue)
+		if err != nil {
+			return nil, err
+		}
+		return NewCIDRConstraintRule(resourceType, allowed), nil
+	case RuleTypePortRange:
+		allowed, err := parsePortRanges(constraintValue)
+		if err != nil {
+			return nil, err
+		}
+		return NewPortRangeRule(resourceType, allowed), nil
 	default:
 		return nil, fmt.Errorf("unknown constraint type: %s", constraintType)
 	}
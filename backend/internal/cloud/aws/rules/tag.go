@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RequiresTagRule validates that a resource carries the required tags, optionally with
+// one of a set of allowed values
+type RequiresTagRule struct {
+	ResourceType string
+	Tags         []string            // Required tag keys, in declaration order
+	Values       map[string][]string // Allowed values by tag key; keys without an entry accept any value
+}
+
+func (r *RequiresTagRule) GetType() RuleType {
+	return RuleTypeRequiresTag
+}
+
+func (r *RequiresTagRule) GetResourceType() string {
+	return r.ResourceType
+}
+
+// GetValue returns the rule in its stored form, e.g. "Environment=dev|prod,Owner"
+func (r *RequiresTagRule) GetValue() string {
+	parts := make([]string, len(r.Tags))
+	for i, key := range r.Tags {
+		parts[i] = key
+		if values := r.Values[key]; len(values) > 0 {
+			parts[i] += "=" + strings.Join(values, "|")
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func (r *RequiresTagRule) Evaluate(ctx context.Context, evalCtx *EvaluationContext) error {
+	if evalCtx.Resource == nil {
+		return fmt.Errorf("resource is required for evaluation")
+	}
+
+	tags := resourceTags(evalCtx.Resource.Metadata)
+	// Generated Terraform always sets Name from the resource name.
+	if _, ok := tags["Name"]; !ok && evalCtx.Resource.Name != "" {
+		tags["Name"] = evalCtx.Resource.Name
+	}
+
+	var missing, invalid []string
+	for _, key := range r.Tags {
+		value, ok := tags[key]
+		if !ok || strings.TrimSpace(value) == "" {
+			missing = append(missing, key)
+			continue
+		}
+		if allowed := r.Values[key]; len(allowed) > 0 && !contains(allowed, value) {
+			invalid = append(invalid, fmt.Sprintf("%s=%q (allowed: %s)", key, value, strings.Join(allowed, ", ")))
+		}
+	}
+	if len(missing) == 0 && len(invalid) == 0 {
+		return nil
+	}
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing required tags: "+strings.Join(missing, ", "))
+	}
+	if len(invalid) > 0 {
+		problems = append(problems, "tags with disallowed values: "+strings.Join(invalid, "; "))
+	}
+	return &RuleError{
+		RuleType:     RuleTypeRequiresTag,
+		ResourceID:   evalCtx.Resource.ID,
+		ResourceName: evalCtx.Resource.Name,
+		ResourceType: r.ResourceType,
+		Message:      "resource has " + strings.Join(problems, " and "),
+		Value:        r.GetValue(),
+	}
+}
+
+// NewRequiresTagRule creates a new RequiresTagRule
+func NewRequiresTagRule(resourceType string, tags []string, values map[string][]string) *RequiresTagRule {
+	if values == nil {
+		values = make(map[string][]string)
+	}
+	return &RequiresTagRule{
+		ResourceType: resourceType,
+		Tags:         tags,
+		Values:       values,
+	}
+}
+
+// parseRequiredTags parses a requires_tag value: comma-separated tag keys, each
+// optionally followed by "=" and "|"-separated allowed values
+func parseRequiredTags(value string) ([]string, map[string][]string, error) {
+	var keys []string
+	values := make(map[string][]string)
+	for _, entry := range parseCommaSeparated(value) {
+		key, allowed, hasValues := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, nil, fmt.Errorf("invalid requires_tag value %q: empty tag key", value)
+		}
+		keys = append(keys, key)
+		if hasValues {
+			for _, v := range strings.Split(allowed, "|") {
+				if v = strings.TrimSpace(v); v != "" {
+					values[key] = append(values[key], v)
+				}
+			}
+			if len(values[key]) == 0 {
+				return nil, nil, fmt.Errorf("invalid requires_tag value %q: no allowed values for tag %s", value, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("requires_tag needs at least one tag key")
+	}
+	return keys, values, nil
+}
+
+// resourceTags reads the "tags" configuration of a resource, given either as an object
+// or as a list of {key, value} entries
+func resourceTags(metadata map[string]interface{}) map[string]string {
+	tags := make(map[string]string)
+	switch raw := metadata["tags"].(type) {
+	case map[string]interface{}:
+		for k, v := range raw {
+			tags[k] = fmt.Sprint(v)
+		}
+	case map[string]string:
+		for k, v := range raw {
+			tags[k] = v
+		}
+	case []interface{}:
+		for _, item := range raw {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, _ := firstString(entry, "key", "Key")
+			value, _ := firstString(entry, "value", "Value")
+			if key != "" {
+				tags[key] = value
+			}
+		}
+	}
+	return tags
+}
+
+func firstString(m map[string]interface{}, keys ...string) (string, bool) {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestRequiresTagRule(t *testing.T) {
+	factory := NewAWSRuleFactory()
+	rule, err := factory.CreateRule("EC2", "requires_tag", "Environment=dev|staging|prod, Owner")
+	if err != nil {
+		t.Fatalf("CreateRule: %v", err)
+	}
+	if rule.GetValue() != "Environment=dev|staging|prod,Owner" {
+		t.Errorf("GetValue = %q", rule.GetValue())
+	}
+
+	tests := []struct {
+		name    string
+		tags    interface{}
+		wantErr string
+	}{
+		{"all tags", map[string]interface{}{"Environment": "prod", "Owner": "web-team"}, ""},
+		{"tag list", []interface{}{map[string]interface{}{"key": "Environment", "value": "dev"}, map[string]interface{}{"key": "Owner", "value": "me"}}, ""},
+		{"missing owner", map[string]interface{}{"Environment": "prod"}, "missing required tags: Owner"},
+		{"no tags", nil, "missing required tags: Environment, Owner"},
+		{"empty owner", map[string]interface{}{"Environment": "prod", "Owner": " "}, "missing required tags: Owner"},
+		{"disallowed value", map[string]interface{}{"Environment": "qa", "Owner": "me"}, `Environment="qa"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &resource.Resource{ID: "ec2-1", Name: "web", Type: resource.ResourceType{Name: "EC2"}, Metadata: map[string]interface{}{}}
+			if tt.tags != nil {
+				res.Metadata["tags"] = tt.tags
+			}
+			err := rule.Evaluate(context.Background(), &EvaluationContext{Resource: res})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Evaluate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Evaluate = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Name is always set by generated Terraform.
+	nameRule, _ := factory.CreateRule("EC2", "requires_tag", "Name")
+	res := &resource.Resource{ID: "ec2-1", Name: "web", Type: resource.ResourceType{Name: "EC2"}}
+	if err := nameRule.Evaluate(context.Background(), &EvaluationContext{Resource: res}); err != nil {
+		t.Errorf("Evaluate(Name) = %v, want nil", err)
+	}
+
+	for _, value := range []string{"", " , ", "Environment=", "=prod"} {
+		if _, err := factory.CreateRule("EC2", "requires_tag", value); err == nil {
+			t.Errorf("CreateRule(%q) succeeded, want an error", value)
+		}
+	}
+}
@@ -215,8 +215,10 @@ func (r *PolicyRepository) ListPoliciesByService(sourceService, destinationServi
 	destinationService = strings.ToLower(destinationService)
 
 	for _, def := range r.policies {
-		// Heuristic 1: Policy name should contain source service (e.g. "Lambda" in "AWSLambdaExecute")
-		if sourceService != "" && !strings.Contains(strings.ToLower(def.Name), sourceService) {
+		// Heuristic 1: Policy name should contain source service (e.g. "Lambda" in "AWSLambdaExecute"),
+		// or the policy is meant to be attached to the source (e.g. "lambda" in the related resources
+		// of AmazonDynamoDBReadOnlyAccess)
+		if sourceService != "" && !strings.Contains(strings.ToLower(def.Name), sourceService) && !attachableTo(def, sourceService) {
 			continue
 		}
 
@@ -230,3 +232,19 @@ func (r *PolicyRepository) ListPoliciesByService(sourceService, destinationServi
 
 	return result
 }
+
+// attachableTo reports whether a policy lists the service among its related resources without
+// being one of its own resource categories, i.e. it grants that service access to another one
+func attachableTo(def *PolicyDefinition, service string) bool {
+	for _, category := range def.ResourceCategories {
+		if strings.EqualFold(category, service) {
+			return false
+		}
+	}
+	for _, rr := range def.RelatedResources {
+		if strings.EqualFold(rr, service) {
+			return true
+		}
+	}
+	return false
+}
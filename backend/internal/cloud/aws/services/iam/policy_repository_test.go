@@ -117,3 +117,48 @@ func TestListPoliciesByService(t *testing.T) {
 		})
 	}
 }
+
+func TestListPoliciesByService_RelatedResources(t *testing.T) {
+	repo := NewPolicyRepository()
+
+	repo.policies = []*PolicyDefinition{
+		{
+			ARN:                "arn:aws:iam::aws:policy/AmazonDynamoDBReadOnlyAccess",
+			Name:               "AmazonDynamoDBReadOnlyAccess",
+			PolicyDocument:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["dynamodb:GetItem","dynamodb:Query"],"Resource":"*"}]}`,
+			ResourceCategories: []string{"dynamodb"},
+			RelatedResources:   []string{"dynamodb", "lambda"},
+		},
+		{
+			ARN:                "arn:aws:iam::aws:policy/AWSLambdaInvocation-DynamoDB",
+			Name:               "AWSLambdaInvocation-DynamoDB",
+			PolicyDocument:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["dynamodb:GetRecords"],"Resource":"*"}]}`,
+			ResourceCategories: []string{"dynamodb"},
+			RelatedResources:   []string{"dynamodb", "lambda"},
+		},
+		{
+			ARN:                "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess",
+			Name:               "AmazonS3ReadOnlyAccess",
+			PolicyDocument:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*","s3:List*"],"Resource":"*"}]}`,
+			ResourceCategories: []string{"s3"},
+			RelatedResources:   []string{"s3"},
+		},
+	}
+
+	results := repo.ListPoliciesByService("Lambda", "DynamoDB")
+	if len(results) != 2 {
+		t.Fatalf("ListPoliciesByService(Lambda, DynamoDB) returned %d policies, expected 2", len(results))
+	}
+
+	// The destination still has to appear in the policy document
+	results = repo.ListPoliciesByService("Lambda", "s3")
+	if len(results) != 0 {
+		t.Errorf("ListPoliciesByService(Lambda, s3) returned %d policies, expected 0", len(results))
+	}
+
+	// A policy's own category is not a source it is attached to
+	def := repo.policies[2]
+	if attachableTo(def, "s3") {
+		t.Errorf("Expected %s not to be attachable to s3", def.Name)
+	}
+}
@@ -0,0 +1,320 @@
+package architecture
+
+import (
+	"fmt"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/graph"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// defaultResourceGroupID is the ID of the resource group generated for resources drawn outside one
+const defaultResourceGroupID = "default-resource-group"
+
+// AzureArchitectureGenerator implements ArchitectureGenerator for Azure
+type AzureArchitectureGenerator struct{}
+
+// NewAzureArchitectureGenerator creates a new Azure architecture generator
+func NewAzureArchitectureGenerator() *AzureArchitectureGenerator {
+	return &AzureArchitectureGenerator{}
+}
+
+// Provider returns Azure as the cloud provider
+func (g *AzureArchitectureGenerator) Provider() resource.CloudProvider {
+	return resource.Azure
+}
+
+// Generate converts a diagram graph into a domain architecture for Azure
+func (g *AzureArchitectureGenerator) Generate(diagramGraph *graph.DiagramGraph) (*architecture.Architecture, error) {
+	arch := architecture.NewArchitecture()
+	arch.Provider = resource.Azure
+
+	// Extract region (Azure location) from region node
+	regionNode, hasRegion := diagramGraph.FindRegionNode()
+	if hasRegion {
+		if regionName, ok := extractRegionFromConfig(regionNode.Config); ok {
+			arch.Region = regionName
+		}
+	}
+
+	// Convert diagram variables to architecture variables
+	for _, v := range diagramGraph.Variables {
+		arch.Variables = append(arch.Variables, architecture.Variable{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+			Default:     v.Default,
+			Sensitive:   v.Sensitive,
+		})
+	}
+
+	// Convert diagram outputs to architecture outputs
+	for _, o := range diagramGraph.Outputs {
+		arch.Outputs = append(arch.Outputs, architecture.Output{
+			Name:        o.Name,
+			Value:       o.Value,
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+		})
+	}
+
+	// Build node ID to resource ID mapping (first pass)
+	// Include ALL nodes (including visual-only) for database persistence
+	nodeIDToResourceID := make(map[string]string)
+	for _, node := range diagramGraph.Nodes {
+		if node.IsRegion() {
+			continue
+		}
+		nodeIDToResourceID[node.ID] = node.ID
+	}
+
+	// Create domain resources (second pass)
+	for _, node := range diagramGraph.Nodes {
+		if node.IsRegion() {
+			continue
+		}
+
+		resourceID := nodeIDToResourceID[node.ID]
+
+		// Map IR resource type to domain resource type using Azure resource type mapper
+		// For visual-only nodes, use a generic "VisualIcon" type if mapping fails
+		domainResourceType, err := g.mapIRResourceTypeToDomain(node.ResourceType)
+		if err != nil {
+			if node.IsVisualOnly {
+				domainResourceType = &resource.ResourceType{
+					ID:         node.ResourceType,
+					Name:       node.ResourceType,
+					Category:   "Visual",
+					Kind:       "Icon",
+					IsRegional: false,
+					IsGlobal:   false,
+				}
+			} else {
+				return nil, fmt.Errorf("failed to map resource type for node %s: %w", node.ID, err)
+			}
+		}
+
+		name := extractNameFromConfig(node.Config, node.Label)
+
+		// Extract parent ID (if not region)
+		var parentID *string
+		if node.ParentID != nil {
+			if parentNode, exists := diagramGraph.GetNode(*node.ParentID); exists && !parentNode.IsRegion() {
+				if mappedParentID, ok := nodeIDToResourceID[*node.ParentID]; ok {
+					parentID = &mappedParentID
+				}
+			}
+		}
+
+		// Build dependencies list
+		dependencies := make([]string, 0)
+		for _, edge := range diagramGraph.GetDependencyEdges() {
+			if edge.Source == node.ID {
+				if depID, ok := nodeIDToResourceID[edge.Target]; ok {
+					dependencies = append(dependencies, depID)
+				}
+			}
+		}
+
+		metadata := make(map[string]interface{})
+		for k, v := range node.Config {
+			metadata[k] = v
+		}
+		if node.UI != nil {
+			metadata["ui"] = node.UI
+		}
+		metadata["isVisualOnly"] = node.IsVisualOnly
+
+		arch.Resources = append(arch.Resources, &resource.Resource{
+			ID:        resourceID,
+			Name:      name,
+			Type:      *domainResourceType,
+			Provider:  resource.Azure,
+			Region:    arch.Region,
+			ParentID:  parentID,
+			DependsOn: dependencies,
+			Metadata:  metadata,
+		})
+	}
+
+	// Build containment relationships (include visual-only nodes)
+	for _, node := range diagramGraph.Nodes {
+		if node.IsRegion() || node.ParentID == nil {
+			continue
+		}
+
+		parentNode, exists := diagramGraph.GetNode(*node.ParentID)
+		if exists && !parentNode.IsRegion() {
+			parentResourceID, parentOk := nodeIDToResourceID[*node.ParentID]
+			childResourceID, childOk := nodeIDToResourceID[node.ID]
+
+			if parentOk && childOk {
+				arch.Containments[parentResourceID] = append(arch.Containments[parentResourceID], childResourceID)
+			}
+		}
+	}
+
+	// Build dependency relationships
+	for _, res := range arch.Resources {
+		if len(res.DependsOn) > 0 {
+			arch.Dependencies[res.ID] = res.DependsOn
+		}
+	}
+
+	// Store edges as generic resources to preserve metadata (style, markers, etc.)
+	for _, edge := range diagramGraph.Edges {
+		if !edge.IsDependency() {
+			continue
+		}
+
+		edgeResourceID := edge.ID
+		if edgeResourceID == "" {
+			edgeResourceID = fmt.Sprintf("edge-%s-%s", edge.Source, edge.Target)
+		}
+
+		edgeName := edgeResourceID
+		if label, ok := edge.Config["label"].(string); ok && label != "" {
+			edgeName = label
+		}
+
+		edgeMetadata := make(map[string]interface{})
+		for k, v := range edge.Config {
+			edgeMetadata[k] = v
+		}
+		edgeMetadata["source"] = edge.Source
+		edgeMetadata["target"] = edge.Target
+		edgeMetadata["isVisualOnly"] = true // Edges are visual/structural, not cloud infrastructure
+
+		arch.Resources = append(arch.Resources, &resource.Resource{
+			ID:   edgeResourceID,
+			Name: edgeName,
+			Type: resource.ResourceType{
+				ID:         "GenericEdge",
+				Name:       "GenericEdge",
+				Category:   "Visual",
+				Kind:       "Connection",
+				IsRegional: false,
+				IsGlobal:   false,
+			},
+			Provider: resource.Azure,
+			Region:   arch.Region,
+			Metadata: edgeMetadata,
+		})
+	}
+
+	// Enrich architecture with default resources and fallbacks
+	g.enrichArchitecture(arch)
+
+	return arch, nil
+}
+
+// enrichArchitecture places every resource in a resource group. Each resource records the
+// ID of the group it was drawn in as "resourceGroupId"; resources drawn outside any group
+// are placed in a default resource group in the architecture's location.
+func (g *AzureArchitectureGenerator) enrichArchitecture(arch *architecture.Architecture) {
+	resMap := make(map[string]*resource.Resource)
+	for _, res := range arch.Resources {
+		resMap[res.ID] = res
+	}
+
+	// Helper to find the resource group of a resource (traversing up)
+	findResourceGroup := func(r *resource.Resource) *resource.Resource {
+		curr := r
+		for curr.ParentID != nil && *curr.ParentID != "" {
+			parent, ok := resMap[*curr.ParentID]
+			if !ok {
+				break
+			}
+			if parent.Type.Name == "ResourceGroup" {
+				return parent
+			}
+			curr = parent
+		}
+		return nil
+	}
+
+	var defaultRG *resource.Resource
+
+	for _, res := range arch.Resources {
+		if res.Type.Name == "ResourceGroup" {
+			continue
+		}
+		if isVisualOnly, ok := res.Metadata["isVisualOnly"].(bool); ok && isVisualOnly {
+			continue
+		}
+		if res.Metadata == nil {
+			res.Metadata = make(map[string]interface{})
+		}
+		if id, ok := res.Metadata["resourceGroupId"].(string); ok && id != "" {
+			continue
+		}
+
+		if rg := findResourceGroup(res); rg != nil {
+			res.Metadata["resourceGroupId"] = rg.ID
+			continue
+		}
+
+		// --- Default Resource Group Fallback ---
+		if defaultRG == nil {
+			defaultRG = &resource.Resource{
+				ID:   defaultResourceGroupID,
+				Name: "default-resource-group",
+				Type: resource.ResourceType{
+					ID:         "resource-group",
+					Name:       "ResourceGroup",
+					Category:   string(resource.CategoryNetworking),
+					Kind:       "Configuration",
+					IsRegional: true,
+				},
+				Provider: resource.Azure,
+				Region:   arch.Region,
+				Metadata: map[string]interface{}{
+					"name":     "default-resource-group",
+					"location": arch.Region,
+				},
+			}
+		}
+
+		res.Metadata["resourceGroupId"] = defaultRG.ID
+		// Sort the group first; the Terraform references already order the apply
+		arch.Dependencies[res.ID] = append(arch.Dependencies[res.ID], defaultRG.ID)
+
+		arch.Warnings = append(arch.Warnings, architecture.Warning{
+			Message:    fmt.Sprintf("Resource '%s' is not inside a resource group. Using default resource group '%s'.", res.Name, defaultRG.Name),
+			ResourceID: res.ID,
+		})
+	}
+
+	if defaultRG != nil {
+		arch.Resources = append(arch.Resources, defaultRG)
+	}
+}
+
+// mapIRResourceTypeToDomain maps IR resource type to domain ResourceType using Azure resource type mapper
+func (g *AzureArchitectureGenerator) mapIRResourceTypeToDomain(irType string) (*resource.ResourceType, error) {
+	mapper, ok := architecture.GetResourceTypeMapper(resource.Azure)
+	if !ok {
+		return nil, fmt.Errorf("Azure resource type mapper not registered")
+	}
+
+	return mapper.MapIRTypeToResourceType(irType)
+}
+
+// extractRegionFromConfig extracts the region name from a region node's config
+func extractRegionFromConfig(config map[string]interface{}) (string, bool) {
+	if name, ok := config["name"].(string); ok {
+		return name, true
+	}
+	return "", false
+}
+
+// extractNameFromConfig extracts the resource name from config, falling back to label
+func extractNameFromConfig(config map[string]interface{}, label string) string {
+	if name, ok := config["name"].(string); ok && name != "" {
+		return name
+	}
+	if label != "" {
+		return label
+	}
+	return "unnamed-resource"
+}
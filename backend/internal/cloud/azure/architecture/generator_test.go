@@ -0,0 +1,197 @@
+package architecture
+
+import (
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/graph"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestAzureArchitectureGenerator_Provider(t *testing.T) {
+	generator := NewAzureArchitectureGenerator()
+	if generator.Provider() != resource.Azure {
+		t.Errorf("Expected provider to be Azure, got %s", generator.Provider())
+	}
+
+	registered, ok := architecture.GetGenerator(resource.Azure)
+	if !ok || registered.Provider() != resource.Azure {
+		t.Error("Expected Azure generator to be registered")
+	}
+}
+
+func TestAzureArchitectureGenerator_Generate(t *testing.T) {
+	generator := NewAzureArchitectureGenerator()
+
+	diagramGraph := &graph.DiagramGraph{
+		Nodes: map[string]*graph.Node{
+			"region-1": {
+				ID:           "region-1",
+				Type:         "containerNode",
+				ResourceType: "region",
+				Config:       map[string]interface{}{"name": "westeurope"},
+			},
+			"rg-1": {
+				ID:           "rg-1",
+				Type:         "containerNode",
+				ResourceType: "resource-group",
+				Config:       map[string]interface{}{"name": "app-rg"},
+				ParentID:     stringPtr("region-1"),
+			},
+			"vnet-1": {
+				ID:           "vnet-1",
+				Type:         "containerNode",
+				ResourceType: "vnet",
+				Config:       map[string]interface{}{"name": "app-vnet", "addressSpace": []interface{}{"10.0.0.0/16"}},
+				ParentID:     stringPtr("rg-1"),
+			},
+			"subnet-1": {
+				ID:           "subnet-1",
+				Type:         "containerNode",
+				ResourceType: "subnet",
+				Config:       map[string]interface{}{"name": "web", "cidr": "10.0.1.0/24"},
+				ParentID:     stringPtr("vnet-1"),
+			},
+			"vm-1": {
+				ID:           "vm-1",
+				Type:         "resourceNode",
+				ResourceType: "virtual-machine",
+				Label:        "web-vm",
+				Config:       map[string]interface{}{},
+				ParentID:     stringPtr("subnet-1"),
+			},
+			"nsg-1": {
+				ID:           "nsg-1",
+				Type:         "resourceNode",
+				ResourceType: "nsg",
+				Config:       map[string]interface{}{"name": "web-nsg"},
+				ParentID:     stringPtr("rg-1"),
+			},
+		},
+		Edges: []*graph.Edge{
+			{ID: "edge-1", Source: "vm-1", Target: "nsg-1", Type: "dependency"},
+		},
+	}
+
+	arch, err := generator.Generate(diagramGraph)
+	if err != nil {
+		t.Fatalf("Failed to generate architecture: %v", err)
+	}
+
+	if arch.Provider != resource.Azure {
+		t.Errorf("Expected provider to be Azure, got %s", arch.Provider)
+	}
+	if arch.Region != "westeurope" {
+		t.Errorf("Expected region to be 'westeurope', got '%s'", arch.Region)
+	}
+
+	// 5 resources plus the dependency edge
+	if len(arch.Resources) != 6 {
+		t.Fatalf("Expected 6 resources, got %d", len(arch.Resources))
+	}
+	if len(arch.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", arch.Warnings)
+	}
+
+	resources := make(map[string]*resource.Resource)
+	for _, res := range arch.Resources {
+		resources[res.ID] = res
+	}
+
+	vm := resources["vm-1"]
+	if vm.Type.Name != "VirtualMachine" || vm.Name != "web-vm" {
+		t.Errorf("Unexpected VM resource: %s %s", vm.Type.Name, vm.Name)
+	}
+	if vm.Provider != resource.Azure {
+		t.Errorf("Expected VM provider to be Azure, got %s", vm.Provider)
+	}
+	if len(vm.DependsOn) != 1 || vm.DependsOn[0] != "nsg-1" {
+		t.Errorf("Expected VM to depend on nsg-1, got %v", vm.DependsOn)
+	}
+
+	// Every resource records the resource group it was drawn in, however deeply nested
+	for _, id := range []string{"vnet-1", "subnet-1", "vm-1", "nsg-1"} {
+		if rg := resources[id].Metadata["resourceGroupId"]; rg != "rg-1" {
+			t.Errorf("Expected %s to be in resource group rg-1, got %v", id, rg)
+		}
+	}
+
+	if children := arch.Containments["rg-1"]; len(children) != 2 {
+		t.Errorf("Expected resource group to contain 2 resources, got %v", children)
+	}
+}
+
+func TestAzureArchitectureGenerator_DefaultResourceGroup(t *testing.T) {
+	generator := NewAzureArchitectureGenerator()
+
+	diagramGraph := &graph.DiagramGraph{
+		Nodes: map[string]*graph.Node{
+			"region-1": {
+				ID:           "region-1",
+				Type:         "containerNode",
+				ResourceType: "region",
+				Config:       map[string]interface{}{"name": "eastus"},
+			},
+			"st-1": {
+				ID:           "st-1",
+				Type:         "resourceNode",
+				ResourceType: "storage-account",
+				Config:       map[string]interface{}{"name": "assets"},
+				ParentID:     stringPtr("region-1"),
+			},
+			"sql-1": {
+				ID:           "sql-1",
+				Type:         "resourceNode",
+				ResourceType: "azure-sql",
+				Config:       map[string]interface{}{"name": "orders"},
+			},
+		},
+	}
+
+	arch, err := generator.Generate(diagramGraph)
+	if err != nil {
+		t.Fatalf("Failed to generate architecture: %v", err)
+	}
+
+	if len(arch.Resources) != 3 {
+		t.Fatalf("Expected 2 resources and the default resource group, got %d", len(arch.Resources))
+	}
+	if len(arch.Warnings) != 2 {
+		t.Errorf("Expected a warning per resource outside a resource group, got %d", len(arch.Warnings))
+	}
+
+	rg := arch.Resources[2]
+	if rg.ID != defaultResourceGroupID || rg.Type.Name != "ResourceGroup" {
+		t.Fatalf("Expected the default resource group last, got %s (%s)", rg.ID, rg.Type.Name)
+	}
+	if rg.Metadata["location"] != "eastus" {
+		t.Errorf("Expected default resource group in eastus, got %v", rg.Metadata["location"])
+	}
+
+	for _, res := range arch.Resources[:2] {
+		if res.Metadata["resourceGroupId"] != defaultResourceGroupID {
+			t.Errorf("Expected %s in the default resource group, got %v", res.ID, res.Metadata["resourceGroupId"])
+		}
+		if deps := arch.Dependencies[res.ID]; len(deps) != 1 || deps[0] != defaultResourceGroupID {
+			t.Errorf("Expected %s to depend on the default resource group, got %v", res.ID, deps)
+		}
+	}
+}
+
+func TestAzureArchitectureGenerator_UnknownResourceType(t *testing.T) {
+	generator := NewAzureArchitectureGenerator()
+
+	diagramGraph := &graph.DiagramGraph{
+		Nodes: map[string]*graph.Node{
+			"vpc-1": {ID: "vpc-1", Type: "containerNode", ResourceType: "vpc", Config: map[string]interface{}{}},
+		},
+	}
+
+	if _, err := generator.Generate(diagramGraph); err == nil {
+		t.Error("Expected an error for an AWS resource type in an Azure diagram")
+	}
+}
@@ -0,0 +1,17 @@
+package architecture
+
+import (
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/inventory" // Register Azure IR type mapper
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func init() {
+	// Register Azure architecture generator
+	generator := NewAzureArchitectureGenerator()
+	architecture.RegisterGenerator(generator)
+
+	// Register Azure resource type mapper
+	mapper := NewAzureResourceTypeMapper()
+	architecture.RegisterResourceTypeMapper(resource.Azure, mapper)
+}
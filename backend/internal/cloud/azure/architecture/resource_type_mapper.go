@@ -0,0 +1,113 @@
+package architecture
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// AzureResourceTypeMapper implements ResourceTypeMapper for Azure
+type AzureResourceTypeMapper struct{}
+
+// NewAzureResourceTypeMapper creates a new Azure resource type mapper
+func NewAzureResourceTypeMapper() *AzureResourceTypeMapper {
+	return &AzureResourceTypeMapper{}
+}
+
+// MapIRTypeToResourceType maps an IR type (kebab-case) to ResourceType for Azure
+func (m *AzureResourceTypeMapper) MapIRTypeToResourceType(irType string) (*resource.ResourceType, error) {
+	if mapper, ok := architecture.GetIRTypeMapper(resource.Azure); ok {
+		if resourceName, found := mapper.GetResourceNameByIRType(irType); found {
+			return m.MapResourceNameToResourceType(resourceName)
+		}
+		if resourceName, found := mapper.GetResourceNameByIRType(strings.ToLower(irType)); found {
+			return m.MapResourceNameToResourceType(resourceName)
+		}
+	}
+
+	// The FE may send the resource name (e.g. "VirtualMachine") directly as the type
+	if rt, err := m.MapResourceNameToResourceType(irType); err == nil {
+		return rt, nil
+	}
+
+	return nil, fmt.Errorf("unknown IR type for Azure: %s", irType)
+}
+
+// MapResourceNameToResourceType maps a resource name (PascalCase) to ResourceType for Azure
+func (m *AzureResourceTypeMapper) MapResourceNameToResourceType(resourceName string) (*resource.ResourceType, error) {
+	resourceTypeMap := map[string]resource.ResourceType{
+		"ResourceGroup": {
+			ID:         "resource-group",
+			Name:       "ResourceGroup",
+			Category:   string(resource.CategoryNetworking),
+			Kind:       "Configuration",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"VirtualNetwork": {
+			ID:         "virtual-network",
+			Name:       "VirtualNetwork",
+			Category:   string(resource.CategoryNetworking),
+			Kind:       "Network",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"Subnet": {
+			ID:         "subnet",
+			Name:       "Subnet",
+			Category:   string(resource.CategoryNetworking),
+			Kind:       "Network",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"NetworkSecurityGroup": {
+			ID:         "network-security-group",
+			Name:       "NetworkSecurityGroup",
+			Category:   string(resource.CategoryNetworking),
+			Kind:       "Network",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"VirtualMachine": {
+			ID:         "virtual-machine",
+			Name:       "VirtualMachine",
+			Category:   string(resource.CategoryCompute),
+			Kind:       "VirtualMachine",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"LoadBalancer": {
+			ID:         "load-balancer",
+			Name:       "LoadBalancer",
+			Category:   string(resource.CategoryCompute),
+			Kind:       "LoadBalancer",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"StorageAccount": {
+			ID:         "storage-account",
+			Name:       "StorageAccount",
+			Category:   string(resource.CategoryStorage),
+			Kind:       "Storage",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+		"SQLDatabase": {
+			ID:         "sql-database",
+			Name:       "SQLDatabase",
+			Category:   string(resource.CategoryDatabase),
+			Kind:       "Database",
+			IsRegional: true,
+			IsGlobal:   false,
+		},
+	}
+
+	rt, exists := resourceTypeMap[resourceName]
+	if !exists {
+		return nil, fmt.Errorf("unknown Azure resource name: %s", resourceName)
+	}
+
+	return &rt, nil
+}
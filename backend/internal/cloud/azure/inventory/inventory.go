@@ -0,0 +1,98 @@
+package inventory
+
+import (
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// ResourceClassification represents how a resource is classified
+type ResourceClassification struct {
+	Category     string   // "Networking", "Compute", etc.
+	ResourceName string   // "VirtualNetwork", "Subnet", "VirtualMachine", etc.
+	Aliases      []string // ["vnet", "virtual_network"] for IR mapping
+	IRType       string   // IR resource type (kebab-case) for mapping from diagram
+}
+
+// FunctionRegistry holds function references for dynamic dispatch
+type FunctionRegistry struct {
+	// TerraformMapper maps a domain resource to Terraform blocks
+	TerraformMapper func(*resource.Resource) ([]tfmapper.TerraformBlock, error)
+}
+
+// Inventory holds all resource classifications and function mappings for Azure
+type Inventory struct {
+	// Classifications maps resource name to its classification
+	Classifications map[string]ResourceClassification
+
+	// Functions maps resource name to its function registry
+	Functions map[string]FunctionRegistry
+
+	// ByCategory maps category to list of resource names
+	ByCategory map[string][]string
+
+	// ByIRType maps IR type (kebab-case) to resource name for diagram parsing
+	ByIRType map[string]string
+}
+
+// NewInventory creates a new empty inventory
+func NewInventory() *Inventory {
+	return &Inventory{
+		Classifications: make(map[string]ResourceClassification),
+		Functions:       make(map[string]FunctionRegistry),
+		ByCategory:      make(map[string][]string),
+		ByIRType:        make(map[string]string),
+	}
+}
+
+// RegisterResource registers a resource in the inventory
+func (inv *Inventory) RegisterResource(classification ResourceClassification, functions FunctionRegistry) {
+	resourceName := classification.ResourceName
+
+	inv.Classifications[resourceName] = classification
+	inv.Functions[resourceName] = functions
+	inv.ByCategory[classification.Category] = append(inv.ByCategory[classification.Category], resourceName)
+
+	if classification.IRType != "" {
+		inv.ByIRType[classification.IRType] = resourceName
+	}
+	for _, alias := range classification.Aliases {
+		inv.ByIRType[alias] = resourceName
+	}
+}
+
+// GetResourceClassification retrieves classification for a resource
+func (inv *Inventory) GetResourceClassification(resourceName string) (ResourceClassification, bool) {
+	classification, ok := inv.Classifications[resourceName]
+	return classification, ok
+}
+
+// GetFunctions retrieves function registry for a resource
+func (inv *Inventory) GetFunctions(resourceName string) (FunctionRegistry, bool) {
+	functions, ok := inv.Functions[resourceName]
+	return functions, ok
+}
+
+// GetResourcesByCategory returns all resource names in a category
+func (inv *Inventory) GetResourcesByCategory(category string) []string {
+	return inv.ByCategory[category]
+}
+
+// GetResourceNameByIRType maps IR type to resource name
+func (inv *Inventory) GetResourceNameByIRType(irType string) (string, bool) {
+	resourceName, ok := inv.ByIRType[irType]
+	return resourceName, ok
+}
+
+// SupportsResource checks if a resource type is supported
+func (inv *Inventory) SupportsResource(resourceName string) bool {
+	_, ok := inv.Classifications[resourceName]
+	return ok
+}
+
+// SetTerraformMapper sets the Terraform mapper function for a resource
+func (inv *Inventory) SetTerraformMapper(resourceName string, mapper func(*resource.Resource) ([]tfmapper.TerraformBlock, error)) {
+	if functions, ok := inv.Functions[resourceName]; ok {
+		functions.TerraformMapper = mapper
+		inv.Functions[resourceName] = functions
+	}
+}
@@ -0,0 +1,38 @@
+package inventory
+
+import (
+	architecture "github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+var (
+	// DefaultAzureInventory is the singleton Azure inventory instance
+	DefaultAzureInventory *Inventory
+)
+
+func init() {
+	DefaultAzureInventory = NewInventory()
+
+	// Register all resources (without function mappings - those are set by the mapper)
+	for _, classification := range GetAzureResourceClassifications() {
+		DefaultAzureInventory.RegisterResource(classification, FunctionRegistry{})
+	}
+
+	// Register Azure inventory as IR type mapper for the domain architecture layer
+	architecture.RegisterIRTypeMapper(resource.Azure, &azureInventoryMapperAdapter{inventory: DefaultAzureInventory})
+}
+
+// azureInventoryMapperAdapter adapts the Inventory to the IRTypeMapper interface
+type azureInventoryMapperAdapter struct {
+	inventory *Inventory
+}
+
+// GetResourceNameByIRType implements IRTypeMapper interface
+func (a *azureInventoryMapperAdapter) GetResourceNameByIRType(irType string) (string, bool) {
+	return a.inventory.GetResourceNameByIRType(irType)
+}
+
+// GetDefaultInventory returns the default Azure inventory
+func GetDefaultInventory() *Inventory {
+	return DefaultAzureInventory
+}
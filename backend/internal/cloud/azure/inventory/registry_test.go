@@ -0,0 +1,77 @@
+package inventory
+
+import (
+	"testing"
+
+	architecture "github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestGetDefaultInventory(t *testing.T) {
+	inv := GetDefaultInventory()
+
+	if inv == nil {
+		t.Fatal("GetDefaultInventory() returned nil")
+	}
+
+	expectedResources := []string{
+		"ResourceGroup", "VirtualNetwork", "Subnet", "NetworkSecurityGroup",
+		"VirtualMachine", "LoadBalancer", "StorageAccount", "SQLDatabase",
+	}
+	for _, resName := range expectedResources {
+		if !inv.SupportsResource(resName) {
+			t.Errorf("Expected resource %s not found in default inventory", resName)
+		}
+	}
+
+	if got := inv.GetResourcesByCategory(resource.CategoryNetworking); len(got) != 4 {
+		t.Errorf("Expected 4 networking resources, got %v", got)
+	}
+}
+
+func TestDefaultInventory_IRTypeMapping(t *testing.T) {
+	inv := GetDefaultInventory()
+
+	testCases := []struct {
+		irType      string
+		expectedRes string
+	}{
+		{"resource-group", "ResourceGroup"},
+		{"vnet", "VirtualNetwork"},
+		{"subnet", "Subnet"},
+		{"nsg", "NetworkSecurityGroup"},
+		{"vm", "VirtualMachine"},
+		{"lb", "LoadBalancer"},
+		{"storage-account", "StorageAccount"},
+		{"azure-sql", "SQLDatabase"},
+	}
+
+	for _, tc := range testCases {
+		resourceName, ok := inv.GetResourceNameByIRType(tc.irType)
+		if !ok {
+			t.Errorf("Failed to map IR type %s in default inventory", tc.irType)
+			continue
+		}
+
+		if resourceName != tc.expectedRes {
+			t.Errorf("Expected %s for IR type %s, got %s", tc.expectedRes, tc.irType, resourceName)
+		}
+	}
+
+	// AWS-only IR types are not part of the Azure inventory
+	if _, ok := inv.GetResourceNameByIRType("vpc"); ok {
+		t.Error("Expected IR type vpc to be unknown for Azure")
+	}
+}
+
+func TestRegisterAzureInventoryAsMapper(t *testing.T) {
+	mapper, ok := architecture.GetIRTypeMapper(resource.Azure)
+	if !ok {
+		t.Fatal("Azure IR type mapper should be registered")
+	}
+
+	resourceName, ok := mapper.GetResourceNameByIRType("virtual-network")
+	if !ok || resourceName != "VirtualNetwork" {
+		t.Errorf("Expected VirtualNetwork, got %q (found=%v)", resourceName, ok)
+	}
+}
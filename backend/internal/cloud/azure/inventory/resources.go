@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// GetAzureResourceClassifications returns all Azure resource classifications
+func GetAzureResourceClassifications() []ResourceClassification {
+	return []ResourceClassification{
+		// Networking Resources
+		{
+			Category:     resource.CategoryNetworking,
+			ResourceName: "ResourceGroup",
+			IRType:       "resource-group",
+			Aliases:      []string{"resource-group", "resource_group", "rg"},
+		},
+		{
+			Category:     resource.CategoryNetworking,
+			ResourceName: "VirtualNetwork",
+			IRType:       "virtual-network",
+			Aliases:      []string{"virtual-network", "virtual_network", "vnet"},
+		},
+		{
+			Category:     resource.CategoryNetworking,
+			ResourceName: "Subnet",
+			IRType:       "subnet",
+			Aliases:      []string{"subnet"},
+		},
+		{
+			Category:     resource.CategoryNetworking,
+			ResourceName: "NetworkSecurityGroup",
+			IRType:       "network-security-group",
+			Aliases:      []string{"network-security-group", "network_security_group", "nsg"},
+		},
+		// Compute Resources
+		{
+			Category:     resource.CategoryCompute,
+			ResourceName: "VirtualMachine",
+			IRType:       "virtual-machine",
+			Aliases:      []string{"virtual-machine", "virtual_machine", "vm"},
+		},
+		{
+			Category:     resource.CategoryCompute,
+			ResourceName: "LoadBalancer",
+			IRType:       "load-balancer",
+			Aliases:      []string{"load-balancer", "load_balancer", "lb"},
+		},
+		// Storage Resources
+		{
+			Category:     resource.CategoryStorage,
+			ResourceName: "StorageAccount",
+			IRType:       "storage-account",
+			Aliases:      []string{"storage-account", "storage_account"},
+		},
+		// Database Resources
+		{
+			Category:     resource.CategoryDatabase,
+			ResourceName: "SQLDatabase",
+			IRType:       "sql-database",
+			Aliases:      []string{"sql-database", "sql_database", "azure-sql"},
+		},
+	}
+}
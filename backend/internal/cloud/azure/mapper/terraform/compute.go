@@ -0,0 +1,261 @@
+package terraform
+
+import (
+	"fmt"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// vmIPConfigurationName names the single IP configuration of a virtual machine's NIC; load
+// balancer backend pool associations refer to it
+const vmIPConfigurationName = "internal"
+
+// lbFrontendName names the frontend IP configuration of a load balancer
+const lbFrontendName = "frontend"
+
+// Default virtual machine image: Ubuntu Server 22.04 LTS
+var defaultVMImage = map[string]string{
+	"publisher": "Canonical",
+	"offer":     "0001-com-ubuntu-server-jammy",
+	"sku":       "22_04-lts",
+	"version":   "latest",
+}
+
+// mapVirtualMachine maps a Linux virtual machine to its network interface, an optional
+// public IP and the azurerm_linux_virtual_machine itself.
+func (m *AzureMapper) mapVirtualMachine(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	if res.ParentID == nil || *res.ParentID == "" {
+		return nil, fmt.Errorf("virtual machine requires parent subnet (parentID missing)")
+	}
+	rgAttrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+
+	name := tfBlockName(res)
+	blocks := []tfmapper.TerraformBlock{}
+
+	ipConfig := map[string]tfmapper.TerraformValue{
+		"name":                          tfString(vmIPConfigurationName),
+		"subnet_id":                     tfExpr(tfmapper.Reference{ResourceType: "azurerm_subnet", ResourceName: resolveRef(*res.ParentID, res.Metadata), Attribute: "id"}.Expr()),
+		"private_ip_address_allocation": tfString("Dynamic"),
+	}
+	if public, _ := getBool(res.Metadata, "publicIp"); public {
+		blocks = append(blocks, publicIPBlock(res, rgAttrs, name, "Standard"))
+		ipConfig["public_ip_address_id"] = tfExpr(tfmapper.Reference{ResourceType: "azurerm_public_ip", ResourceName: name, Attribute: "id"}.Expr())
+	}
+
+	nicAttrs := copyAttrs(rgAttrs)
+	nicAttrs["name"] = tfString(res.Name + "-nic")
+	blocks = append(blocks, tfmapper.TerraformBlock{
+		Kind:         "resource",
+		Labels:       []string{"azurerm_network_interface", name},
+		Attributes:   nicAttrs,
+		NestedBlocks: map[string][]tfmapper.NestedBlock{"ip_configuration": {{Attributes: ipConfig}}},
+	})
+	nicID := tfExpr(tfmapper.Reference{ResourceType: "azurerm_network_interface", ResourceName: name, Attribute: "id"}.Expr())
+
+	if nsg := networkSecurityGroupRef(res); nsg != "" {
+		blocks = append(blocks, tfmapper.TerraformBlock{
+			Kind:   "resource",
+			Labels: []string{"azurerm_network_interface_security_group_association", name},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"network_interface_id":      nicID,
+				"network_security_group_id": tfExpr(tfmapper.Reference{ResourceType: "azurerm_network_security_group", ResourceName: nsg, Attribute: "id"}.Expr()),
+			},
+		})
+	}
+
+	adminUsername := getStringOr(res.Metadata, "adminUsername", "azureuser")
+	attrs := copyAttrs(rgAttrs)
+	attrs["name"] = tfString(res.Name)
+	attrs["size"] = tfStringOrVar(res.Metadata, "size", getStringOr(res.Metadata, "size", "Standard_B1s"))
+	attrs["admin_username"] = tfStringOrVar(res.Metadata, "adminUsername", adminUsername)
+	attrs["network_interface_ids"] = tfList([]tfmapper.TerraformValue{nicID})
+
+	nested := map[string][]tfmapper.NestedBlock{
+		"os_disk": {{
+			Attributes: map[string]tfmapper.TerraformValue{
+				"caching":              tfString("ReadWrite"),
+				"storage_account_type": tfString(getStringOr(res.Metadata, "osDiskType", "Standard_LRS")),
+			},
+		}},
+	}
+
+	// Password authentication when a password is configured, SSH keys otherwise
+	if password, ok := getString(res.Metadata, "adminPassword"); ok && password != "" {
+		attrs["admin_password"] = tfStringOrVar(res.Metadata, "adminPassword", password)
+		attrs["disable_password_authentication"] = tfBool(false)
+	} else {
+		publicKey := tfExpr(tfmapper.TerraformExpr(`file("~/.ssh/id_rsa.pub")`))
+		if key, ok := getString(res.Metadata, "sshPublicKey"); ok && key != "" {
+			publicKey = tfStringOrVar(res.Metadata, "sshPublicKey", key)
+		}
+		nested["admin_ssh_key"] = []tfmapper.NestedBlock{{
+			Attributes: map[string]tfmapper.TerraformValue{
+				"username":   tfString(adminUsername),
+				"public_key": publicKey,
+			},
+		}}
+	}
+
+	image := map[string]tfmapper.TerraformValue{}
+	configured, _ := res.Metadata["image"].(map[string]interface{})
+	for _, key := range []string{"publisher", "offer", "sku", "version"} {
+		image[key] = tfString(getStringOr(configured, key, defaultVMImage[key]))
+	}
+	nested["source_image_reference"] = []tfmapper.NestedBlock{{Attributes: image}}
+
+	addDependsOn(attrs, res)
+
+	blocks = append(blocks, tfmapper.TerraformBlock{
+		Kind:         "resource",
+		Labels:       []string{"azurerm_linux_virtual_machine", name},
+		Attributes:   attrs,
+		NestedBlocks: nested,
+	})
+
+	return blocks, nil
+}
+
+// mapLoadBalancer maps a Standard load balancer with a public (or, when internal, private)
+// frontend, a backend pool, and a probe and rule per configured rule. Virtual machines the
+// load balancer depends on are added to its backend pool.
+func (m *AzureMapper) mapLoadBalancer(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	rgAttrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+
+	name := tfBlockName(res)
+	sku := getStringOr(res.Metadata, "sku", "Standard")
+	blocks := []tfmapper.TerraformBlock{}
+
+	frontend := map[string]tfmapper.TerraformValue{
+		"name": tfString(lbFrontendName),
+	}
+	if internal, _ := getBool(res.Metadata, "internal"); internal {
+		subnetID := getStringOr(res.Metadata, "subnetId", "")
+		if subnetID == "" && res.ParentID != nil {
+			subnetID = *res.ParentID
+		}
+		if subnetID == "" {
+			return nil, fmt.Errorf("internal load balancer requires a subnet (subnetId or parent subnet)")
+		}
+		frontend["subnet_id"] = tfExpr(tfmapper.Reference{ResourceType: "azurerm_subnet", ResourceName: resolveRef(subnetID, res.Metadata), Attribute: "id"}.Expr())
+		frontend["private_ip_address_allocation"] = tfString("Dynamic")
+	} else {
+		blocks = append(blocks, publicIPBlock(res, rgAttrs, name, sku))
+		frontend["public_ip_address_id"] = tfExpr(tfmapper.Reference{ResourceType: "azurerm_public_ip", ResourceName: name, Attribute: "id"}.Expr())
+	}
+
+	attrs := copyAttrs(rgAttrs)
+	attrs["name"] = tfString(res.Name)
+	attrs["sku"] = tfString(sku)
+	addDependsOn(attrs, res)
+
+	blocks = append(blocks, tfmapper.TerraformBlock{
+		Kind:         "resource",
+		Labels:       []string{"azurerm_lb", name},
+		Attributes:   attrs,
+		NestedBlocks: map[string][]tfmapper.NestedBlock{"frontend_ip_configuration": {{Attributes: frontend}}},
+	})
+
+	lbID := tfExpr(tfmapper.Reference{ResourceType: "azurerm_lb", ResourceName: name, Attribute: "id"}.Expr())
+	poolID := tfExpr(tfmapper.Reference{ResourceType: "azurerm_lb_backend_address_pool", ResourceName: name, Attribute: "id"}.Expr())
+	blocks = append(blocks, tfmapper.TerraformBlock{
+		Kind:   "resource",
+		Labels: []string{"azurerm_lb_backend_address_pool", name},
+		Attributes: map[string]tfmapper.TerraformValue{
+			"name":            tfString("backend"),
+			"loadbalancer_id": lbID,
+		},
+	})
+
+	rules, _ := getArray(res.Metadata, "rules")
+	for i, raw := range rules {
+		rule, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		ruleName := getStringOr(rule, "name", fmt.Sprintf("rule-%d", i+1))
+		frontendPort, ok := getInt(rule, "frontendPort")
+		if !ok {
+			return nil, fmt.Errorf("load balancer rule %q: missing required config %q", ruleName, "frontendPort")
+		}
+		backendPort, ok := getInt(rule, "backendPort")
+		if !ok {
+			backendPort = frontendPort
+		}
+		probePort, ok := getInt(rule, "probePort")
+		if !ok {
+			probePort = backendPort
+		}
+
+		ruleBlockName := tfName(name + "_" + ruleName)
+		blocks = append(blocks,
+			tfmapper.TerraformBlock{
+				Kind:   "resource",
+				Labels: []string{"azurerm_lb_probe", ruleBlockName},
+				Attributes: map[string]tfmapper.TerraformValue{
+					"name":            tfString(ruleName + "-probe"),
+					"loadbalancer_id": lbID,
+					"port":            tfNumber(float64(probePort)),
+				},
+			},
+			tfmapper.TerraformBlock{
+				Kind:   "resource",
+				Labels: []string{"azurerm_lb_rule", ruleBlockName},
+				Attributes: map[string]tfmapper.TerraformValue{
+					"name":                           tfString(ruleName),
+					"loadbalancer_id":                lbID,
+					"protocol":                       tfString(getStringOr(rule, "protocol", "Tcp")),
+					"frontend_port":                  tfNumber(float64(frontendPort)),
+					"backend_port":                   tfNumber(float64(backendPort)),
+					"frontend_ip_configuration_name": tfString(lbFrontendName),
+					"backend_address_pool_ids":       tfList([]tfmapper.TerraformValue{poolID}),
+					"probe_id":                       tfExpr(tfmapper.Reference{ResourceType: "azurerm_lb_probe", ResourceName: ruleBlockName, Attribute: "id"}.Expr()),
+				},
+			},
+		)
+	}
+
+	for _, vm := range dependencyRefs(res, "VirtualMachine") {
+		blocks = append(blocks, tfmapper.TerraformBlock{
+			Kind:   "resource",
+			Labels: []string{"azurerm_network_interface_backend_address_pool_association", tfName(name + "_" + vm)},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"network_interface_id":    tfExpr(tfmapper.Reference{ResourceType: "azurerm_network_interface", ResourceName: vm, Attribute: "id"}.Expr()),
+				"ip_configuration_name":   tfString(vmIPConfigurationName),
+				"backend_address_pool_id": poolID,
+			},
+		})
+	}
+
+	return blocks, nil
+}
+
+// publicIPBlock builds a static public IP named after the resource it is attached to
+func publicIPBlock(res *resource.Resource, rgAttrs map[string]tfmapper.TerraformValue, name, sku string) tfmapper.TerraformBlock {
+	attrs := copyAttrs(rgAttrs)
+	attrs["name"] = tfString(res.Name + "-pip")
+	attrs["allocation_method"] = tfString("Static")
+	attrs["sku"] = tfString(sku)
+
+	return tfmapper.TerraformBlock{
+		Kind:       "resource",
+		Labels:     []string{"azurerm_public_ip", name},
+		Attributes: attrs,
+	}
+}
+
+// copyAttrs returns a shallow copy of the shared attributes so each block gets its own map
+func copyAttrs(attrs map[string]tfmapper.TerraformValue) map[string]tfmapper.TerraformValue {
+	out := make(map[string]tfmapper.TerraformValue, len(attrs))
+	for k, v := range attrs {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// mapSQLDatabase maps an Azure SQL database to its logical server and the database itself
+func (m *AzureMapper) mapSQLDatabase(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	password, ok := getString(res.Metadata, "administratorPassword")
+	if (!ok || password == "") && getVarRef(res.Metadata, "administratorPassword") == "" {
+		return nil, fmt.Errorf("missing required config %q", "administratorPassword")
+	}
+
+	serverName := sanitizeSQLServerName(getStringOr(res.Metadata, "serverName", res.Name+"-server"))
+	if serverName == "" {
+		return nil, fmt.Errorf("sql server name must contain lowercase letters, numbers or hyphens")
+	}
+
+	serverAttrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+	serverAttrs["name"] = tfString(serverName)
+	serverAttrs["version"] = tfString(getStringOr(res.Metadata, "version", "12.0"))
+	serverAttrs["administrator_login"] = tfStringOrVar(res.Metadata, "administratorLogin", getStringOr(res.Metadata, "administratorLogin", "sqladmin"))
+	serverAttrs["administrator_login_password"] = tfStringOrVar(res.Metadata, "administratorPassword", password)
+	serverAttrs["minimum_tls_version"] = tfString("1.2")
+
+	name := tfBlockName(res)
+	serverID := tfExpr(tfmapper.Reference{ResourceType: "azurerm_mssql_server", ResourceName: name, Attribute: "id"}.Expr())
+
+	dbAttrs := map[string]tfmapper.TerraformValue{
+		"name":      tfString(getStringOr(res.Metadata, "databaseName", res.Name)),
+		"server_id": serverID,
+		"sku_name":  tfString(getStringOr(res.Metadata, "skuName", "Basic")),
+		"tags":      tfTags(res.Name),
+	}
+	if size, ok := getInt(res.Metadata, "maxSizeGb"); ok && size > 0 {
+		dbAttrs["max_size_gb"] = tfNumber(float64(size))
+	}
+	addDependsOn(dbAttrs, res)
+
+	blocks := []tfmapper.TerraformBlock{
+		{
+			Kind:       "resource",
+			Labels:     []string{"azurerm_mssql_server", name},
+			Attributes: serverAttrs,
+		},
+		{
+			Kind:       "resource",
+			Labels:     []string{"azurerm_mssql_database", name},
+			Attributes: dbAttrs,
+		},
+	}
+
+	// The 0.0.0.0 rule is Azure's convention for allowing other Azure services
+	if allow, _ := getBool(res.Metadata, "allowAzureServices"); allow {
+		blocks = append(blocks, tfmapper.TerraformBlock{
+			Kind:   "resource",
+			Labels: []string{"azurerm_mssql_firewall_rule", name},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"name":             tfString("AllowAzureServices"),
+				"server_id":        serverID,
+				"start_ip_address": tfString("0.0.0.0"),
+				"end_ip_address":   tfString("0.0.0.0"),
+			},
+		})
+	}
+
+	return blocks, nil
+}
+
+// sanitizeSQLServerName lowercases a name and keeps the letters, numbers and hyphens a
+// server name allows, truncated to 63 characters
+func sanitizeSQLServerName(name string) string {
+	s := strings.ToLower(strings.TrimSpace(name))
+	s = strings.NewReplacer("_", "-", " ", "-").Replace(s)
+	allowed := make([]rune, 0, len(s))
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			allowed = append(allowed, r)
+		}
+	}
+	if len(allowed) > 63 {
+		allowed = allowed[:63]
+	}
+	return strings.Trim(string(allowed), "-")
+}
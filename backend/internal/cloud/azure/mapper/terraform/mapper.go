@@ -0,0 +1,372 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/inventory"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// AzureMapper maps domain resources (Azure provider) into azurerm Terraform blocks.
+//
+// Every resource except the resource group itself is deployed into the resource group
+// recorded as "resourceGroupId" by the architecture generator, and takes its location
+// from that group.
+type AzureMapper struct{}
+
+func New() *AzureMapper {
+	mapper := &AzureMapper{}
+	inv := inventory.GetDefaultInventory()
+
+	inv.SetTerraformMapper("ResourceGroup", mapper.mapResourceGroup)
+	inv.SetTerraformMapper("VirtualNetwork", mapper.mapVirtualNetwork)
+	inv.SetTerraformMapper("Subnet", mapper.mapSubnet)
+	inv.SetTerraformMapper("NetworkSecurityGroup", mapper.mapNetworkSecurityGroup)
+	inv.SetTerraformMapper("VirtualMachine", mapper.mapVirtualMachine)
+	inv.SetTerraformMapper("LoadBalancer", mapper.mapLoadBalancer)
+	inv.SetTerraformMapper("StorageAccount", mapper.mapStorageAccount)
+	inv.SetTerraformMapper("SQLDatabase", mapper.mapSQLDatabase)
+
+	return mapper
+}
+
+func (m *AzureMapper) Provider() string { return string(resource.Azure) }
+
+// RequiredProvider pins the hashicorp/azurerm provider major version the mappers target.
+func (m *AzureMapper) RequiredProvider() tfmapper.ProviderRequirement {
+	return tfmapper.ProviderRequirement{Source: "hashicorp/azurerm", Version: "~> 3.0", LocalName: "azurerm"}
+}
+
+// ProviderBlock writes provider "azurerm" { features {} }. azurerm has no provider-level
+// region; the location is set on each resource group.
+func (m *AzureMapper) ProviderBlock(region string) tfmapper.TerraformBlock {
+	return tfmapper.TerraformBlock{
+		Kind:         "provider",
+		Labels:       []string{"azurerm"},
+		Attributes:   map[string]tfmapper.TerraformValue{},
+		NestedBlocks: map[string][]tfmapper.NestedBlock{"features": {{}}},
+	}
+}
+
+// ResourceCategory looks up the inventory classification of a resource type.
+func (m *AzureMapper) ResourceCategory(resourceType string) (string, bool) {
+	classification, ok := inventory.GetDefaultInventory().GetResourceClassification(resourceType)
+	if !ok || classification.Category == "" {
+		return "", false
+	}
+	return classification.Category, true
+}
+
+func (m *AzureMapper) SupportsResource(resourceType string) bool {
+	return inventory.GetDefaultInventory().SupportsResource(resourceType)
+}
+
+func (m *AzureMapper) MapResource(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	if res == nil {
+		return nil, fmt.Errorf("resource is nil")
+	}
+	if res.ID == "" {
+		return nil, fmt.Errorf("resource id is empty")
+	}
+
+	functions, ok := inventory.GetDefaultInventory().GetFunctions(res.Type.Name)
+	if !ok || functions.TerraformMapper == nil {
+		return m.mapResourceFallback(res)
+	}
+	return functions.TerraformMapper(res)
+}
+
+// mapResourceFallback maps resources when the inventory has no function registered
+func (m *AzureMapper) mapResourceFallback(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	switch res.Type.Name {
+	case "ResourceGroup":
+		return m.mapResourceGroup(res)
+	case "VirtualNetwork":
+		return m.mapVirtualNetwork(res)
+	case "Subnet":
+		return m.mapSubnet(res)
+	case "NetworkSecurityGroup":
+		return m.mapNetworkSecurityGroup(res)
+	case "VirtualMachine":
+		return m.mapVirtualMachine(res)
+	case "LoadBalancer":
+		return m.mapLoadBalancer(res)
+	case "StorageAccount":
+		return m.mapStorageAccount(res)
+	case "SQLDatabase":
+		return m.mapSQLDatabase(res)
+	default:
+		return nil, fmt.Errorf("unsupported resource type %q", res.Type.Name)
+	}
+}
+
+var _ tfmapper.ResourceMapper = (*AzureMapper)(nil)
+var _ tfmapper.ProviderRequirer = (*AzureMapper)(nil)
+var _ tfmapper.ProviderConfigurer = (*AzureMapper)(nil)
+var _ tfmapper.ResourceCategorizer = (*AzureMapper)(nil)
+
+// resourceGroupAttrs returns the attributes shared by resources deployed into a resource
+// group: its name, its location and the resource's tags.
+func resourceGroupAttrs(res *resource.Resource) (map[string]tfmapper.TerraformValue, error) {
+	rgID, _ := getString(res.Metadata, "resourceGroupId")
+	if rgID == "" {
+		return nil, fmt.Errorf("%s requires a resource group (resourceGroupId missing)", res.Type.Name)
+	}
+	rg := resolveRef(rgID, res.Metadata)
+
+	return map[string]tfmapper.TerraformValue{
+		"resource_group_name": tfExpr(tfmapper.Reference{ResourceType: "azurerm_resource_group", ResourceName: rg, Attribute: "name"}.Expr()),
+		"location":            tfExpr(tfmapper.Reference{ResourceType: "azurerm_resource_group", ResourceName: rg, Attribute: "location"}.Expr()),
+		"tags":                tfTags(res.Name),
+	}, nil
+}
+
+// dependencies returns the _dependsOn metadata injected by the generator (id, type, name)
+func dependencies(res *resource.Resource) []map[string]string {
+	if res.Metadata == nil {
+		return nil
+	}
+	if deps, ok := res.Metadata["_dependsOn"].([]map[string]string); ok {
+		return deps
+	}
+
+	var deps []map[string]string
+	rawDeps, _ := res.Metadata["_dependsOn"].([]interface{})
+	for _, d := range rawDeps {
+		switch dm := d.(type) {
+		case map[string]string:
+			deps = append(deps, dm)
+		case map[string]interface{}:
+			converted := make(map[string]string)
+			for _, key := range []string{"id", "type", "name"} {
+				if v, ok := dm[key].(string); ok {
+					converted[key] = v
+				}
+			}
+			deps = append(deps, converted)
+		}
+	}
+	return deps
+}
+
+// dependencyRefs returns the Terraform local names of the dependencies of a domain type
+func dependencyRefs(res *resource.Resource, domainType string) []string {
+	var refs []string
+	for _, dep := range dependencies(res) {
+		if dep["type"] == domainType && dep["id"] != "" {
+			refs = append(refs, depRefName(dep))
+		}
+	}
+	return refs
+}
+
+// depRefName names a dependency the way its own mapper named it (Name, falling back to ID)
+func depRefName(dep map[string]string) string {
+	if name := dep["name"]; name != "" {
+		if s := tfName(name); s != "resource" {
+			return s
+		}
+	}
+	return tfName(dep["id"])
+}
+
+// addDependsOn adds the resource's explicit dependencies as a depends_on attribute
+func addDependsOn(attrs map[string]tfmapper.TerraformValue, res *resource.Resource) {
+	var tfDeps []tfmapper.TerraformValue
+	for _, dep := range dependencies(res) {
+		tfType := getTerraformType(dep["type"])
+		if tfType == "" || dep["id"] == "" {
+			continue
+		}
+		tfDeps = append(tfDeps, tfExpr(tfmapper.TerraformExpr(fmt.Sprintf("%s.%s", tfType, depRefName(dep)))))
+	}
+	if len(tfDeps) > 0 {
+		attrs["depends_on"] = tfList(tfDeps)
+	}
+}
+
+// getTerraformType maps domain resource type to the Terraform type of its main block
+func getTerraformType(domainType string) string {
+	switch domainType {
+	case "ResourceGroup":
+		return "azurerm_resource_group"
+	case "VirtualNetwork":
+		return "azurerm_virtual_network"
+	case "Subnet":
+		return "azurerm_subnet"
+	case "NetworkSecurityGroup":
+		return "azurerm_network_security_group"
+	case "VirtualMachine":
+		return "azurerm_linux_virtual_machine"
+	case "LoadBalancer":
+		return "azurerm_lb"
+	case "StorageAccount":
+		return "azurerm_storage_account"
+	case "SQLDatabase":
+		return "azurerm_mssql_database"
+	default:
+		return ""
+	}
+}
+
+var tfNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+func tfName(id string) string {
+	if id == "" {
+		return "resource"
+	}
+	s := tfNameSanitizer.ReplaceAllString(id, "_")
+	s = strings.Trim(s, "_")
+	s = strings.ToLower(s)
+	if s == "" {
+		return "resource"
+	}
+	// Terraform identifiers must not start with a digit.
+	if s[0] >= '0' && s[0] <= '9' {
+		s = "r_" + s
+	}
+	return s
+}
+
+// tfBlockName returns the terraform local name for the resource: Name, falling back to ID
+func tfBlockName(res *resource.Resource) string {
+	if res.Name != "" {
+		if s := tfName(res.Name); s != "resource" {
+			return s
+		}
+	}
+	return tfName(res.ID)
+}
+
+// resolveRef resolves an ID to a Terraform reference name.
+// It checks _resourceNames (domain ID -> name, injected by generator), then
+// _originalIDToName (frontend node ID -> name, injected by LoadArchitecture).
+// Falls back to tfName(id).
+func resolveRef(id string, metadata map[string]interface{}) string {
+	for _, key := range []string{"_resourceNames", "_originalIDToName"} {
+		if mapping, ok := metadata[key].(map[string]string); ok {
+			if name, found := mapping[id]; found {
+				if s := tfName(name); s != "resource" {
+					return s
+				}
+			}
+		}
+	}
+	return tfName(id)
+}
+
+func tfString(s string) tfmapper.TerraformValue {
+	return tfmapper.TerraformValue{String: &s}
+}
+
+// tfStringOrVar returns a TerraformValue that uses a variable reference if one exists,
+// otherwise uses the resolved string value.
+func tfStringOrVar(metadata map[string]interface{}, fieldKey string, resolvedValue string) tfmapper.TerraformValue {
+	if varRef := getVarRef(metadata, fieldKey); varRef != "" {
+		return tfExpr(tfmapper.TerraformExpr(varRef))
+	}
+	return tfString(resolvedValue)
+}
+
+// getVarRef retrieves the original variable reference for a field from _varRefs metadata.
+// Returns empty string if no variable reference exists for that field.
+func getVarRef(metadata map[string]interface{}, fieldKey string) string {
+	switch varRefs := metadata["_varRefs"].(type) {
+	case map[string]interface{}:
+		ref, _ := varRefs[fieldKey].(string)
+		return ref
+	case map[string]string:
+		return varRefs[fieldKey]
+	default:
+		return ""
+	}
+}
+
+func tfBool(b bool) tfmapper.TerraformValue {
+	return tfmapper.TerraformValue{Bool: &b}
+}
+
+func tfNumber(n float64) tfmapper.TerraformValue {
+	return tfmapper.TerraformValue{Number: &n}
+}
+
+func tfExpr(e tfmapper.TerraformExpr) tfmapper.TerraformValue {
+	return tfmapper.TerraformValue{Expr: &e}
+}
+
+func tfList(items []tfmapper.TerraformValue) tfmapper.TerraformValue {
+	return tfmapper.TerraformValue{List: items}
+}
+
+func tfStringList(items []string) tfmapper.TerraformValue {
+	values := make([]tfmapper.TerraformValue, 0, len(items))
+	for _, item := range items {
+		values = append(values, tfString(item))
+	}
+	return tfList(values)
+}
+
+func tfTags(name string) tfmapper.TerraformValue {
+	if name == "" {
+		name = "arch-resource"
+	}
+	return tfmapper.TerraformValue{
+		Map: map[string]tfmapper.TerraformValue{
+			"Name": tfString(name),
+		},
+	}
+}
+
+func getString(m map[string]interface{}, key string) (string, bool) {
+	v, ok := m[key].(string)
+	return v, ok
+}
+
+// getStringOr returns a non-empty string config value or the default
+func getStringOr(m map[string]interface{}, key, def string) string {
+	if v, ok := getString(m, key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+func getBool(m map[string]interface{}, key string) (bool, bool) {
+	v, ok := m[key].(bool)
+	return v, ok
+}
+
+func getInt(m map[string]interface{}, key string) (int, bool) {
+	switch t := m[key].(type) {
+	case int:
+		return t, true
+	case float64:
+		return int(t), true
+	default:
+		return 0, false
+	}
+}
+
+func getStringSlice(m map[string]interface{}, key string) ([]string, bool) {
+	switch t := m[key].(type) {
+	case []string:
+		return t, true
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func getArray(m map[string]interface{}, key string) ([]interface{}, bool) {
+	v, ok := m[key].([]interface{})
+	return v, ok
+}
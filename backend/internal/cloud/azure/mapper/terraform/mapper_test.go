@@ -0,0 +1,299 @@
+package terraform
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func azureResource(id, name, typeName string, parentID *string, metadata map[string]interface{}) *resource.Resource {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	if _, ok := metadata["resourceGroupId"]; !ok && typeName != "ResourceGroup" {
+		metadata["resourceGroupId"] = "rg-1"
+	}
+	metadata["_resourceNames"] = map[string]string{
+		"rg-1":     "app-rg",
+		"vnet-1":   "app-vnet",
+		"subnet-1": "web",
+	}
+	return &resource.Resource{
+		ID:       id,
+		Name:     name,
+		Type:     resource.ResourceType{Name: typeName},
+		Provider: resource.Azure,
+		Region:   "westeurope",
+		ParentID: parentID,
+		Metadata: metadata,
+	}
+}
+
+func expr(v tfmapper.TerraformValue) string {
+	if v.Expr == nil {
+		return ""
+	}
+	return string(*v.Expr)
+}
+
+func TestAzureMapper_Provider(t *testing.T) {
+	m := New()
+	assert.Equal(t, "azure", m.Provider())
+	assert.Equal(t, "azurerm", m.RequiredProvider().LocalName)
+	assert.True(t, m.SupportsResource("VirtualMachine"))
+	assert.False(t, m.SupportsResource("EC2"))
+
+	category, ok := m.ResourceCategory("StorageAccount")
+	assert.True(t, ok)
+	assert.Equal(t, resource.CategoryStorage, category)
+
+	pb := m.ProviderBlock("westeurope")
+	assert.Equal(t, []string{"azurerm"}, pb.Labels)
+	assert.Len(t, pb.NestedBlocks["features"], 1)
+}
+
+func TestMapResourceGroup(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("rg-1", "app-rg", "ResourceGroup", nil, nil))
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, []string{"azurerm_resource_group", "app_rg"}, blocks[0].Labels)
+	assert.Equal(t, "westeurope", *blocks[0].Attributes["location"].String)
+
+	// An explicit location wins over the architecture region
+	blocks, err = m.MapResource(azureResource("rg-1", "app-rg", "ResourceGroup", nil, map[string]interface{}{"location": "northeurope"}))
+	require.NoError(t, err)
+	assert.Equal(t, "northeurope", *blocks[0].Attributes["location"].String)
+
+	res := azureResource("rg-1", "app-rg", "ResourceGroup", nil, nil)
+	res.Region = ""
+	_, err = m.MapResource(res)
+	assert.ErrorContains(t, err, `"location"`)
+}
+
+func TestMapVirtualNetworkAndSubnet(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("vnet-1", "app-vnet", "VirtualNetwork", stringPtr("rg-1"), map[string]interface{}{
+		"addressSpace": []interface{}{"10.0.0.0/16"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	vnet := blocks[0]
+	assert.Equal(t, []string{"azurerm_virtual_network", "app_vnet"}, vnet.Labels)
+	assert.Equal(t, "azurerm_resource_group.app_rg.name", expr(vnet.Attributes["resource_group_name"]))
+	assert.Equal(t, "azurerm_resource_group.app_rg.location", expr(vnet.Attributes["location"]))
+	assert.Equal(t, "10.0.0.0/16", *vnet.Attributes["address_space"].List[0].String)
+
+	blocks, err = m.MapResource(azureResource("subnet-1", "web", "Subnet", stringPtr("vnet-1"), map[string]interface{}{
+		"cidr": "10.0.1.0/24",
+		"_dependsOn": []map[string]string{
+			{"id": "nsg-1", "type": "NetworkSecurityGroup", "name": "web-nsg"},
+		},
+	}))
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	subnet := blocks[0]
+	assert.Equal(t, "azurerm_virtual_network.app_vnet.name", expr(subnet.Attributes["virtual_network_name"]))
+	assert.Equal(t, "10.0.1.0/24", *subnet.Attributes["address_prefixes"].List[0].String)
+	assert.NotContains(t, subnet.Attributes, "location")
+
+	assoc := blocks[1]
+	assert.Equal(t, "azurerm_subnet_network_security_group_association", assoc.Labels[0])
+	assert.Equal(t, "azurerm_network_security_group.web_nsg.id", expr(assoc.Attributes["network_security_group_id"]))
+}
+
+func TestMapNetworkSecurityGroup(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("nsg-1", "web-nsg", "NetworkSecurityGroup", stringPtr("rg-1"), map[string]interface{}{
+		"securityRules": []interface{}{
+			map[string]interface{}{"name": "ssh", "priority": float64(100), "destinationPortRange": "22"},
+			map[string]interface{}{"name": "deny-all", "priority": float64(4096), "access": "Deny", "protocol": "*"},
+		},
+	}))
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+
+	rules := blocks[0].NestedBlocks["security_rule"]
+	require.Len(t, rules, 2)
+	assert.Equal(t, "22", *rules[0].Attributes["destination_port_range"].String)
+	assert.Equal(t, "Inbound", *rules[0].Attributes["direction"].String)
+	assert.Equal(t, "Tcp", *rules[0].Attributes["protocol"].String)
+	assert.Equal(t, "Deny", *rules[1].Attributes["access"].String)
+
+	_, err = m.MapResource(azureResource("nsg-1", "web-nsg", "NetworkSecurityGroup", stringPtr("rg-1"), map[string]interface{}{
+		"securityRules": []interface{}{
+			map[string]interface{}{"name": "http", "priority": float64(50)},
+		},
+	}))
+	assert.ErrorContains(t, err, "priority must be between 100 and 4096")
+}
+
+func TestMapVirtualMachine(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("vm-1", "web-vm", "VirtualMachine", stringPtr("subnet-1"), map[string]interface{}{
+		"publicIp": true,
+		"size":     "Standard_B2s",
+		"_dependsOn": []map[string]string{
+			{"id": "nsg-1", "type": "NetworkSecurityGroup", "name": "web-nsg"},
+		},
+	}))
+	require.NoError(t, err)
+
+	labels := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		labels = append(labels, b.Labels[0])
+	}
+	assert.Equal(t, []string{
+		"azurerm_public_ip",
+		"azurerm_network_interface",
+		"azurerm_network_interface_security_group_association",
+		"azurerm_linux_virtual_machine",
+	}, labels)
+
+	ipConfig := blocks[1].NestedBlocks["ip_configuration"][0]
+	assert.Equal(t, "azurerm_subnet.web.id", expr(ipConfig.Attributes["subnet_id"]))
+	assert.Equal(t, "azurerm_public_ip.web_vm.id", expr(ipConfig.Attributes["public_ip_address_id"]))
+
+	vm := blocks[3]
+	assert.Equal(t, "Standard_B2s", *vm.Attributes["size"].String)
+	assert.Equal(t, "azurerm_network_interface.web_vm.id", expr(vm.Attributes["network_interface_ids"].List[0]))
+	assert.Equal(t, `file("~/.ssh/id_rsa.pub")`, expr(vm.NestedBlocks["admin_ssh_key"][0].Attributes["public_key"]))
+	assert.Equal(t, "Canonical", *vm.NestedBlocks["source_image_reference"][0].Attributes["publisher"].String)
+	assert.Len(t, vm.Attributes["depends_on"].List, 1)
+
+	_, err = m.MapResource(azureResource("vm-1", "web-vm", "VirtualMachine", nil, nil))
+	assert.ErrorContains(t, err, "requires parent subnet")
+}
+
+func TestMapLoadBalancer(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("lb-1", "web-lb", "LoadBalancer", stringPtr("rg-1"), map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"name": "http", "frontendPort": float64(80), "backendPort": float64(8080)},
+		},
+		"_dependsOn": []map[string]string{
+			{"id": "vm-1", "type": "VirtualMachine", "name": "web-vm"},
+		},
+	}))
+	require.NoError(t, err)
+
+	byType := make(map[string]tfmapper.TerraformBlock)
+	for _, b := range blocks {
+		byType[b.Labels[0]] = b
+	}
+	assert.Len(t, blocks, 6)
+	assert.Equal(t, "Standard", *byType["azurerm_lb"].Attributes["sku"].String)
+	assert.Equal(t, "azurerm_public_ip.web_lb.id", expr(byType["azurerm_lb"].NestedBlocks["frontend_ip_configuration"][0].Attributes["public_ip_address_id"]))
+	assert.Equal(t, float64(8080), *byType["azurerm_lb_probe"].Attributes["port"].Number)
+	assert.Equal(t, "azurerm_lb_probe.web_lb_http.id", expr(byType["azurerm_lb_rule"].Attributes["probe_id"]))
+
+	assoc := byType["azurerm_network_interface_backend_address_pool_association"]
+	assert.Equal(t, "azurerm_network_interface.web_vm.id", expr(assoc.Attributes["network_interface_id"]))
+	assert.Equal(t, "azurerm_lb_backend_address_pool.web_lb.id", expr(assoc.Attributes["backend_address_pool_id"]))
+
+	// Internal load balancers take a private frontend in a subnet and no public IP
+	blocks, err = m.MapResource(azureResource("lb-2", "app-lb", "LoadBalancer", stringPtr("subnet-1"), map[string]interface{}{"internal": true}))
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "azurerm_subnet.web.id", expr(blocks[0].NestedBlocks["frontend_ip_configuration"][0].Attributes["subnet_id"]))
+
+	_, err = m.MapResource(azureResource("lb-3", "app-lb", "LoadBalancer", nil, map[string]interface{}{"internal": true}))
+	assert.ErrorContains(t, err, "internal load balancer requires a subnet")
+}
+
+func TestMapStorageAccount(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("st-1", "App Assets_01", "StorageAccount", stringPtr("rg-1"), map[string]interface{}{
+		"replicationType": "GRS",
+		"containers":      []interface{}{"images"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "appassets01", *blocks[0].Attributes["name"].String)
+	assert.Equal(t, "GRS", *blocks[0].Attributes["account_replication_type"].String)
+	assert.Equal(t, "azurerm_storage_account.app_assets_01.name", expr(blocks[1].Attributes["storage_account_name"]))
+
+	assert.Equal(t, "abcdefghijklmnopqrstuvwx", sanitizeStorageAccountName("abcdefghijklmnopqrstuvwxyz"))
+	assert.Equal(t, "", sanitizeStorageAccountName("a-b"))
+}
+
+func TestMapSQLDatabase(t *testing.T) {
+	m := New()
+
+	blocks, err := m.MapResource(azureResource("sql-1", "orders", "SQLDatabase", stringPtr("rg-1"), map[string]interface{}{
+		"administratorPassword": "s3cret!",
+		"allowAzureServices":    true,
+		"_varRefs":              map[string]interface{}{"administratorPassword": "var.sql_password"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+
+	server := blocks[0]
+	assert.Equal(t, []string{"azurerm_mssql_server", "orders"}, server.Labels)
+	assert.Equal(t, "orders-server", *server.Attributes["name"].String)
+	assert.Equal(t, "sqladmin", *server.Attributes["administrator_login"].String)
+	assert.Equal(t, "var.sql_password", expr(server.Attributes["administrator_login_password"]))
+
+	db := blocks[1]
+	assert.Equal(t, "azurerm_mssql_server.orders.id", expr(db.Attributes["server_id"]))
+	assert.Equal(t, "Basic", *db.Attributes["sku_name"].String)
+	assert.Equal(t, "azurerm_mssql_firewall_rule", blocks[2].Labels[0])
+
+	_, err = m.MapResource(azureResource("sql-1", "orders", "SQLDatabase", stringPtr("rg-1"), nil))
+	assert.ErrorContains(t, err, `"administratorPassword"`)
+}
+
+func TestMapResource_RequiresResourceGroup(t *testing.T) {
+	m := New()
+
+	res := azureResource("st-1", "assets", "StorageAccount", nil, map[string]interface{}{"resourceGroupId": ""})
+	_, err := m.MapResource(res)
+	assert.ErrorContains(t, err, "requires a resource group")
+}
+
+func TestAzureMapper_GenerateTerraform(t *testing.T) {
+	reg := tfmapper.NewRegistry()
+	require.NoError(t, reg.Register(New()))
+	engine := generator.NewEngine(reg)
+
+	resources := []*resource.Resource{
+		azureResource("rg-1", "app-rg", "ResourceGroup", nil, nil),
+		azureResource("vnet-1", "app-vnet", "VirtualNetwork", stringPtr("rg-1"), map[string]interface{}{"cidr": "10.0.0.0/16"}),
+		azureResource("subnet-1", "web", "Subnet", stringPtr("vnet-1"), map[string]interface{}{"cidr": "10.0.1.0/24"}),
+	}
+	arch := &architecture.Architecture{
+		Resources: resources,
+		Region:    "westeurope",
+		Provider:  resource.Azure,
+	}
+
+	out, err := engine.Generate(context.Background(), arch, resources, iac.Options{})
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range out.Files {
+		files[f.Path] = f.Content
+	}
+	assert.Contains(t, files["versions.tf"], `"hashicorp/azurerm"`)
+	assert.Contains(t, files["providers.tf"], `provider "azurerm"`)
+	assert.Contains(t, files["providers.tf"], "features {")
+	assert.True(t, strings.Contains(files["networking.tf"], `resource "azurerm_subnet" "web"`), files["networking.tf"])
+}
@@ -0,0 +1,175 @@
+package terraform
+
+import (
+	"fmt"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func (m *AzureMapper) mapResourceGroup(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	location := getStringOr(res.Metadata, "location", res.Region)
+	if location == "" {
+		return nil, fmt.Errorf("missing required config %q", "location")
+	}
+
+	attrs := map[string]tfmapper.TerraformValue{
+		"name":     tfString(res.Name),
+		"location": tfStringOrVar(res.Metadata, "location", location),
+		"tags":     tfTags(res.Name),
+	}
+
+	return []tfmapper.TerraformBlock{
+		{
+			Kind:       "resource",
+			Labels:     []string{"azurerm_resource_group", tfBlockName(res)},
+			Attributes: attrs,
+		},
+	}, nil
+}
+
+func (m *AzureMapper) mapVirtualNetwork(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	// Accept a list of address spaces or a single CIDR
+	addressSpace, _ := getStringSlice(res.Metadata, "addressSpace")
+	if len(addressSpace) == 0 {
+		if cidr, ok := getString(res.Metadata, "cidr"); ok && cidr != "" {
+			addressSpace = []string{cidr}
+		}
+	}
+	if len(addressSpace) == 0 {
+		return nil, fmt.Errorf("missing required config %q", "addressSpace")
+	}
+
+	attrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+	attrs["name"] = tfString(res.Name)
+	if len(addressSpace) == 1 {
+		attrs["address_space"] = tfList([]tfmapper.TerraformValue{tfStringOrVar(res.Metadata, "cidr", addressSpace[0])})
+	} else {
+		attrs["address_space"] = tfStringList(addressSpace)
+	}
+	if dns, ok := getStringSlice(res.Metadata, "dnsServers"); ok && len(dns) > 0 {
+		attrs["dns_servers"] = tfStringList(dns)
+	}
+
+	return []tfmapper.TerraformBlock{
+		{
+			Kind:       "resource",
+			Labels:     []string{"azurerm_virtual_network", tfBlockName(res)},
+			Attributes: attrs,
+		},
+	}, nil
+}
+
+func (m *AzureMapper) mapSubnet(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	cidr, ok := getString(res.Metadata, "cidr")
+	if !ok || cidr == "" {
+		return nil, fmt.Errorf("missing required config %q", "cidr")
+	}
+	if res.ParentID == nil || *res.ParentID == "" {
+		return nil, fmt.Errorf("subnet requires parent virtual network (parentID missing)")
+	}
+
+	rgAttrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subnets inherit the location of their virtual network and take no tags
+	attrs := map[string]tfmapper.TerraformValue{
+		"name":                 tfString(res.Name),
+		"resource_group_name":  rgAttrs["resource_group_name"],
+		"virtual_network_name": tfExpr(tfmapper.Reference{ResourceType: "azurerm_virtual_network", ResourceName: resolveRef(*res.ParentID, res.Metadata), Attribute: "name"}.Expr()),
+		"address_prefixes":     tfList([]tfmapper.TerraformValue{tfStringOrVar(res.Metadata, "cidr", cidr)}),
+	}
+	if endpoints, ok := getStringSlice(res.Metadata, "serviceEndpoints"); ok && len(endpoints) > 0 {
+		attrs["service_endpoints"] = tfStringList(endpoints)
+	}
+
+	blocks := []tfmapper.TerraformBlock{
+		{
+			Kind:       "resource",
+			Labels:     []string{"azurerm_subnet", tfBlockName(res)},
+			Attributes: attrs,
+		},
+	}
+
+	// A dependency on a network security group applies it to the whole subnet
+	if nsg := networkSecurityGroupRef(res); nsg != "" {
+		blocks = append(blocks, tfmapper.TerraformBlock{
+			Kind:   "resource",
+			Labels: []string{"azurerm_subnet_network_security_group_association", tfBlockName(res)},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"subnet_id":                 tfExpr(tfmapper.Reference{ResourceType: "azurerm_subnet", ResourceName: tfBlockName(res), Attribute: "id"}.Expr()),
+				"network_security_group_id": tfExpr(tfmapper.Reference{ResourceType: "azurerm_network_security_group", ResourceName: nsg, Attribute: "id"}.Expr()),
+			},
+		})
+	}
+
+	return blocks, nil
+}
+
+func (m *AzureMapper) mapNetworkSecurityGroup(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	attrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+	attrs["name"] = tfString(res.Name)
+
+	var rules []tfmapper.NestedBlock
+	securityRules, _ := getArray(res.Metadata, "securityRules")
+	for i, raw := range securityRules {
+		rule, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := getStringOr(rule, "name", fmt.Sprintf("rule-%d", i+1))
+		priority, ok := getInt(rule, "priority")
+		if !ok {
+			return nil, fmt.Errorf("security rule %q: missing required config %q", name, "priority")
+		}
+		if priority < 100 || priority > 4096 {
+			return nil, fmt.Errorf("security rule %q: priority must be between 100 and 4096", name)
+		}
+
+		rules = append(rules, tfmapper.NestedBlock{
+			Attributes: map[string]tfmapper.TerraformValue{
+				"name":                       tfString(name),
+				"priority":                   tfNumber(float64(priority)),
+				"direction":                  tfString(getStringOr(rule, "direction", "Inbound")),
+				"access":                     tfString(getStringOr(rule, "access", "Allow")),
+				"protocol":                   tfString(getStringOr(rule, "protocol", "Tcp")),
+				"source_port_range":          tfString(getStringOr(rule, "sourcePortRange", "*")),
+				"destination_port_range":     tfString(getStringOr(rule, "destinationPortRange", "*")),
+				"source_address_prefix":      tfString(getStringOr(rule, "sourceAddressPrefix", "*")),
+				"destination_address_prefix": tfString(getStringOr(rule, "destinationAddressPrefix", "*")),
+			},
+		})
+	}
+
+	block := tfmapper.TerraformBlock{
+		Kind:       "resource",
+		Labels:     []string{"azurerm_network_security_group", tfBlockName(res)},
+		Attributes: attrs,
+	}
+	if len(rules) > 0 {
+		block.NestedBlocks = map[string][]tfmapper.NestedBlock{"security_rule": rules}
+	}
+
+	return []tfmapper.TerraformBlock{block}, nil
+}
+
+// networkSecurityGroupRef returns the Terraform name of the network security group a subnet or
+// virtual machine uses: an explicit networkSecurityGroupId, else its first NSG dependency
+func networkSecurityGroupRef(res *resource.Resource) string {
+	if id, ok := getString(res.Metadata, "networkSecurityGroupId"); ok && id != "" {
+		return resolveRef(id, res.Metadata)
+	}
+	if refs := dependencyRefs(res, "NetworkSecurityGroup"); len(refs) > 0 {
+		return refs[0]
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// mapStorageAccount maps a storage account and its blob containers
+func (m *AzureMapper) mapStorageAccount(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	accountName := sanitizeStorageAccountName(getStringOr(res.Metadata, "accountName", res.Name))
+	if accountName == "" {
+		return nil, fmt.Errorf("storage account name %q must contain 3-24 lowercase letters and numbers", getStringOr(res.Metadata, "accountName", res.Name))
+	}
+
+	attrs, err := resourceGroupAttrs(res)
+	if err != nil {
+		return nil, err
+	}
+	attrs["name"] = tfStringOrVar(res.Metadata, "accountName", accountName)
+	attrs["account_tier"] = tfString(getStringOr(res.Metadata, "accountTier", "Standard"))
+	attrs["account_replication_type"] = tfString(getStringOr(res.Metadata, "replicationType", "LRS"))
+	attrs["account_kind"] = tfString(getStringOr(res.Metadata, "accountKind", "StorageV2"))
+	attrs["min_tls_version"] = tfString("TLS1_2")
+	if tier, ok := getString(res.Metadata, "accessTier"); ok && tier != "" {
+		attrs["access_tier"] = tfString(tier)
+	}
+	addDependsOn(attrs, res)
+
+	name := tfBlockName(res)
+	blocks := []tfmapper.TerraformBlock{
+		{
+			Kind:       "resource",
+			Labels:     []string{"azurerm_storage_account", name},
+			Attributes: attrs,
+		},
+	}
+
+	containers, _ := getStringSlice(res.Metadata, "containers")
+	for _, container := range containers {
+		blocks = append(blocks, tfmapper.TerraformBlock{
+			Kind:   "resource",
+			Labels: []string{"azurerm_storage_container", tfName(name + "_" + container)},
+			Attributes: map[string]tfmapper.TerraformValue{
+				"name":                  tfString(container),
+				"storage_account_name":  tfExpr(tfmapper.Reference{ResourceType: "azurerm_storage_account", ResourceName: name, Attribute: "name"}.Expr()),
+				"container_access_type": tfString("private"),
+			},
+		})
+	}
+
+	return blocks, nil
+}
+
+// sanitizeStorageAccountName reduces a name to the lowercase letters and numbers storage
+// account names allow, truncated to 24 characters. Returns "" when fewer than 3 remain.
+func sanitizeStorageAccountName(name string) string {
+	allowed := make([]rune, 0, len(name))
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			allowed = append(allowed, r)
+		}
+	}
+	if len(allowed) > 24 {
+		allowed = allowed[:24]
+	}
+	if len(allowed) < 3 {
+		return ""
+	}
+	return string(allowed)
+}
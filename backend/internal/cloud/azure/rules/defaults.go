@@ -0,0 +1,73 @@
+package rules
+
+import (
+	awsrules "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/rules"
+)
+
+// DefaultNetworkingRules returns the default Azure networking rules
+// Resources drawn outside a resource group are placed in a default one by the architecture
+// generator, so no resource requires a resource group parent
+func DefaultNetworkingRules() []awsrules.ConstraintRecord {
+	return []awsrules.ConstraintRecord{
+		// ResourceGroup Rules
+		// ResourceGroup requires a location
+		{ResourceType: "ResourceGroup", ConstraintType: "requires_region", ConstraintValue: "true"},
+		// Azure allows up to 800 resources of a type per resource group
+		{ResourceType: "ResourceGroup", ConstraintType: "max_children", ConstraintValue: "800"},
+
+		// VirtualNetwork Rules
+		{ResourceType: "VirtualNetwork", ConstraintType: "allowed_parent", ConstraintValue: "ResourceGroup"},
+		{ResourceType: "VirtualNetwork", ConstraintType: "requires_region", ConstraintValue: "true"},
+		// Azure limit is 3000 subnets per virtual network
+		{ResourceType: "VirtualNetwork", ConstraintType: "max_children", ConstraintValue: "3000"},
+
+		// Subnet Rules
+		// Subnet requires VirtualNetwork as parent
+		{ResourceType: "Subnet", ConstraintType: "requires_parent", ConstraintValue: "VirtualNetwork"},
+		{ResourceType: "Subnet", ConstraintType: "allowed_parent", ConstraintValue: "VirtualNetwork"},
+		// Subnet can be associated with a NetworkSecurityGroup
+		{ResourceType: "Subnet", ConstraintType: "allowed_dependencies", ConstraintValue: "NetworkSecurityGroup"},
+		{ResourceType: "Subnet", ConstraintType: "forbidden_dependencies", ConstraintValue: "Subnet,VirtualNetwork"},
+
+		// NetworkSecurityGroup Rules
+		{ResourceType: "NetworkSecurityGroup", ConstraintType: "allowed_parent", ConstraintValue: "ResourceGroup"},
+		{ResourceType: "NetworkSecurityGroup", ConstraintType: "requires_region", ConstraintValue: "true"},
+		// NSGs are applied by subnets and virtual machines, not the other way round
+		{ResourceType: "NetworkSecurityGroup", ConstraintType: "forbidden_dependencies", ConstraintValue: "VirtualNetwork,Subnet,VirtualMachine"},
+	}
+}
+
+// DefaultComputeRules returns the default Azure compute rules
+func DefaultComputeRules() []awsrules.ConstraintRecord {
+	return []awsrules.ConstraintRecord{
+		// VirtualMachine Rules
+		// VirtualMachine requires Subnet as parent (its network interface is placed there)
+		{ResourceType: "VirtualMachine", ConstraintType: "requires_parent", ConstraintValue: "Subnet"},
+		{ResourceType: "VirtualMachine", ConstraintType: "allowed_parent", ConstraintValue: "Subnet"},
+		{ResourceType: "VirtualMachine", ConstraintType: "requires_region", ConstraintValue: "true"},
+		{ResourceType: "VirtualMachine", ConstraintType: "allowed_dependencies", ConstraintValue: "NetworkSecurityGroup,StorageAccount,SQLDatabase"},
+
+		// LoadBalancer Rules
+		// Public load balancers live in a resource group, internal ones in a subnet
+		{ResourceType: "LoadBalancer", ConstraintType: "allowed_parent", ConstraintValue: "ResourceGroup,Subnet"},
+		{ResourceType: "LoadBalancer", ConstraintType: "requires_region", ConstraintValue: "true"},
+		// LoadBalancer depends on the virtual machines in its backend pool
+		{ResourceType: "LoadBalancer", ConstraintType: "allowed_dependencies", ConstraintValue: "VirtualMachine"},
+	}
+}
+
+// DefaultStorageRules returns the default Azure storage rules
+func DefaultStorageRules() []awsrules.ConstraintRecord {
+	return []awsrules.ConstraintRecord{
+		{ResourceType: "StorageAccount", ConstraintType: "allowed_parent", ConstraintValue: "ResourceGroup"},
+		{ResourceType: "StorageAccount", ConstraintType: "requires_region", ConstraintValue: "true"},
+	}
+}
+
+// DefaultDatabaseRules returns the default Azure database rules
+func DefaultDatabaseRules() []awsrules.ConstraintRecord {
+	return []awsrules.ConstraintRecord{
+		{ResourceType: "SQLDatabase", ConstraintType: "allowed_parent", ConstraintValue: "ResourceGroup"},
+		{ResourceType: "SQLDatabase", ConstraintType: "requires_region", ConstraintValue: "true"},
+	}
+}
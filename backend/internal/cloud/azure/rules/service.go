@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"context"
+
+	awsrules "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/rules"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// AzureRuleService provides Azure rule evaluation services on top of the shared rules engine
+type AzureRuleService struct {
+	registry  awsrules.RuleRegistry
+	factory   awsrules.RuleFactory
+	evaluator awsrules.RuleEvaluator
+}
+
+// NewAzureRuleService creates a new Azure rule service
+// Use LoadRulesWithDefaults() to load default rules and merge with DB constraints
+func NewAzureRuleService() *AzureRuleService {
+	return &AzureRuleService{
+		registry:  awsrules.NewRuleRegistry(),
+		factory:   awsrules.NewRuleFactory(),
+		evaluator: awsrules.NewRuleEvaluator(),
+	}
+}
+
+// LoadRulesFromConstraints loads rules from database constraints
+func (s *AzureRuleService) LoadRulesFromConstraints(ctx context.Context, constraints []awsrules.ConstraintRecord) error {
+	for _, constraint := range constraints {
+		rule, err := s.factory.CreateRule(constraint.ResourceType, constraint.ConstraintType, constraint.ConstraintValue)
+		if err != nil {
+			return err
+		}
+		if err := s.registry.RegisterRule(constraint.ResourceType, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadRulesWithDefaults loads rules from database constraints and merges with defaults
+// DB constraints override defaults when they have the same resource type + constraint type
+func (s *AzureRuleService) LoadRulesWithDefaults(ctx context.Context, dbConstraints []awsrules.ConstraintRecord) error {
+	defaultRules := DefaultNetworkingRules()
+	defaultRules = append(defaultRules, DefaultComputeRules()...)
+	defaultRules = append(defaultRules, DefaultStorageRules()...)
+	defaultRules = append(defaultRules, DefaultDatabaseRules()...)
+
+	overrideMap := make(map[string]bool)
+	for _, dbConstraint := range dbConstraints {
+		overrideMap[dbConstraint.ResourceType+":"+dbConstraint.ConstraintType] = true
+	}
+
+	var defaults []awsrules.ConstraintRecord
+	for _, defaultRule := range defaultRules {
+		if !overrideMap[defaultRule.ResourceType+":"+defaultRule.ConstraintType] {
+			defaults = append(defaults, defaultRule)
+		}
+	}
+	if err := s.LoadRulesFromConstraints(ctx, defaults); err != nil {
+		return err
+	}
+
+	return s.LoadRulesFromConstraints(ctx, dbConstraints)
+}
+
+// ValidateResource validates a resource against all applicable rules
+func (s *AzureRuleService) ValidateResource(
+	ctx context.Context,
+	res *resource.Resource,
+	architecture *awsrules.Architecture,
+) (*awsrules.EvaluationResult, error) {
+	if isVisualOnly, ok := res.Metadata["isVisualOnly"].(bool); ok && isVisualOnly {
+		return &awsrules.EvaluationResult{Valid: true, Results: []*awsrules.RuleResult{}}, nil
+	}
+
+	resourceRules := s.registry.GetRules(res.Type.Name)
+	if len(resourceRules) == 0 {
+		return &awsrules.EvaluationResult{Valid: true, Results: []*awsrules.RuleResult{}}, nil
+	}
+
+	evalCtx := awsrules.BuildEvaluationContext(res, architecture, string(resource.Azure))
+	return awsrules.EvaluateAllRules(ctx, s.evaluator, resourceRules, evalCtx), nil
+}
+
+// ValidateArchitecture validates all resources in an architecture
+func (s *AzureRuleService) ValidateArchitecture(
+	ctx context.Context,
+	architecture *awsrules.Architecture,
+) (map[string]*awsrules.EvaluationResult, error) {
+	results := make(map[string]*awsrules.EvaluationResult)
+
+	for _, res := range architecture.Resources {
+		result, err := s.ValidateResource(ctx, res, architecture)
+		if err != nil {
+			return nil, err
+		}
+		results[res.ID] = result
+	}
+
+	return results, nil
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	awsrules "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/rules"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestAzureRuleService_LoadRulesWithDefaults(t *testing.T) {
+	service := NewAzureRuleService()
+
+	if err := service.LoadRulesWithDefaults(context.Background(), nil); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	for _, defaultRule := range append(DefaultNetworkingRules(), DefaultComputeRules()...) {
+		found := false
+		for _, rule := range service.registry.GetRules(defaultRule.ResourceType) {
+			if rule.GetType() == awsrules.RuleType(defaultRule.ConstraintType) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected default rule %s:%s to be loaded", defaultRule.ResourceType, defaultRule.ConstraintType)
+		}
+	}
+}
+
+func TestAzureRuleService_LoadRulesWithDefaults_Override(t *testing.T) {
+	service := NewAzureRuleService()
+
+	dbConstraints := []awsrules.ConstraintRecord{
+		{ResourceType: "VirtualNetwork", ConstraintType: "max_children", ConstraintValue: "10"},
+	}
+	if err := service.LoadRulesWithDefaults(context.Background(), dbConstraints); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var values []string
+	for _, rule := range service.registry.GetRules("VirtualNetwork") {
+		if rule.GetType() == awsrules.RuleTypeMaxChildren {
+			values = append(values, rule.GetValue())
+		}
+	}
+	if len(values) != 1 || values[0] != "10" {
+		t.Errorf("Expected only the overriding max_children rule, got %v", values)
+	}
+}
+
+func TestAzureRuleService_ValidateArchitecture(t *testing.T) {
+	service := NewAzureRuleService()
+	if err := service.LoadRulesWithDefaults(context.Background(), nil); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	newResource := func(id, typeName string, parentID *string, deps ...string) *resource.Resource {
+		return &resource.Resource{
+			ID:        id,
+			Name:      id,
+			Type:      resource.ResourceType{Name: typeName},
+			Provider:  resource.Azure,
+			Region:    "westeurope",
+			ParentID:  parentID,
+			DependsOn: deps,
+			Metadata:  map[string]interface{}{},
+		}
+	}
+
+	arch := &awsrules.Architecture{
+		Resources: []*resource.Resource{
+			newResource("rg", "ResourceGroup", nil),
+			newResource("vnet", "VirtualNetwork", stringPtr("rg")),
+			newResource("nsg", "NetworkSecurityGroup", stringPtr("rg")),
+			newResource("subnet", "Subnet", stringPtr("vnet"), "nsg"),
+			newResource("vm", "VirtualMachine", stringPtr("subnet"), "nsg"),
+			// A subnet directly in the resource group and a VM outside any subnet are invalid
+			newResource("orphan-subnet", "Subnet", stringPtr("rg")),
+			newResource("orphan-vm", "VirtualMachine", nil),
+		},
+	}
+
+	results, err := service.ValidateArchitecture(context.Background(), arch)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	for _, id := range []string{"rg", "vnet", "nsg", "subnet", "vm"} {
+		if !results[id].Valid {
+			t.Errorf("Expected %s to be valid, got errors %v", id, results[id].Errors)
+		}
+	}
+	for _, id := range []string{"orphan-subnet", "orphan-vm"} {
+		if results[id].Valid {
+			t.Errorf("Expected %s to be invalid", id)
+		}
+	}
+}
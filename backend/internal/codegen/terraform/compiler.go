@@ -32,5 +32,5 @@ func (c *Compiler) Compile(ctx context.Context, arch *architecture.Architecture)
 		return nil, err
 	}
 
-	return c.engine.Generate(ctx, arch, sorted)
+	return c.engine.Generate(ctx, arch, sorted, iac.Options{})
 }
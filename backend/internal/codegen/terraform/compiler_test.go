@@ -18,7 +18,7 @@ type fakeEngine struct {
 
 func (f *fakeEngine) Name() string { return "terraform" }
 
-func (f *fakeEngine) Generate(ctx context.Context, arch *architecture.Architecture, sorted []*resource.Resource) (*iac.Output, error) {
+func (f *fakeEngine) Generate(ctx context.Context, arch *architecture.Architecture, sorted []*resource.Resource, opts iac.Options) (*iac.Output, error) {
 	f.lastArch = arch
 	f.lastSorted = sorted
 	return f.output, f.returnError
@@ -0,0 +1,323 @@
+package compliance
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource/networking"
+)
+
+// Checks read resource configuration under both the camelCase keys of the diagram
+// editor and the snake_case keys of imported Terraform.
+
+// forEach runs a pass/fail test on every resource of a type.
+func forEach(typeName string, pass func(arch *architecture.Architecture, res *resource.Resource) bool) check {
+	return func(arch *architecture.Architecture) (checked, failed []string) {
+		for _, res := range arch.Resources {
+			if res.Type.Name != typeName {
+				continue
+			}
+			checked = append(checked, res.ID)
+			if !pass(arch, res) {
+				failed = append(failed, res.ID)
+			}
+		}
+		return checked, failed
+	}
+}
+
+// all combines checks; a resource fails when it fails any of them.
+func all(checks ...check) check {
+	return func(arch *architecture.Architecture) (checked, failed []string) {
+		for _, c := range checks {
+			ch, f := c(arch)
+			checked = append(checked, ch...)
+			failed = append(failed, f...)
+		}
+		return checked, failed
+	}
+}
+
+// s3Encrypted: the bucket sets default server-side encryption.
+var s3Encrypted = forEach("S3", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	if s, ok := str(res, "encryption", "sseAlgorithm", "sse_algorithm", "serverSideEncryption", "server_side_encryption"); ok {
+		return s != "" && !strings.EqualFold(s, "none")
+	}
+	enabled, _ := flag(res, "encryption", "encrypted", "serverSideEncryption", "server_side_encryption_configuration")
+	return enabled
+})
+
+// s3NotPublic: the bucket grants no public ACL and does not turn off public access blocking.
+var s3NotPublic = forEach("S3", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	if acl, _ := str(res, "acl"); strings.HasPrefix(acl, "public-") {
+		return false
+	}
+	if block, ok := flag(res, "blockPublicAccess", "block_public_access"); ok && !block {
+		return false
+	}
+	if settings, ok := res.Metadata["publicAccessBlock"].(map[string]interface{}); ok {
+		for _, v := range settings {
+			if b, ok := v.(bool); ok && !b {
+				return false
+			}
+		}
+	}
+	return true
+})
+
+// s3Versioned: versioning is enabled, so objects can be recovered.
+var s3Versioned = forEach("S3", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	enabled, _ := flag(res, "versioning", "versioningEnabled", "versioning_enabled")
+	return enabled
+})
+
+// rdsEncrypted: storage encryption is on, explicitly or through a KMS key.
+var rdsEncrypted = forEach("RDS", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	if enabled, _ := flag(res, "storageEncrypted", "storage_encrypted", "encrypted"); enabled {
+		return true
+	}
+	key, _ := str(res, "kmsKeyId", "kms_key_id")
+	return key != ""
+})
+
+// rdsMultiAZ: the instance has a standby in another availability zone.
+var rdsMultiAZ = forEach("RDS", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	enabled, _ := flag(res, "multiAz", "multi_az", "multiAZ")
+	return enabled
+})
+
+// rdsBackups: automated backups are kept. AWS keeps them for one day unless the
+// retention period is set to 0.
+var rdsBackups = forEach("RDS", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	days, ok := number(res, "backupRetentionPeriod", "backup_retention_period")
+	return !ok || days > 0
+})
+
+// databasesPrivate: no database is publicly accessible or reachable from the internet.
+func databasesPrivate(arch *architecture.Architecture) (checked, failed []string) {
+	checked, failed = forEach("RDS", func(_ *architecture.Architecture, res *resource.Resource) bool {
+		public, _ := flag(res, "publiclyAccessible", "publicly_accessible")
+		return !public
+	})(arch)
+	if len(checked) == 0 {
+		return nil, nil
+	}
+	for _, f := range architecture.AnalyzeReachability(arch).Findings {
+		if f.Code == architecture.FindingDatabaseExposed {
+			failed = append(failed, f.Resource.ID)
+		}
+	}
+	return checked, failed
+}
+
+// ebsEncrypted: the volume is encrypted.
+var ebsEncrypted = forEach("EBS", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	enabled, _ := flag(res, "encrypted")
+	return enabled
+})
+
+// noAdminPortsFromWorld: no security group admits 0.0.0.0/0 or ::/0 to SSH (22) or RDP
+// (3389).
+var noAdminPortsFromWorld = forEach("SecurityGroup", func(_ *architecture.Architecture, sg *resource.Resource) bool {
+	for _, rule := range architecture.SecurityGroupRules(sg) {
+		if rule.Type != "ingress" || !worldOpen(rule.CIDRBlocks) {
+			continue
+		}
+		if coversPort(rule, 22) || coversPort(rule, 3389) {
+			return false
+		}
+	}
+	return true
+})
+
+// iamNoFullAdmin: no IAM policy allows every action on every resource.
+var iamNoFullAdmin = forEach("IAMPolicy", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	for _, stmt := range policyStatements(res) {
+		effect, _ := stmt["Effect"].(string)
+		if effect == "Allow" && hasWildcard(stmt["Action"]) && hasWildcard(stmt["Resource"]) {
+			return false
+		}
+	}
+	return true
+})
+
+// vpcFlowLogs: the VPC has flow logs, configured on the VPC or as a FlowLog resource
+// attached to it.
+var vpcFlowLogs = forEach("VPC", func(arch *architecture.Architecture, vpc *resource.Resource) bool {
+	if enabled, _ := flag(vpc, "flowLogs", "flow_logs", "enableFlowLogs", "enable_flow_logs"); enabled {
+		return true
+	}
+	for _, res := range arch.Resources {
+		if res.Type.Name != "FlowLog" {
+			continue
+		}
+		if res.ParentID != nil && *res.ParentID == vpc.ID {
+			return true
+		}
+		if id, _ := str(res, "vpcId", "vpc_id"); id == vpc.ID {
+			return true
+		}
+		for _, dep := range res.DependsOn {
+			if dep == vpc.ID {
+				return true
+			}
+		}
+	}
+	return false
+})
+
+// listenersEncrypted: load balancer listeners use HTTPS or TLS, or only redirect.
+var listenersEncrypted = forEach("Listener", func(_ *architecture.Architecture, res *resource.Resource) bool {
+	protocol, _ := str(res, "protocol")
+	switch strings.ToUpper(protocol) {
+	case "HTTPS", "TLS":
+		return true
+	case "HTTP", "TCP", "UDP", "TCP_UDP":
+		action, _ := str(res, "defaultActionType", "default_action_type")
+		return strings.EqualFold(action, "redirect")
+	}
+	return protocol == ""
+})
+
+// ── metadata helpers ──────────────────────────────────────────────────────────
+
+// str returns the first of the keys set to a string.
+func str(res *resource.Resource, keys ...string) (string, bool) {
+	for _, k := range keys {
+		if s, ok := res.Metadata[k].(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// flag returns the first of the keys set to a boolean, a boolean string ("true",
+// "Enabled"), or an object with an "enabled" or "status" entry.
+func flag(res *resource.Resource, keys ...string) (bool, bool) {
+	for _, k := range keys {
+		if b, ok := truth(res.Metadata[k]); ok {
+			return b, true
+		}
+	}
+	return false, false
+}
+
+func truth(v interface{}) (bool, bool) {
+	switch t := v.(type) {
+	case bool:
+		return t, true
+	case string:
+		switch strings.ToLower(t) {
+		case "true", "enabled", "on", "yes":
+			return true, true
+		case "false", "disabled", "suspended", "off", "no":
+			return false, true
+		}
+	case map[string]interface{}:
+		if b, ok := truth(t["enabled"]); ok {
+			return b, true
+		}
+		return truth(t["status"])
+	}
+	return false, false
+}
+
+// number returns the first of the keys set to a number or a numeric string.
+func number(res *resource.Resource, keys ...string) (float64, bool) {
+	for _, k := range keys {
+		switch v := res.Metadata[k].(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func worldOpen(cidrs []string) bool {
+	for _, c := range cidrs {
+		if c == "0.0.0.0/0" || c == "::/0" {
+			return true
+		}
+	}
+	return false
+}
+
+func coversPort(rule networking.SecurityGroupRule, port int) bool {
+	switch rule.Protocol {
+	case networking.ProtocolAll:
+		return true
+	case networking.ProtocolICMP:
+		return false
+	}
+	from, to := 0, 65535
+	if rule.FromPort != nil {
+		from = *rule.FromPort
+	}
+	if rule.ToPort != nil {
+		to = *rule.ToPort
+	}
+	return from <= port && port <= to
+}
+
+// policyStatements reads the statements of a policy document, given as JSON text or as
+// an object, under "policy", "policyDocument" or "document".
+func policyStatements(res *resource.Resource) []map[string]interface{} {
+	var doc map[string]interface{}
+	for _, k := range []string{"policy", "policyDocument", "policy_document", "document"} {
+		if doc = policyDocument(res.Metadata[k]); doc != nil {
+			break
+		}
+	}
+
+	var stmts []map[string]interface{}
+	switch s := doc["Statement"].(type) {
+	case map[string]interface{}:
+		stmts = append(stmts, s)
+	case []interface{}:
+		for _, item := range s {
+			if m, ok := item.(map[string]interface{}); ok {
+				stmts = append(stmts, m)
+			}
+		}
+	}
+	return stmts
+}
+
+func policyDocument(v interface{}) map[string]interface{} {
+	switch t := v.(type) {
+	case string:
+		var doc map[string]interface{}
+		if json.Unmarshal([]byte(t), &doc) == nil {
+			return doc
+		}
+	case map[string]interface{}:
+		return t
+	}
+	return nil
+}
+
+// hasWildcard reports whether an Action or Resource element is, or contains, "*".
+func hasWildcard(v interface{}) bool {
+	switch t := v.(type) {
+	case string:
+		return t == "*" || t == "*:*"
+	case []interface{}:
+		for _, item := range t {
+			if hasWildcard(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
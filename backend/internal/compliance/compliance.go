@@ -0,0 +1,138 @@
+package compliance
+
+import (
+	"sort"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+)
+
+// Control statuses.
+const (
+	StatusPass          = "pass"
+	StatusFail          = "fail"
+	StatusNotApplicable = "not_applicable" // the architecture has no resource the control checks
+)
+
+// Control severities, most severe first.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// Standard is a built-in control set. Slug matches models.ComplianceStandard.Slug, so the
+// compliance labels of marketplace templates can be verified against it.
+type Standard struct {
+	Slug        string
+	Name        string
+	Description string
+	Controls    []Control
+}
+
+// Control is one check of a standard.
+type Control struct {
+	ID          string // the control's reference in the standard, e.g. "2.1.1" or "CC6.1"
+	Title       string
+	Severity    string
+	Remediation string
+	check       check
+}
+
+// check inspects an architecture and returns the IDs of the resources it applies to
+// and of those that fail it.
+type check func(arch *architecture.Architecture) (checked, failed []string)
+
+// Report is the result of evaluating an architecture against a standard.
+type Report struct {
+	Standard      string
+	Name          string
+	Passed        bool // no control failed
+	PassCount     int
+	FailCount     int
+	NotApplicable int
+	Controls      []ControlResult
+}
+
+// ControlResult is the outcome of one control.
+type ControlResult struct {
+	ID          string
+	Title       string
+	Severity    string
+	Status      string
+	ResourceIDs []string // failing resources
+	Remediation string
+}
+
+// Standards returns the built-in standards, by slug.
+func Standards() []*Standard {
+	out := make([]*Standard, 0, len(standards))
+	for _, s := range standards {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Slug < out[j].Slug })
+	return out
+}
+
+// Lookup returns the built-in standard with the slug.
+func Lookup(slug string) (*Standard, bool) {
+	s, ok := standards[slug]
+	return s, ok
+}
+
+// Evaluate checks an architecture against every control of a standard. Visual-only
+// resources are ignored.
+func Evaluate(std *Standard, arch *architecture.Architecture) *Report {
+	report := &Report{Standard: std.Slug, Name: std.Name, Passed: true}
+	arch = withoutVisualOnly(arch)
+	for _, c := range std.Controls {
+		checked, failed := c.check(arch)
+		result := ControlResult{
+			ID:          c.ID,
+			Title:       c.Title,
+			Severity:    c.Severity,
+			Status:      StatusPass,
+			ResourceIDs: []string{},
+			Remediation: c.Remediation,
+		}
+		switch {
+		case len(failed) > 0:
+			result.Status = StatusFail
+			result.ResourceIDs = uniqueSorted(failed)
+			report.FailCount++
+			report.Passed = false
+		case len(checked) == 0:
+			result.Status = StatusNotApplicable
+			report.NotApplicable++
+		default:
+			report.PassCount++
+		}
+		report.Controls = append(report.Controls, result)
+	}
+	return report
+}
+
+func withoutVisualOnly(arch *architecture.Architecture) *architecture.Architecture {
+	filtered := *arch
+	filtered.Resources = filtered.Resources[:0:0]
+	for _, res := range arch.Resources {
+		if visualOnly, _ := res.Metadata["isVisualOnly"].(bool); visualOnly {
+			continue
+		}
+		filtered.Resources = append(filtered.Resources, res)
+	}
+	return &filtered
+}
+
+func uniqueSorted(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
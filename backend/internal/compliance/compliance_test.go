@@ -0,0 +1,146 @@
+package compliance
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func newResource(id, typeName string, metadata map[string]interface{}) *resource.Resource {
+	return &resource.Resource{
+		ID:        id,
+		Name:      id,
+		Type:      resource.ResourceType{ID: typeName, Name: typeName},
+		Provider:  resource.AWS,
+		Region:    "us-east-1",
+		DependsOn: []string{},
+		Metadata:  metadata,
+	}
+}
+
+func newArchitecture(resources ...*resource.Resource) *architecture.Architecture {
+	a := architecture.NewArchitecture()
+	a.Resources = resources
+	return a
+}
+
+func result(t *testing.T, r *Report, id string) ControlResult {
+	t.Helper()
+	for _, c := range r.Controls {
+		if c.ID == id {
+			return c
+		}
+	}
+	t.Fatalf("control %s not in report", id)
+	return ControlResult{}
+}
+
+func TestEvaluate_CIS(t *testing.T) {
+	std, ok := Lookup("cis-aws-foundations")
+	if !ok {
+		t.Fatal("cis-aws-foundations not found")
+	}
+	arch := newArchitecture(
+		newResource("logs", "S3", map[string]interface{}{"encryption": "AES256", "acl": "private", "versioning": true}),
+		newResource("site", "S3", map[string]interface{}{"acl": "public-read"}),
+		newResource("db", "RDS", map[string]interface{}{"storageEncrypted": true, "publiclyAccessible": false}),
+		newResource("vol", "EBS", map[string]interface{}{"encrypted": "false"}),
+		newResource("admin", "IAMPolicy", map[string]interface{}{
+			"policy": `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,
+		}),
+		newResource("readonly", "IAMPolicy", map[string]interface{}{
+			"policy": `{"Statement":{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"*"}}`,
+		}),
+		newResource("sg", "SecurityGroup", map[string]interface{}{
+			"ingressRules": []interface{}{map[string]interface{}{"protocol": "tcp", "fromPort": 22.0, "toPort": 22.0, "cidr": "0.0.0.0/0"}},
+		}),
+		newResource("hidden", "S3", map[string]interface{}{"isVisualOnly": true}),
+	)
+
+	report := Evaluate(std, arch)
+	if report.Passed {
+		t.Error("report passed, want failures")
+	}
+
+	tests := []struct {
+		id        string
+		status    string
+		resources []string
+	}{
+		{"1.16", StatusFail, []string{"admin"}},
+		{"2.1.1", StatusFail, []string{"site"}},
+		{"2.1.5", StatusFail, []string{"site"}},
+		{"2.2.1", StatusFail, []string{"vol"}},
+		{"2.3.1", StatusPass, []string{}},
+		{"2.3.3", StatusPass, []string{}},
+		{"3.9", StatusNotApplicable, []string{}},
+		{"5.2", StatusFail, []string{"sg"}},
+	}
+	for _, tt := range tests {
+		got := result(t, report, tt.id)
+		if got.Status != tt.status || !reflect.DeepEqual(got.ResourceIDs, tt.resources) {
+			t.Errorf("%s: got %s %v, want %s %v", tt.id, got.Status, got.ResourceIDs, tt.status, tt.resources)
+		}
+		if got.Status == StatusFail && got.Remediation == "" {
+			t.Errorf("%s: failing control has no remediation", tt.id)
+		}
+	}
+	if report.PassCount != 2 || report.FailCount != 5 || report.NotApplicable != 1 {
+		t.Errorf("counts = %d/%d/%d, want 2/5/1", report.PassCount, report.FailCount, report.NotApplicable)
+	}
+}
+
+func TestEvaluate_SOC2(t *testing.T) {
+	std, _ := Lookup("soc-2")
+	vpc := newResource("vpc", "VPC", map[string]interface{}{})
+	flowLog := newResource("fl", "FlowLog", map[string]interface{}{"vpcId": "vpc"})
+	arch := newArchitecture(
+		vpc, flowLog,
+		newResource("https", "Listener", map[string]interface{}{"protocol": "HTTPS"}),
+		newResource("redirect", "Listener", map[string]interface{}{"protocol": "HTTP", "defaultActionType": "redirect"}),
+		newResource("plain", "Listener", map[string]interface{}{"protocol": "HTTP", "defaultActionType": "forward"}),
+		newResource("db", "RDS", map[string]interface{}{"kmsKeyId": "alias/rds", "multiAz": false, "backupRetentionPeriod": 0.0}),
+	)
+
+	report := Evaluate(std, arch)
+	if got := result(t, report, "CC7.2"); got.Status != StatusPass {
+		t.Errorf("CC7.2 = %s, want pass with a flow log on the VPC", got.Status)
+	}
+	if got := result(t, report, "CC6.7"); !reflect.DeepEqual(got.ResourceIDs, []string{"plain"}) {
+		t.Errorf("CC6.7 resources = %v, want [plain]", got.ResourceIDs)
+	}
+	if got := result(t, report, "CC6.1-encryption"); got.Status != StatusPass {
+		t.Errorf("CC6.1-encryption = %s, want pass with a KMS key", got.Status)
+	}
+	if got := result(t, report, "A1.2-backups"); !reflect.DeepEqual(got.ResourceIDs, []string{"db"}) {
+		t.Errorf("A1.2-backups resources = %v, want [db]", got.ResourceIDs)
+	}
+	if got := result(t, report, "A1.2-redundancy"); got.Status != StatusFail {
+		t.Errorf("A1.2-redundancy = %s, want fail", got.Status)
+	}
+}
+
+func TestStandards(t *testing.T) {
+	var slugs []string
+	for _, s := range Standards() {
+		slugs = append(slugs, s.Slug)
+		seen := map[string]bool{}
+		for _, c := range s.Controls {
+			if seen[c.ID] {
+				t.Errorf("%s: duplicate control %s", s.Slug, c.ID)
+			}
+			seen[c.ID] = true
+			if c.check == nil || c.Title == "" || c.Severity == "" || c.Remediation == "" {
+				t.Errorf("%s: control %s is incomplete", s.Slug, c.ID)
+			}
+		}
+	}
+	if want := []string{"cis-aws-foundations", "hipaa", "soc-2"}; !reflect.DeepEqual(slugs, want) {
+		t.Errorf("Standards() = %v, want %v", slugs, want)
+	}
+	if _, ok := Lookup("pci-dss"); ok {
+		t.Error("Lookup(pci-dss) found a standard")
+	}
+}
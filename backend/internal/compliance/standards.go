@@ -0,0 +1,65 @@
+package compliance
+
+// Shared remediation text, as the same check serves several standards.
+const (
+	fixS3Encryption  = "Set the bucket's default encryption to AES256 (SSE-S3) or aws:kms (SSE-KMS)."
+	fixS3Public      = "Use a private ACL and keep S3 Block Public Access enabled; serve public content through CloudFront instead."
+	fixS3Versioning  = "Enable versioning on the bucket."
+	fixRDSEncryption = "Enable storage encryption (storageEncrypted) on the instance; it cannot be enabled after creation without a snapshot restore."
+	fixRDSMultiAZ    = "Enable Multi-AZ deployment (multiAz) on the instance."
+	fixRDSBackups    = "Set a backup retention period of at least 7 days (backupRetentionPeriod)."
+	fixRDSPrivate    = "Turn off public accessibility, place the instance in private subnets and only admit application security groups."
+	fixEBSEncryption = "Enable encryption on the volume (encrypted)."
+	fixAdminPorts    = "Remove ingress from 0.0.0.0/0 and ::/0 to ports 22 and 3389; use Session Manager, a bastion host or a VPN range instead."
+	fixIAMWildcard   = "Replace \"Action\": \"*\" on \"Resource\": \"*\" with the actions and resources the principal needs."
+	fixFlowLogs      = "Enable VPC flow logs, delivered to CloudWatch Logs or S3, for every VPC."
+	fixListenerTLS   = "Use HTTPS or TLS listeners with an ACM certificate, or make plain HTTP listeners redirect to HTTPS."
+)
+
+// standards are the built-in control sets, by slug. They cover what can be checked on a
+// design; controls about account settings, such as root account MFA, are left out.
+var standards = map[string]*Standard{
+	"cis-aws-foundations": {
+		Slug:        "cis-aws-foundations",
+		Name:        "CIS AWS Foundations Benchmark",
+		Description: "Resource-level controls of the CIS Amazon Web Services Foundations Benchmark v1.5.",
+		Controls: []Control{
+			{ID: "1.16", Title: "IAM policies that allow full \"*:*\" administrative privileges are not attached", Severity: SeverityHigh, Remediation: fixIAMWildcard, check: iamNoFullAdmin},
+			{ID: "2.1.1", Title: "S3 buckets employ encryption at rest", Severity: SeverityHigh, Remediation: fixS3Encryption, check: s3Encrypted},
+			{ID: "2.1.5", Title: "S3 buckets are configured with Block Public Access", Severity: SeverityHigh, Remediation: fixS3Public, check: s3NotPublic},
+			{ID: "2.2.1", Title: "EBS volume encryption is enabled", Severity: SeverityMedium, Remediation: fixEBSEncryption, check: ebsEncrypted},
+			{ID: "2.3.1", Title: "Encryption is enabled for RDS instances", Severity: SeverityHigh, Remediation: fixRDSEncryption, check: rdsEncrypted},
+			{ID: "2.3.3", Title: "RDS instances are not publicly accessible", Severity: SeverityCritical, Remediation: fixRDSPrivate, check: databasesPrivate},
+			{ID: "3.9", Title: "VPC flow logging is enabled in all VPCs", Severity: SeverityMedium, Remediation: fixFlowLogs, check: vpcFlowLogs},
+			{ID: "5.2", Title: "No security groups allow ingress from 0.0.0.0/0 or ::/0 to remote server administration ports", Severity: SeverityHigh, Remediation: fixAdminPorts, check: noAdminPortsFromWorld},
+		},
+	},
+	"soc-2": {
+		Slug:        "soc-2",
+		Name:        "SOC 2",
+		Description: "Infrastructure controls supporting the SOC 2 Trust Services Criteria for security and availability.",
+		Controls: []Control{
+			{ID: "CC6.1-iam", Title: "Logical access is restricted to least privilege", Severity: SeverityHigh, Remediation: fixIAMWildcard, check: iamNoFullAdmin},
+			{ID: "CC6.1-encryption", Title: "Data at rest is encrypted", Severity: SeverityHigh, Remediation: fixRDSEncryption + " " + fixS3Encryption + " " + fixEBSEncryption, check: all(rdsEncrypted, s3Encrypted, ebsEncrypted)},
+			{ID: "CC6.6-admin", Title: "Remote administration is not open to the internet", Severity: SeverityHigh, Remediation: fixAdminPorts, check: noAdminPortsFromWorld},
+			{ID: "CC6.6-data", Title: "Data stores are not exposed to the internet", Severity: SeverityCritical, Remediation: fixRDSPrivate + " " + fixS3Public, check: all(databasesPrivate, s3NotPublic)},
+			{ID: "CC6.7", Title: "Data in transit is encrypted", Severity: SeverityMedium, Remediation: fixListenerTLS, check: listenersEncrypted},
+			{ID: "CC7.2", Title: "Network activity is logged for monitoring", Severity: SeverityMedium, Remediation: fixFlowLogs, check: vpcFlowLogs},
+			{ID: "A1.2-backups", Title: "Data is backed up and recoverable", Severity: SeverityMedium, Remediation: fixRDSBackups + " " + fixS3Versioning, check: all(rdsBackups, s3Versioned)},
+			{ID: "A1.2-redundancy", Title: "Databases are deployed across availability zones", Severity: SeverityMedium, Remediation: fixRDSMultiAZ, check: rdsMultiAZ},
+		},
+	},
+	"hipaa": {
+		Slug:        "hipaa",
+		Name:        "HIPAA",
+		Description: "Technical safeguards of the HIPAA Security Rule (45 CFR 164.312) and contingency planning (164.308(a)(7)) that apply to infrastructure holding ePHI.",
+		Controls: []Control{
+			{ID: "164.312(a)(1)-iam", Title: "Access to ePHI is limited to authorized principals", Severity: SeverityHigh, Remediation: fixIAMWildcard, check: iamNoFullAdmin},
+			{ID: "164.312(a)(1)-network", Title: "Systems holding ePHI are not reachable from the internet", Severity: SeverityCritical, Remediation: fixRDSPrivate + " " + fixS3Public, check: all(databasesPrivate, s3NotPublic, noAdminPortsFromWorld)},
+			{ID: "164.312(a)(2)(iv)", Title: "ePHI at rest is encrypted", Severity: SeverityHigh, Remediation: fixRDSEncryption + " " + fixS3Encryption + " " + fixEBSEncryption, check: all(rdsEncrypted, s3Encrypted, ebsEncrypted)},
+			{ID: "164.312(b)", Title: "Audit controls record network activity", Severity: SeverityMedium, Remediation: fixFlowLogs, check: vpcFlowLogs},
+			{ID: "164.312(e)(1)", Title: "ePHI in transit is encrypted", Severity: SeverityHigh, Remediation: fixListenerTLS, check: listenersEncrypted},
+			{ID: "164.308(a)(7)", Title: "ePHI is backed up and recoverable", Severity: SeverityMedium, Remediation: fixRDSBackups + " " + fixS3Versioning + " " + fixRDSMultiAZ, check: all(rdsBackups, s3Versioned, rdsMultiAZ)},
+		},
+	},
+}
@@ -0,0 +1,150 @@
+package schema
+
+// RegisterAzureSchemas registers all Azure resource schemas
+func RegisterAzureSchemas(registry *InMemorySchemaRegistry) {
+	// Region schema (special - container only)
+	registry.Register(&ResourceSchema{
+		ResourceType: "region",
+		Provider:     "azure",
+		Category:     "global",
+		Description:  "Azure Region - top-level container for all resources",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Region name (e.g., westeurope)"},
+		},
+		ValidChildTypes: []string{"resource-group", "virtual-network", "network-security-group", "load-balancer", "storage-account", "sql-database"},
+	})
+
+	// Resource Group schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "resource-group",
+		Provider:     "azure",
+		Category:     "networking",
+		Description:  "Resource Group",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Resource group name", Constraints: &FieldConstraint{MaxLength: intPtr(90)}},
+			{Name: "location", Type: FieldTypeString, Required: false, Description: "Location (defaults to the diagram region)"},
+		},
+		ValidParentTypes: []string{"region"},
+		ValidChildTypes:  []string{"virtual-network", "network-security-group", "load-balancer", "storage-account", "sql-database"},
+	})
+
+	// Virtual Network schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "virtual-network",
+		Provider:     "azure",
+		Category:     "networking",
+		Description:  "Virtual Network",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Virtual network name"},
+			{Name: "cidr", Type: FieldTypeCIDR, Required: false, Description: "Address space CIDR block", Constraints: &FieldConstraint{CIDRVersion: strPtr("ipv4")}},
+			{Name: "addressSpace", Type: FieldTypeArray, Required: false, Description: "Address space CIDR blocks", ItemType: fieldTypePtr(FieldTypeCIDR)},
+			{Name: "dnsServers", Type: FieldTypeArray, Required: false, Description: "Custom DNS servers", ItemType: fieldTypePtr(FieldTypeString)},
+		},
+		ValidParentTypes: []string{"region", "resource-group"},
+		ValidChildTypes:  []string{"subnet"},
+	})
+
+	// Subnet schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "subnet",
+		Provider:     "azure",
+		Category:     "networking",
+		Description:  "Virtual Network Subnet",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Subnet name"},
+			{Name: "cidr", Type: FieldTypeCIDR, Required: true, Description: "Subnet address prefix", Constraints: &FieldConstraint{CIDRVersion: strPtr("ipv4")}},
+			{Name: "serviceEndpoints", Type: FieldTypeArray, Required: false, Description: "Service endpoints (e.g., Microsoft.Storage)", ItemType: fieldTypePtr(FieldTypeString)},
+		},
+		ValidParentTypes: []string{"virtual-network"},
+		ValidChildTypes:  []string{"virtual-machine", "load-balancer"},
+	})
+
+	// Network Security Group schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "network-security-group",
+		Provider:     "azure",
+		Category:     "networking",
+		Description:  "Network Security Group",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Network security group name"},
+			{Name: "securityRules", Type: FieldTypeArray, Required: false, Description: "Security rules"},
+		},
+		ValidParentTypes: []string{"region", "resource-group"},
+		ValidChildTypes:  []string{},
+	})
+
+	// Virtual Machine schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "virtual-machine",
+		Provider:     "azure",
+		Category:     "compute",
+		Description:  "Linux Virtual Machine",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Virtual machine name", Constraints: &FieldConstraint{MaxLength: intPtr(64)}},
+			{Name: "size", Type: FieldTypeString, Required: false, Description: "VM size (e.g., Standard_B1s)"},
+			{Name: "adminUsername", Type: FieldTypeString, Required: false, Description: "Administrator username"},
+			{Name: "adminPassword", Type: FieldTypeString, Required: false, Description: "Administrator password (disables SSH key authentication)"},
+			{Name: "sshPublicKey", Type: FieldTypeString, Required: false, Description: "SSH public key"},
+			{Name: "publicIp", Type: FieldTypeBool, Required: false, Description: "Attach a public IP address"},
+			{Name: "osDiskType", Type: FieldTypeString, Required: false, Description: "OS disk storage type", Constraints: &FieldConstraint{Enum: []string{"Standard_LRS", "StandardSSD_LRS", "Premium_LRS"}}},
+		},
+		ValidParentTypes: []string{"subnet"},
+		ValidChildTypes:  []string{},
+	})
+
+	// Load Balancer schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "load-balancer",
+		Provider:     "azure",
+		Category:     "compute",
+		Description:  "Load Balancer",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Load balancer name"},
+			{Name: "sku", Type: FieldTypeString, Required: false, Description: "Load balancer SKU", Constraints: &FieldConstraint{Enum: []string{"Basic", "Standard"}}},
+			{Name: "internal", Type: FieldTypeBool, Required: false, Description: "Use a private frontend in the parent subnet"},
+			{Name: "rules", Type: FieldTypeArray, Required: false, Description: "Load balancing rules"},
+		},
+		ValidParentTypes: []string{"region", "resource-group", "subnet"},
+		ValidChildTypes:  []string{},
+	})
+
+	// Storage Account schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "storage-account",
+		Provider:     "azure",
+		Category:     "storage",
+		Description:  "Storage Account",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Storage account name"},
+			{Name: "accountTier", Type: FieldTypeString, Required: false, Description: "Account tier", Constraints: &FieldConstraint{Enum: []string{"Standard", "Premium"}}},
+			{Name: "replicationType", Type: FieldTypeString, Required: false, Description: "Replication type", Constraints: &FieldConstraint{Enum: []string{"LRS", "GRS", "RAGRS", "ZRS", "GZRS", "RAGZRS"}}},
+			{Name: "containers", Type: FieldTypeArray, Required: false, Description: "Blob container names", ItemType: fieldTypePtr(FieldTypeString)},
+		},
+		ValidParentTypes: []string{"region", "resource-group"},
+		ValidChildTypes:  []string{},
+	})
+
+	// SQL Database schema
+	registry.Register(&ResourceSchema{
+		ResourceType: "sql-database",
+		Provider:     "azure",
+		Category:     "database",
+		Description:  "Azure SQL Database",
+		Fields: []FieldSpec{
+			{Name: "name", Type: FieldTypeString, Required: true, Description: "Database name"},
+			{Name: "serverName", Type: FieldTypeString, Required: false, Description: "Logical server name", Constraints: &FieldConstraint{MaxLength: intPtr(63)}},
+			{Name: "administratorLogin", Type: FieldTypeString, Required: false, Description: "Server administrator login"},
+			{Name: "administratorPassword", Type: FieldTypeString, Required: true, Description: "Server administrator password"},
+			{Name: "skuName", Type: FieldTypeString, Required: false, Description: "Database SKU (e.g., Basic, S0)"},
+			{Name: "maxSizeGb", Type: FieldTypeInt, Required: false, Description: "Maximum size in GB", Constraints: &FieldConstraint{MinValue: floatPtr(1)}},
+			{Name: "allowAzureServices", Type: FieldTypeBool, Required: false, Description: "Allow access from Azure services"},
+		},
+		ValidParentTypes: []string{"region", "resource-group"},
+		ValidChildTypes:  []string{},
+	})
+}
+
+// init registers Azure schemas on package load
+func init() {
+	RegisterAzureSchemas(DefaultRegistry)
+}
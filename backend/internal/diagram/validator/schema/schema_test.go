@@ -156,6 +156,28 @@ func TestAWSSchemaFields(t *testing.T) {
 	}
 }
 
+func TestDefaultRegistryHasAzureSchemas(t *testing.T) {
+	azureResources := []string{
+		"resource-group", "virtual-network", "subnet", "network-security-group",
+		"virtual-machine", "load-balancer", "storage-account", "sql-database",
+	}
+
+	for _, rt := range azureResources {
+		if !DefaultRegistry.Has(rt, "azure") {
+			t.Errorf("Default registry should have Azure schema for '%s'", rt)
+		}
+	}
+
+	// Azure subnets are not tied to an availability zone
+	subnetSchema, _ := DefaultRegistry.Get("subnet", "azure")
+	if subnetSchema.GetField("availabilityZoneId") != nil {
+		t.Error("Azure subnet should not have an 'availabilityZoneId' field")
+	}
+	if len(subnetSchema.ValidParentTypes) != 1 || subnetSchema.ValidParentTypes[0] != "virtual-network" {
+		t.Errorf("Azure subnet should only be contained in 'virtual-network', got %v", subnetSchema.ValidParentTypes)
+	}
+}
+
 func TestSchemaRegistration(t *testing.T) {
 	registry := NewSchemaRegistry()
 
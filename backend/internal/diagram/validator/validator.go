@@ -568,17 +568,17 @@ func validateContainmentTypes(g *graph.DiagramGraph, result *ValidationResult, o
 	}
 }
 
-// validateCIDRs checks CIDR validity and overlaps (VPC/Subnet focused).
+// validateCIDRs checks CIDR validity and overlaps (VPC or virtual network/Subnet focused).
 func validateCIDRs(g *graph.DiagramGraph, result *ValidationResult) {
 	// Track VPC CIDRs by vpc node id
 	vpcCIDR := make(map[string]*net.IPNet)
 
-	// Parse VPC CIDRs
+	// Parse VPC and virtual network CIDRs
 	for _, node := range g.Nodes {
 		if node.IsVisualOnly {
 			continue
 		}
-		if strings.ToLower(node.ResourceType) != "vpc" {
+		if !isNetworkType(node.ResourceType) {
 			continue
 		}
 		cidrStr, ok := node.Config["cidr"].(string)
@@ -628,7 +628,7 @@ func validateCIDRs(g *graph.DiagramGraph, result *ValidationResult) {
 		// Associate subnet with its direct parent if it is a VPC (common pattern)
 		parentVPC := ""
 		if node.ParentID != nil {
-			if p, ok := g.Nodes[*node.ParentID]; ok && strings.ToLower(p.ResourceType) == "vpc" {
+			if p, ok := g.Nodes[*node.ParentID]; ok && isNetworkType(p.ResourceType) {
 				parentVPC = p.ID
 			}
 		}
@@ -669,6 +669,16 @@ func validateCIDRs(g *graph.DiagramGraph, result *ValidationResult) {
 	}
 }
 
+// isNetworkType reports whether a resource type is a network that contains subnets,
+// an AWS VPC or an Azure virtual network
+func isNetworkType(resourceType string) bool {
+	switch strings.ToLower(resourceType) {
+	case "vpc", "virtual-network":
+		return true
+	}
+	return false
+}
+
 func cidrOverlaps(a, b *net.IPNet) bool {
 	if a == nil || b == nil {
 		return false
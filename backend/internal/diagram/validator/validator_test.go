@@ -45,6 +45,58 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateAzureVirtualNetwork(t *testing.T) {
+	g := &graph.DiagramGraph{
+		Nodes: make(map[string]*graph.Node),
+		Edges: make([]*graph.Edge, 0),
+	}
+
+	g.Nodes["region-1"] = &graph.Node{
+		ID:           "region-1",
+		Type:         "containerNode",
+		ResourceType: "region",
+		Label:        "Region",
+		Config:       map[string]interface{}{"name": "westeurope"},
+	}
+	g.Nodes["vnet-1"] = &graph.Node{
+		ID:           "vnet-1",
+		Type:         "containerNode",
+		ResourceType: "virtual-network",
+		Label:        "VNet",
+		Config:       map[string]interface{}{"name": "app-vnet", "cidr": "10.0.0.0/16"},
+		ParentID:     stringPtr("region-1"),
+	}
+	// Azure subnets carry no availability zone
+	g.Nodes["subnet-1"] = &graph.Node{
+		ID:           "subnet-1",
+		Type:         "containerNode",
+		ResourceType: "subnet",
+		Label:        "Subnet",
+		Config:       map[string]interface{}{"name": "web", "cidr": "10.0.1.0/24"},
+		ParentID:     stringPtr("vnet-1"),
+	}
+
+	opts := &ValidationOptions{Provider: "azure"}
+	result := Validate(g, opts)
+	if !result.Valid {
+		t.Errorf("Expected valid graph, but got errors: %v", result.Errors)
+	}
+
+	// Subnet CIDRs are checked against their virtual network like they are against a VPC
+	g.Nodes["subnet-1"].Config["cidr"] = "10.1.0.0/24"
+	result = Validate(g, opts)
+	found := false
+	for _, err := range result.Errors {
+		if err.Code == "CIDR_OUTSIDE_VPC" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("Expected CIDR_OUTSIDE_VPC error, got %v", result.Errors)
+	}
+}
+
 func TestValidateMissingParent(t *testing.T) {
 	g := &graph.DiagramGraph{
 		Nodes: make(map[string]*graph.Node),
@@ -0,0 +1,82 @@
+package iac
+
+import "fmt"
+
+// Supported state backend types.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendHTTP  = "http"
+)
+
+// StateBackend describes where generated IaC keeps its state.
+// Exactly the settings block matching Type is used.
+type StateBackend struct {
+	Type  string        `json:"type"`
+	S3    *S3Backend    `json:"s3,omitempty"`
+	Local *LocalBackend `json:"local,omitempty"`
+	HTTP  *HTTPBackend  `json:"http,omitempty"`
+
+	// Bootstrap also generates a separate configuration that creates the resources the
+	// backend relies on (e.g. the S3 state bucket and DynamoDB lock table).
+	Bootstrap bool `json:"bootstrap,omitempty"`
+}
+
+// S3Backend stores state in an S3 bucket with optional DynamoDB locking.
+type S3Backend struct {
+	Bucket             string `json:"bucket"`
+	Key                string `json:"key"`
+	Region             string `json:"region,omitempty"` // defaults to the project region
+	DynamoDBTable      string `json:"dynamodb_table,omitempty"`
+	Encrypt            bool   `json:"encrypt"`
+	WorkspaceKeyPrefix string `json:"workspace_key_prefix,omitempty"`
+}
+
+// LocalBackend stores state on the local filesystem.
+type LocalBackend struct {
+	Path string `json:"path,omitempty"`
+}
+
+// HTTPBackend stores state behind a REST endpoint. Credentials are not stored;
+// they are supplied at init time (e.g. TF_HTTP_PASSWORD).
+type HTTPBackend struct {
+	Address       string `json:"address"`
+	LockAddress   string `json:"lock_address,omitempty"`
+	UnlockAddress string `json:"unlock_address,omitempty"`
+	LockMethod    string `json:"lock_method,omitempty"`
+	UnlockMethod  string `json:"unlock_method,omitempty"`
+	Username      string `json:"username,omitempty"`
+}
+
+// Validate checks that the settings for the selected backend type are complete.
+func (b *StateBackend) Validate() error {
+	if b == nil {
+		return fmt.Errorf("state backend is nil")
+	}
+	switch b.Type {
+	case BackendS3:
+		if b.S3 == nil {
+			return fmt.Errorf("s3 backend settings are required")
+		}
+		if b.S3.Bucket == "" {
+			return fmt.Errorf("s3 backend bucket is required")
+		}
+		if b.S3.Key == "" {
+			return fmt.Errorf("s3 backend key is required")
+		}
+	case BackendHTTP:
+		if b.HTTP == nil || b.HTTP.Address == "" {
+			return fmt.Errorf("http backend address is required")
+		}
+		if b.Bootstrap {
+			return fmt.Errorf("bootstrap is not supported for the http backend")
+		}
+	case BackendLocal:
+		if b.Bootstrap {
+			return fmt.Errorf("bootstrap is not supported for the local backend")
+		}
+	default:
+		return fmt.Errorf("unsupported state backend type %q (supported: %s, %s, %s)", b.Type, BackendS3, BackendLocal, BackendHTTP)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package iac
+
+import "testing"
+
+func TestStateBackend_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend *StateBackend
+		wantErr bool
+	}{
+		{name: "nil", backend: nil, wantErr: true},
+		{name: "unknown type", backend: &StateBackend{Type: "gcs"}, wantErr: true},
+		{name: "local", backend: &StateBackend{Type: BackendLocal}},
+		{name: "local bootstrap", backend: &StateBackend{Type: BackendLocal, Bootstrap: true}, wantErr: true},
+		{name: "s3", backend: &StateBackend{Type: BackendS3, S3: &S3Backend{Bucket: "state", Key: "app.tfstate"}}},
+		{name: "s3 missing settings", backend: &StateBackend{Type: BackendS3}, wantErr: true},
+		{name: "s3 missing bucket", backend: &StateBackend{Type: BackendS3, S3: &S3Backend{Key: "app.tfstate"}}, wantErr: true},
+		{name: "s3 missing key", backend: &StateBackend{Type: BackendS3, S3: &S3Backend{Bucket: "state"}}, wantErr: true},
+		{name: "http", backend: &StateBackend{Type: BackendHTTP, HTTP: &HTTPBackend{Address: "https://state.example.com"}}},
+		{name: "http missing address", backend: &StateBackend{Type: BackendHTTP, HTTP: &HTTPBackend{}}, wantErr: true},
+		{name: "http bootstrap", backend: &StateBackend{Type: BackendHTTP, HTTP: &HTTPBackend{Address: "https://state.example.com"}, Bootstrap: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.backend.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -31,5 +31,17 @@ type Engine interface {
 
 	// Generate compiles a validated architecture into IaC files.
 	// The caller is responsible for ensuring validations/rules have passed.
-	Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource) (*Output, error)
+	Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource, opts Options) (*Output, error)
+}
+
+// Options are per-request generation settings. Engines return an error for options
+// they do not support rather than silently ignoring them.
+type Options struct {
+	// Modules groups each network subtree (e.g. an AWS VPC and everything inside it)
+	// into a reusable local module under modules/ instead of writing it at the root.
+	Modules bool
+
+	// Backend configures where the generated code keeps its state. Nil keeps the
+	// engine's default (local state for Terraform).
+	Backend *StateBackend
 }
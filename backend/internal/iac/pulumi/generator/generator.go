@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	pmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/writer"
+	tfgen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+const (
+	defaultProject = "arch-visualizer"
+	defaultStack   = "dev"
+)
+
+// Engine generates a Pulumi TypeScript project.
+//
+// Pulumi's cloud providers are bridged from the Terraform providers, so the engine
+// reuses the Terraform mapping pipeline (enrichment, reference resolution, per-resource
+// mappers) and translates the resulting blocks with a provider-specific Pulumi mapper.
+type Engine struct {
+	terraform *tfgen.Engine
+	mappers   *pmapper.MapperRegistry
+}
+
+func NewEngine(terraform *tfgen.Engine, mappers *pmapper.MapperRegistry) *Engine {
+	return &Engine{terraform: terraform, mappers: mappers}
+}
+
+func (e *Engine) Name() string {
+	return "pulumi"
+}
+
+func (e *Engine) Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource, opts iac.Options) (*iac.Output, error) {
+	if arch == nil {
+		return nil, fmt.Errorf("architecture is nil")
+	}
+	if e.terraform == nil {
+		return nil, fmt.Errorf("terraform engine is nil")
+	}
+	if e.mappers == nil {
+		return nil, fmt.Errorf("pulumi mapper registry is nil")
+	}
+	if opts.Modules {
+		return nil, fmt.Errorf("pulumi engine does not support modules")
+	}
+	if opts.Backend != nil {
+		return nil, fmt.Errorf("pulumi engine does not support terraform state backends")
+	}
+
+	provider := string(arch.Provider)
+	mapper, ok := e.mappers.Get(provider)
+	if !ok {
+		return nil, fmt.Errorf("no pulumi mapper registered for provider %q", provider)
+	}
+
+	blocks, err := e.terraform.MapBlocks(ctx, arch, sortedResources)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTranslator(mapper)
+	configs, err := t.declareConfigs(arch.Variables)
+	if err != nil {
+		return nil, err
+	}
+	t.declareResources(blocks)
+
+	program := pmapper.Program{
+		Project:     defaultProject,
+		Description: "Infrastructure generated by arch-visualizer",
+		Stack:       defaultStack,
+		Region:      arch.Region,
+		Package:     mapper.Package(),
+		Configs:     configs,
+	}
+
+	for _, b := range blocks {
+		// The provider block only carries the region, which goes to stack config.
+		if b.Kind != "resource" {
+			continue
+		}
+		res, err := t.translateBlock(b)
+		if err != nil {
+			return nil, err
+		}
+		program.Resources = append(program.Resources, res)
+	}
+	program.Outputs, err = t.outputs(arch.Outputs, arch.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	indexTS, err := writer.RenderIndexTS(program)
+	if err != nil {
+		return nil, fmt.Errorf("render index.ts: %w", err)
+	}
+	projectYAML, err := writer.RenderPulumiYAML(program)
+	if err != nil {
+		return nil, fmt.Errorf("render Pulumi.yaml: %w", err)
+	}
+	packageJSON, err := writer.RenderPackageJSON(program)
+	if err != nil {
+		return nil, fmt.Errorf("render package.json: %w", err)
+	}
+
+	out := &iac.Output{
+		Files: []iac.GeneratedFile{
+			{Path: "Pulumi.yaml", Content: projectYAML, Type: "yaml"},
+			{Path: "index.ts", Content: indexTS, Type: "typescript"},
+			{Path: "package.json", Content: packageJSON, Type: "json"},
+			{Path: "tsconfig.json", Content: writer.RenderTSConfig(), Type: "json"},
+		},
+	}
+
+	stackYAML, err := writer.RenderStackYAML(program)
+	if err != nil {
+		return nil, fmt.Errorf("render stack config: %w", err)
+	}
+	if stackYAML != "" {
+		out.Files = append(out.Files, iac.GeneratedFile{
+			Path:    fmt.Sprintf("Pulumi.%s.yaml", program.Stack),
+			Content: stackYAML,
+			Type:    "yaml",
+		})
+	}
+
+	return out, nil
+}
+
+var _ iac.Engine = (*Engine)(nil)
@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	pmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+	tfgen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+type fakeTFMapper struct{}
+
+func (m *fakeTFMapper) Provider() string { return "aws" }
+
+func (m *fakeTFMapper) SupportsResource(resourceType string) bool { return true }
+
+func (m *fakeTFMapper) MapResource(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	name := strings.ReplaceAll(res.Name, "-", "_")
+	block := tfmapper.TerraformBlock{
+		Kind:       "resource",
+		Labels:     []string{"aws_test", name},
+		Attributes: map[string]tfmapper.TerraformValue{},
+	}
+	if res.ParentID != nil {
+		ref := tfmapper.TerraformExpr("aws_test.parent.id")
+		block.Attributes["parent_id"] = tfmapper.TerraformValue{Expr: &ref}
+		block.Attributes["depends_on"] = tfmapper.TerraformValue{List: []tfmapper.TerraformValue{{Expr: &ref}}}
+	} else {
+		v := tfmapper.TerraformExpr("var.size")
+		block.Attributes["size_gb"] = tfmapper.TerraformValue{Expr: &v}
+	}
+	return []tfmapper.TerraformBlock{block}, nil
+}
+
+type fakePulumiMapper struct {
+	specs map[string]pmapper.ResourceSpec
+}
+
+func (m *fakePulumiMapper) Provider() string { return "aws" }
+
+func (m *fakePulumiMapper) Package() pmapper.Package {
+	return pmapper.Package{Name: "@pulumi/aws", Alias: "aws", Version: "^6.0.0", RegionKey: "aws:region"}
+}
+
+func (m *fakePulumiMapper) Spec(terraformType string) (pmapper.ResourceSpec, bool) {
+	spec, ok := m.specs[terraformType]
+	return spec, ok
+}
+
+func newTestEngine(t *testing.T, specs map[string]pmapper.ResourceSpec) *Engine {
+	t.Helper()
+	tfReg := tfmapper.NewRegistry()
+	if err := tfReg.Register(&fakeTFMapper{}); err != nil {
+		t.Fatalf("register terraform mapper: %v", err)
+	}
+	pReg := pmapper.NewRegistry()
+	if err := pReg.Register(&fakePulumiMapper{specs: specs}); err != nil {
+		t.Fatalf("register pulumi mapper: %v", err)
+	}
+	return NewEngine(tfgen.NewEngine(tfReg), pReg)
+}
+
+func TestEngine_Name(t *testing.T) {
+	e := NewEngine(tfgen.NewEngine(tfmapper.NewRegistry()), pmapper.NewRegistry())
+	if got := e.Name(); got != "pulumi" {
+		t.Fatalf("Name() = %q, want %q", got, "pulumi")
+	}
+}
+
+func TestEngine_Generate_ErrorsOnNilArchitecture(t *testing.T) {
+	e := NewEngine(tfgen.NewEngine(tfmapper.NewRegistry()), pmapper.NewRegistry())
+	if _, err := e.Generate(context.Background(), nil, nil, iac.Options{}); err == nil {
+		t.Fatalf("Generate() with nil architecture expected error, got nil")
+	}
+}
+
+func TestEngine_Generate_ErrorsOnMissingMapper(t *testing.T) {
+	e := NewEngine(tfgen.NewEngine(tfmapper.NewRegistry()), pmapper.NewRegistry())
+	arch := &architecture.Architecture{Provider: resource.AWS}
+	if _, err := e.Generate(context.Background(), arch, []*resource.Resource{}, iac.Options{}); err == nil {
+		t.Fatalf("Generate() expected error when no pulumi mapper is registered, got nil")
+	}
+}
+
+func TestEngine_Generate_ErrorsOnUnsupportedTerraformType(t *testing.T) {
+	e := newTestEngine(t, map[string]pmapper.ResourceSpec{})
+	res := &resource.Resource{ID: "r1", Name: "parent", Type: resource.ResourceType{Name: "Test"}, Provider: resource.AWS}
+	arch := &architecture.Architecture{Provider: resource.AWS, Resources: []*resource.Resource{res}}
+
+	if _, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{}); err == nil {
+		t.Fatalf("Generate() expected error for unsupported terraform type, got nil")
+	}
+}
+
+func TestEngine_Generate_RejectsModules(t *testing.T) {
+	e := newTestEngine(t, map[string]pmapper.ResourceSpec{"aws_test": {Class: "aws.test.Thing"}})
+	arch := &architecture.Architecture{Provider: resource.AWS}
+	if _, err := e.Generate(context.Background(), arch, nil, iac.Options{Modules: true}); err == nil {
+		t.Fatalf("Generate() expected error for unsupported modules option, got nil")
+	}
+}
+
+func TestEngine_Generate_TranslatesReferencesAndConfig(t *testing.T) {
+	e := newTestEngine(t, map[string]pmapper.ResourceSpec{
+		"aws_test": {Class: "aws.test.Thing"},
+	})
+
+	parentID := "r1"
+	parent := &resource.Resource{ID: parentID, Name: "parent", Type: resource.ResourceType{Name: "Test"}, Provider: resource.AWS}
+	child := &resource.Resource{ID: "r2", Name: "child", Type: resource.ResourceType{Name: "Test"}, Provider: resource.AWS, ParentID: &parentID}
+
+	arch := &architecture.Architecture{
+		Provider:  resource.AWS,
+		Region:    "us-west-2",
+		Resources: []*resource.Resource{parent, child},
+		Variables: []architecture.Variable{{Name: "size", Type: "number", Default: 20}},
+		Outputs:   []architecture.Output{{Name: "parent-id", Value: "aws_test.parent.id"}},
+	}
+
+	out, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range out.Files {
+		files[f.Path] = f.Content
+	}
+	for _, path := range []string{"Pulumi.yaml", "index.ts", "package.json", "tsconfig.json", "Pulumi.dev.yaml"} {
+		if _, ok := files[path]; !ok {
+			t.Fatalf("Generate() missing file %q", path)
+		}
+	}
+
+	index := files["index.ts"]
+	for _, want := range []string{
+		`const size = config.getNumber("size") ?? 20;`,
+		`const parent = new aws.test.Thing("parent", {`,
+		`sizeGb: size,`,
+		`parentId: parent.id,`,
+		`}, { dependsOn: [parent] });`,
+		`export const parent_id = parent.id;`,
+	} {
+		if !strings.Contains(index, want) {
+			t.Fatalf("index.ts missing %q in:\n%s", want, index)
+		}
+	}
+	if strings.Contains(index, "provider") {
+		t.Fatalf("index.ts should not contain the terraform provider block:\n%s", index)
+	}
+}
+
+func TestEngine_Generate_ErrorsOnUnresolvedReferences(t *testing.T) {
+	e := newTestEngine(t, map[string]pmapper.ResourceSpec{
+		"aws_test": {Class: "aws.test.Thing"},
+	})
+	parent := &resource.Resource{ID: "r1", Name: "parent", Type: resource.ResourceType{Name: "Test"}, Provider: resource.AWS}
+
+	tests := []struct {
+		name string
+		arch *architecture.Architecture
+		want string
+	}{
+		{
+			name: "undeclared variable",
+			arch: &architecture.Architecture{Provider: resource.AWS, Resources: []*resource.Resource{parent}},
+			want: `"var.size"`,
+		},
+		{
+			name: "unknown resource in output",
+			arch: &architecture.Architecture{
+				Provider:  resource.AWS,
+				Resources: []*resource.Resource{parent},
+				Variables: []architecture.Variable{{Name: "size", Type: "number"}},
+				Outputs:   []architecture.Output{{Name: "missing", Value: "aws_test.missing.id"}},
+			},
+			want: `"aws_test.missing.id"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.Generate(context.Background(), tt.arch, tt.arch.Resources, iac.Options{})
+			if err == nil {
+				t.Fatalf("Generate() expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Generate() error = %q, want it to name %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,455 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	pmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/writer"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+var (
+	nonIdentChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+	tfNameChars   = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+	// tfAddress matches a reference to a resource address with optional attribute path,
+	// e.g. "aws_vpc.main" or "aws_vpc.main.id".
+	tfAddress = regexp.MustCompile(`^([a-z][a-z0-9]*_[a-z0-9_]+)\.([A-Za-z_][A-Za-z0-9_-]*)((?:\.[A-Za-z_][A-Za-z0-9_]*)*)$`)
+)
+
+// reservedIdents cannot be used as TypeScript variable names in the generated program.
+var reservedIdents = map[string]bool{
+	"pulumi": true, "config": true, "break": true, "case": true, "catch": true, "class": true,
+	"const": true, "continue": true, "debugger": true, "default": true, "delete": true, "do": true,
+	"else": true, "enum": true, "export": true, "extends": true, "false": true, "finally": true,
+	"for": true, "function": true, "if": true, "import": true, "in": true, "instanceof": true,
+	"new": true, "null": true, "return": true, "super": true, "switch": true, "this": true,
+	"throw": true, "true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "let": true, "static": true, "yield": true, "await": true,
+	"interface": true, "package": true, "private": true, "protected": true, "public": true,
+	"implements": true, "undefined": true,
+}
+
+// translator converts Terraform blocks produced by the Terraform mapping pipeline
+// into the Pulumi program IR. It owns the symbol table used to resolve references.
+type translator struct {
+	mapper pmapper.ResourceMapper
+
+	idents  map[string]bool   // used TypeScript identifiers
+	symbols map[string]string // terraform address ("aws_vpc.main") -> ident
+	configs map[string]string // variable name -> ident
+}
+
+func newTranslator(m pmapper.ResourceMapper) *translator {
+	t := &translator{
+		mapper:  m,
+		idents:  make(map[string]bool),
+		symbols: make(map[string]string),
+		configs: make(map[string]string),
+	}
+	t.idents[m.Package().Alias] = true
+	return t
+}
+
+// declareConfigs converts architecture variables into stack configuration reads.
+func (t *translator) declareConfigs(vars []architecture.Variable) ([]pmapper.Config, error) {
+	configs := make([]pmapper.Config, 0, len(vars))
+	for _, v := range vars {
+		if v.Name == "" {
+			return nil, fmt.Errorf("variable name is empty")
+		}
+		ident := t.allocIdent(camelCase(v.Name), "Config")
+		t.configs[v.Name] = ident
+
+		cfg := pmapper.Config{
+			Key:         v.Name,
+			Ident:       ident,
+			Type:        configType(v.Type),
+			Description: v.Description,
+			Secret:      v.Sensitive,
+		}
+		if v.Default != nil {
+			def := goValueToValue(v.Default)
+			cfg.Default = &def
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// declareResources registers an identifier for every resource block up front so
+// references can be resolved regardless of block order.
+func (t *translator) declareResources(blocks []tfmapper.TerraformBlock) {
+	for _, b := range blocks {
+		if b.Kind != "resource" || len(b.Labels) != 2 {
+			continue
+		}
+		addr := b.Labels[0] + "." + b.Labels[1]
+		if _, exists := t.symbols[addr]; exists {
+			continue
+		}
+		t.symbols[addr] = t.allocIdent(camelCase(b.Labels[1]), pascalCase(trimProviderPrefix(b.Labels[0])))
+	}
+}
+
+// translateBlock converts a Terraform resource block into a Pulumi resource.
+func (t *translator) translateBlock(b tfmapper.TerraformBlock) (pmapper.Resource, error) {
+	if len(b.Labels) != 2 {
+		return pmapper.Resource{}, fmt.Errorf("resource block %v: expected 2 labels", b.Labels)
+	}
+	tfType, name := b.Labels[0], b.Labels[1]
+	spec, ok := t.mapper.Spec(tfType)
+	if !ok {
+		return pmapper.Resource{}, fmt.Errorf("pulumi mapper for %q does not support terraform type %q", t.mapper.Provider(), tfType)
+	}
+
+	res := pmapper.Resource{
+		Ident:       t.symbols[tfType+"."+name],
+		LogicalName: name,
+		Class:       spec.Class,
+		Args:        make(map[string]pmapper.Value),
+	}
+
+	for key, val := range b.Attributes {
+		if key == "depends_on" {
+			res.DependsOn = t.dependsOn(val)
+			continue
+		}
+		prop := propertyName(spec, key)
+		if spec.Archives[key] && val.String != nil {
+			expr := pmapper.Expr(fmt.Sprintf("new pulumi.asset.FileArchive(%s)", writer.Quote(*val.String)))
+			res.Args[prop] = pmapper.Value{Expr: &expr}
+			continue
+		}
+		converted, err := t.value(val, verbatimMap(spec, key))
+		if err != nil {
+			return pmapper.Resource{}, fmt.Errorf("%s.%s attribute %q: %w", tfType, name, key, err)
+		}
+		res.Args[prop] = converted
+	}
+
+	nested, err := t.nestedBlocks(spec, b.NestedBlocks)
+	if err != nil {
+		return pmapper.Resource{}, fmt.Errorf("%s.%s: %w", tfType, name, err)
+	}
+	for k, v := range nested {
+		res.Args[k] = v
+	}
+
+	return res, nil
+}
+
+// nestedBlocks converts Terraform nested blocks into list (or single object) properties.
+func (t *translator) nestedBlocks(spec pmapper.ResourceSpec, blocks map[string][]tfmapper.NestedBlock) (map[string]pmapper.Value, error) {
+	out := make(map[string]pmapper.Value)
+	for blockType, list := range blocks {
+		if len(list) == 0 {
+			continue
+		}
+		items := make([]pmapper.Value, 0, len(list))
+		for _, nb := range list {
+			obj := make(map[string]pmapper.Value)
+			for key, val := range nb.Attributes {
+				converted, err := t.value(val, verbatimMap(spec, key))
+				if err != nil {
+					return nil, fmt.Errorf("nested block %s attribute %q: %w", blockType, key, err)
+				}
+				obj[propertyName(spec, key)] = converted
+			}
+			children, err := t.nestedBlocks(spec, nb.NestedBlocks)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range children {
+				obj[k] = v
+			}
+			items = append(items, pmapper.Value{Map: obj})
+		}
+
+		prop := propertyName(spec, blockType)
+		if spec.SingleBlocks[blockType] {
+			out[prop] = items[0]
+		} else {
+			out[prop] = pmapper.Value{List: items}
+		}
+	}
+	return out, nil
+}
+
+// value converts a TerraformValue. Map keys are camel-cased unless the map holds user data.
+func (t *translator) value(v tfmapper.TerraformValue, verbatim bool) (pmapper.Value, error) {
+	switch {
+	case v.Expr != nil:
+		translated, err := t.expr(string(*v.Expr))
+		if err != nil {
+			return pmapper.Value{}, err
+		}
+		expr := pmapper.Expr(translated)
+		return pmapper.Value{Expr: &expr}, nil
+	case v.String != nil:
+		s := *v.String
+		return pmapper.Value{String: &s}, nil
+	case v.Number != nil:
+		n := *v.Number
+		return pmapper.Value{Number: &n}, nil
+	case v.Bool != nil:
+		b := *v.Bool
+		return pmapper.Value{Bool: &b}, nil
+	case v.Map != nil:
+		m := make(map[string]pmapper.Value, len(v.Map))
+		for k, item := range v.Map {
+			converted, err := t.value(item, verbatim)
+			if err != nil {
+				return pmapper.Value{}, err
+			}
+			if !verbatim {
+				k = camelCase(k)
+			}
+			m[k] = converted
+		}
+		return pmapper.Value{Map: m}, nil
+	case v.List != nil:
+		list := make([]pmapper.Value, 0, len(v.List))
+		for _, item := range v.List {
+			converted, err := t.value(item, verbatim)
+			if err != nil {
+				return pmapper.Value{}, err
+			}
+			list = append(list, converted)
+		}
+		return pmapper.Value{List: list}, nil
+	default:
+		return pmapper.Value{}, fmt.Errorf("empty TerraformValue")
+	}
+}
+
+// expr translates a Terraform expression into TypeScript:
+//   - var.name               -> config ident
+//   - aws_vpc.main.id        -> mainVpc.id
+//   - jsonencode(<json>)     -> JSON.stringify(<json>)
+//
+// Expressions that cannot be resolved are an error: emitting them as string
+// literals would compile but deploy the wrong values.
+func (t *translator) expr(e string) (string, error) {
+	e = strings.TrimSpace(e)
+
+	if strings.HasPrefix(e, "var.") {
+		if ident, ok := t.configs[strings.TrimPrefix(e, "var.")]; ok {
+			return ident, nil
+		}
+		return "", fmt.Errorf("expression %q references an undeclared variable", e)
+	}
+
+	if strings.HasPrefix(e, "jsonencode(") && strings.HasSuffix(e, ")") {
+		return "JSON.stringify(" + strings.TrimSuffix(strings.TrimPrefix(e, "jsonencode("), ")") + ")", nil
+	}
+
+	if m := tfAddress.FindStringSubmatch(e); m != nil {
+		ident, ok := t.symbols[m[1]+"."+m[2]]
+		if !ok {
+			return "", fmt.Errorf("expression %q references unknown resource %s.%s", e, m[1], m[2])
+		}
+		out := ident
+		for _, attr := range strings.Split(strings.TrimPrefix(m[3], "."), ".") {
+			if attr != "" {
+				out += "." + camelCase(attr)
+			}
+		}
+		return out, nil
+	}
+
+	return "", fmt.Errorf("unsupported expression %q", e)
+}
+
+// dependsOn resolves a Terraform depends_on list into resource idents.
+func (t *translator) dependsOn(v tfmapper.TerraformValue) []string {
+	var deps []string
+	seen := make(map[string]bool)
+	for _, item := range v.List {
+		if item.Expr == nil {
+			continue
+		}
+		m := tfAddress.FindStringSubmatch(string(*item.Expr))
+		if m == nil {
+			continue
+		}
+		if ident, ok := t.symbols[m[1]+"."+m[2]]; ok && !seen[ident] {
+			seen[ident] = true
+			deps = append(deps, ident)
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// outputs converts architecture outputs into stack exports. Output values use the same
+// Terraform reference syntax as outputs.tf; resource IDs are resolved to block names.
+func (t *translator) outputs(archOutputs []architecture.Output, resources []*resource.Resource) ([]pmapper.Output, error) {
+	idToName := make(map[string]string, len(resources))
+	for _, res := range resources {
+		if res.Name != "" {
+			idToName[res.ID] = tfBlockName(res.Name)
+		}
+	}
+
+	outputs := make([]pmapper.Output, 0, len(archOutputs))
+	for _, o := range archOutputs {
+		value := o.Value
+		parts := strings.Split(value, ".")
+		if len(parts) >= 2 {
+			if name, ok := idToName[parts[1]]; ok {
+				if _, known := t.symbols[parts[0]+"."+parts[1]]; !known {
+					parts[1] = name
+					value = strings.Join(parts, ".")
+				}
+			}
+		}
+		expr, err := t.expr(value)
+		if err != nil {
+			return nil, fmt.Errorf("output %q: %w", o.Name, err)
+		}
+		outputs = append(outputs, pmapper.Output{
+			Name:        exportName(o.Name),
+			Value:       pmapper.Expr(expr),
+			Description: o.Description,
+			Secret:      o.Sensitive,
+		})
+	}
+	return outputs, nil
+}
+
+// allocIdent reserves a unique TypeScript identifier. On collision the suffix
+// (usually derived from the resource type) is appended, then a counter.
+func (t *translator) allocIdent(base, suffix string) string {
+	if base == "" {
+		base = "resource"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "r" + base
+	}
+	candidate := base
+	if t.idents[candidate] || reservedIdents[candidate] {
+		candidate = base + suffix
+	}
+	for i := 2; t.idents[candidate] || reservedIdents[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%s%d", base, suffix, i)
+	}
+	t.idents[candidate] = true
+	return candidate
+}
+
+func propertyName(spec pmapper.ResourceSpec, key string) string {
+	if renamed, ok := spec.Renames[key]; ok {
+		return renamed
+	}
+	return camelCase(key)
+}
+
+func verbatimMap(spec pmapper.ResourceSpec, key string) bool {
+	return key == "tags" || spec.VerbatimMaps[key]
+}
+
+// configType maps a Terraform variable type onto a pulumi.Config getter family.
+func configType(tfType string) string {
+	switch strings.TrimSpace(tfType) {
+	case "", "string":
+		return "string"
+	case "number":
+		return "number"
+	case "bool":
+		return "bool"
+	default:
+		return "object"
+	}
+}
+
+// goValueToValue converts a decoded JSON value (variable default) into a Value.
+func goValueToValue(val interface{}) pmapper.Value {
+	switch v := val.(type) {
+	case string:
+		return pmapper.Value{String: &v}
+	case float64:
+		return pmapper.Value{Number: &v}
+	case int:
+		f := float64(v)
+		return pmapper.Value{Number: &f}
+	case bool:
+		return pmapper.Value{Bool: &v}
+	case []interface{}:
+		list := make([]pmapper.Value, 0, len(v))
+		for _, item := range v {
+			list = append(list, goValueToValue(item))
+		}
+		return pmapper.Value{List: list}
+	case map[string]interface{}:
+		m := make(map[string]pmapper.Value, len(v))
+		for k, item := range v {
+			m[k] = goValueToValue(item)
+		}
+		return pmapper.Value{Map: m}
+	default:
+		empty := ""
+		return pmapper.Value{String: &empty}
+	}
+}
+
+// camelCase converts snake_case / kebab-case names into lowerCamelCase.
+func camelCase(s string) string {
+	parts := nonIdentChars.Split(strings.ReplaceAll(s, "_", " "), -1)
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(strings.ToLower(p[:1]) + p[1:])
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+	}
+	return b.String()
+}
+
+func pascalCase(s string) string {
+	c := camelCase(s)
+	if c == "" {
+		return c
+	}
+	return strings.ToUpper(c[:1]) + c[1:]
+}
+
+func trimProviderPrefix(tfType string) string {
+	if i := strings.Index(tfType, "_"); i >= 0 {
+		return tfType[i+1:]
+	}
+	return tfType
+}
+
+// tfBlockName mirrors the provider mappers' local-name sanitisation for resource names.
+func tfBlockName(name string) string {
+	s := tfNameChars.ReplaceAllString(name, "_")
+	s = strings.ToLower(strings.Trim(s, "_"))
+	if s == "" {
+		return "resource"
+	}
+	if s[0] >= '0' && s[0] <= '9' {
+		s = "r_" + s
+	}
+	return s
+}
+
+// exportName makes an output name usable as an exported TypeScript identifier.
+func exportName(name string) string {
+	s := tfNameChars.ReplaceAllString(name, "_")
+	if s == "" {
+		return "output"
+	}
+	if (s[0] >= '0' && s[0] <= '9') || reservedIdents[s] {
+		s = "_" + s
+	}
+	return s
+}
@@ -0,0 +1,14 @@
+package mapper
+
+// ResourceMapper translates provider-specific Terraform resource types into Pulumi resources.
+// Implementations are provider-specific (AWS/GCP/Azure...), but the interface is cloud-agnostic
+// and lives in the Pulumi engine package.
+type ResourceMapper interface {
+	Provider() string
+
+	// Package returns the Pulumi provider SDK the generated program imports.
+	Package() Package
+
+	// Spec returns the translation rules for a Terraform resource type (e.g. "aws_vpc").
+	Spec(terraformType string) (ResourceSpec, bool)
+}
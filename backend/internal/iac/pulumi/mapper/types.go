@@ -0,0 +1,89 @@
+package mapper
+
+// Expr is a TypeScript expression that should be written as-is
+// (e.g. "mainVpc.id") rather than as a quoted string literal.
+type Expr string
+
+// Value is a value that can be rendered into a Pulumi TypeScript program.
+// Exactly one field should be set.
+type Value struct {
+	String *string
+	Number *float64
+	Bool   *bool
+	List   []Value
+	Map    map[string]Value
+	Expr   *Expr
+}
+
+// Resource is a single `new <Class>(<LogicalName>, {...})` statement.
+type Resource struct {
+	// Ident is the TypeScript variable holding the resource (e.g. "mainVpc").
+	Ident string
+	// LogicalName is the Pulumi logical name, unique per resource type.
+	LogicalName string
+	// Class is the constructor path (e.g. "aws.ec2.Vpc").
+	Class string
+	// Args are the resource input properties, keyed by Pulumi property name.
+	Args map[string]Value
+	// DependsOn lists the idents of resources this one explicitly depends on.
+	DependsOn []string
+}
+
+// Config describes a stack configuration value read through pulumi.Config.
+type Config struct {
+	Key         string // configuration key (e.g. "vpc_cidr")
+	Ident       string // TypeScript variable name (e.g. "vpcCidr")
+	Type        string // "string", "number", "bool" or anything else for objects
+	Description string
+	Default     *Value
+	Secret      bool
+}
+
+// Output describes an exported stack output.
+type Output struct {
+	Name        string
+	Value       Expr
+	Description string
+	Secret      bool
+}
+
+// Package describes the Pulumi provider SDK a program depends on.
+type Package struct {
+	Name      string // npm package (e.g. "@pulumi/aws")
+	Alias     string // import alias (e.g. "aws")
+	Version   string // semver range (e.g. "^6.0.0")
+	RegionKey string // stack config key for the provider region (e.g. "aws:region")
+}
+
+// Program is the Pulumi intermediate representation rendered by the writer.
+type Program struct {
+	Project     string
+	Description string
+	Stack       string
+	Region      string
+	Package     Package
+	Configs     []Config
+	Resources   []Resource
+	Outputs     []Output
+}
+
+// ResourceSpec describes how a Terraform resource type translates into a Pulumi resource.
+// The Pulumi providers for the major clouds are bridged from the Terraform providers, so
+// property names follow the Terraform schema in camelCase; the spec only carries exceptions.
+type ResourceSpec struct {
+	// Class is the TypeScript constructor path (e.g. "aws.ec2.Vpc").
+	Class string
+	// Renames maps Terraform attribute or nested block names to Pulumi property names
+	// where the default snake_case -> camelCase conversion is not correct
+	// (e.g. "vpc_zone_identifier" -> "vpcZoneIdentifiers").
+	Renames map[string]string
+	// SingleBlocks lists nested block types that Pulumi models as a single object
+	// instead of a list (e.g. "health_check").
+	SingleBlocks map[string]bool
+	// Archives lists attributes holding local file paths that Pulumi expects as
+	// FileArchive assets (e.g. Lambda "filename" -> "code").
+	Archives map[string]bool
+	// VerbatimMaps lists map attributes whose keys are user data and must not be
+	// camel-cased. "tags" is always treated as verbatim.
+	VerbatimMaps map[string]bool
+}
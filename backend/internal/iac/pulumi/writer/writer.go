@@ -0,0 +1,291 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+)
+
+const indentUnit = "    "
+
+var identPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// RenderIndexTS renders the Pulumi TypeScript entrypoint (index.ts) for a program.
+// Configuration reads come first, then resources in the given order, then stack outputs.
+func RenderIndexTS(p mapper.Program) (string, error) {
+	if p.Package.Name == "" || p.Package.Alias == "" {
+		return "", fmt.Errorf("provider package is empty")
+	}
+
+	var b strings.Builder
+	b.WriteString("import * as pulumi from \"@pulumi/pulumi\";\n")
+	fmt.Fprintf(&b, "import * as %s from %s;\n", p.Package.Alias, Quote(p.Package.Name))
+
+	if len(p.Configs) > 0 {
+		b.WriteString("\nconst config = new pulumi.Config();\n")
+		for _, c := range p.Configs {
+			line, err := renderConfig(c)
+			if err != nil {
+				return "", err
+			}
+			if c.Description != "" {
+				fmt.Fprintf(&b, "// %s\n", singleLine(c.Description))
+			}
+			b.WriteString(line)
+		}
+	}
+
+	for _, r := range p.Resources {
+		if r.Ident == "" || r.Class == "" {
+			return "", fmt.Errorf("resource %q: ident or class is empty", r.LogicalName)
+		}
+		args, err := renderObject(r.Args, 0)
+		if err != nil {
+			return "", fmt.Errorf("resource %s %q: %w", r.Class, r.LogicalName, err)
+		}
+		fmt.Fprintf(&b, "\nconst %s = new %s(%s, %s", r.Ident, r.Class, Quote(r.LogicalName), args)
+		if len(r.DependsOn) > 0 {
+			fmt.Fprintf(&b, ", { dependsOn: [%s] }", strings.Join(r.DependsOn, ", "))
+		}
+		b.WriteString(");\n")
+	}
+
+	if len(p.Outputs) > 0 {
+		b.WriteString("\n")
+		for _, o := range p.Outputs {
+			if o.Name == "" {
+				return "", fmt.Errorf("output name is empty")
+			}
+			if o.Description != "" {
+				fmt.Fprintf(&b, "// %s\n", singleLine(o.Description))
+			}
+			value := string(o.Value)
+			if o.Secret {
+				value = "pulumi.secret(" + value + ")"
+			}
+			fmt.Fprintf(&b, "export const %s = %s;\n", o.Name, value)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// RenderPulumiYAML renders the Pulumi project file (Pulumi.yaml) for a TypeScript program.
+func RenderPulumiYAML(p mapper.Program) (string, error) {
+	if p.Project == "" {
+		return "", fmt.Errorf("project name is empty")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "name: %s\n", p.Project)
+	b.WriteString("runtime:\n")
+	b.WriteString("  name: nodejs\n")
+	b.WriteString("  options:\n")
+	b.WriteString("    typescript: true\n")
+	if p.Description != "" {
+		fmt.Fprintf(&b, "description: %s\n", Quote(p.Description))
+	}
+	return b.String(), nil
+}
+
+// RenderStackYAML renders the stack configuration file (Pulumi.<stack>.yaml).
+// Only the provider region is pinned here; program inputs carry their defaults in index.ts.
+// Returns an empty string when there is nothing to configure.
+func RenderStackYAML(p mapper.Program) (string, error) {
+	if p.Region == "" || p.Package.RegionKey == "" {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("config:\n")
+	fmt.Fprintf(&b, "  %s: %s\n", p.Package.RegionKey, p.Region)
+	return b.String(), nil
+}
+
+// RenderPackageJSON renders the npm manifest pinning the Pulumi SDKs.
+func RenderPackageJSON(p mapper.Program) (string, error) {
+	manifest := map[string]interface{}{
+		"name": p.Project,
+		"main": "index.ts",
+		"devDependencies": map[string]string{
+			"@types/node": "^18.0.0",
+			"typescript":  "^5.0.0",
+		},
+		"dependencies": map[string]string{
+			"@pulumi/pulumi": "^3.0.0",
+			p.Package.Name:   p.Package.Version,
+		},
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal package.json: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// RenderTSConfig renders the TypeScript compiler configuration used by Pulumi's nodejs runtime.
+func RenderTSConfig() string {
+	return `{
+  "compilerOptions": {
+    "strict": true,
+    "outDir": "bin",
+    "target": "es2020",
+    "module": "commonjs",
+    "moduleResolution": "node",
+    "sourceMap": true,
+    "experimentalDecorators": true,
+    "pretty": true,
+    "noFallthroughCasesInSwitch": true,
+    "noImplicitReturns": true,
+    "forceConsistentCasingInFileNames": true
+  },
+  "files": [
+    "index.ts"
+  ]
+}
+`
+}
+
+// renderConfig renders a single typed pulumi.Config read with an optional default.
+func renderConfig(c mapper.Config) (string, error) {
+	if c.Key == "" || c.Ident == "" {
+		return "", fmt.Errorf("config key or ident is empty")
+	}
+
+	getter, tsType := "", ""
+	switch c.Type {
+	case "string", "":
+		getter = "get"
+	case "number":
+		getter = "getNumber"
+	case "bool":
+		getter = "getBoolean"
+	default:
+		getter, tsType = "getObject", "<any>"
+	}
+	if c.Secret {
+		getter += "Secret"
+	}
+
+	if c.Default == nil {
+		required := "require" + strings.TrimPrefix(getter, "get")
+		return fmt.Sprintf("const %s = config.%s%s(%s);\n", c.Ident, required, tsType, Quote(c.Key)), nil
+	}
+
+	def, err := renderValue(*c.Default, 0)
+	if err != nil {
+		return "", fmt.Errorf("config %q default: %w", c.Key, err)
+	}
+	if c.Secret {
+		def = "pulumi.secret(" + def + ")"
+	}
+	return fmt.Sprintf("const %s = config.%s%s(%s) ?? %s;\n", c.Ident, getter, tsType, Quote(c.Key), def), nil
+}
+
+// renderValue converts a Value into TypeScript source at the given indentation depth.
+func renderValue(v mapper.Value, depth int) (string, error) {
+	switch {
+	case v.Expr != nil:
+		return string(*v.Expr), nil
+	case v.String != nil:
+		return Quote(*v.String), nil
+	case v.Number != nil:
+		return strconv.FormatFloat(*v.Number, 'f', -1, 64), nil
+	case v.Bool != nil:
+		return strconv.FormatBool(*v.Bool), nil
+	case v.Map != nil:
+		return renderObject(v.Map, depth)
+	case v.List != nil:
+		return renderList(v.List, depth)
+	default:
+		return "", fmt.Errorf("empty Value")
+	}
+}
+
+// renderObject renders an object literal with keys sorted for stable output.
+func renderObject(m map[string]mapper.Value, depth int) (string, error) {
+	if len(m) == 0 {
+		return "{}", nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	inner := strings.Repeat(indentUnit, depth+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, k := range keys {
+		val, err := renderValue(m[k], depth+1)
+		if err != nil {
+			return "", fmt.Errorf("property %q: %w", k, err)
+		}
+		key := k
+		if !identPattern.MatchString(k) {
+			key = Quote(k)
+		}
+		fmt.Fprintf(&b, "%s%s: %s,\n", inner, key, val)
+	}
+	b.WriteString(strings.Repeat(indentUnit, depth))
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// renderList renders an array literal; scalar lists stay on one line.
+func renderList(list []mapper.Value, depth int) (string, error) {
+	if len(list) == 0 {
+		return "[]", nil
+	}
+
+	multiline := false
+	for _, item := range list {
+		if item.Map != nil || item.List != nil {
+			multiline = true
+			break
+		}
+	}
+
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		s, err := renderValue(item, depth+1)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, s)
+	}
+
+	if !multiline {
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+
+	inner := strings.Repeat(indentUnit, depth+1)
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, s := range items {
+		fmt.Fprintf(&b, "%s%s,\n", inner, s)
+	}
+	b.WriteString(strings.Repeat(indentUnit, depth))
+	b.WriteString("]")
+	return b.String(), nil
+}
+
+// Quote renders a string as a double-quoted TypeScript literal.
+// JSON string escaping is a valid subset of TypeScript string escaping.
+func Quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
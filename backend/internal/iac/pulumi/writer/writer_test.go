@@ -0,0 +1,161 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
+)
+
+func strPtr(s string) *string { return &s }
+
+func numPtr(n float64) *float64 { return &n }
+
+func exprPtr(e string) *mapper.Expr {
+	x := mapper.Expr(e)
+	return &x
+}
+
+func testPackage() mapper.Package {
+	return mapper.Package{Name: "@pulumi/aws", Alias: "aws", Version: "^6.0.0", RegionKey: "aws:region"}
+}
+
+func TestRenderIndexTS_ResourcesConfigsAndOutputs(t *testing.T) {
+	p := mapper.Program{
+		Project: "demo",
+		Package: testPackage(),
+		Configs: []mapper.Config{
+			{Key: "vpc_cidr", Ident: "vpcCidr", Type: "string", Default: &mapper.Value{String: strPtr("10.0.0.0/16")}},
+			{Key: "db_password", Ident: "dbPassword", Type: "string", Secret: true},
+		},
+		Resources: []mapper.Resource{
+			{
+				Ident:       "main",
+				LogicalName: "main",
+				Class:       "aws.ec2.Vpc",
+				Args: map[string]mapper.Value{
+					"cidrBlock": {Expr: exprPtr("vpcCidr")},
+					"tags": {Map: map[string]mapper.Value{
+						"Name":             {String: strPtr("main")},
+						"kubernetes.io/ok": {String: strPtr("1")},
+					}},
+				},
+			},
+			{
+				Ident:       "web",
+				LogicalName: "web",
+				Class:       "aws.ec2.SecurityGroup",
+				Args: map[string]mapper.Value{
+					"vpcId": {Expr: exprPtr("main.id")},
+					"ingress": {List: []mapper.Value{
+						{Map: map[string]mapper.Value{
+							"fromPort":   {Number: numPtr(443)},
+							"cidrBlocks": {List: []mapper.Value{{String: strPtr("0.0.0.0/0")}}},
+						}},
+					}},
+				},
+				DependsOn: []string{"main"},
+			},
+		},
+		Outputs: []mapper.Output{
+			{Name: "vpc_id", Value: "main.id", Description: "The VPC"},
+			{Name: "secret_out", Value: "dbPassword", Secret: true},
+		},
+	}
+
+	got, err := RenderIndexTS(p)
+	if err != nil {
+		t.Fatalf("RenderIndexTS() error = %v", err)
+	}
+
+	wantContains := []string{
+		`import * as pulumi from "@pulumi/pulumi";`,
+		`import * as aws from "@pulumi/aws";`,
+		`const config = new pulumi.Config();`,
+		`const vpcCidr = config.get("vpc_cidr") ?? "10.0.0.0/16";`,
+		`const dbPassword = config.requireSecret("db_password");`,
+		`const main = new aws.ec2.Vpc("main", {`,
+		`    cidrBlock: vpcCidr,`,
+		`        "kubernetes.io/ok": "1",`,
+		`        Name: "main",`,
+		`            cidrBlocks: ["0.0.0.0/0"],`,
+		`            fromPort: 443,`,
+		`}, { dependsOn: [main] });`,
+		"// The VPC\nexport const vpc_id = main.id;",
+		`export const secret_out = pulumi.secret(dbPassword);`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(got, want) {
+			t.Fatalf("RenderIndexTS() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderIndexTS_ErrorsOnMissingPackage(t *testing.T) {
+	if _, err := RenderIndexTS(mapper.Program{}); err == nil {
+		t.Fatalf("RenderIndexTS() with empty package expected error, got nil")
+	}
+}
+
+func TestRenderIndexTS_ErrorsOnEmptyValue(t *testing.T) {
+	p := mapper.Program{
+		Package: testPackage(),
+		Resources: []mapper.Resource{
+			{Ident: "x", LogicalName: "x", Class: "aws.ec2.Vpc", Args: map[string]mapper.Value{"bad": {}}},
+		},
+	}
+	if _, err := RenderIndexTS(p); err == nil {
+		t.Fatalf("RenderIndexTS() with empty value expected error, got nil")
+	}
+}
+
+func TestRenderStackYAML(t *testing.T) {
+	got, err := RenderStackYAML(mapper.Program{Region: "eu-west-1", Package: testPackage()})
+	if err != nil {
+		t.Fatalf("RenderStackYAML() error = %v", err)
+	}
+	if got != "config:\n  aws:region: eu-west-1\n" {
+		t.Fatalf("RenderStackYAML() = %q", got)
+	}
+
+	got, err = RenderStackYAML(mapper.Program{Package: testPackage()})
+	if err != nil || got != "" {
+		t.Fatalf("RenderStackYAML() without region = %q, %v; want empty", got, err)
+	}
+}
+
+func TestRenderPulumiYAMLAndPackageJSON(t *testing.T) {
+	p := mapper.Program{Project: "demo", Description: "Demo stack", Package: testPackage()}
+
+	project, err := RenderPulumiYAML(p)
+	if err != nil {
+		t.Fatalf("RenderPulumiYAML() error = %v", err)
+	}
+	for _, want := range []string{"name: demo\n", "  name: nodejs\n", "    typescript: true\n", "description: \"Demo stack\"\n"} {
+		if !strings.Contains(project, want) {
+			t.Fatalf("RenderPulumiYAML() missing %q in:\n%s", want, project)
+		}
+	}
+
+	pkg, err := RenderPackageJSON(p)
+	if err != nil {
+		t.Fatalf("RenderPackageJSON() error = %v", err)
+	}
+	for _, want := range []string{`"@pulumi/pulumi": "^3.0.0"`, `"@pulumi/aws": "^6.0.0"`, `"main": "index.ts"`} {
+		if !strings.Contains(pkg, want) {
+			t.Fatalf("RenderPackageJSON() missing %q in:\n%s", want, pkg)
+		}
+	}
+
+	if _, err := RenderPulumiYAML(mapper.Program{}); err == nil {
+		t.Fatalf("RenderPulumiYAML() with empty project expected error, got nil")
+	}
+}
+
+func TestQuote_UsesTypeScriptCompatibleEscapes(t *testing.T) {
+	got := Quote("a\"b\\c\a\x01<tag>\xff")
+	want := `"a\"b\\c\u0007\u0001<tag>` + "\ufffd" + `"`
+	if got != want {
+		t.Fatalf("Quote() = %s, want %s", got, want)
+	}
+}
@@ -15,7 +15,7 @@ type fakeEngine struct {
 
 func (f *fakeEngine) Name() string { return f.name }
 
-func (f *fakeEngine) Generate(ctx context.Context, arch *architecture.Architecture, sorted []*resource.Resource) (*iac.Output, error) {
+func (f *fakeEngine) Generate(ctx context.Context, arch *architecture.Architecture, sorted []*resource.Resource, opts iac.Options) (*iac.Output, error) {
 	return &iac.Output{Files: []iac.GeneratedFile{}}, nil
 }
 
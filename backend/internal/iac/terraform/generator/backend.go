@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/writer"
+)
+
+// bootstrapDir holds the configuration that creates the backend's own resources.
+// It keeps local state, since the remote state does not exist until it is applied.
+const bootstrapDir = "bootstrap"
+
+// backendBlock builds the terraform { backend "<type>" { ... } } block written to backend.tf.
+// Backend blocks cannot reference variables, so every value is a literal; the S3
+// region falls back to the architecture region.
+func backendBlock(b iac.StateBackend, region string) (tfmapper.TerraformBlock, error) {
+	attrs := map[string]tfmapper.TerraformValue{}
+	setString := func(key, value string) {
+		if value != "" {
+			attrs[key] = tfmapper.TerraformValue{String: &value}
+		}
+	}
+
+	switch b.Type {
+	case iac.BackendS3:
+		s3Region := backendRegion(b, region)
+		if s3Region == "" {
+			return tfmapper.TerraformBlock{}, fmt.Errorf("s3 backend region is required")
+		}
+		setString("bucket", b.S3.Bucket)
+		setString("key", b.S3.Key)
+		setString("region", s3Region)
+		setString("dynamodb_table", b.S3.DynamoDBTable)
+		setString("workspace_key_prefix", b.S3.WorkspaceKeyPrefix)
+		encrypt := b.S3.Encrypt
+		attrs["encrypt"] = tfmapper.TerraformValue{Bool: &encrypt}
+	case iac.BackendLocal:
+		if b.Local != nil {
+			setString("path", b.Local.Path)
+		}
+	case iac.BackendHTTP:
+		setString("address", b.HTTP.Address)
+		setString("lock_address", b.HTTP.LockAddress)
+		setString("unlock_address", b.HTTP.UnlockAddress)
+		setString("lock_method", b.HTTP.LockMethod)
+		setString("unlock_method", b.HTTP.UnlockMethod)
+		setString("username", b.HTTP.Username)
+	default:
+		return tfmapper.TerraformBlock{}, fmt.Errorf("unsupported state backend type %q", b.Type)
+	}
+
+	return tfmapper.TerraformBlock{
+		Kind:       "terraform",
+		Attributes: map[string]tfmapper.TerraformValue{},
+		NestedBlocks: map[string][]tfmapper.NestedBlock{
+			"backend": {{Labels: []string{b.Type}, Attributes: attrs}},
+		},
+	}, nil
+}
+
+// backendRegion returns the region the backend resources live in.
+func backendRegion(b iac.StateBackend, region string) string {
+	if b.S3 != nil && b.S3.Region != "" {
+		return b.S3.Region
+	}
+	return region
+}
+
+// appendBootstrapFiles writes the configuration that creates the backend's state
+// storage (bootstrap/versions.tf, providers.tf and main.tf) using the mapper's bootstrap resources.
+func appendBootstrapFiles(out *iac.Output, b iac.StateBackend, provider, region string, m tfmapper.ResourceMapper) error {
+	bootstrapper, ok := m.(tfmapper.BackendBootstrapper)
+	if !ok {
+		return fmt.Errorf("terraform mapper for %q cannot bootstrap a %s backend", provider, b.Type)
+	}
+	resourceRegion := backendRegion(b, region)
+	blocks, err := bootstrapper.BootstrapBackend(b)
+	if err != nil {
+		return fmt.Errorf("bootstrap %s backend: %w", b.Type, err)
+	}
+
+	files := newTFFiles()
+	files.add(bootstrapDir+"/versions.tf", versionsBlock(provider, m, true))
+	if pb, ok := providerBlockWithVars(provider, resourceRegion, nil); ok {
+		files.add(bootstrapDir+"/providers.tf", pb)
+	}
+	files.add(bootstrapDir+"/main.tf", blocks...)
+	for _, path := range files.order {
+		content, err := writer.RenderMainTF(files.blocks[path])
+		if err != nil {
+			return fmt.Errorf("render %s: %w", path, err)
+		}
+		out.Files = append(out.Files, iac.GeneratedFile{Path: path, Content: content, Type: "hcl"})
+	}
+	return nil
+}
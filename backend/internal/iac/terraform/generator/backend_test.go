@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// bootstrapMapper is a refMapper that can also bootstrap backends.
+type bootstrapMapper struct {
+	refMapper
+	got *iac.StateBackend
+}
+
+func (m *bootstrapMapper) BootstrapBackend(backend iac.StateBackend) ([]tfmapper.TerraformBlock, error) {
+	m.got = &backend
+	bucket := backend.S3.Bucket
+	return []tfmapper.TerraformBlock{{
+		Kind:       "resource",
+		Labels:     []string{"aws_test_bucket", "state"},
+		Attributes: map[string]tfmapper.TerraformValue{"bucket": {String: &bucket}},
+	}}, nil
+}
+
+func newBackendTestEngine(t *testing.T, m tfmapper.ResourceMapper) *Engine {
+	t.Helper()
+	reg := tfmapper.NewRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatalf("Register mapper error = %v, want nil", err)
+	}
+	return NewEngine(reg)
+}
+
+func backendTestArch() *architecture.Architecture {
+	res := &resource.Resource{ID: "vpc-1", Name: "main", Type: resource.ResourceType{Name: "VPC", Category: resource.CategoryNetworking}}
+	return &architecture.Architecture{Resources: []*resource.Resource{res}, Region: "eu-west-1", Provider: resource.AWS}
+}
+
+func TestEngine_Generate_BackendTF(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend iac.StateBackend
+		want    []string
+		not     []string
+	}{
+		{
+			name: "s3 falls back to the architecture region",
+			backend: iac.StateBackend{Type: iac.BackendS3, S3: &iac.S3Backend{
+				Bucket: "acme-state", Key: "app/terraform.tfstate", DynamoDBTable: "acme-locks", Encrypt: true, WorkspaceKeyPrefix: "env",
+			}},
+			want: []string{`backend "s3" {`, `"acme-state"`, `"app/terraform.tfstate"`, `"eu-west-1"`, `"acme-locks"`, "encrypt", "workspace_key_prefix"},
+		},
+		{
+			name:    "s3 explicit region",
+			backend: iac.StateBackend{Type: iac.BackendS3, S3: &iac.S3Backend{Bucket: "b", Key: "k", Region: "us-east-2"}},
+			want:    []string{`"us-east-2"`},
+			not:     []string{"eu-west-1", "dynamodb_table"},
+		},
+		{
+			name:    "local",
+			backend: iac.StateBackend{Type: iac.BackendLocal, Local: &iac.LocalBackend{Path: "state/terraform.tfstate"}},
+			want:    []string{`backend "local" {`, `"state/terraform.tfstate"`},
+		},
+		{
+			name:    "http",
+			backend: iac.StateBackend{Type: iac.BackendHTTP, HTTP: &iac.HTTPBackend{Address: "https://state.example.com/app", LockMethod: "POST", Username: "ci"}},
+			want:    []string{`backend "http" {`, `"https://state.example.com/app"`, `"POST"`, `"ci"`},
+			not:     []string{"password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newBackendTestEngine(t, &refMapper{})
+			arch := backendTestArch()
+			backend := tt.backend
+
+			out, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{Backend: &backend})
+			if err != nil {
+				t.Fatalf("Generate() error = %v, want nil", err)
+			}
+			files := filesByPath(out)
+			content, ok := files["backend.tf"]
+			if !ok {
+				t.Fatalf("expected backend.tf to be generated, got files %v", out.Files)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Fatalf("expected %q in backend.tf, got:\n%s", want, content)
+				}
+			}
+			for _, not := range tt.not {
+				if strings.Contains(content, not) {
+					t.Fatalf("did not expect %q in backend.tf, got:\n%s", not, content)
+				}
+			}
+			for path := range files {
+				if strings.HasPrefix(path, bootstrapDir+"/") {
+					t.Fatalf("did not expect bootstrap files without Bootstrap, got %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_Generate_NoBackendByDefault(t *testing.T) {
+	e := newBackendTestEngine(t, &refMapper{})
+	arch := backendTestArch()
+
+	out, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	if _, ok := filesByPath(out)["backend.tf"]; ok {
+		t.Fatalf("did not expect backend.tf without a configured backend")
+	}
+}
+
+func TestEngine_Generate_BackendBootstrap(t *testing.T) {
+	m := &bootstrapMapper{}
+	e := newBackendTestEngine(t, m)
+	arch := backendTestArch()
+	backend := iac.StateBackend{Type: iac.BackendS3, S3: &iac.S3Backend{Bucket: "acme-state", Key: "k"}, Bootstrap: true}
+
+	out, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{Backend: &backend})
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	if m.got == nil || m.got.S3.Bucket != "acme-state" {
+		t.Fatalf("expected mapper to be asked to bootstrap the backend, got %+v", m.got)
+	}
+
+	files := filesByPath(out)
+	checks := map[string][]string{
+		"bootstrap/main.tf":      {`resource "aws_test_bucket" "state"`, `"acme-state"`},
+		"bootstrap/providers.tf": {`provider "aws"`, `"eu-west-1"`},
+		"bootstrap/versions.tf":  {"required_providers", "required_version"},
+	}
+	for path, wants := range checks {
+		content, ok := files[path]
+		if !ok {
+			t.Fatalf("expected %s to be generated, got files %v", path, out.Files)
+		}
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("expected %q in %s, got:\n%s", want, path, content)
+			}
+		}
+	}
+	if strings.Contains(files["bootstrap/versions.tf"], "backend") {
+		t.Fatalf("bootstrap configuration must keep local state, got:\n%s", files["bootstrap/versions.tf"])
+	}
+}
+
+func TestEngine_Generate_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend iac.StateBackend
+	}{
+		{name: "invalid settings", backend: iac.StateBackend{Type: iac.BackendS3, S3: &iac.S3Backend{Key: "k"}}},
+		{name: "bootstrap unsupported by mapper", backend: iac.StateBackend{Type: iac.BackendS3, S3: &iac.S3Backend{Bucket: "b", Key: "k"}, Bootstrap: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newBackendTestEngine(t, &refMapper{})
+			arch := backendTestArch()
+			backend := tt.backend
+			if _, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{Backend: &backend}); err == nil {
+				t.Fatalf("Generate() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestModuleRewriter_KeepsNestedBlockLabels(t *testing.T) {
+	w := &moduleRewriter{owner: map[string]string{}, modules: map[string]*localModule{}, rootVars: map[string]tfmapper.Variable{}}
+	nested := map[string][]tfmapper.NestedBlock{
+		"dynamic": {{Labels: []string{"ingress"}, Attributes: map[string]tfmapper.TerraformValue{}}},
+	}
+
+	got := w.rewriteNested(nested, "")
+	if labels := got["dynamic"][0].Labels; len(labels) != 1 || labels[0] != "ingress" {
+		t.Fatalf("rewriteNested() labels = %v, want [ingress]", labels)
+	}
+}
@@ -24,7 +24,178 @@ func (e *Engine) Name() string {
 	return "terraform"
 }
 
-func (e *Engine) Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource) (*iac.Output, error) {
+// Generate writes the standard Terraform layout: versions.tf (required_providers pins),
+// providers.tf, one file per resource category (networking.tf, compute.tf, ...),
+// variables.tf and outputs.tf. With opts.Modules set, each VPC subtree is
+// written as a local module under modules/<name>/ and instantiated from main.tf.
+// With opts.Backend set, the state backend is written to backend.tf, plus a
+// bootstrap/ configuration creating the backend's storage when requested.
+func (e *Engine) Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource, opts iac.Options) (*iac.Output, error) {
+	if opts.Backend != nil {
+		if err := opts.Backend.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid state backend: %w", err)
+		}
+	}
+	m, err := e.mapArchitecture(ctx, arch, sortedResources)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := string(arch.Provider)
+	vars := convertArchVariablesToTF(arch.Variables)
+	outputs := convertArchOutputsToTF(arch.Outputs, arch.Resources)
+
+	root := newTFFiles()
+	root.add("versions.tf", versionsBlock(provider, m.mapper, true))
+	if m.provider != nil {
+		root.add("providers.tf", *m.provider)
+	}
+	if opts.Backend != nil {
+		block, err := backendBlock(*opts.Backend, arch.Region)
+		if err != nil {
+			return nil, err
+		}
+		root.add("backend.tf", block)
+	}
+
+	resources := m.resources
+	var modules []*localModule
+	if opts.Modules {
+		layout := extractModules(m.resources, arch.Resources, vars, outputs)
+		resources, modules, outputs = layout.root, layout.modules, layout.outputs
+		for _, mod := range modules {
+			root.add("main.tf", mod.callBlock())
+		}
+	}
+	for _, mr := range resources {
+		root.add(categoryFile(resourceCategory(mr.res, m.mapper)), mr.blocks...)
+	}
+
+	out := &iac.Output{}
+	for _, path := range root.order {
+		content, err := writer.RenderMainTF(root.blocks[path])
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", path, err)
+		}
+		out.Files = append(out.Files, iac.GeneratedFile{Path: path, Content: content, Type: "hcl"})
+	}
+
+	// Generate variables.tf if there are variables
+	if err := appendVariablesTF(out, "variables.tf", vars); err != nil {
+		return nil, err
+	}
+
+	// Generate outputs.tf if there are outputs
+	if err := appendOutputsTF(out, "outputs.tf", outputs); err != nil {
+		return nil, err
+	}
+
+	for _, mod := range modules {
+		if err := appendModuleFiles(out, mod, provider, m.mapper); err != nil {
+			return nil, fmt.Errorf("module %q: %w", mod.name, err)
+		}
+	}
+
+	if opts.Backend != nil && opts.Backend.Bootstrap {
+		if err := appendBootstrapFiles(out, *opts.Backend, provider, arch.Region, m.mapper); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// appendModuleFiles renders a local module as main.tf, variables.tf, outputs.tf and versions.tf.
+func appendModuleFiles(out *iac.Output, mod *localModule, provider string, m tfmapper.ResourceMapper) error {
+	var blocks []tfmapper.TerraformBlock
+	for _, mr := range mod.resources {
+		blocks = append(blocks, mr.blocks...)
+	}
+	mainTF, err := writer.RenderMainTF(blocks)
+	if err != nil {
+		return fmt.Errorf("render main.tf: %w", err)
+	}
+	versionsTF, err := writer.RenderMainTF([]tfmapper.TerraformBlock{versionsBlock(provider, m, false)})
+	if err != nil {
+		return fmt.Errorf("render versions.tf: %w", err)
+	}
+
+	out.Files = append(out.Files,
+		iac.GeneratedFile{Path: mod.dir() + "/main.tf", Content: mainTF, Type: "hcl"},
+	)
+	if err := appendVariablesTF(out, mod.dir()+"/variables.tf", mod.sortedVariables()); err != nil {
+		return err
+	}
+	if err := appendOutputsTF(out, mod.dir()+"/outputs.tf", mod.sortedOutputs()); err != nil {
+		return err
+	}
+	out.Files = append(out.Files, iac.GeneratedFile{Path: mod.dir() + "/versions.tf", Content: versionsTF, Type: "hcl"})
+	return nil
+}
+
+func appendVariablesTF(out *iac.Output, path string, vars []tfmapper.Variable) error {
+	if len(vars) == 0 {
+		return nil
+	}
+	varsTF, err := writer.RenderVariablesTF(vars)
+	if err != nil {
+		return fmt.Errorf("render %s: %w", path, err)
+	}
+	if varsTF != "" {
+		out.Files = append(out.Files, iac.GeneratedFile{Path: path, Content: varsTF, Type: "hcl"})
+	}
+	return nil
+}
+
+func appendOutputsTF(out *iac.Output, path string, outputs []tfmapper.Output) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+	outputsTF, err := writer.RenderOutputsTF(outputs)
+	if err != nil {
+		return fmt.Errorf("render %s: %w", path, err)
+	}
+	if outputsTF != "" {
+		out.Files = append(out.Files, iac.GeneratedFile{Path: path, Content: outputsTF, Type: "hcl"})
+	}
+	return nil
+}
+
+// MapBlocks enriches the architecture resources and maps them, in the given
+// topological order, into Terraform blocks (provider block first).
+// It is the shared front half of Generate and is also used by engines that
+// translate Terraform blocks into another IaC language (e.g. Pulumi).
+func (e *Engine) MapBlocks(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	m, err := e.mapArchitecture(ctx, arch, sortedResources)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]tfmapper.TerraformBlock, 0, len(m.resources)+1)
+	if m.provider != nil {
+		blocks = append(blocks, *m.provider)
+	}
+	for _, mr := range m.resources {
+		blocks = append(blocks, mr.blocks...)
+	}
+	return blocks, nil
+}
+
+// mappedResource holds the blocks produced for a single domain resource.
+type mappedResource struct {
+	res    *resource.Resource
+	blocks []tfmapper.TerraformBlock
+}
+
+// mappedArchitecture is the result of mapping an architecture, keeping the link
+// between domain resources and their blocks so Generate can lay out files.
+type mappedArchitecture struct {
+	mapper    tfmapper.ResourceMapper
+	provider  *tfmapper.TerraformBlock
+	resources []mappedResource
+}
+
+func (e *Engine) mapArchitecture(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource) (*mappedArchitecture, error) {
 	_ = ctx
 
 	if arch == nil {
@@ -40,12 +211,18 @@ func (e *Engine) Generate(ctx context.Context, arch *architecture.Architecture,
 		return nil, fmt.Errorf("no terraform mapper registered for provider %q", provider)
 	}
 
-	blocks := make([]tfmapper.TerraformBlock, 0, len(sortedResources)+1)
+	out := &mappedArchitecture{
+		mapper:    mapper,
+		resources: make([]mappedResource, 0, len(sortedResources)),
+	}
 	// if not provider block, add it explicitly ex) provider "aws" {
 	//   region = var.aws_region  (if variable exists)
 	// }
-	if pb, ok := providerBlockWithVars(provider, arch.Region, arch.Variables); ok {
-		blocks = append(blocks, pb)
+	if pc, ok := mapper.(tfmapper.ProviderConfigurer); ok {
+		pb := pc.ProviderBlock(arch.Region)
+		out.provider = &pb
+	} else if pb, ok := providerBlockWithVars(provider, arch.Region, arch.Variables); ok {
+		out.provider = &pb
 	}
 
 	// Build a lookup map for security groups: metadata "id" -> resource ID
@@ -233,50 +410,7 @@ func (e *Engine) Generate(ctx context.Context, arch *architecture.Architecture,
 		if err != nil {
 			return nil, fmt.Errorf("map resource %q (%s): %w", res.ID, res.Type.Name, err)
 		}
-		blocks = append(blocks, bs...)
-	}
-
-	mainTF, err := writer.RenderMainTF(blocks)
-	if err != nil {
-		return nil, err
-	}
-
-	out := &iac.Output{
-		Files: []iac.GeneratedFile{
-			{Path: "main.tf", Content: mainTF, Type: "hcl"},
-		},
-	}
-
-	// Generate variables.tf if there are variables
-	if len(arch.Variables) > 0 {
-		vars := convertArchVariablesToTF(arch.Variables)
-		varsTF, err := writer.RenderVariablesTF(vars)
-		if err != nil {
-			return nil, fmt.Errorf("render variables.tf: %w", err)
-		}
-		if varsTF != "" {
-			out.Files = append(out.Files, iac.GeneratedFile{
-				Path:    "variables.tf",
-				Content: varsTF,
-				Type:    "hcl",
-			})
-		}
-	}
-
-	// Generate outputs.tf if there are outputs
-	if len(arch.Outputs) > 0 {
-		outputs := convertArchOutputsToTF(arch.Outputs, arch.Resources)
-		outputsTF, err := writer.RenderOutputsTF(outputs)
-		if err != nil {
-			return nil, fmt.Errorf("render outputs.tf: %w", err)
-		}
-		if outputsTF != "" {
-			out.Files = append(out.Files, iac.GeneratedFile{
-				Path:    "outputs.tf",
-				Content: outputsTF,
-				Type:    "hcl",
-			})
-		}
+		out.resources = append(out.resources, mappedResource{res: res, blocks: bs})
 	}
 
 	return out, nil
@@ -364,62 +498,9 @@ func isPublicSubnetName(name string) bool {
 // have routes to an Internet Gateway (making them public subnets)
 func buildSubnetIGWRouteMap(resources []*resource.Resource) map[string]bool {
 	result := make(map[string]bool)
-
-	// First, find all route tables and check if they have IGW routes
-	routeTableHasIGW := make(map[string]bool)
-	routeTableToSubnets := make(map[string][]string)
-
-	for _, res := range resources {
-		if res.Type.Name == "RouteTable" {
-			routeTableID := res.ID
-			hasIGWRoute := false
-
-			// Check routes for Internet Gateway target
-			if routes, ok := res.Metadata["routes"].([]interface{}); ok {
-				for _, routeRaw := range routes {
-					if route, ok := routeRaw.(map[string]interface{}); ok {
-						if target, ok := route["target"].(map[string]interface{}); ok {
-							if targetType, ok := target["type"].(string); ok {
-								if targetType == "InternetGateway" {
-									hasIGWRoute = true
-									break
-								}
-							}
-						}
-					}
-				}
-			}
-			routeTableHasIGW[routeTableID] = hasIGWRoute
-
-			// Extract subnet associations
-			if associations, ok := res.Metadata["associations"].([]interface{}); ok {
-				for _, assocRaw := range associations {
-					if assoc, ok := assocRaw.(map[string]interface{}); ok {
-						assocType, _ := assoc["associationType"].(string)
-						subnetID, _ := assoc["associatedResourceId"].(string)
-						if assocType == "Subnet" && subnetID != "" {
-							routeTableToSubnets[routeTableID] = append(routeTableToSubnets[routeTableID], subnetID)
-						}
-					}
-				}
-			}
-		}
+	for subnetID, route := range architecture.SubnetRoutes(resources) {
+		result[subnetID] = route.InternetGateway
 	}
-
-	// Map subnets to their public/private status based on route table associations
-	for routeTableID, subnets := range routeTableToSubnets {
-		hasIGW := routeTableHasIGW[routeTableID]
-		for _, subnetID := range subnets {
-			// If subnet is associated with a route table that has IGW route, it's public
-			// If already marked as public by another route table, keep it public
-			if existing, found := result[subnetID]; found {
-				result[subnetID] = existing || hasIGW
-			} else {
-				result[subnetID] = hasIGW
-			}
-		}
-	}
-
 	return result
 }
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
@@ -38,7 +39,7 @@ func TestEngine_Name(t *testing.T) {
 
 func TestEngine_Generate_ErrorsOnNilArchitecture(t *testing.T) {
 	e := NewEngine(tfmapper.NewRegistry())
-	_, err := e.Generate(context.Background(), nil, nil)
+	_, err := e.Generate(context.Background(), nil, nil, iac.Options{})
 	if err == nil {
 		t.Fatalf("Generate() with nil architecture expected error, got nil")
 	}
@@ -52,7 +53,7 @@ func TestEngine_Generate_ErrorsOnMissingMapper(t *testing.T) {
 		Provider: resource.AWS,
 	}
 
-	_, err := e.Generate(context.Background(), arch, []*resource.Resource{})
+	_, err := e.Generate(context.Background(), arch, []*resource.Resource{}, iac.Options{})
 	if err == nil {
 		t.Fatalf("Generate() expected error when no mapper is registered, got nil")
 	}
@@ -83,7 +84,7 @@ func TestEngine_Generate_HappyPath(t *testing.T) {
 		Provider:  resource.AWS,
 	}
 
-	out, err := e.Generate(context.Background(), arch, []*resource.Resource{res})
+	out, err := e.Generate(context.Background(), arch, []*resource.Resource{res}, iac.Options{})
 	fmt.Println("out", out)
 	if err != nil {
 		t.Fatalf("Generate() error = %v, want nil", err)
@@ -91,20 +92,177 @@ func TestEngine_Generate_HappyPath(t *testing.T) {
 	if out == nil {
 		t.Fatalf("Generate() returned nil output")
 	}
-	if len(out.Files) != 1 {
-		t.Fatalf("expected 1 generated file, got %d", len(out.Files))
+	files := filesByPath(out)
+	if len(out.Files) != 3 {
+		t.Fatalf("expected 3 generated files, got %d: %v", len(out.Files), out.Files)
 	}
-	main := out.Files[0]
-	if main.Path != "main.tf" {
-		t.Fatalf("expected main.tf path, got %q", main.Path)
+	if got := out.Files[0].Path; got != "versions.tf" {
+		t.Fatalf("expected versions.tf first, got %q", got)
 	}
-	if main.Type != "hcl" {
-		t.Fatalf("expected file type hcl, got %q", main.Type)
+	if !strings.Contains(files["versions.tf"], "required_providers") || !strings.Contains(files["versions.tf"], `"hashicorp/aws"`) {
+		t.Fatalf("expected required_providers pin in versions.tf, got:\n%s", files["versions.tf"])
 	}
-	if !strings.Contains(main.Content, `provider "aws"`) {
-		t.Fatalf("expected provider block in content, got:\n%s", main.Content)
+	if !strings.Contains(files["providers.tf"], `provider "aws"`) {
+		t.Fatalf("expected provider block in providers.tf, got:\n%s", files["providers.tf"])
+	}
+	// The resource has no category, so it lands in main.tf.
+	if !strings.Contains(files["main.tf"], `resource "aws_test" "example"`) {
+		t.Fatalf("expected resource block in main.tf, got:\n%s", files["main.tf"])
+	}
+	for _, f := range out.Files {
+		if f.Type != "hcl" {
+			t.Fatalf("expected file type hcl for %s, got %q", f.Path, f.Type)
+		}
 	}
 	if len(mapper.mapped) != 1 || mapper.mapped[0] != res {
 		t.Fatalf("expected mapper to receive resource, got %#v", mapper.mapped)
 	}
 }
+
+// pinnedMapper is a fake mapper that emits one block per resource, named after the
+// resource, and pins its provider through tfmapper.ProviderRequirer.
+type pinnedMapper struct{}
+
+func (m *pinnedMapper) Provider() string { return "aws" }
+
+func (m *pinnedMapper) SupportsResource(resourceType string) bool { return true }
+
+func (m *pinnedMapper) MapResource(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	return []tfmapper.TerraformBlock{
+		{Kind: "resource", Labels: []string{"aws_test", tfName(res.Name)}},
+	}, nil
+}
+
+func (m *pinnedMapper) RequiredProvider() tfmapper.ProviderRequirement {
+	return tfmapper.ProviderRequirement{Source: "hashicorp/aws", Version: "~> 5.0"}
+}
+
+func (m *pinnedMapper) ResourceCategory(resourceType string) (string, bool) {
+	if resourceType == "Bucket" {
+		return resource.CategoryStorage, true
+	}
+	return "", false
+}
+
+func filesByPath(out *iac.Output) map[string]string {
+	files := make(map[string]string, len(out.Files))
+	for _, f := range out.Files {
+		files[f.Path] = f.Content
+	}
+	return files
+}
+
+func TestEngine_Generate_SplitsFilesByCategory(t *testing.T) {
+	reg := tfmapper.NewRegistry()
+	if err := reg.Register(&pinnedMapper{}); err != nil {
+		t.Fatalf("Register mapper error = %v, want nil", err)
+	}
+	e := NewEngine(reg)
+
+	resources := []*resource.Resource{
+		{ID: "vpc-1", Name: "main", Type: resource.ResourceType{Name: "VPC", Category: resource.CategoryNetworking}},
+		{ID: "ec2-1", Name: "web", Type: resource.ResourceType{Name: "EC2", Category: resource.CategoryCompute}},
+		{ID: "ecs-1", Name: "cluster", Type: resource.ResourceType{Name: "ECSCluster", Category: resource.CategoryContainers}},
+		{ID: "role-1", Name: "app-role", Type: resource.ResourceType{Name: "IAMRole", Category: resource.CategoryIAM}},
+		// No category on the resource: the mapper classifies it.
+		{ID: "s3-1", Name: "assets", Type: resource.ResourceType{Name: "Bucket"}},
+	}
+	arch := &architecture.Architecture{
+		Resources: resources,
+		Region:    "us-east-1",
+		Provider:  resource.AWS,
+		Variables: []architecture.Variable{{Name: "env", Type: "string", Default: "dev"}},
+		Outputs:   []architecture.Output{{Name: "vpc", Value: "aws_test.main.id"}},
+	}
+
+	out, err := e.Generate(context.Background(), arch, resources, iac.Options{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	files := filesByPath(out)
+
+	wantOrder := []string{"versions.tf", "providers.tf", "networking.tf", "compute.tf", "iam.tf", "storage.tf", "variables.tf", "outputs.tf"}
+	if len(out.Files) != len(wantOrder) {
+		t.Fatalf("expected %d files, got %d: %v", len(wantOrder), len(out.Files), out.Files)
+	}
+	for i, path := range wantOrder {
+		if out.Files[i].Path != path {
+			t.Fatalf("file %d = %q, want %q", i, out.Files[i].Path, path)
+		}
+	}
+
+	checks := map[string]string{
+		"networking.tf": `resource "aws_test" "main"`,
+		"storage.tf":    `resource "aws_test" "assets"`,
+		"iam.tf":        `resource "aws_test" "app_role"`,
+		"versions.tf":   `"~> 5.0"`,
+	}
+	for path, want := range checks {
+		if !strings.Contains(files[path], want) {
+			t.Fatalf("expected %q in %s, got:\n%s", want, path, files[path])
+		}
+	}
+	if !strings.Contains(files["compute.tf"], `"web"`) || !strings.Contains(files["compute.tf"], `"cluster"`) {
+		t.Fatalf("expected compute and container resources in compute.tf, got:\n%s", files["compute.tf"])
+	}
+	if !strings.Contains(files["versions.tf"], "required_version") {
+		t.Fatalf("expected required_version in versions.tf, got:\n%s", files["versions.tf"])
+	}
+}
+
+// configuredMapper is a fake mapper whose provider uses a different local name and a
+// custom provider block, like azurerm.
+type configuredMapper struct{}
+
+func (m *configuredMapper) Provider() string { return "azure" }
+
+func (m *configuredMapper) SupportsResource(resourceType string) bool { return true }
+
+func (m *configuredMapper) MapResource(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	return []tfmapper.TerraformBlock{
+		{Kind: "resource", Labels: []string{"azurerm_test", tfName(res.Name)}},
+	}, nil
+}
+
+func (m *configuredMapper) RequiredProvider() tfmapper.ProviderRequirement {
+	return tfmapper.ProviderRequirement{Source: "hashicorp/azurerm", Version: "~> 3.0", LocalName: "azurerm"}
+}
+
+func (m *configuredMapper) ProviderBlock(region string) tfmapper.TerraformBlock {
+	return tfmapper.TerraformBlock{
+		Kind:         "provider",
+		Labels:       []string{"azurerm"},
+		NestedBlocks: map[string][]tfmapper.NestedBlock{"features": {{}}},
+	}
+}
+
+func TestEngine_Generate_ProviderConfigurer(t *testing.T) {
+	reg := tfmapper.NewRegistry()
+	if err := reg.Register(&configuredMapper{}); err != nil {
+		t.Fatalf("Register mapper error = %v, want nil", err)
+	}
+	e := NewEngine(reg)
+
+	res := &resource.Resource{ID: "rg-1", Name: "main", Type: resource.ResourceType{Name: "ResourceGroup"}}
+	arch := &architecture.Architecture{
+		Resources: []*resource.Resource{res},
+		Region:    "westeurope",
+		Provider:  resource.Azure,
+	}
+
+	out, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	files := filesByPath(out)
+
+	if !strings.Contains(files["versions.tf"], "azurerm = {") || strings.Contains(files["versions.tf"], "azure = {") {
+		t.Fatalf("expected required_providers keyed by the local name, got:\n%s", files["versions.tf"])
+	}
+	if !strings.Contains(files["providers.tf"], `provider "azurerm"`) || !strings.Contains(files["providers.tf"], "features {") {
+		t.Fatalf("expected the mapper's provider block, got:\n%s", files["providers.tf"])
+	}
+	if strings.Contains(files["providers.tf"], "region") {
+		t.Fatalf("expected no region in the azurerm provider block, got:\n%s", files["providers.tf"])
+	}
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// terraformRequiredVersion is the Terraform CLI constraint written to versions.tf.
+const terraformRequiredVersion = ">= 1.5.0"
+
+// categoryFiles maps a resource category to the root file its blocks are written to.
+// Containers share compute.tf; other categories get a file named after the category,
+// and uncategorized resources go to main.tf.
+var categoryFiles = map[string]string{
+	resource.CategoryNetworking: "networking.tf",
+	resource.CategoryCompute:    "compute.tf",
+	resource.CategoryContainers: "compute.tf",
+	resource.CategoryStorage:    "storage.tf",
+	resource.CategoryDatabase:   "database.tf",
+	resource.CategoryIAM:        "iam.tf",
+}
+
+// categoryFile returns the file name for a resource category.
+func categoryFile(category string) string {
+	if file, ok := categoryFiles[category]; ok {
+		return file
+	}
+	if category == "" {
+		return "main.tf"
+	}
+	return tfName(category) + ".tf"
+}
+
+// resourceCategory returns the category of a resource, asking the mapper when
+// the resource type carries none (e.g. architectures built in code).
+func resourceCategory(res *resource.Resource, m tfmapper.ResourceMapper) string {
+	if res.Type.Category != "" {
+		return res.Type.Category
+	}
+	if c, ok := m.(tfmapper.ResourceCategorizer); ok {
+		if category, found := c.ResourceCategory(res.Type.Name); found {
+			return category
+		}
+	}
+	return ""
+}
+
+// versionsBlock builds the terraform {} block pinning the provider plugin.
+// The CLI version constraint is only written for the root module.
+func versionsBlock(provider string, m tfmapper.ResourceMapper, root bool) tfmapper.TerraformBlock {
+	req := tfmapper.ProviderRequirement{Source: "hashicorp/" + provider}
+	if r, ok := m.(tfmapper.ProviderRequirer); ok {
+		req = r.RequiredProvider()
+	}
+
+	name := provider
+	if req.LocalName != "" {
+		name = req.LocalName
+	}
+
+	pin := map[string]tfmapper.TerraformValue{
+		"source": {String: &req.Source},
+	}
+	if req.Version != "" {
+		pin["version"] = tfmapper.TerraformValue{String: &req.Version}
+	}
+
+	block := tfmapper.TerraformBlock{
+		Kind:       "terraform",
+		Attributes: map[string]tfmapper.TerraformValue{},
+		NestedBlocks: map[string][]tfmapper.NestedBlock{
+			"required_providers": {
+				{Attributes: map[string]tfmapper.TerraformValue{name: {Map: pin}}},
+			},
+		},
+	}
+	if root {
+		v := terraformRequiredVersion
+		block.Attributes["required_version"] = tfmapper.TerraformValue{String: &v}
+	}
+	return block
+}
+
+// tfFiles collects blocks per file, preserving the order in which files are first used.
+type tfFiles struct {
+	order  []string
+	blocks map[string][]tfmapper.TerraformBlock
+}
+
+func newTFFiles() *tfFiles {
+	return &tfFiles{blocks: make(map[string][]tfmapper.TerraformBlock)}
+}
+
+func (f *tfFiles) add(path string, blocks ...tfmapper.TerraformBlock) {
+	if len(blocks) == 0 {
+		return
+	}
+	if _, exists := f.blocks[path]; !exists {
+		f.order = append(f.order, path)
+	}
+	f.blocks[path] = append(f.blocks[path], blocks...)
+}
@@ -0,0 +1,392 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// moduleRootType is the domain resource type whose subtree becomes a local module.
+const moduleRootType = "VPC"
+
+// traversalPattern matches dotted references such as "aws_vpc.main.id" or "var.region"
+// inside a Terraform expression.
+var traversalPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_-]*(?:\.[A-Za-z_][A-Za-z0-9_-]*)+`)
+
+// localModule is a network subtree written as a reusable module under modules/<name>.
+type localModule struct {
+	name      string
+	resources []mappedResource
+	variables map[string]tfmapper.Variable
+	outputs   map[string]tfmapper.Output
+	inputs    map[string]tfmapper.TerraformValue
+}
+
+func (m *localModule) dir() string {
+	return "modules/" + m.name
+}
+
+// callBlock builds the root module block that instantiates this module.
+func (m *localModule) callBlock() tfmapper.TerraformBlock {
+	source := "./" + m.dir()
+	attrs := map[string]tfmapper.TerraformValue{
+		"source": {String: &source},
+	}
+	for name, v := range m.inputs {
+		attrs[name] = v
+	}
+	return tfmapper.TerraformBlock{Kind: "module", Labels: []string{m.name}, Attributes: attrs}
+}
+
+func (m *localModule) sortedVariables() []tfmapper.Variable {
+	vars := make([]tfmapper.Variable, 0, len(m.variables))
+	for _, v := range m.variables {
+		vars = append(vars, v)
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+func (m *localModule) sortedOutputs() []tfmapper.Output {
+	outputs := make([]tfmapper.Output, 0, len(m.outputs))
+	for _, o := range m.outputs {
+		outputs = append(outputs, o)
+	}
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].Name < outputs[j].Name })
+	return outputs
+}
+
+// moduleLayout is the result of splitting mapped resources into local modules.
+type moduleLayout struct {
+	root    []mappedResource
+	modules []*localModule
+	outputs []tfmapper.Output // root outputs with references rewritten through modules
+}
+
+// extractModules moves every VPC subtree into its own local module and rewires the
+// references that cross a module boundary:
+//   - root -> module:   aws_subnet.a.id becomes module.<vpc>.aws_subnet_a_id (module output)
+//   - module -> root:   the value is passed in as a module variable
+//   - module -> module: the value flows through the owning module's output
+//   - var.x in a module: the root variable is passed through unchanged
+//
+// depends_on entries pointing across a boundary are redirected to the module (root) or
+// dropped (inside a module), since Terraform cannot address resources in another module.
+func extractModules(mapped []mappedResource, all []*resource.Resource, rootVars []tfmapper.Variable, outputs []tfmapper.Output) *moduleLayout {
+	byID := make(map[string]*resource.Resource, len(all))
+	for _, res := range all {
+		byID[res.ID] = res
+	}
+
+	w := &moduleRewriter{
+		owner:    make(map[string]string),
+		modules:  make(map[string]*localModule),
+		rootVars: make(map[string]tfmapper.Variable, len(rootVars)),
+	}
+	for _, v := range rootVars {
+		w.rootVars[v.Name] = v
+	}
+
+	// Assign each mapped resource to the module of its enclosing VPC.
+	layout := &moduleLayout{}
+	groupOf := make([]string, len(mapped))
+	moduleByRootID := make(map[string]*localModule)
+	usedNames := make(map[string]bool)
+	for i, mr := range mapped {
+		rootID := moduleRootID(mr.res, byID)
+		if rootID == "" {
+			continue
+		}
+		mod, ok := moduleByRootID[rootID]
+		if !ok {
+			mod = &localModule{
+				name:      uniqueModuleName(moduleName(byID[rootID], mapped), usedNames),
+				variables: make(map[string]tfmapper.Variable),
+				outputs:   make(map[string]tfmapper.Output),
+				inputs:    make(map[string]tfmapper.TerraformValue),
+			}
+			moduleByRootID[rootID] = mod
+			w.modules[mod.name] = mod
+			layout.modules = append(layout.modules, mod)
+		}
+		groupOf[i] = mod.name
+	}
+
+	for i, mr := range mapped {
+		for _, b := range mr.blocks {
+			if b.Kind == "resource" && len(b.Labels) == 2 {
+				w.owner[b.Labels[0]+"."+b.Labels[1]] = groupOf[i]
+			}
+		}
+	}
+
+	for i, mr := range mapped {
+		group := groupOf[i]
+		rewritten := mappedResource{res: mr.res, blocks: make([]tfmapper.TerraformBlock, 0, len(mr.blocks))}
+		for _, b := range mr.blocks {
+			rewritten.blocks = append(rewritten.blocks, w.rewriteBlock(b, group))
+		}
+		if group == "" {
+			layout.root = append(layout.root, rewritten)
+		} else {
+			mod := w.modules[group]
+			mod.resources = append(mod.resources, rewritten)
+		}
+	}
+
+	for _, o := range outputs {
+		v, keep := w.rewriteValue(o.Value, "")
+		if !keep {
+			continue
+		}
+		o.Value = v
+		layout.outputs = append(layout.outputs, o)
+	}
+
+	return layout
+}
+
+// moduleRootID walks up the containment chain and returns the ID of the enclosing
+// VPC (or the resource itself if it is one), or "" for resources outside any VPC.
+func moduleRootID(res *resource.Resource, byID map[string]*resource.Resource) string {
+	seen := make(map[string]bool)
+	for cur := res; cur != nil && !seen[cur.ID]; {
+		if cur.Type.Name == moduleRootType {
+			return cur.ID
+		}
+		seen[cur.ID] = true
+		if cur.ParentID == nil || *cur.ParentID == "" {
+			return ""
+		}
+		cur = byID[*cur.ParentID]
+	}
+	return ""
+}
+
+// moduleName uses the Terraform label of the VPC block so module and resource names match.
+func moduleName(root *resource.Resource, mapped []mappedResource) string {
+	for _, mr := range mapped {
+		if mr.res.ID != root.ID {
+			continue
+		}
+		for _, b := range mr.blocks {
+			if b.Kind == "resource" && len(b.Labels) == 2 {
+				return b.Labels[1]
+			}
+		}
+	}
+	return tfName(root.Name)
+}
+
+func uniqueModuleName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// moduleRewriter rewrites references between the root module and local modules.
+type moduleRewriter struct {
+	owner    map[string]string // resource address ("aws_vpc.main") -> module name, "" for root
+	modules  map[string]*localModule
+	rootVars map[string]tfmapper.Variable
+}
+
+func (w *moduleRewriter) rewriteBlock(b tfmapper.TerraformBlock, group string) tfmapper.TerraformBlock {
+	out := b
+	out.Attributes = make(map[string]tfmapper.TerraformValue, len(b.Attributes))
+	for k, v := range b.Attributes {
+		nv, keep := w.rewriteValue(v, group)
+		if !keep {
+			continue
+		}
+		if k == "depends_on" {
+			nv.List = uniqueExprs(nv.List)
+			if len(nv.List) == 0 {
+				continue
+			}
+		}
+		out.Attributes[k] = nv
+	}
+	out.NestedBlocks = w.rewriteNested(b.NestedBlocks, group)
+	return out
+}
+
+func (w *moduleRewriter) rewriteNested(blocks map[string][]tfmapper.NestedBlock, group string) map[string][]tfmapper.NestedBlock {
+	if blocks == nil {
+		return nil
+	}
+	out := make(map[string][]tfmapper.NestedBlock, len(blocks))
+	for kind, list := range blocks {
+		for _, nb := range list {
+			attrs := make(map[string]tfmapper.TerraformValue, len(nb.Attributes))
+			for k, v := range nb.Attributes {
+				if nv, keep := w.rewriteValue(v, group); keep {
+					attrs[k] = nv
+				}
+			}
+			out[kind] = append(out[kind], tfmapper.NestedBlock{
+				Labels:       nb.Labels,
+				Attributes:   attrs,
+				NestedBlocks: w.rewriteNested(nb.NestedBlocks, group),
+			})
+		}
+	}
+	return out
+}
+
+// rewriteValue rewrites every expression in v as seen from group ("" for root).
+// It reports false when the value must be dropped (a bare cross-module address).
+func (w *moduleRewriter) rewriteValue(v tfmapper.TerraformValue, group string) (tfmapper.TerraformValue, bool) {
+	switch {
+	case v.Expr != nil:
+		expr, keep := w.rewriteExpr(string(*v.Expr), group)
+		if !keep {
+			return v, false
+		}
+		e := tfmapper.TerraformExpr(expr)
+		return tfmapper.TerraformValue{Expr: &e}, true
+	case v.List != nil:
+		list := make([]tfmapper.TerraformValue, 0, len(v.List))
+		for _, item := range v.List {
+			if nv, keep := w.rewriteValue(item, group); keep {
+				list = append(list, nv)
+			}
+		}
+		return tfmapper.TerraformValue{List: list}, true
+	case v.Map != nil:
+		m := make(map[string]tfmapper.TerraformValue, len(v.Map))
+		for k, item := range v.Map {
+			if nv, keep := w.rewriteValue(item, group); keep {
+				m[k] = nv
+			}
+		}
+		return tfmapper.TerraformValue{Map: m}, true
+	default:
+		return v, true
+	}
+}
+
+func (w *moduleRewriter) rewriteExpr(expr, group string) (string, bool) {
+	matches := traversalPattern.FindAllStringIndex(expr, -1)
+	if len(matches) == 0 {
+		return expr, true
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		// Skip matches that are part of a string literal or a longer traversal.
+		if start > 0 && strings.ContainsRune(`"._`, rune(expr[start-1])) {
+			continue
+		}
+		replacement, keep := w.rewriteTraversal(expr[start:end], group)
+		if !keep {
+			if start == 0 && end == len(expr) {
+				return "", false
+			}
+			continue
+		}
+		b.WriteString(expr[last:start])
+		b.WriteString(replacement)
+		last = end
+	}
+	b.WriteString(expr[last:])
+	return b.String(), true
+}
+
+func (w *moduleRewriter) rewriteTraversal(trav, group string) (string, bool) {
+	parts := strings.Split(trav, ".")
+
+	if parts[0] == "var" {
+		if group != "" {
+			w.passVariable(w.modules[group], parts[1])
+		}
+		return trav, true
+	}
+
+	addr := parts[0] + "." + parts[1]
+	owner, known := w.owner[addr]
+	if !known || owner == group {
+		return trav, true
+	}
+
+	if len(parts) == 2 {
+		// Bare address, only used by depends_on.
+		if group == "" {
+			return "module." + owner, true
+		}
+		return "", false
+	}
+
+	ref := strings.Join(parts[:3], ".")
+	rest := ""
+	if len(parts) > 3 {
+		rest = "." + strings.Join(parts[3:], ".")
+	}
+	name := tfName(strings.Join(parts[:3], "_"))
+
+	value := ref
+	if owner != "" {
+		src := w.modules[owner]
+		if _, exists := src.outputs[name]; !exists {
+			e := tfmapper.TerraformExpr(ref)
+			src.outputs[name] = tfmapper.Output{Name: name, Value: tfmapper.TerraformValue{Expr: &e}}
+		}
+		value = "module." + owner + "." + name
+	}
+
+	if group == "" {
+		return value + rest, true
+	}
+
+	dst := w.modules[group]
+	if _, exists := dst.variables[name]; !exists {
+		dst.variables[name] = tfmapper.Variable{
+			Name:        name,
+			Description: fmt.Sprintf("Value of %s, passed in from the calling module", ref),
+		}
+		e := tfmapper.TerraformExpr(value)
+		dst.inputs[name] = tfmapper.TerraformValue{Expr: &e}
+	}
+	return "var." + name + rest, true
+}
+
+// passVariable declares a root variable in the module and passes it through.
+func (w *moduleRewriter) passVariable(mod *localModule, name string) {
+	if _, exists := mod.variables[name]; exists {
+		return
+	}
+	v, ok := w.rootVars[name]
+	if !ok {
+		v = tfmapper.Variable{Name: name}
+	}
+	v.Default = nil
+	mod.variables[name] = v
+	e := tfmapper.TerraformExpr("var." + name)
+	mod.inputs[name] = tfmapper.TerraformValue{Expr: &e}
+}
+
+// uniqueExprs drops repeated expressions, e.g. several depends_on entries that now
+// all point at the same module.
+func uniqueExprs(list []tfmapper.TerraformValue) []tfmapper.TerraformValue {
+	seen := make(map[tfmapper.TerraformExpr]bool, len(list))
+	out := list[:0]
+	for _, v := range list {
+		if v.Expr != nil {
+			if seen[*v.Expr] {
+				continue
+			}
+			seen[*v.Expr] = true
+		}
+		out = append(out, v)
+	}
+	return out
+}
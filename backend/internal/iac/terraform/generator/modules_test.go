@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// refMapper emits one block per resource with the expressions listed in metadata
+// "refs" (attribute -> expression) and "deps" (depends_on addresses).
+type refMapper struct{}
+
+func (m *refMapper) Provider() string { return "aws" }
+
+func (m *refMapper) SupportsResource(resourceType string) bool { return true }
+
+func (m *refMapper) MapResource(res *resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	attrs := make(map[string]tfmapper.TerraformValue)
+	if refs, ok := res.Metadata["refs"].(map[string]string); ok {
+		for k, v := range refs {
+			e := tfmapper.TerraformExpr(v)
+			attrs[k] = tfmapper.TerraformValue{Expr: &e}
+		}
+	}
+	if deps, ok := res.Metadata["deps"].([]string); ok {
+		list := make([]tfmapper.TerraformValue, 0, len(deps))
+		for _, d := range deps {
+			e := tfmapper.TerraformExpr(d)
+			list = append(list, tfmapper.TerraformValue{Expr: &e})
+		}
+		attrs["depends_on"] = tfmapper.TerraformValue{List: list}
+	}
+	return []tfmapper.TerraformBlock{
+		{Kind: "resource", Labels: []string{"aws_test", res.Name}, Attributes: attrs},
+	}, nil
+}
+
+func TestEngine_Generate_VPCModules(t *testing.T) {
+	reg := tfmapper.NewRegistry()
+	if err := reg.Register(&refMapper{}); err != nil {
+		t.Fatalf("Register mapper error = %v, want nil", err)
+	}
+	e := NewEngine(reg)
+
+	vpcID, subnetID := "vpc-1", "subnet-1"
+	resources := []*resource.Resource{
+		{ID: "role-1", Name: "role", Type: resource.ResourceType{Name: "IAMRole", Category: resource.CategoryIAM}},
+		{ID: vpcID, Name: "main", Type: resource.ResourceType{Name: "VPC", Category: resource.CategoryNetworking},
+			Metadata: map[string]interface{}{"refs": map[string]string{"cidr_block": "var.vpc_cidr"}}},
+		{ID: subnetID, Name: "public", ParentID: &vpcID, Type: resource.ResourceType{Name: "Subnet", Category: resource.CategoryNetworking},
+			Metadata: map[string]interface{}{"refs": map[string]string{"vpc_id": "aws_test.main.id"}}},
+		{ID: "ec2-1", Name: "web", ParentID: &subnetID, Type: resource.ResourceType{Name: "EC2", Category: resource.CategoryCompute},
+			Metadata: map[string]interface{}{
+				"refs": map[string]string{"subnet_id": "aws_test.public.id", "iam_instance_profile": "aws_test.role.name"},
+				"deps": []string{"aws_test.role", "aws_test.public"},
+			}},
+		{ID: "fn-1", Name: "fn", Type: resource.ResourceType{Name: "Lambda", Category: resource.CategoryCompute},
+			Metadata: map[string]interface{}{
+				"refs": map[string]string{"subnet_id": "aws_test.public.id"},
+				"deps": []string{"aws_test.public", "aws_test.web", "aws_test.role"},
+			}},
+	}
+	arch := &architecture.Architecture{
+		Resources: resources,
+		Region:    "us-east-1",
+		Provider:  resource.AWS,
+		Variables: []architecture.Variable{{Name: "vpc_cidr", Type: "string", Default: "10.0.0.0/16"}},
+		Outputs:   []architecture.Output{{Name: "web_id", Value: "aws_test.web.id"}},
+	}
+
+	out, err := e.Generate(context.Background(), arch, resources, iac.Options{Modules: true})
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	files := filesByPath(out)
+
+	checks := []struct {
+		path string
+		want []string
+		not  []string
+	}{
+		{"main.tf", []string{`module "main"`, `"./modules/main"`, "aws_test_role_name", "aws_test.role.name", "vpc_cidr", "var.vpc_cidr"}, nil},
+		{"iam.tf", []string{`resource "aws_test" "role"`}, nil},
+		{"compute.tf", []string{`resource "aws_test" "fn"`, "module.main.aws_test_public_id", "[module.main,", "aws_test.role]"}, []string{`"web"`}},
+		{"outputs.tf", []string{"module.main.aws_test_web_id"}, nil},
+		{"modules/main/main.tf", []string{`"main"`, `"public"`, `"web"`, "var.aws_test_role_name", "aws_test.public.id", "var.vpc_cidr", "[aws_test.public]"}, []string{"aws_test.role"}},
+		{"modules/main/variables.tf", []string{`variable "aws_test_role_name"`, `variable "vpc_cidr"`}, []string{"10.0.0.0/16"}},
+		{"modules/main/outputs.tf", []string{`output "aws_test_public_id"`, `output "aws_test_web_id"`}, nil},
+		{"modules/main/versions.tf", []string{"required_providers"}, []string{"required_version"}},
+	}
+	for _, c := range checks {
+		content, ok := files[c.path]
+		if !ok {
+			t.Fatalf("expected %s to be generated, got files %v", c.path, out.Files)
+		}
+		for _, want := range c.want {
+			if !strings.Contains(content, want) {
+				t.Fatalf("expected %q in %s, got:\n%s", want, c.path, content)
+			}
+		}
+		for _, not := range c.not {
+			if strings.Contains(content, not) {
+				t.Fatalf("did not expect %q in %s, got:\n%s", not, c.path, content)
+			}
+		}
+	}
+	if _, ok := files["networking.tf"]; ok {
+		t.Fatalf("expected VPC resources to move into the module, got networking.tf:\n%s", files["networking.tf"])
+	}
+}
+
+func TestEngine_Generate_ModulesDisabledByDefault(t *testing.T) {
+	reg := tfmapper.NewRegistry()
+	if err := reg.Register(&refMapper{}); err != nil {
+		t.Fatalf("Register mapper error = %v, want nil", err)
+	}
+	e := NewEngine(reg)
+
+	res := &resource.Resource{ID: "vpc-1", Name: "main", Type: resource.ResourceType{Name: "VPC", Category: resource.CategoryNetworking}}
+	arch := &architecture.Architecture{Resources: []*resource.Resource{res}, Provider: resource.AWS}
+
+	out, err := e.Generate(context.Background(), arch, arch.Resources, iac.Options{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	for _, f := range out.Files {
+		if strings.HasPrefix(f.Path, "modules/") {
+			t.Fatalf("did not expect module files without iac.Options.Modules, got %s", f.Path)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package importer reads existing Terraform configuration back into the diagram IR,
+// the reverse of the generator/writer path.
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/parser"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+)
+
+// regionNodeID is the ID of the synthetic region container every top-level node sits in.
+const regionNodeID = "region"
+
+// Result is an imported diagram plus everything that could not be carried over.
+type Result struct {
+	Diagram *parser.IRDiagram      `json:"diagram"`
+	Region  string                 `json:"region,omitempty"`
+	Issues  []tfmapper.ImportIssue `json:"issues"`
+
+	// Addresses maps each drawn resource's Terraform address to its node ID.
+	Addresses map[string]string `json:"-"`
+}
+
+// Import maps a parsed module onto the diagram IR using the provider's mapper, which
+// must implement tfmapper.ResourceImporter. Containment comes from the parent IDs the
+// mapper resolves (e.g. vpc_id, subnet_id) and dependencies become dependency edges.
+func Import(mod *Module, m tfmapper.ResourceMapper) (*Result, error) {
+	if mod == nil {
+		return nil, fmt.Errorf("module is nil")
+	}
+	if m == nil {
+		return nil, fmt.Errorf("mapper is nil")
+	}
+	imp, ok := m.(tfmapper.ResourceImporter)
+	if !ok {
+		return nil, fmt.Errorf("terraform mapper for %q cannot import resources", m.Provider())
+	}
+
+	ids := nodeIDs(mod.Resources)
+	resolve := func(address string) (string, bool) {
+		id, ok := ids[address]
+		return id, ok
+	}
+
+	imported, issues, err := imp.ImportResources(mod.Resources, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	region := providerRegion(mod, m.Provider())
+	diagram := &parser.IRDiagram{
+		Nodes:     make([]parser.IRNode, 0, len(imported)+1),
+		Edges:     make([]parser.IREdge, 0),
+		Variables: make([]parser.IRVariable, 0, len(mod.Variables)),
+		Outputs:   make([]parser.IROutput, 0, len(mod.Outputs)),
+		Policies:  make([]parser.IRPolicy, 0),
+	}
+
+	present := make(map[string]bool, len(imported))
+	for _, ir := range imported {
+		present[ir.Resource.ID] = true
+	}
+
+	var rootParent *string
+	if region != "" {
+		id := regionNodeID
+		rootParent = &id
+		diagram.Nodes = append(diagram.Nodes, parser.IRNode{
+			ID:   regionNodeID,
+			Type: "containerNode",
+			Data: parser.IRNodeData{
+				Label:        region,
+				ResourceType: "region",
+				Config:       map[string]interface{}{"name": region},
+			},
+		})
+	}
+
+	parents := make(map[string]bool)
+	for _, ir := range imported {
+		if p := ir.Resource.ParentID; p != nil && present[*p] {
+			parents[*p] = true
+		}
+	}
+
+	for _, ir := range imported {
+		res := ir.Resource
+		parentID := rootParent
+		if res.ParentID != nil && present[*res.ParentID] {
+			parentID = res.ParentID
+		}
+		nodeType := "resourceNode"
+		if parents[res.ID] {
+			nodeType = "containerNode"
+		}
+		config := res.Metadata
+		if config == nil {
+			config = make(map[string]interface{})
+		}
+		diagram.Nodes = append(diagram.Nodes, parser.IRNode{
+			ID:       res.ID,
+			Type:     nodeType,
+			ParentID: parentID,
+			Data: parser.IRNodeData{
+				Label:        res.Name,
+				ResourceType: ir.IRType,
+				Config:       config,
+			},
+		})
+
+		for _, dep := range res.DependsOn {
+			if !present[dep] {
+				continue
+			}
+			edgeType := "dependency"
+			diagram.Edges = append(diagram.Edges, parser.IREdge{
+				ID:     fmt.Sprintf("%s-%s", res.ID, dep),
+				Source: res.ID,
+				Target: dep,
+				Type:   &edgeType,
+			})
+		}
+	}
+
+	for _, v := range mod.Variables {
+		irVar := parser.IRVariable{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+			Sensitive:   v.Sensitive,
+		}
+		if v.Default != nil {
+			irVar.Default = Interface(*v.Default)
+		}
+		diagram.Variables = append(diagram.Variables, irVar)
+	}
+	for _, o := range mod.Outputs {
+		diagram.Outputs = append(diagram.Outputs, parser.IROutput{
+			Name:        o.Name,
+			Value:       fmt.Sprint(Interface(o.Value)),
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+		})
+	}
+
+	Layout(diagram.Nodes)
+
+	addresses := make(map[string]string, len(imported))
+	for address, id := range ids {
+		if present[id] {
+			addresses[address] = id
+		}
+	}
+
+	return &Result{
+		Diagram:   diagram,
+		Region:    region,
+		Issues:    append(append([]tfmapper.ImportIssue{}, mod.Ignored...), issues...),
+		Addresses: addresses,
+	}, nil
+}
+
+// Interface converts a TerraformValue into the plain JSON-like value diagram configs use.
+// Expressions are returned as their source text (e.g. "var.vpc_cidr").
+func Interface(v tfmapper.TerraformValue) interface{} {
+	switch {
+	case v.String != nil:
+		return *v.String
+	case v.Number != nil:
+		return *v.Number
+	case v.Bool != nil:
+		return *v.Bool
+	case v.Expr != nil:
+		return string(*v.Expr)
+	case v.Map != nil:
+		m := make(map[string]interface{}, len(v.Map))
+		for k, item := range v.Map {
+			m[k] = Interface(item)
+		}
+		return m
+	case v.List != nil:
+		list := make([]interface{}, 0, len(v.List))
+		for _, item := range v.List {
+			list = append(list, Interface(item))
+		}
+		return list
+	}
+	return nil
+}
+
+// nodeIDs assigns each resource block a diagram node ID. The Terraform name is used as-is
+// so regenerated code keeps the same addresses; names shared by several resource types
+// are prefixed with the type to stay unique.
+func nodeIDs(blocks []tfmapper.TerraformBlock) map[string]string {
+	count := make(map[string]int)
+	for _, b := range blocks {
+		count[b.Labels[1]]++
+	}
+	count[regionNodeID]++ // reserved for the region container
+
+	ids := make(map[string]string, len(blocks))
+	for _, b := range blocks {
+		address := b.Labels[0] + "." + b.Labels[1]
+		if count[b.Labels[1]] > 1 {
+			ids[address] = b.Labels[0] + "_" + b.Labels[1]
+		} else {
+			ids[address] = b.Labels[1]
+		}
+	}
+	return ids
+}
+
+// providerRegion returns the region of the default (non-aliased) provider block,
+// following a var.* reference to the variable's default.
+func providerRegion(mod *Module, provider string) string {
+	defaults := make(map[string]string)
+	for _, v := range mod.Variables {
+		if v.Default != nil && v.Default.String != nil {
+			defaults["var."+v.Name] = *v.Default.String
+		}
+	}
+
+	blocks := append([]tfmapper.TerraformBlock(nil), mod.Providers...)
+	sort.SliceStable(blocks, func(i, j int) bool {
+		_, ai := blocks[i].Attributes["alias"]
+		_, aj := blocks[j].Attributes["alias"]
+		return !ai && aj
+	})
+	for _, b := range blocks {
+		if len(b.Labels) == 0 || b.Labels[0] != provider {
+			continue
+		}
+		region, ok := b.Attributes["region"]
+		switch {
+		case !ok:
+			continue
+		case region.String != nil:
+			return *region.String
+		case region.Expr != nil:
+			if def, ok := defaults[strings.TrimSpace(string(*region.Expr))]; ok {
+				return def
+			}
+		}
+	}
+	return ""
+}
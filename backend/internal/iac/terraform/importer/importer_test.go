@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// fakeImporter draws every block as an "item" resource whose parent is the "parent" attribute.
+type fakeImporter struct{}
+
+func (fakeImporter) Provider() string             { return "test" }
+func (fakeImporter) SupportsResource(string) bool { return true }
+func (fakeImporter) MapResource(*resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	return nil, nil
+}
+
+func (fakeImporter) ImportResources(blocks []tfmapper.TerraformBlock, resolve func(string) (string, bool)) ([]tfmapper.ImportedResource, []tfmapper.ImportIssue, error) {
+	var out []tfmapper.ImportedResource
+	for _, b := range blocks {
+		id, _ := resolve(b.Labels[0] + "." + b.Labels[1])
+		res := &resource.Resource{ID: id, Name: b.Labels[1], Metadata: map[string]interface{}{}}
+		if p, ok := b.Attributes["parent"]; ok && p.Expr != nil {
+			ref, _ := tfmapper.ParseReference(*p.Expr)
+			if parentID, ok := resolve(ref.Address()); ok {
+				res.ParentID = &parentID
+			}
+		}
+		out = append(out, tfmapper.ImportedResource{Resource: res, IRType: "item"})
+	}
+	return out, nil, nil
+}
+
+func TestParseFiles_ConvertsValues(t *testing.T) {
+	mod, err := ParseFiles(map[string][]byte{"main.tf": []byte(`
+resource "test_thing" "a" {
+  name    = "a"
+  size    = 3
+  enabled = true
+  tags    = { Name = "a", Team = "core" }
+  ids     = [test_thing.b.id, "literal"]
+  cidr    = var.cidr
+  label   = "${var.prefix}-a"
+
+  rule {
+    port = 443
+  }
+
+  backend "s3" {
+    bucket = "x"
+  }
+}
+
+locals {
+  x = 1
+}
+`)})
+	if err != nil {
+		t.Fatalf("ParseFiles() error = %v", err)
+	}
+	if len(mod.Resources) != 1 {
+		t.Fatalf("resources = %d, want 1", len(mod.Resources))
+	}
+	attrs := mod.Resources[0].Attributes
+
+	if got := attrs["name"].String; got == nil || *got != "a" {
+		t.Errorf("name = %+v, want string a", attrs["name"])
+	}
+	if got := attrs["size"].Number; got == nil || *got != 3 {
+		t.Errorf("size = %+v, want number 3", attrs["size"])
+	}
+	if got := attrs["enabled"].Bool; got == nil || !*got {
+		t.Errorf("enabled = %+v, want true", attrs["enabled"])
+	}
+	if got := attrs["tags"].Map["Team"].String; got == nil || *got != "core" {
+		t.Errorf("tags = %+v, want Team=core", attrs["tags"])
+	}
+	ids := attrs["ids"].List
+	if len(ids) != 2 || ids[0].Expr == nil || *ids[0].Expr != "test_thing.b.id" || ids[1].String == nil {
+		t.Errorf("ids = %+v, want [expr, string]", ids)
+	}
+	if got := attrs["cidr"].Expr; got == nil || *got != "var.cidr" {
+		t.Errorf("cidr = %+v, want expr var.cidr", attrs["cidr"])
+	}
+	if got := attrs["label"].Expr; got == nil || *got != `"${var.prefix}-a"` {
+		t.Errorf("label = %+v, want template expression", attrs["label"])
+	}
+
+	nested := mod.Resources[0].NestedBlocks
+	if got := nested["rule"]; len(got) != 1 || *got[0].Attributes["port"].Number != 443 {
+		t.Errorf("rule = %+v, want one block with port 443", got)
+	}
+	if got := nested["backend"]; len(got) != 1 || len(got[0].Labels) != 1 || got[0].Labels[0] != "s3" {
+		t.Errorf("backend = %+v, want labelled nested block", got)
+	}
+	if len(mod.Ignored) != 1 || mod.Ignored[0].Address != "locals" {
+		t.Errorf("ignored = %+v, want locals", mod.Ignored)
+	}
+}
+
+func TestParseFiles_SyntaxError(t *testing.T) {
+	_, err := ParseFiles(map[string][]byte{"broken.tf": []byte(`resource "a" "b" {`)})
+	if err == nil || !strings.Contains(err.Error(), "broken.tf") {
+		t.Fatalf("ParseFiles() error = %v, want a parse error naming the file", err)
+	}
+}
+
+func TestImport_BuildsDiagram(t *testing.T) {
+	mod, err := ParseFiles(map[string][]byte{
+		"main.tf": []byte(`
+provider "test" {
+  region = "us-east-1"
+}
+
+resource "test_net" "main" {}
+
+resource "test_host" "main" {
+  parent = test_net.main.id
+}
+
+resource "test_host" "web" {
+  parent = test_net.main.id
+}
+`),
+		"variables.tf": []byte(`
+variable "size" {
+  type        = number
+  default     = 2
+  description = "hosts"
+}
+
+output "web" {
+  value     = test_host.web.id
+  sensitive = true
+}
+`),
+	})
+	if err != nil {
+		t.Fatalf("ParseFiles() error = %v", err)
+	}
+
+	result, err := Import(mod, fakeImporter{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Region != "us-east-1" {
+		t.Errorf("region = %q, want us-east-1", result.Region)
+	}
+
+	nodes := make(map[string]int)
+	for i, n := range result.Diagram.Nodes {
+		nodes[n.ID] = i
+	}
+	for _, id := range []string{"region", "test_net_main", "test_host_main", "web"} {
+		if _, ok := nodes[id]; !ok {
+			t.Fatalf("node %q missing; nodes = %v", id, nodes)
+		}
+	}
+
+	if got := result.Addresses["test_host.web"]; got != "web" {
+		t.Errorf("address test_host.web = %q, want node web", got)
+	}
+
+	net := result.Diagram.Nodes[nodes["test_net_main"]]
+	if net.Type != "containerNode" || net.ParentID == nil || *net.ParentID != "region" {
+		t.Errorf("net = %+v, want container inside the region", net)
+	}
+	if net.Width == nil || *net.Width <= layoutNodeWidth {
+		t.Errorf("net width = %v, want sized to fit children", net.Width)
+	}
+	web := result.Diagram.Nodes[nodes["web"]]
+	if web.Type != "resourceNode" || web.ParentID == nil || *web.ParentID != "test_net_main" {
+		t.Errorf("web = %+v, want resource inside test_net_main", web)
+	}
+	host := result.Diagram.Nodes[nodes["test_host_main"]]
+	if web.Position == nil || host.Position == nil || *web.Position == *host.Position {
+		t.Errorf("siblings overlap: %+v / %+v", host.Position, web.Position)
+	}
+
+	if len(result.Diagram.Variables) != 1 || result.Diagram.Variables[0].Default != 2.0 || result.Diagram.Variables[0].Type != "number" {
+		t.Errorf("variables = %+v", result.Diagram.Variables)
+	}
+	if len(result.Diagram.Outputs) != 1 || result.Diagram.Outputs[0].Value != "test_host.web.id" || !result.Diagram.Outputs[0].Sensitive {
+		t.Errorf("outputs = %+v", result.Diagram.Outputs)
+	}
+}
+
+func TestImport_RequiresResourceImporter(t *testing.T) {
+	_, err := Import(&Module{}, &tfmapperOnly{})
+	if err == nil || !strings.Contains(err.Error(), "cannot import") {
+		t.Fatalf("Import() error = %v, want mapper capability error", err)
+	}
+}
+
+type tfmapperOnly struct{}
+
+func (tfmapperOnly) Provider() string             { return "test" }
+func (tfmapperOnly) SupportsResource(string) bool { return true }
+func (tfmapperOnly) MapResource(*resource.Resource) ([]tfmapper.TerraformBlock, error) {
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"math"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/parser"
+)
+
+// Layout sizes used for imported diagrams. Positions are relative to the parent
+// container, as the canvas expects.
+const (
+	layoutNodeWidth  = 180.0
+	layoutNodeHeight = 80.0
+	layoutPadding    = 40.0
+	layoutHeader     = 60.0
+	layoutGap        = 40.0
+)
+
+// Layout places nodes on a grid inside their parent containers and sizes each
+// container to fit its children. Nodes keep their order within a container.
+func Layout(nodes []parser.IRNode) {
+	index := make(map[string]int, len(nodes))
+	for i := range nodes {
+		index[nodes[i].ID] = i
+	}
+
+	children := make(map[string][]int)
+	var roots []int
+	for i := range nodes {
+		if p := nodes[i].ParentID; p != nil {
+			if _, ok := index[*p]; ok {
+				children[*p] = append(children[*p], i)
+				continue
+			}
+		}
+		roots = append(roots, i)
+	}
+
+	type size struct{ w, h float64 }
+	sizes := make(map[int]size, len(nodes))
+
+	var measure func(i int) size
+	measure = func(i int) size {
+		if s, ok := sizes[i]; ok {
+			return s
+		}
+		kids := children[nodes[i].ID]
+		if len(kids) == 0 {
+			sizes[i] = size{layoutNodeWidth, layoutNodeHeight}
+			return sizes[i]
+		}
+		cols, rows, cellW, cellH := grid(kids, func(k int) (float64, float64) {
+			s := measure(k)
+			return s.w, s.h
+		})
+		s := size{
+			w: 2*layoutPadding + float64(cols)*cellW + float64(cols-1)*layoutGap,
+			h: layoutHeader + layoutPadding + float64(rows)*cellH + float64(rows-1)*layoutGap,
+		}
+		sizes[i] = s
+		return s
+	}
+
+	var place func(members []int, originX, originY float64)
+	place = func(members []int, originX, originY float64) {
+		cols, _, cellW, cellH := grid(members, func(k int) (float64, float64) {
+			s := measure(k)
+			return s.w, s.h
+		})
+		for n, k := range members {
+			col, row := n%cols, n/cols
+			nodes[k].Position = &parser.IRPosition{
+				X: originX + float64(col)*(cellW+layoutGap),
+				Y: originY + float64(row)*(cellH+layoutGap),
+			}
+			if kids := children[nodes[k].ID]; len(kids) > 0 {
+				s := measure(k)
+				w, h := s.w, s.h
+				nodes[k].Width = &w
+				nodes[k].Height = &h
+				place(kids, layoutPadding, layoutHeader)
+			}
+		}
+	}
+
+	place(roots, 0, 0)
+}
+
+// grid picks a roughly square grid for the members and the cell size that fits the largest.
+func grid(members []int, dims func(int) (float64, float64)) (cols, rows int, cellW, cellH float64) {
+	cols = int(math.Ceil(math.Sqrt(float64(len(members)))))
+	if cols < 1 {
+		cols = 1
+	}
+	rows = (len(members) + cols - 1) / cols
+	for _, k := range members {
+		w, h := dims(k)
+		cellW = math.Max(cellW, w)
+		cellH = math.Max(cellH, h)
+	}
+	return cols, rows, cellW, cellH
+}
@@ -0,0 +1,243 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Module is the subset of a Terraform root module the importer understands,
+// expressed in the same IR the generator writes.
+type Module struct {
+	Providers []tfmapper.TerraformBlock
+	Resources []tfmapper.TerraformBlock
+	Variables []tfmapper.Variable
+	Outputs   []tfmapper.Output
+
+	// Ignored lists blocks that have no diagram equivalent (data sources, modules, locals).
+	Ignored []tfmapper.ImportIssue
+}
+
+// ParseFiles parses .tf sources keyed by file name. Files are read in name order so
+// the result is stable. Literal values become typed TerraformValues; anything that
+// needs evaluation (references, function calls, interpolations) is kept as an expression.
+func ParseFiles(files map[string][]byte) (*Module, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mod := &Module{}
+	for _, name := range names {
+		if err := mod.parseFile(name, files[name]); err != nil {
+			return nil, err
+		}
+	}
+	return mod, nil
+}
+
+func (mod *Module) parseFile(name string, src []byte) error {
+	file, diags := hclsyntax.ParseConfig(src, name, hcl.InitialPos)
+	if diags.HasErrors() {
+		return fmt.Errorf("parse %s: %w", name, diags)
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return fmt.Errorf("parse %s: unexpected body type %T", name, file.Body)
+	}
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "resource":
+			if len(block.Labels) != 2 {
+				return fmt.Errorf("%s: resource block needs a type and a name", block.DefRange())
+			}
+			mod.Resources = append(mod.Resources, convertBlock(block, src))
+		case "provider":
+			mod.Providers = append(mod.Providers, convertBlock(block, src))
+		case "variable":
+			v, err := convertVariable(block, src)
+			if err != nil {
+				return err
+			}
+			mod.Variables = append(mod.Variables, v)
+		case "output":
+			o, err := convertOutput(block, src)
+			if err != nil {
+				return err
+			}
+			mod.Outputs = append(mod.Outputs, o)
+		case "data", "module", "locals":
+			mod.Ignored = append(mod.Ignored, tfmapper.ImportIssue{
+				Address: strings.Join(append([]string{block.Type}, block.Labels...), "."),
+				Message: block.Type + " blocks are not imported",
+			})
+		}
+		// terraform {} (versions, backend) and other blocks are regenerated, not imported.
+	}
+	return nil
+}
+
+func convertBlock(block *hclsyntax.Block, src []byte) tfmapper.TerraformBlock {
+	nested := convertNested(block.Body, src)
+	return tfmapper.TerraformBlock{
+		Kind:         block.Type,
+		Labels:       block.Labels,
+		Attributes:   convertAttributes(block.Body, src),
+		NestedBlocks: nested.NestedBlocks,
+	}
+}
+
+func convertNested(body *hclsyntax.Body, src []byte) tfmapper.NestedBlock {
+	out := tfmapper.NestedBlock{Attributes: convertAttributes(body, src)}
+	for _, child := range body.Blocks {
+		if out.NestedBlocks == nil {
+			out.NestedBlocks = make(map[string][]tfmapper.NestedBlock)
+		}
+		nb := convertNested(child.Body, src)
+		nb.Labels = child.Labels
+		out.NestedBlocks[child.Type] = append(out.NestedBlocks[child.Type], nb)
+	}
+	return out
+}
+
+func convertAttributes(body *hclsyntax.Body, src []byte) map[string]tfmapper.TerraformValue {
+	attrs := make(map[string]tfmapper.TerraformValue, len(body.Attributes))
+	for name, attr := range body.Attributes {
+		attrs[name] = convertExpr(attr.Expr, src)
+	}
+	return attrs
+}
+
+// convertExpr keeps list and object structure so that individual elements
+// (e.g. security group references) stay addressable.
+func convertExpr(expr hclsyntax.Expression, src []byte) tfmapper.TerraformValue {
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		list := make([]tfmapper.TerraformValue, 0, len(e.Exprs))
+		for _, item := range e.Exprs {
+			list = append(list, convertExpr(item, src))
+		}
+		return tfmapper.TerraformValue{List: list}
+	case *hclsyntax.ObjectConsExpr:
+		m := make(map[string]tfmapper.TerraformValue, len(e.Items))
+		for _, item := range e.Items {
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || key.IsNull() || !key.IsKnown() || key.Type() != cty.String {
+				return exprValue(expr, src)
+			}
+			m[key.AsString()] = convertExpr(item.ValueExpr, src)
+		}
+		return tfmapper.TerraformValue{Map: m}
+	}
+
+	if len(expr.Variables()) == 0 {
+		if v, diags := expr.Value(nil); !diags.HasErrors() {
+			if tv, ok := fromCty(v); ok {
+				return tv
+			}
+		}
+	}
+	return exprValue(expr, src)
+}
+
+func exprValue(expr hclsyntax.Expression, src []byte) tfmapper.TerraformValue {
+	text := tfmapper.TerraformExpr(strings.TrimSpace(string(expr.Range().SliceBytes(src))))
+	return tfmapper.TerraformValue{Expr: &text}
+}
+
+func fromCty(v cty.Value) (tfmapper.TerraformValue, bool) {
+	if v.IsNull() || !v.IsWhollyKnown() {
+		return tfmapper.TerraformValue{}, false
+	}
+	t := v.Type()
+	switch {
+	case t == cty.String:
+		s := v.AsString()
+		return tfmapper.TerraformValue{String: &s}, true
+	case t == cty.Number:
+		f, _ := v.AsBigFloat().Float64()
+		return tfmapper.TerraformValue{Number: &f}, true
+	case t == cty.Bool:
+		b := v.True()
+		return tfmapper.TerraformValue{Bool: &b}, true
+	case t.IsListType() || t.IsTupleType() || t.IsSetType():
+		list := make([]tfmapper.TerraformValue, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			tv, ok := fromCty(ev)
+			if !ok {
+				return tfmapper.TerraformValue{}, false
+			}
+			list = append(list, tv)
+		}
+		return tfmapper.TerraformValue{List: list}, true
+	case t.IsMapType() || t.IsObjectType():
+		m := make(map[string]tfmapper.TerraformValue, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			tv, ok := fromCty(ev)
+			if !ok {
+				return tfmapper.TerraformValue{}, false
+			}
+			m[k.AsString()] = tv
+		}
+		return tfmapper.TerraformValue{Map: m}, true
+	}
+	return tfmapper.TerraformValue{}, false
+}
+
+func convertVariable(block *hclsyntax.Block, src []byte) (tfmapper.Variable, error) {
+	if len(block.Labels) != 1 {
+		return tfmapper.Variable{}, fmt.Errorf("%s: variable block needs a name", block.DefRange())
+	}
+	v := tfmapper.Variable{Name: block.Labels[0], Type: "string"}
+	attrs := block.Body.Attributes
+	if attr, ok := attrs["type"]; ok {
+		v.Type = strings.TrimSpace(string(attr.Expr.Range().SliceBytes(src)))
+	}
+	if attr, ok := attrs["default"]; ok {
+		def := convertExpr(attr.Expr, src)
+		v.Default = &def
+	}
+	if attr, ok := attrs["description"]; ok {
+		if s := convertExpr(attr.Expr, src).String; s != nil {
+			v.Description = *s
+		}
+	}
+	if attr, ok := attrs["sensitive"]; ok {
+		if b := convertExpr(attr.Expr, src).Bool; b != nil {
+			v.Sensitive = *b
+		}
+	}
+	return v, nil
+}
+
+func convertOutput(block *hclsyntax.Block, src []byte) (tfmapper.Output, error) {
+	if len(block.Labels) != 1 {
+		return tfmapper.Output{}, fmt.Errorf("%s: output block needs a name", block.DefRange())
+	}
+	attrs := block.Body.Attributes
+	value, ok := attrs["value"]
+	if !ok {
+		return tfmapper.Output{}, fmt.Errorf("%s: output %q has no value", block.DefRange(), block.Labels[0])
+	}
+	o := tfmapper.Output{Name: block.Labels[0], Value: exprValue(value.Expr, src)}
+	if attr, ok := attrs["description"]; ok {
+		if s := convertExpr(attr.Expr, src).String; s != nil {
+			o.Description = *s
+		}
+	}
+	if attr, ok := attrs["sensitive"]; ok {
+		if b := convertExpr(attr.Expr, src).Bool; b != nil {
+			o.Sensitive = *b
+		}
+	}
+	return o, nil
+}
@@ -1,6 +1,9 @@
 package mapper
 
-import "github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+import (
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
 
 // ResourceMapper maps a domain resource into one or more Terraform blocks.
 // Implementations must be provider-specific (AWS/GCP/Azure...), but the interface
@@ -15,3 +18,63 @@ type ResourceMapper interface {
 	// The returned blocks are expected to be in a stable order.
 	MapResource(res *resource.Resource) ([]TerraformBlock, error)
 }
+
+// ProviderRequirement pins a provider plugin in the required_providers block of versions.tf.
+type ProviderRequirement struct {
+	Source  string // e.g. "hashicorp/aws"
+	Version string // version constraint, e.g. "~> 5.0"
+	// LocalName is the provider's name in required_providers when it differs from the
+	// mapper's Provider(), e.g. "azurerm" for "azure".
+	LocalName string
+}
+
+// ProviderRequirer is optionally implemented by mappers that pin their provider plugin.
+// Mappers that don't implement it are written as "hashicorp/<provider>" without a constraint.
+type ProviderRequirer interface {
+	RequiredProvider() ProviderRequirement
+}
+
+// ProviderConfigurer is optionally implemented by mappers whose provider block is not the
+// default provider "<provider>" { region = ... } (e.g. azurerm requires a features {} block
+// and sets the location on each resource instead).
+type ProviderConfigurer interface {
+	ProviderBlock(region string) TerraformBlock
+}
+
+// ResourceCategorizer is optionally implemented by mappers that can classify a domain
+// resource type (e.g. "Networking") when the resource itself carries no category.
+type ResourceCategorizer interface {
+	ResourceCategory(resourceType string) (string, bool)
+}
+
+// BackendBootstrapper is optionally implemented by mappers that can generate the
+// resources a state backend needs (e.g. an S3 bucket and a DynamoDB lock table).
+// The blocks are written to a separate bootstrap configuration that uses local state.
+type BackendBootstrapper interface {
+	BootstrapBackend(backend iac.StateBackend) ([]TerraformBlock, error)
+}
+
+// ResourceImporter is optionally implemented by mappers that can turn existing Terraform
+// resource blocks back into domain resources (the inverse of MapResource).
+//
+// resolve returns the resource ID the importer assigned to a Terraform address such as
+// "aws_vpc.main"; mappers use it for ParentID, DependsOn and ID-valued metadata.
+// Blocks that only configure another resource (e.g. a standalone route) may be folded
+// into it. Blocks that cannot be imported, and attributes that cannot be represented,
+// are reported as issues rather than failing the import.
+type ResourceImporter interface {
+	ImportResources(blocks []TerraformBlock, resolve func(address string) (string, bool)) ([]ImportedResource, []ImportIssue, error)
+}
+
+// ImportedResource is a domain resource recovered from Terraform, together with the
+// diagram (IR) resource type used to draw it.
+type ImportedResource struct {
+	Resource *resource.Resource
+	IRType   string // e.g. "vpc", "security-group"
+}
+
+// ImportIssue describes a block or attribute that was skipped during import.
+type ImportIssue struct {
+	Address string `json:"address"` // e.g. "aws_instance.web" or "aws_instance.web.ami"
+	Message string `json:"message"`
+}
@@ -1,5 +1,10 @@
 package mapper
 
+import (
+	"regexp"
+	"strings"
+)
+
 // TerraformExpr represents an HCL expression that should be written as-is
 // (e.g. "aws_vpc.main.id") rather than as a quoted string literal.
 type TerraformExpr string
@@ -31,6 +36,7 @@ type TerraformBlock struct {
 
 // NestedBlock represents a nested block within a Terraform resource (e.g., ingress, egress).
 type NestedBlock struct {
+	Labels       []string // e.g. ["s3"] for backend "s3" {}; usually empty
 	Attributes   map[string]TerraformValue
 	NestedBlocks map[string][]NestedBlock
 }
@@ -49,6 +55,23 @@ func (r Reference) Expr() TerraformExpr {
 	return TerraformExpr(r.ResourceType + "." + r.ResourceName + "." + r.Attribute)
 }
 
+// Address returns the resource address without the attribute, e.g. "aws_vpc.main".
+func (r Reference) Address() string {
+	return r.ResourceType + "." + r.ResourceName
+}
+
+var referencePattern = regexp.MustCompile(`^([a-z][a-z0-9]*_[a-z0-9_]+)\.([A-Za-z_][A-Za-z0-9_-]*)(?:\.([A-Za-z_][A-Za-z0-9_]*))?$`)
+
+// ParseReference is the inverse of Reference.Expr. It reports false for anything that is
+// not a plain managed-resource reference (variables, data sources, indexes, functions...).
+func ParseReference(e TerraformExpr) (Reference, bool) {
+	m := referencePattern.FindStringSubmatch(strings.TrimSpace(string(e)))
+	if m == nil {
+		return Reference{}, false
+	}
+	return Reference{ResourceType: m[1], ResourceName: m[2], Attribute: m[3]}, true
+}
+
 // Variable describes a Terraform input variable (optional generation).
 type Variable struct {
 	Name        string
@@ -0,0 +1,35 @@
+package mapper
+
+import "testing"
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		expr string
+		want Reference
+		ok   bool
+	}{
+		{expr: "aws_vpc.main.id", want: Reference{ResourceType: "aws_vpc", ResourceName: "main", Attribute: "id"}, ok: true},
+		{expr: "aws_lb.front-end.arn", want: Reference{ResourceType: "aws_lb", ResourceName: "front-end", Attribute: "arn"}, ok: true},
+		{expr: "aws_internet_gateway.gw", want: Reference{ResourceType: "aws_internet_gateway", ResourceName: "gw"}, ok: true},
+		{expr: "var.vpc_cidr"},
+		{expr: "data.aws_ami.ubuntu.id"},
+		{expr: "module.vpc.vpc_id"},
+		{expr: "aws_instance.web[0].id"},
+		{expr: `"literal"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, ok := ParseReference(TerraformExpr(tt.expr))
+			if ok != tt.ok {
+				t.Fatalf("ParseReference(%q) ok = %v, want %v", tt.expr, ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseReference(%q) = %+v, want %+v", tt.expr, got, tt.want)
+			}
+			if ok && got.Expr() != TerraformExpr(tt.expr) {
+				t.Fatalf("Expr() = %q, want round trip of %q", got.Expr(), tt.expr)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+)
+
+// Object is one managed resource instance and the resource block it corresponds to.
+type Object struct {
+	Resource Resource
+	Instance Instance
+	Block    tfmapper.TerraformBlock
+}
+
+// BlockAddress returns the address of the object's block, e.g. aws_subnet.private_a.
+func (o Object) BlockAddress() string {
+	return o.Block.Labels[0] + "." + o.Block.Labels[1]
+}
+
+// droppedAttributes are recorded in the state but do not belong in a design: identifiers
+// the cloud assigns, derived tag sets and timeouts, and values the state only keeps as a
+// hash (user_data) or that are secrets (password).
+var droppedAttributes = map[string]bool{
+	"id":        true,
+	"arn":       true,
+	"tags_all":  true,
+	"timeouts":  true,
+	"user_data": true,
+	"password":  true,
+}
+
+// referenceAttributes hold other resources' identifiers without the usual *_id / *_arn suffix.
+var referenceAttributes = map[string]bool{
+	"subnets":              true,
+	"security_groups":      true,
+	"vpc_zone_identifier":  true,
+	"db_subnet_group_name": true,
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// Objects converts the managed instances of one provider into resource blocks. Attribute
+// values that are the ID or ARN of another instance in the state become references again
+// (e.g. vpc_id = aws_vpc.main.id), so the blocks can take the same import path as HCL.
+// Data sources and other providers' resources are returned as issues.
+func (s *State) Objects(provider string) ([]Object, []tfmapper.ImportIssue) {
+	var (
+		objects []Object
+		issues  []tfmapper.ImportIssue
+		used    = make(map[string]bool)
+	)
+	for _, r := range s.Resources {
+		switch {
+		case !r.Managed():
+			issues = append(issues, tfmapper.ImportIssue{Address: r.Address(), Message: "data sources are not imported"})
+			continue
+		case r.ProviderName() != provider:
+			issues = append(issues, tfmapper.ImportIssue{
+				Address: r.Address(),
+				Message: fmt.Sprintf("resources of provider %s are not imported", r.ProviderName()),
+			})
+			continue
+		}
+		for _, i := range r.Instances {
+			label := uniqueLabel(r.Type, blockLabel(r, i), used)
+			objects = append(objects, Object{
+				Resource: r,
+				Instance: i,
+				Block:    tfmapper.TerraformBlock{Kind: "resource", Labels: []string{r.Type, label}},
+			})
+		}
+	}
+
+	// Index every ID and ARN first so references work regardless of resource order.
+	refs := make(map[string]tfmapper.TerraformExpr)
+	for _, o := range objects {
+		if id := o.Instance.ID(); id != "" {
+			refs[id] = tfmapper.TerraformExpr(o.BlockAddress() + ".id")
+		}
+		if arn := o.Instance.ARN(); arn != "" {
+			refs[arn] = tfmapper.TerraformExpr(o.BlockAddress() + ".arn")
+		}
+	}
+	c := converter{refs: refs}
+	for n := range objects {
+		attrs, nested := c.attributes(objects[n].Instance.Attributes, false)
+		objects[n].Block.Attributes = attrs
+		objects[n].Block.NestedBlocks = nested
+	}
+	return objects, issues
+}
+
+// blockLabel derives a Terraform local name from the instance address: module path,
+// resource name and index key joined by underscores.
+func blockLabel(r Resource, i Instance) string {
+	parts := make([]string, 0, 3)
+	if r.Module != "" {
+		parts = append(parts, strings.ReplaceAll(strings.TrimPrefix(r.Module, "module."), ".module.", "_"))
+	}
+	parts = append(parts, r.Name)
+	if i.IndexKey != nil {
+		parts = append(parts, fmt.Sprint(i.IndexKey))
+	}
+	label := strings.Trim(invalidLabelChars.ReplaceAllString(strings.Join(parts, "_"), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') || label[0] == '-' {
+		label = "r_" + label
+	}
+	return label
+}
+
+func uniqueLabel(resourceType, label string, used map[string]bool) string {
+	candidate := label
+	for n := 2; used[resourceType+"."+candidate]; n++ {
+		candidate = fmt.Sprintf("%s_%d", label, n)
+	}
+	used[resourceType+"."+candidate] = true
+	return candidate
+}
+
+// converter turns recorded attribute values into Terraform values.
+type converter struct {
+	refs map[string]tfmapper.TerraformExpr // ID or ARN -> reference expression
+}
+
+// attributes converts an attribute object. Lists of objects are how the state records
+// nested blocks (ingress, route, launch_template, ...) and become nested blocks again.
+// Null and empty values are left out, as they would be in hand-written configuration.
+func (c converter) attributes(raw map[string]interface{}, inBlock bool) (map[string]tfmapper.TerraformValue, map[string][]tfmapper.NestedBlock) {
+	attrs := make(map[string]tfmapper.TerraformValue)
+	nested := make(map[string][]tfmapper.NestedBlock)
+
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !inBlock && droppedAttributes[key] {
+			continue
+		}
+		if objects, ok := objectList(raw[key]); ok {
+			for _, obj := range objects {
+				a, nb := c.attributes(obj, true)
+				nested[key] = append(nested[key], tfmapper.NestedBlock{Attributes: a, NestedBlocks: nb})
+			}
+			continue
+		}
+		if v, ok := c.value(raw[key], isReference(key, inBlock)); ok {
+			attrs[key] = v
+		}
+	}
+	return attrs, nested
+}
+
+func (c converter) value(raw interface{}, reference bool) (tfmapper.TerraformValue, bool) {
+	switch v := raw.(type) {
+	case string:
+		if v == "" {
+			return tfmapper.TerraformValue{}, false
+		}
+		if expr, ok := c.refs[v]; ok && reference {
+			return tfmapper.TerraformValue{Expr: &expr}, true
+		}
+		return tfmapper.TerraformValue{String: &v}, true
+	case float64:
+		return tfmapper.TerraformValue{Number: &v}, true
+	case bool:
+		return tfmapper.TerraformValue{Bool: &v}, true
+	case []interface{}:
+		list := make([]tfmapper.TerraformValue, 0, len(v))
+		for _, item := range v {
+			if tv, ok := c.value(item, reference); ok {
+				list = append(list, tv)
+			}
+		}
+		if len(list) == 0 {
+			return tfmapper.TerraformValue{}, false
+		}
+		return tfmapper.TerraformValue{List: list}, true
+	case map[string]interface{}:
+		m := make(map[string]tfmapper.TerraformValue, len(v))
+		for k, item := range v {
+			if tv, ok := c.value(item, false); ok {
+				m[k] = tv
+			}
+		}
+		if len(m) == 0 {
+			return tfmapper.TerraformValue{}, false
+		}
+		return tfmapper.TerraformValue{Map: m}, true
+	}
+	return tfmapper.TerraformValue{}, false
+}
+
+// objectList reports whether v is a non-empty list of objects.
+func objectList(v interface{}) ([]map[string]interface{}, bool) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	out := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		out = append(out, obj)
+	}
+	return out, true
+}
+
+// isReference reports whether an attribute may hold another resource's ID or ARN.
+// Inside nested blocks a plain "id" is a reference too (e.g. launch_template { id }).
+func isReference(key string, inBlock bool) bool {
+	if inBlock && key == "id" {
+		return true
+	}
+	if referenceAttributes[key] {
+		return true
+	}
+	for _, suffix := range []string{"_id", "_ids", "_arn", "_arns"} {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
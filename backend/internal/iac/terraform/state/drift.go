@@ -0,0 +1,289 @@
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+)
+
+// DriftReport lists how a design differs from what a state file recorded. Resources are
+// matched by address, so the design must be mapped with the same local names the
+// generator writes (the sanitized domain resource IDs).
+type DriftReport struct {
+	NotDeployed []DriftResource        `json:"not_deployed"` // designed but absent from the state
+	NotDesigned []DriftResource        `json:"not_designed"` // recorded in the state but not designed
+	Changed     []ResourceDrift        `json:"changed"`
+	InSync      int                    `json:"in_sync"` // resources present in both with no attribute drift
+	Issues      []tfmapper.ImportIssue `json:"issues"`  // state entries that were not compared
+}
+
+// DriftResource is a resource that exists on only one side.
+type DriftResource struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	ID      string `json:"id,omitempty"` // cloud ID, for deployed resources
+}
+
+// ResourceDrift is a resource present on both sides whose attributes differ.
+type ResourceDrift struct {
+	Address    string           `json:"address"`
+	ID         string           `json:"id"`
+	Attributes []AttributeDrift `json:"attributes"`
+}
+
+// AttributeDrift is one differing attribute. For nested blocks (ingress, route, ...)
+// each block without a match on the other side is reported with the other value nil.
+type AttributeDrift struct {
+	Attribute string      `json:"attribute"` // e.g. "instance_type" or "ingress"
+	Designed  interface{} `json:"designed"`
+	Deployed  interface{} `json:"deployed"`
+}
+
+// Drift compares designed resource blocks with the managed instances of the provider
+// recorded in the state. Only attributes the design sets are compared, so values the
+// cloud fills in (defaults, computed IDs) are not reported. Designed values that cannot
+// be known without planning, such as variables or references to computed attributes
+// other than id and arn, are skipped too.
+func (s *State) Drift(designed []tfmapper.TerraformBlock, provider string) *DriftReport {
+	objects, issues := s.Objects(provider)
+	deployed := make(map[string]Object, len(objects))
+	for _, o := range objects {
+		deployed[o.BlockAddress()] = o
+	}
+
+	report := &DriftReport{
+		NotDeployed: []DriftResource{},
+		NotDesigned: []DriftResource{},
+		Changed:     []ResourceDrift{},
+		Issues:      issues,
+	}
+	if report.Issues == nil {
+		report.Issues = []tfmapper.ImportIssue{}
+	}
+
+	matched := make(map[string]bool)
+	for _, b := range designed {
+		if b.Kind != "resource" || len(b.Labels) != 2 {
+			continue
+		}
+		address := b.Labels[0] + "." + b.Labels[1]
+		o, ok := deployed[address]
+		if !ok {
+			report.NotDeployed = append(report.NotDeployed, DriftResource{Address: address, Type: b.Labels[0]})
+			continue
+		}
+		matched[address] = true
+		if diffs := compareBlock(b, o); len(diffs) > 0 {
+			report.Changed = append(report.Changed, ResourceDrift{Address: address, ID: o.Instance.ID(), Attributes: diffs})
+		} else {
+			report.InSync++
+		}
+	}
+	for _, o := range objects {
+		if !matched[o.BlockAddress()] {
+			report.NotDesigned = append(report.NotDesigned, DriftResource{
+				Address: o.BlockAddress(),
+				Type:    o.Resource.Type,
+				ID:      o.Instance.ID(),
+			})
+		}
+	}
+	return report
+}
+
+// compareBlock returns the attribute and nested block differences of one resource.
+// Attributes the state does not record at all (write-only or unknown to the provider
+// version that wrote it) are skipped.
+func compareBlock(b tfmapper.TerraformBlock, o Object) []AttributeDrift {
+	var diffs []AttributeDrift
+	for _, key := range sortedKeys(b.Attributes) {
+		if droppedAttributes[key] {
+			continue
+		}
+		if _, recorded := o.Instance.Attributes[key]; !recorded {
+			continue
+		}
+		want, ok := plainValue(b.Attributes[key])
+		if !ok {
+			continue
+		}
+		got, _ := plainValue(o.Block.Attributes[key])
+		if canonical(want) != canonical(got) {
+			diffs = append(diffs, AttributeDrift{Attribute: key, Designed: want, Deployed: got})
+		}
+	}
+
+	nestedKeys := make([]string, 0, len(b.NestedBlocks))
+	for key := range b.NestedBlocks {
+		nestedKeys = append(nestedKeys, key)
+	}
+	sort.Strings(nestedKeys)
+	for _, key := range nestedKeys {
+		if _, recorded := o.Instance.Attributes[key]; !recorded {
+			continue
+		}
+		diffs = append(diffs, compareNested(key, b.NestedBlocks[key], o.Block.NestedBlocks[key])...)
+	}
+	return diffs
+}
+
+// compareNested pairs designed and deployed blocks of one type (the state keeps them
+// as an unordered set) and reports the ones left without a partner on either side.
+func compareNested(key string, designed, deployed []tfmapper.NestedBlock) []AttributeDrift {
+	var diffs []AttributeDrift
+	used := make([]bool, len(deployed))
+	for _, d := range designed {
+		found := false
+		for i, candidate := range deployed {
+			if !used[i] && nestedMatches(d, candidate) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			diffs = append(diffs, AttributeDrift{Attribute: key, Designed: plainBlock(d)})
+		}
+	}
+	for i, candidate := range deployed {
+		if !used[i] {
+			diffs = append(diffs, AttributeDrift{Attribute: key, Deployed: plainBlock(candidate)})
+		}
+	}
+	return diffs
+}
+
+// nestedMatches reports whether every value the designed block sets is also in the deployed one.
+func nestedMatches(designed, deployed tfmapper.NestedBlock) bool {
+	for key, v := range designed.Attributes {
+		want, ok := plainValue(v)
+		if !ok {
+			continue
+		}
+		got, _ := plainValue(deployed.Attributes[key])
+		if canonical(want) != canonical(got) {
+			return false
+		}
+	}
+	for key, blocks := range designed.NestedBlocks {
+		if len(compareNested(key, blocks, deployed.NestedBlocks[key])) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// plainValue converts a Terraform value into JSON-like data. Empty values become nil, the
+// way the state conversion drops them. It reports false when the value cannot be compared.
+func plainValue(v tfmapper.TerraformValue) (interface{}, bool) {
+	switch {
+	case v.String != nil:
+		if *v.String == "" {
+			return nil, true
+		}
+		return *v.String, true
+	case v.Number != nil:
+		return *v.Number, true
+	case v.Bool != nil:
+		return *v.Bool, true
+	case v.Expr != nil:
+		ref, ok := tfmapper.ParseReference(*v.Expr)
+		if !ok || (ref.Attribute != "id" && ref.Attribute != "arn") {
+			return nil, false
+		}
+		return string(*v.Expr), true
+	case v.List != nil:
+		list := make([]interface{}, 0, len(v.List))
+		for _, item := range v.List {
+			p, ok := plainValue(item)
+			if !ok {
+				return nil, false
+			}
+			if p != nil {
+				list = append(list, p)
+			}
+		}
+		if len(list) == 0 {
+			return nil, true
+		}
+		return list, true
+	case v.Map != nil:
+		m := make(map[string]interface{}, len(v.Map))
+		for k, item := range v.Map {
+			p, ok := plainValue(item)
+			if !ok {
+				return nil, false
+			}
+			if p != nil {
+				m[k] = p
+			}
+		}
+		if len(m) == 0 {
+			return nil, true
+		}
+		return m, true
+	}
+	return nil, true
+}
+
+// plainBlock converts a nested block for the report, leaving out values it cannot show.
+func plainBlock(b tfmapper.NestedBlock) map[string]interface{} {
+	out := make(map[string]interface{}, len(b.Attributes)+len(b.NestedBlocks))
+	for key, v := range b.Attributes {
+		if p, ok := plainValue(v); ok && p != nil {
+			out[key] = p
+		}
+	}
+	for key, blocks := range b.NestedBlocks {
+		list := make([]interface{}, 0, len(blocks))
+		for _, nb := range blocks {
+			list = append(list, plainBlock(nb))
+		}
+		out[key] = list
+	}
+	return out
+}
+
+// canonical renders a plain value so that equal values compare equal regardless of list
+// order (the state stores most lists as sets) and of number/string/bool spelling.
+func canonical(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.Quote(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		return strconv.Quote(strconv.FormatBool(v))
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = canonical(item)
+		}
+		sort.Strings(items)
+		return "[" + strings.Join(items, ",") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = strconv.Quote(k) + "=" + canonical(v[k])
+		}
+		return "{" + strings.Join(items, ",") + "}"
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]tfmapper.TerraformValue) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
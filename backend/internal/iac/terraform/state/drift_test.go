@@ -0,0 +1,105 @@
+package state
+
+import (
+	"testing"
+
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+)
+
+const driftFixture = `{
+  "version": 4,
+  "serial": 7,
+  "lineage": "d1",
+  "resources": [
+    {"mode": "managed", "type": "aws_vpc", "name": "vpc_1", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "vpc-1", "cidr_block": "10.0.0.0/16", "enable_dns_support": true}}]},
+    {"mode": "managed", "type": "aws_security_group", "name": "web_sg", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "sg-1", "vpc_id": "vpc-1",
+       "ingress": [
+         {"from_port": 443, "to_port": 443, "protocol": "tcp", "cidr_blocks": ["0.0.0.0/0"], "description": "", "self": false},
+         {"from_port": 22, "to_port": 22, "protocol": "tcp", "cidr_blocks": ["0.0.0.0/0"], "description": "", "self": false}
+       ]}}]},
+    {"mode": "managed", "type": "aws_instance", "name": "web", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "i-1", "instance_type": "t3.large", "vpc_security_group_ids": ["sg-1"], "user_data": "3c1f"}}]},
+    {"mode": "managed", "type": "aws_eip", "name": "manual", "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+     "instances": [{"attributes": {"id": "eipalloc-1"}}]}
+  ]
+}`
+
+func str(s string) tfmapper.TerraformValue  { return tfmapper.TerraformValue{String: &s} }
+func num(n float64) tfmapper.TerraformValue { return tfmapper.TerraformValue{Number: &n} }
+func expr(e string) tfmapper.TerraformValue {
+	x := tfmapper.TerraformExpr(e)
+	return tfmapper.TerraformValue{Expr: &x}
+}
+func list(v ...tfmapper.TerraformValue) tfmapper.TerraformValue {
+	return tfmapper.TerraformValue{List: v}
+}
+
+func TestDrift(t *testing.T) {
+	s, err := Parse([]byte(driftFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	rule := func(port float64) tfmapper.NestedBlock {
+		return tfmapper.NestedBlock{Attributes: map[string]tfmapper.TerraformValue{
+			"from_port":   num(port),
+			"to_port":     num(port),
+			"protocol":    str("tcp"),
+			"cidr_blocks": list(str("0.0.0.0/0")),
+		}}
+	}
+	designed := []tfmapper.TerraformBlock{
+		{Kind: "provider", Labels: []string{"aws"}},
+		{Kind: "resource", Labels: []string{"aws_vpc", "vpc_1"}, Attributes: map[string]tfmapper.TerraformValue{
+			"cidr_block": str("10.0.0.0/16"),
+		}},
+		{Kind: "resource", Labels: []string{"aws_security_group", "web_sg"},
+			Attributes:   map[string]tfmapper.TerraformValue{"vpc_id": expr("aws_vpc.vpc_1.id")},
+			NestedBlocks: map[string][]tfmapper.NestedBlock{"ingress": {rule(443), rule(80)}},
+		},
+		{Kind: "resource", Labels: []string{"aws_instance", "web"}, Attributes: map[string]tfmapper.TerraformValue{
+			"instance_type":          str("t3.micro"),
+			"vpc_security_group_ids": list(expr("aws_security_group.web_sg.id")),
+			"user_data":              str("#!/bin/bash"),
+			"ami":                    expr("var.ami"),
+		}},
+		{Kind: "resource", Labels: []string{"aws_s3_bucket", "logs"}},
+	}
+
+	report := s.Drift(designed, "aws")
+
+	if report.InSync != 1 {
+		t.Errorf("InSync = %d, want 1 (the VPC)", report.InSync)
+	}
+	if len(report.NotDeployed) != 1 || report.NotDeployed[0].Address != "aws_s3_bucket.logs" {
+		t.Errorf("NotDeployed = %+v, want aws_s3_bucket.logs", report.NotDeployed)
+	}
+	if len(report.NotDesigned) != 1 || report.NotDesigned[0].Address != "aws_eip.manual" || report.NotDesigned[0].ID != "eipalloc-1" {
+		t.Errorf("NotDesigned = %+v, want aws_eip.manual", report.NotDesigned)
+	}
+
+	changed := make(map[string][]AttributeDrift)
+	for _, c := range report.Changed {
+		changed[c.Address] = c.Attributes
+	}
+	if len(changed) != 2 {
+		t.Fatalf("Changed = %+v, want the security group and the instance", report.Changed)
+	}
+
+	web := changed["aws_instance.web"]
+	if len(web) != 1 || web[0].Attribute != "instance_type" || web[0].Designed != "t3.micro" || web[0].Deployed != "t3.large" {
+		t.Errorf("aws_instance.web drift = %+v, want only instance_type (user_data and var.ami are not comparable)", web)
+	}
+
+	sg := changed["aws_security_group.web_sg"]
+	if len(sg) != 2 {
+		t.Fatalf("aws_security_group.web_sg drift = %+v, want the missing port 80 and the extra port 22 rule", sg)
+	}
+	if sg[0].Attribute != "ingress" || sg[0].Deployed != nil || sg[0].Designed.(map[string]interface{})["from_port"] != float64(80) {
+		t.Errorf("first ingress drift = %+v, want designed port 80 rule", sg[0])
+	}
+	if sg[1].Designed != nil || sg[1].Deployed.(map[string]interface{})["from_port"] != float64(22) {
+		t.Errorf("second ingress drift = %+v, want deployed port 22 rule", sg[1])
+	}
+}
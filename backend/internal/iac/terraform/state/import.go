@@ -0,0 +1,81 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/importer"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// DeployedKey is the node config key holding what the state recorded for a resource.
+const DeployedKey = "deployed"
+
+// Import draws the managed resources of the mapper's provider the way an HCL import
+// does: containment comes from vpc_id / subnet_id references and nodes are laid out
+// automatically. Each node's config also gets the resource's deployed identity (ID, ARN,
+// lifecycle state and state address) under DeployedKey.
+func Import(s *State, m tfmapper.ResourceMapper) (*importer.Result, error) {
+	if s == nil {
+		return nil, fmt.Errorf("state is nil")
+	}
+	if m == nil {
+		return nil, fmt.Errorf("mapper is nil")
+	}
+
+	objects, issues := s.Objects(m.Provider())
+	mod := &importer.Module{Ignored: issues}
+	if region := s.Region(m.Provider()); region != "" {
+		mod.Providers = []tfmapper.TerraformBlock{{
+			Kind:       "provider",
+			Labels:     []string{m.Provider()},
+			Attributes: map[string]tfmapper.TerraformValue{"region": {String: &region}},
+		}}
+	}
+	byAddress := make(map[string]Object, len(objects))
+	for _, o := range objects {
+		mod.Resources = append(mod.Resources, o.Block)
+		byAddress[o.BlockAddress()] = o
+	}
+
+	result, err := importer.Import(mod, m)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]int, len(result.Diagram.Nodes))
+	for i, n := range result.Diagram.Nodes {
+		nodes[n.ID] = i
+	}
+	for address, id := range result.Addresses {
+		o, ok := byAddress[address]
+		if !ok {
+			continue
+		}
+		node := &result.Diagram.Nodes[nodes[id]]
+		node.Data.Config[DeployedKey] = deployedConfig(o.Resource.Output(o.Instance))
+	}
+	return result, nil
+}
+
+// deployedConfig flattens a ResourceOutput into the JSON-like form node configs use.
+func deployedConfig(out *resource.ResourceOutput) map[string]interface{} {
+	cfg := map[string]interface{}{"id": out.ID}
+	if out.ARN != nil {
+		cfg["arn"] = *out.ARN
+	}
+	if out.State != nil {
+		cfg["state"] = *out.State
+	}
+	if out.CreatedAt != nil {
+		cfg["createdAt"] = out.CreatedAt.Format(time.RFC3339)
+	}
+	if out.Region != "" {
+		cfg["region"] = out.Region
+	}
+	for k, v := range out.Metadata {
+		cfg[k] = v
+	}
+	return cfg
+}
@@ -0,0 +1,197 @@
+// Package state reads Terraform state files (format version 4) so deployed infrastructure
+// can be drawn and compared with a design without cloud credentials.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// SupportedVersion is the only state format version Parse accepts (Terraform >= 0.12).
+const SupportedVersion = 4
+
+// State is a terraform.tfstate document.
+type State struct {
+	Version          int        `json:"version"`
+	TerraformVersion string     `json:"terraform_version"`
+	Serial           int64      `json:"serial"`
+	Lineage          string     `json:"lineage"`
+	Resources        []Resource `json:"resources"`
+}
+
+// Resource is one resource (or data source) block recorded in the state.
+type Resource struct {
+	Module    string     `json:"module,omitempty"` // e.g. "module.network"; empty in the root module
+	Mode      string     `json:"mode"`             // "managed" or "data"
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Provider  string     `json:"provider"` // e.g. provider["registry.terraform.io/hashicorp/aws"]
+	Instances []Instance `json:"instances"`
+}
+
+// Instance is a single instance of a resource; count and for_each produce several.
+type Instance struct {
+	IndexKey     interface{}            `json:"index_key,omitempty"`
+	Status       string                 `json:"status,omitempty"` // "tainted" when a create or replace failed
+	Attributes   map[string]interface{} `json:"attributes"`
+	Dependencies []string               `json:"dependencies,omitempty"`
+}
+
+// Parse decodes a state document, rejecting format versions other than 4.
+func Parse(data []byte) (*State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decode state: %w", err)
+	}
+	if s.Version != SupportedVersion {
+		return nil, fmt.Errorf("unsupported state version %d (want %d)", s.Version, SupportedVersion)
+	}
+	return &s, nil
+}
+
+// Managed reports whether the resource is a managed resource rather than a data source.
+func (r Resource) Managed() bool {
+	return r.Mode == "managed"
+}
+
+// Address returns the resource address, e.g. module.network.aws_subnet.private.
+func (r Resource) Address() string {
+	prefix := "data."
+	if r.Managed() {
+		prefix = ""
+	}
+	if r.Module != "" {
+		return r.Module + "." + prefix + r.Type + "." + r.Name
+	}
+	return prefix + r.Type + "." + r.Name
+}
+
+// InstanceAddress returns the address of one instance, e.g. aws_subnet.private["a"].
+func (r Resource) InstanceAddress(i Instance) string {
+	switch key := i.IndexKey.(type) {
+	case nil:
+		return r.Address()
+	case string:
+		return fmt.Sprintf("%s[%q]", r.Address(), key)
+	default:
+		return fmt.Sprintf("%s[%v]", r.Address(), key)
+	}
+}
+
+// ProviderName returns the provider's local name, e.g. "aws" for
+// provider["registry.terraform.io/hashicorp/aws"] or its aliases.
+func (r Resource) ProviderName() string {
+	p := r.Provider
+	if start := strings.Index(p, `["`); start >= 0 {
+		if end := strings.Index(p[start:], `"]`); end >= 0 {
+			p = p[start+2 : start+end]
+		}
+	}
+	if slash := strings.LastIndex(p, "/"); slash >= 0 {
+		p = p[slash+1:]
+	}
+	return p
+}
+
+// ID returns the provider-assigned identifier of the instance.
+func (i Instance) ID() string {
+	return i.stringAttr("id")
+}
+
+// ARN returns the instance's ARN, or "" for resources that have none.
+func (i Instance) ARN() string {
+	return i.stringAttr("arn")
+}
+
+// Region returns the region the instance was created in, taken from the provider's
+// region attribute or else from the ARN.
+func (i Instance) Region() string {
+	if region := i.stringAttr("region"); region != "" {
+		return region
+	}
+	// arn:partition:service:region:account:resource
+	if parts := strings.SplitN(i.ARN(), ":", 5); len(parts) == 5 {
+		return parts[3]
+	}
+	return ""
+}
+
+// lifecycleAttributes hold the runtime state a resource reports, in order of preference.
+var lifecycleAttributes = []string{"instance_state", "state", "status"}
+
+// Output returns the ResourceOutput fields recorded for the instance: its cloud
+// identifiers and, where the provider reports one, its lifecycle state.
+func (r Resource) Output(i Instance) *resource.ResourceOutput {
+	out := &resource.ResourceOutput{
+		ID:       i.ID(),
+		Name:     r.Name,
+		Provider: resource.CloudProvider(r.ProviderName()),
+		Region:   i.Region(),
+		Metadata: map[string]interface{}{"address": r.InstanceAddress(i)},
+	}
+	if arn := i.ARN(); arn != "" {
+		out.ARN = &arn
+	}
+	state := i.Status
+	for _, attr := range lifecycleAttributes {
+		if state != "" {
+			break
+		}
+		state = i.stringAttr(attr)
+	}
+	if state != "" {
+		out.State = &state
+	}
+	for _, attr := range []string{"create_time", "creation_date", "created_date"} {
+		if t, err := time.Parse(time.RFC3339, i.stringAttr(attr)); err == nil {
+			out.CreatedAt = &t
+			break
+		}
+	}
+	return out
+}
+
+// Region returns the first region recorded on any managed instance of the provider.
+func (s *State) Region(provider string) string {
+	for _, r := range s.Resources {
+		if !r.Managed() || r.ProviderName() != provider {
+			continue
+		}
+		for _, i := range r.Instances {
+			if region := i.Region(); region != "" {
+				return region
+			}
+		}
+	}
+	return ""
+}
+
+// Providers returns the providers of the managed resources, most used first.
+func (s *State) Providers() []string {
+	count := make(map[string]int)
+	var order []string
+	for _, r := range s.Resources {
+		if !r.Managed() {
+			continue
+		}
+		name := r.ProviderName()
+		if _, seen := count[name]; !seen {
+			order = append(order, name)
+		}
+		count[name] += len(r.Instances)
+	}
+	sort.SliceStable(order, func(a, b int) bool { return count[order[a]] > count[order[b]] })
+	return order
+}
+
+func (i Instance) stringAttr(name string) string {
+	if s, ok := i.Attributes[name].(string); ok {
+		return s
+	}
+	return ""
+}
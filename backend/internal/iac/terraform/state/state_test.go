@@ -0,0 +1,178 @@
+package state
+
+import (
+	"strings"
+	"testing"
+)
+
+const fixture = `{
+  "version": 4,
+  "terraform_version": "1.7.5",
+  "serial": 12,
+  "lineage": "5f0c",
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_vpc",
+      "name": "main",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "attributes": {
+            "id": "vpc-0a1",
+            "arn": "arn:aws:ec2:eu-west-1:123456789012:vpc/vpc-0a1",
+            "cidr_block": "10.0.0.0/16",
+            "tags": {"Name": "main"},
+            "tags_all": {"Name": "main"}
+          }
+        }
+      ]
+    },
+    {
+      "module": "module.app",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"].west",
+      "instances": [
+        {
+          "index_key": 0,
+          "status": "tainted",
+          "attributes": {
+            "id": "i-1",
+            "instance_state": "running",
+            "vpc_id": "vpc-0a1",
+            "vpc_security_group_ids": ["sg-9", "vpc-0a1"],
+            "key_name": "",
+            "user_data": "3c1f",
+            "root_block_device": [{"volume_size": 8, "kms_key_id": ""}],
+            "ebs_block_device": []
+          }
+        },
+        {
+          "index_key": "b c",
+          "attributes": {"id": "i-2", "instance_state": "stopped"}
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_ami",
+      "name": "ubuntu",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"attributes": {"id": "ami-1"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "random_id",
+      "name": "suffix",
+      "provider": "provider[\"registry.terraform.io/hashicorp/random\"]",
+      "instances": [{"attributes": {"id": "x"}}]
+    }
+  ]
+}`
+
+func TestParse_RejectsOtherVersions(t *testing.T) {
+	if _, err := Parse([]byte(`{"version": 3}`)); err == nil || !strings.Contains(err.Error(), "version 3") {
+		t.Fatalf("Parse() error = %v, want unsupported version", err)
+	}
+	if _, err := Parse([]byte(`{`)); err == nil {
+		t.Fatal("Parse() accepted malformed JSON")
+	}
+}
+
+func TestResource_Addresses(t *testing.T) {
+	s, err := Parse([]byte(fixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	web := s.Resources[1]
+	if got := web.ProviderName(); got != "aws" {
+		t.Errorf("ProviderName() = %q, want aws", got)
+	}
+	if got := web.InstanceAddress(web.Instances[0]); got != "module.app.aws_instance.web[0]" {
+		t.Errorf("InstanceAddress() = %q", got)
+	}
+	if got := web.InstanceAddress(web.Instances[1]); got != `module.app.aws_instance.web["b c"]` {
+		t.Errorf("InstanceAddress() = %q", got)
+	}
+	if got := s.Resources[2].Address(); got != "data.aws_ami.ubuntu" {
+		t.Errorf("Address() = %q, want data.aws_ami.ubuntu", got)
+	}
+	if got := s.Providers(); len(got) != 2 || got[0] != "aws" || got[1] != "random" {
+		t.Errorf("Providers() = %v, want [aws random]", got)
+	}
+	if got := s.Region("aws"); got != "eu-west-1" {
+		t.Errorf("Region() = %q, want eu-west-1 from the VPC ARN", got)
+	}
+}
+
+func TestObjects_RestoresReferences(t *testing.T) {
+	s, err := Parse([]byte(fixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	objects, issues := s.Objects("aws")
+	if len(objects) != 3 {
+		t.Fatalf("objects = %d, want 3", len(objects))
+	}
+	if len(issues) != 2 {
+		t.Errorf("issues = %+v, want the data source and the random resource", issues)
+	}
+
+	vpc := objects[0].Block
+	if _, ok := vpc.Attributes["id"]; ok {
+		t.Error("id should be dropped")
+	}
+	if _, ok := vpc.Attributes["tags_all"]; ok {
+		t.Error("tags_all should be dropped")
+	}
+	if got := vpc.Attributes["tags"].Map["Name"].String; got == nil || *got != "main" {
+		t.Errorf("tags = %+v", vpc.Attributes["tags"])
+	}
+
+	web := objects[1]
+	if got := web.BlockAddress(); got != "aws_instance.app_web_0" {
+		t.Errorf("BlockAddress() = %q, want aws_instance.app_web_0", got)
+	}
+	if got := objects[2].BlockAddress(); got != "aws_instance.app_web_b_c" {
+		t.Errorf("BlockAddress() = %q, want aws_instance.app_web_b_c", got)
+	}
+	attrs := web.Block.Attributes
+	if got := attrs["vpc_id"].Expr; got == nil || *got != "aws_vpc.main.id" {
+		t.Errorf("vpc_id = %+v, want reference to aws_vpc.main", attrs["vpc_id"])
+	}
+	sgs := attrs["vpc_security_group_ids"].List
+	if len(sgs) != 2 || sgs[0].String == nil || sgs[1].Expr == nil {
+		t.Errorf("vpc_security_group_ids = %+v, want unknown ID kept and known ID referenced", sgs)
+	}
+	for _, dropped := range []string{"key_name", "user_data", "ebs_block_device"} {
+		if _, ok := attrs[dropped]; ok {
+			t.Errorf("%s should be left out", dropped)
+		}
+	}
+	root := web.Block.NestedBlocks["root_block_device"]
+	if len(root) != 1 || *root[0].Attributes["volume_size"].Number != 8 {
+		t.Errorf("root_block_device = %+v, want one nested block", root)
+	}
+}
+
+func TestOutput_RecordsIdentity(t *testing.T) {
+	s, err := Parse([]byte(fixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	vpc := s.Resources[0]
+	out := vpc.Output(vpc.Instances[0])
+	if out.ID != "vpc-0a1" || out.ARN == nil || out.Region != "eu-west-1" {
+		t.Errorf("Output() = %+v", out)
+	}
+
+	web := s.Resources[1]
+	if got := web.Output(web.Instances[0]).State; got == nil || *got != "tainted" {
+		t.Errorf("tainted instance state = %v, want tainted", got)
+	}
+	if got := web.Output(web.Instances[1]).State; got == nil || *got != "stopped" {
+		t.Errorf("instance state = %v, want stopped", got)
+	}
+}
@@ -69,7 +69,7 @@ func renderNestedBlocks(body *hclwrite.Body, blocks map[string][]mapper.NestedBl
 
 	for _, nestedType := range nestedTypes {
 		for _, nested := range blocks[nestedType] {
-			nestedBlk := body.AppendNewBlock(nestedType, nil)
+			nestedBlk := body.AppendNewBlock(nestedType, nested.Labels)
 			nestedBody := nestedBlk.Body()
 
 			// Render attributes
@@ -62,6 +62,28 @@ func TestRenderMainTF_SimpleResourceAndProvider(t *testing.T) {
 	}
 }
 
+func TestRenderMainTF_LabelledNestedBlock(t *testing.T) {
+	blocks := []mapper.TerraformBlock{
+		{
+			Kind: "terraform",
+			NestedBlocks: map[string][]mapper.NestedBlock{
+				"backend": {{
+					Labels:     []string{"s3"},
+					Attributes: map[string]mapper.TerraformValue{"bucket": {String: strPtr("state")}},
+				}},
+			},
+		},
+	}
+
+	out, err := RenderMainTF(blocks)
+	if err != nil {
+		t.Fatalf("RenderMainTF() error = %v, want nil", err)
+	}
+	if !strings.Contains(out, `backend "s3" {`) {
+		t.Fatalf("expected labelled backend block, got:\n%s", out)
+	}
+}
+
 func TestRenderVariablesTF_TypeIsExpression(t *testing.T) {
 	vars := []mapper.Variable{
 		{
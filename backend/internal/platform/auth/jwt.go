@@ -0,0 +1,342 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/config"
+)
+
+// Signing algorithms supported by the Authenticator.
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+// clockSkew is the leeway applied to exp and nbf checks.
+const clockSkew = time.Minute
+
+var (
+	// ErrInvalidToken is returned for malformed tokens, bad signatures and claim mismatches.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken is returned for tokens past their exp claim.
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// Claims are the registered JWT claims the API issues and checks.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Email     string   `json:"email,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// UserID parses the subject as a user ID.
+func (c *Claims) UserID() (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: subject is not a user ID", ErrInvalidToken)
+	}
+	return id, nil
+}
+
+// Audience is the aud claim, which may be a single string or an array.
+type Audience []string
+
+// UnmarshalJSON accepts both forms of the claim.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// MarshalJSON writes a single audience as a string.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a Audience) contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Authenticator issues and verifies the API's JSON Web Tokens.
+//
+// HS256 tokens are verified with the shared secret; RS256 tokens with the public half
+// of the signing key or any key of the configured JWKS file, selected by kid.
+type Authenticator struct {
+	secret     []byte
+	privateKey *rsa.PrivateKey
+	keyID      string
+	publicKeys map[string]*rsa.PublicKey // by kid; "" when the key has none
+	issuer     string
+	audience   string
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// NewAuthenticator loads the signing and verification keys named by cfg.
+func NewAuthenticator(cfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		secret:     []byte(cfg.JWTSecret),
+		keyID:      cfg.JWTKeyID,
+		publicKeys: make(map[string]*rsa.PublicKey),
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		ttl:        cfg.TokenTTL,
+		now:        time.Now,
+	}
+	if a.ttl <= 0 {
+		a.ttl = 24 * time.Hour
+	}
+
+	if cfg.JWTPrivateKeyFile != "" {
+		key, err := loadPrivateKey(cfg.JWTPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		a.privateKey = key
+		a.publicKeys[cfg.JWTKeyID] = &key.PublicKey
+	}
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("read JWKS file: %w", err)
+		}
+		keys, err := ParseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			a.publicKeys[kid] = key
+		}
+	}
+
+	if len(a.secret) == 0 && len(a.publicKeys) == 0 {
+		return nil, errors.New("no JWT keys configured: set JWT_SECRET, JWT_PRIVATE_KEY_FILE or JWT_JWKS_FILE")
+	}
+	return a, nil
+}
+
+// CanIssue reports whether a signing key is configured. With only a JWKS file the
+// Authenticator verifies tokens issued elsewhere.
+func (a *Authenticator) CanIssue() bool {
+	return a.privateKey != nil || len(a.secret) > 0
+}
+
+// TTL is the lifetime of issued tokens.
+func (a *Authenticator) TTL() time.Duration {
+	return a.ttl
+}
+
+// Issue signs a token for the user, with RS256 when a private key is configured.
+func (a *Authenticator) Issue(userID uuid.UUID, email string) (string, time.Time, error) {
+	now := a.now()
+	expires := now.Add(a.ttl)
+	claims := Claims{
+		Subject:   userID.String(),
+		Email:     email,
+		Issuer:    a.issuer,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: expires.Unix(),
+	}
+	if a.audience != "" {
+		claims.Audience = Audience{a.audience}
+	}
+
+	h := header{Alg: AlgHS256, Typ: "JWT"}
+	if a.privateKey != nil {
+		h = header{Alg: AlgRS256, Typ: "JWT", Kid: a.keyID}
+	} else if len(a.secret) == 0 {
+		return "", time.Time{}, errors.New("no JWT signing key configured")
+	}
+
+	signingInput, err := encodeSegments(h, claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	sig, err := a.sign(h.Alg, signingInput)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), expires, nil
+}
+
+// Verify checks the token's signature, expiry, issuer and audience and returns its claims.
+func (a *Authenticator) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err := a.verifySignature(h, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	now := a.now()
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("%w: token not valid yet", ErrInvalidToken)
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if a.audience != "" && !claims.Audience.contains(a.audience) {
+		return nil, fmt.Errorf("%w: token is not intended for this audience", ErrInvalidToken)
+	}
+	return &claims, nil
+}
+
+func (a *Authenticator) sign(alg, signingInput string) ([]byte, error) {
+	switch alg {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, a.secret)
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil), nil
+	case AlgRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.SignPKCS1v15(rand.Reader, a.privateKey, crypto.SHA256, digest[:])
+	}
+	return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+}
+
+func (a *Authenticator) verifySignature(h header, signingInput string, sig []byte) error {
+	switch h.Alg {
+	case AlgHS256:
+		if len(a.secret) == 0 {
+			return fmt.Errorf("%w: HS256 tokens are not accepted", ErrInvalidToken)
+		}
+		expected, _ := a.sign(AlgHS256, signingInput)
+		if !hmac.Equal(sig, expected) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	case AlgRS256:
+		key, err := a.publicKey(h.Kid)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, h.Alg)
+}
+
+// publicKey selects the RS256 verification key by kid. Tokens without a kid are
+// accepted only when a single key is configured.
+func (a *Authenticator) publicKey(kid string) (*rsa.PublicKey, error) {
+	if key, ok := a.publicKeys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(a.publicKeys) == 1 {
+		for _, key := range a.publicKeys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+}
+
+func encodeSegments(h header, claims Claims) (string, error) {
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	cb, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb), nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	return nil
+}
+
+// loadPrivateKey reads a PKCS#1 or PKCS#8 PEM-encoded RSA private key.
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read JWT private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("JWT private key %s is not PEM encoded", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse JWT private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("JWT private key %s is not an RSA key", path)
+	}
+	return key, nil
+}
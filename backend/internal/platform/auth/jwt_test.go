@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/config"
+)
+
+func hsConfig() config.AuthConfig {
+	return config.AuthConfig{
+		JWTSecret: "test-secret",
+		Issuer:    "arch-visualizer",
+		Audience:  "arch-visualizer-api",
+		TokenTTL:  time.Hour,
+	}
+}
+
+func TestAuthenticator_HS256(t *testing.T) {
+	a, err := NewAuthenticator(hsConfig())
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	userID := uuid.New()
+	token, expires, err := a.Issue(userID, "dev@example.com")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if time.Until(expires) > time.Hour || time.Until(expires) < 59*time.Minute {
+		t.Errorf("expires = %v, want in an hour", expires)
+	}
+
+	claims, err := a.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got, _ := claims.UserID(); got != userID || claims.Email != "dev@example.com" {
+		t.Errorf("claims = %+v, want the issued user", claims)
+	}
+
+	// Tampered payload.
+	other, _, _ := a.Issue(uuid.New(), "")
+	forged := token[:strings.Index(token, ".")] + other[strings.Index(other, "."):strings.LastIndex(other, ".")] + token[strings.LastIndex(token, "."):]
+	if _, err := a.Verify(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(forged) = %v, want ErrInvalidToken", err)
+	}
+
+	// Wrong secret, issuer and audience.
+	for name, mutate := range map[string]func(*config.AuthConfig){
+		"secret":   func(c *config.AuthConfig) { c.JWTSecret = "other" },
+		"issuer":   func(c *config.AuthConfig) { c.Issuer = "someone-else" },
+		"audience": func(c *config.AuthConfig) { c.Audience = "another-api" },
+	} {
+		cfg := hsConfig()
+		mutate(&cfg)
+		b, _ := NewAuthenticator(cfg)
+		if _, err := b.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s mismatch: Verify = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestAuthenticator_Expired(t *testing.T) {
+	a, _ := NewAuthenticator(hsConfig())
+	a.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	token, _, err := a.Issue(uuid.New(), "")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	a.now = time.Now
+	if _, err := a.Verify(token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("Verify = %v, want ErrExpiredToken", err)
+	}
+}
+
+func TestAuthenticator_RejectsNone(t *testing.T) {
+	a, _ := NewAuthenticator(hsConfig())
+	h := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	c := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":%q,"exp":%d}`, uuid.New(), time.Now().Add(time.Hour).Unix())))
+	if _, err := a.Verify(h + "." + c + "."); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(alg none) = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestAuthenticator_RS256WithJWKS(t *testing.T) {
+	dir := t.TempDir()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, "key.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, pemBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The issuer signs with the private key.
+	issuer, err := NewAuthenticator(config.AuthConfig{JWTPrivateKeyFile: keyFile, JWTKeyID: "k1", Issuer: "idp", Audience: "api", TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAuthenticator(private key): %v", err)
+	}
+	userID := uuid.New()
+	token, _, err := issuer.Issue(userID, "")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	// A verifier that only has the published JWKS.
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"k1","use":"sig","alg":"RS256","n":%q,"e":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(jwksFile, []byte(jwks), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewAuthenticator(config.AuthConfig{JWKSFile: jwksFile, Issuer: "idp", Audience: "api"})
+	if err != nil {
+		t.Fatalf("NewAuthenticator(JWKS): %v", err)
+	}
+	if verifier.CanIssue() {
+		t.Error("a JWKS-only authenticator should not issue tokens")
+	}
+	claims, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got, _ := claims.UserID(); got != userID {
+		t.Errorf("subject = %v, want %v", got, userID)
+	}
+
+	// HS256 tokens are refused when no secret is configured.
+	hs, _ := NewAuthenticator(hsConfig())
+	hsToken, _, _ := hs.Issue(userID, "")
+	if _, err := verifier.Verify(hsToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(HS256) = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !CheckPassword(hash, "correct horse") {
+		t.Error("CheckPassword rejected the right password")
+	}
+	if CheckPassword(hash, "wrong") || CheckPassword("", "") {
+		t.Error("CheckPassword accepted a wrong password or an empty hash")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +12,10 @@ import (
 // Config holds all application configuration
 type Config struct {
 	Database DatabaseConfig
+	Auth     AuthConfig
+
+	// GuardrailsDir holds instance-wide guardrail policy files (*.policy); empty for none
+	GuardrailsDir string
 }
 
 // DatabaseConfig holds database connection configuration
@@ -23,6 +28,18 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// AuthConfig holds JWT authentication configuration.
+// Tokens are issued with RS256 when JWTPrivateKeyFile is set and with HS256 otherwise.
+type AuthConfig struct {
+	JWTSecret         string // HS256 signing key
+	JWTPrivateKeyFile string // PEM-encoded RSA private key for RS256
+	JWTKeyID          string // kid header of RS256 tokens
+	JWKSFile          string // JSON Web Key Set with additional RS256 verification keys
+	Issuer            string
+	Audience          string
+	TokenTTL          time.Duration
+}
+
 // Load loads configuration from .env file in the backend root directory
 func Load() (*Config, error) {
 	// Get the backend root directory (assuming this is called from backend/)
@@ -46,7 +63,22 @@ func Load() (*Config, error) {
 			Name:     getEnv("DB_NAME", "arch_visualizer"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
+		Auth: AuthConfig{
+			JWTSecret:         getEnv("JWT_SECRET", ""),
+			JWTPrivateKeyFile: getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			JWTKeyID:          getEnv("JWT_KEY_ID", ""),
+			JWKSFile:          getEnv("JWT_JWKS_FILE", ""),
+			Issuer:            getEnv("JWT_ISSUER", "arch-visualizer"),
+			Audience:          getEnv("JWT_AUDIENCE", "arch-visualizer-api"),
+		},
+		GuardrailsDir: getEnv("GUARDRAILS_DIR", ""),
+	}
+
+	ttl, err := time.ParseDuration(getEnv("JWT_TTL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_TTL: %w", err)
 	}
+	config.Auth.TokenTTL = ttl
 
 	return config, nil
 }
@@ -34,6 +34,8 @@ const (
 	CodeProjectCreateFailed = "PROJECT_CREATE_FAILED"
 	CodeProjectUpdateFailed = "PROJECT_UPDATE_FAILED"
 	CodeProjectDeleteFailed = "PROJECT_DELETE_FAILED"
+	CodeProjectOverBudget   = "PROJECT_OVER_BUDGET"
+	CodeProjectUnpriced     = "PROJECT_UNPRICED"
 
 	// User repository errors
 	CodeUserNotFound       = "USER_NOT_FOUND"
@@ -172,6 +174,17 @@ func NewProjectCreateFailed(cause error) *errors.AppError {
 	return errors.Wrap(cause, CodeProjectCreateFailed, errors.KindInternal, "Failed to create project")
 }
 
+// NewProjectOverBudget creates an error for a version refused by the project budget
+func NewProjectOverBudget(summary string) *errors.AppError {
+	return errors.New(CodeProjectOverBudget, errors.KindConflict, "Version exceeds the project budget: "+summary)
+}
+
+// NewProjectUnpriced creates an error for a version a blocking budget cannot check because
+// some of its resources could not be priced
+func NewProjectUnpriced(summary string) *errors.AppError {
+	return errors.New(CodeProjectUnpriced, errors.KindConflict, "Version cannot be checked against the project budget: "+summary)
+}
+
 // NewUserNotFound creates an error for when a user is not found
 func NewUserNotFound(userID interface{}) *errors.AppError {
 	return errors.New(CodeUserNotFound, errors.KindNotFound, "User not found").
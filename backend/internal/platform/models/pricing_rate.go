@@ -14,7 +14,7 @@ type PricingRate struct {
 	ComponentName   string         `gorm:"type:varchar(100);not null" json:"component_name"`
 	PricingModel    string         `gorm:"type:varchar(50);not null" json:"pricing_model"`
 	Unit            string         `gorm:"type:varchar(50);not null" json:"unit"`
-	Rate            float64        `gorm:"type:numeric(14,6);not null" json:"rate"`
+	Rate            float64        `gorm:"type:numeric(20,10);not null" json:"rate"`
 	Currency        string         `gorm:"type:varchar(10);default:'USD'" json:"currency"`
 	Region          *string        `gorm:"type:varchar(50);index" json:"region,omitempty"`
 	InstanceType    *string        `gorm:"type:varchar(50);index" json:"instance_type,omitempty"`
@@ -1,9 +1,13 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -21,8 +25,11 @@ type Project struct {
 	Region        string         `gorm:"type:text;not null" json:"region"`
 	Thumbnail     string         `gorm:"type:text" json:"thumbnail"`
 	Tags          []string       `gorm:"type:text[]" json:"tags"`
-	ResourceCount int            `gorm:"-" json:"resourceCount"` // Calculated field
-	EstimatedCost float64        `gorm:"-" json:"estimatedCost"` // Calculated field
+	BackendConfig datatypes.JSON `gorm:"type:jsonb" json:"backend_config,omitempty"` // iac.StateBackend; NULL = local state
+	UsageProfile  datatypes.JSON `gorm:"type:jsonb" json:"usage_profile,omitempty"`  // pricing.UsageProfile; NULL = 24/7, no traffic
+	Budget        datatypes.JSON `gorm:"type:jsonb" json:"budget,omitempty"`         // pricing.Budget; NULL = no budget
+	ResourceCount int            `gorm:"-" json:"resourceCount"`                     // Calculated field
+	EstimatedCost float64        `gorm:"-" json:"estimatedCost"`                     // Calculated field
 	CreatedAt     time.Time      `gorm:"default:now()" json:"created_at"`
 	UpdatedAt     time.Time      `gorm:"default:now()" json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
@@ -47,3 +54,39 @@ type Project struct {
 func (Project) TableName() string {
 	return "projects"
 }
+
+// UnmarshalBackendConfig unmarshals BackendConfig; it returns nil when the project uses local state.
+func (p *Project) UnmarshalBackendConfig() (*iac.StateBackend, error) {
+	if len(p.BackendConfig) == 0 || string(p.BackendConfig) == "null" {
+		return nil, nil
+	}
+	var backend iac.StateBackend
+	if err := json.Unmarshal(p.BackendConfig, &backend); err != nil {
+		return nil, err
+	}
+	return &backend, nil
+}
+
+// UnmarshalUsageProfile unmarshals UsageProfile; it returns nil when the project has no usage profile.
+func (p *Project) UnmarshalUsageProfile() (*pricing.UsageProfile, error) {
+	if len(p.UsageProfile) == 0 || string(p.UsageProfile) == "null" {
+		return nil, nil
+	}
+	var profile pricing.UsageProfile
+	if err := json.Unmarshal(p.UsageProfile, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// UnmarshalBudget unmarshals Budget; it returns nil when the project has no budget.
+func (p *Project) UnmarshalBudget() (*pricing.Budget, error) {
+	if len(p.Budget) == 0 || string(p.Budget) == "null" {
+		return nil, nil
+	}
+	var budget pricing.Budget
+	if err := json.Unmarshal(p.Budget, &budget); err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewFlag records a user flagging a review for moderation; a user flags a review at most once
+type ReviewFlag struct {
+	ReviewID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"review_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Reason    *string   `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt time.Time `gorm:"default:current_timestamp" json:"created_at"`
+
+	// Relationships
+	Review Review `gorm:"foreignKey:ReviewID;constraint:OnDelete:CASCADE" json:"review,omitempty"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (ReviewFlag) TableName() string {
+	return "review_flags"
+}
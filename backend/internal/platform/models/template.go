@@ -1,9 +1,12 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"gorm.io/datatypes"
 )
 
 // Template represents a marketplace template
@@ -32,6 +35,13 @@ type Template struct {
 	CreatedAt         time.Time `gorm:"default:current_timestamp" json:"created_at"`
 	UpdatedAt         time.Time `gorm:"default:current_timestamp" json:"updated_at"`
 
+	// Architecture is the dto.ArchitectureResponse of the published version; NULL for templates
+	// that were not published from a project
+	Architecture    datatypes.JSON `gorm:"type:jsonb" json:"-"`
+	SourceVersionID *uuid.UUID     `gorm:"type:uuid" json:"source_version_id,omitempty"`
+	// Parameters is the []TemplateParameter filled in when the template is instantiated
+	Parameters datatypes.JSON `gorm:"type:jsonb" json:"-"`
+
 	// Relationships
 	Category            Category             `gorm:"foreignKey:CategoryID;constraint:OnDelete:RESTRICT" json:"category,omitempty"`
 	Author              User                 `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE" json:"author,omitempty"`
@@ -48,3 +58,62 @@ type Template struct {
 func (Template) TableName() string {
 	return "templates"
 }
+
+// UnmarshalArchitecture unmarshals Architecture; it returns nil when the template has no architecture.
+func (t *Template) UnmarshalArchitecture() (*dto.ArchitectureResponse, error) {
+	if len(t.Architecture) == 0 || string(t.Architecture) == "null" {
+		return nil, nil
+	}
+	var arch dto.ArchitectureResponse
+	if err := json.Unmarshal(t.Architecture, &arch); err != nil {
+		return nil, err
+	}
+	return &arch, nil
+}
+
+// UnmarshalParameters unmarshals Parameters; it returns nil when the template has no parameters.
+func (t *Template) UnmarshalParameters() ([]TemplateParameter, error) {
+	if len(t.Parameters) == 0 || string(t.Parameters) == "null" {
+		return nil, nil
+	}
+	var params []TemplateParameter
+	if err := json.Unmarshal(t.Parameters, &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// TemplateParameter is an input of a template, chosen when the template is instantiated
+type TemplateParameter struct {
+	Name string `json:"name"`
+	// Type is region, az_count, string, cidr, number, bool, list or map
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	// Options lists the accepted values; empty accepts any value of Type
+	Options []string `json:"options,omitempty"`
+	// Min and Max bound az_count parameters
+	Min       *int `json:"min,omitempty"`
+	Max       *int `json:"max,omitempty"`
+	Required  bool `json:"required,omitempty"`
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// TemplateFilter selects marketplace templates. Empty fields do not filter.
+type TemplateFilter struct {
+	// Search matches the title or description
+	Search string
+	// Category, Technology and Compliance are slugs
+	Category   string
+	Technology string
+	Compliance string
+	// CloudProvider is matched case-insensitively, e.g. "aws" matches "AWS"
+	CloudProvider string
+	// MinCost and MaxCost keep templates whose estimated monthly cost range overlaps [MinCost, MaxCost]
+	MinCost *float64
+	MaxCost *float64
+	// Sort is popular (default), rating, downloads, newest or cost
+	Sort  string
+	Page  int
+	Limit int
+}
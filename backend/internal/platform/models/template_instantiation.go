@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateInstantiation records a user creating a project from a template.
+// ProjectID is the root project that was created.
+type TemplateInstantiation struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	TemplateID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"template_id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ProjectID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"project_id"`
+	CodeGeneratedAt *time.Time `json:"code_generated_at,omitempty"` // first code generation from the project; NULL = none yet
+	CreatedAt       time.Time  `gorm:"default:current_timestamp" json:"created_at"`
+
+	// Relationships
+	Template Template `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"template,omitempty"`
+	User     User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (TemplateInstantiation) TableName() string {
+	return "template_instantiations"
+}
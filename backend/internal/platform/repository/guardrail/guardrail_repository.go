@@ -0,0 +1,60 @@
+package guardrailrepo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository"
+
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+)
+
+// GuardrailRepository provides operations for user guardrails.
+type GuardrailRepository struct {
+	*repository.BaseRepository
+}
+
+// NewGuardrailRepository creates a new guardrail repository.
+func NewGuardrailRepository() (*GuardrailRepository, error) {
+	base, err := repository.NewBaseRepository()
+	if err != nil {
+		return nil, platformerrors.NewDatabaseConnectionFailed(err)
+	}
+	return &GuardrailRepository{BaseRepository: base}, nil
+}
+
+// Create creates a new guardrail.
+func (r *GuardrailRepository) Create(ctx context.Context, guardrail *models.Guardrail) error {
+	return r.GetDB(ctx).Omit("User").Create(guardrail).Error
+}
+
+// FindByID finds a guardrail by ID.
+func (r *GuardrailRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Guardrail, error) {
+	var guardrail models.Guardrail
+	err := r.GetDB(ctx).First(&guardrail, "id = ?", id).Error
+	if err != nil {
+		return nil, platformerrors.HandleGormError(err, "guardrail", "GuardrailRepository.FindByID")
+	}
+	return &guardrail, nil
+}
+
+// FindByUserID lists a user's guardrails by name.
+func (r *GuardrailRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Guardrail, error) {
+	var guardrails []*models.Guardrail
+	err := r.GetDB(ctx).
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&guardrails).Error
+	return guardrails, err
+}
+
+// Update updates an existing guardrail.
+func (r *GuardrailRepository) Update(ctx context.Context, guardrail *models.Guardrail) error {
+	return r.GetDB(ctx).Omit("User").Save(guardrail).Error
+}
+
+// Delete deletes a guardrail by ID.
+func (r *GuardrailRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.GetDB(ctx).Delete(&models.Guardrail{}, "id = ?", id).Error
+}
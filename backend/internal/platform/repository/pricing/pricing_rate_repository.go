@@ -126,11 +126,12 @@ func (r *PricingRateRepository) UpsertRate(ctx context.Context, rate *models.Pri
 		conditions["instance_type"] = nil
 	}
 
+	// Matches the unique index: rates without an operating system are stored as linux
+	operatingSystem := "linux"
 	if rate.OperatingSystem != nil {
-		conditions["operating_system"] = *rate.OperatingSystem
-	} else {
-		conditions["operating_system"] = nil
+		operatingSystem = *rate.OperatingSystem
 	}
+	conditions["COALESCE(operating_system, 'linux')"] = operatingSystem
 
 	// Check if rate exists
 	var existing models.PricingRate
@@ -182,11 +183,12 @@ func (r *PricingRateRepository) BulkUpsert(ctx context.Context, rates []*models.
 				conditions["instance_type"] = nil
 			}
 
+			// Matches the unique index: rates without an operating system are stored as linux
+			operatingSystem := "linux"
 			if rate.OperatingSystem != nil {
-				conditions["operating_system"] = *rate.OperatingSystem
-			} else {
-				conditions["operating_system"] = nil
+				operatingSystem = *rate.OperatingSystem
 			}
+			conditions["COALESCE(operating_system, 'linux')"] = operatingSystem
 
 			// Check if rate exists
 			var existing models.PricingRate
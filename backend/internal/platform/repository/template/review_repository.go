@@ -2,12 +2,15 @@ package templaterepo
 
 import (
 	"context"
+	"time"
+
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository"
 
 	"github.com/google/uuid"
 	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ReviewRepository provides operations for template reviews.
@@ -55,6 +58,36 @@ func (r *ReviewRepository) FindByTemplate(ctx context.Context, templateID uuid.U
 	return reviews, err
 }
 
+// FindByTemplateAndUser finds the review a user wrote for a template.
+func (r *ReviewRepository) FindByTemplateAndUser(ctx context.Context, templateID, userID uuid.UUID) (*models.Review, error) {
+	var review models.Review
+	err := r.GetDB(ctx).
+		First(&review, "template_id = ? AND user_id = ?", templateID, userID).Error
+	if err != nil {
+		return nil, platformerrors.HandleGormError(err, "review", "ReviewRepository.FindByTemplateAndUser")
+	}
+	return &review, nil
+}
+
+// FindVisibleByTemplate lists the reviews of a template that are not hidden, with their
+// authors, and the number of such reviews before pagination.
+func (r *ReviewRepository) FindVisibleByTemplate(ctx context.Context, templateID uuid.UUID, limit, offset int) ([]*models.Review, int64, error) {
+	db := r.GetDB(ctx).
+		Model(&models.Review{}).
+		Where("template_id = ? AND hidden = ?", templateID, false)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reviews []*models.Review
+	db = db.Preload("User").Order("verified_deployment DESC, created_at DESC")
+	if limit > 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err := db.Find(&reviews).Error
+	return reviews, total, err
+}
+
 // FindByUser lists reviews created by a given user.
 func (r *ReviewRepository) FindByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Review, error) {
 	var reviews []*models.Review
@@ -70,7 +103,16 @@ func (r *ReviewRepository) FindByUser(ctx context.Context, userID uuid.UUID, lim
 
 // Update updates an existing review.
 func (r *ReviewRepository) Update(ctx context.Context, review *models.Review) error {
-	return r.GetDB(ctx).Save(review).Error
+	return r.GetDB(ctx).Omit(clause.Associations).Save(review).Error
+}
+
+// Delete removes a review and its flags.
+func (r *ReviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	db := r.GetDB(ctx)
+	if err := db.Where("review_id = ?", id).Delete(&models.ReviewFlag{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(&models.Review{}, "id = ?", id).Error
 }
 
 // IncrementHelpfulCount increments the helpful_count for a review.
@@ -80,3 +122,64 @@ func (r *ReviewRepository) IncrementHelpfulCount(ctx context.Context, id uuid.UU
 		Where("id = ?", id).
 		UpdateColumn("helpful_count", gorm.Expr("helpful_count + 1")).Error
 }
+
+// CreateFlag records a user flagging a review. A user flags a review at most once; a
+// second flag is a duplicate entry.
+func (r *ReviewRepository) CreateFlag(ctx context.Context, flag *models.ReviewFlag) error {
+	db := r.GetDB(ctx)
+	var count int64
+	if err := db.Model(&models.ReviewFlag{}).
+		Where("review_id = ? AND user_id = ?", flag.ReviewID, flag.UserID).
+		Count(&count).Error; err != nil {
+		return platformerrors.HandleGormError(err, "review_flag", "ReviewRepository.CreateFlag")
+	}
+	if count > 0 {
+		return platformerrors.NewRepositoryDuplicateEntry("review_flag", "user_id", flag.UserID).
+			WithOp("ReviewRepository.CreateFlag")
+	}
+	return db.Create(flag).Error
+}
+
+// IncrementFlagCount increments the flag_count of a review and hides it once the count
+// reaches hideAt.
+func (r *ReviewRepository) IncrementFlagCount(ctx context.Context, id uuid.UUID, hideAt int) error {
+	return r.GetDB(ctx).
+		Model(&models.Review{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"flag_count": gorm.Expr("flag_count + 1"),
+			"hidden":     gorm.Expr("hidden OR flag_count + 1 >= ?", hideAt),
+		}).Error
+}
+
+// SetVerifiedDeployment marks the review a user wrote for a template, if any, as a verified deployment.
+func (r *ReviewRepository) SetVerifiedDeployment(ctx context.Context, templateID, userID uuid.UUID) error {
+	return r.GetDB(ctx).
+		Model(&models.Review{}).
+		Where("template_id = ? AND user_id = ?", templateID, userID).
+		UpdateColumn("verified_deployment", true).Error
+}
+
+// RecalculateTemplateRating sets the rating and review_count of a template from its
+// reviews that are not hidden.
+func (r *ReviewRepository) RecalculateTemplateRating(ctx context.Context, templateID uuid.UUID) error {
+	db := r.GetDB(ctx)
+	var agg struct {
+		Rating float64
+		Count  int
+	}
+	err := db.Model(&models.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS rating, COUNT(*) AS count").
+		Where("template_id = ? AND hidden = ?", templateID, false).
+		Scan(&agg).Error
+	if err != nil {
+		return platformerrors.HandleGormError(err, "review", "ReviewRepository.RecalculateTemplateRating")
+	}
+	return db.Model(&models.Template{}).
+		Where("id = ?", templateID).
+		UpdateColumns(map[string]interface{}{
+			"rating":       agg.Rating,
+			"review_count": agg.Count,
+			"updated_at":   time.Now(),
+		}).Error
+}
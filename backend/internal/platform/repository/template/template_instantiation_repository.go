@@ -0,0 +1,66 @@
+package templaterepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository"
+)
+
+// TemplateInstantiationRepository records the projects users create from templates.
+type TemplateInstantiationRepository struct {
+	*repository.BaseRepository
+}
+
+// NewTemplateInstantiationRepository creates a new template instantiation repository.
+func NewTemplateInstantiationRepository() (*TemplateInstantiationRepository, error) {
+	base, err := repository.NewBaseRepository()
+	if err != nil {
+		return nil, platformerrors.NewDatabaseConnectionFailed(err)
+	}
+	return &TemplateInstantiationRepository{BaseRepository: base}, nil
+}
+
+// Create records a template instantiation.
+func (r *TemplateInstantiationRepository) Create(ctx context.Context, instantiation *models.TemplateInstantiation) error {
+	return r.GetDB(ctx).Create(instantiation).Error
+}
+
+// FindByTemplateAndUser lists the instantiations of a template by a user, newest first.
+func (r *TemplateInstantiationRepository) FindByTemplateAndUser(ctx context.Context, templateID, userID uuid.UUID) ([]*models.TemplateInstantiation, error) {
+	var instantiations []*models.TemplateInstantiation
+	err := r.GetDB(ctx).
+		Where("template_id = ? AND user_id = ?", templateID, userID).
+		Order("created_at DESC").
+		Find(&instantiations).Error
+	return instantiations, err
+}
+
+// MarkCodeGenerated records that code was generated from a root project and returns the
+// instantiations that created it. Instantiations keep the time of their first generation.
+func (r *TemplateInstantiationRepository) MarkCodeGenerated(ctx context.Context, projectID uuid.UUID) ([]*models.TemplateInstantiation, error) {
+	db := r.GetDB(ctx)
+	var instantiations []*models.TemplateInstantiation
+	if err := db.Where("project_id = ?", projectID).Find(&instantiations).Error; err != nil {
+		return nil, err
+	}
+	if len(instantiations) == 0 {
+		return nil, nil
+	}
+	now := time.Now()
+	err := db.Model(&models.TemplateInstantiation{}).
+		Where("project_id = ? AND code_generated_at IS NULL", projectID).
+		UpdateColumn("code_generated_at", now).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, inst := range instantiations {
+		if inst.CodeGeneratedAt == nil {
+			inst.CodeGeneratedAt = &now
+		}
+	}
+	return instantiations, nil
+}
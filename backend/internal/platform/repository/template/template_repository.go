@@ -2,13 +2,25 @@ package templaterepo
 
 import (
 	"context"
+	"strings"
+
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository"
 
 	"github.com/google/uuid"
 	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"gorm.io/gorm"
 )
 
+// templateSortOrders maps the sort names of models.TemplateFilter to their ORDER BY clauses.
+var templateSortOrders = map[string][]string{
+	"popular":   {"is_popular DESC", "downloads DESC", "rating DESC"},
+	"rating":    {"rating DESC", "review_count DESC"},
+	"downloads": {"downloads DESC"},
+	"newest":    {"created_at DESC"},
+	"cost":      {"estimated_cost_min ASC", "estimated_cost_max ASC"},
+}
+
 // TemplateRepository provides operations for marketplace templates.
 type TemplateRepository struct {
 	*repository.BaseRepository
@@ -85,3 +97,79 @@ func (r *TemplateRepository) ListPopular(ctx context.Context, limit, offset int)
 	err := db.Find(&templates).Error
 	return templates, err
 }
+
+// Search lists the templates matching a filter, with their category, technologies,
+// compliance standards and IaC formats preloaded, and the number of matches before pagination.
+func (r *TemplateRepository) Search(ctx context.Context, filter models.TemplateFilter) ([]*models.Template, int64, error) {
+	var templates []*models.Template
+	var total int64
+
+	db := r.GetDB(ctx).Model(&models.Template{})
+	if filter.Search != "" {
+		searchParam := "%" + strings.ToLower(filter.Search) + "%"
+		db = db.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", searchParam, searchParam)
+	}
+	if filter.Category != "" {
+		db = db.Where("category_id IN (SELECT id FROM categories WHERE slug = ?)", filter.Category)
+	}
+	if filter.Technology != "" {
+		db = db.Where(`id IN (SELECT tt.template_id FROM template_technologies tt
+			JOIN technologies t ON t.id = tt.technology_id WHERE t.slug = ?)`, filter.Technology)
+	}
+	if filter.Compliance != "" {
+		db = db.Where(`id IN (SELECT tc.template_id FROM template_compliance tc
+			JOIN compliance_standards cs ON cs.id = tc.compliance_id WHERE cs.slug = ?)`, filter.Compliance)
+	}
+	if filter.CloudProvider != "" {
+		db = db.Where("LOWER(cloud_provider) = ?", strings.ToLower(filter.CloudProvider))
+	}
+	if filter.MinCost != nil {
+		db = db.Where("estimated_cost_max >= ?", *filter.MinCost)
+	}
+	if filter.MaxCost != nil {
+		db = db.Where("estimated_cost_min <= ?", *filter.MaxCost)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orders, ok := templateSortOrders[filter.Sort]
+	if !ok {
+		orders = templateSortOrders["popular"]
+	}
+	for _, order := range orders {
+		db = db.Order(order)
+	}
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		db = db.Limit(filter.Limit).Offset(offset)
+	}
+
+	err := db.
+		Preload("Category").
+		Preload("Technologies").
+		Preload("IACFormats").
+		Preload("ComplianceStandards").
+		Find(&templates).Error
+	return templates, total, err
+}
+
+// UpdateEstimatedCost sets the estimated monthly cost range of a template.
+func (r *TemplateRepository) UpdateEstimatedCost(ctx context.Context, id uuid.UUID, min, max float64) error {
+	return r.GetDB(ctx).
+		Model(&models.Template{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"estimated_cost_min": min, "estimated_cost_max": max}).Error
+}
+
+// IncrementDownloads increments the downloads count of a template.
+func (r *TemplateRepository) IncrementDownloads(ctx context.Context, id uuid.UUID) error {
+	return r.GetDB(ctx).
+		Model(&models.Template{}).
+		Where("id = ?", id).
+		UpdateColumn("downloads", gorm.Expr("downloads + 1")).Error
+}
@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository"
+	guardrailrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/guardrail"
+)
+
+func TestGuardrailRepository_CRUD(t *testing.T) {
+	db := newTestDB(t, &models.Guardrail{})
+	base := repository.NewBaseRepositoryWithDB(db)
+	repo := &guardrailrepo.GuardrailRepository{BaseRepository: base}
+
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+
+	for _, g := range []*models.Guardrail{
+		{ID: uuid.New(), UserID: owner, Name: "storage", Source: `policy "a" {}`, Enabled: true},
+		{ID: uuid.New(), UserID: owner, Name: "compute", Source: `policy "b" {}`, Enabled: false},
+		{ID: uuid.New(), UserID: other, Name: "network", Source: `policy "c" {}`, Enabled: true},
+	} {
+		if err := repo.Create(ctx, g); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+	}
+
+	list, err := repo.FindByUserID(ctx, owner)
+	if err != nil {
+		t.Fatalf("FindByUserID returned error: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "compute" || list[1].Name != "storage" {
+		t.Fatalf("expected the owner's guardrails by name, got %+v", list)
+	}
+	if list[0].Enabled {
+		t.Fatalf("expected the compute guardrail to stay disabled")
+	}
+
+	list[1].Description = "Buckets"
+	if err := repo.Update(ctx, list[1]); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	found, err := repo.FindByID(ctx, list[1].ID)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if found.Description != "Buckets" {
+		t.Fatalf("expected updated description, got %q", found.Description)
+	}
+
+	if err := repo.Delete(ctx, found.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, found.ID); err == nil {
+		t.Fatalf("expected FindByID to fail after Delete")
+	}
+}
@@ -140,3 +140,272 @@ func TestMarketplaceRepositories_BasicOperations(t *testing.T) {
 		t.Fatalf("ReviewRepository.FindByTemplate error: %v", err)
 	}
 }
+
+func TestTemplateRepository_Search(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(
+		t,
+		&models.Category{},
+		&models.Template{},
+		&models.Technology{},
+		&models.ComplianceStandard{},
+		&models.IACFormat{},
+		&models.User{},
+	)
+	base := repository.NewBaseRepositoryWithDB(db)
+	tmplRepo := &templaterepo.TemplateRepository{BaseRepository: base}
+
+	networking := models.Category{ID: uuid.New(), Name: "Networking", Slug: "networking", CreatedAt: time.Now()}
+	web := models.Category{ID: uuid.New(), Name: "Web", Slug: "web", CreatedAt: time.Now()}
+	if err := db.Create(&[]models.Category{networking, web}).Error; err != nil {
+		t.Fatalf("failed to create categories: %v", err)
+	}
+	lambda := models.Technology{ID: uuid.New(), Name: "Lambda", Slug: "lambda", CreatedAt: time.Now()}
+	author := &models.User{ID: uuid.New(), Name: "Author", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := db.Create(author).Error; err != nil {
+		t.Fatalf("failed to create author: %v", err)
+	}
+
+	newTemplate := func(title string, category uuid.UUID, costMin, costMax float64, downloads int, technologies ...models.Technology) *models.Template {
+		template := &models.Template{
+			ID:               uuid.New(),
+			Title:            title,
+			Description:      title + " template",
+			CategoryID:       category,
+			CloudProvider:    "AWS",
+			Downloads:        downloads,
+			EstimatedCostMin: costMin,
+			EstimatedCostMax: costMax,
+			AuthorID:         author.ID,
+			Technologies:     technologies,
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		}
+		if err := tmplRepo.Create(ctx, template); err != nil {
+			t.Fatalf("TemplateRepository.Create error: %v", err)
+		}
+		return template
+	}
+	vpc := newTemplate("VPC Baseline", networking.ID, 30, 40, 5)
+	api := newTemplate("Serverless API", web.ID, 2, 8, 50, lambda)
+	site := newTemplate("Static Site", web.ID, 1, 3, 10)
+
+	titles := func(templates []*models.Template) []string {
+		out := make([]string, 0, len(templates))
+		for _, template := range templates {
+			out = append(out, template.Title)
+		}
+		return out
+	}
+
+	templates, total, err := tmplRepo.Search(ctx, models.TemplateFilter{Category: "web", Sort: "downloads"})
+	if err != nil {
+		t.Fatalf("Search by category error: %v", err)
+	}
+	if total != 2 || len(templates) != 2 || templates[0].ID != api.ID || templates[1].ID != site.ID {
+		t.Fatalf("Search by category = %v (total %d), want [Serverless API Static Site]", titles(templates), total)
+	}
+	if templates[0].Category.Slug != "web" {
+		t.Errorf("expected the category to be preloaded, got %+v", templates[0].Category)
+	}
+
+	templates, total, err = tmplRepo.Search(ctx, models.TemplateFilter{Technology: "lambda"})
+	if err != nil {
+		t.Fatalf("Search by technology error: %v", err)
+	}
+	if total != 1 || len(templates) != 1 || templates[0].ID != api.ID || len(templates[0].Technologies) != 1 {
+		t.Errorf("Search by technology = %v (total %d), want [Serverless API] with its technology", titles(templates), total)
+	}
+
+	minCost, maxCost := 5.0, 35.0
+	templates, total, err = tmplRepo.Search(ctx, models.TemplateFilter{MinCost: &minCost, MaxCost: &maxCost, Sort: "cost"})
+	if err != nil {
+		t.Fatalf("Search by cost error: %v", err)
+	}
+	if total != 2 || len(templates) != 2 || templates[0].ID != api.ID || templates[1].ID != vpc.ID {
+		t.Errorf("Search by cost = %v (total %d), want [Serverless API VPC Baseline]", titles(templates), total)
+	}
+
+	templates, total, err = tmplRepo.Search(ctx, models.TemplateFilter{Search: "site", Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("Search by text error: %v", err)
+	}
+	if total != 1 || len(templates) != 1 || templates[0].ID != site.ID {
+		t.Errorf("Search by text = %v (total %d), want [Static Site]", titles(templates), total)
+	}
+
+	templates, total, err = tmplRepo.Search(ctx, models.TemplateFilter{Sort: "downloads", Page: 2, Limit: 2})
+	if err != nil {
+		t.Fatalf("Search page 2 error: %v", err)
+	}
+	if total != 3 || len(templates) != 1 || templates[0].ID != vpc.ID {
+		t.Errorf("Search page 2 = %v (total %d), want [VPC Baseline] of 3", titles(templates), total)
+	}
+
+	if err := tmplRepo.IncrementDownloads(ctx, vpc.ID); err != nil {
+		t.Fatalf("IncrementDownloads error: %v", err)
+	}
+	if err := tmplRepo.UpdateEstimatedCost(ctx, vpc.ID, 20, 45); err != nil {
+		t.Fatalf("UpdateEstimatedCost error: %v", err)
+	}
+	var updated models.Template
+	if err := db.First(&updated, "id = ?", vpc.ID).Error; err != nil {
+		t.Fatalf("failed to reload template: %v", err)
+	}
+	if updated.Downloads != 6 || updated.EstimatedCostMin != 20 || updated.EstimatedCostMax != 45 {
+		t.Errorf("updated template = downloads %d cost %v-%v, want 6 and 20-45", updated.Downloads, updated.EstimatedCostMin, updated.EstimatedCostMax)
+	}
+}
+
+func TestReviewRepository_RatingAndModeration(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(
+		t,
+		&models.Category{},
+		&models.Template{},
+		&models.Review{},
+		&models.ReviewFlag{},
+		&models.TemplateInstantiation{},
+		&models.User{},
+	)
+	base := repository.NewBaseRepositoryWithDB(db)
+	tmplRepo := &templaterepo.TemplateRepository{BaseRepository: base}
+	reviewRepo := &templaterepo.ReviewRepository{BaseRepository: base}
+	instRepo := &templaterepo.TemplateInstantiationRepository{BaseRepository: base}
+
+	category := models.Category{ID: uuid.New(), Name: "Web", Slug: "web", CreatedAt: time.Now()}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	users := make([]models.User, 4)
+	for i := range users {
+		users[i] = models.User{ID: uuid.New(), Name: "User", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users: %v", err)
+	}
+	template := &models.Template{
+		ID:            uuid.New(),
+		Title:         "Static Site",
+		Description:   "S3 and CloudFront",
+		CategoryID:    category.ID,
+		CloudProvider: "AWS",
+		AuthorID:      users[0].ID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := tmplRepo.Create(ctx, template); err != nil {
+		t.Fatalf("TemplateRepository.Create error: %v", err)
+	}
+
+	newReview := func(user uuid.UUID, rating int) *models.Review {
+		review := &models.Review{
+			ID:         uuid.New(),
+			TemplateID: template.ID,
+			UserID:     user,
+			Rating:     rating,
+			Title:      "Review",
+			Content:    "Content",
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		if err := reviewRepo.Create(ctx, review); err != nil {
+			t.Fatalf("ReviewRepository.Create error: %v", err)
+		}
+		return review
+	}
+	good := newReview(users[1].ID, 5)
+	newReview(users[2].ID, 2)
+
+	assertRating := func(wantRating float64, wantCount int) {
+		t.Helper()
+		if err := reviewRepo.RecalculateTemplateRating(ctx, template.ID); err != nil {
+			t.Fatalf("RecalculateTemplateRating error: %v", err)
+		}
+		var got models.Template
+		if err := db.First(&got, "id = ?", template.ID).Error; err != nil {
+			t.Fatalf("failed to reload template: %v", err)
+		}
+		if got.Rating != wantRating || got.ReviewCount != wantCount {
+			t.Errorf("rating = %v over %d reviews, want %v over %d", got.Rating, got.ReviewCount, wantRating, wantCount)
+		}
+	}
+	assertRating(3.5, 2)
+
+	if _, err := reviewRepo.FindByTemplateAndUser(ctx, template.ID, users[1].ID); err != nil {
+		t.Fatalf("FindByTemplateAndUser error: %v", err)
+	}
+	if _, err := reviewRepo.FindByTemplateAndUser(ctx, template.ID, users[3].ID); err == nil {
+		t.Error("expected FindByTemplateAndUser to fail for a user without a review")
+	}
+
+	// A second flag by the same user is rejected; two distinct flags hide the review at a threshold of 2
+	flag := &models.ReviewFlag{ReviewID: good.ID, UserID: users[2].ID, CreatedAt: time.Now()}
+	if err := reviewRepo.CreateFlag(ctx, flag); err != nil {
+		t.Fatalf("CreateFlag error: %v", err)
+	}
+	if err := reviewRepo.CreateFlag(ctx, flag); err == nil {
+		t.Error("expected a second flag by the same user to be rejected")
+	}
+	for i := 0; i < 2; i++ {
+		if err := reviewRepo.IncrementFlagCount(ctx, good.ID, 2); err != nil {
+			t.Fatalf("IncrementFlagCount error: %v", err)
+		}
+		got, err := reviewRepo.FindByID(ctx, good.ID)
+		if err != nil {
+			t.Fatalf("FindByID error: %v", err)
+		}
+		if got.FlagCount != i+1 || got.Hidden != (i == 1) {
+			t.Errorf("after %d flags: flag_count=%d hidden=%v", i+1, got.FlagCount, got.Hidden)
+		}
+	}
+	assertRating(2, 1)
+	visible, total, err := reviewRepo.FindVisibleByTemplate(ctx, template.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("FindVisibleByTemplate error: %v", err)
+	}
+	if total != 1 || len(visible) != 1 || visible[0].UserID != users[2].ID {
+		t.Errorf("FindVisibleByTemplate = %d reviews (total %d), want the unflagged review", len(visible), total)
+	}
+
+	// Code generation marks the instantiation once and verifies the review
+	projectID := uuid.New()
+	if err := instRepo.Create(ctx, &models.TemplateInstantiation{
+		ID:         uuid.New(),
+		TemplateID: template.ID,
+		UserID:     users[1].ID,
+		ProjectID:  projectID,
+		CreatedAt:  time.Now(),
+	}); err != nil {
+		t.Fatalf("TemplateInstantiationRepository.Create error: %v", err)
+	}
+	marked, err := instRepo.MarkCodeGenerated(ctx, projectID)
+	if err != nil {
+		t.Fatalf("MarkCodeGenerated error: %v", err)
+	}
+	if len(marked) != 1 || marked[0].CodeGeneratedAt == nil {
+		t.Fatalf("MarkCodeGenerated = %+v, want one marked instantiation", marked)
+	}
+	if err := reviewRepo.SetVerifiedDeployment(ctx, template.ID, users[1].ID); err != nil {
+		t.Fatalf("SetVerifiedDeployment error: %v", err)
+	}
+	instantiations, err := instRepo.FindByTemplateAndUser(ctx, template.ID, users[1].ID)
+	if err != nil {
+		t.Fatalf("FindByTemplateAndUser error: %v", err)
+	}
+	if len(instantiations) != 1 || instantiations[0].CodeGeneratedAt == nil {
+		t.Errorf("expected the stored instantiation to be marked, got %+v", instantiations)
+	}
+	if got, _ := reviewRepo.FindByID(ctx, good.ID); got == nil || !got.VerifiedDeployment {
+		t.Error("expected the review to be a verified deployment")
+	}
+
+	if err := reviewRepo.Delete(ctx, good.ID); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	var flags int64
+	db.Model(&models.ReviewFlag{}).Where("review_id = ?", good.ID).Count(&flags)
+	if flags != 0 {
+		t.Errorf("expected the review's flags to be deleted, %d left", flags)
+	}
+}
@@ -28,11 +28,22 @@ func newTestDB(t *testing.T, modelsToMigrate ...interface{}) *gorm.DB {
 			name TEXT,
 			email TEXT,
 			auth0_id TEXT,
+			password_hash TEXT,
 			avatar TEXT,
 			is_verified INTEGER,
 			created_at DATETIME,
 			updated_at DATETIME
 		);`,
+		`CREATE TABLE IF NOT EXISTS guardrails (
+			id TEXT PRIMARY KEY,
+			user_id TEXT,
+			name TEXT,
+			description TEXT,
+			source TEXT,
+			enabled INTEGER,
+			created_at DATETIME,
+			updated_at DATETIME
+		);`,
 		`CREATE TABLE IF NOT EXISTS projects (
 			id TEXT PRIMARY KEY,
 			root_project_id TEXT,
@@ -44,6 +55,9 @@ func newTestDB(t *testing.T, modelsToMigrate ...interface{}) *gorm.DB {
 			region TEXT,
 			thumbnail TEXT,
 			tags TEXT,
+			backend_config TEXT,
+			usage_profile TEXT,
+			budget TEXT,
 			created_at DATETIME,
 			updated_at DATETIME,
 			deleted_at DATETIME
@@ -152,6 +166,9 @@ func newTestDB(t *testing.T, modelsToMigrate ...interface{}) *gorm.DB {
 			resources INTEGER,
 			deployment_time TEXT,
 			regions TEXT,
+			architecture TEXT,
+			source_version_id TEXT,
+			parameters TEXT,
 			created_at DATETIME,
 			updated_at DATETIME
 		);`,
@@ -167,9 +184,27 @@ func newTestDB(t *testing.T, modelsToMigrate ...interface{}) *gorm.DB {
 			deployment_time TEXT,
 			helpful_count INTEGER,
 			creator_response TEXT,
+			verified_deployment INTEGER DEFAULT 0,
+			flag_count INTEGER DEFAULT 0,
+			hidden INTEGER DEFAULT 0,
 			created_at DATETIME,
 			updated_at DATETIME
 		);`,
+		`CREATE TABLE IF NOT EXISTS review_flags (
+			review_id TEXT,
+			user_id TEXT,
+			reason TEXT,
+			created_at DATETIME,
+			PRIMARY KEY (review_id, user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS template_instantiations (
+			id TEXT PRIMARY KEY,
+			template_id TEXT,
+			user_id TEXT,
+			project_id TEXT,
+			code_generated_at DATETIME,
+			created_at DATETIME
+		);`,
 		`CREATE TABLE IF NOT EXISTS iac_formats (
 			id TEXT PRIMARY KEY,
 			name TEXT,
@@ -245,6 +280,7 @@ func newTestDB(t *testing.T, modelsToMigrate ...interface{}) *gorm.DB {
 			duration_seconds INTEGER,
 			provider TEXT,
 			region TEXT,
+			budget_status TEXT,
 			calculated_at DATETIME
 		);`,
 		`CREATE TABLE IF NOT EXISTS service_pricing (
@@ -20,11 +20,13 @@ type ArchitectureService interface {
 	GetSortedResources(ctx context.Context, arch *architecture.Architecture) ([]*resource.Resource, error)
 }
 
-// RuleValidationResult contains the result of rule validation
+// RuleValidationResult contains the result of rule validation. Warnings come from
+// guardrails of warning severity and do not make the result invalid.
 type RuleValidationResult struct {
-	Valid   bool
-	Results map[string]*ResourceValidationResult
-	Errors  []string
+	Valid    bool
+	Results  map[string]*ResourceValidationResult
+	Errors   []string
+	Warnings []string
 }
 
 // ResourceValidationResult contains validation result for a single resource
@@ -41,4 +43,5 @@ type ValidationError struct {
 	ResourceType string
 	Message      string
 	Code         string
+	Severity     string // "error" or "warning"
 }
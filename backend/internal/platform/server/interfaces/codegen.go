@@ -10,7 +10,7 @@ import (
 // CodegenService handles Infrastructure as Code generation
 type CodegenService interface {
 	// Generate generates IaC code for an architecture using the specified engine
-	Generate(ctx context.Context, arch *architecture.Architecture, engine string) (*iac.Output, error)
+	Generate(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error)
 
 	// SupportedEngines returns a list of supported IaC engines (e.g., "terraform", "pulumi")
 	SupportedEngines() []string
@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceService evaluates designed architectures against the built-in control sets
+// of compliance frameworks (see internal/compliance).
+type ComplianceService interface {
+	// ListStandards returns the standards that can be evaluated
+	ListStandards(ctx context.Context) []ComplianceStandardInfo
+
+	// EvaluateVersion checks a project version against a standard
+	EvaluateVersion(ctx context.Context, projectID, versionID uuid.UUID, standard string) (*ComplianceReport, error)
+
+	// VerifyTemplate checks that a project version, built from a marketplace template,
+	// satisfies every compliance standard the template claims
+	VerifyTemplate(ctx context.Context, templateID, projectID, versionID uuid.UUID) (*TemplateComplianceVerification, error)
+}
+
+// Template compliance claim statuses.
+const (
+	ComplianceClaimVerified    = "verified"
+	ComplianceClaimFailed      = "failed"
+	ComplianceClaimUnsupported = "unsupported" // no built-in control set for the standard
+)
+
+// ComplianceStandardInfo describes a built-in standard.
+type ComplianceStandardInfo struct {
+	Slug         string `json:"slug"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ControlCount int    `json:"control_count"`
+}
+
+// ComplianceReport is the per-control result of evaluating a version against a standard.
+type ComplianceReport struct {
+	VersionID     uuid.UUID                 `json:"version_id"`
+	Standard      string                    `json:"standard"`
+	Name          string                    `json:"name"`
+	Passed        bool                      `json:"passed"` // no control failed
+	PassCount     int                       `json:"pass_count"`
+	FailCount     int                       `json:"fail_count"`
+	NotApplicable int                       `json:"not_applicable_count"`
+	Controls      []ComplianceControlResult `json:"controls"`
+}
+
+// ComplianceControlResult is the outcome of one control.
+type ComplianceControlResult struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Severity    string   `json:"severity"` // critical, high, medium or low
+	Status      string   `json:"status"`   // pass, fail or not_applicable
+	ResourceIDs []string `json:"resource_ids"`
+	Remediation string   `json:"remediation"`
+}
+
+// TemplateComplianceVerification is the result of checking a template's compliance claims.
+type TemplateComplianceVerification struct {
+	TemplateID uuid.UUID                 `json:"template_id"`
+	VersionID  uuid.UUID                 `json:"version_id"`
+	Verified   bool                      `json:"verified"` // every claim is verified
+	Claims     []TemplateComplianceClaim `json:"claims"`
+}
+
+// TemplateComplianceClaim is one compliance standard claimed by a template.
+type TemplateComplianceClaim struct {
+	Standard string            `json:"standard"`
+	Name     string            `json:"name"`
+	Status   string            `json:"status"` // verified, failed or unsupported
+	Report   *ComplianceReport `json:"report,omitempty"`
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/state"
+)
+
+// DriftService compares designed architectures with deployed infrastructure.
+type DriftService interface {
+	// DetectDrift compares a project version with a Terraform state file (format version 4).
+	// Resources are matched by the Terraform addresses the generator writes for the version.
+	DetectDrift(ctx context.Context, projectID, versionID uuid.UUID, req *DetectDriftRequest) (*DriftResult, error)
+}
+
+// DetectDriftRequest is the payload for POST /projects/{id}/versions/{version_id}/drift.
+type DetectDriftRequest struct {
+	State json.RawMessage `json:"state" binding:"required" swaggertype:"object"`
+}
+
+// DriftResult is the drift report of one version against one state snapshot.
+type DriftResult struct {
+	VersionID uuid.UUID `json:"version_id"`
+	Lineage   string    `json:"lineage"` // state lineage and serial identify the snapshot compared
+	Serial    int64     `json:"serial"`
+	state.DriftReport
+}
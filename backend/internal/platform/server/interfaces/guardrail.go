@@ -0,0 +1,52 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/policy"
+)
+
+// GuardrailService manages policy-as-code guardrails and evaluates them against
+// architectures. Guardrails are policy documents (see internal/policy) owned by a user;
+// instance-wide guardrails are loaded from files at startup and apply to everyone.
+type GuardrailService interface {
+	// List returns the guardrails owned by a user
+	List(ctx context.Context, userID uuid.UUID) ([]*models.Guardrail, error)
+
+	// Get returns one of the user's guardrails
+	Get(ctx context.Context, userID, id uuid.UUID) (*models.Guardrail, error)
+
+	// Create parses and saves a new guardrail for the user
+	Create(ctx context.Context, userID uuid.UUID, req *GuardrailRequest) (*models.Guardrail, error)
+
+	// Update changes one of the user's guardrails; nil request fields are left unchanged
+	Update(ctx context.Context, userID, id uuid.UUID, req *GuardrailRequest) (*models.Guardrail, error)
+
+	// Delete removes one of the user's guardrails
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// InstanceGuardrails returns the instance-wide guardrails
+	InstanceGuardrails() []InstanceGuardrail
+
+	// Evaluate checks an architecture against the instance-wide guardrails and the
+	// enabled guardrails of the authenticated caller, if any
+	Evaluate(ctx context.Context, arch *architecture.Architecture) ([]policy.Violation, error)
+}
+
+// GuardrailRequest holds the fields of a guardrail to create or update.
+type GuardrailRequest struct {
+	Name        *string
+	Description *string
+	Source      *string
+	Enabled     *bool
+}
+
+// InstanceGuardrail is a guardrail file loaded at startup.
+type InstanceGuardrail struct {
+	Name     string   `json:"name"`
+	Source   string   `json:"source"`
+	Policies []string `json:"policies"`
+}
@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+)
+
+// ImportService turns existing infrastructure code into project versions
+type ImportService interface {
+	// ImportTerraform parses the given Terraform files and saves the resources it recognises
+	// as a new version of the project. Anything that could not be carried over is reported
+	// in the result's Issues instead of failing the import.
+	ImportTerraform(ctx context.Context, projectID uuid.UUID, req *ImportTerraformRequest) (*ImportResult, error)
+
+	// ImportState creates a new project from a Terraform state file (format version 4).
+	// Managed resources are drawn with the IDs, ARNs and lifecycle state the state recorded,
+	// so the project shows what is deployed rather than what was designed.
+	ImportState(ctx context.Context, userID uuid.UUID, req *ImportStateRequest) (*StateImportResult, error)
+}
+
+// ImportTerraformRequest is the payload for POST /projects/{id}/import/terraform.
+type ImportTerraformRequest struct {
+	Files   map[string]string `json:"files" binding:"required"` // file name -> HCL source
+	Message string            `json:"message"`
+}
+
+// ImportResult is the version created by an import together with what was left out.
+type ImportResult struct {
+	Version *ProjectVersionDetail  `json:"version"`
+	Region  string                 `json:"region,omitempty"`
+	Issues  []tfmapper.ImportIssue `json:"issues"`
+}
+
+// ImportStateRequest is the payload for POST /projects/import/tfstate.
+type ImportStateRequest struct {
+	Name      string          `json:"name" binding:"required,min=3,max=100"`
+	IACToolID uint            `json:"iac_tool_id" binding:"required,min=1"`
+	Region    string          `json:"region"` // defaults to the region recorded in the state
+	State     json.RawMessage `json:"state" binding:"required" swaggertype:"object"`
+}
+
+// StateImportResult is the project created from a state file.
+type StateImportResult struct {
+	ProjectID uuid.UUID              `json:"project_id"`
+	Version   *ProjectVersionDetail  `json:"version"`
+	Region    string                 `json:"region"`
+	Issues    []tfmapper.ImportIssue `json:"issues"`
+}
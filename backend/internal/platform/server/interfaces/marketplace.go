@@ -0,0 +1,83 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+)
+
+// MarketplaceService publishes project versions as marketplace templates and turns
+// templates back into projects.
+type MarketplaceService interface {
+	// Publish saves a version of one of the caller's projects as a template. The version's
+	// architecture is stored with the template and its monthly cost range is estimated.
+	Publish(ctx context.Context, userID uuid.UUID, req *PublishTemplateRequest) (*models.Template, error)
+
+	// Search lists the templates matching a filter, with the number of matches
+	Search(ctx context.Context, filter models.TemplateFilter) ([]*models.Template, int64, error)
+
+	// Get returns a template with its features, use cases, components, tags, author and the
+	// parameters chosen on instantiation
+	Get(ctx context.Context, templateID uuid.UUID) (*models.Template, error)
+
+	// Instantiate creates a project for the caller from a template's architecture, with the
+	// chosen parameter values substituted, and estimates the new project's monthly cost range.
+	// The instantiation counts as a download and lets the caller review the template.
+	Instantiate(ctx context.Context, userID, templateID uuid.UUID, req *InstantiateTemplateRequest) (*TemplateInstance, error)
+}
+
+// PublishTemplateRequest holds the version to publish and its marketplace listing.
+// Category, technologies, compliance standards and IaC formats are slugs.
+type PublishTemplateRequest struct {
+	ProjectID           uuid.UUID
+	VersionID           uuid.UUID
+	Title               string
+	Description         string
+	Category            string
+	Technologies        []string
+	ComplianceStandards []string
+	IACFormats          []string
+	Features            []string
+	UseCases            []TemplateUseCaseInput
+	ImageURL            *string
+	DeploymentTime      *string
+	// Parameters describe or restrict the parameters derived from the version
+	Parameters []TemplateParameterInput
+}
+
+// TemplateParameterInput describes a parameter of a template to publish. Options, when
+// set, are the only values accepted on instantiation.
+type TemplateParameterInput struct {
+	Name        string
+	Description *string
+	Options     []string
+}
+
+// TemplateUseCaseInput is a use case of a template to publish.
+type TemplateUseCaseInput struct {
+	Title       string
+	Description *string
+	Icon        *string
+}
+
+// InstantiateTemplateRequest holds the details of the project to create from a template.
+type InstantiateTemplateRequest struct {
+	Name      string
+	IACToolID uint
+	// Parameters holds the chosen parameter values by name; parameters left out take their default
+	Parameters map[string]interface{}
+}
+
+// TemplateInstance is the project created from a template.
+type TemplateInstance struct {
+	TemplateID uuid.UUID             `json:"template_id"`
+	ProjectID  uuid.UUID             `json:"project_id"`
+	Version    *ProjectVersionDetail `json:"version"`
+	// Parameters holds the parameter values the project was created with; sensitive values are left out
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// EstimatedCostMin and EstimatedCostMax are the monthly cost range of the new project, with
+	// the chosen parameters: the cheapest purchase option and on-demand
+	EstimatedCostMin float64 `json:"estimated_cost_min"`
+	EstimatedCostMax float64 `json:"estimated_cost_max"`
+}
@@ -0,0 +1,48 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// NetworkService analyzes the network paths of designed architectures.
+type NetworkService interface {
+	// AnalyzeReachability works out which resources of a project version are reachable
+	// from the internet and from each other, from security group rules, route tables,
+	// internet and NAT gateways and subnet placement.
+	AnalyzeReachability(ctx context.Context, projectID, versionID uuid.UUID) (*ReachabilityReport, error)
+}
+
+// ReachabilityReport is the reachability matrix and findings of one version.
+type ReachabilityReport struct {
+	VersionID uuid.UUID              `json:"version_id"`
+	Resources []ReachabilityResource `json:"resources"`
+	// Matrix maps a source resource ID, or "internet", to the target resource IDs it can
+	// open connections to and the ports it can reach them on ("tcp/443", "tcp/8000-8080",
+	// "all").
+	Matrix   map[string]map[string][]string `json:"matrix"`
+	Findings []ReachabilityFinding          `json:"findings"`
+}
+
+// ReachabilityResource is a resource taking part in network traffic.
+type ReachabilityResource struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	SecurityGroups []string `json:"security_groups"`
+	Public         bool     `json:"public"`          // reachable from the internet when its security groups allow it
+	InternetEgress bool     `json:"internet_egress"` // can reach the internet, directly or through a NAT gateway
+}
+
+// ReachabilityFinding is a network exposure issue, such as a database reachable from
+// the internet or SSH open to the world.
+type ReachabilityFinding struct {
+	Severity     string   `json:"severity"` // critical, high, medium or low
+	Code         string   `json:"code"`
+	ResourceID   string   `json:"resource_id"`
+	ResourceName string   `json:"resource_name"`
+	ResourceType string   `json:"resource_type"`
+	Ports        []string `json:"ports"`
+	Message      string   `json:"message"`
+}
@@ -17,15 +17,19 @@ type OptimizationWithSavings struct {
 	Suggestions           []OptimizationSuggestion `json:"suggestions"`
 	TotalPotentialSavings float64                  `json:"total_potential_savings"`
 	Currency              string                   `json:"currency"`
+	Period                string                   `json:"period"` // savings are "monthly"
 }
 
 // OptimizationSuggestion represents a single cost optimization recommendation
 type OptimizationSuggestion struct {
-	ID               string  `json:"id"`
-	Severity         string  `json:"severity"` // "high", "medium", "low"
-	Title            string  `json:"title"`
-	Description      string  `json:"description"`
-	EstimatedSavings float64 `json:"estimated_savings"`
-	ResourceType     string  `json:"resource_type"`
-	ResourceID       string  `json:"resource_id,omitempty"`
+	ID                   string  `json:"id"`
+	Rule                 string  `json:"rule"`     // e.g. "rightsizing", "graviton", "ebs_gp3"
+	Severity             string  `json:"severity"` // "high", "medium", "low"
+	Title                string  `json:"title"`
+	Description          string  `json:"description"`
+	EstimatedSavings     float64 `json:"estimated_savings"`
+	CurrentMonthlyCost   float64 `json:"current_monthly_cost"`
+	OptimizedMonthlyCost float64 `json:"optimized_monthly_cost"`
+	ResourceType         string  `json:"resource_type"`
+	ResourceID           string  `json:"resource_id,omitempty"`
 }
@@ -44,4 +44,5 @@ type GenerateCodeRequest struct {
 	ProjectID     uuid.UUID
 	Engine        string
 	CloudProvider string
+	Options       iac.Options
 }
@@ -19,11 +19,23 @@ type PricingService interface {
 	// CalculateArchitectureCost calculates the total cost for an architecture over a given duration
 	CalculateArchitectureCost(ctx context.Context, arch *architecture.Architecture, duration time.Duration) (*ArchitectureCostEstimate, error)
 
+	// CalculateArchitectureCostWithPurchaseOption calculates the total cost for an architecture with
+	// compute paid under a purchase option (on-demand, reserved instance or savings plan)
+	CalculateArchitectureCostWithPurchaseOption(ctx context.Context, arch *architecture.Architecture, duration time.Duration, option domainpricing.PurchaseOption) (*ArchitectureCostEstimate, error)
+
+	// ComparePurchaseOptions calculates the total cost for an architecture under every purchase option
+	ComparePurchaseOptions(ctx context.Context, arch *architecture.Architecture, duration time.Duration) (*PurchaseOptionComparison, error)
+
+	// ForecastArchitectureCost projects the monthly cost of an architecture over months, with
+	// instances, storage and traffic growing and 12 month free tier allowances expiring
+	ForecastArchitectureCost(ctx context.Context, arch *architecture.Architecture, req *CostForecastRequest) (*CostForecast, error)
+
 	// PersistResourcePricing saves resource pricing to the database
 	PersistResourcePricing(ctx context.Context, projectID, resourceID uuid.UUID, estimate *domainpricing.CostEstimate, provider, region string) error
 
-	// PersistProjectPricing saves project-level pricing to the database
-	PersistProjectPricing(ctx context.Context, projectID uuid.UUID, estimate *domainpricing.CostEstimate, provider, region string) error
+	// PersistProjectPricing saves project-level pricing to the database, with the comparison
+	// against the project budget (nil when the project has no budget)
+	PersistProjectPricing(ctx context.Context, projectID uuid.UUID, estimate *domainpricing.CostEstimate, provider, region string, budget *domainpricing.BudgetStatus) error
 
 	// GetProjectPricing retrieves pricing for a project
 	GetProjectPricing(ctx context.Context, projectID uuid.UUID) ([]*models.ProjectPricing, error)
@@ -48,6 +60,92 @@ type ArchitectureCostEstimate struct {
 	Provider string `json:"provider"`
 	// Region is the region (if applicable)
 	Region string `json:"region,omitempty"`
+	// PurchaseOption is how compute is paid for in this estimate
+	PurchaseOption string `json:"purchase_option"`
+	// Warnings lists resources left out of the estimate because their region has no pricing data
+	Warnings []string `json:"warnings,omitempty"`
+	// Budget is the comparison against the project budget, omitted when the project has none
+	Budget *domainpricing.BudgetStatus `json:"budget,omitempty"`
+}
+
+// PurchaseOptionComparison compares the cost of an architecture across purchase options
+type PurchaseOptionComparison struct {
+	// Currency is the currency used
+	Currency string `json:"currency"`
+	// Period is the time period of the costs
+	Period string `json:"period"`
+	// OnDemandCost is the total cost with everything paid on-demand
+	OnDemandCost float64 `json:"on_demand_cost"`
+	// Scenarios contains one entry per purchase option, on-demand first
+	Scenarios []PurchaseOptionScenario `json:"scenarios"`
+}
+
+// PurchaseOptionScenario is the cost of an architecture under one purchase option
+type PurchaseOptionScenario struct {
+	// PurchaseOption identifies the option (e.g., "reserved_1yr_all_upfront")
+	PurchaseOption string `json:"purchase_option"`
+	// Name is a human readable name (e.g., "Reserved 1yr All Upfront")
+	Name string `json:"name"`
+	// Model is the pricing model of the compute rates (per_hour, reserved or savings_plan)
+	Model string `json:"model"`
+	// Term is the commitment term ("1yr" or "3yr"), empty for on-demand
+	Term string `json:"term,omitempty"`
+	// PaymentOption is no_upfront, partial_upfront or all_upfront, empty for on-demand
+	PaymentOption string `json:"payment_option,omitempty"`
+	// TotalCost is the total cost of the architecture, with upfront payments amortized over the term
+	TotalCost float64 `json:"total_cost"`
+	// ComputeCost is the part of TotalCost spent on EC2 instances
+	ComputeCost float64 `json:"compute_cost"`
+	// Savings is the difference with the on-demand cost
+	Savings float64 `json:"savings"`
+	// SavingsPercent is Savings as a percentage of the on-demand cost
+	SavingsPercent float64 `json:"savings_percent"`
+}
+
+// CostForecastRequest configures a cost forecast
+type CostForecastRequest struct {
+	// Months is the forecast horizon, 12 to 36 (default 12)
+	Months int `json:"months"`
+	// StartMonth is the first month of the forecast as YYYY-MM (default the current month)
+	StartMonth string `json:"start_month,omitempty"`
+	// Growth holds the monthly growth of instances, storage and traffic
+	Growth domainpricing.GrowthAssumptions `json:"growth"`
+	// FreeTierMonths is how many months of the 12 month AWS Free Tier the account has left (0 to 12)
+	FreeTierMonths int `json:"free_tier_months,omitempty"`
+	// PurchaseOption is how compute is paid for (default on_demand)
+	PurchaseOption string `json:"purchase_option,omitempty"`
+}
+
+// CostForecast is the projected monthly cost of an architecture
+type CostForecast struct {
+	// Currency is the currency used
+	Currency string `json:"currency"`
+	// Months is the forecast horizon
+	Months int `json:"months"`
+	// PurchaseOption is how compute is paid for in this forecast
+	PurchaseOption string `json:"purchase_option"`
+	// TotalCost is the cost over the whole horizon
+	TotalCost float64 `json:"total_cost"`
+	// FreeTierSavings is the part of the cost covered by free tier allowances over the horizon
+	FreeTierSavings float64 `json:"free_tier_savings"`
+	// Services lists the services (resource types) of the series, sorted
+	Services []string `json:"services"`
+	// Series contains one entry per month, in order
+	Series []ForecastMonth `json:"series"`
+	// Warnings lists resources left out of the forecast because their region has no pricing data
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ForecastMonth is the projected cost of one month
+type ForecastMonth struct {
+	// Month is the month as YYYY-MM
+	Month string `json:"month"`
+	// TotalCost is the cost of the month, after free tier credits
+	TotalCost float64 `json:"total_cost"`
+	// FreeTierCredit is the cost covered by free tier allowances this month
+	FreeTierCredit float64 `json:"free_tier_credit"`
+	// Services holds the cost of the month by service (resource type)
+	Services map[string]float64 `json:"services"`
 }
 
 // ResourceCostEstimate contains the cost estimate for a single resource
@@ -58,6 +156,8 @@ type ResourceCostEstimate struct {
 	ResourceName string `json:"resource_name"`
 	// ResourceType is the type of resource
 	ResourceType string `json:"resource_type"`
+	// Category is the resource category, e.g. "Compute" (empty when unknown)
+	Category string `json:"category,omitempty"`
 	// TotalCost is the total estimated cost for this resource
 	TotalCost float64 `json:"total_cost"`
 	// Currency is the currency used
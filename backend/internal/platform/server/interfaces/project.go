@@ -7,7 +7,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 )
 
 // ProjectService handles project management and persistence.
@@ -38,6 +40,38 @@ type ProjectService interface {
 	// Delete hard-deletes a project snapshot and its resources.
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// ── State backend (non-versioned) ────────────────────────────────────────
+
+	// GetBackend returns the project's Terraform state backend, or nil when it uses local state.
+	GetBackend(ctx context.Context, projectID uuid.UUID) (*iac.StateBackend, error)
+
+	// UpdateBackend validates and saves the state backend in place; nil resets to local state.
+	// New snapshots inherit the setting.
+	UpdateBackend(ctx context.Context, projectID uuid.UUID, backend *iac.StateBackend) (*iac.StateBackend, error)
+
+	// ── Usage profile (non-versioned) ────────────────────────────────────────
+
+	// GetUsageProfile returns the workload the project is priced for, or nil for 24/7 with no traffic.
+	GetUsageProfile(ctx context.Context, projectID uuid.UUID) (*domainpricing.UsageProfile, error)
+
+	// UpdateUsageProfile validates and saves the usage profile in place; nil removes it.
+	// New snapshots inherit the profile, with traffic annotations of their diagram edges.
+	UpdateUsageProfile(ctx context.Context, projectID uuid.UUID, profile *domainpricing.UsageProfile) (*domainpricing.UsageProfile, error)
+
+	// ── Budget (non-versioned) ───────────────────────────────────────────────
+
+	// GetBudget returns the project's monthly budget, or nil when it has none.
+	GetBudget(ctx context.Context, projectID uuid.UUID) (*domainpricing.Budget, error)
+
+	// UpdateBudget validates and saves the budget in place; nil removes it.
+	// New snapshots inherit the budget and are compared against it when created.
+	UpdateBudget(ctx context.Context, projectID uuid.UUID, budget *domainpricing.Budget) (*domainpricing.Budget, error)
+
+	// EvaluateBudget compares an estimate of a project snapshot against its budget, sets
+	// estimate.Budget and records the comparison with the snapshot's pricing.
+	// It returns nil when the project has no budget.
+	EvaluateBudget(ctx context.Context, projectID uuid.UUID, estimate *ArchitectureCostEstimate) (*domainpricing.BudgetStatus, error)
+
 	// ── Version CRUD ─────────────────────────────────────────────────────────
 
 	// CreateVersion snapshots the supplied architecture as a new immutable version.
@@ -56,6 +90,22 @@ type ProjectService interface {
 	// DeleteVersion removes a single version entry (does not delete the snapshot project row).
 	DeleteVersion(ctx context.Context, projectID uuid.UUID, versionID uuid.UUID) error
 
+	// RestoreVersion creates a new head version whose architecture, variables, outputs
+	// and UI state are copied from an earlier version. History is kept: the new version's
+	// parent is the current head and its message names the restored version.
+	RestoreVersion(ctx context.Context, projectID uuid.UUID, versionID uuid.UUID, req *RestoreVersionRequest) (*ProjectVersionDetail, error)
+
+	// DiffVersions compares two versions of the project lineage: resources added, removed
+	// and modified (per configuration field), containment and dependency edges added or
+	// removed, and the change in estimated monthly cost.
+	DiffVersions(ctx context.Context, projectID, fromVersionID, toVersionID uuid.UUID) (*VersionDiff, error)
+
+	// MergeVersions merges the changes of another version into a version, three ways
+	// against their common ancestor. It creates the merged version, a child of the target
+	// version, only when no conflicts remain after applying the request's resolutions;
+	// otherwise the result lists the conflicts and Version is nil.
+	MergeVersions(ctx context.Context, projectID, versionID uuid.UUID, req *MergeVersionsRequest) (*MergeVersionsResult, error)
+
 	// ── Architecture (read-only) ──────────────────────────────────────────────
 
 	// GetArchitecture retrieves the full architecture for a project snapshot.
@@ -103,21 +153,28 @@ type CreateVersionRequest struct {
 	Message   string                     `json:"message"`
 }
 
+// RestoreVersionRequest is the optional payload for POST /projects/{id}/versions/{version_id}/restore.
+type RestoreVersionRequest struct {
+	Message string `json:"message"`
+}
+
 // ProjectVersionSummary is a lightweight listing entry for GET /projects/{id}/versions.
 type ProjectVersionSummary struct {
-	ID              uuid.UUID  `json:"id"`
-	ProjectID       uuid.UUID  `json:"project_id"`
-	ParentVersionID *uuid.UUID `json:"parent_version_id"`
-	VersionNumber   int        `json:"version_number"`
-	Message         string     `json:"message,omitempty"`
-	CreatedAt       string     `json:"created_at"`
-	CreatedBy       uuid.UUID  `json:"created_by"`
+	ID                   uuid.UUID  `json:"id"`
+	ProjectID            uuid.UUID  `json:"project_id"`
+	ParentVersionID      *uuid.UUID `json:"parent_version_id"`
+	MergeParentVersionID *uuid.UUID `json:"merge_parent_version_id,omitempty"` // merge versions: the version merged in
+	VersionNumber        int        `json:"version_number"`
+	Message              string     `json:"message,omitempty"`
+	CreatedAt            string     `json:"created_at"`
+	CreatedBy            uuid.UUID  `json:"created_by"`
 }
 
 // ProjectVersionDetail is the full version response including architecture state.
 type ProjectVersionDetail struct {
 	ProjectVersionSummary
-	State *dto.ArchitectureResponse `json:"state"`
+	State  *dto.ArchitectureResponse   `json:"state"`
+	Budget *domainpricing.BudgetStatus `json:"budget,omitempty"` // set on creation when the project has a budget
 }
 
 // ArchitecturePersistResult contains the result of persisting an architecture with pricing.
@@ -125,3 +182,101 @@ type ArchitecturePersistResult struct {
 	ResourceIDMapping map[string]uuid.UUID      `json:"resource_id_mapping"`
 	PricingEstimate   *ArchitectureCostEstimate `json:"pricing_estimate,omitempty"`
 }
+
+// VersionDiff is the response of GET /projects/{id}/versions/{version_id}/diff/{other_version_id}.
+// Resource and edge IDs are those of the version the entry belongs to: the "to" version for
+// added entries and the "from" version for removed ones.
+type VersionDiff struct {
+	FromVersionID uuid.UUID          `json:"from_version_id"`
+	ToVersionID   uuid.UUID          `json:"to_version_id"`
+	Added         []DiffResource     `json:"added"`
+	Removed       []DiffResource     `json:"removed"`
+	Modified      []ModifiedResource `json:"modified"`
+	AddedEdges    []DiffEdge         `json:"added_edges"`
+	RemovedEdges  []DiffEdge         `json:"removed_edges"`
+	Cost          *CostDelta         `json:"cost,omitempty"` // omitted when pricing is unavailable
+}
+
+// DiffResource identifies a resource in a version diff.
+type DiffResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ModifiedResource is a resource present in both versions whose fields changed.
+type ModifiedResource struct {
+	FromID  string        `json:"from_id"`
+	ToID    string        `json:"to_id"`
+	Name    string        `json:"name"`
+	Type    string        `json:"type"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange is one changed field: "name" or a configuration path such as
+// "config.instanceType". From is null for added entries and To for removed ones.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DiffEdge is a containment ("contains") or dependency ("depends_on") edge in a version diff.
+type DiffEdge struct {
+	Type       string `json:"type"`
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	SourceName string `json:"source_name,omitempty"`
+	TargetName string `json:"target_name,omitempty"`
+}
+
+// CostDelta is the change in estimated monthly cost between two versions.
+type CostDelta struct {
+	From     float64 `json:"from"`
+	To       float64 `json:"to"`
+	Delta    float64 `json:"delta"`
+	Currency string  `json:"currency"`
+	Period   string  `json:"period"`
+}
+
+// MergeVersionsRequest is the payload for POST /projects/{id}/versions/{version_id}/merge.
+type MergeVersionsRequest struct {
+	// TheirsVersionID is the version whose changes are merged into the path version.
+	TheirsVersionID uuid.UUID `json:"theirs_version_id" binding:"required"`
+	// BaseVersionID is the common ancestor; defaults to the nearest shared parent.
+	BaseVersionID *uuid.UUID        `json:"base_version_id,omitempty"`
+	Resolutions   []MergeResolution `json:"resolutions,omitempty"`
+	Message       string            `json:"message"`
+}
+
+// MergeResolution settles one conflict by taking "ours" or "theirs", or, for field
+// conflicts, by an explicit value when take is omitted.
+type MergeResolution struct {
+	ConflictID string      `json:"conflict_id" binding:"required"`
+	Take       string      `json:"take,omitempty"`
+	Value      interface{} `json:"value,omitempty"`
+}
+
+// MergeVersionsResult is the response of a merge. Version is set once the merge is
+// created; Conflicts lists what still needs a resolution otherwise.
+type MergeVersionsResult struct {
+	BaseVersionID   uuid.UUID             `json:"base_version_id"`
+	OursVersionID   uuid.UUID             `json:"ours_version_id"`
+	TheirsVersionID uuid.UUID             `json:"theirs_version_id"`
+	Conflicts       []MergeConflict       `json:"conflicts"`
+	Version         *ProjectVersionDetail `json:"version,omitempty"`
+}
+
+// MergeConflict is a resource field, resource, variable or output changed differently
+// on both sides. Field is empty for whole-entry conflicts (e.g. deleted on one side and
+// modified on the other); base, ours and theirs are null where the value is absent.
+type MergeConflict struct {
+	ID     string      `json:"id"`
+	Kind   string      `json:"kind"` // "resource", "variable" or "output"
+	Key    string      `json:"key"`
+	Name   string      `json:"name"`
+	Field  string      `json:"field,omitempty"`
+	Base   interface{} `json:"base"`
+	Ours   interface{} `json:"ours"`
+	Theirs interface{} `json:"theirs"`
+}
@@ -69,9 +69,78 @@ type DependencyTypeRepository interface {
 // UserRepository defines user repository operations
 type UserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	Create(ctx context.Context, user *models.User) error
 }
 
+// GuardrailRepository defines guardrail repository operations
+type GuardrailRepository interface {
+	Create(ctx context.Context, guardrail *models.Guardrail) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Guardrail, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Guardrail, error)
+	Update(ctx context.Context, guardrail *models.Guardrail) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TemplateRepository defines marketplace template repository operations
+type TemplateRepository interface {
+	// Create saves a template with its features, use cases, components and tags
+	Create(ctx context.Context, template *models.Template) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Template, error)
+	// Search returns the templates matching the filter and the number of matches before pagination
+	Search(ctx context.Context, filter models.TemplateFilter) ([]*models.Template, int64, error)
+	UpdateEstimatedCost(ctx context.Context, id uuid.UUID, min, max float64) error
+	IncrementDownloads(ctx context.Context, id uuid.UUID) error
+}
+
+// ReviewRepository defines marketplace review repository operations
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.Review) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Review, error)
+	FindByTemplateAndUser(ctx context.Context, templateID, userID uuid.UUID) (*models.Review, error)
+	// FindVisibleByTemplate returns the reviews of a template that are not hidden and their number before pagination
+	FindVisibleByTemplate(ctx context.Context, templateID uuid.UUID, limit, offset int) ([]*models.Review, int64, error)
+	Update(ctx context.Context, review *models.Review) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// CreateFlag fails with a conflict when the user already flagged the review
+	CreateFlag(ctx context.Context, flag *models.ReviewFlag) error
+	IncrementFlagCount(ctx context.Context, id uuid.UUID, hideAt int) error
+	SetVerifiedDeployment(ctx context.Context, templateID, userID uuid.UUID) error
+	// RecalculateTemplateRating sets a template's rating and review count from its visible reviews
+	RecalculateTemplateRating(ctx context.Context, templateID uuid.UUID) error
+	BeginTransaction(ctx context.Context) (*gorm.DB, context.Context)
+	CommitTransaction(tx *gorm.DB) error
+	RollbackTransaction(tx *gorm.DB) error
+}
+
+// TemplateInstantiationRepository defines operations on the record of projects created from templates
+type TemplateInstantiationRepository interface {
+	Create(ctx context.Context, instantiation *models.TemplateInstantiation) error
+	FindByTemplateAndUser(ctx context.Context, templateID, userID uuid.UUID) ([]*models.TemplateInstantiation, error)
+	// MarkCodeGenerated records code generation from a root project and returns the instantiations that created it
+	MarkCodeGenerated(ctx context.Context, projectID uuid.UUID) ([]*models.TemplateInstantiation, error)
+}
+
+// CategoryRepository defines marketplace category repository operations
+type CategoryRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*models.Category, error)
+}
+
+// TechnologyRepository defines marketplace technology repository operations
+type TechnologyRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*models.Technology, error)
+}
+
+// ComplianceStandardRepository defines marketplace compliance standard repository operations
+type ComplianceStandardRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*models.ComplianceStandard, error)
+}
+
+// IACFormatRepository defines marketplace IaC format repository operations
+type IACFormatRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*models.IACFormat, error)
+}
+
 // IACTargetRepository defines IaC target repository operations
 type IACTargetRepository interface {
 	FindByName(ctx context.Context, name string) (*models.IACTarget, error)
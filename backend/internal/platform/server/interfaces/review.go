@@ -0,0 +1,43 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+)
+
+// ReviewService manages the reviews of marketplace templates and keeps each template's
+// rating and review count in sync with them. A user reviews a template at most once and
+// only after creating a project from it; the review is a verified deployment once the
+// user generated code from such a project.
+type ReviewService interface {
+	// List returns the reviews of a template that are not hidden, with their number
+	List(ctx context.Context, templateID uuid.UUID, limit, offset int) ([]*models.Review, int64, error)
+
+	// Create saves the caller's review of a template
+	Create(ctx context.Context, userID, templateID uuid.UUID, req *ReviewRequest) (*models.Review, error)
+
+	// Update changes the caller's review of a template; nil request fields are left unchanged
+	Update(ctx context.Context, userID, templateID, reviewID uuid.UUID, req *ReviewRequest) (*models.Review, error)
+
+	// Delete removes the caller's review of a template
+	Delete(ctx context.Context, userID, templateID, reviewID uuid.UUID) error
+
+	// Flag reports a review for moderation; a review flagged by enough users is hidden
+	Flag(ctx context.Context, userID, templateID, reviewID uuid.UUID, reason *string) (*models.Review, error)
+
+	// RecordCodeGeneration marks the reviews of the templates a project was created from
+	// as verified deployments
+	RecordCodeGeneration(ctx context.Context, project *models.Project) error
+}
+
+// ReviewRequest holds the fields of a review to create or update.
+type ReviewRequest struct {
+	Rating         *int
+	Title          *string
+	Content        *string
+	UseCase        *string
+	TeamSize       *string
+	DeploymentTime *string
+}
@@ -14,6 +14,8 @@ type UserService interface {
 	Create(ctx context.Context, req *CreateUserRequest) (*models.User, error)
 	// GetByID retrieves a user by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	// Authenticate returns the user with the given email and password
+	Authenticate(ctx context.Context, email, password string) (*models.User, error)
 }
 
 // CreateUserRequest contains data needed to create a user
@@ -21,6 +23,7 @@ type CreateUserRequest struct {
 	Name      string
 	Email     string
 	Auth0ID   string
+	Password  string
 	AvatarURL string
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/validator"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
@@ -15,6 +16,8 @@ type PipelineOrchestratorImpl struct {
 	architectureService serverinterfaces.ArchitectureService
 	codegenService      serverinterfaces.CodegenService
 	projectService      serverinterfaces.ProjectService
+	// reviewService, when set, is told about code generation to verify template reviews
+	reviewService serverinterfaces.ReviewService
 }
 
 // NewPipelineOrchestrator creates a new pipeline orchestrator
@@ -32,6 +35,25 @@ func NewPipelineOrchestrator(
 	}
 }
 
+// NewPipelineOrchestratorWithReviews creates a pipeline orchestrator that marks the template
+// reviews of users who generate code from a project created from the template as verified
+// deployments
+func NewPipelineOrchestratorWithReviews(
+	diagramService serverinterfaces.DiagramService,
+	architectureService serverinterfaces.ArchitectureService,
+	codegenService serverinterfaces.CodegenService,
+	projectService serverinterfaces.ProjectService,
+	reviewService serverinterfaces.ReviewService,
+) serverinterfaces.PipelineOrchestrator {
+	return &PipelineOrchestratorImpl{
+		diagramService:      diagramService,
+		architectureService: architectureService,
+		codegenService:      codegenService,
+		projectService:      projectService,
+		reviewService:       reviewService,
+	}
+}
+
 // ProcessDiagram processes a diagram JSON and persists it as a project
 func (o *PipelineOrchestratorImpl) ProcessDiagram(ctx context.Context, req *serverinterfaces.ProcessDiagramRequest) (*serverinterfaces.ProcessDiagramResult, error) {
 	if req == nil {
@@ -44,10 +66,15 @@ func (o *PipelineOrchestratorImpl) ProcessDiagram(ctx context.Context, req *serv
 		return nil, fmt.Errorf("failed to parse diagram: %w", err)
 	}
 
+	provider := resource.CloudProvider(req.CloudProvider)
+	if provider == "" {
+		provider = resource.AWS // Default to AWS
+	}
+
 	// Step 2: Validate diagram
 	// Note: In production, you'd want to build ValidResourceTypes from the database
-	// For now, we'll use nil which means the validator will use default validation
-	validationResult, err := o.diagramService.Validate(ctx, diagramGraph, nil)
+	// For now, only the provider is set so config is checked against that provider's schemas
+	validationResult, err := o.diagramService.Validate(ctx, diagramGraph, &validator.ValidationOptions{Provider: string(provider)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate diagram: %w", err)
 	}
@@ -57,11 +84,6 @@ func (o *PipelineOrchestratorImpl) ProcessDiagram(ctx context.Context, req *serv
 	}
 
 	// Step 3: Map to domain architecture
-	provider := resource.CloudProvider(req.CloudProvider)
-	if provider == "" {
-		provider = resource.AWS // Default to AWS
-	}
-
 	arch, err := o.architectureService.MapFromDiagram(ctx, diagramGraph, provider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map diagram to architecture: %w", err)
@@ -87,6 +109,9 @@ func (o *PipelineOrchestratorImpl) ProcessDiagram(ctx context.Context, req *serv
 	}
 
 	if createProjectReq.Region == "" {
+		createProjectReq.Region = arch.Region
+	}
+	if createProjectReq.Region == "" && provider == resource.AWS {
 		createProjectReq.Region = "us-east-1" // Default
 	}
 
@@ -166,10 +191,28 @@ func (o *PipelineOrchestratorImpl) GenerateCode(ctx context.Context, req *server
 		engine = "terraform" // Default
 	}
 
-	output, err := o.codegenService.Generate(ctx, arch, engine)
+	// The request's backend overrides the one saved with the project. Saved backends are
+	// Terraform state backends, so other engines do not get them.
+	opts := req.Options
+	if opts.Backend == nil && engine == "terraform" {
+		backend, err := project.UnmarshalBackendConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read project backend config: %w", err)
+		}
+		opts.Backend = backend
+	}
+
+	output, err := o.codegenService.Generate(ctx, arch, engine, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate code: %w", err)
 	}
 
+	// A failure to record the generation does not fail it
+	if o.reviewService != nil {
+		if err := o.reviewService.RecordCodeGeneration(ctx, project); err != nil {
+			fmt.Printf("⚠️  Failed to record code generation for project %s: %v\n", project.ID, err)
+		}
+	}
+
 	return output, nil
 }
@@ -3,6 +3,8 @@ package orchestrator
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -14,7 +16,10 @@ import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/services"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"gorm.io/datatypes"
 )
 
 // Mock services for testing
@@ -81,12 +86,12 @@ func (m *mockArchitectureService) GetSortedResources(ctx context.Context, arch *
 }
 
 type mockCodegenService struct {
-	generateFunc func(ctx context.Context, arch *architecture.Architecture, engine string) (*iac.Output, error)
+	generateFunc func(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error)
 }
 
-func (m *mockCodegenService) Generate(ctx context.Context, arch *architecture.Architecture, engine string) (*iac.Output, error) {
+func (m *mockCodegenService) Generate(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error) {
 	if m.generateFunc != nil {
-		return m.generateFunc(ctx, arch, engine)
+		return m.generateFunc(ctx, arch, engine, opts)
 	}
 	return &iac.Output{
 		Files: []iac.GeneratedFile{},
@@ -116,6 +121,12 @@ type mockProjectService struct {
 	getVersionByIDFunc      func(ctx context.Context, projectID, versionID uuid.UUID) (*serverinterfaces.ProjectVersionDetail, error)
 	deleteVersionFunc       func(ctx context.Context, projectID, versionID uuid.UUID) error
 	validateVersionArchFunc func(ctx context.Context, versionID uuid.UUID) (*dto.ValidationResponse, error)
+	diffVersionsFunc        func(ctx context.Context, projectID, fromVersionID, toVersionID uuid.UUID) (*serverinterfaces.VersionDiff, error)
+	mergeVersionsFunc       func(ctx context.Context, projectID, versionID uuid.UUID, req *serverinterfaces.MergeVersionsRequest) (*serverinterfaces.MergeVersionsResult, error)
+	restoreVersionFunc      func(ctx context.Context, projectID, versionID uuid.UUID, req *serverinterfaces.RestoreVersionRequest) (*serverinterfaces.ProjectVersionDetail, error)
+	// State backend
+	getBackendFunc    func(ctx context.Context, projectID uuid.UUID) (*iac.StateBackend, error)
+	updateBackendFunc func(ctx context.Context, projectID uuid.UUID, backend *iac.StateBackend) (*iac.StateBackend, error)
 }
 
 func (m *mockProjectService) Create(ctx context.Context, req *serverinterfaces.CreateProjectRequest) (*models.Project, error) {
@@ -235,6 +246,27 @@ func (m *mockProjectService) DeleteVersion(ctx context.Context, projectID, versi
 	return nil
 }
 
+func (m *mockProjectService) DiffVersions(ctx context.Context, projectID, fromVersionID, toVersionID uuid.UUID) (*serverinterfaces.VersionDiff, error) {
+	if m.diffVersionsFunc != nil {
+		return m.diffVersionsFunc(ctx, projectID, fromVersionID, toVersionID)
+	}
+	return &serverinterfaces.VersionDiff{FromVersionID: fromVersionID, ToVersionID: toVersionID}, nil
+}
+
+func (m *mockProjectService) RestoreVersion(ctx context.Context, projectID, versionID uuid.UUID, req *serverinterfaces.RestoreVersionRequest) (*serverinterfaces.ProjectVersionDetail, error) {
+	if m.restoreVersionFunc != nil {
+		return m.restoreVersionFunc(ctx, projectID, versionID, req)
+	}
+	return &serverinterfaces.ProjectVersionDetail{}, nil
+}
+
+func (m *mockProjectService) MergeVersions(ctx context.Context, projectID, versionID uuid.UUID, req *serverinterfaces.MergeVersionsRequest) (*serverinterfaces.MergeVersionsResult, error) {
+	if m.mergeVersionsFunc != nil {
+		return m.mergeVersionsFunc(ctx, projectID, versionID, req)
+	}
+	return &serverinterfaces.MergeVersionsResult{OursVersionID: versionID, TheirsVersionID: req.TheirsVersionID}, nil
+}
+
 func (m *mockProjectService) UpdateMetadata(ctx context.Context, project *models.Project) (*models.Project, error) {
 	if m.updateMetadataFunc != nil {
 		return m.updateMetadataFunc(ctx, project)
@@ -263,6 +295,40 @@ func (m *mockProjectService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *mockProjectService) GetBackend(ctx context.Context, projectID uuid.UUID) (*iac.StateBackend, error) {
+	if m.getBackendFunc != nil {
+		return m.getBackendFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *mockProjectService) UpdateBackend(ctx context.Context, projectID uuid.UUID, backend *iac.StateBackend) (*iac.StateBackend, error) {
+	if m.updateBackendFunc != nil {
+		return m.updateBackendFunc(ctx, projectID, backend)
+	}
+	return backend, nil
+}
+
+func (m *mockProjectService) GetUsageProfile(ctx context.Context, projectID uuid.UUID) (*pricing.UsageProfile, error) {
+	return nil, nil
+}
+
+func (m *mockProjectService) UpdateUsageProfile(ctx context.Context, projectID uuid.UUID, profile *pricing.UsageProfile) (*pricing.UsageProfile, error) {
+	return profile, nil
+}
+
+func (m *mockProjectService) GetBudget(ctx context.Context, projectID uuid.UUID) (*pricing.Budget, error) {
+	return nil, nil
+}
+
+func (m *mockProjectService) UpdateBudget(ctx context.Context, projectID uuid.UUID, budget *pricing.Budget) (*pricing.Budget, error) {
+	return budget, nil
+}
+
+func (m *mockProjectService) EvaluateBudget(ctx context.Context, projectID uuid.UUID, estimate *serverinterfaces.ArchitectureCostEstimate) (*pricing.BudgetStatus, error) {
+	return nil, nil
+}
+
 func TestPipelineOrchestrator_ProcessDiagram(t *testing.T) {
 	ctx := context.Background()
 
@@ -500,7 +566,7 @@ func TestPipelineOrchestrator_GenerateCode(t *testing.T) {
 						Results: make(map[string]*serverinterfaces.ResourceValidationResult),
 					}, nil
 				}
-				cs.generateFunc = func(ctx context.Context, arch *architecture.Architecture, engine string) (*iac.Output, error) {
+				cs.generateFunc = func(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error) {
 					return &iac.Output{
 						Files: []iac.GeneratedFile{
 							{Path: "main.tf", Content: "# Terraform code", Type: "hcl"},
@@ -509,6 +575,91 @@ func TestPipelineOrchestrator_GenerateCode(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "saved project backend is used when the request has none",
+			req: &serverinterfaces.GenerateCodeRequest{
+				ProjectID:     uuid.New(),
+				Engine:        "terraform",
+				CloudProvider: "aws",
+			},
+			wantError: false,
+			setupMocks: func(ps *mockProjectService, as *mockArchitectureService, cs *mockCodegenService) {
+				ps.getByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+					return &models.Project{
+						ID:            id,
+						CloudProvider: "aws",
+						Region:        "us-east-1",
+						BackendConfig: datatypes.JSON(`{"type":"s3","s3":{"bucket":"acme-state","key":"app.tfstate","encrypt":true}}`),
+					}, nil
+				}
+				ps.loadArchFunc = func(ctx context.Context, projID uuid.UUID) (*architecture.Architecture, error) {
+					return &architecture.Architecture{Provider: resource.AWS, Region: "us-east-1"}, nil
+				}
+				cs.generateFunc = func(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error) {
+					if opts.Backend == nil || opts.Backend.S3 == nil || opts.Backend.S3.Bucket != "acme-state" {
+						return nil, fmt.Errorf("expected saved s3 backend, got %+v", opts.Backend)
+					}
+					return &iac.Output{}, nil
+				}
+			},
+		},
+		{
+			name: "saved project backend is not passed to pulumi",
+			req: &serverinterfaces.GenerateCodeRequest{
+				ProjectID:     uuid.New(),
+				Engine:        "pulumi",
+				CloudProvider: "aws",
+			},
+			wantError: false,
+			setupMocks: func(ps *mockProjectService, as *mockArchitectureService, cs *mockCodegenService) {
+				ps.getByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+					return &models.Project{
+						ID:            id,
+						CloudProvider: "aws",
+						Region:        "us-east-1",
+						BackendConfig: datatypes.JSON(`{"type":"s3","s3":{"bucket":"acme-state","key":"app.tfstate"}}`),
+					}, nil
+				}
+				ps.loadArchFunc = func(ctx context.Context, projID uuid.UUID) (*architecture.Architecture, error) {
+					return &architecture.Architecture{Provider: resource.AWS, Region: "us-east-1"}, nil
+				}
+				cs.generateFunc = func(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error) {
+					// The pulumi engine rejects terraform state backends
+					if engine != "pulumi" || opts.Backend != nil {
+						return nil, fmt.Errorf("expected pulumi without a backend, got %s with %+v", engine, opts.Backend)
+					}
+					return &iac.Output{Files: []iac.GeneratedFile{{Path: "index.ts", Content: "// Pulumi program", Type: "typescript"}}}, nil
+				}
+			},
+		},
+		{
+			name: "request backend overrides the saved one",
+			req: &serverinterfaces.GenerateCodeRequest{
+				ProjectID:     uuid.New(),
+				Engine:        "terraform",
+				CloudProvider: "aws",
+				Options:       iac.Options{Backend: &iac.StateBackend{Type: iac.BackendLocal}},
+			},
+			wantError: false,
+			setupMocks: func(ps *mockProjectService, as *mockArchitectureService, cs *mockCodegenService) {
+				ps.getByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+					return &models.Project{
+						ID:            id,
+						CloudProvider: "aws",
+						BackendConfig: datatypes.JSON(`{"type":"s3","s3":{"bucket":"acme-state","key":"app.tfstate"}}`),
+					}, nil
+				}
+				ps.loadArchFunc = func(ctx context.Context, projID uuid.UUID) (*architecture.Architecture, error) {
+					return &architecture.Architecture{Provider: resource.AWS}, nil
+				}
+				cs.generateFunc = func(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error) {
+					if opts.Backend == nil || opts.Backend.Type != iac.BackendLocal {
+						return nil, fmt.Errorf("expected request backend, got %+v", opts.Backend)
+					}
+					return &iac.Output{}, nil
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -546,3 +697,33 @@ func TestPipelineOrchestrator_GenerateCode(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineOrchestrator_GenerateCodePulumiWithSavedBackend(t *testing.T) {
+	ctx := context.Background()
+	projectService := &mockProjectService{
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+			return &models.Project{
+				ID:            id,
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				BackendConfig: datatypes.JSON(`{"type":"s3","s3":{"bucket":"acme-state","key":"app.tfstate","region":"us-east-1"}}`),
+			}, nil
+		},
+		loadArchFunc: func(ctx context.Context, projectID uuid.UUID) (*architecture.Architecture, error) {
+			return &architecture.Architecture{Provider: resource.AWS, Region: "us-east-1"}, nil
+		},
+	}
+	orchestrator := NewPipelineOrchestrator(&mockDiagramService{}, &mockArchitectureService{}, services.NewCodegenService(slog.Default()), projectService)
+
+	output, err := orchestrator.GenerateCode(ctx, &serverinterfaces.GenerateCodeRequest{ProjectID: uuid.New(), Engine: "pulumi"})
+	if err != nil {
+		t.Fatalf("GenerateCode(pulumi) error: %v", err)
+	}
+	hasProgram := false
+	for _, file := range output.Files {
+		hasProgram = hasProgram || file.Path == "index.ts"
+	}
+	if !hasProgram {
+		t.Errorf("GenerateCode(pulumi) files = %+v, want index.ts", output.Files)
+	}
+}
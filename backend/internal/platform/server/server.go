@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 
 	"log/slog"
@@ -12,14 +14,20 @@ import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/services/iam"
 	awsnetworking "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/services/networking"
 	awsstorage "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/services/storage"
+	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/architecture" // Register Azure architecture generator
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/config"
+	guardrailrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/guardrail"
 	infrastructurerepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/infrastructure"
 	pricingrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/pricing"
 	projectrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/project"
 	resourcerepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/resource"
+	templaterepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/template"
 	userrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/user"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/orchestrator"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/services"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/pkg/seeder"
 )
 
@@ -35,8 +43,18 @@ type Server struct {
 	UserService             serverinterfaces.UserService
 	StaticDataService       serverinterfaces.StaticDataService
 	ResourceMetadataService serverinterfaces.ResourceMetadataService
+	ImportService           serverinterfaces.ImportService
+	DriftService            serverinterfaces.DriftService
+	NetworkService          serverinterfaces.NetworkService
+	GuardrailService        serverinterfaces.GuardrailService
+	ComplianceService       serverinterfaces.ComplianceService
+	MarketplaceService      serverinterfaces.MarketplaceService
+	ReviewService           serverinterfaces.ReviewService
 	IAMService              iam.AWSIAMService
 
+	// Authenticator verifies bearer tokens and issues them on login
+	Authenticator *auth.Authenticator
+
 	// Orchestrator
 	PipelineOrchestrator serverinterfaces.PipelineOrchestrator
 }
@@ -104,13 +122,66 @@ func NewServer(logger *slog.Logger) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create output repository: %w", err)
 	}
+	guardrailRepo, err := guardrailrepo.NewGuardrailRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guardrail repository: %w", err)
+	}
+	templateRepo, err := templaterepo.NewTemplateRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template repository: %w", err)
+	}
+	categoryRepo, err := templaterepo.NewCategoryRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category repository: %w", err)
+	}
+	technologyRepo, err := templaterepo.NewTechnologyRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create technology repository: %w", err)
+	}
+	complianceStandardRepo, err := templaterepo.NewComplianceStandardRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compliance standard repository: %w", err)
+	}
+	iacFormatRepo, err := templaterepo.NewIACFormatRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iac format repository: %w", err)
+	}
+	reviewRepo, err := templaterepo.NewReviewRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review repository: %w", err)
+	}
+	instantiationRepo, err := templaterepo.NewTemplateInstantiationRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template instantiation repository: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
 
 	// ── Services ──────────────────────────────────────────────────────────────
 	diagramService := services.NewDiagramService(logger)
 	ruleService := services.NewAWSRuleServiceAdapter()
-	architectureService := services.NewArchitectureService(ruleService, logger)
+	guardrailService := services.NewGuardrailService(guardrailRepo)
+	if cfg.GuardrailsDir != "" {
+		loaded, err := guardrailService.LoadInstanceGuardrails(cfg.GuardrailsDir)
+		if err != nil {
+			fmt.Printf("Warning: Failed to load guardrails from %s: %v\n", cfg.GuardrailsDir, err)
+		}
+		fmt.Printf("✓ Loaded %d instance-wide guardrail files\n", loaded)
+	}
+	azureRuleService := services.NewAzureRuleServiceAdapter()
+	// Resource constraints in the database describe AWS types, so Azure only loads its defaults
+	if err := azureRuleService.LoadRulesWithDefaults(context.Background(), nil); err != nil {
+		fmt.Printf("Warning: Failed to load Azure rules: %v\n", err)
+	}
+	architectureService := services.NewMultiProviderArchitectureService(map[resource.CloudProvider]serverinterfaces.RuleService{
+		resource.AWS:   ruleService,
+		resource.Azure: azureRuleService,
+	}, guardrailService, logger)
 	codegenService := services.NewCodegenService(logger)
-	optimizationService := services.NewOptimizationService()
+	optimizationService := services.NewOptimizationServiceWithRepos(pricingRateRepo, hiddenDepRepo)
 
 	pricingService := services.NewPricingServiceWithRepos(
 		pricingRepo,
@@ -151,11 +222,30 @@ func NewServer(logger *slog.Logger) (*Server, error) {
 		pricingService,
 	)
 
-	pipelineOrchestrator := orchestrator.NewPipelineOrchestrator(
+	reviewService := services.NewReviewService(reviewRepo, instantiationRepo)
+
+	pipelineOrchestrator := orchestrator.NewPipelineOrchestratorWithReviews(
 		diagramService,
 		architectureService,
 		codegenService,
 		projectService,
+		reviewService,
+	)
+
+	importService := services.NewImportService(projectService)
+	driftService := services.NewDriftService(projectService)
+	networkService := services.NewNetworkService(projectService)
+	complianceService := services.NewComplianceService(projectService, templateRepo)
+	marketplaceService := services.NewMarketplaceService(
+		projectService,
+		pricingService,
+		templateRepo,
+		categoryRepo,
+		technologyRepo,
+		complianceStandardRepo,
+		iacFormatRepo,
+		instantiationRepo,
+		logger,
 	)
 
 	userService := services.NewUserService(userRepo)
@@ -176,6 +266,21 @@ func NewServer(logger *slog.Logger) (*Server, error) {
 
 	iamService := iam.NewIAMService()
 
+	// ── Authentication ────────────────────────────────────────────────────────
+	authCfg := cfg.Auth
+	if authCfg.JWTSecret == "" && authCfg.JWTPrivateKeyFile == "" && authCfg.JWKSFile == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate JWT secret: %w", err)
+		}
+		authCfg.JWTSecret = hex.EncodeToString(secret)
+		fmt.Printf("Warning: No JWT keys configured, signing tokens with a random secret; tokens will not survive a restart\n")
+	}
+	authenticator, err := auth.NewAuthenticator(authCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+
 	return &Server{
 		DiagramService:          diagramService,
 		ArchitectureService:     architectureService,
@@ -186,7 +291,15 @@ func NewServer(logger *slog.Logger) (*Server, error) {
 		UserService:             userService,
 		StaticDataService:       staticDataService,
 		ResourceMetadataService: resourceMetadataService,
+		ImportService:           importService,
+		DriftService:            driftService,
+		NetworkService:          networkService,
+		GuardrailService:        guardrailService,
+		ComplianceService:       complianceService,
+		MarketplaceService:      marketplaceService,
+		ReviewService:           reviewService,
 		IAMService:              iamService,
+		Authenticator:           authenticator,
 		PipelineOrchestrator:    pipelineOrchestrator,
 	}, nil
 }
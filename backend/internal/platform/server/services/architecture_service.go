@@ -11,23 +11,56 @@ import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/rules"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/graph"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/policy"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
 
 // ArchitectureServiceImpl implements ArchitectureService interface
 type ArchitectureServiceImpl struct {
-	ruleService serverinterfaces.RuleService
-	logger      *slog.Logger
+	ruleServices     map[resource.CloudProvider]serverinterfaces.RuleService
+	guardrailService serverinterfaces.GuardrailService
+	logger           *slog.Logger
 }
 
-// NewArchitectureService creates a new architecture service
+// NewArchitectureService creates a new architecture service that validates AWS
+// architectures with the given rule service
 func NewArchitectureService(ruleService serverinterfaces.RuleService, logger *slog.Logger) serverinterfaces.ArchitectureService {
 	return &ArchitectureServiceImpl{
-		ruleService: ruleService,
-		logger:      logger,
+		ruleServices: awsRuleServices(ruleService),
+		logger:       logger,
 	}
 }
 
+// NewArchitectureServiceWithGuardrails creates an architecture service that also checks
+// architectures against policy-as-code guardrails
+func NewArchitectureServiceWithGuardrails(ruleService serverinterfaces.RuleService, guardrailService serverinterfaces.GuardrailService, logger *slog.Logger) serverinterfaces.ArchitectureService {
+	return &ArchitectureServiceImpl{
+		ruleServices:     awsRuleServices(ruleService),
+		guardrailService: guardrailService,
+		logger:           logger,
+	}
+}
+
+// NewMultiProviderArchitectureService creates an architecture service that validates each
+// architecture with the rule service registered for its provider. Providers without a rule
+// service are only checked against guardrails.
+func NewMultiProviderArchitectureService(ruleServices map[resource.CloudProvider]serverinterfaces.RuleService, guardrailService serverinterfaces.GuardrailService, logger *slog.Logger) serverinterfaces.ArchitectureService {
+	return &ArchitectureServiceImpl{
+		ruleServices:     ruleServices,
+		guardrailService: guardrailService,
+		logger:           logger,
+	}
+}
+
+// awsRuleServices registers a single rule service for AWS, the provider the
+// single-service constructors have always validated
+func awsRuleServices(ruleService serverinterfaces.RuleService) map[resource.CloudProvider]serverinterfaces.RuleService {
+	if ruleService == nil {
+		return nil
+	}
+	return map[resource.CloudProvider]serverinterfaces.RuleService{resource.AWS: ruleService}
+}
+
 // MapFromDiagram converts a diagram graph to a domain architecture
 func (s *ArchitectureServiceImpl) MapFromDiagram(ctx context.Context, graph *graph.DiagramGraph, provider resource.CloudProvider) (*architecture.Architecture, error) {
 	s.logger.Info("Mapping diagram to architecture", "provider", provider)
@@ -54,12 +87,21 @@ func (s *ArchitectureServiceImpl) ValidateRules(ctx context.Context, arch *archi
 		return nil, fmt.Errorf("architecture is nil")
 	}
 
-	if s.ruleService == nil {
-		// No rule service provided, return valid result
-		return &serverinterfaces.RuleValidationResult{
+	if provider == "" {
+		provider = arch.Provider
+	}
+	if provider == "" {
+		provider = resource.AWS
+	}
+
+	ruleService := s.ruleServices[provider]
+	if ruleService == nil {
+		// No rule service for this provider, only guardrails can fail the architecture
+		return s.applyGuardrails(ctx, arch, &serverinterfaces.RuleValidationResult{
 			Valid:   true,
 			Results: make(map[string]*serverinterfaces.ResourceValidationResult),
-		}, nil
+			Errors:  make([]string, 0),
+		})
 	}
 
 	// Adapt domain architecture to rules engine architecture view
@@ -68,7 +110,7 @@ func (s *ArchitectureServiceImpl) ValidateRules(ctx context.Context, arch *archi
 	}
 
 	// Validate architecture using rule service
-	resultsMap, err := s.ruleService.ValidateArchitecture(ctx, engineArch)
+	resultsMap, err := ruleService.ValidateArchitecture(ctx, engineArch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate architecture rules: %w", err)
 	}
@@ -122,6 +164,7 @@ func (s *ArchitectureServiceImpl) ValidateRules(ctx context.Context, arch *archi
 						ResourceType: ruleResult.Error.ResourceType,
 						Message:      ruleResult.Error.Message,
 						Code:         string(ruleResult.Error.RuleType),
+						Severity:     policy.SeverityError,
 					})
 				}
 			}
@@ -130,7 +173,52 @@ func (s *ArchitectureServiceImpl) ValidateRules(ctx context.Context, arch *archi
 		validationResult.Results[resID] = resourceResult
 	}
 
-	return validationResult, nil
+	return s.applyGuardrails(ctx, arch, validationResult)
+}
+
+// applyGuardrails adds guardrail violations to a rule validation result. Violations of
+// error severity invalidate the resource and the architecture; warnings are reported
+// alongside without doing so.
+func (s *ArchitectureServiceImpl) applyGuardrails(ctx context.Context, arch *architecture.Architecture, result *serverinterfaces.RuleValidationResult) (*serverinterfaces.RuleValidationResult, error) {
+	if s.guardrailService == nil {
+		return result, nil
+	}
+
+	violations, err := s.guardrailService.Evaluate(ctx, arch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate guardrails: %w", err)
+	}
+
+	for _, v := range violations {
+		resourceResult, ok := result.Results[v.ResourceID]
+		if !ok {
+			resourceResult = &serverinterfaces.ResourceValidationResult{
+				ResourceID:   v.ResourceID,
+				ResourceType: v.ResourceType,
+				Valid:        true,
+				Errors:       make([]serverinterfaces.ValidationError, 0),
+			}
+			result.Results[v.ResourceID] = resourceResult
+		}
+		resourceResult.Errors = append(resourceResult.Errors, serverinterfaces.ValidationError{
+			ResourceID:   v.ResourceID,
+			ResourceType: v.ResourceType,
+			Message:      v.Message,
+			Code:         "guardrail:" + v.Policy,
+			Severity:     v.Severity,
+		})
+
+		summary := fmt.Sprintf("resource %s (%s): %s", v.ResourceID, v.ResourceType, v.Message)
+		if v.Severity == policy.SeverityWarning {
+			result.Warnings = append(result.Warnings, summary)
+			continue
+		}
+		resourceResult.Valid = false
+		result.Valid = false
+		result.Errors = append(result.Errors, summary)
+	}
+
+	return result, nil
 }
 
 // convertToEvaluationResult converts a map to EvaluationResult
@@ -3,6 +3,9 @@ package services
 import (
 	"context"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
@@ -160,6 +163,143 @@ func TestArchitectureService_ValidateRules(t *testing.T) {
 	}
 }
 
+func TestArchitectureService_ValidateRules_Guardrails(t *testing.T) {
+	ctx := context.Background()
+	ruleService := NewAWSRuleServiceAdapter()
+	err := ruleService.LoadRulesWithDefaults(ctx, []serverinterfaces.ConstraintRecord{
+		{ResourceType: "EC2", ConstraintType: "requires_tag", ConstraintValue: "Environment,Owner"},
+		{ResourceType: "VPC", ConstraintType: "cidr_constraint", ConstraintValue: "10.0.0.0/8"},
+		{ResourceType: "SecurityGroup", ConstraintType: "port_range", ConstraintValue: "80,443"},
+	})
+	if err != nil {
+		t.Fatalf("LoadRulesWithDefaults: %v", err)
+	}
+
+	parent := func(id string) *string { return &id }
+	arch := &architecture.Architecture{Resources: []*resource.Resource{
+		{ID: "vpc", Name: "main", Region: "us-east-1", Type: resource.ResourceType{Name: "VPC"},
+			Metadata: map[string]interface{}{"cidr": "192.168.0.0/16"}},
+		{ID: "subnet", Name: "public", Region: "us-east-1", ParentID: parent("vpc"), Type: resource.ResourceType{Name: "Subnet"},
+			Metadata: map[string]interface{}{}},
+		{ID: "sg", Name: "web-sg", Region: "us-east-1", ParentID: parent("vpc"), Type: resource.ResourceType{Name: "SecurityGroup"},
+			Metadata: map[string]interface{}{"ingressRules": []interface{}{map[string]interface{}{"protocol": "tcp", "fromPort": 22.0, "toPort": 22.0, "cidr": "0.0.0.0/0"}}}},
+		{ID: "ec2", Name: "web", Region: "us-east-1", ParentID: parent("subnet"), Type: resource.ResourceType{Name: "EC2"},
+			Metadata: map[string]interface{}{"instanceType": "t3.micro", "tags": map[string]interface{}{"Environment": "prod"}}},
+	}}
+
+	result, err := NewArchitectureService(ruleService, slog.Default()).ValidateRules(ctx, arch, resource.AWS)
+	if err != nil {
+		t.Fatalf("ValidateRules: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("expected the guardrails to fail")
+	}
+	for id, code := range map[string]string{"vpc": "cidr_constraint", "sg": "port_range", "ec2": "requires_tag"} {
+		found := false
+		for _, e := range result.Results[id].Errors {
+			found = found || e.Code == code
+		}
+		if !found {
+			t.Errorf("%s errors = %+v, want a %s violation", id, result.Results[id].Errors, code)
+		}
+	}
+}
+
+func TestArchitectureService_ValidateRules_ByProvider(t *testing.T) {
+	ctx := context.Background()
+	azureRuleService := NewAzureRuleServiceAdapter()
+	if err := azureRuleService.LoadRulesWithDefaults(ctx, nil); err != nil {
+		t.Fatalf("LoadRulesWithDefaults: %v", err)
+	}
+	awsCalled := false
+	awsRuleService := &mockRuleService{
+		validateArchitectureFunc: func(ctx context.Context, architecture interface{}) (map[string]interface{}, error) {
+			awsCalled = true
+			return map[string]interface{}{}, nil
+		},
+	}
+	service := NewMultiProviderArchitectureService(map[resource.CloudProvider]serverinterfaces.RuleService{
+		resource.AWS:   awsRuleService,
+		resource.Azure: azureRuleService,
+	}, nil, slog.Default())
+
+	// An Azure subnet outside a virtual network breaks the Azure rules, not the AWS ones
+	arch := &architecture.Architecture{Provider: resource.Azure, Resources: []*resource.Resource{
+		{ID: "subnet", Name: "web", Region: "westeurope", Type: resource.ResourceType{Name: "Subnet"},
+			Metadata: map[string]interface{}{}},
+	}}
+
+	result, err := service.ValidateRules(ctx, arch, resource.Azure)
+	if err != nil {
+		t.Fatalf("ValidateRules: %v", err)
+	}
+	if awsCalled {
+		t.Error("expected the AWS rule service not to be used for an Azure architecture")
+	}
+	if result.Valid || result.Results["subnet"].Errors[0].Code != "requires_parent" {
+		t.Errorf("result = %+v, want a requires_parent violation", result)
+	}
+
+	// Without an explicit provider the architecture's provider is used
+	if _, err := service.ValidateRules(ctx, arch, ""); err != nil || awsCalled {
+		t.Errorf("ValidateRules(\"\") err = %v, awsCalled = %v; want the Azure rule service", err, awsCalled)
+	}
+
+	// Providers without a rule service are only checked against guardrails
+	result, err = service.ValidateRules(ctx, arch, resource.GCP)
+	if err != nil || !result.Valid {
+		t.Errorf("ValidateRules(gcp) = %+v, %v; want a valid result", result, err)
+	}
+}
+
+func TestArchitectureService_ValidateRules_Policies(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"storage.policy": `policy "s3-versioned" {
+  resource "S3"
+  require  has_child("S3BucketVersioning")
+}`,
+		"compute.policy": `policy "dev-instance-size" {
+  severity "warning"
+  resource "EC2"
+  when     tags.Environment == "dev"
+  require  size(config.instanceType) <= size("m5.xlarge")
+}`,
+		"broken.policy": `policy "broken" { resource "EC2" }`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	guardrailService := NewGuardrailService(nil)
+	loaded, err := guardrailService.LoadInstanceGuardrails(dir)
+	if loaded != 2 || err == nil || !strings.Contains(err.Error(), "broken.policy") {
+		t.Fatalf("LoadInstanceGuardrails = %d, %v; want 2 and an error for broken.policy", loaded, err)
+	}
+
+	arch := &architecture.Architecture{Resources: []*resource.Resource{
+		{ID: "s3", Name: "logs", Type: resource.ResourceType{Name: "S3"}, Metadata: map[string]interface{}{}},
+		{ID: "ec2", Name: "batch", Type: resource.ResourceType{Name: "EC2"},
+			Metadata: map[string]interface{}{"instanceType": "m5.4xlarge", "tags": map[string]interface{}{"Environment": "dev"}}},
+	}}
+	service := NewArchitectureServiceWithGuardrails(&mockRuleService{}, guardrailService, slog.Default())
+
+	result, err := service.ValidateRules(context.Background(), arch, resource.AWS)
+	if err != nil {
+		t.Fatalf("ValidateRules: %v", err)
+	}
+	if result.Valid || len(result.Errors) != 1 || len(result.Warnings) != 1 {
+		t.Fatalf("result = %+v, want invalid with one error and one warning", result)
+	}
+	if errs := result.Results["s3"].Errors; result.Results["s3"].Valid || len(errs) != 1 || errs[0].Code != "guardrail:s3-versioned" {
+		t.Errorf("s3 = %+v, want an s3-versioned error", result.Results["s3"])
+	}
+	if errs := result.Results["ec2"].Errors; !result.Results["ec2"].Valid || len(errs) != 1 || errs[0].Severity != "warning" {
+		t.Errorf("ec2 = %+v, want a valid resource with a size warning", result.Results["ec2"])
+	}
+}
+
 func TestArchitectureService_GetSortedResources(t *testing.T) {
 	service := NewArchitectureService(nil, slog.Default())
 	ctx := context.Background()
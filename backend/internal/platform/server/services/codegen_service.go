@@ -7,8 +7,12 @@ import (
 	"log/slog"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	awspulumi "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/pulumi"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/terraform"
+	azureterraform "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/mapper/terraform"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	pulumigen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/generator"
+	pulumimapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/pulumi/mapper"
 	tfgen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
 	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
@@ -31,12 +35,19 @@ func NewCodegenService(logger *slog.Logger) serverinterfaces.CodegenService {
 		// Log error but continue - engine will fail when used if mapper registration fails
 		fmt.Printf("Warning: failed to register AWS Terraform mapper: %v\n", err)
 	}
+	// Register Azure Terraform mapper
+	if err := terraformMapperRegistry.Register(azureterraform.New()); err != nil {
+		fmt.Printf("Warning: failed to register Azure Terraform mapper: %v\n", err)
+	}
 	terraformEngine := tfgen.NewEngine(terraformMapperRegistry)
 	engines["terraform"] = terraformEngine
 
-	// TODO: Register Pulumi engine when available
-	// pulumiEngine := pulumi.NewEngine()
-	// engines["pulumi"] = pulumiEngine
+	// Register Pulumi engine (translates the Terraform mapping into a TypeScript program)
+	pulumiMapperRegistry := pulumimapper.NewRegistry()
+	if err := pulumiMapperRegistry.Register(awspulumi.New()); err != nil {
+		fmt.Printf("Warning: failed to register AWS Pulumi mapper: %v\n", err)
+	}
+	engines["pulumi"] = pulumigen.NewEngine(terraformEngine, pulumiMapperRegistry)
 
 	return &CodegenServiceImpl{
 		engines: engines,
@@ -52,7 +63,7 @@ func NewCodegenServiceWithEngines(engines map[string]iac.Engine) serverinterface
 }
 
 // Generate generates IaC code for an architecture using the specified engine
-func (s *CodegenServiceImpl) Generate(ctx context.Context, arch *architecture.Architecture, engine string) (*iac.Output, error) {
+func (s *CodegenServiceImpl) Generate(ctx context.Context, arch *architecture.Architecture, engine string, opts iac.Options) (*iac.Output, error) {
 	s.logger.Info("Generating code", "engine", engine)
 	if arch == nil {
 		return nil, fmt.Errorf("architecture is nil")
@@ -75,7 +86,7 @@ func (s *CodegenServiceImpl) Generate(ctx context.Context, arch *architecture.Ar
 	}
 
 	// Generate code using the engine
-	output, err := iacEngine.Generate(ctx, arch, sorted)
+	output, err := iacEngine.Generate(ctx, arch, sorted, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate %s code: %w", engine, err)
 	}
@@ -69,7 +69,7 @@ func TestCodegenService_Generate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := service.Generate(ctx, tt.arch, tt.engine)
+			result, err := service.Generate(ctx, tt.arch, tt.engine, iac.Options{})
 			if tt.wantError {
 				if err == nil {
 					t.Errorf("expected error but got none")
@@ -103,7 +103,7 @@ func TestCodegenService_GenerateWithValidArchitecture(t *testing.T) {
 		Dependencies: make(map[string][]string),
 	}
 
-	output, err := service.Generate(ctx, arch, "terraform")
+	output, err := service.Generate(ctx, arch, "terraform", iac.Options{})
 	if err != nil {
 		// This might fail if there are no resources, which is expected
 		// We're just testing that the service doesn't panic
@@ -151,7 +151,7 @@ func (m *mockIACEngine) Name() string {
 	return m.name
 }
 
-func (m *mockIACEngine) Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource) (*iac.Output, error) {
+func (m *mockIACEngine) Generate(ctx context.Context, arch *architecture.Architecture, sortedResources []*resource.Resource, opts iac.Options) (*iac.Output, error) {
 	return &iac.Output{
 		Files: []iac.GeneratedFile{
 			{
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/compliance"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// ComplianceServiceImpl implements ComplianceService interface
+type ComplianceServiceImpl struct {
+	projectService serverinterfaces.ProjectService
+	templateRepo   serverinterfaces.TemplateRepository
+}
+
+// NewComplianceService creates a new compliance service
+func NewComplianceService(projectService serverinterfaces.ProjectService, templateRepo serverinterfaces.TemplateRepository) serverinterfaces.ComplianceService {
+	return &ComplianceServiceImpl{projectService: projectService, templateRepo: templateRepo}
+}
+
+// ListStandards returns the built-in standards
+func (s *ComplianceServiceImpl) ListStandards(ctx context.Context) []serverinterfaces.ComplianceStandardInfo {
+	stds := compliance.Standards()
+	out := make([]serverinterfaces.ComplianceStandardInfo, 0, len(stds))
+	for _, std := range stds {
+		out = append(out, serverinterfaces.ComplianceStandardInfo{
+			Slug:         std.Slug,
+			Name:         std.Name,
+			Description:  std.Description,
+			ControlCount: len(std.Controls),
+		})
+	}
+	return out
+}
+
+// EvaluateVersion loads the version's architecture and checks it against a standard
+func (s *ComplianceServiceImpl) EvaluateVersion(ctx context.Context, projectID, versionID uuid.UUID, standard string) (*serverinterfaces.ComplianceReport, error) {
+	std, ok := compliance.Lookup(standard)
+	if !ok {
+		return nil, apperrors.New(apperrors.CodeInvalidValue, apperrors.KindNotFound, fmt.Sprintf("unknown compliance standard %q", standard))
+	}
+	reports, err := s.evaluate(ctx, projectID, versionID, []*compliance.Standard{std})
+	if err != nil {
+		return nil, err
+	}
+	return reports[0], nil
+}
+
+// VerifyTemplate checks each compliance standard claimed by the template against the
+// version. Claims without a built-in control set are reported as unsupported and do not
+// count as verified.
+func (s *ComplianceServiceImpl) VerifyTemplate(ctx context.Context, templateID, projectID, versionID uuid.UUID) (*serverinterfaces.TemplateComplianceVerification, error) {
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var supported []*compliance.Standard
+	for _, claim := range template.ComplianceStandards {
+		if std, ok := compliance.Lookup(claim.Slug); ok {
+			supported = append(supported, std)
+		}
+	}
+	reports, err := s.evaluate(ctx, projectID, versionID, supported)
+	if err != nil {
+		return nil, err
+	}
+	bySlug := make(map[string]*serverinterfaces.ComplianceReport, len(reports))
+	for _, r := range reports {
+		bySlug[r.Standard] = r
+	}
+
+	result := &serverinterfaces.TemplateComplianceVerification{
+		TemplateID: templateID,
+		VersionID:  versionID,
+		Verified:   true,
+		Claims:     make([]serverinterfaces.TemplateComplianceClaim, 0, len(template.ComplianceStandards)),
+	}
+	for _, claim := range template.ComplianceStandards {
+		c := serverinterfaces.TemplateComplianceClaim{
+			Standard: claim.Slug,
+			Name:     claim.Name,
+			Status:   serverinterfaces.ComplianceClaimUnsupported,
+		}
+		if report, ok := bySlug[claim.Slug]; ok {
+			c.Report = report
+			c.Status = serverinterfaces.ComplianceClaimVerified
+			if !report.Passed {
+				c.Status = serverinterfaces.ComplianceClaimFailed
+			}
+		}
+		if c.Status != serverinterfaces.ComplianceClaimVerified {
+			result.Verified = false
+		}
+		result.Claims = append(result.Claims, c)
+	}
+	return result, nil
+}
+
+// evaluate loads the version's architecture once and checks it against each standard.
+func (s *ComplianceServiceImpl) evaluate(ctx context.Context, projectID, versionID uuid.UUID, stds []*compliance.Standard) ([]*serverinterfaces.ComplianceReport, error) {
+	version, err := s.projectService.GetVersionByID(ctx, projectID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := s.projectService.LoadArchitecture(ctx, version.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("load architecture: %w", err)
+	}
+
+	reports := make([]*serverinterfaces.ComplianceReport, 0, len(stds))
+	for _, std := range stds {
+		r := compliance.Evaluate(std, arch)
+		report := &serverinterfaces.ComplianceReport{
+			VersionID:     versionID,
+			Standard:      r.Standard,
+			Name:          r.Name,
+			Passed:        r.Passed,
+			PassCount:     r.PassCount,
+			FailCount:     r.FailCount,
+			NotApplicable: r.NotApplicable,
+			Controls:      make([]serverinterfaces.ComplianceControlResult, 0, len(r.Controls)),
+		}
+		for _, c := range r.Controls {
+			report.Controls = append(report.Controls, serverinterfaces.ComplianceControlResult{
+				ID:          c.ID,
+				Title:       c.Title,
+				Severity:    c.Severity,
+				Status:      c.Status,
+				ResourceIDs: c.ResourceIDs,
+				Remediation: c.Remediation,
+			})
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
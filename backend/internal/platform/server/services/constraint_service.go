@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/rules"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
 	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	resourcerepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/resource"
@@ -63,8 +65,11 @@ func (s *ConstraintService) GetAllConstraints(ctx context.Context) ([]serverinte
 	return records, nil
 }
 
-// SaveConstraint saves a new constraint
+// SaveConstraint saves a new constraint after checking that the rules engine can build
+// a rule from it, so that a malformed value cannot break rule loading at startup
 func (s *ConstraintService) SaveConstraint(ctx context.Context, constraint *models.ResourceConstraint) error {
-	// Here you might add validation logic before saving
+	if _, err := rules.NewAWSRuleFactory().CreateRule(constraint.ResourceType.Name, constraint.ConstraintType, constraint.ConstraintValue); err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInvalidValue, apperrors.KindValidation, "invalid resource constraint")
+	}
 	return s.repo.Create(ctx, constraint)
 }
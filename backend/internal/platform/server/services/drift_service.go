@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/terraform"
+	azureterraform "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/mapper/terraform"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	tfgen "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/state"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// DriftServiceImpl implements DriftService interface
+type DriftServiceImpl struct {
+	projectService serverinterfaces.ProjectService
+	mappers        *tfmapper.MapperRegistry
+	engine         *tfgen.Engine
+}
+
+// NewDriftService creates a new drift service backed by the built-in Terraform mappers
+func NewDriftService(projectService serverinterfaces.ProjectService) serverinterfaces.DriftService {
+	mappers := tfmapper.NewRegistry()
+	if err := mappers.Register(terraform.New()); err != nil {
+		fmt.Printf("Warning: failed to register AWS Terraform mapper: %v\n", err)
+	}
+	if err := mappers.Register(azureterraform.New()); err != nil {
+		fmt.Printf("Warning: failed to register Azure Terraform mapper: %v\n", err)
+	}
+	return &DriftServiceImpl{
+		projectService: projectService,
+		mappers:        mappers,
+		engine:         tfgen.NewEngine(mappers),
+	}
+}
+
+// DetectDrift maps the version's architecture to Terraform blocks, as code generation
+// would, and compares them with the resources recorded in the state
+func (s *DriftServiceImpl) DetectDrift(ctx context.Context, projectID, versionID uuid.UUID, req *serverinterfaces.DetectDriftRequest) (*serverinterfaces.DriftResult, error) {
+	st, err := state.Parse(req.State)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInvalidFormat, apperrors.KindValidation, "invalid terraform state")
+	}
+
+	version, err := s.projectService.GetVersionByID(ctx, projectID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := s.projectService.LoadArchitecture(ctx, version.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("load architecture: %w", err)
+	}
+	mapper, ok := s.mappers.Get(string(arch.Provider))
+	if !ok {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "drift detection is not supported for provider %q", arch.Provider)
+	}
+	sorted, err := architecture.NewGraph(arch).GetSortedResources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sort resources: %w", err)
+	}
+	blocks, err := s.engine.MapBlocks(ctx, arch, sorted)
+	if err != nil {
+		return nil, fmt.Errorf("map architecture to terraform: %w", err)
+	}
+
+	return &serverinterfaces.DriftResult{
+		VersionID:   versionID,
+		Lineage:     st.Lineage,
+		Serial:      st.Serial,
+		DriftReport: *st.Drift(blocks, stateProvider(mapper)),
+	}, nil
+}
+
+// stateProvider returns the provider name the state records the mapper's resources under,
+// e.g. "azurerm" for "azure"
+func stateProvider(m tfmapper.ResourceMapper) string {
+	if r, ok := m.(tfmapper.ProviderRequirer); ok && r.RequiredProvider().LocalName != "" {
+		return r.RequiredProvider().LocalName
+	}
+	return m.Provider()
+}
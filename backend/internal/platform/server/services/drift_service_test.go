@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+const emptyDriftState = `{"version": 4, "serial": 3, "lineage": "l1", "resources": []}`
+
+func TestDriftService_DetectDrift(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	projects := store.projectService()
+	svc := NewDriftService(projects)
+
+	root, version, err := store.createProject(ctx, projects, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	req := &serverinterfaces.DetectDriftRequest{State: []byte(emptyDriftState)}
+
+	result, err := svc.DetectDrift(ctx, root.ID, version.ID, req)
+	if err != nil {
+		t.Fatalf("DetectDrift error: %v", err)
+	}
+	if result.VersionID != version.ID || result.Lineage != "l1" || result.Serial != 3 {
+		t.Errorf("DetectDrift = version %s, lineage %q, serial %d, want %s, l1, 3", result.VersionID, result.Lineage, result.Serial, version.ID)
+	}
+	if len(result.NotDeployed) != 1 || result.NotDeployed[0].Type != "aws_vpc" {
+		t.Errorf("NotDeployed = %+v, want the designed aws_vpc", result.NotDeployed)
+	}
+
+	if _, err := svc.DetectDrift(ctx, root.ID, version.ID, &serverinterfaces.DetectDriftRequest{State: []byte(`{"version": 3}`)}); err == nil {
+		t.Error("DetectDrift with an unsupported state version: expected error")
+	}
+}
+
+func TestDriftService_DetectDriftRejectsOtherLineage(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	projects := store.projectService()
+	svc := NewDriftService(projects)
+
+	root, _, err := store.createProject(ctx, projects, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	_, other, err := store.createProject(ctx, projects, vpcVersion("initial", [2]string{"vpc-b", "other"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+
+	req := &serverinterfaces.DetectDriftRequest{State: []byte(emptyDriftState)}
+	if _, err := svc.DetectDrift(ctx, root.ID, other.ID, req); err == nil {
+		t.Error("DetectDrift with a version of another project: expected error")
+	}
+}
+
+func TestDriftService_DetectDriftAzure(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	projects := store.projectService()
+	svc := NewDriftService(projects)
+
+	root := &models.Project{ID: uuid.New(), UserID: uuid.New(), Name: "shop", CloudProvider: "azure", Region: "eastus"}
+	store.projects[root.ID] = root
+	version, err := projects.CreateVersion(ctx, root.ID, &serverinterfaces.CreateVersionRequest{
+		Message: "initial",
+		Nodes: []dto.ArchitectureNode{{
+			ID:   "rg-1",
+			Type: "ResourceGroup",
+			Data: dto.ArchitectureNodeData{Label: "shop", ResourceType: "ResourceGroup", Config: map[string]interface{}{"name": "shop", "location": "eastus"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CreateVersion error: %v", err)
+	}
+
+	result, err := svc.DetectDrift(ctx, root.ID, version.ID, &serverinterfaces.DetectDriftRequest{State: []byte(azureDriftState)})
+	if err != nil {
+		t.Fatalf("DetectDrift error: %v", err)
+	}
+	// The state records Azure resources under the azurerm provider
+	if len(result.NotDeployed) != 0 || len(result.Changed) != 1 {
+		t.Fatalf("DetectDrift = %+v, want the resource group matched", result.DriftReport)
+	}
+	if changed := result.Changed[0]; changed.Address != "azurerm_resource_group.shop" || len(changed.Attributes) != 1 || changed.Attributes[0].Attribute != "location" {
+		t.Errorf("Changed = %+v, want the resource group location", changed)
+	}
+}
+
+const azureDriftState = `{"version": 4, "serial": 1, "lineage": "l1", "resources": [
+	{"mode": "managed", "type": "azurerm_resource_group", "name": "shop", "provider": "provider[\"registry.terraform.io/hashicorp/azurerm\"]",
+	 "instances": [{"attributes": {"id": "/subscriptions/s/resourceGroups/shop", "name": "shop", "location": "westeurope"}}]}
+]}`
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/policy"
+)
+
+// GuardrailServiceImpl implements GuardrailService
+type GuardrailServiceImpl struct {
+	repo serverinterfaces.GuardrailRepository
+
+	mu       sync.RWMutex
+	instance []instanceGuardrail
+}
+
+type instanceGuardrail struct {
+	serverinterfaces.InstanceGuardrail
+	policies []*policy.Policy
+}
+
+// NewGuardrailService creates a new guardrail service
+func NewGuardrailService(repo serverinterfaces.GuardrailRepository) *GuardrailServiceImpl {
+	return &GuardrailServiceImpl{repo: repo}
+}
+
+// LoadInstanceGuardrails replaces the instance-wide guardrails with the *.policy files
+// of dir. Files that fail to parse are skipped and reported in the returned error;
+// the others are still loaded.
+func (s *GuardrailServiceImpl) LoadInstanceGuardrails(dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.policy"))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(paths)
+
+	var loaded []instanceGuardrail
+	var errs []error
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		policies, err := policy.Parse(string(src))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+			continue
+		}
+		loaded = append(loaded, instanceGuardrail{
+			InstanceGuardrail: serverinterfaces.InstanceGuardrail{
+				Name:     strings.TrimSuffix(filepath.Base(path), ".policy"),
+				Source:   string(src),
+				Policies: policyNames(policies),
+			},
+			policies: policies,
+		})
+	}
+
+	s.mu.Lock()
+	s.instance = loaded
+	s.mu.Unlock()
+	return len(loaded), errors.Join(errs...)
+}
+
+// InstanceGuardrails returns the instance-wide guardrails
+func (s *GuardrailServiceImpl) InstanceGuardrails() []serverinterfaces.InstanceGuardrail {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]serverinterfaces.InstanceGuardrail, len(s.instance))
+	for i, g := range s.instance {
+		out[i] = g.InstanceGuardrail
+	}
+	return out
+}
+
+// List returns the guardrails owned by a user
+func (s *GuardrailServiceImpl) List(ctx context.Context, userID uuid.UUID) ([]*models.Guardrail, error) {
+	guardrails, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, platformerrors.NewDatabaseQueryFailed("list_guardrails", err)
+	}
+	return guardrails, nil
+}
+
+// Get returns one of the user's guardrails. Guardrails of other users are reported as
+// missing so their existence is not revealed.
+func (s *GuardrailServiceImpl) Get(ctx context.Context, userID, id uuid.UUID) (*models.Guardrail, error) {
+	guardrail, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if guardrail.UserID != userID {
+		return nil, platformerrors.NewRepositoryNotFound("guardrail", id)
+	}
+	return guardrail, nil
+}
+
+// Create parses and saves a new guardrail for the user
+func (s *GuardrailServiceImpl) Create(ctx context.Context, userID uuid.UUID, req *serverinterfaces.GuardrailRequest) (*models.Guardrail, error) {
+	guardrail := &models.Guardrail{ID: uuid.New(), UserID: userID, Enabled: true}
+	if err := applyGuardrailRequest(guardrail, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, guardrail); err != nil {
+		return nil, platformerrors.NewRepositoryCreateFailed("guardrail", err)
+	}
+	return guardrail, nil
+}
+
+// Update changes one of the user's guardrails
+func (s *GuardrailServiceImpl) Update(ctx context.Context, userID, id uuid.UUID, req *serverinterfaces.GuardrailRequest) (*models.Guardrail, error) {
+	guardrail, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyGuardrailRequest(guardrail, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, guardrail); err != nil {
+		return nil, platformerrors.NewRepositoryUpdateFailed("guardrail", err)
+	}
+	return guardrail, nil
+}
+
+// Delete removes one of the user's guardrails
+func (s *GuardrailServiceImpl) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.Get(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return platformerrors.NewRepositoryDeleteFailed("guardrail", err)
+	}
+	return nil
+}
+
+// Evaluate checks an architecture against the instance-wide guardrails and the enabled
+// guardrails of the authenticated caller
+func (s *GuardrailServiceImpl) Evaluate(ctx context.Context, arch *architecture.Architecture) ([]policy.Violation, error) {
+	s.mu.RLock()
+	var policies []*policy.Policy
+	for _, g := range s.instance {
+		policies = append(policies, g.policies...)
+	}
+	s.mu.RUnlock()
+
+	if callerID, ok := auth.UserIDFromContext(ctx); ok && s.repo != nil {
+		guardrails, err := s.repo.FindByUserID(ctx, callerID)
+		if err != nil {
+			return nil, platformerrors.NewDatabaseQueryFailed("list_guardrails", err)
+		}
+		for _, g := range guardrails {
+			if !g.Enabled {
+				continue
+			}
+			parsed, err := policy.Parse(g.Source)
+			if err != nil {
+				// Sources are checked when saved, so this only happens when the
+				// language has changed underneath a stored guardrail.
+				return nil, fmt.Errorf("guardrail %q: %w", g.Name, err)
+			}
+			policies = append(policies, parsed...)
+		}
+	}
+
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return policy.Evaluate(policies, arch), nil
+}
+
+// applyGuardrailRequest copies the set request fields onto a guardrail and checks
+// that the result is complete and that its source parses.
+func applyGuardrailRequest(guardrail *models.Guardrail, req *serverinterfaces.GuardrailRequest) error {
+	if req.Name != nil {
+		guardrail.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		guardrail.Description = *req.Description
+	}
+	if req.Source != nil {
+		guardrail.Source = *req.Source
+	}
+	if req.Enabled != nil {
+		guardrail.Enabled = *req.Enabled
+	}
+
+	if guardrail.Name == "" {
+		return apperrors.New(apperrors.CodeRequiredFieldMissing, apperrors.KindValidation, "guardrail name is required")
+	}
+	if _, err := policy.Parse(guardrail.Source); err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInvalidValue, apperrors.KindValidation, "invalid guardrail policy")
+	}
+	return nil
+}
+
+func policyNames(policies []*policy.Policy) []string {
+	names := make([]string, len(policies))
+	for i, p := range policies {
+		names[i] = p.Name
+	}
+	return names
+}
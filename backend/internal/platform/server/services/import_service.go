@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/terraform"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/parser"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/importer"
+	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/state"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+const (
+	// defaultImportMessage is the version message used when the request does not set one
+	defaultImportMessage = "Imported from Terraform"
+	// stateImportMessage is the message of the version created from a state file
+	stateImportMessage = "Imported from Terraform state"
+)
+
+// ImportServiceImpl implements ImportService interface
+type ImportServiceImpl struct {
+	projectService serverinterfaces.ProjectService
+	mappers        *tfmapper.MapperRegistry
+}
+
+// NewImportService creates a new import service backed by the built-in Terraform mappers.
+// Only mappers that implement tfmapper.ResourceImporter are registered; imports for other
+// providers, such as Azure, are rejected as unsupported.
+func NewImportService(projectService serverinterfaces.ProjectService) serverinterfaces.ImportService {
+	mappers := tfmapper.NewRegistry()
+	if err := mappers.Register(terraform.New()); err != nil {
+		fmt.Printf("Warning: failed to register AWS Terraform mapper: %v\n", err)
+	}
+	return &ImportServiceImpl{
+		projectService: projectService,
+		mappers:        mappers,
+	}
+}
+
+// ImportTerraform parses Terraform files and saves the recognised resources as a new project version
+func (s *ImportServiceImpl) ImportTerraform(ctx context.Context, projectID uuid.UUID, req *serverinterfaces.ImportTerraformRequest) (*serverinterfaces.ImportResult, error) {
+	if len(req.Files) == 0 {
+		return nil, apperrors.New(apperrors.CodeRequiredFieldMissing, apperrors.KindValidation, "no terraform files provided")
+	}
+
+	project, err := s.projectService.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	mapper, ok := s.mappers.Get(project.CloudProvider)
+	if !ok {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "terraform import is not supported for provider %q", project.CloudProvider)
+	}
+
+	files := make(map[string][]byte, len(req.Files))
+	for name, src := range req.Files {
+		files[name] = []byte(src)
+	}
+	mod, err := importer.ParseFiles(files)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInvalidFormat, apperrors.KindValidation, "invalid terraform source")
+	}
+	result, err := importer.Import(mod, mapper)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Diagram.Nodes) == 0 {
+		return nil, apperrors.New(apperrors.CodeValidationFailed, apperrors.KindValidation, "no importable resources found")
+	}
+
+	issues := result.Issues
+	if result.Region != "" && project.Region != "" && result.Region != project.Region {
+		issues = append(issues, tfmapper.ImportIssue{
+			Address: "provider." + project.CloudProvider,
+			Message: fmt.Sprintf("region %s differs from the project region %s; the project region is kept", result.Region, project.Region),
+		})
+	}
+
+	message := req.Message
+	if message == "" {
+		message = defaultImportMessage
+	}
+	version, err := s.projectService.CreateVersion(ctx, projectID, diagramToVersionRequest(result.Diagram, message))
+	if err != nil {
+		return nil, err
+	}
+
+	return &serverinterfaces.ImportResult{
+		Version: version,
+		Region:  result.Region,
+		Issues:  issues,
+	}, nil
+}
+
+// ImportState creates a new project showing the resources recorded in a Terraform state file
+func (s *ImportServiceImpl) ImportState(ctx context.Context, userID uuid.UUID, req *serverinterfaces.ImportStateRequest) (*serverinterfaces.StateImportResult, error) {
+	st, err := state.Parse(req.State)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInvalidFormat, apperrors.KindValidation, "invalid terraform state")
+	}
+
+	var mapper tfmapper.ResourceMapper
+	for _, provider := range st.Providers() {
+		if m, ok := s.mappers.Get(provider); ok {
+			mapper = m
+			break
+		}
+	}
+	if mapper == nil {
+		if providers := st.Providers(); len(providers) > 0 {
+			return nil, apperrors.Newf(apperrors.CodeValidationFailed, apperrors.KindValidation, "terraform state import is not supported for provider %s", strings.Join(providers, ", "))
+		}
+		return nil, apperrors.New(apperrors.CodeValidationFailed, apperrors.KindValidation, "the state has no resources of a supported provider")
+	}
+
+	result, err := state.Import(st, mapper)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Diagram.Nodes) == 0 {
+		return nil, apperrors.New(apperrors.CodeValidationFailed, apperrors.KindValidation, "no importable resources found")
+	}
+
+	region := req.Region
+	if region == "" {
+		region = result.Region
+	}
+	if region == "" {
+		return nil, apperrors.New(apperrors.CodeRequiredFieldMissing, apperrors.KindValidation, "region is not recorded in the state; set it in the request")
+	}
+
+	project, err := s.projectService.Create(ctx, &serverinterfaces.CreateProjectRequest{
+		UserID:        userID,
+		Name:          req.Name,
+		IACTargetID:   req.IACToolID,
+		CloudProvider: mapper.Provider(),
+		Region:        region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.projectService.CreateVersion(ctx, project.ID, diagramToVersionRequest(result.Diagram, stateImportMessage))
+	if err != nil {
+		// Do not leave an empty project behind.
+		if delErr := s.projectService.Delete(ctx, project.ID); delErr != nil {
+			return nil, fmt.Errorf("%w (removing the new project failed: %v)", err, delErr)
+		}
+		return nil, err
+	}
+
+	return &serverinterfaces.StateImportResult{
+		ProjectID: project.ID,
+		Version:   version,
+		Region:    region,
+		Issues:    result.Issues,
+	}, nil
+}
+
+// diagramToVersionRequest converts an imported IR diagram into the payload CreateVersion expects.
+func diagramToVersionRequest(d *parser.IRDiagram, message string) *serverinterfaces.CreateVersionRequest {
+	req := &serverinterfaces.CreateVersionRequest{
+		Nodes:     make([]dto.ArchitectureNode, 0, len(d.Nodes)),
+		Edges:     make([]dto.ArchitectureEdge, 0, len(d.Edges)),
+		Variables: make([]dto.ArchitectureVariable, 0, len(d.Variables)),
+		Outputs:   make([]dto.ArchitectureOutput, 0, len(d.Outputs)),
+		Message:   message,
+	}
+
+	for _, n := range d.Nodes {
+		node := dto.ArchitectureNode{
+			ID:       n.ID,
+			Type:     n.Type,
+			ParentID: n.ParentID,
+			Data: dto.ArchitectureNodeData{
+				Label:        n.Data.Label,
+				ResourceType: n.Data.ResourceType,
+				Config:       n.Data.Config,
+			},
+		}
+		if n.Data.IsVisualOnly != nil {
+			node.Data.IsVisualOnly = *n.Data.IsVisualOnly
+		}
+		if n.Position != nil {
+			node.Position = dto.NodePosition{X: n.Position.X, Y: n.Position.Y}
+		}
+		if n.Width != nil && n.Height != nil {
+			node.UIState = &dto.NodeUIState{
+				X:      node.Position.X,
+				Y:      node.Position.Y,
+				Width:  *n.Width,
+				Height: *n.Height,
+			}
+		}
+		req.Nodes = append(req.Nodes, node)
+	}
+	for _, e := range d.Edges {
+		req.Edges = append(req.Edges, dto.ArchitectureEdge{
+			ID:     e.ID,
+			Source: e.Source,
+			Target: e.Target,
+			Type:   "depends_on",
+		})
+	}
+	for _, v := range d.Variables {
+		req.Variables = append(req.Variables, dto.ArchitectureVariable{
+			Name:        v.Name,
+			Type:        v.Type,
+			Value:       v.Default,
+			Description: v.Description,
+			Sensitive:   v.Sensitive,
+		})
+	}
+	for _, o := range d.Outputs {
+		req.Outputs = append(req.Outputs, dto.ArchitectureOutput{
+			Name:        o.Name,
+			Value:       o.Value,
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+		})
+	}
+	return req
+}
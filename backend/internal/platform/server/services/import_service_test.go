@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+func TestImportService_RejectsAzure(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := NewImportService(store.projectService())
+
+	root := &models.Project{ID: uuid.New(), UserID: uuid.New(), Name: "shop", CloudProvider: "azure", Region: "eastus"}
+	store.projects[root.ID] = root
+	files := map[string]string{"main.tf": `resource "azurerm_resource_group" "shop" {
+  name     = "shop"
+  location = "eastus"
+}`}
+	_, err := svc.ImportTerraform(ctx, root.ID, &serverinterfaces.ImportTerraformRequest{Files: files})
+	if !apperrors.IsKind(err, apperrors.KindValidation) || !strings.Contains(err.Error(), "azure") {
+		t.Errorf("ImportTerraform into an Azure project: err = %v, want provider not supported", err)
+	}
+
+	_, err = svc.ImportState(ctx, root.UserID, &serverinterfaces.ImportStateRequest{State: []byte(azureDriftState)})
+	if !apperrors.IsKind(err, apperrors.KindValidation) || !strings.Contains(err.Error(), "azurerm") {
+		t.Errorf("ImportState of an Azure state: err = %v, want provider not supported", err)
+	}
+	if len(store.versions) != 0 {
+		t.Errorf("rejected imports created %d versions", len(store.versions))
+	}
+}
@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"gorm.io/datatypes"
+)
+
+const (
+	// defaultTemplatePageSize is the page size of template searches that do not set one
+	defaultTemplatePageSize = 20
+	// maxTemplatePageSize caps the page size of template searches
+	maxTemplatePageSize = 100
+)
+
+// templateSorts are the sort orders accepted by Search
+var templateSorts = map[string]bool{"popular": true, "rating": true, "downloads": true, "newest": true, "cost": true}
+
+// templateCloudProviders maps project cloud providers to the cloud providers of templates
+var templateCloudProviders = map[string]string{"aws": "AWS", "azure": "Azure", "gcp": "GCP"}
+
+// MarketplaceServiceImpl implements MarketplaceService interface
+type MarketplaceServiceImpl struct {
+	projectService serverinterfaces.ProjectService
+	pricingService serverinterfaces.PricingService
+	templateRepo   serverinterfaces.TemplateRepository
+	categoryRepo   serverinterfaces.CategoryRepository
+	technologyRepo serverinterfaces.TechnologyRepository
+	complianceRepo serverinterfaces.ComplianceStandardRepository
+	iacFormatRepo  serverinterfaces.IACFormatRepository
+	// instantiationRepo records who created projects from which templates, for reviews
+	instantiationRepo serverinterfaces.TemplateInstantiationRepository
+	logger            *slog.Logger
+}
+
+// NewMarketplaceService creates a new marketplace service
+func NewMarketplaceService(
+	projectService serverinterfaces.ProjectService,
+	pricingService serverinterfaces.PricingService,
+	templateRepo serverinterfaces.TemplateRepository,
+	categoryRepo serverinterfaces.CategoryRepository,
+	technologyRepo serverinterfaces.TechnologyRepository,
+	complianceRepo serverinterfaces.ComplianceStandardRepository,
+	iacFormatRepo serverinterfaces.IACFormatRepository,
+	instantiationRepo serverinterfaces.TemplateInstantiationRepository,
+	logger *slog.Logger,
+) serverinterfaces.MarketplaceService {
+	return &MarketplaceServiceImpl{
+		projectService:    projectService,
+		pricingService:    pricingService,
+		templateRepo:      templateRepo,
+		categoryRepo:      categoryRepo,
+		technologyRepo:    technologyRepo,
+		complianceRepo:    complianceRepo,
+		iacFormatRepo:     iacFormatRepo,
+		instantiationRepo: instantiationRepo,
+		logger:            logger,
+	}
+}
+
+// Publish saves a version of one of the caller's projects as a template
+func (s *MarketplaceServiceImpl) Publish(ctx context.Context, userID uuid.UUID, req *serverinterfaces.PublishTemplateRequest) (*models.Template, error) {
+	version, err := s.projectService.GetVersionByID(ctx, req.ProjectID, req.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	// Only the owner of the version's snapshot may publish it
+	project, err := s.projectService.GetByID(ctx, version.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	cloudProvider, ok := templateCloudProviders[strings.ToLower(project.CloudProvider)]
+	if !ok {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "cloud provider %q cannot be published", project.CloudProvider)
+	}
+
+	template := &models.Template{
+		ID:              uuid.New(),
+		Title:           req.Title,
+		Description:     req.Description,
+		CloudProvider:   cloudProvider,
+		AuthorID:        userID,
+		ImageURL:        req.ImageURL,
+		IsNew:           true,
+		LastUpdated:     time.Now(),
+		DeploymentTime:  req.DeploymentTime,
+		Regions:         &project.Region,
+		SourceVersionID: &version.ID,
+	}
+	if err := s.resolveTags(ctx, template, req); err != nil {
+		return nil, err
+	}
+
+	if version.State == nil {
+		return nil, fmt.Errorf("Publish: version %s has no architecture", version.ID)
+	}
+	state := *version.State
+	state.VersionID = ""
+	state.Warnings = nil
+
+	params := templateParameters(&state, cloudProvider, project.Region)
+	if err := annotateTemplateParameters(params, req.Parameters); err != nil {
+		return nil, err
+	}
+	// Sensitive values are not published: their references are put back in place of the
+	// resolved values and resolved again from the values chosen on instantiation.
+	variables := make([]dto.ArchitectureVariable, len(state.Variables))
+	vars := make(map[string]interface{}, len(state.Variables))
+	for i, v := range state.Variables {
+		if v.Sensitive {
+			v.Value = nil
+		} else if v.Value != nil {
+			vars[v.Name] = v.Value
+		}
+		variables[i] = v
+	}
+	state.Variables = variables
+	nodes := make([]dto.ArchitectureNode, len(state.Nodes))
+	for i, node := range state.Nodes {
+		config, _ := cloneConfigValue(node.Data.Config).(map[string]interface{})
+		node.Data.Config = resolveTemplateVariables(config, vars)
+		nodes[i] = node
+	}
+	state.Nodes = nodes
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("Publish: encode architecture: %w", err)
+	}
+	template.Architecture = datatypes.JSON(raw)
+	if raw, err = json.Marshal(params); err != nil {
+		return nil, fmt.Errorf("Publish: encode parameters: %w", err)
+	}
+	template.Parameters = datatypes.JSON(raw)
+
+	for i, feature := range req.Features {
+		template.Features = append(template.Features, models.TemplateFeature{Feature: feature, DisplayOrder: i})
+	}
+	for i, useCase := range req.UseCases {
+		template.UseCases = append(template.UseCases, models.TemplateUseCase{
+			Title:        useCase.Title,
+			Description:  useCase.Description,
+			Icon:         useCase.Icon,
+			DisplayOrder: i,
+		})
+	}
+
+	arch, err := s.projectService.LoadArchitecture(ctx, version.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("Publish: load architecture: %w", err)
+	}
+	components, err := s.templateComponents(ctx, arch)
+	if err != nil {
+		return nil, fmt.Errorf("Publish: %w", err)
+	}
+	template.Components = components
+	template.Resources = len(components)
+	if template.EstimatedCostMin, template.EstimatedCostMax, err = s.estimateCostRange(ctx, arch); err != nil {
+		return nil, fmt.Errorf("Publish: %w", err)
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("Publish: %w", err)
+	}
+	return s.templateRepo.FindByID(ctx, template.ID)
+}
+
+// Search lists the templates matching a filter
+func (s *MarketplaceServiceImpl) Search(ctx context.Context, filter models.TemplateFilter) ([]*models.Template, int64, error) {
+	if filter.Sort == "" {
+		filter.Sort = "popular"
+	}
+	if !templateSorts[filter.Sort] {
+		return nil, 0, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "unknown sort %q (popular, rating, downloads, newest or cost)", filter.Sort)
+	}
+	if filter.MinCost != nil && filter.MaxCost != nil && *filter.MinCost > *filter.MaxCost {
+		return nil, 0, apperrors.New(apperrors.CodeInvalidValue, apperrors.KindValidation, "min_cost cannot be greater than max_cost")
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTemplatePageSize
+	}
+	if filter.Limit > maxTemplatePageSize {
+		filter.Limit = maxTemplatePageSize
+	}
+	return s.templateRepo.Search(ctx, filter)
+}
+
+// Get returns a template with its related data. Templates published before parameters
+// were stored get the parameters derived from their architecture.
+func (s *MarketplaceServiceImpl) Get(ctx context.Context, templateID uuid.UUID) (*models.Template, error) {
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if len(template.Parameters) == 0 {
+		if state, err := template.UnmarshalArchitecture(); err == nil && state != nil {
+			if raw, err := json.Marshal(templateParameters(state, template.CloudProvider, templateRegion(template))); err == nil {
+				template.Parameters = datatypes.JSON(raw)
+			}
+		}
+	}
+	return template, nil
+}
+
+// Instantiate creates a project for the caller from a template's architecture. The new
+// project is removed again when its first version or the record of the instantiation,
+// which reviews require, cannot be created.
+func (s *MarketplaceServiceImpl) Instantiate(ctx context.Context, userID, templateID uuid.UUID, req *serverinterfaces.InstantiateTemplateRequest) (*serverinterfaces.TemplateInstance, error) {
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := template.UnmarshalArchitecture()
+	if err != nil {
+		return nil, fmt.Errorf("Instantiate: decode architecture: %w", err)
+	}
+	if state == nil {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "template %s has no architecture to instantiate", templateID)
+	}
+	params, err := template.UnmarshalParameters()
+	if err != nil {
+		return nil, fmt.Errorf("Instantiate: decode parameters: %w", err)
+	}
+	region := templateRegion(template)
+	if params == nil {
+		params = templateParameters(state, template.CloudProvider, region)
+	}
+	values, err := resolveTemplateParameters(params, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTemplateParameters(state, region, values); err != nil {
+		return nil, err
+	}
+	if chosen, ok := values[templateParamRegion].(string); ok {
+		region = chosen
+	}
+
+	project, err := s.projectService.Create(ctx, &serverinterfaces.CreateProjectRequest{
+		UserID:        userID,
+		Name:          req.Name,
+		Description:   template.Description,
+		IACTargetID:   req.IACToolID,
+		CloudProvider: strings.ToLower(template.CloudProvider),
+		Region:        region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Do not leave an empty or unreviewable project behind: its first version, and the
+	// snapshot project holding it, go before the project itself.
+	var version *serverinterfaces.ProjectVersionDetail
+	discard := func(err error) error {
+		if version != nil {
+			if delErr := s.projectService.DeleteVersion(ctx, project.ID, version.ID); delErr != nil {
+				return fmt.Errorf("%w (removing the new version failed: %v)", err, delErr)
+			}
+			if delErr := s.projectService.Delete(ctx, version.ProjectID); delErr != nil {
+				return fmt.Errorf("%w (removing the new version's snapshot failed: %v)", err, delErr)
+			}
+		}
+		if delErr := s.projectService.Delete(ctx, project.ID); delErr != nil {
+			return fmt.Errorf("%w (removing the new project failed: %v)", err, delErr)
+		}
+		return err
+	}
+	version, err = s.projectService.CreateVersion(ctx, project.ID, &serverinterfaces.CreateVersionRequest{
+		Nodes:     state.Nodes,
+		Edges:     state.Edges,
+		Variables: state.Variables,
+		Outputs:   state.Outputs,
+		Message:   "Created from template " + template.Title,
+	})
+	if err != nil {
+		return nil, discard(err)
+	}
+	if err := s.instantiationRepo.Create(ctx, &models.TemplateInstantiation{
+		ID:         uuid.New(),
+		TemplateID: templateID,
+		UserID:     userID,
+		ProjectID:  project.ID,
+	}); err != nil {
+		return nil, discard(fmt.Errorf("Instantiate: record instantiation: %w", err))
+	}
+
+	instance := &serverinterfaces.TemplateInstance{
+		TemplateID: templateID,
+		ProjectID:  project.ID,
+		Version:    version,
+		Parameters: make(map[string]interface{}, len(values)),
+	}
+	for _, p := range params {
+		if value, ok := values[p.Name]; ok && !p.Sensitive {
+			instance.Parameters[p.Name] = value
+		}
+	}
+	if err := s.templateRepo.IncrementDownloads(ctx, templateID); err != nil {
+		s.logger.Warn("Failed to count template download", "template_id", templateID, "error", err)
+	}
+
+	// The chosen parameters change the price, so the instance is priced on its own; the
+	// template keeps the estimate of its default architecture. The project exists by now,
+	// so a pricing failure leaves the instance without an estimate.
+	arch, err := s.projectService.LoadArchitecture(ctx, version.ProjectID)
+	if err != nil {
+		s.logger.Warn("Failed to load instantiated architecture", "project_id", version.ProjectID, "error", err)
+		return instance, nil
+	}
+	if instance.EstimatedCostMin, instance.EstimatedCostMax, err = s.estimateCostRange(ctx, arch); err != nil {
+		s.logger.Warn("Failed to price template instance", "template_id", templateID, "project_id", project.ID, "error", err)
+	}
+	return instance, nil
+}
+
+// templateRegion returns the first region of a template, or "".
+func templateRegion(template *models.Template) string {
+	if template.Regions == nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.Split(*template.Regions, ",")[0])
+}
+
+// resolveTags sets the template's category, technologies, compliance standards and IaC
+// formats from their slugs. Unknown slugs are validation errors.
+func (s *MarketplaceServiceImpl) resolveTags(ctx context.Context, template *models.Template, req *serverinterfaces.PublishTemplateRequest) error {
+	category, err := s.categoryRepo.FindBySlug(ctx, req.Category)
+	if err != nil {
+		return unknownSlug(err, "category", req.Category)
+	}
+	template.CategoryID = category.ID
+
+	for _, slug := range req.Technologies {
+		tech, err := s.technologyRepo.FindBySlug(ctx, slug)
+		if err != nil {
+			return unknownSlug(err, "technology", slug)
+		}
+		template.Technologies = append(template.Technologies, *tech)
+	}
+	for _, slug := range req.ComplianceStandards {
+		standard, err := s.complianceRepo.FindBySlug(ctx, slug)
+		if err != nil {
+			return unknownSlug(err, "compliance standard", slug)
+		}
+		template.ComplianceStandards = append(template.ComplianceStandards, *standard)
+	}
+	for _, slug := range req.IACFormats {
+		format, err := s.iacFormatRepo.FindBySlug(ctx, slug)
+		if err != nil {
+			return unknownSlug(err, "IaC format", slug)
+		}
+		template.IACFormats = append(template.IACFormats, *format)
+	}
+	return nil
+}
+
+// unknownSlug reports a slug that was not found as a validation error; other lookup
+// errors are returned as they are.
+func unknownSlug(err error, kind, slug string) error {
+	if apperrors.IsKind(err, apperrors.KindNotFound) {
+		return apperrors.Wrapf(err, apperrors.CodeInvalidValue, apperrors.KindValidation, "unknown %s %q", kind, slug)
+	}
+	return err
+}
+
+// templateComponents prices the architecture on-demand for a month and returns one
+// component per resource, in architecture order. Visual-only resources are left out.
+func (s *MarketplaceServiceImpl) templateComponents(ctx context.Context, arch *architecture.Architecture) ([]models.TemplateComponent, error) {
+	estimate, err := s.pricingService.CalculateArchitectureCost(ctx, arch, 720*time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("price architecture: %w", err)
+	}
+
+	components := make([]models.TemplateComponent, 0, len(arch.Resources))
+	for _, res := range arch.Resources {
+		if isVisualOnly, ok := res.Metadata["isVisualOnly"].(bool); ok && isVisualOnly {
+			continue
+		}
+		component := models.TemplateComponent{
+			Name:         res.Name,
+			Service:      res.Type.Name,
+			DisplayOrder: len(components),
+		}
+		if resEstimate, ok := estimate.ResourceEstimates[res.ID]; ok {
+			component.MonthlyCost = resEstimate.TotalCost
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// estimateCostRange returns the monthly cost of the architecture under its cheapest
+// purchase option and on-demand.
+func (s *MarketplaceServiceImpl) estimateCostRange(ctx context.Context, arch *architecture.Architecture) (float64, float64, error) {
+	comparison, err := s.pricingService.ComparePurchaseOptions(ctx, arch, 720*time.Hour)
+	if err != nil {
+		return 0, 0, fmt.Errorf("compare purchase options: %w", err)
+	}
+	cheapest := comparison.OnDemandCost
+	for _, scenario := range comparison.Scenarios {
+		if scenario.TotalCost < cheapest {
+			cheapest = scenario.TotalCost
+		}
+	}
+	return cheapest, comparison.OnDemandCost, nil
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"gorm.io/gorm"
+)
+
+// mockTemplateRepository keeps templates in memory and records the last search filter
+type mockTemplateRepository struct {
+	templates map[uuid.UUID]*models.Template
+	filter    models.TemplateFilter
+}
+
+func (m *mockTemplateRepository) Create(ctx context.Context, template *models.Template) error {
+	m.templates[template.ID] = template
+	return nil
+}
+
+func (m *mockTemplateRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Template, error) {
+	template, ok := m.templates[id]
+	if !ok {
+		return nil, platformerrors.HandleGormError(gorm.ErrRecordNotFound, "template", "mockTemplateRepository.FindByID")
+	}
+	return template, nil
+}
+
+func (m *mockTemplateRepository) Search(ctx context.Context, filter models.TemplateFilter) ([]*models.Template, int64, error) {
+	m.filter = filter
+	return nil, 0, nil
+}
+
+func (m *mockTemplateRepository) UpdateEstimatedCost(ctx context.Context, id uuid.UUID, costMin, costMax float64) error {
+	m.templates[id].EstimatedCostMin, m.templates[id].EstimatedCostMax = costMin, costMax
+	return nil
+}
+
+func (m *mockTemplateRepository) IncrementDownloads(ctx context.Context, id uuid.UUID) error {
+	m.templates[id].Downloads++
+	return nil
+}
+
+// mockCategoryRepository finds categories by slug
+type mockCategoryRepository map[string]*models.Category
+
+func (m mockCategoryRepository) FindBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	category, ok := m[slug]
+	if !ok {
+		return nil, platformerrors.HandleGormError(gorm.ErrRecordNotFound, "category", "mockCategoryRepository.FindBySlug")
+	}
+	return category, nil
+}
+
+// mockMarketplacePricing prices every architecture with the same purchase option comparison
+type mockMarketplacePricing struct {
+	serverinterfaces.PricingService
+	comparison *serverinterfaces.PurchaseOptionComparison
+}
+
+func (m *mockMarketplacePricing) CalculateArchitectureCost(ctx context.Context, arch *architecture.Architecture, duration time.Duration) (*serverinterfaces.ArchitectureCostEstimate, error) {
+	return &serverinterfaces.ArchitectureCostEstimate{TotalCost: m.comparison.OnDemandCost}, nil
+}
+
+func (m *mockMarketplacePricing) ComparePurchaseOptions(ctx context.Context, arch *architecture.Architecture, duration time.Duration) (*serverinterfaces.PurchaseOptionComparison, error) {
+	return m.comparison, nil
+}
+
+// priceRange returns a comparison costing onDemand on-demand and cheapest under a reserved option
+func priceRange(cheapest, onDemand float64) *serverinterfaces.PurchaseOptionComparison {
+	return &serverinterfaces.PurchaseOptionComparison{
+		OnDemandCost: onDemand,
+		Scenarios: []serverinterfaces.PurchaseOptionScenario{
+			{PurchaseOption: "on_demand", TotalCost: onDemand},
+			{PurchaseOption: "reserved_1yr_all_upfront", TotalCost: cheapest},
+		},
+	}
+}
+
+// marketplaceFixture builds a marketplace service over a snapshot store
+type marketplaceFixture struct {
+	store          *snapshotStore
+	projects       *ProjectServiceImpl
+	pricing        *mockMarketplacePricing
+	templates      *mockTemplateRepository
+	instantiations *mockTemplateInstantiationRepository
+	category       *models.Category
+	svc            serverinterfaces.MarketplaceService
+}
+
+func newMarketplaceFixture() *marketplaceFixture {
+	f := &marketplaceFixture{
+		store:          newSnapshotStore(),
+		pricing:        &mockMarketplacePricing{comparison: priceRange(80, 120)},
+		templates:      &mockTemplateRepository{templates: map[uuid.UUID]*models.Template{}},
+		instantiations: &mockTemplateInstantiationRepository{},
+		category:       &models.Category{ID: uuid.New(), Name: "Networking", Slug: "networking"},
+	}
+	f.projects = f.store.projectService()
+	categories := mockCategoryRepository{f.category.Slug: f.category}
+	f.svc = NewMarketplaceService(f.projects, f.pricing, f.templates, categories, nil, nil, nil, f.instantiations, slog.Default())
+	return f
+}
+
+// publishDatabase publishes a VPC configured with a sensitive password variable
+func (f *marketplaceFixture) publishDatabase(t *testing.T, ctx context.Context) (*models.Project, *models.Template) {
+	t.Helper()
+	req := vpcVersion("initial", [2]string{"vpc-a", "main"})
+	req.Nodes[0].Data.Config["password"] = "hunter2"
+	req.Nodes[0].Data.Config["_varRefs"] = map[string]interface{}{"password": "var.db_password"}
+	req.Variables = []dto.ArchitectureVariable{
+		{Name: "db_password", Type: "string", Value: "hunter2", Sensitive: true},
+		{Name: "env", Type: "string", Value: "prod"},
+	}
+	project, version, err := f.store.createProject(ctx, f.projects, req)
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	template, err := f.svc.Publish(ctx, project.UserID, &serverinterfaces.PublishTemplateRequest{
+		ProjectID: project.ID,
+		VersionID: version.ID,
+		Title:     "Private network",
+		Category:  f.category.Slug,
+	})
+	if err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	return project, template
+}
+
+func vpcPassword(state *dto.ArchitectureResponse) interface{} {
+	for _, node := range state.Nodes {
+		if node.Data.ResourceType == "VPC" {
+			return node.Data.Config["password"]
+		}
+	}
+	return nil
+}
+
+func TestMarketplaceService_PublishStripsSensitiveValues(t *testing.T) {
+	ctx := context.Background()
+	f := newMarketplaceFixture()
+	project, template := f.publishDatabase(t, ctx)
+
+	if strings.Contains(string(template.Architecture), "hunter2") {
+		t.Errorf("published architecture holds the sensitive value: %s", template.Architecture)
+	}
+	if strings.Contains(string(template.Parameters), "hunter2") {
+		t.Errorf("published parameters hold the sensitive value: %s", template.Parameters)
+	}
+	state, err := template.UnmarshalArchitecture()
+	if err != nil {
+		t.Fatalf("UnmarshalArchitecture error: %v", err)
+	}
+	if got := vpcPassword(state); got != "var.db_password" {
+		t.Errorf("published VPC password = %v, want the variable reference", got)
+	}
+	for _, v := range state.Variables {
+		if v.Name == "env" && v.Value != "prod" {
+			t.Errorf("published env = %v, want the non-sensitive value kept", v.Value)
+		}
+	}
+	if template.AuthorID != project.UserID || template.CategoryID != f.category.ID || template.CloudProvider != "AWS" {
+		t.Errorf("Publish = author %s, category %s, provider %s", template.AuthorID, template.CategoryID, template.CloudProvider)
+	}
+	if template.EstimatedCostMin != 80 || template.EstimatedCostMax != 120 {
+		t.Errorf("estimate = %v-%v, want 80-120", template.EstimatedCostMin, template.EstimatedCostMax)
+	}
+}
+
+func TestMarketplaceService_Search(t *testing.T) {
+	ctx := context.Background()
+	low, high := 50.0, 10.0
+
+	tests := []struct {
+		name      string
+		filter    models.TemplateFilter
+		wantSort  string
+		wantPage  int
+		wantLimit int
+		wantErr   bool
+	}{
+		{"defaults", models.TemplateFilter{}, "popular", 1, 20, false},
+		{"negative page", models.TemplateFilter{Sort: "cost", Page: -3, Limit: 5}, "cost", 1, 5, false},
+		{"limit capped", models.TemplateFilter{Sort: "newest", Page: 2, Limit: 500}, "newest", 2, 100, false},
+		{"unknown sort", models.TemplateFilter{Sort: "cheapest"}, "", 0, 0, true},
+		{"inverted cost range", models.TemplateFilter{MinCost: &low, MaxCost: &high}, "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMarketplaceFixture()
+			_, _, err := f.svc.Search(ctx, tt.filter)
+			if tt.wantErr {
+				if !apperrors.IsKind(err, apperrors.KindValidation) {
+					t.Errorf("Search: err = %v, want validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Search error: %v", err)
+			}
+			got := f.templates.filter
+			if got.Sort != tt.wantSort || got.Page != tt.wantPage || got.Limit != tt.wantLimit {
+				t.Errorf("searched with sort %q, page %d, limit %d, want %q, %d, %d", got.Sort, got.Page, got.Limit, tt.wantSort, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestMarketplaceService_Instantiate(t *testing.T) {
+	ctx := context.Background()
+	f := newMarketplaceFixture()
+	_, template := f.publishDatabase(t, ctx)
+	userID := uuid.New()
+
+	req := &serverinterfaces.InstantiateTemplateRequest{Name: "my network"}
+	if _, err := f.svc.Instantiate(ctx, userID, template.ID, req); !apperrors.IsKind(err, apperrors.KindValidation) {
+		t.Fatalf("Instantiate without the sensitive value: err = %v, want validation", err)
+	}
+
+	// The chosen values price the project differently from the template's defaults
+	f.pricing.comparison = priceRange(60, 90)
+	req.Parameters = map[string]interface{}{"db_password": "s3cret"}
+	instance, err := f.svc.Instantiate(ctx, userID, template.ID, req)
+	if err != nil {
+		t.Fatalf("Instantiate error: %v", err)
+	}
+
+	project := f.store.projects[instance.ProjectID]
+	if project == nil || project.UserID != userID || project.Name != "my network" || project.CloudProvider != "aws" {
+		t.Fatalf("instantiated project = %+v", project)
+	}
+	if instance.Version == nil || instance.Version.ProjectID == project.ID || f.store.version(instance.Version.ID) == nil {
+		t.Fatalf("instantiated version = %+v, want a snapshot of the new project", instance.Version)
+	}
+	if got := vpcPassword(instance.Version.State); got != "s3cret" {
+		t.Errorf("instantiated VPC password = %v, want the chosen value", got)
+	}
+	if _, ok := instance.Parameters["db_password"]; ok || instance.Parameters["env"] != "prod" {
+		t.Errorf("instance parameters = %v, want env without db_password", instance.Parameters)
+	}
+
+	if instance.EstimatedCostMin != 60 || instance.EstimatedCostMax != 90 {
+		t.Errorf("instance estimate = %v-%v, want 60-90", instance.EstimatedCostMin, instance.EstimatedCostMax)
+	}
+	if template.EstimatedCostMin != 80 || template.EstimatedCostMax != 120 {
+		t.Errorf("template estimate = %v-%v, want the published 80-120", template.EstimatedCostMin, template.EstimatedCostMax)
+	}
+	if template.Downloads != 1 {
+		t.Errorf("Downloads = %d, want 1", template.Downloads)
+	}
+	if len(f.instantiations.instantiations) != 1 || f.instantiations.instantiations[0].ProjectID != project.ID || f.instantiations.instantiations[0].UserID != userID {
+		t.Errorf("instantiations = %+v, want the new project", f.instantiations.instantiations)
+	}
+}
+
+// mockFailingInstantiationRepository cannot record instantiations
+type mockFailingInstantiationRepository struct {
+	mockTemplateInstantiationRepository
+}
+
+func (m *mockFailingInstantiationRepository) Create(ctx context.Context, instantiation *models.TemplateInstantiation) error {
+	return errors.New("connection reset")
+}
+
+func TestMarketplaceService_InstantiateFailsWithoutInstantiationRecord(t *testing.T) {
+	ctx := context.Background()
+	f := newMarketplaceFixture()
+	_, template := f.publishDatabase(t, ctx)
+	svc := NewMarketplaceService(f.projects, f.pricing, f.templates, nil, nil, nil, nil, &mockFailingInstantiationRepository{}, slog.Default())
+
+	projects, versions := len(f.store.projects), len(f.store.versions)
+	req := &serverinterfaces.InstantiateTemplateRequest{Name: "my network", Parameters: map[string]interface{}{"db_password": "s3cret"}}
+	if _, err := svc.Instantiate(ctx, uuid.New(), template.ID, req); err == nil {
+		t.Fatal("Instantiate without an instantiation record: expected error")
+	}
+	// Neither the project nor its first version and snapshot are left behind
+	for _, project := range f.store.projects {
+		if project.Name == "my network" {
+			t.Errorf("Instantiate left project %s (root %v) behind", project.ID, project.RootProjectID)
+		}
+	}
+	if len(f.store.projects) != projects || len(f.store.versions) != versions {
+		t.Errorf("Instantiate left %d projects and %d versions behind", len(f.store.projects)-projects, len(f.store.versions)-versions)
+	}
+	if template.Downloads != 0 {
+		t.Errorf("Downloads = %d, want the failed instantiation not counted", template.Downloads)
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// NetworkServiceImpl implements NetworkService interface
+type NetworkServiceImpl struct {
+	projectService serverinterfaces.ProjectService
+}
+
+// NewNetworkService creates a new network analysis service
+func NewNetworkService(projectService serverinterfaces.ProjectService) serverinterfaces.NetworkService {
+	return &NetworkServiceImpl{projectService: projectService}
+}
+
+// AnalyzeReachability loads the version's architecture and reports its reachability
+// matrix and exposure findings
+func (s *NetworkServiceImpl) AnalyzeReachability(ctx context.Context, projectID, versionID uuid.UUID) (*serverinterfaces.ReachabilityReport, error) {
+	version, err := s.projectService.GetVersionByID(ctx, projectID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := s.projectService.LoadArchitecture(ctx, version.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("load architecture: %w", err)
+	}
+	result := architecture.AnalyzeReachability(arch)
+
+	report := &serverinterfaces.ReachabilityReport{
+		VersionID: versionID,
+		Resources: make([]serverinterfaces.ReachabilityResource, 0, len(result.Endpoints)),
+		Matrix:    make(map[string]map[string][]string, len(result.Matrix)),
+		Findings:  make([]serverinterfaces.ReachabilityFinding, 0, len(result.Findings)),
+	}
+	for _, ep := range result.Endpoints {
+		sgs := make([]string, len(ep.SecurityGroups))
+		for i, sg := range ep.SecurityGroups {
+			sgs[i] = sg.ID
+		}
+		report.Resources = append(report.Resources, serverinterfaces.ReachabilityResource{
+			ID:             ep.Resource.ID,
+			Name:           ep.Resource.Name,
+			Type:           ep.Resource.Type.Name,
+			SecurityGroups: sgs,
+			Public:         ep.Public,
+			InternetEgress: ep.InternetEgress,
+		})
+	}
+	for source, targets := range result.Matrix {
+		row := make(map[string][]string, len(targets))
+		for target, ports := range targets {
+			row[target] = portStrings(ports)
+		}
+		report.Matrix[source] = row
+	}
+	for _, f := range result.Findings {
+		report.Findings = append(report.Findings, serverinterfaces.ReachabilityFinding{
+			Severity:     f.Severity,
+			Code:         f.Code,
+			ResourceID:   f.Resource.ID,
+			ResourceName: f.Resource.Name,
+			ResourceType: f.Resource.Type.Name,
+			Ports:        portStrings(f.Ports),
+			Message:      f.Message,
+		})
+	}
+	return report, nil
+}
+
+func portStrings(ports []architecture.PortRange) []string {
+	out := make([]string, len(ports))
+	for i, p := range ports {
+		out[i] = p.String()
+	}
+	return out
+}
@@ -2,119 +2,56 @@ package services
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	awspricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing/optimization"
+	pricingrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/pricing"
+	resourcerepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/resource"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 )
 
+// OptimizationServiceImpl prices cost optimizations with the same rates as cost estimates
 type OptimizationServiceImpl struct {
+	rates optimization.Rates
 }
 
+// NewOptimizationService creates an optimization service using the static AWS rate tables
 func NewOptimizationService() serverinterfaces.OptimizationService {
-	return &OptimizationServiceImpl{}
+	awsCalculator := awspricing.NewAWSPricingCalculator(awspricing.NewAWSPricingService())
+	return &OptimizationServiceImpl{rates: awsCalculator}
 }
 
-func (s *OptimizationServiceImpl) OptimizeArchitecture(ctx context.Context, arch *architecture.Architecture) (*serverinterfaces.OptimizationWithSavings, error) {
-	suggestions := []serverinterfaces.OptimizationSuggestion{}
-	totalSavings := 0.0
-
-	// 1. Check for older generation instances (e.g., t2 vs t3/t4g)
-	for _, res := range arch.Resources {
-		if res.Type.Name == "EC2" {
-			if instanceType, ok := res.Metadata["instance_type"].(string); ok {
-				if strings.HasPrefix(instanceType, "t2.") {
-					// t3 is generally cheaper and better performance
-					suggestion := serverinterfaces.OptimizationSuggestion{
-						ID:               fmt.Sprintf("opt-upgrade-%s", res.ID),
-						Severity:         "medium",
-						Title:            "Upgrade to next generation instance",
-						Description:      fmt.Sprintf("Resource '%s' uses %s. Consider upgrading to t3.%s for better price/performance.", res.Name, instanceType, strings.TrimPrefix(instanceType, "t2.")),
-						EstimatedSavings: 0.0, // Needs pricing calculation difference, defaulting to 0 or mock value for now
-						ResourceType:     "EC2",
-						ResourceID:       res.ID,
-					}
-					// Estimate 20% savings roughly
-					suggestion.EstimatedSavings = 5.0 // Mock savings
-					suggestions = append(suggestions, suggestion)
-					totalSavings += suggestion.EstimatedSavings
-				}
-			}
-		}
-	}
-
-	// 2. Check for potential Unattached Elastic IPs (if we can infer from graph)
-	// In the architecture graph, if an EIP node exists but has no dependencies or is not depended upon by an instance, it MIGHT be unattached.
-	// But EIPs are often "depended on" by NAT Gateways or "attached" to instances via ID references in config.
-	for _, res := range arch.Resources {
-		if res.Type.Name == "ElasticIP" {
-			// Check if it's used by anything
-			isUsed := false
-
-			// Check incoming dependencies (something implies they need this IP)
-			// arch.Dependencies maps resourceID -> []dependencyIDs (outgoing)
-			// We need checked who depends on us.
-			for _, deps := range arch.Dependencies {
-				for _, depID := range deps {
-					if depID == res.ID {
-						isUsed = true
-						break
-					}
-				}
-				if isUsed {
-					break
-				}
-			}
-
-			if !isUsed {
-				// Also check if it depends on an instance (attachment)
-				if len(arch.Dependencies[res.ID]) > 0 {
-					isUsed = true
-				}
-			}
-
-			if !isUsed {
-				suggestion := serverinterfaces.OptimizationSuggestion{
-					ID:               fmt.Sprintf("opt-eip-unattached-%s", res.ID),
-					Severity:         "high",
-					Title:            "Potential Unattached Elastic IP",
-					Description:      fmt.Sprintf("Elastic IP '%s' appears to be unattached. Unattached EIPs incur hourly charges.", res.Name),
-					EstimatedSavings: 3.60, // $0.005 * 720 hours
-					ResourceType:     "ElasticIP",
-					ResourceID:       res.ID,
-				}
-				suggestions = append(suggestions, suggestion)
-				totalSavings += suggestion.EstimatedSavings
-			}
-		}
-	}
+// NewOptimizationServiceWithRepos creates an optimization service using the imported
+// pricing rates, falling back to the static rate tables
+func NewOptimizationServiceWithRepos(
+	pricingRateRepo *pricingrepo.PricingRateRepository,
+	hiddenDepRepo *resourcerepo.HiddenDependencyRepository,
+) serverinterfaces.OptimizationService {
+	awsPricingService := awspricing.NewAWSPricingServiceWithRepos(pricingRateRepo, hiddenDepRepo)
+	return &OptimizationServiceImpl{rates: awsPricingService.GetCalculator()}
+}
 
-	// 3. Generic Savings Plan / Reserved Instances suggestion
-	// If there are EC2 instances running 24/7 (which we assume for architecture diagrams)
-	hasEC2 := false
-	for _, res := range arch.Resources {
-		if res.Type.Name == "EC2" {
-			hasEC2 = true
-			break
-		}
+func (s *OptimizationServiceImpl) OptimizeArchitecture(ctx context.Context, arch *architecture.Architecture) (*serverinterfaces.OptimizationWithSavings, error) {
+	result := &serverinterfaces.OptimizationWithSavings{
+		Suggestions: []serverinterfaces.OptimizationSuggestion{},
+		Currency:    "USD",
+		Period:      "monthly",
 	}
-
-	if hasEC2 {
-		suggestions = append(suggestions, serverinterfaces.OptimizationSuggestion{
-			ID:               "opt-ri-savings",
-			Severity:         "high",
-			Title:            "Use Reserved Instances or Savings Plans",
-			Description:      "For steady-state workloads, purchasing Reserved Instances or Savings Plans can reduce costs by up to 72% compared to On-Demand prices.",
-			EstimatedSavings: 50.0, // Mock value
-			ResourceType:     "EC2",
+	for _, s := range optimization.Analyze(ctx, arch, s.rates) {
+		result.Suggestions = append(result.Suggestions, serverinterfaces.OptimizationSuggestion{
+			ID:                   s.ID,
+			Rule:                 s.Rule,
+			Severity:             s.Severity,
+			Title:                s.Title,
+			Description:          s.Description,
+			EstimatedSavings:     s.MonthlySavings,
+			CurrentMonthlyCost:   s.CurrentMonthlyCost,
+			OptimizedMonthlyCost: s.OptimizedMonthlyCost,
+			ResourceType:         s.ResourceType,
+			ResourceID:           s.ResourceID,
 		})
-		totalSavings += 50.0
+		result.TotalPotentialSavings += s.MonthlySavings
 	}
-
-	return &serverinterfaces.OptimizationWithSavings{
-		Suggestions:           suggestions,
-		TotalPotentialSavings: totalSavings,
-		Currency:              "USD",
-	}, nil
+	return result, nil
 }
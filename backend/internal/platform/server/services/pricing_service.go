@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/inventory"
 	awspricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	pricingrepo "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/repository/pricing"
@@ -15,6 +18,7 @@ import (
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"gorm.io/datatypes"
 )
 
 // PricingServiceImpl implements PricingService interface
@@ -60,6 +64,10 @@ func NewPricingServiceWithRepos(
 
 // CalculateResourceCost calculates the cost for a single resource over a given duration
 func (s *PricingServiceImpl) CalculateResourceCost(ctx context.Context, res *resource.Resource, duration time.Duration) (*domainpricing.CostEstimate, error) {
+	return s.calculateResourceCost(ctx, res, duration, domainpricing.OnDemand)
+}
+
+func (s *PricingServiceImpl) calculateResourceCost(ctx context.Context, res *resource.Resource, duration time.Duration, option domainpricing.PurchaseOption) (*domainpricing.CostEstimate, error) {
 	if res == nil {
 		return nil, fmt.Errorf("resource is nil")
 	}
@@ -70,7 +78,7 @@ func (s *PricingServiceImpl) CalculateResourceCost(ctx context.Context, res *res
 	// Route to the appropriate calculator based on provider
 	switch mappedRes.Provider {
 	case resource.AWS:
-		return s.awsCalculator.CalculateResourceCost(ctx, mappedRes, duration)
+		return s.awsCalculator.CalculateResourceCostWithPurchaseOption(ctx, mappedRes, duration, option)
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", mappedRes.Provider)
 	}
@@ -108,27 +116,85 @@ func mapResourceTypeForPricing(res *resource.Resource) *resource.Resource {
 
 // CalculateArchitectureCost calculates the total cost for an architecture over a given duration
 func (s *PricingServiceImpl) CalculateArchitectureCost(ctx context.Context, arch *architecture.Architecture, duration time.Duration) (*serverinterfaces.ArchitectureCostEstimate, error) {
+	return s.calculateArchitectureCost(ctx, arch, duration, domainpricing.OnDemand, true)
+}
+
+// CalculateArchitectureCostWithPurchaseOption calculates the total cost for an architecture with
+// EC2 instances paid under the purchase option
+func (s *PricingServiceImpl) CalculateArchitectureCostWithPurchaseOption(ctx context.Context, arch *architecture.Architecture, duration time.Duration, option domainpricing.PurchaseOption) (*serverinterfaces.ArchitectureCostEstimate, error) {
+	return s.calculateArchitectureCost(ctx, arch, duration, option, true)
+}
+
+// ComparePurchaseOptions calculates the total cost for an architecture under every purchase option
+func (s *PricingServiceImpl) ComparePurchaseOptions(ctx context.Context, arch *architecture.Architecture, duration time.Duration) (*serverinterfaces.PurchaseOptionComparison, error) {
+	comparison := &serverinterfaces.PurchaseOptionComparison{Currency: "USD"}
+	for _, option := range domainpricing.PurchaseOptions {
+		estimate, err := s.calculateArchitectureCost(ctx, arch, duration, option, false)
+		if err != nil {
+			return nil, err
+		}
+		if option == domainpricing.OnDemand {
+			comparison.OnDemandCost = estimate.TotalCost
+			comparison.Period = estimate.Period
+		}
+
+		scenario := serverinterfaces.PurchaseOptionScenario{
+			PurchaseOption: string(option),
+			Name:           option.DisplayName(),
+			Model:          string(option.Model()),
+			Term:           option.Term(),
+			PaymentOption:  option.PaymentOption(),
+			TotalCost:      estimate.TotalCost,
+			Savings:        comparison.OnDemandCost - estimate.TotalCost,
+		}
+		for _, re := range estimate.ResourceEstimates {
+			if re.ResourceType == "EC2" {
+				scenario.ComputeCost += re.TotalCost
+			}
+		}
+		if comparison.OnDemandCost > 0 {
+			scenario.SavingsPercent = scenario.Savings / comparison.OnDemandCost * 100
+		}
+		comparison.Scenarios = append(comparison.Scenarios, scenario)
+	}
+	return comparison, nil
+}
+
+// calculateArchitectureCost calculates the total cost for an architecture, logging each resource when verbose
+func (s *PricingServiceImpl) calculateArchitectureCost(ctx context.Context, arch *architecture.Architecture, duration time.Duration, option domainpricing.PurchaseOption, verbose bool) (*serverinterfaces.ArchitectureCostEstimate, error) {
 	if arch == nil {
 		return nil, fmt.Errorf("architecture is nil")
 	}
+	logf := func(format string, args ...interface{}) {
+		if verbose {
+			fmt.Printf(format, args...)
+		}
+	}
 
 	resourceEstimates := make(map[string]*serverinterfaces.ResourceCostEstimate)
 	var totalCost float64
+	var warnings []string
 
-	fmt.Println("\n💵 Calculating pricing for resources...")
-	fmt.Println(strings.Repeat("-", 100))
+	logf("\n💵 Calculating pricing for resources...\n")
+	logf("%s\n", strings.Repeat("-", 100))
 
-	for _, res := range arch.Resources {
+	// The usage profile sets request counts, traffic and uptime, and adds the traffic along edges
+	for _, res := range domainpricing.ApplyUsageProfile(arch.Resources, arch.Usage, duration) {
 		// Skip visual-only resources (they don't have pricing)
 		if isVisualOnly, ok := res.Metadata["isVisualOnly"].(bool); ok && isVisualOnly {
 			continue
 		}
 
-		estimate, err := s.CalculateResourceCost(ctx, res, duration)
+		estimate, err := s.calculateResourceCost(ctx, res, duration, option)
+		var regionErr *awspricing.RegionPricingError
+		if errors.As(err, &regionErr) {
+			// Resources without pricing data for the region are reported rather than priced at another region's rates
+			warnings = append(warnings, fmt.Sprintf("%s (%s): %v", res.Name, res.Type.Name, err))
+		}
 		if err != nil {
 			// Log error but continue with other resources
 			// Some resources may not have pricing (e.g., VPC, subnets)
-			fmt.Printf("  ⚠️  %s (%s): Pricing calculation skipped - %v\n", res.Name, res.Type.Name, err)
+			logf("  ⚠️  %s (%s): Pricing calculation skipped - %v\n", res.Name, res.Type.Name, err)
 			continue
 		}
 
@@ -144,11 +210,11 @@ func (s *PricingServiceImpl) CalculateArchitectureCost(ctx context.Context, arch
 			}
 		}
 
-		fmt.Printf("  ✓ %s (%s): $%.2f", res.Name, res.Type.Name, estimate.TotalCost)
+		logf("  ✓ %s (%s): $%.2f", res.Name, res.Type.Name, estimate.TotalCost)
 		if hiddenCost > 0 {
-			fmt.Printf(" (Base: $%.2f + Hidden: $%.2f)", baseCost, hiddenCost)
+			logf(" (Base: $%.2f + Hidden: $%.2f)", baseCost, hiddenCost)
 		}
-		fmt.Println()
+		logf("\n")
 
 		// Convert domain estimate to resource cost estimate
 		breakdown := make([]serverinterfaces.CostBreakdownComponent, len(estimate.Breakdown))
@@ -181,6 +247,7 @@ func (s *PricingServiceImpl) CalculateArchitectureCost(ctx context.Context, arch
 			ResourceID:   res.ID,
 			ResourceName: res.Name,
 			ResourceType: res.Type.Name,
+			Category:     resourceCategory(res),
 			TotalCost:    estimate.TotalCost,
 			Currency:     string(estimate.Currency),
 			Breakdown:    breakdown,
@@ -189,9 +256,8 @@ func (s *PricingServiceImpl) CalculateArchitectureCost(ctx context.Context, arch
 		totalCost += estimate.TotalCost
 	}
 
-	fmt.Println(strings.Repeat("-", 100))
-	fmt.Printf("  💰 Total Architecture Cost: $%.2f\n", totalCost)
-	fmt.Println()
+	logf("%s\n", strings.Repeat("-", 100))
+	logf("  💰 Total Architecture Cost: $%.2f\n\n", totalCost)
 
 	// Determine period based on duration
 	var period string
@@ -211,6 +277,8 @@ func (s *PricingServiceImpl) CalculateArchitectureCost(ctx context.Context, arch
 		ResourceEstimates: resourceEstimates,
 		Provider:          string(arch.Provider),
 		Region:            arch.Region,
+		PurchaseOption:    string(option),
+		Warnings:          warnings,
 	}, nil
 }
 
@@ -279,11 +347,20 @@ func (s *PricingServiceImpl) PersistResourcePricing(ctx context.Context, project
 }
 
 // PersistProjectPricing saves project-level pricing to the database
-func (s *PricingServiceImpl) PersistProjectPricing(ctx context.Context, projectID uuid.UUID, estimate *domainpricing.CostEstimate, provider, region string) error {
+func (s *PricingServiceImpl) PersistProjectPricing(ctx context.Context, projectID uuid.UUID, estimate *domainpricing.CostEstimate, provider, region string, budget *domainpricing.BudgetStatus) error {
 	if estimate == nil {
 		return fmt.Errorf("estimate is nil")
 	}
 
+	var budgetStatus datatypes.JSON
+	if budget != nil {
+		raw, err := json.Marshal(budget)
+		if err != nil {
+			return fmt.Errorf("failed to encode budget status: %w", err)
+		}
+		budgetStatus = datatypes.JSON(raw)
+	}
+
 	projectPricing := &models.ProjectPricing{
 		ProjectID:       projectID,
 		TotalCost:       estimate.TotalCost,
@@ -292,6 +369,7 @@ func (s *PricingServiceImpl) PersistProjectPricing(ctx context.Context, projectI
 		DurationSeconds: int64(estimate.Duration.Seconds()),
 		Provider:        provider,
 		Region:          &region,
+		BudgetStatus:    budgetStatus,
 		CalculatedAt:    estimate.CalculatedAt,
 	}
 
@@ -312,6 +390,23 @@ func (s *PricingServiceImpl) GetResourcePricing(ctx context.Context, resourceID
 	return s.pricingRepo.FindResourcePricingByResourceID(ctx, resourceID)
 }
 
+// resourceCategory returns the category of a priced resource, looking it up in the provider
+// inventory when the resource carries none. Edge traffic is networking.
+func resourceCategory(res *resource.Resource) string {
+	if res.Type.Category != "" {
+		return res.Type.Category
+	}
+	if res.Type.Name == domainpricing.DataTransferResourceType {
+		return resource.CategoryNetworking
+	}
+	if res.Provider == resource.AWS {
+		if classification, ok := inventory.GetDefaultInventory().GetResourceClassification(res.Type.Name); ok {
+			return classification.Category
+		}
+	}
+	return ""
+}
+
 // getUnitFromModel returns the unit string based on the pricing model
 func getUnitFromModel(model domainpricing.PricingModel) string {
 	switch model {
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	awspricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// forecastComponent is a cost component of the first month of a forecast
+type forecastComponent struct {
+	service  string
+	name     string
+	driver   domainpricing.CostDriver
+	quantity float64
+	unitRate float64
+	subtotal float64
+	freeTier *awspricing.FreeTierAllowance
+}
+
+// ForecastArchitectureCost projects the monthly cost of an architecture. The architecture is
+// priced for one month, then each cost component grows with its driver (instances, storage or
+// traffic). While the account has free tier months left, eligible components are credited up
+// to the monthly allowance, shared by all resources.
+func (s *PricingServiceImpl) ForecastArchitectureCost(ctx context.Context, arch *architecture.Architecture, req *serverinterfaces.CostForecastRequest) (*serverinterfaces.CostForecast, error) {
+	if arch == nil {
+		return nil, fmt.Errorf("architecture is nil")
+	}
+	months, start, option, err := parseForecastRequest(req)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInvalidValue, apperrors.KindValidation, "invalid forecast request")
+	}
+
+	estimate, err := s.calculateArchitectureCost(ctx, arch, 720*time.Hour, option, false)
+	if err != nil {
+		return nil, err
+	}
+	components := forecastComponents(arch, estimate)
+
+	forecast := &serverinterfaces.CostForecast{
+		Currency:       estimate.Currency,
+		Months:         months,
+		PurchaseOption: string(option),
+		Services:       make([]string, 0),
+		Series:         make([]serverinterfaces.ForecastMonth, 0, months),
+		Warnings:       estimate.Warnings,
+	}
+	seen := make(map[string]bool)
+	for _, c := range components {
+		if !seen[c.service] {
+			seen[c.service] = true
+			forecast.Services = append(forecast.Services, c.service)
+		}
+	}
+	sort.Strings(forecast.Services)
+
+	for m := 0; m < months; m++ {
+		month := serverinterfaces.ForecastMonth{
+			Month:    start.AddDate(0, m, 0).Format("2006-01"),
+			Services: make(map[string]float64, len(forecast.Services)),
+		}
+		// Allowances left this month, by component
+		allowances := make(map[string]float64)
+		for _, c := range components {
+			if c.freeTier != nil && m < req.FreeTierMonths && m < c.freeTier.ExpiresAfterMonths {
+				allowances[c.name] = c.freeTier.Amount
+			}
+		}
+
+		for _, c := range components {
+			factor := req.Growth.Factor(c.driver, m)
+			cost := c.subtotal * factor
+			if left := allowances[c.name]; left > 0 && c.freeTier != nil {
+				free := math.Min(c.quantity*factor, left)
+				allowances[c.name] = left - free
+				credit := math.Min(free*c.unitRate, cost)
+				cost -= credit
+				month.FreeTierCredit += credit
+			}
+			month.Services[c.service] += cost
+			month.TotalCost += cost
+		}
+
+		forecast.TotalCost += month.TotalCost
+		forecast.FreeTierSavings += month.FreeTierCredit
+		forecast.Series = append(forecast.Series, month)
+	}
+	return forecast, nil
+}
+
+// parseForecastRequest validates the request and returns its horizon, first month and purchase option
+func parseForecastRequest(req *serverinterfaces.CostForecastRequest) (int, time.Time, domainpricing.PurchaseOption, error) {
+	if req.Months == 0 {
+		req.Months = domainpricing.MinForecastMonths
+	}
+	if req.Months < domainpricing.MinForecastMonths || req.Months > domainpricing.MaxForecastMonths {
+		return 0, time.Time{}, "", fmt.Errorf("months must be between %d and %d, got %d", domainpricing.MinForecastMonths, domainpricing.MaxForecastMonths, req.Months)
+	}
+	if req.FreeTierMonths < 0 || req.FreeTierMonths > 12 {
+		return 0, time.Time{}, "", fmt.Errorf("free_tier_months must be between 0 and 12, got %d", req.FreeTierMonths)
+	}
+	if err := req.Growth.Validate(); err != nil {
+		return 0, time.Time{}, "", err
+	}
+	option, err := domainpricing.ParsePurchaseOption(req.PurchaseOption)
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if req.StartMonth != "" {
+		if start, err = time.Parse("2006-01", req.StartMonth); err != nil {
+			return 0, time.Time{}, "", fmt.Errorf("start_month must be YYYY-MM, got %q", req.StartMonth)
+		}
+	}
+	return req.Months, start, option, nil
+}
+
+// forecastComponents returns the cost components of a one month estimate, ordered by
+// resource ID, with their growth driver and free tier allowance
+func forecastComponents(arch *architecture.Architecture, estimate *serverinterfaces.ArchitectureCostEstimate) []forecastComponent {
+	resources := make(map[string]*resource.Resource, len(arch.Resources))
+	for _, res := range arch.Resources {
+		resources[res.ID] = res
+	}
+	ids := make([]string, 0, len(estimate.ResourceEstimates))
+	for id := range estimate.ResourceEstimates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var components []forecastComponent
+	for _, id := range ids {
+		resEstimate := estimate.ResourceEstimates[id]
+		res := resources[id]
+		for _, comp := range resEstimate.Breakdown {
+			c := forecastComponent{
+				service:  resEstimate.ResourceType,
+				name:     comp.ComponentName,
+				driver:   domainpricing.ComponentDriver(resEstimate.Category, comp.ComponentName, domainpricing.PricingModel(comp.Model)),
+				quantity: comp.Quantity,
+				unitRate: comp.UnitRate,
+				subtotal: comp.Subtotal,
+			}
+			if res != nil && res.Provider == resource.AWS {
+				c.freeTier, _ = awspricing.GetFreeTierOffer(res, comp.ComponentName)
+			}
+			components = append(components, c)
+		}
+	}
+	return components
+}
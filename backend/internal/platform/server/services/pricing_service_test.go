@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 )
 
@@ -188,6 +192,160 @@ func TestPricingService_CalculateArchitectureCost(t *testing.T) {
 	}
 }
 
+func TestPricingService_CalculateArchitectureCost_RegionWithoutRates(t *testing.T) {
+	service := NewPricingService(&mockPricingRepository{})
+	nat := func(id, region string) *resource.Resource {
+		return &resource.Resource{
+			ID:       id,
+			Name:     id,
+			Provider: resource.AWS,
+			Region:   region,
+			Type:     resource.ResourceType{Name: "NATGateway"},
+		}
+	}
+	arch := &architecture.Architecture{
+		Provider:  resource.AWS,
+		Region:    "eu-west-1",
+		Resources: []*resource.Resource{nat("nat-us", "us-east-1"), nat("nat-eu", "eu-west-1")},
+	}
+
+	estimate, err := service.CalculateArchitectureCost(context.Background(), arch, 720*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := estimate.ResourceEstimates["nat-eu"]; ok {
+		t.Error("expected the eu-west-1 NAT gateway to be left unpriced")
+	}
+	if _, ok := estimate.ResourceEstimates["nat-us"]; !ok {
+		t.Error("expected the us-east-1 NAT gateway to be priced")
+	}
+	if len(estimate.Warnings) != 1 {
+		t.Errorf("expected one warning, got %v", estimate.Warnings)
+	}
+}
+
+func TestPricingService_CalculateArchitectureCost_UsageProfile(t *testing.T) {
+	service := NewPricingService(&mockPricingRepository{})
+	arch := &architecture.Architecture{
+		Provider: resource.AWS,
+		Region:   "us-east-1",
+		Resources: []*resource.Resource{
+			{ID: "nat-1", Name: "nat", Provider: resource.AWS, Region: "us-east-1", Type: resource.ResourceType{Name: "NATGateway"}},
+		},
+	}
+
+	always, err := service.CalculateArchitectureCost(context.Background(), arch, 720*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	arch.Usage = &domainpricing.UsageProfile{
+		Schedule: &domainpricing.UptimeSchedule{Preset: domainpricing.BusinessHours},
+		Edges:    []domainpricing.EdgeTraffic{{Source: "nat", GBPerMonth: 11}},
+	}
+	estimate, err := service.CalculateArchitectureCost(context.Background(), arch, 720*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := estimate.ResourceEstimates["nat-1"].TotalCost, always.TotalCost*50/168; math.Abs(got-want) > 0.001 {
+		t.Errorf("expected business hours NAT gateway cost %.4f, got %.4f", want, got)
+	}
+	edge, ok := estimate.ResourceEstimates["edge:nat->"]
+	if !ok || math.Abs(edge.TotalCost-0.9) > 0.001 {
+		t.Errorf("expected the internet traffic of the NAT gateway to cost 0.90, got %+v", edge)
+	}
+	if ok && (edge.Category != resource.CategoryNetworking || estimate.ResourceEstimates["nat-1"].Category != resource.CategoryNetworking) {
+		t.Errorf("expected the NAT gateway and its traffic in the networking category, got %q and %q", estimate.ResourceEstimates["nat-1"].Category, edge.Category)
+	}
+}
+
+func TestPricingService_PersistProjectPricing_BudgetStatus(t *testing.T) {
+	var saved []*models.ProjectPricing
+	repo := &mockPricingRepository{
+		createProjectPricingFunc: func(ctx context.Context, pricing *models.ProjectPricing) error {
+			saved = append(saved, pricing)
+			return nil
+		},
+	}
+	service := NewPricingService(repo)
+	estimate := &domainpricing.CostEstimate{TotalCost: 120, Currency: domainpricing.USD, Period: domainpricing.Monthly, Duration: 720 * time.Hour}
+	status := (&domainpricing.Budget{MonthlyLimit: 100}).Evaluate(120, nil)
+
+	if err := service.PersistProjectPricing(context.Background(), uuid.New(), estimate, "aws", "us-east-1", status); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.PersistProjectPricing(context.Background(), uuid.New(), estimate, "aws", "us-east-1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("expected 2 project pricing records, got %d", len(saved))
+	}
+	var got domainpricing.BudgetStatus
+	if err := json.Unmarshal(saved[0].BudgetStatus, &got); err != nil || got.Status != domainpricing.OverBudget || len(got.Overages) != 1 {
+		t.Errorf("expected the budget status to be recorded, got %s (%v)", saved[0].BudgetStatus, err)
+	}
+	if saved[1].BudgetStatus != nil {
+		t.Errorf("expected no budget status without a budget, got %s", saved[1].BudgetStatus)
+	}
+}
+
+func TestPricingService_ForecastArchitectureCost(t *testing.T) {
+	service := NewPricingService(&mockPricingRepository{})
+	arch := &architecture.Architecture{
+		Provider: resource.AWS,
+		Region:   "us-east-1",
+		Resources: []*resource.Resource{
+			{ID: "web-1", Name: "web", Provider: resource.AWS, Region: "us-east-1", Type: resource.ResourceType{Name: "EC2"}, Metadata: map[string]interface{}{"instance_type": "t3.micro"}},
+			{ID: "nat-1", Name: "nat", Provider: resource.AWS, Region: "us-east-1", Type: resource.ResourceType{Name: "NATGateway"}},
+		},
+	}
+	month, err := service.CalculateArchitectureCost(context.Background(), arch, 720*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	web, nat := month.ResourceEstimates["web-1"].TotalCost, month.ResourceEstimates["nat-1"].TotalCost
+
+	forecast, err := service.ForecastArchitectureCost(context.Background(), arch, &serverinterfaces.CostForecastRequest{
+		StartMonth:     "2026-11",
+		Growth:         domainpricing.GrowthAssumptions{Instances: &domainpricing.Growth{Model: domainpricing.LinearGrowth, Rate: 10}},
+		FreeTierMonths: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if forecast.Months != 12 || len(forecast.Series) != 12 || forecast.Series[0].Month != "2026-11" || forecast.Series[11].Month != "2027-10" {
+		t.Fatalf("expected 12 months from 2026-11, got %d: %+v", len(forecast.Series), forecast.Series)
+	}
+	if len(forecast.Services) != 2 || forecast.Services[0] != "EC2" || forecast.Services[1] != "NATGateway" {
+		t.Errorf("unexpected services %v", forecast.Services)
+	}
+
+	// 720 instance hours are free in the first month; 792 - 750 hours are paid in the second
+	first, second, third := forecast.Series[0], forecast.Series[1], forecast.Series[2]
+	if first.Services["EC2"] != 0 || math.Abs(first.FreeTierCredit-web) > 1e-9 {
+		t.Errorf("expected the instance to be free in the first month, got %+v", first)
+	}
+	if want := web / 720 * 42; math.Abs(second.Services["EC2"]-want) > 1e-9 {
+		t.Errorf("expected %.4f for 42 paid hours in the second month, got %.4f", want, second.Services["EC2"])
+	}
+	if third.FreeTierCredit != 0 || math.Abs(third.Services["EC2"]-web*1.2) > 1e-9 || math.Abs(third.Services["NATGateway"]-nat) > 1e-9 {
+		t.Errorf("expected the free tier to expire and instances to grow, got %+v", third)
+	}
+
+	var total, credits float64
+	for _, m := range forecast.Series {
+		total += m.TotalCost
+		credits += m.FreeTierCredit
+	}
+	if math.Abs(forecast.TotalCost-total) > 1e-9 || math.Abs(forecast.FreeTierSavings-credits) > 1e-9 {
+		t.Errorf("expected totals to sum the series, got %.4f and %.4f", forecast.TotalCost, forecast.FreeTierSavings)
+	}
+
+	if _, err := service.ForecastArchitectureCost(context.Background(), arch, &serverinterfaces.CostForecastRequest{Months: 48}); err == nil {
+		t.Error("expected a 48 month horizon to be rejected")
+	}
+}
+
 func TestPricingService_GetProjectPricing(t *testing.T) {
 	ctx := context.Background()
 	projectID := uuid.New()
@@ -10,9 +10,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/graph"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
-	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -93,16 +93,23 @@ func (s *ProjectServiceImpl) Create(ctx context.Context, req *serverinterfaces.C
 
 // GetByID retrieves a project by ID with related data
 func (s *ProjectServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
-	return s.projectRepo.FindByID(ctx, id)
+	return s.findProject(ctx, id)
 }
 
-// List retrieves projects with pagination and filtering
+// List retrieves projects with pagination and filtering. Authenticated callers only see
+// their own projects, whatever userID is passed.
 func (s *ProjectServiceImpl) List(ctx context.Context, userID uuid.UUID, page, limit int, sort, order, search string) ([]*models.Project, int64, error) {
+	if callerID, ok := auth.UserIDFromContext(ctx); ok {
+		userID = callerID
+	}
 	return s.projectRepo.FindAll(ctx, userID, page, limit, sort, order, search)
 }
 
 // Delete deletes a project by ID
 func (s *ProjectServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findProject(ctx, id); err != nil {
+		return err
+	}
 	return s.projectRepo.Delete(ctx, id)
 }
 
@@ -359,6 +366,13 @@ func (s *ProjectServiceImpl) PersistArchitectureWithPricing(ctx context.Context,
 		fmt.Println("\n💵 Calculating pricing for resources...")
 		fmt.Println(strings.Repeat("-", 100))
 
+		// Price the snapshot for the workload saved with it
+		if arch.Usage == nil {
+			if project, err := s.findProject(ctx, projectID); err == nil {
+				arch.Usage, _ = project.UnmarshalUsageProfile()
+			}
+		}
+
 		archEstimate, err := s.pricingService.CalculateArchitectureCost(ctx, arch, pricingDuration)
 		if err != nil {
 			fmt.Printf("  ⚠️  Failed to calculate architecture cost: %v\n", err)
@@ -412,17 +426,11 @@ func (s *ProjectServiceImpl) PersistArchitectureWithPricing(ctx context.Context,
 				}
 			}
 
-			// Persist project-level pricing
-			projectEstimate := &domainpricing.CostEstimate{
-				TotalCost:    archEstimate.TotalCost,
-				Currency:     domainpricing.Currency(archEstimate.Currency),
-				Period:       domainpricing.Period(archEstimate.Period),
-				Duration:     archEstimate.Duration,
-				CalculatedAt: time.Now(),
-				Provider:     domainpricing.CloudProvider(archEstimate.Provider),
-				Region:       &archEstimate.Region,
+			// Persist project-level pricing, compared against the project budget
+			if project, err := s.findProject(ctx, projectID); err == nil {
+				_, _ = budgetStatus(project, archEstimate)
 			}
-			_ = s.pricingService.PersistProjectPricing(ctx, projectID, projectEstimate, archEstimate.Provider, archEstimate.Region)
+			_ = s.persistProjectEstimate(ctx, projectID, archEstimate)
 		}
 	}
 
@@ -441,7 +449,7 @@ func (s *ProjectServiceImpl) GetProjectPricing(ctx context.Context, projectID uu
 // Uses bulk queries for containments and dependencies to avoid N+1 DB round-trips.
 func (s *ProjectServiceImpl) LoadArchitecture(ctx context.Context, projectID uuid.UUID) (*architecture.Architecture, error) {
 	// Step 1: Load project
-	project, err := s.projectRepo.FindByID(ctx, projectID)
+	project, err := s.findProject(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load project: %w", err)
 	}
@@ -603,6 +611,9 @@ func (s *ProjectServiceImpl) LoadArchitecture(ctx context.Context, projectID uui
 		})
 	}
 
+	// Step 8: Usage profile (non-fatal — an unreadable profile prices 24/7 with no traffic)
+	usage, _ := project.UnmarshalUsageProfile()
+
 	return &architecture.Architecture{
 		Resources:    domainResources,
 		Region:       project.Region,
@@ -611,6 +622,7 @@ func (s *ProjectServiceImpl) LoadArchitecture(ctx context.Context, projectID uui
 		Dependencies: dependencies,
 		Variables:    domainVariables,
 		Outputs:      domainOutputs,
+		Usage:        usage,
 	}, nil
 }
 
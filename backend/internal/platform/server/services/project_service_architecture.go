@@ -10,6 +10,7 @@ import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/diagram/graph"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
 	"gorm.io/datatypes"
 )
@@ -19,6 +20,7 @@ type versionedOperationResult struct {
 	NewProjectID  uuid.UUID
 	VersionID     uuid.UUID
 	VersionNumber int
+	BudgetStatus  *pricing.BudgetStatus // nil when the project has no budget
 }
 
 // versionedArchitectureResult is returned by SaveArchitecture.
@@ -36,18 +38,21 @@ type cloneProjectSnapshotOptions struct {
 	applyArch *dto.UpdateArchitectureRequest
 	// message is the human-readable version description stored in project_versions.message.
 	message string
+	// mergeParentVersionID, if non-nil, is stored as the second parent of a merge version.
+	mergeParentVersionID *uuid.UUID
 }
 
 // cloneProjectSnapshot is the core immutable write path:
 //  1. Load source project + architecture.
 //  2. Create a brand-new project row (new UUID).
 //  3. Persist a fresh copy of all resources (new UUIDs) into the new project.
-//  4. Insert a project_versions row linking to the previous version.
+//  4. Compare the snapshot against the project budget, refusing it over a blocking budget.
+//  5. Insert a project_versions row linking to the previous version.
 //
 // It returns a VersionedOperationResult with the new project ID and version info.
 func (s *ProjectServiceImpl) cloneProjectSnapshot(ctx context.Context, opts cloneProjectSnapshotOptions) (*versionedOperationResult, *models.Project, error) {
 	// 1. Load source project
-	srcProject, err := s.projectRepo.FindByID(ctx, opts.sourceProjectID)
+	srcProject, err := s.findProject(ctx, opts.sourceProjectID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cloneProjectSnapshot: source project not found: %w", err)
 	}
@@ -93,6 +98,9 @@ func (s *ProjectServiceImpl) cloneProjectSnapshot(ctx context.Context, opts clon
 		CloudProvider: srcProject.CloudProvider,
 		Region:        srcProject.Region,
 		Thumbnail:     srcProject.Thumbnail,
+		BackendConfig: srcProject.BackendConfig,
+		UsageProfile:  usageProfileWithEdgeTraffic(srcProject.UsageProfile, archReq),
+		Budget:        srcProject.Budget,
 	}
 	if err := s.projectRepo.Create(ctx, newProject); err != nil {
 		return nil, nil, fmt.Errorf("cloneProjectSnapshot: create new project: %w", err)
@@ -110,26 +118,44 @@ func (s *ProjectServiceImpl) cloneProjectSnapshot(ctx context.Context, opts clon
 		return nil, nil, fmt.Errorf("cloneProjectSnapshot: persist architecture: %w", err)
 	}
 
-	// 6. Determine new version number
+	// 6. Compare the snapshot's monthly estimate against the project budget
+	budgetStatus, err := s.checkSnapshotBudget(ctx, newProject, arch)
+	if err != nil {
+		_ = s.projectRepo.Delete(ctx, newProject.ID)
+		return nil, nil, fmt.Errorf("cloneProjectSnapshot: %w", err)
+	}
+
+	// 7. Number the version after the highest in the lineage: versions created from an
+	// older version, and merges, must not reuse the number of a later one
+	lineage, err := s.versionRepo.ListByRootProjectID(ctx, *rootProjectID)
+	if err != nil {
+		_ = s.projectRepo.Delete(ctx, newProject.ID)
+		return nil, nil, fmt.Errorf("cloneProjectSnapshot: list versions: %w", err)
+	}
 	newVersionNumber := 1
+	for _, v := range lineage {
+		if v.VersionNumber >= newVersionNumber {
+			newVersionNumber = v.VersionNumber + 1
+		}
+	}
 	var parentVersionID *uuid.UUID
 	if parentVersion != nil {
-		newVersionNumber = parentVersion.VersionNumber + 1
 		parentVersionID = &parentVersion.ID
 	}
 
-	// 7. Insert project_versions chain entry
+	// 8. Insert project_versions chain entry
 	createdBy := opts.createdBy
 	if createdBy == uuid.Nil {
 		createdBy = srcProject.UserID
 	}
 	newVersion := &models.ProjectVersion{
-		ID:              uuid.New(),
-		ProjectID:       newProject.ID,
-		ParentVersionID: parentVersionID,
-		VersionNumber:   newVersionNumber,
-		Message:         opts.message,
-		CreatedBy:       createdBy,
+		ID:                   uuid.New(),
+		ProjectID:            newProject.ID,
+		ParentVersionID:      parentVersionID,
+		MergeParentVersionID: opts.mergeParentVersionID,
+		VersionNumber:        newVersionNumber,
+		Message:              opts.message,
+		CreatedBy:            createdBy,
 	}
 	if err := s.versionRepo.Create(ctx, newVersion); err != nil {
 		// Non-fatal: the project snapshot is valid even if the version record fails
@@ -140,6 +166,7 @@ func (s *ProjectServiceImpl) cloneProjectSnapshot(ctx context.Context, opts clon
 		NewProjectID:  newProject.ID,
 		VersionID:     newVersion.ID,
 		VersionNumber: newVersionNumber,
+		BudgetStatus:  budgetStatus,
 	}, newProject, nil
 }
 
@@ -233,6 +260,16 @@ func (s *ProjectServiceImpl) GetArchitecture(ctx context.Context, projectID uuid
 		return nil, fmt.Errorf("failed to load architecture: %w", err)
 	}
 
+	resp := architectureToResponse(arch)
+	versions, err := s.versionRepo.ListByProjectID(ctx, projectID)
+	if err == nil && len(versions) > 0 {
+		resp.VersionID = versions[0].ID.String()
+	}
+	return resp, nil
+}
+
+// architectureToResponse converts a domain architecture to the API representation.
+func architectureToResponse(arch *architecture.Architecture) *dto.ArchitectureResponse {
 	nodes := make([]dto.ArchitectureNode, len(arch.Resources))
 	for i, res := range arch.Resources {
 		posX, posY := 0.0, 0.0
@@ -306,13 +343,15 @@ func (s *ProjectServiceImpl) GetArchitecture(ctx context.Context, projectID uuid
 			})
 		}
 	}
+	traffic := edgeTrafficByResourceID(arch)
 	for fromID, toIDs := range arch.Dependencies {
 		for _, toID := range toIDs {
 			edges = append(edges, dto.ArchitectureEdge{
-				ID:     fmt.Sprintf("depend-%s-%s", fromID, toID),
-				Source: fromID,
-				Target: toID,
-				Type:   "depends_on",
+				ID:                fmt.Sprintf("depend-%s-%s", fromID, toID),
+				Source:            fromID,
+				Target:            toID,
+				Type:              "depends_on",
+				TrafficGBPerMonth: traffic[[2]string{fromID, toID}],
 			})
 		}
 	}
@@ -348,20 +387,13 @@ func (s *ProjectServiceImpl) GetArchitecture(ctx context.Context, projectID uuid
 		})
 	}
 
-	versionID := ""
-	versions, err := s.versionRepo.ListByProjectID(ctx, projectID)
-	if err == nil && len(versions) > 0 {
-		versionID = versions[0].ID.String()
-	}
-
 	return &dto.ArchitectureResponse{
-		VersionID: versionID,
 		Nodes:     nodes,
 		Edges:     edges,
 		Variables: variables,
 		Outputs:   outputs,
 		Warnings:  warnings,
-	}, nil
+	}
 }
 
 // SaveArchitecture creates a new immutable project snapshot with the given architecture.
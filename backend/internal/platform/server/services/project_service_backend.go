@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
+	"gorm.io/datatypes"
+)
+
+// ── State backend ─────────────────────────────────────────────────────────────
+
+// GetBackend returns the project's Terraform state backend, or nil when it uses local state.
+func (s *ProjectServiceImpl) GetBackend(ctx context.Context, projectID uuid.UUID) (*iac.StateBackend, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetBackend: %w", err)
+	}
+	backend, err := project.UnmarshalBackendConfig()
+	if err != nil {
+		return nil, fmt.Errorf("GetBackend: decode backend config: %w", err)
+	}
+	return backend, nil
+}
+
+// UpdateBackend validates and saves the state backend in place (no snapshot is created).
+// A nil backend resets the project to local state.
+func (s *ProjectServiceImpl) UpdateBackend(ctx context.Context, projectID uuid.UUID, backend *iac.StateBackend) (*iac.StateBackend, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateBackend: %w", err)
+	}
+
+	project.BackendConfig = nil
+	if backend != nil {
+		if err := backend.Validate(); err != nil {
+			return nil, fmt.Errorf("UpdateBackend: %w", err)
+		}
+		raw, err := json.Marshal(backend)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateBackend: encode backend config: %w", err)
+		}
+		project.BackendConfig = datatypes.JSON(raw)
+	}
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("UpdateBackend: %w", err)
+	}
+	return backend, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"gorm.io/datatypes"
+)
+
+// ── Budget ────────────────────────────────────────────────────────────────────
+
+// GetBudget returns the project's monthly budget, or nil when it has none.
+func (s *ProjectServiceImpl) GetBudget(ctx context.Context, projectID uuid.UUID) (*pricing.Budget, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetBudget: %w", err)
+	}
+	budget, err := project.UnmarshalBudget()
+	if err != nil {
+		return nil, fmt.Errorf("GetBudget: decode budget: %w", err)
+	}
+	return budget, nil
+}
+
+// UpdateBudget validates and saves the budget in place (no snapshot is created).
+// A nil budget removes it.
+func (s *ProjectServiceImpl) UpdateBudget(ctx context.Context, projectID uuid.UUID, budget *pricing.Budget) (*pricing.Budget, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateBudget: %w", err)
+	}
+
+	project.Budget = nil
+	if budget != nil {
+		if err := budget.Validate(); err != nil {
+			return nil, apperrors.Wrap(err, apperrors.CodeInvalidValue, apperrors.KindValidation, "invalid budget")
+		}
+		raw, err := json.Marshal(budget)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateBudget: encode budget: %w", err)
+		}
+		project.Budget = datatypes.JSON(raw)
+	}
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("UpdateBudget: %w", err)
+	}
+	return budget, nil
+}
+
+// EvaluateBudget compares an estimate of a project snapshot against its budget, sets
+// estimate.Budget and records the estimate with the comparison as project pricing.
+// It returns nil when the project has no budget.
+func (s *ProjectServiceImpl) EvaluateBudget(ctx context.Context, projectID uuid.UUID, estimate *serverinterfaces.ArchitectureCostEstimate) (*pricing.BudgetStatus, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluateBudget: %w", err)
+	}
+	status, err := budgetStatus(project, estimate)
+	if err != nil || status == nil {
+		return nil, err
+	}
+	if s.pricingService != nil {
+		if err := s.persistProjectEstimate(ctx, projectID, estimate); err != nil {
+			return status, fmt.Errorf("EvaluateBudget: %w", err)
+		}
+	}
+	return status, nil
+}
+
+// checkSnapshotBudget prices a new snapshot for a month and compares it against the
+// project budget. Over a blocking budget, or with resources left unpriced under one, it
+// returns an error without recording the estimate; otherwise the estimate is recorded with the comparison. Pricing failures
+// do not prevent the snapshot from being saved, but a budget that cannot be decoded does.
+func (s *ProjectServiceImpl) checkSnapshotBudget(ctx context.Context, project *models.Project, arch *architecture.Architecture) (*pricing.BudgetStatus, error) {
+	budget, err := project.UnmarshalBudget()
+	if err != nil {
+		return nil, fmt.Errorf("decode budget: %w", err)
+	}
+	if budget == nil || s.pricingService == nil {
+		return nil, nil
+	}
+
+	arch.Usage, _ = project.UnmarshalUsageProfile()
+	estimate, err := s.pricingService.CalculateArchitectureCost(ctx, arch, 720*time.Hour)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to price snapshot for its budget: %v\n", err)
+		return nil, nil
+	}
+
+	status, _ := budgetStatus(project, estimate)
+	if status.Blocked {
+		if len(status.Overages) == 0 {
+			return status, platformerrors.NewProjectUnpriced(status.Summary())
+		}
+		return status, platformerrors.NewProjectOverBudget(status.Summary())
+	}
+	if err := s.persistProjectEstimate(ctx, project.ID, estimate); err != nil {
+		fmt.Printf("⚠️  Failed to record snapshot pricing: %v\n", err)
+	}
+	return status, nil
+}
+
+// budgetStatus compares the estimate, scaled to a month, against the project budget and
+// sets estimate.Budget. Costs are split by the lower-cased resource category; resources
+// without a category only count towards the monthly limit. The estimate's warnings are
+// reported as unpriced resources.
+func budgetStatus(project *models.Project, estimate *serverinterfaces.ArchitectureCostEstimate) (*pricing.BudgetStatus, error) {
+	budget, err := project.UnmarshalBudget()
+	if err != nil {
+		return nil, fmt.Errorf("decode budget: %w", err)
+	}
+	if budget == nil {
+		return nil, nil
+	}
+
+	months := estimate.Duration.Hours() / 720.0
+	if months <= 0 {
+		months = 1
+	}
+	categoryCosts := make(map[string]float64)
+	for _, resEstimate := range estimate.ResourceEstimates {
+		if resEstimate.Category != "" {
+			categoryCosts[pricing.BudgetCategory(resEstimate.Category)] += resEstimate.TotalCost / months
+		}
+	}
+
+	estimate.Budget = budget.Evaluate(estimate.TotalCost/months, categoryCosts)
+	estimate.Budget.SetUnpriced(estimate.Warnings)
+	return estimate.Budget, nil
+}
+
+// persistProjectEstimate records an architecture estimate, with its budget comparison, as project pricing
+func (s *ProjectServiceImpl) persistProjectEstimate(ctx context.Context, projectID uuid.UUID, estimate *serverinterfaces.ArchitectureCostEstimate) error {
+	projectEstimate := &pricing.CostEstimate{
+		TotalCost:    estimate.TotalCost,
+		Currency:     pricing.Currency(estimate.Currency),
+		Period:       pricing.Period(estimate.Period),
+		Duration:     estimate.Duration,
+		CalculatedAt: time.Now(),
+		Provider:     pricing.CloudProvider(estimate.Provider),
+		Region:       &estimate.Region,
+	}
+	return s.pricingService.PersistProjectPricing(ctx, projectID, projectEstimate, estimate.Provider, estimate.Region, estimate.Budget)
+}
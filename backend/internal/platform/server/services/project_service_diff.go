@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// monthlyPricingDuration is the period cost comparisons are estimated over.
+const monthlyPricingDuration = 720 * time.Hour
+
+// versionArchitecture is a version together with its loaded domain architecture.
+type versionArchitecture struct {
+	versionID     uuid.UUID
+	versionNumber int
+	projectID     uuid.UUID // the version's snapshot project
+	arch          *architecture.Architecture
+}
+
+// loadVersionArchitecture loads the architecture of a version in the lineage of projectID.
+func (s *ProjectServiceImpl) loadVersionArchitecture(ctx context.Context, projectID, versionID uuid.UUID) (*versionArchitecture, error) {
+	ver, err := s.lineageVersion(ctx, projectID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := s.LoadArchitecture(ctx, ver.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("load architecture of version %s: %w", versionID, err)
+	}
+	return &versionArchitecture{versionID: ver.ID, versionNumber: ver.VersionNumber, projectID: ver.ProjectID, arch: arch}, nil
+}
+
+// resourceAliases pairs the resources of two snapshots through the node IDs they were
+// saved from. Every snapshot stores its resources under new IDs and keeps the ID of the
+// node it was created from as OriginalID, which is either the previous snapshot's
+// resource ID or a node ID the editor kept across saves. The result maps "to" resource
+// IDs to "from" resource IDs, for architecture.DiffArchitectures.
+func (s *ProjectServiceImpl) resourceAliases(ctx context.Context, from, to *versionArchitecture) (map[string]string, error) {
+	fromRows, err := s.resourceRepo.FindByProjectID(ctx, from.projectID)
+	if err != nil {
+		return nil, fmt.Errorf("load resources of version %s: %w", from.versionID, err)
+	}
+	toRows, err := s.resourceRepo.FindByProjectID(ctx, to.projectID)
+	if err != nil {
+		return nil, fmt.Errorf("load resources of version %s: %w", to.versionID, err)
+	}
+
+	fromIDs := make(map[string]bool, len(fromRows))
+	fromByOriginal := make(map[string]string, len(fromRows))
+	for _, r := range fromRows {
+		fromIDs[r.ID.String()] = true
+		if r.OriginalID != "" {
+			fromByOriginal[r.OriginalID] = r.ID.String()
+		}
+	}
+
+	aliases := make(map[string]string)
+	toIDs := make(map[string]bool, len(toRows))
+	for _, r := range toRows {
+		id := r.ID.String()
+		toIDs[id] = true
+		switch {
+		case r.OriginalID == "":
+		case fromIDs[r.OriginalID]: // "to" was saved from "from"
+			aliases[id] = r.OriginalID
+		case fromByOriginal[r.OriginalID] != "": // both saved from the same node
+			aliases[id] = fromByOriginal[r.OriginalID]
+		}
+	}
+	// "from" was saved from "to" when comparing against an older version.
+	for original, fromID := range fromByOriginal {
+		if toIDs[original] {
+			if _, ok := aliases[original]; !ok {
+				aliases[original] = fromID
+			}
+		}
+	}
+	return aliases, nil
+}
+
+// DiffVersions compares two versions of the project lineage.
+func (s *ProjectServiceImpl) DiffVersions(ctx context.Context, projectID, fromVersionID, toVersionID uuid.UUID) (*serverinterfaces.VersionDiff, error) {
+	from, err := s.loadVersionArchitecture(ctx, projectID, fromVersionID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.loadVersionArchitecture(ctx, projectID, toVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.resourceAliases(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	d := architecture.DiffArchitectures(from.arch, to.arch, aliases)
+
+	out := &serverinterfaces.VersionDiff{
+		FromVersionID: fromVersionID,
+		ToVersionID:   toVersionID,
+		Added:         diffResources(d.Added),
+		Removed:       diffResources(d.Removed),
+		Modified:      make([]serverinterfaces.ModifiedResource, 0, len(d.Modified)),
+		AddedEdges:    diffEdges(d.AddedEdges, to.arch),
+		RemovedEdges:  diffEdges(d.RemovedEdges, from.arch),
+		Cost:          s.costDelta(ctx, from.arch, to.arch),
+	}
+	for _, m := range d.Modified {
+		changes := make([]serverinterfaces.FieldChange, len(m.Changes))
+		for i, c := range m.Changes {
+			changes[i] = serverinterfaces.FieldChange{Field: c.Field, From: c.From, To: c.To}
+		}
+		out.Modified = append(out.Modified, serverinterfaces.ModifiedResource{
+			FromID:  m.From.ID,
+			ToID:    m.To.ID,
+			Name:    m.To.Name,
+			Type:    m.To.Type.Name,
+			Changes: changes,
+		})
+	}
+	return out, nil
+}
+
+// costDelta estimates both architectures' monthly cost. It returns nil when pricing is
+// not configured or fails, so a diff never fails because of pricing.
+func (s *ProjectServiceImpl) costDelta(ctx context.Context, from, to *architecture.Architecture) *serverinterfaces.CostDelta {
+	if s.pricingService == nil {
+		return nil
+	}
+	before, err := s.pricingService.CalculateArchitectureCost(ctx, from, monthlyPricingDuration)
+	if err != nil {
+		return nil
+	}
+	after, err := s.pricingService.CalculateArchitectureCost(ctx, to, monthlyPricingDuration)
+	if err != nil {
+		return nil
+	}
+	return &serverinterfaces.CostDelta{
+		From:     before.TotalCost,
+		To:       after.TotalCost,
+		Delta:    after.TotalCost - before.TotalCost,
+		Currency: after.Currency,
+		Period:   after.Period,
+	}
+}
+
+func diffResources(resources []*resource.Resource) []serverinterfaces.DiffResource {
+	out := make([]serverinterfaces.DiffResource, len(resources))
+	for i, r := range resources {
+		out[i] = serverinterfaces.DiffResource{ID: r.ID, Name: r.Name, Type: r.Type.Name}
+	}
+	return out
+}
+
+// diffEdges converts edges, naming their endpoints from the architecture they belong to.
+func diffEdges(edges []architecture.Edge, arch *architecture.Architecture) []serverinterfaces.DiffEdge {
+	names := make(map[string]string, len(arch.Resources))
+	for _, r := range arch.Resources {
+		names[r.ID] = r.Name
+	}
+	out := make([]serverinterfaces.DiffEdge, len(edges))
+	for i, e := range edges {
+		out[i] = serverinterfaces.DiffEdge{
+			Type:       e.Type,
+			Source:     e.Source,
+			Target:     e.Target,
+			SourceName: names[e.Source],
+			TargetName: names[e.Target],
+		}
+	}
+	return out
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 )
@@ -14,7 +16,7 @@ import (
 
 // Duplicate creates an independent copy of the project (new root, version 1).
 func (s *ProjectServiceImpl) Duplicate(ctx context.Context, projectID uuid.UUID, name string) (*models.Project, *models.ProjectVersion, error) {
-	originalProject, err := s.projectRepo.FindByID(ctx, projectID)
+	originalProject, err := s.findProject(ctx, projectID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find original project: %w", err)
 	}
@@ -59,6 +61,12 @@ func (s *ProjectServiceImpl) Duplicate(ctx context.Context, projectID uuid.UUID,
 
 // UpdateMetadata performs an in-place update of project metadata (no snapshot created).
 func (s *ProjectServiceImpl) UpdateMetadata(ctx context.Context, project *models.Project) (*models.Project, error) {
+	existing, err := s.findProject(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Ownership cannot be changed through a metadata update.
+	project.UserID = existing.UserID
 	if err := s.projectRepo.Update(ctx, project); err != nil {
 		return nil, fmt.Errorf("UpdateMetadata: %w", err)
 	}
@@ -73,6 +81,12 @@ func (s *ProjectServiceImpl) UpdateMetadata(ctx context.Context, project *models
 
 // CreateVersion snapshots the supplied architecture as a new immutable version.
 func (s *ProjectServiceImpl) CreateVersion(ctx context.Context, projectID uuid.UUID, req *serverinterfaces.CreateVersionRequest) (*serverinterfaces.ProjectVersionDetail, error) {
+	return s.createVersion(ctx, projectID, req, nil)
+}
+
+// createVersion snapshots the architecture as a new version whose parent is the latest
+// version of projectID and, for merges, whose second parent is mergeParentVersionID.
+func (s *ProjectServiceImpl) createVersion(ctx context.Context, projectID uuid.UUID, req *serverinterfaces.CreateVersionRequest, mergeParentVersionID *uuid.UUID) (*serverinterfaces.ProjectVersionDetail, error) {
 	archReq := &dto.UpdateArchitectureRequest{
 		Nodes:     req.Nodes,
 		Edges:     req.Edges,
@@ -81,9 +95,10 @@ func (s *ProjectServiceImpl) CreateVersion(ctx context.Context, projectID uuid.U
 	}
 
 	result, newProject, err := s.cloneProjectSnapshot(ctx, cloneProjectSnapshotOptions{
-		sourceProjectID: projectID,
-		applyArch:       archReq,
-		message:         req.Message,
+		sourceProjectID:      projectID,
+		applyArch:            archReq,
+		message:              req.Message,
+		mergeParentVersionID: mergeParentVersionID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("CreateVersion: %w", err)
@@ -100,12 +115,14 @@ func (s *ProjectServiceImpl) CreateVersion(ctx context.Context, projectID uuid.U
 		return nil, fmt.Errorf("CreateVersion: fetch version: %w", err)
 	}
 
-	return versionDetail(ver, arch), nil
+	detail := versionDetail(ver, arch)
+	detail.Budget = result.BudgetStatus
+	return detail, nil
 }
 
 // GetVersions returns the full ordered version chain for any project ID in the lineage.
 func (s *ProjectServiceImpl) GetVersions(ctx context.Context, projectID uuid.UUID) ([]*serverinterfaces.ProjectVersionSummary, error) {
-	project, err := s.projectRepo.FindByID(ctx, projectID)
+	project, err := s.findProject(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
@@ -129,22 +146,10 @@ func (s *ProjectServiceImpl) GetVersions(ctx context.Context, projectID uuid.UUI
 
 // GetLatestVersion returns the most recent version with full architecture state.
 func (s *ProjectServiceImpl) GetLatestVersion(ctx context.Context, projectID uuid.UUID) (*serverinterfaces.ProjectVersionDetail, error) {
-	project, err := s.projectRepo.FindByID(ctx, projectID)
+	latest, err := s.headVersion(ctx, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("project not found: %w", err)
-	}
-
-	rootID := projectID
-	if project.RootProjectID != nil {
-		rootID = *project.RootProjectID
-	}
-
-	versions, err := s.versionRepo.ListByRootProjectID(ctx, rootID)
-	if err != nil || len(versions) == 0 {
-		return nil, fmt.Errorf("no versions found for project %s", projectID)
+		return nil, err
 	}
-
-	latest := versions[len(versions)-1]
 	arch, err := s.GetArchitecture(ctx, latest.ProjectID)
 	if err != nil {
 		return nil, fmt.Errorf("GetLatestVersion: load architecture: %w", err)
@@ -154,9 +159,9 @@ func (s *ProjectServiceImpl) GetLatestVersion(ctx context.Context, projectID uui
 
 // GetVersionByID returns a specific version with full architecture state.
 func (s *ProjectServiceImpl) GetVersionByID(ctx context.Context, projectID uuid.UUID, versionID uuid.UUID) (*serverinterfaces.ProjectVersionDetail, error) {
-	ver, err := s.versionRepo.FindByID(ctx, versionID)
+	ver, err := s.lineageVersion(ctx, projectID, versionID)
 	if err != nil {
-		return nil, fmt.Errorf("version not found: %w", err)
+		return nil, err
 	}
 
 	arch, err := s.GetArchitecture(ctx, ver.ProjectID)
@@ -168,38 +173,42 @@ func (s *ProjectServiceImpl) GetVersionByID(ctx context.Context, projectID uuid.
 
 // DeleteVersion removes a single version entry (does NOT delete the project snapshot).
 func (s *ProjectServiceImpl) DeleteVersion(ctx context.Context, projectID uuid.UUID, versionID uuid.UUID) error {
-	// Verify the version belongs to this project lineage
-	ver, err := s.versionRepo.FindByID(ctx, versionID)
-	if err != nil {
-		return fmt.Errorf("version not found: %w", err)
-	}
-
-	project, _ := s.projectRepo.FindByID(ctx, projectID)
-	if project == nil {
-		return fmt.Errorf("project not found")
+	if _, err := s.lineageVersion(ctx, projectID, versionID); err != nil {
+		return err
 	}
+	return s.versionRepo.Delete(ctx, versionID)
+}
 
-	// Verify version is in the same lineage
-	rootID := projectID
-	if project.RootProjectID != nil {
-		rootID = *project.RootProjectID
+// RestoreVersion copies an earlier version's architecture into a new head version.
+func (s *ProjectServiceImpl) RestoreVersion(ctx context.Context, projectID uuid.UUID, versionID uuid.UUID, req *serverinterfaces.RestoreVersionRequest) (*serverinterfaces.ProjectVersionDetail, error) {
+	source, err := s.lineageVersion(ctx, projectID, versionID)
+	if err != nil {
+		return nil, err
 	}
-
-	verProject, err := s.projectRepo.FindByID(ctx, ver.ProjectID)
+	head, err := s.headVersion(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("version project snapshot not found: %w", err)
+		return nil, err
 	}
 
-	verRootID := ver.ProjectID
-	if verProject.RootProjectID != nil {
-		verRootID = *verProject.RootProjectID
+	state, err := s.GetArchitecture(ctx, source.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("RestoreVersion: load architecture: %w", err)
 	}
 
-	if verRootID != rootID {
-		return fmt.Errorf("version %s does not belong to project lineage %s", versionID, projectID)
+	message := fmt.Sprintf("Restore version %d (%s)", source.VersionNumber, source.ID)
+	if req != nil && req.Message != "" {
+		message = fmt.Sprintf("%s (restored from version %d, %s)", req.Message, source.VersionNumber, source.ID)
 	}
 
-	return s.versionRepo.Delete(ctx, versionID)
+	// Snapshot from the head so the restored version continues the chain rather than
+	// forking from the old one.
+	return s.CreateVersion(ctx, head.ProjectID, &serverinterfaces.CreateVersionRequest{
+		Nodes:     state.Nodes,
+		Edges:     state.Edges,
+		Variables: state.Variables,
+		Outputs:   state.Outputs,
+		Message:   message,
+	})
 }
 
 // ValidateVersionArchitecture validates the architecture stored in a specific version.
@@ -233,15 +242,85 @@ func (s *ProjectServiceImpl) ValidateVersionArchitecture(ctx context.Context, ve
 
 // ── internal helpers ──────────────────────────────────────────────────────────
 
+// lineageVersion loads a version and verifies it belongs to the lineage of projectID.
+func (s *ProjectServiceImpl) lineageVersion(ctx context.Context, projectID uuid.UUID, versionID uuid.UUID) (*models.ProjectVersion, error) {
+	ver, err := s.versionRepo.FindByID(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("version not found: %w", err)
+	}
+
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Verify version is in the same lineage
+	rootID := projectID
+	if project.RootProjectID != nil {
+		rootID = *project.RootProjectID
+	}
+
+	verProject, err := s.projectRepo.FindByID(ctx, ver.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("version project snapshot not found: %w", err)
+	}
+
+	verRootID := ver.ProjectID
+	if verProject.RootProjectID != nil {
+		verRootID = *verProject.RootProjectID
+	}
+
+	if verRootID != rootID {
+		return nil, fmt.Errorf("version %s does not belong to project lineage %s", versionID, projectID)
+	}
+	return ver, nil
+}
+
+// findProject loads a project, treating projects owned by someone other than the
+// authenticated caller as missing so their existence is not revealed.
+func (s *ProjectServiceImpl) findProject(ctx context.Context, projectID uuid.UUID) (*models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, platformerrors.NewProjectNotFound(projectID)
+	}
+	if callerID, ok := auth.UserIDFromContext(ctx); ok && project.UserID != callerID {
+		return nil, platformerrors.NewProjectNotFound(projectID)
+	}
+	return project, nil
+}
+
+// headVersion returns the latest version in the lineage of projectID.
+func (s *ProjectServiceImpl) headVersion(ctx context.Context, projectID uuid.UUID) (*models.ProjectVersion, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	rootID := projectID
+	if project.RootProjectID != nil {
+		rootID = *project.RootProjectID
+	}
+
+	versions, err := s.versionRepo.ListByRootProjectID(ctx, rootID)
+	if err != nil || len(versions) == 0 {
+		return nil, fmt.Errorf("no versions found for project %s", projectID)
+	}
+	return versions[len(versions)-1], nil
+}
+
 func versionSummary(v *models.ProjectVersion) *serverinterfaces.ProjectVersionSummary {
 	return &serverinterfaces.ProjectVersionSummary{
-		ID:              v.ID,
-		ProjectID:       v.ProjectID,
-		ParentVersionID: v.ParentVersionID,
-		VersionNumber:   v.VersionNumber,
-		Message:         v.Message,
-		CreatedAt:       v.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		CreatedBy:       v.CreatedBy,
+		ID:                   v.ID,
+		ProjectID:            v.ProjectID,
+		ParentVersionID:      v.ParentVersionID,
+		MergeParentVersionID: v.MergeParentVersionID,
+		VersionNumber:        v.VersionNumber,
+		Message:              v.Message,
+		CreatedAt:            v.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedBy:            v.CreatedBy,
 	}
 }
 
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// stateSummary describes an architecture by resource names, since every snapshot
+// stores its resources under new IDs. Visual-only nodes are left out: the generator
+// adds one for each dependency edge on every save.
+func stateSummary(state *dto.ArchitectureResponse) []string {
+	names := make(map[string]string, len(state.Nodes))
+	var out []string
+	for _, node := range state.Nodes {
+		names[node.ID] = node.Data.Label
+		if !node.Data.IsVisualOnly {
+			out = append(out, fmt.Sprintf("node %s %s %v", node.Data.Label, node.Data.ResourceType, node.Data.Config["cidr"]))
+		}
+	}
+	for _, edge := range state.Edges {
+		out = append(out, fmt.Sprintf("edge %s %s %s", names[edge.Source], edge.Type, names[edge.Target]))
+	}
+	for _, v := range state.Variables {
+		out = append(out, fmt.Sprintf("variable %+v", v))
+	}
+	for _, o := range state.Outputs {
+		out = append(out, fmt.Sprintf("output %+v", o))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// restoreFixture saves a version with two dependent VPCs, a variable and an output,
+// then a second version that replaces them with a single VPC
+func restoreFixture(t *testing.T, ctx context.Context, store *snapshotStore, svc *ProjectServiceImpl) (root uuid.UUID, first, head *serverinterfaces.ProjectVersionDetail) {
+	t.Helper()
+	req := vpcVersion("initial", [2]string{"vpc-a", "main"}, [2]string{"vpc-b", "edge"})
+	req.Edges = []dto.ArchitectureEdge{{ID: "e1", Source: "vpc-b", Target: "vpc-a", Type: "depends_on"}}
+	req.Variables = []dto.ArchitectureVariable{{Name: "env", Type: "string", Value: "prod", Description: "Environment"}}
+	req.Outputs = []dto.ArchitectureOutput{{Name: "vpc_id", Value: "${aws_vpc.main.id}", Description: "Main VPC"}}
+	project, first, err := store.createProject(ctx, svc, req)
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	head, err = childVersion(ctx, svc, first, "main", "10.9.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion error: %v", err)
+	}
+	head, err = svc.CreateVersion(ctx, head.ProjectID, vpcVersion("replace", [2]string{"vpc-z", "single"}))
+	if err != nil {
+		t.Fatalf("CreateVersion error: %v", err)
+	}
+	return project.ID, first, head
+}
+
+func TestProjectService_RestoreVersion(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+	root, first, head := restoreFixture(t, ctx, store, svc)
+
+	restored, err := svc.RestoreVersion(ctx, root, first.ID, nil)
+	if err != nil {
+		t.Fatalf("RestoreVersion error: %v", err)
+	}
+
+	if restored.ParentVersionID == nil || *restored.ParentVersionID != head.ID {
+		t.Errorf("ParentVersionID = %v, want the head %s", restored.ParentVersionID, head.ID)
+	}
+	if restored.VersionNumber != head.VersionNumber+1 {
+		t.Errorf("VersionNumber = %d, want %d", restored.VersionNumber, head.VersionNumber+1)
+	}
+	if want := fmt.Sprintf("Restore version 1 (%s)", first.ID); restored.Message != want {
+		t.Errorf("Message = %q, want %q", restored.Message, want)
+	}
+
+	got, want := stateSummary(restored.State), stateSummary(first.State)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("restored state = %v, want %v", got, want)
+	}
+	if len(restored.State.Edges) != 1 || len(restored.State.Variables) != 1 || len(restored.State.Outputs) != 1 {
+		t.Errorf("restored state has %d edges, %d variables, %d outputs, want 1 of each",
+			len(restored.State.Edges), len(restored.State.Variables), len(restored.State.Outputs))
+	}
+
+	latest, err := svc.GetLatestVersion(ctx, root)
+	if err != nil {
+		t.Fatalf("GetLatestVersion error: %v", err)
+	}
+	if latest.ID != restored.ID {
+		t.Errorf("latest version = %s, want the restored version %s", latest.ID, restored.ID)
+	}
+}
+
+func TestProjectService_RestoreVersionMessage(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+	root, first, _ := restoreFixture(t, ctx, store, svc)
+
+	restored, err := svc.RestoreVersion(ctx, root, first.ID, &serverinterfaces.RestoreVersionRequest{Message: "Undo the VPC replacement"})
+	if err != nil {
+		t.Fatalf("RestoreVersion error: %v", err)
+	}
+	if want := fmt.Sprintf("Undo the VPC replacement (restored from version 1, %s)", first.ID); restored.Message != want {
+		t.Errorf("Message = %q, want %q", restored.Message, want)
+	}
+}
+
+func TestProjectService_RestoreVersionRejectsOtherLineage(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+	root, _, _ := restoreFixture(t, ctx, store, svc)
+	_, other, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-o", "other"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	versions := len(store.versions)
+
+	if _, err := svc.RestoreVersion(ctx, root, other.ID, nil); err == nil {
+		t.Fatal("RestoreVersion with a version of another project: expected error")
+	}
+	if _, err := svc.RestoreVersion(ctx, root, uuid.New(), nil); err == nil {
+		t.Error("RestoreVersion with an unknown version: expected error")
+	}
+	if len(store.versions) != versions {
+		t.Errorf("rejected restores created %d versions", len(store.versions)-versions)
+	}
+}
+
+func TestProjectService_ScopesProjectsToCaller(t *testing.T) {
+	store := newSnapshotStore()
+	svc := store.projectService()
+	project, _, err := store.createProject(context.Background(), svc, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	owner := auth.WithUserID(context.Background(), project.UserID)
+	other := auth.WithUserID(context.Background(), uuid.New())
+
+	// Another user gets not found, as if the project did not exist
+	if _, err := svc.GetByID(other, project.ID); !apperrors.IsKind(err, apperrors.KindNotFound) {
+		t.Errorf("GetByID by another user: err = %v, want not found", err)
+	}
+	if _, err := svc.UpdateMetadata(other, &models.Project{ID: project.ID, Name: "taken"}); !apperrors.IsKind(err, apperrors.KindNotFound) {
+		t.Errorf("UpdateMetadata by another user: err = %v, want not found", err)
+	}
+	if err := svc.Delete(other, project.ID); !apperrors.IsKind(err, apperrors.KindNotFound) {
+		t.Errorf("Delete by another user: err = %v, want not found", err)
+	}
+	if store.projects[project.ID] == nil || store.projects[project.ID].Name != "shop" {
+		t.Fatalf("project after another user's update and delete = %+v, want it unchanged", store.projects[project.ID])
+	}
+	// The user ID passed to List is ignored for authenticated callers
+	if projects, total, err := svc.List(other, project.UserID, 1, 20, "", "", ""); err != nil || total != 0 || len(projects) != 0 {
+		t.Errorf("List by another user = %d projects (total %d), err %v, want none", len(projects), total, err)
+	}
+
+	if got, err := svc.GetByID(owner, project.ID); err != nil || got.ID != project.ID {
+		t.Errorf("GetByID by the owner = %+v, %v", got, err)
+	}
+	if projects, total, err := svc.List(owner, uuid.New(), 1, 20, "", "", ""); err != nil || total != 1 || len(projects) != 1 || projects[0].ID != project.ID {
+		t.Errorf("List by the owner = %d projects (total %d), err %v, want the project", len(projects), total, err)
+	}
+	updated, err := svc.UpdateMetadata(owner, &models.Project{ID: project.ID, UserID: uuid.New(), Name: "renamed"})
+	if err != nil {
+		t.Fatalf("UpdateMetadata by the owner error: %v", err)
+	}
+	if updated.Name != "renamed" || updated.UserID != project.UserID {
+		t.Errorf("UpdateMetadata = name %q, user %s, want renamed and still owned by %s", updated.Name, updated.UserID, project.UserID)
+	}
+	if err := svc.Delete(owner, project.ID); err != nil {
+		t.Fatalf("Delete by the owner error: %v", err)
+	}
+	if _, ok := store.projects[project.ID]; ok {
+		t.Error("Delete by the owner left the project")
+	}
+}
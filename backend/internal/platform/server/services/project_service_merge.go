@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// MergeVersions merges the changes of req.TheirsVersionID into versionID.
+func (s *ProjectServiceImpl) MergeVersions(ctx context.Context, projectID, versionID uuid.UUID, req *serverinterfaces.MergeVersionsRequest) (*serverinterfaces.MergeVersionsResult, error) {
+	if req.TheirsVersionID == versionID {
+		return nil, apperrors.New(apperrors.CodeInvalidValue, apperrors.KindValidation, "cannot merge a version into itself")
+	}
+	resolutions := make(map[string]architecture.Resolution, len(req.Resolutions))
+	for _, r := range req.Resolutions {
+		switch r.Take {
+		case "", architecture.TakeOurs, architecture.TakeTheirs:
+		default:
+			return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "resolution of %s: take must be %q or %q", r.ConflictID, architecture.TakeOurs, architecture.TakeTheirs)
+		}
+		resolutions[r.ConflictID] = architecture.Resolution{Take: r.Take, Value: r.Value}
+	}
+
+	ours, err := s.loadVersionArchitecture(ctx, projectID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := s.loadVersionArchitecture(ctx, projectID, req.TheirsVersionID)
+	if err != nil {
+		return nil, err
+	}
+	baseID := uuid.Nil
+	if req.BaseVersionID != nil {
+		baseID = *req.BaseVersionID
+	} else if baseID, err = s.commonAncestor(ctx, versionID, req.TheirsVersionID); err != nil {
+		return nil, err
+	}
+	base, err := s.loadVersionArchitecture(ctx, projectID, baseID)
+	if err != nil {
+		return nil, err
+	}
+
+	oursAliases, err := s.resourceAliases(ctx, base, ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsAliases, err := s.resourceAliases(ctx, base, theirs)
+	if err != nil {
+		return nil, err
+	}
+	merged := architecture.MergeArchitectures(base.arch,
+		architecture.MergeInput{Architecture: ours.arch, Pairs: architecture.PairResources(base.arch, ours.arch, oursAliases)},
+		architecture.MergeInput{Architecture: theirs.arch, Pairs: architecture.PairResources(base.arch, theirs.arch, theirsAliases)},
+		resolutions,
+	)
+
+	result := &serverinterfaces.MergeVersionsResult{
+		BaseVersionID:   baseID,
+		OursVersionID:   versionID,
+		TheirsVersionID: req.TheirsVersionID,
+		Conflicts:       make([]serverinterfaces.MergeConflict, len(merged.Conflicts)),
+	}
+	for i, c := range merged.Conflicts {
+		result.Conflicts[i] = serverinterfaces.MergeConflict{
+			ID:     c.ID(),
+			Kind:   c.Kind,
+			Key:    c.Key,
+			Name:   c.Name,
+			Field:  c.Field,
+			Base:   c.Base,
+			Ours:   c.Ours,
+			Theirs: c.Theirs,
+		}
+	}
+	if merged.Architecture == nil {
+		return result, nil
+	}
+
+	message := req.Message
+	if message == "" {
+		message = fmt.Sprintf("Merge version %d into version %d", theirs.versionNumber, ours.versionNumber)
+	}
+	state := architectureToResponse(merged.Architecture)
+	// Record theirs as the second parent so later merges find this version as the base
+	ver, err := s.createVersion(ctx, ours.projectID, &serverinterfaces.CreateVersionRequest{
+		Nodes:     state.Nodes,
+		Edges:     state.Edges,
+		Variables: state.Variables,
+		Outputs:   state.Outputs,
+		Message:   message,
+	}, &theirs.versionID)
+	if err != nil {
+		return nil, fmt.Errorf("MergeVersions: %w", err)
+	}
+	result.Version = ver
+	return result, nil
+}
+
+// commonAncestor returns the nearest version both versions descend from through their
+// parent_version_id and merge_parent_version_id links (a version counts as its own
+// ancestor). Ancestors of b are visited breadth-first, so the closest one is returned.
+func (s *ProjectServiceImpl) commonAncestor(ctx context.Context, a, b uuid.UUID) (uuid.UUID, error) {
+	ancestors := make(map[uuid.UUID]bool)
+	if err := s.walkAncestors(ctx, a, func(id uuid.UUID) bool {
+		ancestors[id] = true
+		return false
+	}); err != nil {
+		return uuid.Nil, err
+	}
+	common := uuid.Nil
+	if err := s.walkAncestors(ctx, b, func(id uuid.UUID) bool {
+		if ancestors[id] {
+			common = id
+			return true
+		}
+		return false
+	}); err != nil {
+		return uuid.Nil, err
+	}
+	if common == uuid.Nil {
+		return uuid.Nil, apperrors.New(apperrors.CodeValidationFailed, apperrors.KindValidation, "the versions have no common ancestor; set base_version_id")
+	}
+	return common, nil
+}
+
+// walkAncestors visits a version and its ancestors through both parent links
+// breadth-first, until visit returns true.
+func (s *ProjectServiceImpl) walkAncestors(ctx context.Context, id uuid.UUID, visit func(uuid.UUID) bool) error {
+	queue := []uuid.UUID{id}
+	seen := map[uuid.UUID]bool{id: true}
+	for len(queue) > 0 {
+		id, queue = queue[0], queue[1:]
+		if visit(id) {
+			return nil
+		}
+		ver, err := s.versionRepo.FindByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("version not found: %w", err)
+		}
+		for _, parent := range []*uuid.UUID{ver.ParentVersionID, ver.MergeParentVersionID} {
+			if parent != nil && !seen[*parent] {
+				seen[*parent] = true
+				queue = append(queue, *parent)
+			}
+		}
+	}
+	return nil
+}
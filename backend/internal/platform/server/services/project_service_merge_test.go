@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// childVersion creates a version on top of parent from its state, with the CIDR of the
+// VPC named vpc replaced, as the editor would save it
+func childVersion(ctx context.Context, svc *ProjectServiceImpl, parent *serverinterfaces.ProjectVersionDetail, vpc, cidr string) (*serverinterfaces.ProjectVersionDetail, error) {
+	nodes := make([]dto.ArchitectureNode, len(parent.State.Nodes))
+	for i, node := range parent.State.Nodes {
+		config := make(map[string]interface{}, len(node.Data.Config))
+		for k, v := range node.Data.Config {
+			config[k] = v
+		}
+		if node.Data.Label == vpc {
+			config["cidr"] = cidr
+		}
+		node.Data.Config = config
+		nodes[i] = node
+	}
+	return svc.CreateVersion(ctx, parent.ProjectID, &serverinterfaces.CreateVersionRequest{Nodes: nodes, Message: "set " + vpc + " CIDR to " + cidr})
+}
+
+func vpcCIDR(detail *serverinterfaces.ProjectVersionDetail, vpc string) interface{} {
+	for _, node := range detail.State.Nodes {
+		if node.Data.Label == vpc {
+			return node.Data.Config["cidr"]
+		}
+	}
+	return nil
+}
+
+func TestProjectService_MergeVersions(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+
+	root, base, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	ours, err := childVersion(ctx, svc, base, "main", "10.1.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion(ours) error: %v", err)
+	}
+	theirs, err := childVersion(ctx, svc, base, "main", "10.2.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion(theirs) error: %v", err)
+	}
+	versions := len(store.versions)
+
+	// Both sides changed the CIDR: the merge stops at the conflict
+	result, err := svc.MergeVersions(ctx, root.ID, ours.ID, &serverinterfaces.MergeVersionsRequest{TheirsVersionID: theirs.ID})
+	if err != nil {
+		t.Fatalf("MergeVersions error: %v", err)
+	}
+	if result.BaseVersionID != base.ID {
+		t.Errorf("BaseVersionID = %s, want the shared parent %s", result.BaseVersionID, base.ID)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Ours != "10.1.0.0/16" || result.Conflicts[0].Theirs != "10.2.0.0/16" {
+		t.Fatalf("Conflicts = %+v, want the CIDR conflict", result.Conflicts)
+	}
+	if result.Version != nil || len(store.versions) != versions {
+		t.Errorf("unresolved merge saved version %+v", result.Version)
+	}
+
+	// Resolved in favour of ours: the merge is saved with both parents
+	result, err = svc.MergeVersions(ctx, root.ID, ours.ID, &serverinterfaces.MergeVersionsRequest{
+		TheirsVersionID: theirs.ID,
+		Resolutions:     []serverinterfaces.MergeResolution{{ConflictID: result.Conflicts[0].ID, Take: architecture.TakeOurs}},
+	})
+	if err != nil {
+		t.Fatalf("MergeVersions with resolution error: %v", err)
+	}
+	merge := result.Version
+	if merge == nil {
+		t.Fatalf("MergeVersions with resolution left conflicts %+v", result.Conflicts)
+	}
+	if got := vpcCIDR(merge, "main"); got != "10.1.0.0/16" {
+		t.Errorf("merged CIDR = %v, want ours", got)
+	}
+	saved := store.version(merge.ID)
+	if saved == nil || saved.ParentVersionID == nil || *saved.ParentVersionID != ours.ID {
+		t.Fatalf("saved merge = %+v, want parent %s", saved, ours.ID)
+	}
+	if saved.MergeParentVersionID == nil || *saved.MergeParentVersionID != theirs.ID {
+		t.Errorf("saved merge parent = %v, want theirs %s", saved.MergeParentVersionID, theirs.ID)
+	}
+	if saved.Message != "Merge version 3 into version 2" {
+		t.Errorf("saved merge message = %q", saved.Message)
+	}
+
+	// Theirs moves on without touching the CIDR: merging it again starts from theirs,
+	// reached through the merge parent, so the settled conflict is not reported again
+	nodes := append(append([]dto.ArchitectureNode{}, theirs.State.Nodes...), vpcVersion("", [2]string{"vpc-c", "edge"}).Nodes...)
+	next, err := svc.CreateVersion(ctx, theirs.ProjectID, &serverinterfaces.CreateVersionRequest{Nodes: nodes, Message: "add edge VPC"})
+	if err != nil {
+		t.Fatalf("CreateVersion(next) error: %v", err)
+	}
+	result, err = svc.MergeVersions(ctx, root.ID, merge.ID, &serverinterfaces.MergeVersionsRequest{TheirsVersionID: next.ID})
+	if err != nil {
+		t.Fatalf("second MergeVersions error: %v", err)
+	}
+	if result.BaseVersionID != theirs.ID {
+		t.Errorf("second merge BaseVersionID = %s, want the merged-in version %s", result.BaseVersionID, theirs.ID)
+	}
+	if len(result.Conflicts) != 0 || result.Version == nil {
+		t.Fatalf("second merge conflicts = %+v, want a clean merge", result.Conflicts)
+	}
+	if got := vpcCIDR(result.Version, "main"); got != "10.1.0.0/16" {
+		t.Errorf("second merge CIDR = %v, want ours kept", got)
+	}
+	if len(result.Version.State.Nodes) != 2 {
+		t.Errorf("second merge has %d nodes, want the edge VPC added", len(result.Version.State.Nodes))
+	}
+}
+
+func TestProjectService_MergeVersionsOfOlderVersions(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+
+	root, base, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	ours, err := childVersion(ctx, svc, base, "main", "10.1.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion(ours) error: %v", err)
+	}
+	theirs, err := childVersion(ctx, svc, base, "main", "10.2.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion(theirs) error: %v", err)
+	}
+	head, err := childVersion(ctx, svc, theirs, "main", "10.3.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion(head) error: %v", err)
+	}
+	if ours.VersionNumber != 2 || theirs.VersionNumber != 3 || head.VersionNumber != 4 {
+		t.Fatalf("version numbers = %d, %d, %d, want 2, 3, 4", ours.VersionNumber, theirs.VersionNumber, head.VersionNumber)
+	}
+
+	// Neither side is the head: the merge is numbered after the head, not after ours
+	result, err := svc.MergeVersions(ctx, root.ID, ours.ID, &serverinterfaces.MergeVersionsRequest{
+		TheirsVersionID: theirs.ID,
+		Resolutions:     []serverinterfaces.MergeResolution{{ConflictID: mergeConflictID(t, ctx, svc, root.ID, ours.ID, theirs.ID), Take: architecture.TakeTheirs}},
+	})
+	if err != nil {
+		t.Fatalf("MergeVersions error: %v", err)
+	}
+	if result.Version == nil {
+		t.Fatalf("MergeVersions left conflicts %+v", result.Conflicts)
+	}
+	if result.Version.VersionNumber != 5 {
+		t.Errorf("merge VersionNumber = %d, want 5", result.Version.VersionNumber)
+	}
+	seen := map[int]bool{}
+	for _, v := range store.versions {
+		if seen[v.VersionNumber] {
+			t.Errorf("version number %d is used twice", v.VersionNumber)
+		}
+		seen[v.VersionNumber] = true
+	}
+}
+
+// mergeConflictID returns the ID of the single conflict between ours and theirs
+func mergeConflictID(t *testing.T, ctx context.Context, svc *ProjectServiceImpl, projectID, ours, theirs uuid.UUID) string {
+	t.Helper()
+	result, err := svc.MergeVersions(ctx, projectID, ours, &serverinterfaces.MergeVersionsRequest{TheirsVersionID: theirs})
+	if err != nil {
+		t.Fatalf("MergeVersions error: %v", err)
+	}
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("Conflicts = %+v, want one", result.Conflicts)
+	}
+	return result.Conflicts[0].ID
+}
+
+func TestProjectService_MergeVersionsRejectsInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+
+	root, base, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	ours, err := childVersion(ctx, svc, base, "main", "10.1.0.0/16")
+	if err != nil {
+		t.Fatalf("CreateVersion error: %v", err)
+	}
+	_, other, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-b", "other"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *serverinterfaces.MergeVersionsRequest
+	}{
+		{"into itself", &serverinterfaces.MergeVersionsRequest{TheirsVersionID: ours.ID}},
+		{"unknown take", &serverinterfaces.MergeVersionsRequest{TheirsVersionID: base.ID, Resolutions: []serverinterfaces.MergeResolution{{ConflictID: "x", Take: "both"}}}},
+		{"other lineage", &serverinterfaces.MergeVersionsRequest{TheirsVersionID: other.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.MergeVersions(ctx, root.ID, ours.ID, tt.req); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
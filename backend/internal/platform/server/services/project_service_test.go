@@ -2,12 +2,23 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+	"gorm.io/datatypes"
 )
 
 // Mock repositories for testing
@@ -248,3 +259,140 @@ func TestProjectService_PersistArchitecture(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageProfileWithEdgeTraffic(t *testing.T) {
+	node := func(id, label string) dto.ArchitectureNode {
+		return dto.ArchitectureNode{ID: id, Data: dto.ArchitectureNodeData{Label: label}}
+	}
+	nodes := []dto.ArchitectureNode{node("n1", "web"), node("n2", "db"), node("n3", "cache")}
+	saved := datatypes.JSON(`{"edges":[{"source":"web","gb_per_month":5},{"source":"web","target":"db","gb_per_month":1,"transfer_type":"same_az"},{"source":"web","target":"cache","gb_per_month":2}]}`)
+
+	// Without annotations the saved profile is kept
+	req := &dto.UpdateArchitectureRequest{Nodes: nodes, Edges: []dto.ArchitectureEdge{{Source: "n1", Target: "n2", Type: "depends_on"}}}
+	if got := usageProfileWithEdgeTraffic(saved, req); string(got) != string(saved) {
+		t.Errorf("expected the profile to be kept, got %s", got)
+	}
+
+	// Annotations replace the traffic between resources, keeping internet traffic and transfer types
+	req.Edges[0].TrafficGBPerMonth = 40
+	var profile pricing.UsageProfile
+	if err := json.Unmarshal(usageProfileWithEdgeTraffic(saved, req), &profile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []pricing.EdgeTraffic{
+		{Source: "web", GBPerMonth: 5},
+		{Source: "web", Target: "db", GBPerMonth: 40, TransferType: pricing.TransferSameAZ},
+	}
+	if len(profile.Edges) != len(want) {
+		t.Fatalf("expected %v, got %v", want, profile.Edges)
+	}
+	for i := range want {
+		if profile.Edges[i] != want[i] {
+			t.Errorf("edge %d: expected %+v, got %+v", i, want[i], profile.Edges[i])
+		}
+	}
+
+	// A project without a profile gets one from its annotations
+	if got := usageProfileWithEdgeTraffic(nil, req); string(got) != `{"edges":[{"source":"web","target":"db","gb_per_month":40}]}` {
+		t.Errorf("unexpected profile %s", got)
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	estimate := &serverinterfaces.ArchitectureCostEstimate{
+		TotalCost: 300,
+		Duration:  3 * 720 * time.Hour,
+		ResourceEstimates: map[string]*serverinterfaces.ResourceCostEstimate{
+			"web":  {Category: resource.CategoryCompute, TotalCost: 240},
+			"nat":  {Category: resource.CategoryNetworking, TotalCost: 45},
+			"edge": {Category: resource.CategoryNetworking, TotalCost: 9},
+			"misc": {TotalCost: 6},
+		},
+	}
+
+	// Without a budget there is nothing to compare
+	if status, err := budgetStatus(&models.Project{}, estimate); err != nil || status != nil || estimate.Budget != nil {
+		t.Fatalf("expected no budget status, got %+v (%v)", status, err)
+	}
+
+	// Costs are compared per month and per lower-cased category
+	project := &models.Project{Budget: datatypes.JSON(`{"monthly_limit":150,"category_limits":{"networking":20},"action":"block"}`)}
+	status, err := budgetStatus(project, estimate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if estimate.Budget != status || status.Status != pricing.WithinBudget || status.Blocked {
+		t.Fatalf("expected a status within budget, got %+v", status)
+	}
+	if math.Abs(status.MonthlyCost-100) > 1e-9 || math.Abs(status.CategoryCosts["compute"]-80) > 1e-9 || math.Abs(status.CategoryCosts["networking"]-18) > 1e-9 {
+		t.Errorf("unexpected monthly costs: %+v", status)
+	}
+
+	project.Budget = datatypes.JSON(`{"category_limits":{"networking":15},"action":"block"}`)
+	status, _ = budgetStatus(project, estimate)
+	if !status.Blocked || len(status.Overages) != 1 || status.Overages[0].Category != "networking" {
+		t.Errorf("expected networking to block the snapshot, got %+v", status)
+	}
+}
+
+func TestProjectService_CreateVersionWithUnpricedResources(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectServiceWithPricing(NewPricingService(&mockPricingRepository{}))
+	root, _, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	// eu-west-1 has no NAT gateway rates
+	req := vpcVersion("add nat", [2]string{"vpc-a", "main"})
+	req.Nodes = append(req.Nodes, dto.ArchitectureNode{
+		ID:   "region",
+		Type: "region",
+		Data: dto.ArchitectureNodeData{Label: "eu-west-1", ResourceType: "region", Config: map[string]interface{}{"name": "eu-west-1"}},
+	}, dto.ArchitectureNode{
+		ID:   "nat",
+		Type: "NATGateway",
+		Data: dto.ArchitectureNodeData{Label: "nat", ResourceType: "NATGateway", Config: map[string]interface{}{"name": "nat"}},
+	})
+
+	store.projects[root.ID].Budget = datatypes.JSON(`{"monthly_limit":1000}`)
+	flagged, err := svc.CreateVersion(ctx, root.ID, req)
+	if err != nil {
+		t.Fatalf("CreateVersion under a flag budget: %v", err)
+	}
+	// The NAT gateway and its Elastic IP
+	if flagged.Budget == nil || flagged.Budget.Blocked || len(flagged.Budget.Unpriced) != 2 {
+		t.Errorf("expected the NAT gateway to be reported unpriced, got %+v", flagged.Budget)
+	}
+
+	store.projects[root.ID].Budget = datatypes.JSON(`{"monthly_limit":1000,"action":"block"}`)
+	projects, versions := len(store.projects), len(store.versions)
+	_, err = svc.CreateVersion(ctx, root.ID, req)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != platformerrors.CodeProjectUnpriced || !strings.Contains(err.Error(), "unpriced nat") {
+		t.Fatalf("CreateVersion under a block budget: err = %v, want unpriced resources refused", err)
+	}
+	if len(store.projects) != projects || len(store.versions) != versions {
+		t.Errorf("refused snapshot left %d projects and %d versions behind", len(store.projects)-projects, len(store.versions)-versions)
+	}
+}
+
+func TestProjectService_CreateVersionRejectsMalformedBudget(t *testing.T) {
+	ctx := context.Background()
+	store := newSnapshotStore()
+	svc := store.projectService()
+	root, _, err := store.createProject(ctx, svc, vpcVersion("initial", [2]string{"vpc-a", "main"}))
+	if err != nil {
+		t.Fatalf("createProject error: %v", err)
+	}
+	store.projects[root.ID].Budget = datatypes.JSON(`{"monthly_limit":"lots"}`)
+	projects, versions := len(store.projects), len(store.versions)
+
+	_, err = svc.CreateVersion(ctx, root.ID, vpcVersion("resize", [2]string{"vpc-a", "main"}))
+	if err == nil || !strings.Contains(err.Error(), "decode budget") {
+		t.Fatalf("CreateVersion with a malformed budget: err = %v, want a decode error", err)
+	}
+	if len(store.projects) != projects || len(store.versions) != versions {
+		t.Errorf("rejected snapshot left %d projects and %d versions behind", len(store.projects)-projects, len(store.versions)-versions)
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"gorm.io/datatypes"
+)
+
+// ── Usage profile ─────────────────────────────────────────────────────────────
+
+// GetUsageProfile returns the project's usage profile, or nil when it is priced 24/7 with no traffic.
+func (s *ProjectServiceImpl) GetUsageProfile(ctx context.Context, projectID uuid.UUID) (*pricing.UsageProfile, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("GetUsageProfile: %w", err)
+	}
+	profile, err := project.UnmarshalUsageProfile()
+	if err != nil {
+		return nil, fmt.Errorf("GetUsageProfile: decode usage profile: %w", err)
+	}
+	return profile, nil
+}
+
+// UpdateUsageProfile validates and saves the usage profile in place (no snapshot is created).
+// A nil profile removes it.
+func (s *ProjectServiceImpl) UpdateUsageProfile(ctx context.Context, projectID uuid.UUID, profile *pricing.UsageProfile) (*pricing.UsageProfile, error) {
+	project, err := s.findProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateUsageProfile: %w", err)
+	}
+
+	project.UsageProfile = nil
+	if profile != nil {
+		if err := profile.Validate(); err != nil {
+			return nil, apperrors.Wrap(err, apperrors.CodeInvalidValue, apperrors.KindValidation, "invalid usage profile")
+		}
+		raw, err := json.Marshal(profile)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateUsageProfile: encode usage profile: %w", err)
+		}
+		project.UsageProfile = datatypes.JSON(raw)
+	}
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("UpdateUsageProfile: %w", err)
+	}
+	return profile, nil
+}
+
+// usageProfileWithEdgeTraffic returns the usage profile of a new snapshot: the source
+// snapshot's profile, with its traffic between resources replaced by the traffic annotations
+// of the saved diagram edges. When no edge is annotated the profile is kept as is, so clients
+// unaware of annotations do not drop traffic set through the usage profile.
+func usageProfileWithEdgeTraffic(raw datatypes.JSON, req *dto.UpdateArchitectureRequest) datatypes.JSON {
+	labels := make(map[string]string, len(req.Nodes))
+	for _, node := range req.Nodes {
+		labels[node.ID] = node.Data.Label
+	}
+	var annotated []pricing.EdgeTraffic
+	for _, edge := range req.Edges {
+		source, target := labels[edge.Source], labels[edge.Target]
+		if edge.TrafficGBPerMonth > 0 && source != "" && target != "" {
+			annotated = append(annotated, pricing.EdgeTraffic{Source: source, Target: target, GBPerMonth: edge.TrafficGBPerMonth})
+		}
+	}
+	if len(annotated) == 0 {
+		return raw
+	}
+
+	var profile pricing.UsageProfile
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &profile); err != nil {
+			return raw
+		}
+	}
+
+	transferTypes := make(map[[2]string]string)
+	edges := make([]pricing.EdgeTraffic, 0, len(profile.Edges)+len(annotated))
+	for _, edge := range profile.Edges {
+		if edge.Target == "" {
+			edges = append(edges, edge) // traffic to the internet is not drawn in the diagram
+			continue
+		}
+		transferTypes[[2]string{edge.Source, edge.Target}] = edge.TransferType
+	}
+	for _, edge := range annotated {
+		edge.TransferType = transferTypes[[2]string{edge.Source, edge.Target}]
+		edges = append(edges, edge)
+	}
+	profile.Edges = edges
+
+	merged, err := json.Marshal(profile)
+	if err != nil {
+		return raw
+	}
+	return datatypes.JSON(merged)
+}
+
+// edgeTrafficByResourceID returns the traffic of the usage profile's edges between resources,
+// keyed by source and target resource IDs of the architecture
+func edgeTrafficByResourceID(arch *architecture.Architecture) map[[2]string]float64 {
+	if arch.Usage == nil || len(arch.Usage.Edges) == 0 {
+		return nil
+	}
+	ids := make(map[string]string, len(arch.Resources))
+	for _, res := range arch.Resources {
+		ids[res.Name] = res.ID
+	}
+	traffic := make(map[[2]string]float64)
+	for _, edge := range arch.Usage.Edges {
+		source, target := ids[edge.Source], ids[edge.Target]
+		if source != "" && target != "" {
+			traffic[[2]string{source, target}] += edge.GBPerMonth
+		}
+	}
+	return traffic
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+const (
+	// reviewFlagHideThreshold is the number of flags that hides a review
+	reviewFlagHideThreshold = 3
+	// maxReviewTitleLength is the length of the reviews.title column
+	maxReviewTitleLength = 255
+)
+
+// ReviewServiceImpl implements ReviewService
+type ReviewServiceImpl struct {
+	reviewRepo        serverinterfaces.ReviewRepository
+	instantiationRepo serverinterfaces.TemplateInstantiationRepository
+}
+
+// NewReviewService creates a new review service
+func NewReviewService(
+	reviewRepo serverinterfaces.ReviewRepository,
+	instantiationRepo serverinterfaces.TemplateInstantiationRepository,
+) serverinterfaces.ReviewService {
+	return &ReviewServiceImpl{
+		reviewRepo:        reviewRepo,
+		instantiationRepo: instantiationRepo,
+	}
+}
+
+// List returns the reviews of a template that are not hidden, verified deployments first
+func (s *ReviewServiceImpl) List(ctx context.Context, templateID uuid.UUID, limit, offset int) ([]*models.Review, int64, error) {
+	if limit <= 0 {
+		limit = defaultTemplatePageSize
+	}
+	if limit > maxTemplatePageSize {
+		limit = maxTemplatePageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	reviews, total, err := s.reviewRepo.FindVisibleByTemplate(ctx, templateID, limit, offset)
+	if err != nil {
+		return nil, 0, platformerrors.NewDatabaseQueryFailed("list_reviews", err)
+	}
+	return reviews, total, nil
+}
+
+// Create saves the caller's review of a template. The caller must have created a project
+// from the template and must not have reviewed it yet.
+func (s *ReviewServiceImpl) Create(ctx context.Context, userID, templateID uuid.UUID, req *serverinterfaces.ReviewRequest) (*models.Review, error) {
+	instantiations, err := s.instantiationRepo.FindByTemplateAndUser(ctx, templateID, userID)
+	if err != nil {
+		return nil, platformerrors.NewDatabaseQueryFailed("find_template_instantiations", err)
+	}
+	if len(instantiations) == 0 {
+		return nil, apperrors.Newf(apperrors.CodeValidationFailed, apperrors.KindForbidden, "template %s can only be reviewed after creating a project from it", templateID)
+	}
+	if _, err := s.reviewRepo.FindByTemplateAndUser(ctx, templateID, userID); err == nil {
+		return nil, apperrors.Newf(apperrors.CodeValidationFailed, apperrors.KindConflict, "template %s is already reviewed; edit the existing review instead", templateID)
+	} else if !apperrors.IsKind(err, apperrors.KindNotFound) {
+		return nil, err
+	}
+
+	review := &models.Review{ID: uuid.New(), TemplateID: templateID, UserID: userID}
+	if err := applyReviewRequest(review, req); err != nil {
+		return nil, err
+	}
+	for _, inst := range instantiations {
+		if inst.CodeGeneratedAt != nil {
+			review.VerifiedDeployment = true
+			break
+		}
+	}
+
+	err = s.withRatingUpdate(ctx, templateID, func(txCtx context.Context) error {
+		if err := s.reviewRepo.Create(txCtx, review); err != nil {
+			return platformerrors.NewRepositoryCreateFailed("review", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.FindByID(ctx, review.ID)
+}
+
+// Update changes the caller's review of a template
+func (s *ReviewServiceImpl) Update(ctx context.Context, userID, templateID, reviewID uuid.UUID, req *serverinterfaces.ReviewRequest) (*models.Review, error) {
+	review, err := s.get(ctx, templateID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID != userID {
+		return nil, platformerrors.NewAuthForbidden("only the author of a review can edit it")
+	}
+	if err := applyReviewRequest(review, req); err != nil {
+		return nil, err
+	}
+
+	err = s.withRatingUpdate(ctx, templateID, func(txCtx context.Context) error {
+		if err := s.reviewRepo.Update(txCtx, review); err != nil {
+			return platformerrors.NewRepositoryUpdateFailed("review", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.FindByID(ctx, review.ID)
+}
+
+// Delete removes the caller's review of a template
+func (s *ReviewServiceImpl) Delete(ctx context.Context, userID, templateID, reviewID uuid.UUID) error {
+	review, err := s.get(ctx, templateID, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID != userID {
+		return platformerrors.NewAuthForbidden("only the author of a review can delete it")
+	}
+	return s.withRatingUpdate(ctx, templateID, func(txCtx context.Context) error {
+		if err := s.reviewRepo.Delete(txCtx, reviewID); err != nil {
+			return platformerrors.NewRepositoryDeleteFailed("review", err)
+		}
+		return nil
+	})
+}
+
+// Flag reports a review for moderation. Each user flags a review at most once and cannot
+// flag their own; the review is hidden, and no longer counts towards the template's
+// rating, once reviewFlagHideThreshold users flagged it.
+func (s *ReviewServiceImpl) Flag(ctx context.Context, userID, templateID, reviewID uuid.UUID, reason *string) (*models.Review, error) {
+	review, err := s.get(ctx, templateID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID == userID {
+		return nil, apperrors.New(apperrors.CodeInvalidValue, apperrors.KindValidation, "a review cannot be flagged by its author")
+	}
+	if reason != nil {
+		trimmed := strings.TrimSpace(*reason)
+		reason = &trimmed
+		if trimmed == "" {
+			reason = nil
+		}
+	}
+
+	err = s.withRatingUpdate(ctx, templateID, func(txCtx context.Context) error {
+		if err := s.reviewRepo.CreateFlag(txCtx, &models.ReviewFlag{ReviewID: reviewID, UserID: userID, Reason: reason}); err != nil {
+			if apperrors.IsKind(err, apperrors.KindConflict) {
+				return apperrors.New(apperrors.CodeValidationFailed, apperrors.KindConflict, "review is already flagged")
+			}
+			return platformerrors.NewRepositoryCreateFailed("review_flag", err)
+		}
+		if err := s.reviewRepo.IncrementFlagCount(txCtx, reviewID, reviewFlagHideThreshold); err != nil {
+			return platformerrors.NewRepositoryUpdateFailed("review", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.FindByID(ctx, reviewID)
+}
+
+// RecordCodeGeneration marks the reviews of the templates a project was created from as
+// verified deployments. Later versions of the project count as well.
+func (s *ReviewServiceImpl) RecordCodeGeneration(ctx context.Context, project *models.Project) error {
+	rootID := project.ID
+	if project.RootProjectID != nil {
+		rootID = *project.RootProjectID
+	}
+	instantiations, err := s.instantiationRepo.MarkCodeGenerated(ctx, rootID)
+	if err != nil {
+		return platformerrors.NewRepositoryUpdateFailed("template_instantiation", err)
+	}
+	for _, inst := range instantiations {
+		if err := s.reviewRepo.SetVerifiedDeployment(ctx, inst.TemplateID, inst.UserID); err != nil {
+			return platformerrors.NewRepositoryUpdateFailed("review", err)
+		}
+	}
+	return nil
+}
+
+// get returns a review of a template. Reviews of other templates are reported as not found.
+func (s *ReviewServiceImpl) get(ctx context.Context, templateID, reviewID uuid.UUID) (*models.Review, error) {
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.TemplateID != templateID {
+		return nil, platformerrors.NewRepositoryNotFound("review", reviewID)
+	}
+	return review, nil
+}
+
+// withRatingUpdate runs fn and recalculates the template's rating and review count in
+// one transaction, so that the aggregates never disagree with the reviews.
+func (s *ReviewServiceImpl) withRatingUpdate(ctx context.Context, templateID uuid.UUID, fn func(txCtx context.Context) error) error {
+	tx, txCtx := s.reviewRepo.BeginTransaction(ctx)
+	defer func() {
+		if r := recover(); r != nil {
+			s.reviewRepo.RollbackTransaction(tx)
+			panic(r)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		s.reviewRepo.RollbackTransaction(tx)
+		return err
+	}
+	if err := s.reviewRepo.RecalculateTemplateRating(txCtx, templateID); err != nil {
+		s.reviewRepo.RollbackTransaction(tx)
+		return err
+	}
+	if err := s.reviewRepo.CommitTransaction(tx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// applyReviewRequest copies the set fields of a request onto a review and validates the result
+func applyReviewRequest(review *models.Review, req *serverinterfaces.ReviewRequest) error {
+	if req.Rating != nil {
+		review.Rating = *req.Rating
+	}
+	if req.Title != nil {
+		review.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Content != nil {
+		review.Content = strings.TrimSpace(*req.Content)
+	}
+	if req.UseCase != nil {
+		review.UseCase = req.UseCase
+	}
+	if req.TeamSize != nil {
+		review.TeamSize = req.TeamSize
+	}
+	if req.DeploymentTime != nil {
+		review.DeploymentTime = req.DeploymentTime
+	}
+
+	if review.Rating < 1 || review.Rating > 5 {
+		return apperrors.Newf(apperrors.CodeValueOutOfRange, apperrors.KindValidation, "rating must be between 1 and 5, got %d", review.Rating)
+	}
+	if review.Title == "" {
+		return apperrors.New(apperrors.CodeRequiredFieldMissing, apperrors.KindValidation, "review title is required")
+	}
+	if len(review.Title) > maxReviewTitleLength {
+		return apperrors.Newf(apperrors.CodeValueOutOfRange, apperrors.KindValidation, "review title must be at most %d characters", maxReviewTitleLength)
+	}
+	if review.Content == "" {
+		return apperrors.New(apperrors.CodeRequiredFieldMissing, apperrors.KindValidation, "review content is required")
+	}
+	return nil
+}
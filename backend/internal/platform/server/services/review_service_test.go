@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"gorm.io/gorm"
+)
+
+// mockReviewRepository keeps reviews in memory and tracks template ratings
+type mockReviewRepository struct {
+	reviews   map[uuid.UUID]*models.Review
+	flags     map[[2]uuid.UUID]bool
+	ratings   map[uuid.UUID]float64
+	counts    map[uuid.UUID]int
+	commits   int
+	rollbacks int
+}
+
+func newMockReviewRepository() *mockReviewRepository {
+	return &mockReviewRepository{
+		reviews: map[uuid.UUID]*models.Review{},
+		flags:   map[[2]uuid.UUID]bool{},
+		ratings: map[uuid.UUID]float64{},
+		counts:  map[uuid.UUID]int{},
+	}
+}
+
+func (m *mockReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	copied := *review
+	m.reviews[review.ID] = &copied
+	return nil
+}
+
+func (m *mockReviewRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	review, ok := m.reviews[id]
+	if !ok {
+		return nil, platformerrors.NewRepositoryNotFound("review", id)
+	}
+	copied := *review
+	return &copied, nil
+}
+
+func (m *mockReviewRepository) FindByTemplateAndUser(ctx context.Context, templateID, userID uuid.UUID) (*models.Review, error) {
+	for _, review := range m.reviews {
+		if review.TemplateID == templateID && review.UserID == userID {
+			copied := *review
+			return &copied, nil
+		}
+	}
+	return nil, platformerrors.NewRepositoryNotFound("review", userID)
+}
+
+func (m *mockReviewRepository) FindVisibleByTemplate(ctx context.Context, templateID uuid.UUID, limit, offset int) ([]*models.Review, int64, error) {
+	var out []*models.Review
+	for _, review := range m.reviews {
+		if review.TemplateID == templateID && !review.Hidden {
+			out = append(out, review)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (m *mockReviewRepository) Update(ctx context.Context, review *models.Review) error {
+	return m.Create(ctx, review)
+}
+
+func (m *mockReviewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(m.reviews, id)
+	return nil
+}
+
+func (m *mockReviewRepository) CreateFlag(ctx context.Context, flag *models.ReviewFlag) error {
+	key := [2]uuid.UUID{flag.ReviewID, flag.UserID}
+	if m.flags[key] {
+		return platformerrors.NewRepositoryDuplicateEntry("review_flag", "user_id", flag.UserID)
+	}
+	m.flags[key] = true
+	return nil
+}
+
+func (m *mockReviewRepository) IncrementFlagCount(ctx context.Context, id uuid.UUID, hideAt int) error {
+	review := m.reviews[id]
+	review.FlagCount++
+	review.Hidden = review.Hidden || review.FlagCount >= hideAt
+	return nil
+}
+
+func (m *mockReviewRepository) SetVerifiedDeployment(ctx context.Context, templateID, userID uuid.UUID) error {
+	for _, review := range m.reviews {
+		if review.TemplateID == templateID && review.UserID == userID {
+			review.VerifiedDeployment = true
+		}
+	}
+	return nil
+}
+
+func (m *mockReviewRepository) RecalculateTemplateRating(ctx context.Context, templateID uuid.UUID) error {
+	sum, count := 0, 0
+	for _, review := range m.reviews {
+		if review.TemplateID == templateID && !review.Hidden {
+			sum += review.Rating
+			count++
+		}
+	}
+	m.ratings[templateID], m.counts[templateID] = 0, count
+	if count > 0 {
+		m.ratings[templateID] = float64(sum) / float64(count)
+	}
+	return nil
+}
+
+func (m *mockReviewRepository) BeginTransaction(ctx context.Context) (*gorm.DB, context.Context) {
+	return nil, ctx
+}
+
+func (m *mockReviewRepository) CommitTransaction(tx *gorm.DB) error {
+	m.commits++
+	return nil
+}
+
+func (m *mockReviewRepository) RollbackTransaction(tx *gorm.DB) error {
+	m.rollbacks++
+	return nil
+}
+
+// mockTemplateInstantiationRepository keeps template instantiations in memory
+type mockTemplateInstantiationRepository struct {
+	instantiations []*models.TemplateInstantiation
+}
+
+func (m *mockTemplateInstantiationRepository) Create(ctx context.Context, instantiation *models.TemplateInstantiation) error {
+	m.instantiations = append(m.instantiations, instantiation)
+	return nil
+}
+
+func (m *mockTemplateInstantiationRepository) FindByTemplateAndUser(ctx context.Context, templateID, userID uuid.UUID) ([]*models.TemplateInstantiation, error) {
+	var out []*models.TemplateInstantiation
+	for _, inst := range m.instantiations {
+		if inst.TemplateID == templateID && inst.UserID == userID {
+			out = append(out, inst)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTemplateInstantiationRepository) MarkCodeGenerated(ctx context.Context, projectID uuid.UUID) ([]*models.TemplateInstantiation, error) {
+	var out []*models.TemplateInstantiation
+	now := time.Now()
+	for _, inst := range m.instantiations {
+		if inst.ProjectID == projectID {
+			if inst.CodeGeneratedAt == nil {
+				inst.CodeGeneratedAt = &now
+			}
+			out = append(out, inst)
+		}
+	}
+	return out, nil
+}
+
+func reviewRequest(rating int, title string) *serverinterfaces.ReviewRequest {
+	content := "Deployed it in an afternoon"
+	return &serverinterfaces.ReviewRequest{Rating: &rating, Title: &title, Content: &content}
+}
+
+func TestReviewService_CreateRequiresInstantiationAndIsUnique(t *testing.T) {
+	ctx := context.Background()
+	reviews := newMockReviewRepository()
+	instantiations := &mockTemplateInstantiationRepository{}
+	svc := NewReviewService(reviews, instantiations)
+	templateID, userID := uuid.New(), uuid.New()
+
+	if _, err := svc.Create(ctx, userID, templateID, reviewRequest(5, "Great")); !apperrors.IsKind(err, apperrors.KindForbidden) {
+		t.Fatalf("Create before instantiating: err = %v, want forbidden", err)
+	}
+
+	instantiations.Create(ctx, &models.TemplateInstantiation{ID: uuid.New(), TemplateID: templateID, UserID: userID, ProjectID: uuid.New()})
+	if _, err := svc.Create(ctx, userID, templateID, reviewRequest(6, "Great")); !apperrors.IsKind(err, apperrors.KindValidation) {
+		t.Errorf("Create with rating 6: err = %v, want validation", err)
+	}
+	review, err := svc.Create(ctx, userID, templateID, reviewRequest(4, "  Great  "))
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if review.Title != "Great" || review.VerifiedDeployment {
+		t.Errorf("Create = %+v, want trimmed title and no verified badge", review)
+	}
+	if reviews.ratings[templateID] != 4 || reviews.counts[templateID] != 1 || reviews.commits != 1 {
+		t.Errorf("rating = %v over %d reviews after %d commits, want 4 over 1 after 1", reviews.ratings[templateID], reviews.counts[templateID], reviews.commits)
+	}
+
+	if _, err := svc.Create(ctx, userID, templateID, reviewRequest(1, "Again")); !apperrors.IsKind(err, apperrors.KindConflict) {
+		t.Errorf("second Create: err = %v, want conflict", err)
+	}
+}
+
+func TestReviewService_UpdateDeleteAndFlag(t *testing.T) {
+	ctx := context.Background()
+	reviews := newMockReviewRepository()
+	instantiations := &mockTemplateInstantiationRepository{}
+	svc := NewReviewService(reviews, instantiations)
+	templateID := uuid.New()
+
+	users := make([]uuid.UUID, 5)
+	created := make([]*models.Review, 2)
+	for i := range users {
+		users[i] = uuid.New()
+		instantiations.Create(ctx, &models.TemplateInstantiation{ID: uuid.New(), TemplateID: templateID, UserID: users[i], ProjectID: uuid.New()})
+	}
+	for i := range created {
+		review, err := svc.Create(ctx, users[i], templateID, reviewRequest(5-3*i, "Review"))
+		if err != nil {
+			t.Fatalf("Create error: %v", err)
+		}
+		created[i] = review
+	}
+	if reviews.ratings[templateID] != 3.5 {
+		t.Fatalf("rating = %v, want 3.5", reviews.ratings[templateID])
+	}
+
+	rating := 4
+	if _, err := svc.Update(ctx, users[1], templateID, created[0].ID, &serverinterfaces.ReviewRequest{Rating: &rating}); !apperrors.IsKind(err, apperrors.KindForbidden) {
+		t.Errorf("Update by another user: err = %v, want forbidden", err)
+	}
+	if _, err := svc.Update(ctx, users[0], uuid.New(), created[0].ID, &serverinterfaces.ReviewRequest{Rating: &rating}); !apperrors.IsKind(err, apperrors.KindNotFound) {
+		t.Errorf("Update under another template: err = %v, want not found", err)
+	}
+	if _, err := svc.Update(ctx, users[0], templateID, created[0].ID, &serverinterfaces.ReviewRequest{Rating: &rating}); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if reviews.ratings[templateID] != 3 {
+		t.Errorf("rating after update = %v, want 3", reviews.ratings[templateID])
+	}
+
+	// Flags: not by the author, once per user, hidden at the threshold
+	if _, err := svc.Flag(ctx, users[1], templateID, created[1].ID, nil); !apperrors.IsKind(err, apperrors.KindValidation) {
+		t.Errorf("Flag by the author: err = %v, want validation", err)
+	}
+	for i := 0; i < reviewFlagHideThreshold; i++ {
+		flagged, err := svc.Flag(ctx, users[2+i], templateID, created[1].ID, nil)
+		if err != nil {
+			t.Fatalf("Flag error: %v", err)
+		}
+		if flagged.Hidden != (i == reviewFlagHideThreshold-1) {
+			t.Errorf("after %d flags hidden = %v", i+1, flagged.Hidden)
+		}
+	}
+	if _, err := svc.Flag(ctx, users[2], templateID, created[1].ID, nil); !apperrors.IsKind(err, apperrors.KindConflict) {
+		t.Errorf("second Flag by the same user: err = %v, want conflict", err)
+	}
+	if reviews.ratings[templateID] != 4 || reviews.counts[templateID] != 1 {
+		t.Errorf("rating after hiding = %v over %d reviews, want 4 over 1", reviews.ratings[templateID], reviews.counts[templateID])
+	}
+	if reviews.rollbacks != 1 {
+		t.Errorf("rollbacks = %d, want 1 for the rejected flag", reviews.rollbacks)
+	}
+
+	if err := svc.Delete(ctx, users[0], templateID, created[0].ID); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if reviews.ratings[templateID] != 0 || reviews.counts[templateID] != 0 {
+		t.Errorf("rating after delete = %v over %d reviews, want 0 over 0", reviews.ratings[templateID], reviews.counts[templateID])
+	}
+}
+
+func TestReviewService_RecordCodeGenerationVerifiesReviews(t *testing.T) {
+	ctx := context.Background()
+	reviews := newMockReviewRepository()
+	instantiations := &mockTemplateInstantiationRepository{}
+	svc := NewReviewService(reviews, instantiations)
+	templateID, userID, rootID := uuid.New(), uuid.New(), uuid.New()
+	instantiations.Create(ctx, &models.TemplateInstantiation{ID: uuid.New(), TemplateID: templateID, UserID: userID, ProjectID: rootID})
+
+	review, err := svc.Create(ctx, userID, templateID, reviewRequest(5, "Great"))
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if review.VerifiedDeployment {
+		t.Fatal("expected no verified badge before code generation")
+	}
+
+	// Code generated from a later version of the project counts
+	if err := svc.RecordCodeGeneration(ctx, &models.Project{ID: uuid.New(), RootProjectID: &rootID}); err != nil {
+		t.Fatalf("RecordCodeGeneration error: %v", err)
+	}
+	if !reviews.reviews[review.ID].VerifiedDeployment {
+		t.Error("expected the review to be a verified deployment")
+	}
+
+	// Reviews written after code generation are verified right away
+	otherUser := uuid.New()
+	otherRoot := uuid.New()
+	instantiations.Create(ctx, &models.TemplateInstantiation{ID: uuid.New(), TemplateID: templateID, UserID: otherUser, ProjectID: otherRoot})
+	if err := svc.RecordCodeGeneration(ctx, &models.Project{ID: otherRoot}); err != nil {
+		t.Fatalf("RecordCodeGeneration error: %v", err)
+	}
+	review, err = svc.Create(ctx, otherUser, templateID, reviewRequest(3, "Fine"))
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if !review.VerifiedDeployment {
+		t.Error("expected a review after code generation to be a verified deployment")
+	}
+}
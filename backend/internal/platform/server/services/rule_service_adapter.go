@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	awsrules "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/rules"
+	azurerules "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/azure/rules"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 )
 
@@ -22,17 +23,7 @@ func NewAWSRuleServiceAdapter() serverinterfaces.RuleService {
 
 // LoadRulesWithDefaults loads rules from database constraints and merges with defaults
 func (a *awsRuleServiceAdapter) LoadRulesWithDefaults(ctx context.Context, dbConstraints []serverinterfaces.ConstraintRecord) error {
-	// Convert serverinterfaces.ConstraintRecord to awsrules.ConstraintRecord
-	constraints := make([]awsrules.ConstraintRecord, len(dbConstraints))
-	for i, c := range dbConstraints {
-		constraints[i] = awsrules.ConstraintRecord{
-			ResourceType:    c.ResourceType,
-			ConstraintType:  c.ConstraintType,
-			ConstraintValue: c.ConstraintValue,
-		}
-	}
-
-	return a.service.LoadRulesWithDefaults(ctx, constraints)
+	return a.service.LoadRulesWithDefaults(ctx, toEngineConstraints(dbConstraints))
 }
 
 // ValidateArchitecture validates all resources in an architecture
@@ -58,3 +49,53 @@ func (a *awsRuleServiceAdapter) ValidateArchitecture(ctx context.Context, archit
 
 	return resultMap, nil
 }
+
+// azureRuleServiceAdapter adapts azurerules.AzureRuleService to serverinterfaces.RuleService
+type azureRuleServiceAdapter struct {
+	service *azurerules.AzureRuleService
+}
+
+// NewAzureRuleServiceAdapter creates a new Azure rule service adapter
+func NewAzureRuleServiceAdapter() serverinterfaces.RuleService {
+	return &azureRuleServiceAdapter{
+		service: azurerules.NewAzureRuleService(),
+	}
+}
+
+// LoadRulesWithDefaults loads rules from database constraints and merges with defaults
+func (a *azureRuleServiceAdapter) LoadRulesWithDefaults(ctx context.Context, dbConstraints []serverinterfaces.ConstraintRecord) error {
+	return a.service.LoadRulesWithDefaults(ctx, toEngineConstraints(dbConstraints))
+}
+
+// ValidateArchitecture validates all resources in an architecture
+func (a *azureRuleServiceAdapter) ValidateArchitecture(ctx context.Context, architecture interface{}) (map[string]interface{}, error) {
+	engineArch, ok := architecture.(*awsrules.Architecture)
+	if !ok {
+		return nil, fmt.Errorf("invalid architecture type, expected *rules.Architecture")
+	}
+
+	results, err := a.service.ValidateArchitecture(ctx, engineArch)
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap := make(map[string]interface{})
+	for resID, result := range results {
+		resultMap[resID] = result
+	}
+
+	return resultMap, nil
+}
+
+// toEngineConstraints converts serverinterfaces.ConstraintRecord to the rules engine's ConstraintRecord
+func toEngineConstraints(dbConstraints []serverinterfaces.ConstraintRecord) []awsrules.ConstraintRecord {
+	constraints := make([]awsrules.ConstraintRecord, len(dbConstraints))
+	for i, c := range dbConstraints {
+		constraints[i] = awsrules.ConstraintRecord{
+			ResourceType:    c.ResourceType,
+			ConstraintType:  c.ConstraintType,
+			ConstraintValue: c.ConstraintValue,
+		}
+	}
+	return constraints
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+	"gorm.io/gorm"
+)
+
+// snapshotStore keeps project snapshots, their version chain and their architecture in
+// memory, behind the repository interfaces the project service is built from
+type snapshotStore struct {
+	projects      map[uuid.UUID]*models.Project
+	versions      []*models.ProjectVersion
+	resources     []*models.Resource
+	containments  []*models.ResourceContainment
+	dependencies  []*models.ResourceDependency
+	variables     []*models.ProjectVariable
+	outputs       []*models.ProjectOutput
+	resourceTypes map[string]*models.ResourceType
+}
+
+func newSnapshotStore() *snapshotStore {
+	return &snapshotStore{
+		projects:      map[uuid.UUID]*models.Project{},
+		resourceTypes: map[string]*models.ResourceType{},
+	}
+}
+
+// projectService builds a project service without pricing over the store
+func (s *snapshotStore) projectService() *ProjectServiceImpl {
+	return s.projectServiceWithPricing(nil)
+}
+
+func (s *snapshotStore) projectServiceWithPricing(pricingService serverinterfaces.PricingService) *ProjectServiceImpl {
+	svc := NewProjectServiceWithPricing(
+		snapshotProjectRepository{s}, snapshotVersionRepository{s}, snapshotResourceRepository{s},
+		snapshotResourceTypeRepository{s}, snapshotContainmentRepository{s}, snapshotDependencyRepository{s},
+		snapshotDependencyTypeRepository{}, nil, nil, snapshotVariableRepository{s}, snapshotOutputRepository{s},
+		pricingService,
+	)
+	return svc.(*ProjectServiceImpl)
+}
+
+// createProject saves a root project and its first version
+func (s *snapshotStore) createProject(ctx context.Context, svc *ProjectServiceImpl, req *serverinterfaces.CreateVersionRequest) (*models.Project, *serverinterfaces.ProjectVersionDetail, error) {
+	root := &models.Project{ID: uuid.New(), UserID: uuid.New(), Name: "shop", CloudProvider: "aws", Region: "us-east-1"}
+	s.projects[root.ID] = root
+	version, err := svc.CreateVersion(ctx, root.ID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, version, nil
+}
+
+func (s *snapshotStore) version(id uuid.UUID) *models.ProjectVersion {
+	for _, v := range s.versions {
+		if v.ID == id {
+			return v
+		}
+	}
+	return nil
+}
+
+// vpcVersion builds a version request of VPCs given as node ID and name pairs
+func vpcVersion(message string, vpcs ...[2]string) *serverinterfaces.CreateVersionRequest {
+	req := &serverinterfaces.CreateVersionRequest{Message: message}
+	for _, vpc := range vpcs {
+		req.Nodes = append(req.Nodes, dto.ArchitectureNode{
+			ID:   vpc[0],
+			Type: "VPC",
+			Data: dto.ArchitectureNodeData{
+				Label:        vpc[1],
+				ResourceType: "VPC",
+				Config:       map[string]interface{}{"cidr": "10.0.0.0/16", "name": vpc[1]},
+			},
+		})
+	}
+	return req
+}
+
+type snapshotProjectRepository struct{ s *snapshotStore }
+
+func (r snapshotProjectRepository) Create(ctx context.Context, project *models.Project) error {
+	copied := *project
+	r.s.projects[project.ID] = &copied
+	return nil
+}
+
+func (r snapshotProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	project, ok := r.s.projects[id]
+	if !ok {
+		return nil, platformerrors.NewRepositoryNotFound("project", id)
+	}
+	copied := *project
+	return &copied, nil
+}
+
+func (r snapshotProjectRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Project, error) {
+	return nil, nil
+}
+
+// FindAll lists the user's root projects, without pagination
+func (r snapshotProjectRepository) FindAll(ctx context.Context, userID uuid.UUID, page, limit int, sort, order, search string) ([]*models.Project, int64, error) {
+	var out []*models.Project
+	for _, p := range r.s.projects {
+		if p.UserID == userID && p.RootProjectID == nil {
+			out = append(out, p)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (r snapshotProjectRepository) FindByRootProjectID(ctx context.Context, rootProjectID uuid.UUID) ([]*models.Project, error) {
+	var out []*models.Project
+	for _, p := range r.s.projects {
+		if p.ID == rootProjectID || (p.RootProjectID != nil && *p.RootProjectID == rootProjectID) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r snapshotProjectRepository) Update(ctx context.Context, project *models.Project) error {
+	return r.Create(ctx, project)
+}
+
+func (r snapshotProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.s.projects, id)
+	return nil
+}
+
+func (r snapshotProjectRepository) BeginTransaction(ctx context.Context) (*gorm.DB, context.Context) {
+	return nil, ctx
+}
+
+func (r snapshotProjectRepository) CommitTransaction(tx *gorm.DB) error   { return nil }
+func (r snapshotProjectRepository) RollbackTransaction(tx *gorm.DB) error { return nil }
+
+type snapshotVersionRepository struct{ s *snapshotStore }
+
+func (r snapshotVersionRepository) Create(ctx context.Context, version *models.ProjectVersion) error {
+	copied := *version
+	r.s.versions = append(r.s.versions, &copied)
+	return nil
+}
+
+func (r snapshotVersionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.ProjectVersion, error) {
+	if v := r.s.version(id); v != nil {
+		copied := *v
+		return &copied, nil
+	}
+	return nil, platformerrors.NewRepositoryNotFound("project version", id)
+}
+
+func (r snapshotVersionRepository) ListByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ProjectVersion, error) {
+	var out []*models.ProjectVersion
+	for _, v := range r.s.versions {
+		if v.ProjectID == projectID {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func (r snapshotVersionRepository) ListByRootProjectID(ctx context.Context, rootProjectID uuid.UUID) ([]*models.ProjectVersion, error) {
+	var out []*models.ProjectVersion
+	for _, v := range r.s.versions {
+		p := r.s.projects[v.ProjectID]
+		if p != nil && (p.ID == rootProjectID || (p.RootProjectID != nil && *p.RootProjectID == rootProjectID)) {
+			out = append(out, v)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].VersionNumber < out[j].VersionNumber })
+	return out, nil
+}
+
+func (r snapshotVersionRepository) GetLatestVersionForProject(ctx context.Context, projectID uuid.UUID) (*models.ProjectVersion, error) {
+	var latest *models.ProjectVersion
+	for _, v := range r.s.versions {
+		if v.ProjectID == projectID && (latest == nil || v.VersionNumber > latest.VersionNumber) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return nil, platformerrors.NewRepositoryNotFound("project version", projectID)
+	}
+	return latest, nil
+}
+
+func (r snapshotVersionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, v := range r.s.versions {
+		if v.ID == id {
+			r.s.versions = append(r.s.versions[:i], r.s.versions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+type snapshotResourceRepository struct{ s *snapshotStore }
+
+func (r snapshotResourceRepository) Create(ctx context.Context, resource *models.Resource) error {
+	copied := *resource
+	for _, rt := range r.s.resourceTypes {
+		if rt.ID == resource.ResourceTypeID {
+			copied.ResourceType = *rt
+		}
+	}
+	r.s.resources = append(r.s.resources, &copied)
+	return nil
+}
+
+func (r snapshotResourceRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Resource, error) {
+	for _, res := range r.s.resources {
+		if res.ID == id {
+			return res, nil
+		}
+	}
+	return nil, platformerrors.NewRepositoryNotFound("resource", id)
+}
+
+func (r snapshotResourceRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Resource, error) {
+	var out []*models.Resource
+	for _, res := range r.s.resources {
+		if res.ProjectID == projectID {
+			out = append(out, res)
+		}
+	}
+	return out, nil
+}
+
+func (r snapshotResourceRepository) DeleteByProjectID(ctx context.Context, projectID uuid.UUID) error {
+	return nil
+}
+
+func (r snapshotResourceRepository) CreateContainment(ctx context.Context, parentID, childID uuid.UUID) error {
+	return nil
+}
+
+func (r snapshotResourceRepository) CreateDependency(ctx context.Context, dependency *models.ResourceDependency) error {
+	return nil
+}
+
+// inProject reports whether a resource ID belongs to the project
+func (s *snapshotStore) inProject(resourceID, projectID uuid.UUID) bool {
+	for _, res := range s.resources {
+		if res.ID == resourceID {
+			return res.ProjectID == projectID
+		}
+	}
+	return false
+}
+
+type snapshotResourceTypeRepository struct{ s *snapshotStore }
+
+func (r snapshotResourceTypeRepository) FindByNameAndProvider(ctx context.Context, name, provider string) (*models.ResourceType, error) {
+	rt, ok := r.s.resourceTypes[name]
+	if !ok {
+		rt = &models.ResourceType{ID: uint(len(r.s.resourceTypes) + 1), Name: name, CloudProvider: provider, IsRegional: true}
+		r.s.resourceTypes[name] = rt
+	}
+	return rt, nil
+}
+
+func (r snapshotResourceTypeRepository) ListByProvider(ctx context.Context, provider string) ([]*models.ResourceType, error) {
+	return nil, nil
+}
+
+type snapshotDependencyTypeRepository struct{}
+
+func (snapshotDependencyTypeRepository) FindByName(ctx context.Context, name string) (*models.DependencyType, error) {
+	return &models.DependencyType{ID: 1, Name: name}, nil
+}
+
+type snapshotContainmentRepository struct{ s *snapshotStore }
+
+func (r snapshotContainmentRepository) Create(ctx context.Context, containment *models.ResourceContainment) error {
+	r.s.containments = append(r.s.containments, containment)
+	return nil
+}
+
+func (r snapshotContainmentRepository) FindChildren(ctx context.Context, parentID uuid.UUID) ([]*models.ResourceContainment, error) {
+	return nil, nil
+}
+
+func (r snapshotContainmentRepository) FindParents(ctx context.Context, childID uuid.UUID) ([]*models.ResourceContainment, error) {
+	return nil, nil
+}
+
+func (r snapshotContainmentRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ResourceContainment, error) {
+	var out []*models.ResourceContainment
+	for _, c := range r.s.containments {
+		if r.s.inProject(c.ParentResourceID, projectID) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+type snapshotDependencyRepository struct{ s *snapshotStore }
+
+func (r snapshotDependencyRepository) Create(ctx context.Context, dependency *models.ResourceDependency) error {
+	r.s.dependencies = append(r.s.dependencies, dependency)
+	return nil
+}
+
+func (r snapshotDependencyRepository) FindByFromResource(ctx context.Context, fromID uuid.UUID) ([]*models.ResourceDependency, error) {
+	return nil, nil
+}
+
+func (r snapshotDependencyRepository) FindByToResource(ctx context.Context, toID uuid.UUID) ([]*models.ResourceDependency, error) {
+	return nil, nil
+}
+
+func (r snapshotDependencyRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ResourceDependency, error) {
+	var out []*models.ResourceDependency
+	for _, d := range r.s.dependencies {
+		if r.s.inProject(d.FromResourceID, projectID) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+type snapshotVariableRepository struct{ s *snapshotStore }
+
+func (r snapshotVariableRepository) Create(ctx context.Context, variable *models.ProjectVariable) error {
+	r.s.variables = append(r.s.variables, variable)
+	return nil
+}
+
+func (r snapshotVariableRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ProjectVariable, error) {
+	var out []*models.ProjectVariable
+	for _, v := range r.s.variables {
+		if v.ProjectID == projectID {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func (r snapshotVariableRepository) DeleteByProjectID(ctx context.Context, projectID uuid.UUID) error {
+	return nil
+}
+
+type snapshotOutputRepository struct{ s *snapshotStore }
+
+func (r snapshotOutputRepository) Create(ctx context.Context, output *models.ProjectOutput) error {
+	r.s.outputs = append(r.s.outputs, output)
+	return nil
+}
+
+func (r snapshotOutputRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ProjectOutput, error) {
+	var out []*models.ProjectOutput
+	for _, o := range r.s.outputs {
+		if o.ProjectID == projectID {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func (r snapshotOutputRepository) DeleteByProjectID(ctx context.Context, projectID uuid.UUID) error {
+	return nil
+}
@@ -0,0 +1,886 @@
+package services
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/global"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
+)
+
+// Template parameter types. Region and az_count are built in; the others are the types of
+// the architecture's input variables.
+const (
+	templateParamRegion  = "region"
+	templateParamAZCount = "az_count"
+	templateParamString  = "string"
+	templateParamCIDR    = "cidr"
+	templateParamNumber  = "number"
+	templateParamBool    = "bool"
+	templateParamList    = "list"
+	templateParamMap     = "map"
+)
+
+// maxTemplateAZCount caps az_count; regions with a known list of zones cap it further
+const maxTemplateAZCount = 6
+
+// templateVarPattern matches var.variable_name references, as the canvas parser does
+var templateVarPattern = regexp.MustCompile(`var\.([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+// templateParameters derives the parameters of an architecture: its region, its number of
+// availability zones when it places subnets in zones, and its input variables. Sensitive
+// variables and variables without a value have no default and must be chosen.
+func templateParameters(state *dto.ArchitectureResponse, cloudProvider, region string) []models.TemplateParameter {
+	regionParam := models.TemplateParameter{
+		Name:        templateParamRegion,
+		Type:        templateParamRegion,
+		Description: "Region the project is deployed to",
+		Required:    region == "",
+	}
+	if region != "" {
+		regionParam.Default = region
+	}
+	if strings.EqualFold(cloudProvider, "aws") {
+		regionParam.Options = append([]string{}, global.GetAllRegions()...)
+		if region != "" && global.GetRegionByName(region) == "" {
+			regionParam.Options = append(regionParam.Options, region)
+		}
+	}
+	params := []models.TemplateParameter{regionParam}
+
+	if zones := templateZones(state); len(zones) > 0 {
+		min, max := 1, maxTemplateAZCount
+		params = append(params, models.TemplateParameter{
+			Name:        templateParamAZCount,
+			Type:        templateParamAZCount,
+			Description: "Number of availability zones; subnets and their NAT gateways are repeated in each zone",
+			Default:     len(zones),
+			Min:         &min,
+			Max:         &max,
+		})
+	}
+
+	for _, v := range state.Variables {
+		// A variable named after a built-in parameter takes that parameter's value
+		if v.Name == templateParamRegion || v.Name == templateParamAZCount {
+			continue
+		}
+		param := models.TemplateParameter{
+			Name:        v.Name,
+			Type:        variableParameterType(v),
+			Description: v.Description,
+			Sensitive:   v.Sensitive,
+		}
+		if v.Sensitive || v.Value == nil {
+			param.Required = true
+		} else {
+			param.Default = v.Value
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// variableParameterType maps an input variable type to a parameter type. String variables
+// holding a CIDR block become cidr parameters.
+func variableParameterType(v dto.ArchitectureVariable) string {
+	varType := strings.ToLower(strings.TrimSpace(v.Type))
+	switch {
+	case varType == "number":
+		return templateParamNumber
+	case varType == "bool":
+		return templateParamBool
+	case strings.HasPrefix(varType, "list"), strings.HasPrefix(varType, "set"), strings.HasPrefix(varType, "tuple"):
+		return templateParamList
+	case strings.HasPrefix(varType, "map"), strings.HasPrefix(varType, "object"):
+		return templateParamMap
+	}
+	if s, ok := v.Value.(string); ok {
+		if _, _, err := net.ParseCIDR(s); err == nil {
+			return templateParamCIDR
+		}
+	}
+	return templateParamString
+}
+
+// annotateTemplateParameters applies the publisher's descriptions and options to the
+// derived parameters.
+func annotateTemplateParameters(params []models.TemplateParameter, inputs []serverinterfaces.TemplateParameterInput) error {
+	index := make(map[string]int, len(params))
+	for i, p := range params {
+		index[p.Name] = i
+	}
+	for _, input := range inputs {
+		i, ok := index[input.Name]
+		if !ok {
+			return apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "unknown parameter %q", input.Name)
+		}
+		if input.Description != nil {
+			params[i].Description = *input.Description
+		}
+		if len(input.Options) > 0 {
+			params[i].Options = input.Options
+			if params[i].Default != nil && !hasOption(input.Options, params[i].Default) {
+				return apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "default %v of parameter %q is not one of its options", params[i].Default, input.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveTemplateParameters checks the chosen values against the parameters and fills in
+// defaults. Optional parameters without a value or default are left out.
+func resolveTemplateParameters(params []models.TemplateParameter, values map[string]interface{}) (map[string]interface{}, error) {
+	known := make(map[string]bool, len(params))
+	for _, p := range params {
+		known[p.Name] = true
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[name] {
+			return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "unknown parameter %q", name)
+		}
+	}
+
+	resolved := make(map[string]interface{}, len(params))
+	for _, p := range params {
+		value, ok := values[p.Name]
+		if !ok || value == nil {
+			if p.Default == nil {
+				if p.Required {
+					return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "parameter %q is required", p.Name)
+				}
+				continue
+			}
+			value = p.Default
+		}
+		checked, err := checkTemplateParameter(p, value)
+		if err != nil {
+			return nil, err
+		}
+		resolved[p.Name] = checked
+	}
+	return resolved, nil
+}
+
+// checkTemplateParameter validates a value of a parameter. az_count values are returned as int.
+func checkTemplateParameter(p models.TemplateParameter, value interface{}) (interface{}, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "parameter %q "+format, append([]interface{}{p.Name}, args...)...)
+	}
+
+	switch p.Type {
+	case templateParamRegion, templateParamString, templateParamCIDR:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid("must be a string")
+		}
+		if p.Type == templateParamRegion && strings.TrimSpace(s) == "" {
+			return nil, invalid("must not be empty")
+		}
+		if p.Type == templateParamCIDR {
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return nil, invalid("must be a CIDR block such as 10.0.0.0/16")
+			}
+		}
+	case templateParamNumber:
+		n, ok := parameterNumber(value)
+		if !ok {
+			return nil, invalid("must be a number")
+		}
+		value = n
+	case templateParamAZCount:
+		n, ok := parameterNumber(value)
+		if !ok || n != math.Trunc(n) {
+			return nil, invalid("must be a whole number")
+		}
+		min, max := 1, maxTemplateAZCount
+		if p.Min != nil {
+			min = *p.Min
+		}
+		if p.Max != nil {
+			max = *p.Max
+		}
+		if int(n) < min || int(n) > max {
+			return nil, invalid("must be between %d and %d", min, max)
+		}
+		value = int(n)
+	case templateParamBool:
+		if _, ok := value.(bool); !ok {
+			return nil, invalid("must be true or false")
+		}
+	case templateParamList:
+		if _, ok := value.([]interface{}); !ok {
+			return nil, invalid("must be a list")
+		}
+	case templateParamMap:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return nil, invalid("must be an object")
+		}
+	}
+
+	if len(p.Options) > 0 && !hasOption(p.Options, value) {
+		return nil, invalid("must be one of %s", strings.Join(p.Options, ", "))
+	}
+	return value, nil
+}
+
+// parameterNumber returns a JSON number (or Go integer) as float64.
+func parameterNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// hasOption reports whether a value, formatted with %v, is one of the options.
+func hasOption(options []string, value interface{}) bool {
+	formatted := fmt.Sprintf("%v", value)
+	for _, option := range options {
+		if option == formatted {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTemplateParameters writes resolved parameter values into an architecture: input
+// variables take the chosen values and the resource configuration referencing them is
+// resolved again, the template's region is replaced by the chosen one, and subnets with
+// their NAT gateways are repeated or removed to match az_count.
+func applyTemplateParameters(state *dto.ArchitectureResponse, fromRegion string, values map[string]interface{}) error {
+	vars := make(map[string]interface{}, len(state.Variables))
+	for i, v := range state.Variables {
+		if value, ok := values[v.Name]; ok {
+			state.Variables[i].Value = value
+		}
+		if state.Variables[i].Value != nil {
+			vars[v.Name] = state.Variables[i].Value
+		}
+	}
+
+	region, _ := values[templateParamRegion].(string)
+	moveRegion := region != "" && fromRegion != "" && region != fromRegion
+	for i := range state.Nodes {
+		node := &state.Nodes[i]
+		node.Data.Config = resolveTemplateVariables(node.Data.Config, vars)
+		if moveRegion {
+			// Zone names start with the region name, so they move with it
+			node.Data.Config, _ = replaceInStrings(node.Data.Config, fromRegion, region).(map[string]interface{})
+			node.Data.Label = strings.ReplaceAll(node.Data.Label, fromRegion, region)
+		}
+	}
+
+	if count, ok := values[templateParamAZCount].(int); ok {
+		if region == "" {
+			region = fromRegion
+		}
+		return resizeTemplateZones(state, region, count)
+	}
+	return nil
+}
+
+// resolveTemplateVariables resolves the var.variable_name references of a resource
+// configuration with the given variable values. The canvas parser records the references
+// it resolved in _varRefs; those are put back first so they are resolved again with the new
+// values. References to variables without a value are left as they are.
+func resolveTemplateVariables(config map[string]interface{}, vars map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	refs := make(map[string]interface{})
+	tracked := map[string]string{}
+	switch r := config["_varRefs"].(type) {
+	case map[string]interface{}:
+		for path, ref := range r {
+			if s, ok := ref.(string); ok {
+				tracked[path] = s
+			}
+		}
+	case map[string]string:
+		tracked = r
+	}
+	for path, ref := range tracked {
+		// References to the node ID and parent ID are not part of the configuration
+		if !setConfigValue(config, path, ref) {
+			refs[path] = ref
+		}
+	}
+
+	resolved := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if key == "_varRefs" {
+			continue
+		}
+		resolved[key] = resolveTemplateValue(value, vars, key, refs)
+	}
+	if len(refs) > 0 {
+		resolved["_varRefs"] = refs
+	}
+	return resolved
+}
+
+// resolveTemplateValue resolves var.variable_name references in a configuration value and
+// records the references it finds by field path.
+func resolveTemplateValue(value interface{}, vars map[string]interface{}, path string, refs map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "var.") {
+			return v
+		}
+		refs[path] = v
+		// A whole-string reference takes the variable's value with its type
+		if match := templateVarPattern.FindStringSubmatch(v); match != nil && match[0] == v {
+			if resolved, ok := vars[match[1]]; ok {
+				return resolved
+			}
+			return v
+		}
+		return templateVarPattern.ReplaceAllStringFunc(v, func(match string) string {
+			if resolved, ok := vars[strings.TrimPrefix(match, "var.")]; ok {
+				return fmt.Sprintf("%v", resolved)
+			}
+			return match
+		})
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = resolveTemplateValue(item, vars, path+"."+key, refs)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = resolveTemplateValue(item, vars, fmt.Sprintf("%s[%d]", path, i), refs)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// setConfigValue sets the value at a field path such as "tags[0].value"; it reports
+// false when the path does not exist.
+func setConfigValue(config map[string]interface{}, path string, value interface{}) bool {
+	segments := configPathSegments(path)
+	var current interface{} = config
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch container := current.(type) {
+		case map[string]interface{}:
+			key, ok := segment.(string)
+			if !ok {
+				return false
+			}
+			next, exists := container[key]
+			if !exists {
+				return false
+			}
+			if last {
+				container[key] = value
+				return true
+			}
+			current = next
+		case []interface{}:
+			index, ok := segment.(int)
+			if !ok || index < 0 || index >= len(container) {
+				return false
+			}
+			if last {
+				container[index] = value
+				return true
+			}
+			current = container[index]
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// configPathSegments splits a field path into map keys (string) and list indexes (int).
+func configPathSegments(path string) []interface{} {
+	var segments []interface{}
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		var indexes []interface{}
+		for strings.HasSuffix(key, "]") {
+			open := strings.LastIndex(key, "[")
+			if open < 0 {
+				break
+			}
+			index, err := strconv.Atoi(key[open+1 : len(key)-1])
+			if err != nil {
+				break
+			}
+			indexes = append([]interface{}{index}, indexes...)
+			key = key[:open]
+		}
+		segments = append(segments, key)
+		segments = append(segments, indexes...)
+	}
+	return segments
+}
+
+// replaceInStrings replaces old with new in every string of a configuration value.
+func replaceInStrings(value interface{}, old, new string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, old, new)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = replaceInStrings(item, old, new)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = replaceInStrings(item, old, new)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// templateNodeKind normalizes the resource type of a node: "NATGateway" and "nat-gateway"
+// are both "natgateway".
+func templateNodeKind(node dto.ArchitectureNode) string {
+	kind := node.Data.ResourceType
+	if kind == "" {
+		kind = node.Type
+	}
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(kind))
+}
+
+// configString returns a string value of a configuration, or "".
+func configString(config map[string]interface{}, key string) string {
+	s, _ := config[key].(string)
+	return s
+}
+
+// templateZones returns the availability zones the architecture places subnets in, sorted.
+func templateZones(state *dto.ArchitectureResponse) []string {
+	seen := map[string]bool{}
+	var zones []string
+	for _, node := range state.Nodes {
+		if templateNodeKind(node) != "subnet" {
+			continue
+		}
+		if zone := configString(node.Data.Config, "availabilityZoneId"); zone != "" && !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// zoneGroup returns the IDs of the nodes that belong to an availability zone: the zone's
+// subnets, its availability zone node and the NAT gateways placed in its subnets.
+func zoneGroup(state *dto.ArchitectureResponse, zone string) map[string]bool {
+	group := map[string]bool{}
+	for _, node := range state.Nodes {
+		switch templateNodeKind(node) {
+		case "subnet":
+			if configString(node.Data.Config, "availabilityZoneId") == zone {
+				group[node.ID] = true
+			}
+		case "availabilityzone":
+			if configString(node.Data.Config, "zoneName") == zone {
+				group[node.ID] = true
+			}
+		}
+	}
+	for _, node := range state.Nodes {
+		if templateNodeKind(node) != "natgateway" {
+			continue
+		}
+		if (node.ParentID != nil && group[*node.ParentID]) || group[configString(node.Data.Config, "subnetId")] {
+			group[node.ID] = true
+		}
+	}
+	return group
+}
+
+// resizeTemplateZones repeats or removes the subnets and NAT gateways of the architecture's
+// availability zones so that it spans count zones. New zones copy the existing ones in turn.
+func resizeTemplateZones(state *dto.ArchitectureResponse, region string, count int) error {
+	zones := templateZones(state)
+	if len(zones) == 0 || count == len(zones) {
+		return nil
+	}
+	if count < len(zones) {
+		removeTemplateZones(state, zones[count:])
+		return nil
+	}
+
+	added, err := nextTemplateZones(region, zones, count-len(zones))
+	if err != nil {
+		return err
+	}
+	for i, zone := range added {
+		if err := copyTemplateZone(state, zones[i%len(zones)], zone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextTemplateZones picks n zones of the region that the architecture does not use yet.
+func nextTemplateZones(region string, used []string, n int) ([]string, error) {
+	candidates := global.GetAvailabilityZonesByRegion(region)
+	if len(candidates) == 0 {
+		for _, letter := range "abcdef" {
+			candidates = append(candidates, region+string(letter))
+		}
+	}
+	inUse := make(map[string]bool, len(used))
+	for _, zone := range used {
+		inUse[zone] = true
+	}
+	var zones []string
+	for _, candidate := range candidates {
+		if len(zones) == n {
+			break
+		}
+		if !inUse[candidate] {
+			zones = append(zones, candidate)
+		}
+	}
+	if len(zones) < n {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation,
+			"az_count %d is more than the %d availability zones of %s", len(used)+n, len(used)+len(zones), region)
+	}
+	return zones, nil
+}
+
+// removeTemplateZones removes the nodes of the given zones together with the resources
+// placed inside them, the edges touching them and list entries referencing them.
+func removeTemplateZones(state *dto.ArchitectureResponse, zones []string) {
+	removed := map[string]string{}
+	for _, zone := range zones {
+		for id := range zoneGroup(state, zone) {
+			removed[id] = id
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, node := range state.Nodes {
+			if _, gone := removed[node.ID]; gone || node.ParentID == nil {
+				continue
+			}
+			if _, gone := removed[*node.ParentID]; gone {
+				removed[node.ID] = node.ID
+				changed = true
+			}
+		}
+	}
+
+	nodes := make([]dto.ArchitectureNode, 0, len(state.Nodes))
+	for _, node := range state.Nodes {
+		if _, gone := removed[node.ID]; gone {
+			continue
+		}
+		node.Data.Config, _ = dropReferences(node.Data.Config, removed).(map[string]interface{})
+		nodes = append(nodes, node)
+	}
+	state.Nodes = nodes
+
+	edges := make([]dto.ArchitectureEdge, 0, len(state.Edges))
+	for _, edge := range state.Edges {
+		_, fromGone := removed[edge.Source]
+		_, toGone := removed[edge.Target]
+		if !fromGone && !toGone {
+			edges = append(edges, edge)
+		}
+	}
+	state.Edges = edges
+}
+
+// copyTemplateZone repeats the nodes of one zone in another. Copies get new IDs and names,
+// subnets get the next free CIDR block of their VPC, edges touching the zone are repeated
+// and lists referencing its nodes (such as the subnets of a load balancer) are extended.
+func copyTemplateZone(state *dto.ArchitectureResponse, source, zone string) error {
+	group := zoneGroup(state, source)
+	ids := make(map[string]string, len(group))
+	for id := range group {
+		ids[id] = id + "-" + zone
+	}
+	used := templateSubnetCIDRs(state)
+
+	var copies []dto.ArchitectureNode
+	for _, node := range state.Nodes {
+		if !group[node.ID] {
+			continue
+		}
+		cp := node
+		cp.ID = ids[node.ID]
+		if node.ParentID != nil {
+			if parent, ok := ids[*node.ParentID]; ok {
+				cp.ParentID = &parent
+			}
+		}
+		if node.UIState != nil {
+			ui := *node.UIState
+			cp.UIState = &ui
+		}
+
+		config, _ := remapReferences(replaceInStrings(cloneConfigValue(node.Data.Config), source, zone), ids).(map[string]interface{})
+		if name := configString(node.Data.Config, "name"); name != "" && configString(config, "name") == name {
+			config["name"] = name + "-" + zone
+		}
+		if templateNodeKind(node) == "subnet" {
+			if cidr := configString(config, "cidr"); cidr != "" {
+				next, err := nextSubnetCIDR(state, node, cidr, used)
+				if err != nil {
+					return err
+				}
+				config["cidr"] = next.String()
+				used = append(used, next)
+			}
+		}
+		cp.Data.Config = config
+		if cp.Data.Label = strings.ReplaceAll(node.Data.Label, source, zone); cp.Data.Label == node.Data.Label {
+			cp.Data.Label = fmt.Sprintf("%s (%s)", node.Data.Label, zone)
+		}
+		copies = append(copies, cp)
+	}
+
+	for i, node := range state.Nodes {
+		if !group[node.ID] {
+			state.Nodes[i].Data.Config, _ = extendReferences(node.Data.Config, ids).(map[string]interface{})
+		}
+	}
+	state.Nodes = append(state.Nodes, copies...)
+
+	for _, edge := range state.Edges {
+		from, fromIn := ids[edge.Source]
+		to, toIn := ids[edge.Target]
+		if !fromIn && !toIn {
+			continue
+		}
+		cp := edge
+		cp.ID = edge.ID + "-" + zone
+		if fromIn {
+			cp.Source = from
+		}
+		if toIn {
+			cp.Target = to
+		}
+		state.Edges = append(state.Edges, cp)
+	}
+	return nil
+}
+
+// templateSubnetCIDRs returns the CIDR blocks of the architecture's subnets.
+func templateSubnetCIDRs(state *dto.ArchitectureResponse) []*net.IPNet {
+	var blocks []*net.IPNet
+	for _, node := range state.Nodes {
+		if templateNodeKind(node) != "subnet" {
+			continue
+		}
+		if _, block, err := net.ParseCIDR(configString(node.Data.Config, "cidr")); err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// nextSubnetCIDR returns the first block of the subnet's size inside its VPC that overlaps
+// none of the used blocks.
+func nextSubnetCIDR(state *dto.ArchitectureResponse, subnet dto.ArchitectureNode, cidr string, used []*net.IPNet) (*net.IPNet, error) {
+	_, block, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "subnet %s has an invalid CIDR %q", subnet.ID, cidr)
+	}
+	vpc := templateSubnetVPC(state, subnet)
+	if vpc == nil {
+		return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "subnet %s cannot be repeated: its VPC has no CIDR", subnet.ID)
+	}
+
+	prefix, _ := block.Mask.Size()
+	vpcPrefix, bits := vpc.Mask.Size()
+	base := vpc.IP.To4()
+	if base != nil && bits == 32 && prefix >= vpcPrefix {
+		size := uint64(1) << uint(32-prefix)
+		start := uint64(binary.BigEndian.Uint32(base))
+		end := start + uint64(1)<<uint(32-vpcPrefix)
+		for addr := start; addr+size <= end; addr += size {
+			ip := make(net.IP, 4)
+			binary.BigEndian.PutUint32(ip, uint32(addr))
+			candidate := &net.IPNet{IP: ip, Mask: net.CIDRMask(prefix, 32)}
+			free := true
+			for _, u := range used {
+				if u.Contains(candidate.IP) || candidate.Contains(u.IP) {
+					free = false
+					break
+				}
+			}
+			if free {
+				return candidate, nil
+			}
+		}
+	}
+	return nil, apperrors.Newf(apperrors.CodeInvalidValue, apperrors.KindValidation, "VPC %s has no room for another /%d subnet", vpc.String(), prefix)
+}
+
+// templateSubnetVPC returns the CIDR block of the VPC a subnet is placed in: its closest
+// VPC ancestor, or the VPC named by its vpcId.
+func templateSubnetVPC(state *dto.ArchitectureResponse, subnet dto.ArchitectureNode) *net.IPNet {
+	byID := make(map[string]dto.ArchitectureNode, len(state.Nodes))
+	for _, node := range state.Nodes {
+		byID[node.ID] = node
+	}
+	candidates := []string{}
+	for parent := subnet.ParentID; parent != nil && len(candidates) < len(state.Nodes); {
+		candidates = append(candidates, *parent)
+		parent = byID[*parent].ParentID
+	}
+	candidates = append(candidates, configString(subnet.Data.Config, "vpcId"))
+	for _, id := range candidates {
+		node, ok := byID[id]
+		if !ok || templateNodeKind(node) != "vpc" {
+			continue
+		}
+		if _, block, err := net.ParseCIDR(configString(node.Data.Config, "cidr")); err == nil {
+			return block
+		}
+	}
+	return nil
+}
+
+// cloneConfigValue deep-copies a JSON configuration value.
+func cloneConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = cloneConfigValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = cloneConfigValue(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// remapReferences replaces strings that are node IDs of ids with the mapped IDs.
+func remapReferences(value interface{}, ids map[string]string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if mapped, ok := ids[v]; ok {
+			return mapped
+		}
+		return v
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = remapReferences(item, ids)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = remapReferences(item, ids)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// referencesNode reports whether a list entry is one of the node IDs, or an object with a
+// field holding one, such as {"subnetId": "subnet-1"}.
+func referencesNode(entry interface{}, ids map[string]string) bool {
+	switch v := entry.(type) {
+	case string:
+		_, ok := ids[v]
+		return ok
+	case map[string]interface{}:
+		for _, field := range v {
+			if s, ok := field.(string); ok {
+				if _, ok := ids[s]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// extendReferences appends to each list a copy, with remapped IDs, of every entry that
+// references a copied node.
+func extendReferences(value interface{}, ids map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = extendReferences(item, ids)
+		}
+		return v
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		var added []interface{}
+		for _, item := range v {
+			out = append(out, extendReferences(item, ids))
+			if referencesNode(item, ids) {
+				added = append(added, remapReferences(cloneConfigValue(item), ids))
+			}
+		}
+		return append(out, added...)
+	default:
+		return value
+	}
+}
+
+// dropReferences removes list entries that reference a removed node.
+func dropReferences(value interface{}, ids map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = dropReferences(item, ids)
+		}
+		return v
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if !referencesNode(item, ids) {
+				out = append(out, dropReferences(item, ids))
+			}
+		}
+		return out
+	default:
+		return value
+	}
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/api/dto"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+)
+
+// templateTestState returns a VPC with a public and a private subnet in each of two zones,
+// a NAT gateway in the first public subnet and a load balancer spanning the public subnets.
+func templateTestState(t *testing.T) *dto.ArchitectureResponse {
+	t.Helper()
+	raw := `{
+		"nodes": [
+			{"id": "region-1", "type": "Region", "data": {"label": "us-east-1", "resourceType": "Region", "config": {"name": "us-east-1"}}},
+			{"id": "vpc-1", "type": "VPC", "parentId": "region-1", "data": {"label": "main", "resourceType": "VPC", "config": {"name": "main", "cidr": "10.0.0.0/16"}}},
+			{"id": "public-a", "type": "Subnet", "parentId": "vpc-1", "data": {"label": "public-us-east-1a", "resourceType": "Subnet", "config": {"name": "public-us-east-1a", "cidr": "10.0.0.0/24", "availabilityZoneId": "us-east-1a"}}},
+			{"id": "public-b", "type": "Subnet", "parentId": "vpc-1", "data": {"label": "public-us-east-1b", "resourceType": "Subnet", "config": {"name": "public-us-east-1b", "cidr": "10.0.1.0/24", "availabilityZoneId": "us-east-1b"}}},
+			{"id": "private-a", "type": "Subnet", "parentId": "vpc-1", "data": {"label": "private", "resourceType": "Subnet", "config": {"name": "private", "cidr": "10.0.2.0/24", "availabilityZoneId": "us-east-1a"}}},
+			{"id": "private-b", "type": "Subnet", "parentId": "vpc-1", "data": {"label": "private-b", "resourceType": "Subnet", "config": {"name": "private-b", "cidr": "10.0.3.0/24", "availabilityZoneId": "us-east-1b"}}},
+			{"id": "nat-a", "type": "NATGateway", "parentId": "public-a", "data": {"label": "nat", "resourceType": "NATGateway", "config": {"name": "nat", "subnetId": "public-a"}}},
+			{"id": "web-b", "type": "EC2", "parentId": "private-b", "data": {"label": "web", "resourceType": "EC2", "config": {"name": "web", "instanceType": "t3.micro", "_varRefs": {"instanceType": "var.instance_type"}}}},
+			{"id": "alb", "type": "LoadBalancer", "parentId": "vpc-1", "data": {"label": "alb", "resourceType": "LoadBalancer", "config": {"name": "var.environment-alb", "subnets": [{"subnetId": "public-a"}, {"subnetId": "public-b"}]}}}
+		],
+		"edges": [
+			{"id": "contain-vpc-1-public-a", "source": "vpc-1", "target": "public-a", "type": "contains"},
+			{"id": "depend-web-b-alb", "source": "web-b", "target": "alb", "type": "depends_on"}
+		],
+		"variables": [
+			{"name": "instance_type", "type": "string", "value": "t3.micro"},
+			{"name": "environment", "type": "string", "value": "dev"},
+			{"name": "vpc_cidr", "type": "string", "value": "10.0.0.0/16"},
+			{"name": "db_password", "type": "string", "value": "secret", "sensitive": true}
+		],
+		"outputs": []
+	}`
+	var state dto.ArchitectureResponse
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		t.Fatalf("failed to decode architecture: %v", err)
+	}
+	return &state
+}
+
+func templateTestNode(state *dto.ArchitectureResponse, id string) *dto.ArchitectureNode {
+	for i := range state.Nodes {
+		if state.Nodes[i].ID == id {
+			return &state.Nodes[i]
+		}
+	}
+	return nil
+}
+
+func TestTemplateParameters(t *testing.T) {
+	params := templateParameters(templateTestState(t), "AWS", "us-east-1")
+
+	byName := map[string]int{}
+	for i, p := range params {
+		byName[p.Name] = i
+	}
+	want := map[string]string{
+		"region":        templateParamRegion,
+		"az_count":      templateParamAZCount,
+		"instance_type": templateParamString,
+		"environment":   templateParamString,
+		"vpc_cidr":      templateParamCIDR,
+		"db_password":   templateParamString,
+	}
+	if len(params) != len(want) {
+		t.Fatalf("expected %d parameters, got %+v", len(want), params)
+	}
+	for name, paramType := range want {
+		i, ok := byName[name]
+		if !ok {
+			t.Fatalf("missing parameter %q", name)
+		}
+		if params[i].Type != paramType {
+			t.Errorf("parameter %q type = %q, want %q", name, params[i].Type, paramType)
+		}
+	}
+	if azCount := params[byName["az_count"]]; azCount.Default != 2 {
+		t.Errorf("az_count default = %v, want 2", azCount.Default)
+	}
+	if region := params[byName["region"]]; region.Default != "us-east-1" || len(region.Options) == 0 {
+		t.Errorf("region parameter = %+v, want default us-east-1 with the AWS regions as options", region)
+	}
+	if password := params[byName["db_password"]]; password.Default != nil || !password.Required || !password.Sensitive {
+		t.Errorf("sensitive parameter = %+v, want required without default", password)
+	}
+}
+
+func TestResolveTemplateParameters(t *testing.T) {
+	params := templateParameters(templateTestState(t), "AWS", "us-east-1")
+
+	values, err := resolveTemplateParameters(params, map[string]interface{}{
+		"az_count":    float64(3),
+		"db_password": "hunter2",
+	})
+	if err != nil {
+		t.Fatalf("resolveTemplateParameters error: %v", err)
+	}
+	if values["az_count"] != 3 || values["region"] != "us-east-1" || values["instance_type"] != "t3.micro" {
+		t.Errorf("unexpected resolved values: %v", values)
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+	}{
+		{"missing required", map[string]interface{}{}},
+		{"unknown parameter", map[string]interface{}{"db_password": "x", "nope": 1}},
+		{"unknown region", map[string]interface{}{"db_password": "x", "region": "mars-1"}},
+		{"az_count out of range", map[string]interface{}{"db_password": "x", "az_count": float64(9)}},
+		{"fractional az_count", map[string]interface{}{"db_password": "x", "az_count": 1.5}},
+		{"invalid cidr", map[string]interface{}{"db_password": "x", "vpc_cidr": "10.0.0.0"}},
+		{"wrong type", map[string]interface{}{"db_password": "x", "environment": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveTemplateParameters(params, tt.values)
+			if !apperrors.IsKind(err, apperrors.KindValidation) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyTemplateParameters_VariablesAndRegion(t *testing.T) {
+	state := templateTestState(t)
+	err := applyTemplateParameters(state, "us-east-1", map[string]interface{}{
+		"region":        "eu-west-1",
+		"instance_type": "m5.large",
+		"environment":   "prod",
+	})
+	if err != nil {
+		t.Fatalf("applyTemplateParameters error: %v", err)
+	}
+
+	web := templateTestNode(state, "web-b")
+	if web.Data.Config["instanceType"] != "m5.large" {
+		t.Errorf("instanceType = %v, want m5.large", web.Data.Config["instanceType"])
+	}
+	refs, _ := web.Data.Config["_varRefs"].(map[string]interface{})
+	if refs["instanceType"] != "var.instance_type" {
+		t.Errorf("expected the variable reference to be kept, got %v", refs)
+	}
+	if name := templateTestNode(state, "alb").Data.Config["name"]; name != "prod-alb" {
+		t.Errorf("alb name = %v, want the environment substituted", name)
+	}
+	if zone := templateTestNode(state, "public-b").Data.Config["availabilityZoneId"]; zone != "eu-west-1b" {
+		t.Errorf("availabilityZoneId = %v, want eu-west-1b", zone)
+	}
+	if label := templateTestNode(state, "region-1").Data.Label; label != "eu-west-1" {
+		t.Errorf("region label = %q, want eu-west-1", label)
+	}
+	for _, v := range state.Variables {
+		if v.Name == "instance_type" && v.Value != "m5.large" {
+			t.Errorf("instance_type variable = %v, want m5.large", v.Value)
+		}
+	}
+}
+
+func TestApplyTemplateParameters_AddZones(t *testing.T) {
+	state := templateTestState(t)
+	if err := applyTemplateParameters(state, "us-east-1", map[string]interface{}{"az_count": 3}); err != nil {
+		t.Fatalf("applyTemplateParameters error: %v", err)
+	}
+
+	if zones := templateZones(state); len(zones) != 3 || zones[2] != "us-east-1c" {
+		t.Fatalf("zones = %v, want us-east-1a..c", zones)
+	}
+	public := templateTestNode(state, "public-a-us-east-1c")
+	if public == nil {
+		t.Fatal("expected the first zone's public subnet to be repeated in us-east-1c")
+	}
+	if public.Data.Config["name"] != "public-us-east-1c" || public.Data.Config["cidr"] != "10.0.4.0/24" {
+		t.Errorf("repeated subnet config = %v, want public-us-east-1c in 10.0.4.0/24", public.Data.Config)
+	}
+	private := templateTestNode(state, "private-a-us-east-1c")
+	if private == nil || private.Data.Config["name"] != "private-us-east-1c" || private.Data.Config["cidr"] != "10.0.5.0/24" {
+		t.Errorf("repeated private subnet = %+v, want private-us-east-1c in 10.0.5.0/24", private)
+	}
+	nat := templateTestNode(state, "nat-a-us-east-1c")
+	if nat == nil || nat.ParentID == nil || *nat.ParentID != "public-a-us-east-1c" || nat.Data.Config["subnetId"] != "public-a-us-east-1c" {
+		t.Errorf("repeated NAT gateway = %+v, want it in the repeated public subnet", nat)
+	}
+	if templateTestNode(state, "web-b-us-east-1c") != nil {
+		t.Error("workloads should not be repeated")
+	}
+	if subnets, _ := templateTestNode(state, "alb").Data.Config["subnets"].([]interface{}); len(subnets) != 3 {
+		t.Errorf("alb subnets = %v, want the repeated public subnet added", subnets)
+	}
+	found := false
+	for _, edge := range state.Edges {
+		if edge.Source == "vpc-1" && edge.Target == "public-a-us-east-1c" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the containment edge of the repeated subnet")
+	}
+}
+
+func TestApplyTemplateParameters_RemoveZones(t *testing.T) {
+	state := templateTestState(t)
+	if err := applyTemplateParameters(state, "us-east-1", map[string]interface{}{"az_count": 1}); err != nil {
+		t.Fatalf("applyTemplateParameters error: %v", err)
+	}
+
+	for _, id := range []string{"public-b", "private-b", "web-b"} {
+		if templateTestNode(state, id) != nil {
+			t.Errorf("expected %s to be removed with its zone", id)
+		}
+	}
+	if templateTestNode(state, "nat-a") == nil {
+		t.Error("expected the first zone's NAT gateway to be kept")
+	}
+	if subnets, _ := templateTestNode(state, "alb").Data.Config["subnets"].([]interface{}); len(subnets) != 1 {
+		t.Errorf("alb subnets = %v, want only the remaining public subnet", subnets)
+	}
+	for _, edge := range state.Edges {
+		if edge.Source == "web-b" {
+			t.Errorf("expected the edges of removed nodes to be removed, got %+v", edge)
+		}
+	}
+}
+
+func TestApplyTemplateParameters_NoRoomForZone(t *testing.T) {
+	state := templateTestState(t)
+	templateTestNode(state, "vpc-1").Data.Config["cidr"] = "10.0.0.0/22"
+	err := applyTemplateParameters(state, "us-east-1", map[string]interface{}{"az_count": 3})
+	if !apperrors.IsKind(err, apperrors.KindValidation) {
+		t.Errorf("expected a validation error when the VPC is full, got %v", err)
+	}
+}
+
+func TestResolveTemplateVariables_SensitiveReferenceRestored(t *testing.T) {
+	config := map[string]interface{}{
+		"password": "secret",
+		"tags":     []interface{}{map[string]interface{}{"value": "dev"}},
+		"_varRefs": map[string]interface{}{"password": "var.db_password", "tags[0].value": "var.environment"},
+	}
+	resolved := resolveTemplateVariables(config, map[string]interface{}{"environment": "prod"})
+
+	if resolved["password"] != "var.db_password" {
+		t.Errorf("password = %v, want the reference back in place of the value", resolved["password"])
+	}
+	tags := resolved["tags"].([]interface{})
+	if tags[0].(map[string]interface{})["value"] != "prod" {
+		t.Errorf("tag value = %v, want prod", tags[0])
+	}
+}
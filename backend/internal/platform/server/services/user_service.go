@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/errors"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/auth"
+	platformerrors "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/errors"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	serverinterfaces "github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/interfaces"
 )
@@ -42,6 +45,17 @@ func (s *UserServiceImpl) Create(ctx context.Context, req *serverinterfaces.Crea
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	if req.Password != "" {
+		hash, err := auth.HashPassword(req.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		user.PasswordHash = hash
+	}
+	// Users registered with a password have no external identity; keep auth0_id unique.
+	if user.Auth0ID == "" {
+		user.Auth0ID = "local|" + user.ID.String()
+	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
@@ -54,3 +68,19 @@ func (s *UserServiceImpl) Create(ctx context.Context, req *serverinterfaces.Crea
 func (s *UserServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	return s.userRepo.FindByID(ctx, id)
 }
+
+// Authenticate checks an email and password against the stored hash. Unknown emails and
+// wrong passwords fail the same way.
+func (s *UserServiceImpl) Authenticate(ctx context.Context, email, password string) (*models.User, error) {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if apperrors.IsKind(err, apperrors.KindNotFound) {
+			return nil, platformerrors.NewAuthInvalidCredentials()
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil || !auth.CheckPassword(user.PasswordHash, password) {
+		return nil, platformerrors.NewAuthInvalidCredentials()
+	}
+	return user, nil
+}
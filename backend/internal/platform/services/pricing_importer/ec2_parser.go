@@ -0,0 +1,239 @@
+package pricing_importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	domainpricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/pricing"
+	"gorm.io/datatypes"
+)
+
+// ParseEC2Instances parses the scraper JSON file and returns EC2 instances
+func ParseEC2Instances(filePath string) ([]EC2Instance, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var instances []EC2Instance
+	if err := json.Unmarshal(data, &instances); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return instances, nil
+}
+
+// ConvertToPricingRates converts EC2 instances to PricingRate models
+func ConvertToPricingRates(instances []EC2Instance) ([]*models.PricingRate, error) {
+	var rates []*models.PricingRate
+	now := time.Now()
+
+	for _, instance := range instances {
+		if instance.Pricing == nil {
+			continue
+		}
+
+		for region, osMap := range instance.Pricing {
+			if osMap == nil {
+				continue
+			}
+
+			for osName, pricingDataRaw := range osMap {
+				// Handle both EC2PricingData object and string (legacy format)
+				var pricingData EC2PricingData
+				var onDemandStr string
+				var reserved map[string]string
+
+				switch v := pricingDataRaw.(type) {
+				case string:
+					// Legacy format: direct string price
+					onDemandStr = v
+				case map[string]interface{}:
+					// Modern format: object with ondemand and reserved fields
+					if ondemand, ok := v["ondemand"].(string); ok {
+						onDemandStr = ondemand
+					} else {
+						// Try to unmarshal as EC2PricingData
+						dataBytes, _ := json.Marshal(v)
+						if err := json.Unmarshal(dataBytes, &pricingData); err == nil {
+							onDemandStr = pricingData.OnDemand
+						}
+					}
+					reserved = reservedPrices(v["reserved"])
+				default:
+					// Try to unmarshal as EC2PricingData
+					dataBytes, _ := json.Marshal(v)
+					if err := json.Unmarshal(dataBytes, &pricingData); err == nil {
+						onDemandStr = pricingData.OnDemand
+						if pricingData.Reserved != nil {
+							reserved = *pricingData.Reserved
+						}
+					}
+				}
+
+				// Normalize OS name
+				normalizedOS := normalizeOS(osName)
+				rates = append(rates, convertCommitmentRates(instance.InstanceType, region, normalizedOS, reserved, now)...)
+
+				if onDemandStr == "" || onDemandStr == "0" {
+					continue
+				}
+
+				// Parse price string to float
+				rate, err := strconv.ParseFloat(strings.TrimSpace(onDemandStr), 64)
+				if err != nil {
+					continue // Skip invalid prices
+				}
+
+				if rate <= 0 {
+					continue // Skip zero or negative prices
+				}
+
+				// Create PricingRate
+				regionPtr := &region
+				instanceTypePtr := &instance.InstanceType
+				osPtr := &normalizedOS
+
+				pricingRate := &models.PricingRate{
+					Provider:        "aws",
+					ResourceType:    "ec2_instance",
+					ComponentName:   "EC2 Instance Hourly",
+					PricingModel:    "per_hour",
+					Unit:            "hour",
+					Rate:            rate,
+					Currency:        "USD",
+					Region:          regionPtr,
+					InstanceType:    instanceTypePtr,
+					OperatingSystem: osPtr,
+					EffectiveFrom:   now,
+					EffectiveTo:     nil,
+				}
+
+				rates = append(rates, pricingRate)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// reservedTerms maps the scraper's reserved pricing keys ("yrTerm1Standard.noUpfront") to
+// commitment pricing models. Standard RIs are priced as Reserved Instances; Compute Savings
+// Plans give the discount of Convertible RIs, so those prices are used for savings plans.
+var reservedTerms = map[string]struct {
+	model domainpricing.PricingModel
+	term  string
+}{
+	"yrTerm1Standard":    {domainpricing.Reserved, "1yr"},
+	"yrTerm3Standard":    {domainpricing.Reserved, "3yr"},
+	"yrTerm1Convertible": {domainpricing.SavingsPlan, "1yr"},
+	"yrTerm3Convertible": {domainpricing.SavingsPlan, "3yr"},
+}
+
+var reservedPayments = map[string]string{
+	"noUpfront":      domainpricing.NoUpfront,
+	"partialUpfront": domainpricing.PartialUpfront,
+	"allUpfront":     domainpricing.AllUpfront,
+}
+
+// reservedPrices reads the scraper's reserved prices, given as strings or numbers
+func reservedPrices(raw interface{}) map[string]string {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	prices := make(map[string]string, len(m))
+	for key, v := range m {
+		switch price := v.(type) {
+		case string:
+			prices[key] = price
+		case float64:
+			prices[key] = strconv.FormatFloat(price, 'f', -1, 64)
+		}
+	}
+	return prices
+}
+
+// convertCommitmentRates converts the reserved prices of an instance type in a region to
+// PricingRate models, one per purchase option. Prices are effective hourly rates.
+func convertCommitmentRates(instanceType, region, operatingSystem string, reserved map[string]string, now time.Time) []*models.PricingRate {
+	var rates []*models.PricingRate
+	for key, priceStr := range reserved {
+		termKey, paymentKey, ok := strings.Cut(key, ".")
+		if !ok {
+			continue
+		}
+		term, ok := reservedTerms[termKey]
+		if !ok {
+			continue
+		}
+		payment, ok := reservedPayments[paymentKey]
+		if !ok {
+			continue
+		}
+		option, ok := domainpricing.PurchaseOptionFor(term.model, term.term, payment)
+		if !ok {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(priceStr), 64)
+		if err != nil || rate <= 0 {
+			continue
+		}
+
+		metadata, _ := json.Marshal(map[string]string{
+			"purchase_option": string(option),
+			"term":            term.term,
+			"payment_option":  payment,
+		})
+		regionCopy, instanceTypeCopy, osCopy := region, instanceType, operatingSystem
+		rates = append(rates, &models.PricingRate{
+			Provider:        "aws",
+			ResourceType:    "ec2_instance",
+			ComponentName:   "EC2 Instance " + option.DisplayName(),
+			PricingModel:    string(term.model),
+			Unit:            "hour",
+			Rate:            rate,
+			Currency:        "USD",
+			Region:          &regionCopy,
+			InstanceType:    &instanceTypeCopy,
+			OperatingSystem: &osCopy,
+			EffectiveFrom:   now,
+			Metadata:        datatypes.JSON(metadata),
+		})
+	}
+	return rates
+}
+
+// normalizeOS normalizes OS names from scraper format to our format
+func normalizeOS(osName string) string {
+	osName = strings.ToLower(strings.TrimSpace(osName))
+
+	// Map scraper OS names to our constants
+	osMap := map[string]string{
+		"linux":   "linux",
+		"mswin":   "mswin",
+		"windows": "mswin",
+		"rhel":    "rhel",
+		"suse":    "suse",
+	}
+
+	if normalized, ok := osMap[osName]; ok {
+		return normalized
+	}
+
+	// Default to linux if unknown
+	return "linux"
+}
@@ -0,0 +1,59 @@
+package pricing_importer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertToPricingRates_Commitments(t *testing.T) {
+	var instances []EC2Instance
+	data := `[{
+		"instance_type": "m5.large",
+		"pricing": {
+			"us-east-1": {
+				"linux": {
+					"ondemand": "0.096",
+					"reserved": {
+						"yrTerm1Standard.noUpfront": "0.06",
+						"yrTerm3Standard.allUpfront": "0.0383",
+						"yrTerm1Convertible.partialUpfront": "0.0669",
+						"yrTerm1Standard.unknown": "0.01"
+					}
+				},
+				"mswin": "0.188"
+			}
+		}
+	}]`
+	if err := json.Unmarshal([]byte(data), &instances); err != nil {
+		t.Fatalf("Failed to parse test data: %v", err)
+	}
+
+	rates, err := ConvertToPricingRates(instances)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	byComponent := make(map[string]float64)
+	for _, rate := range rates {
+		byComponent[rate.ComponentName+" "+*rate.OperatingSystem] = rate.Rate
+		if rate.PricingModel != "per_hour" && len(rate.Metadata) == 0 {
+			t.Errorf("%s: expected purchase option metadata", rate.ComponentName)
+		}
+	}
+
+	expected := map[string]float64{
+		"EC2 Instance Hourly linux":                           0.096,
+		"EC2 Instance Hourly mswin":                           0.188,
+		"EC2 Instance Reserved 1yr No Upfront linux":          0.06,
+		"EC2 Instance Reserved 3yr All Upfront linux":         0.0383,
+		"EC2 Instance Savings Plan 1yr Partial Upfront linux": 0.0669,
+	}
+	if len(byComponent) != len(expected) {
+		t.Errorf("Expected %d rates, got %d: %v", len(expected), len(byComponent), byComponent)
+	}
+	for component, rate := range expected {
+		if byComponent[component] != rate {
+			t.Errorf("%s: expected %v, got %v", component, rate, byComponent[component])
+		}
+	}
+}
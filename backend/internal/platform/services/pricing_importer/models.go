@@ -0,0 +1,69 @@
+package pricing_importer
+
+// EC2PricingData represents pricing data for an EC2 instance
+type EC2PricingData struct {
+	OnDemand     string             `json:"ondemand,omitempty"`
+	Reserved     *map[string]string `json:"reserved,omitempty"`
+	SpotMin      *string            `json:"spot_min,omitempty"`
+	SpotMax      *string            `json:"spot_max,omitempty"`
+	EMR          string             `json:"emr,omitempty"`
+	PCTInterrupt string             `json:"pct_interrupt,omitempty"`
+	PCTSavingsOD *int               `json:"pct_savings_od,omitempty"`
+	SpotAvg      string             `json:"spot_avg,omitempty"`
+}
+
+// EC2Instance represents an EC2 instance from the scraper output
+type EC2Instance struct {
+	InstanceType string                            `json:"instance_type"`
+	Pricing      map[string]map[string]interface{} `json:"pricing"` // map[region]map[os]EC2PricingData
+}
+
+// ImportStats tracks import statistics
+type ImportStats struct {
+	TotalInstances         int
+	TotalRates             int
+	CommitmentRates        int // reserved instance and savings plan rates, included in TotalRates
+	RegionsProcessed       map[string]int
+	OSProcessed            map[string]int
+	ResourceTypesProcessed map[string]int // region catalog rates by resource type
+	Errors                 []string
+}
+
+// PriceListOffer is an offer file of the AWS Price List bulk API, e.g.
+// https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/eu-west-1/index.json
+type PriceListOffer struct {
+	FormatVersion   string                      `json:"formatVersion"`
+	OfferCode       string                      `json:"offerCode"`
+	Version         string                      `json:"version"`
+	PublicationDate string                      `json:"publicationDate"`
+	Products        map[string]PriceListProduct `json:"products"`
+	Terms           PriceListTerms              `json:"terms"`
+}
+
+// PriceListProduct is a product (SKU) of an offer file
+type PriceListProduct struct {
+	SKU           string            `json:"sku"`
+	ProductFamily string            `json:"productFamily"`
+	Attributes    map[string]string `json:"attributes"`
+}
+
+// PriceListTerms holds the terms of an offer file by SKU and term code. Only On-Demand
+// terms are read.
+type PriceListTerms struct {
+	OnDemand map[string]map[string]PriceListTerm `json:"OnDemand"`
+}
+
+// PriceListTerm is the pricing of a product under a term
+type PriceListTerm struct {
+	SKU             string                        `json:"sku"`
+	PriceDimensions map[string]PriceListDimension `json:"priceDimensions"`
+}
+
+// PriceListDimension is a price of a term, or one tier of it
+type PriceListDimension struct {
+	Unit         string            `json:"unit"`
+	Description  string            `json:"description"`
+	BeginRange   string            `json:"beginRange"`
+	EndRange     string            `json:"endRange"`
+	PricePerUnit map[string]string `json:"pricePerUnit"`
+}
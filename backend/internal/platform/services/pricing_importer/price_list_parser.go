@@ -0,0 +1,325 @@
+package pricing_importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	awspricing "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/pricing"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
+	"gorm.io/datatypes"
+)
+
+// priceListLocations maps the location names of older offer files, whose products have no
+// regionCode attribute, to region codes
+var priceListLocations = map[string]string{
+	"US East (N. Virginia)":     "us-east-1",
+	"US East (Ohio)":            "us-east-2",
+	"US West (N. California)":   "us-west-1",
+	"US West (Oregon)":          "us-west-2",
+	"EU (Ireland)":              "eu-west-1",
+	"EU (London)":               "eu-west-2",
+	"EU (Frankfurt)":            "eu-central-1",
+	"Asia Pacific (Tokyo)":      "ap-northeast-1",
+	"Asia Pacific (Seoul)":      "ap-northeast-2",
+	"Asia Pacific (Singapore)":  "ap-southeast-1",
+	"Asia Pacific (Sydney)":     "ap-southeast-2",
+	"Asia Pacific (Mumbai)":     "ap-south-1",
+	"Canada (Central)":          "ca-central-1",
+	"South America (Sao Paulo)": "sa-east-1",
+}
+
+// s3StorageClasses maps S3 storage usage types to storage classes
+var s3StorageClasses = map[string]string{
+	"TimedStorage-ByteHrs":        "standard",
+	"TimedStorage-SIA-ByteHrs":    "standard-ia",
+	"TimedStorage-ZIA-ByteHrs":    "onezone-ia",
+	"TimedStorage-GlacierByteHrs": "glacier",
+	"TimedStorage-GDA-ByteHrs":    "deep-archive",
+}
+
+// loadBalancerTypes maps Elastic Load Balancing product families to load balancer types
+var loadBalancerTypes = map[string]string{
+	"Load Balancer-Application": "application",
+	"Load Balancer-Network":     "network",
+	"Load Balancer":             "classic",
+}
+
+// rdsEngines maps RDS database engines to the engine names of resources
+var rdsEngines = map[string]string{
+	"MySQL":             "mysql",
+	"PostgreSQL":        "postgres",
+	"MariaDB":           "mariadb",
+	"Aurora MySQL":      "aurora-mysql",
+	"Aurora PostgreSQL": "aurora-postgresql",
+}
+
+// catalogProduct is the region catalog rate a price list product provides
+type catalogProduct struct {
+	resourceType  string
+	componentName string
+	pricingModel  string
+	unit          string
+	instanceType  string
+	metadata      map[string]string
+}
+
+// ParsePriceListOffer parses an offer file of the AWS Price List bulk API
+func ParsePriceListOffer(filePath string) (*PriceListOffer, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	var offer PriceListOffer
+	if err := json.NewDecoder(file).Decode(&offer); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if offer.OfferCode == "" {
+		return nil, fmt.Errorf("not a price list offer file: missing offerCode")
+	}
+
+	return &offer, nil
+}
+
+// ConvertPriceListToPricingRates converts the On-Demand prices of an offer file to region
+// catalog PricingRate models. Products the catalog does not price are skipped; when several
+// products price the same component in a region, the first by SKU is kept.
+func ConvertPriceListToPricingRates(offer *PriceListOffer) ([]*models.PricingRate, error) {
+	if offer == nil {
+		return nil, fmt.Errorf("offer is nil")
+	}
+
+	skus := make([]string, 0, len(offer.Products))
+	for sku := range offer.Products {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	var rates []*models.PricingRate
+	seen := make(map[string]bool)
+	now := time.Now()
+
+	for _, sku := range skus {
+		product := offer.Products[sku]
+		entry, ok := catalogProductFor(offer.OfferCode, product)
+		if !ok {
+			continue
+		}
+		region, ok := priceListRegion(product)
+		if !ok {
+			continue
+		}
+		rate, ok := onDemandPrice(offer.Terms.OnDemand[sku])
+		if !ok {
+			continue
+		}
+
+		key := strings.Join([]string{entry.resourceType, entry.componentName, entry.instanceType, region}, "|")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		regionCopy := region
+		pricingRate := &models.PricingRate{
+			Provider:      "aws",
+			ResourceType:  entry.resourceType,
+			ComponentName: entry.componentName,
+			PricingModel:  entry.pricingModel,
+			Unit:          entry.unit,
+			Rate:          rate,
+			Currency:      "USD",
+			Region:        &regionCopy,
+			EffectiveFrom: now,
+		}
+		if entry.instanceType != "" {
+			instanceType := entry.instanceType
+			pricingRate.InstanceType = &instanceType
+		}
+		if len(entry.metadata) > 0 {
+			metadata, _ := json.Marshal(entry.metadata)
+			pricingRate.Metadata = datatypes.JSON(metadata)
+		}
+		rates = append(rates, pricingRate)
+	}
+
+	return rates, nil
+}
+
+// catalogProductFor matches a price list product to the region catalog rate it provides
+func catalogProductFor(offerCode string, product PriceListProduct) (catalogProduct, bool) {
+	attrs := product.Attributes
+	usageType := attrs["usagetype"]
+
+	switch {
+	case product.ProductFamily == "NAT Gateway" && strings.HasSuffix(usageType, "NatGateway-Hours"):
+		return catalogProduct{resourceType: "nat_gateway", componentName: "NAT Gateway Hourly", pricingModel: "per_hour", unit: "hour"}, true
+	case product.ProductFamily == "NAT Gateway" && strings.HasSuffix(usageType, "NatGateway-Bytes"):
+		return catalogProduct{resourceType: "nat_gateway", componentName: "NAT Gateway Data Processing", pricingModel: "per_gb", unit: "GB"}, true
+	case strings.HasSuffix(usageType, "ElasticIP:IdleAddress") || strings.HasSuffix(usageType, "PublicIPv4:IdleAddress"):
+		return catalogProduct{resourceType: "elastic_ip", componentName: "Elastic IP Hourly (Unattached)", pricingModel: "per_hour", unit: "hour"}, true
+	case product.ProductFamily == "Data Transfer":
+		return dataTransferProduct(attrs)
+	}
+
+	switch offerCode {
+	case "AmazonEC2":
+		if product.ProductFamily == "Storage" && strings.Contains(usageType, "EBS:VolumeUsage") && attrs["volumeApiName"] != "" {
+			volumeType := attrs["volumeApiName"]
+			return catalogProduct{
+				resourceType:  "ebs_volume",
+				componentName: awspricing.CatalogComponentName("EBS Volume Storage", volumeType),
+				pricingModel:  "per_gb",
+				unit:          "GB-month",
+				metadata:      map[string]string{"volume_type": volumeType},
+			}, true
+		}
+
+	case "AmazonS3":
+		if product.ProductFamily == "Storage" {
+			for suffix, class := range s3StorageClasses {
+				if usageType == suffix || strings.HasSuffix(usageType, "-"+suffix) {
+					return catalogProduct{
+						resourceType:  "s3_bucket",
+						componentName: awspricing.CatalogComponentName("S3 Storage", class),
+						pricingModel:  "per_gb",
+						unit:          "GB-month",
+						metadata:      map[string]string{"storage_class": class},
+					}, true
+				}
+			}
+		}
+		if product.ProductFamily == "API Request" {
+			switch {
+			case usageType == "Requests-Tier1" || strings.HasSuffix(usageType, "-Requests-Tier1"):
+				return catalogProduct{resourceType: "s3_bucket", componentName: "S3 PUT Requests", pricingModel: "per_request", unit: "request"}, true
+			case usageType == "Requests-Tier2" || strings.HasSuffix(usageType, "-Requests-Tier2"):
+				return catalogProduct{resourceType: "s3_bucket", componentName: "S3 GET Requests", pricingModel: "per_request", unit: "request"}, true
+			}
+		}
+
+	case "AWSELB":
+		if lbType, ok := loadBalancerTypes[product.ProductFamily]; ok && strings.HasSuffix(usageType, "LoadBalancerUsage") {
+			return catalogProduct{
+				resourceType:  "load_balancer",
+				componentName: awspricing.CatalogComponentName("Load Balancer Hourly", lbType),
+				pricingModel:  "per_hour",
+				unit:          "hour",
+				metadata:      map[string]string{"load_balancer_type": lbType},
+			}, true
+		}
+		if lbType, ok := loadBalancerTypes[product.ProductFamily]; ok && strings.HasSuffix(usageType, "LCUUsage") {
+			return catalogProduct{
+				resourceType:  "load_balancer",
+				componentName: awspricing.CatalogComponentName("Load Balancer LCU", lbType),
+				pricingModel:  "per_hour",
+				unit:          "LCU-hour",
+				metadata:      map[string]string{"load_balancer_type": lbType},
+			}, true
+		}
+
+	case "AWSLambda":
+		switch attrs["group"] {
+		case "AWS-Lambda-Duration":
+			return catalogProduct{resourceType: "lambda_function", componentName: "Lambda Compute", pricingModel: "per_gb", unit: "GB-second"}, true
+		case "AWS-Lambda-Requests":
+			return catalogProduct{resourceType: "lambda_function", componentName: "Lambda Requests", pricingModel: "per_request", unit: "request"}, true
+		}
+
+	case "AmazonRDS":
+		if product.ProductFamily != "Database Instance" || attrs["instanceType"] == "" {
+			break
+		}
+		engine, ok := rdsEngines[attrs["databaseEngine"]]
+		if !ok {
+			break
+		}
+		var deployment string
+		switch attrs["deploymentOption"] {
+		case "Single-AZ":
+			deployment = "single-az"
+		case "Multi-AZ":
+			deployment = "multi-az"
+		default:
+			return catalogProduct{}, false
+		}
+		return catalogProduct{
+			resourceType:  "rds_instance",
+			componentName: awspricing.CatalogComponentName("RDS Instance Hourly", engine, deployment),
+			pricingModel:  "per_hour",
+			unit:          "hour",
+			instanceType:  attrs["instanceType"],
+			metadata:      map[string]string{"engine": engine, "deployment": deployment},
+		}, true
+	}
+
+	return catalogProduct{}, false
+}
+
+// dataTransferProduct matches data transfer out to the internet and between availability
+// zones of a region
+func dataTransferProduct(attrs map[string]string) (catalogProduct, bool) {
+	switch {
+	case attrs["transferType"] == "AWS Outbound" && attrs["toLocation"] == "External":
+		return catalogProduct{resourceType: awspricing.DataTransferResourceType, componentName: awspricing.DataTransferOutComponent, pricingModel: "per_gb", unit: "GB"}, true
+	case attrs["transferType"] == "IntraRegion":
+		return catalogProduct{resourceType: awspricing.DataTransferResourceType, componentName: awspricing.DataTransferInterAZComponent, pricingModel: "per_gb", unit: "GB"}, true
+	}
+	return catalogProduct{}, false
+}
+
+// priceListRegion returns the region of a product. Data transfer products are priced in
+// the region they transfer from. Products of Local Zones, Wavelength Zones and Outposts
+// are skipped.
+func priceListRegion(product PriceListProduct) (string, bool) {
+	attrs := product.Attributes
+	regionKey, locationKey, locationTypeKey := "regionCode", "location", "locationType"
+	if product.ProductFamily == "Data Transfer" {
+		regionKey, locationKey, locationTypeKey = "fromRegionCode", "fromLocation", "fromLocationType"
+	}
+	if locationType := attrs[locationTypeKey]; locationType != "" && locationType != "AWS Region" {
+		return "", false
+	}
+	if region := attrs[regionKey]; region != "" {
+		return region, true
+	}
+	region, ok := priceListLocations[attrs[locationKey]]
+	return region, ok
+}
+
+// onDemandPrice returns the USD price of a product's On-Demand term. For tiered prices
+// this is the first tier with a charge, skipping free tiers.
+func onDemandPrice(terms map[string]PriceListTerm) (float64, bool) {
+	var dimensions []PriceListDimension
+	for _, term := range terms {
+		for _, dimension := range term.PriceDimensions {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return rangeStart(dimensions[i]) < rangeStart(dimensions[j])
+	})
+
+	for _, dimension := range dimensions {
+		price, err := strconv.ParseFloat(strings.TrimSpace(dimension.PricePerUnit["USD"]), 64)
+		if err == nil && price > 0 {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+func rangeStart(dimension PriceListDimension) float64 {
+	begin, err := strconv.ParseFloat(dimension.BeginRange, 64)
+	if err != nil {
+		return math.Inf(1)
+	}
+	return begin
+}
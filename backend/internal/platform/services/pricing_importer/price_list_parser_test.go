@@ -0,0 +1,116 @@
+package pricing_importer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertPriceListToPricingRates(t *testing.T) {
+	offerJSON := func(offerCode, products, terms string) *PriceListOffer {
+		var offer PriceListOffer
+		data := `{"formatVersion": "v1.0", "offerCode": "` + offerCode + `", "products": {` + products + `}, "terms": {"OnDemand": {` + terms + `}}}`
+		if err := json.Unmarshal([]byte(data), &offer); err != nil {
+			t.Fatalf("Failed to parse test data: %v", err)
+		}
+		return &offer
+	}
+	term := func(sku string, dimensions string) string {
+		return `"` + sku + `": {"` + sku + `.JRTCKXETXF": {"sku": "` + sku + `", "priceDimensions": {` + dimensions + `}}}`
+	}
+	price := func(code, begin, usd string) string {
+		return `"` + code + `": {"unit": "Hrs", "beginRange": "` + begin + `", "endRange": "Inf", "pricePerUnit": {"USD": "` + usd + `"}}`
+	}
+
+	tests := []struct {
+		name     string
+		offer    *PriceListOffer
+		expected map[string]float64 // "resource_type/component/region" -> rate
+	}{
+		{
+			name: "ec2 offer",
+			offer: offerJSON("AmazonEC2",
+				`"NAT1": {"sku": "NAT1", "productFamily": "NAT Gateway", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-NatGateway-Hours"}},
+				 "NAT2": {"sku": "NAT2", "productFamily": "NAT Gateway", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-NatGateway-Bytes"}},
+				 "EBS1": {"sku": "EBS1", "productFamily": "Storage", "attributes": {"location": "EU (Ireland)", "usagetype": "EU-EBS:VolumeUsage.gp3", "volumeApiName": "gp3"}},
+				 "EBS2": {"sku": "EBS2", "productFamily": "Storage", "attributes": {"regionCode": "us-east-1-bos-1", "locationType": "AWS Local Zone", "usagetype": "USE1-BOS1-EBS:VolumeUsage.gp3", "volumeApiName": "gp3"}},
+				 "EIP1": {"sku": "EIP1", "productFamily": "IP Address", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-ElasticIP:IdleAddress"}},
+				 "DT1": {"sku": "DT1", "productFamily": "Data Transfer", "attributes": {"fromRegionCode": "eu-west-1", "transferType": "AWS Outbound", "toLocation": "External"}},
+				 "EC2": {"sku": "EC2", "productFamily": "Compute Instance", "attributes": {"regionCode": "eu-west-1", "instanceType": "m5.large"}}`,
+				term("NAT1", price("a", "0", "0.048"))+","+
+					term("NAT2", price("a", "0", "0.048"))+","+
+					term("EBS1", price("a", "0", "0.088"))+","+
+					term("EBS2", price("a", "0", "0.096"))+","+
+					term("EIP1", price("a", "0", "0.005"))+","+
+					// Free first tier, then 0.09 per GB
+					term("DT1", price("a", "10240", "0.085")+","+price("b", "0", "0")+","+price("c", "1", "0.09"))+","+
+					term("EC2", price("a", "0", "0.107")),
+			),
+			expected: map[string]float64{
+				"nat_gateway/NAT Gateway Hourly/eu-west-1":            0.048,
+				"nat_gateway/NAT Gateway Data Processing/eu-west-1":   0.048,
+				"ebs_volume/EBS Volume Storage (gp3)/eu-west-1":       0.088,
+				"elastic_ip/Elastic IP Hourly (Unattached)/eu-west-1": 0.005,
+				"data_transfer/Data Transfer Out/eu-west-1":           0.09,
+			},
+		},
+		{
+			name: "rds offer",
+			offer: offerJSON("AmazonRDS",
+				`"RDS1": {"sku": "RDS1", "productFamily": "Database Instance", "attributes": {"regionCode": "ap-southeast-1", "instanceType": "db.t3.micro", "databaseEngine": "PostgreSQL", "deploymentOption": "Multi-AZ"}},
+				 "RDS2": {"sku": "RDS2", "productFamily": "Database Instance", "attributes": {"regionCode": "ap-southeast-1", "instanceType": "db.t3.micro", "databaseEngine": "Oracle", "deploymentOption": "Single-AZ"}}`,
+				term("RDS1", price("a", "0", "0.056"))+","+term("RDS2", price("a", "0", "0.04")),
+			),
+			expected: map[string]float64{
+				"rds_instance/RDS Instance Hourly (postgres, multi-az)/ap-southeast-1": 0.056,
+			},
+		},
+		{
+			name: "elb offer",
+			offer: offerJSON("AWSELB",
+				`"LB1": {"sku": "LB1", "productFamily": "Load Balancer-Application", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-LoadBalancerUsage"}},
+				 "LB2": {"sku": "LB2", "productFamily": "Load Balancer-Application", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-LCUUsage"}},
+				 "LB3": {"sku": "LB3", "productFamily": "Load Balancer-Network", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-LCUUsage"}}`,
+				term("LB1", price("a", "0", "0.0252"))+","+term("LB2", price("a", "0", "0.008"))+","+term("LB3", price("a", "0", "0.006")),
+			),
+			expected: map[string]float64{
+				"load_balancer/Load Balancer Hourly (application)/eu-west-1": 0.0252,
+				"load_balancer/Load Balancer LCU (application)/eu-west-1":    0.008,
+				"load_balancer/Load Balancer LCU (network)/eu-west-1":        0.006,
+			},
+		},
+		{
+			name: "s3 offer",
+			offer: offerJSON("AmazonS3",
+				`"S1": {"sku": "S1", "productFamily": "Storage", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-TimedStorage-SIA-ByteHrs"}},
+				 "S2": {"sku": "S2", "productFamily": "API Request", "attributes": {"regionCode": "eu-west-1", "usagetype": "EU-Requests-Tier1"}}`,
+				term("S1", price("a", "0", "0.0128"))+","+term("S2", price("a", "0", "0.0000053")),
+			),
+			expected: map[string]float64{
+				"s3_bucket/S3 Storage (standard-ia)/eu-west-1": 0.0128,
+				"s3_bucket/S3 PUT Requests/eu-west-1":          0.0000053,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := ConvertPriceListToPricingRates(tt.offer)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			got := make(map[string]float64)
+			for _, rate := range rates {
+				got[rate.ResourceType+"/"+rate.ComponentName+"/"+*rate.Region] = rate.Rate
+			}
+			if len(got) != len(tt.expected) {
+				t.Errorf("Expected %d rates, got %d: %v", len(tt.expected), len(got), got)
+			}
+			for key, rate := range tt.expected {
+				if got[key] != rate {
+					t.Errorf("%s: expected %v, got %v", key, rate, got[key])
+				}
+			}
+		})
+	}
+}
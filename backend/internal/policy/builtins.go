@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type builtin struct {
+	minArgs, maxArgs int
+	call             func(env *resourceView, args []interface{}) (interface{}, error)
+}
+
+func (b builtin) arity() string {
+	switch {
+	case b.minArgs == b.maxArgs && b.minArgs == 1:
+		return "1 argument"
+	case b.minArgs == b.maxArgs:
+		return fmt.Sprintf("%d arguments", b.minArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", b.minArgs, b.maxArgs)
+}
+
+// builtins are the functions available to expressions.
+var builtins = map[string]builtin{
+	// has_child("S3BucketVersioning"): a contained resource of the type exists.
+	"has_child": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		t, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return len(ofType(env.children, t)) > 0, nil
+	}},
+	// children("Subnet"): the contained resources, optionally of one type.
+	"children": {0, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		return filtered(env.children, args)
+	}},
+	// has_dependency("SecurityGroup"): the resource depends on one of the type.
+	"has_dependency": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		t, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return len(ofType(env.dependencies, t)) > 0, nil
+	}},
+	// dependencies("RDS"): the resources depended on, optionally of one type.
+	"dependencies": {0, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		return filtered(env.dependencies, args)
+	}},
+	// has_ancestor("VPC"): a resource of the type contains this one, at any depth.
+	"has_ancestor": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		t, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		for p, depth := env.parent, 0; p != nil && depth < 64; p, depth = p.parent, depth+1 {
+			if p.res.Type.Name == t {
+				return true, nil
+			}
+		}
+		return false, nil
+	}},
+	// count(x): the length of a list, string or object; 0 for none.
+	"count": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case []interface{}:
+			return float64(len(v)), nil
+		case string:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		}
+		return float64(0), nil
+	}},
+	// exists(config.kmsKeyId): the value is set.
+	"exists": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		if s, ok := args[0].(string); ok {
+			return s != "", nil
+		}
+		return args[0] != nil, nil
+	}},
+	// size("m5.xlarge"): the instance size in normalized units, comparable across
+	// families (nano 0.25 ... large 4, xlarge 8, 2xlarge 16); none if unknown.
+	"size": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		s, _ := args[0].(string)
+		if units, ok := instanceSize(s); ok {
+			return units, nil
+		}
+		return nil, nil
+	}},
+	"lower": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		s, _ := args[0].(string)
+		return strings.ToLower(s), nil
+	}},
+	"upper": {1, 1, func(env *resourceView, args []interface{}) (interface{}, error) {
+		s, _ := args[0].(string)
+		return strings.ToUpper(s), nil
+	}},
+	"startswith": {2, 2, func(env *resourceView, args []interface{}) (interface{}, error) {
+		s, _ := args[0].(string)
+		prefix, err := stringArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return strings.HasPrefix(s, prefix), nil
+	}},
+	"endswith": {2, 2, func(env *resourceView, args []interface{}) (interface{}, error) {
+		s, _ := args[0].(string)
+		suffix, err := stringArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return strings.HasSuffix(s, suffix), nil
+	}},
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d must be a string, got %s", i+1, display(args[i]))
+	}
+	return s, nil
+}
+
+func ofType(views []*resourceView, typeName string) []*resourceView {
+	var out []*resourceView
+	for _, v := range views {
+		if v.res.Type.Name == typeName {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func filtered(views []*resourceView, args []interface{}) (interface{}, error) {
+	if len(args) == 0 {
+		return viewList(views), nil
+	}
+	t, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return viewList(ofType(views, t)), nil
+}
+
+// sizeUnits are the normalization factors of instance sizes.
+var sizeUnits = map[string]float64{
+	"nano":   0.25,
+	"micro":  0.5,
+	"small":  1,
+	"medium": 2,
+	"large":  4,
+	"xlarge": 8,
+}
+
+// instanceSize returns the normalized size of an EC2, RDS ("db.") or ElastiCache
+// ("cache.") instance type: "Nxlarge" is N times xlarge.
+func instanceSize(instanceType string) (float64, bool) {
+	t := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(instanceType), "db."), "cache.")
+	dot := strings.LastIndexByte(t, '.')
+	if dot <= 0 {
+		return 0, false
+	}
+	size := t[dot+1:]
+	if units, ok := sizeUnits[size]; ok {
+		return units, true
+	}
+	if strings.HasSuffix(size, "xlarge") {
+		n, err := strconv.Atoi(strings.TrimSuffix(size, "xlarge"))
+		if err == nil && n > 0 {
+			return float64(n) * sizeUnits["xlarge"], true
+		}
+	}
+	return 0, false
+}
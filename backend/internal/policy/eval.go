@@ -0,0 +1,542 @@
+package policy
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// rootFields are the names an expression can start from; they describe the resource
+// being checked.
+var rootFields = map[string]bool{
+	"id":           true,
+	"name":         true,
+	"type":         true,
+	"region":       true,
+	"config":       true,
+	"tags":         true,
+	"parent":       true,
+	"children":     true,
+	"dependencies": true,
+}
+
+// Expr is a parsed expression. Values are nil, bool, float64, string, lists, objects
+// and resources; missing fields evaluate to nil rather than failing.
+type Expr interface {
+	eval(env *resourceView) (interface{}, error)
+}
+
+// Evaluate checks every resource of the architecture against the policies. Visual-only
+// resources are skipped, as in rule validation. Resources are visited in architecture
+// order, and each policy reports at most one violation per resource.
+func Evaluate(policies []*Policy, arch *architecture.Architecture) []Violation {
+	if arch == nil {
+		return nil
+	}
+	views := buildViews(arch)
+
+	var violations []Violation
+	for _, res := range arch.Resources {
+		if visualOnly, _ := res.Metadata["isVisualOnly"].(bool); visualOnly {
+			continue
+		}
+		view := views[res.ID]
+		for _, pol := range policies {
+			if !pol.Applies(res.Type.Name) {
+				continue
+			}
+			if v := check(pol, view); v != nil {
+				violations = append(violations, *v)
+			}
+		}
+	}
+	return violations
+}
+
+func check(pol *Policy, view *resourceView) *Violation {
+	violation := func(message string) *Violation {
+		return &Violation{
+			Policy:       pol.Name,
+			Severity:     pol.Severity,
+			ResourceID:   view.res.ID,
+			ResourceName: view.res.Name,
+			ResourceType: view.res.Type.Name,
+			Message:      message,
+		}
+	}
+
+	for _, cond := range pol.When {
+		v, err := cond.eval(view)
+		if err != nil {
+			return violation(fmt.Sprintf("policy %q could not be evaluated: %v", pol.Name, err))
+		}
+		if !truthy(v) {
+			return nil
+		}
+	}
+	for _, req := range pol.Require {
+		v, err := req.eval(view)
+		if err != nil {
+			return violation(fmt.Sprintf("policy %q could not be evaluated: %v", pol.Name, err))
+		}
+		if truthy(v) {
+			continue
+		}
+		switch {
+		case pol.Message != nil:
+			return violation(pol.Message.render(view))
+		case pol.Description != "":
+			return violation(fmt.Sprintf("%s %q violates policy %q: %s", view.res.Type.Name, view.res.Name, pol.Name, pol.Description))
+		default:
+			return violation(fmt.Sprintf("%s %q violates policy %q", view.res.Type.Name, view.res.Name, pol.Name))
+		}
+	}
+	return nil
+}
+
+// resourceView is a resource as seen by expressions.
+type resourceView struct {
+	res          *resource.Resource
+	config       map[string]interface{}
+	tags         map[string]interface{}
+	parent       *resourceView
+	children     []*resourceView
+	dependencies []*resourceView
+}
+
+func buildViews(arch *architecture.Architecture) map[string]*resourceView {
+	views := make(map[string]*resourceView, len(arch.Resources))
+	for _, res := range arch.Resources {
+		views[res.ID] = &resourceView{res: res, config: configOf(res), tags: tagsOf(res)}
+	}
+	for _, res := range arch.Resources {
+		view := views[res.ID]
+		if res.ParentID != nil {
+			if parent, ok := views[*res.ParentID]; ok && parent != view {
+				view.parent = parent
+				parent.children = append(parent.children, view)
+			}
+		}
+		seen := make(map[string]bool)
+		deps := append(append([]string{}, res.DependsOn...), arch.Dependencies[res.ID]...)
+		for _, id := range deps {
+			if dep, ok := views[id]; ok && !seen[id] {
+				seen[id] = true
+				view.dependencies = append(view.dependencies, dep)
+			}
+		}
+	}
+	return views
+}
+
+// configOf returns the resource configuration without editor and bookkeeping keys.
+func configOf(res *resource.Resource) map[string]interface{} {
+	config := make(map[string]interface{}, len(res.Metadata))
+	for k, v := range res.Metadata {
+		if k == "ui" || k == "position" || k == "isVisualOnly" || strings.HasPrefix(k, "_") {
+			continue
+		}
+		config[k] = v
+	}
+	return config
+}
+
+// tagsOf reads the "tags" configuration, given either as an object or as a list of
+// {key, value} entries.
+func tagsOf(res *resource.Resource) map[string]interface{} {
+	tags := make(map[string]interface{})
+	switch raw := res.Metadata["tags"].(type) {
+	case map[string]interface{}:
+		for k, v := range raw {
+			tags[k] = v
+		}
+	case map[string]string:
+		for k, v := range raw {
+			tags[k] = v
+		}
+	case []interface{}:
+		for _, item := range raw {
+			entry, _ := item.(map[string]interface{})
+			key, _ := entry["key"].(string)
+			if key == "" {
+				key, _ = entry["Key"].(string)
+			}
+			if key == "" {
+				continue
+			}
+			if v, ok := entry["value"]; ok {
+				tags[key] = v
+			} else {
+				tags[key] = entry["Value"]
+			}
+		}
+	}
+	return tags
+}
+
+func (v *resourceView) field(name string) interface{} {
+	switch name {
+	case "id":
+		return v.res.ID
+	case "name":
+		return v.res.Name
+	case "type":
+		return v.res.Type.Name
+	case "region":
+		return v.res.Region
+	case "config":
+		return v.config
+	case "tags":
+		return v.tags
+	case "parent":
+		if v.parent == nil {
+			return nil
+		}
+		return v.parent
+	case "children":
+		return viewList(v.children)
+	case "dependencies":
+		return viewList(v.dependencies)
+	}
+	return nil
+}
+
+func viewList(views []*resourceView) []interface{} {
+	out := make([]interface{}, len(views))
+	for i, v := range views {
+		out[i] = v
+	}
+	return out
+}
+
+// ── expression nodes ──────────────────────────────────────────────────────────
+
+type literalExpr struct{ value interface{} }
+
+func (e *literalExpr) eval(*resourceView) (interface{}, error) { return e.value, nil }
+
+type listExpr struct{ items []Expr }
+
+func (e *listExpr) eval(env *resourceView) (interface{}, error) {
+	out := make([]interface{}, len(e.items))
+	for i, item := range e.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+type nameExpr struct{ name string }
+
+func (e *nameExpr) eval(env *resourceView) (interface{}, error) { return env.field(e.name), nil }
+
+type fieldExpr struct {
+	target Expr
+	name   string
+}
+
+func (e *fieldExpr) eval(env *resourceView) (interface{}, error) {
+	target, err := e.target.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return lookup(target, e.name), nil
+}
+
+type indexExpr struct {
+	target Expr
+	index  Expr
+}
+
+func (e *indexExpr) eval(env *resourceView) (interface{}, error) {
+	target, err := e.target.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.index.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := index.(string); ok {
+		return lookup(target, key), nil
+	}
+	n, ok := toNumber(index)
+	if !ok {
+		return nil, nil
+	}
+	list, ok := target.([]interface{})
+	if !ok || n < 0 || int(n) >= len(list) || n != float64(int(n)) {
+		return nil, nil
+	}
+	return list[int(n)], nil
+}
+
+func lookup(target interface{}, key string) interface{} {
+	switch t := target.(type) {
+	case *resourceView:
+		return t.field(key)
+	case map[string]interface{}:
+		return t[key]
+	case map[string]string:
+		if v, ok := t[key]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+type notExpr struct{ operand Expr }
+
+func (e *notExpr) eval(env *resourceView) (interface{}, error) {
+	v, err := e.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(v), nil
+}
+
+type logicalExpr struct {
+	op          string
+	left, right Expr
+}
+
+func (e *logicalExpr) eval(env *resourceView) (interface{}, error) {
+	left, err := e.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if e.op == "and" && !truthy(left) {
+		return false, nil
+	}
+	if e.op == "or" && truthy(left) {
+		return true, nil
+	}
+	right, err := e.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(right), nil
+}
+
+type compareExpr struct {
+	op          string
+	left, right Expr
+	re          *regexp.Regexp // compiled literal pattern of "matches"
+}
+
+func (e *compareExpr) eval(env *resourceView) (interface{}, error) {
+	left, err := e.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "in":
+		return contains(right, left), nil
+	case "not in":
+		return !contains(right, left), nil
+	case "matches":
+		s, ok := left.(string)
+		if !ok {
+			return false, nil
+		}
+		re := e.re
+		if re == nil {
+			pattern, ok := right.(string)
+			if !ok {
+				return nil, fmt.Errorf("matches needs a string pattern")
+			}
+			if re, err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+			}
+		}
+		return re.MatchString(s), nil
+	}
+
+	// Ordering compares numbers with numbers and strings with strings; anything else,
+	// including missing values, is false.
+	if a, ok := toNumber(left); ok {
+		b, ok := toNumber(right)
+		if !ok {
+			return false, nil
+		}
+		return ordered(e.op, compareFloat(a, b)), nil
+	}
+	a, aok := left.(string)
+	b, bok := right.(string)
+	if !aok || !bok {
+		return false, nil
+	}
+	return ordered(e.op, strings.Compare(a, b)), nil
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func ordered(op string, c int) bool {
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+type callExpr struct {
+	name string
+	fn   builtin
+	args []Expr
+}
+
+func (e *callExpr) eval(env *resourceView) (interface{}, error) {
+	args := make([]interface{}, len(e.args))
+	for i, arg := range e.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := e.fn.call(env, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.name, err)
+	}
+	return v, nil
+}
+
+// ── values ────────────────────────────────────────────────────────────────────
+
+func truthy(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case string:
+		return t != ""
+	case []interface{}:
+		return len(t) > 0
+	case map[string]interface{}:
+		return len(t) > 0
+	}
+	if n, ok := toNumber(v); ok {
+		return n != 0
+	}
+	return true
+}
+
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func equal(a, b interface{}) bool {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+	if x, ok := a.(*resourceView); ok {
+		y, ok := b.(*resourceView)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// contains implements "in": membership in a list, a substring of a string, or a key
+// of an object.
+func contains(collection, item interface{}) bool {
+	switch c := collection.(type) {
+	case []interface{}:
+		for _, v := range c {
+			if equal(v, item) {
+				return true
+			}
+		}
+	case string:
+		s, ok := item.(string)
+		return ok && strings.Contains(c, s)
+	case map[string]interface{}:
+		s, ok := item.(string)
+		if !ok {
+			return false
+		}
+		_, found := c[s]
+		return found
+	}
+	return false
+}
+
+// display formats a value for a violation message.
+func display(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "none"
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	case *resourceView:
+		return t.res.Name
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			parts[i] = display(item)
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + "=" + display(t[k])
+		}
+		return strings.Join(parts, ", ")
+	}
+	if n, ok := toNumber(v); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
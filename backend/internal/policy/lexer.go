@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNewline
+	tokIdent
+	tokString
+	tokNumber
+	tokOp // == != < <= > >= -
+	tokLBrace
+	tokRBrace
+	tokLParen
+	tokRParen
+	tokLBrack
+	tokRBrack
+	tokComma
+	tokDot
+)
+
+type token struct {
+	kind tokenKind
+	text string // identifier, operator, number text or unquoted string
+	line int
+	col  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokNewline:
+		return "end of line"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// ParseError is a syntax or semantic error in a policy document.
+type ParseError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d:%d: %s", e.Line, e.Col, e.Msg)
+}
+
+// lex splits a policy document into tokens. Newlines are significant between policy
+// fields but not inside parentheses or brackets, so long expressions can be wrapped
+// there. "#" starts a comment running to the end of the line.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	line, col := 1, 1
+	depth := 0
+	advance := func(n int) {
+		for i := 0; i < n; i++ {
+			if runes[0] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			runes = runes[1:]
+		}
+	}
+	emit := func(kind tokenKind, text string) {
+		tokens = append(tokens, token{kind: kind, text: text, line: line, col: col})
+	}
+
+	for len(runes) > 0 {
+		r := runes[0]
+		switch {
+		case r == '\n':
+			if depth == 0 {
+				emit(tokNewline, "\n")
+			}
+			advance(1)
+		case unicode.IsSpace(r):
+			advance(1)
+		case r == '#':
+			for len(runes) > 0 && runes[0] != '\n' {
+				advance(1)
+			}
+		case r == '"':
+			startLine, startCol := line, col
+			var sb strings.Builder
+			advance(1)
+			closed := false
+			for len(runes) > 0 {
+				c := runes[0]
+				if c == '"' {
+					advance(1)
+					closed = true
+					break
+				}
+				if c == '\n' {
+					break
+				}
+				if c == '\\' && len(runes) > 1 {
+					switch runes[1] {
+					case 'n':
+						sb.WriteRune('\n')
+					case 't':
+						sb.WriteRune('\t')
+					default:
+						sb.WriteRune(runes[1])
+					}
+					advance(2)
+					continue
+				}
+				sb.WriteRune(c)
+				advance(1)
+			}
+			if !closed {
+				return nil, &ParseError{Line: startLine, Col: startCol, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), line: startLine, col: startCol})
+		case unicode.IsDigit(r):
+			n := 0
+			for n < len(runes) && (unicode.IsDigit(runes[n]) || runes[n] == '.') {
+				n++
+			}
+			emit(tokNumber, string(runes[:n]))
+			advance(n)
+		case r == '_' || unicode.IsLetter(r):
+			n := 0
+			for n < len(runes) && (runes[n] == '_' || unicode.IsLetter(runes[n]) || unicode.IsDigit(runes[n])) {
+				n++
+			}
+			emit(tokIdent, string(runes[:n]))
+			advance(n)
+		default:
+			two := ""
+			if len(runes) > 1 {
+				two = string(runes[:2])
+			}
+			switch {
+			case two == "==" || two == "!=" || two == "<=" || two == ">=":
+				emit(tokOp, two)
+				advance(2)
+			case r == '<' || r == '>' || r == '-':
+				emit(tokOp, string(r))
+				advance(1)
+			case r == '{':
+				emit(tokLBrace, "{")
+				advance(1)
+			case r == '}':
+				emit(tokRBrace, "}")
+				advance(1)
+			case r == '(' || r == '[':
+				kind := tokLParen
+				if r == '[' {
+					kind = tokLBrack
+				}
+				emit(kind, string(r))
+				depth++
+				advance(1)
+			case r == ')' || r == ']':
+				kind := tokRParen
+				if r == ']' {
+					kind = tokRBrack
+				}
+				emit(kind, string(r))
+				if depth > 0 {
+					depth--
+				}
+				advance(1)
+			case r == ',':
+				emit(tokComma, ",")
+				advance(1)
+			case r == '.':
+				emit(tokDot, ".")
+				advance(1)
+			default:
+				return nil, &ParseError{Line: line, Col: col, Msg: fmt.Sprintf("unexpected character %q", r)}
+			}
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, line: line, col: col})
+	return tokens, nil
+}
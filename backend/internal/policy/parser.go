@@ -0,0 +1,301 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) prev() token {
+	if p.pos == 0 {
+		return p.tokens[0]
+	}
+	return p.tokens[p.pos-1]
+}
+
+func (p *parser) skipNewlines() {
+	for p.peek().kind == tokNewline {
+		p.next()
+	}
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &ParseError{Line: t.line, Col: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.text == word
+}
+
+// ParseExpr parses a single expression, such as a "require" condition.
+func ParseExpr(src string) (Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	p.skipNewlines()
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	return e, nil
+}
+
+// parseExpr parses, from lowest to highest precedence: or, and, not, comparisons,
+// then operands with field access, indexing and calls.
+func (p *parser) parseExpr() (Expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		p.skipNewlines()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		p.skipNewlines()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.isKeyword("not") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	op := ""
+	switch {
+	case t.kind == tokOp && t.text != "-":
+		op = t.text
+	case t.kind == tokIdent && (t.text == "in" || t.text == "matches"):
+		op = t.text
+	case t.kind == tokIdent && t.text == "not" && p.tokens[p.pos+1].kind == tokIdent && p.tokens[p.pos+1].text == "in":
+		p.next()
+		op = "not in"
+	default:
+		return left, nil
+	}
+	p.next()
+	p.skipNewlines()
+	right, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &compareExpr{op: op, left: left, right: right}
+	if op == "matches" {
+		// Literal patterns are compiled once, and bad ones rejected up front.
+		if lit, ok := right.(*literalExpr); ok {
+			pattern, isString := lit.value.(string)
+			if !isString {
+				return nil, p.errorf(t, "matches needs a string pattern")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, p.errorf(t, "invalid pattern %q: %v", pattern, err)
+			}
+			cmp.re = re
+		}
+	}
+	if next := p.peek(); next.kind == tokOp && next.text != "-" {
+		return nil, p.errorf(next, "comparisons cannot be chained; use \"and\"")
+	}
+	return cmp, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if t := p.peek(); t.kind == tokOp && t.text == "-" {
+		p.next()
+		num := p.next()
+		if num.kind != tokNumber {
+			return nil, p.errorf(num, "expected a number after \"-\"")
+		}
+		f, err := strconv.ParseFloat(num.text, 64)
+		if err != nil {
+			return nil, p.errorf(num, "invalid number %q", num.text)
+		}
+		return &literalExpr{value: -f}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (Expr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokDot:
+			p.next()
+			field := p.next()
+			if field.kind != tokIdent {
+				return nil, p.errorf(field, "expected a field name after \".\", found %s", field)
+			}
+			e = &fieldExpr{target: e, name: field.text}
+		case tokLBrack:
+			p.next()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if t := p.next(); t.kind != tokRBrack {
+				return nil, p.errorf(t, "expected \"]\", found %s", t)
+			}
+			e = &indexExpr{target: e, index: index}
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return &literalExpr{value: t.text}, nil
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.errorf(t, "invalid number %q", t.text)
+		}
+		return &literalExpr{value: f}, nil
+	case tokLParen:
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokRParen {
+			return nil, p.errorf(c, "expected \")\", found %s", c)
+		}
+		return e, nil
+	case tokLBrack:
+		list := &listExpr{}
+		if p.peek().kind == tokRBrack {
+			p.next()
+			return list, nil
+		}
+		for {
+			item, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			list.items = append(list.items, item)
+			sep := p.next()
+			if sep.kind == tokRBrack {
+				return list, nil
+			}
+			if sep.kind != tokComma {
+				return nil, p.errorf(sep, "expected \",\" or \"]\", found %s", sep)
+			}
+		}
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &literalExpr{value: true}, nil
+		case "false":
+			return &literalExpr{value: false}, nil
+		case "null":
+			return &literalExpr{value: nil}, nil
+		case "and", "or", "not", "in", "matches":
+			return nil, p.errorf(t, "unexpected %q", t.text)
+		}
+		if p.peek().kind == tokLParen {
+			return p.parseCall(t)
+		}
+		if !rootFields[t.text] {
+			return nil, p.errorf(t, "unknown name %q", t.text)
+		}
+		return &nameExpr{name: t.text}, nil
+	}
+	return nil, p.errorf(t, "expected a value, found %s", t)
+}
+
+func (p *parser) parseCall(name token) (Expr, error) {
+	fn, ok := builtins[name.text]
+	if !ok {
+		return nil, p.errorf(name, "unknown function %q", name.text)
+	}
+	p.next() // (
+	call := &callExpr{name: name.text, fn: fn}
+	if p.peek().kind != tokRParen {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if t := p.next(); t.kind != tokRParen {
+		return nil, p.errorf(t, "expected \")\", found %s", t)
+	}
+	if len(call.args) < fn.minArgs || len(call.args) > fn.maxArgs {
+		return nil, p.errorf(name, "%s takes %s", name.text, fn.arity())
+	}
+	return call, nil
+}
@@ -0,0 +1,242 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severities of policy violations. Error violations make an architecture invalid;
+// warnings are reported without blocking.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Policy is one guardrail: resources of the listed types that match When must satisfy
+// every Require expression.
+type Policy struct {
+	Name        string
+	Description string
+	Severity    string
+	Resources   []string // resource type names; "*" matches every type
+	When        []Expr
+	Require     []Expr
+	Message     *Template // nil for the default message
+}
+
+// Applies reports whether the policy covers a resource type.
+func (p *Policy) Applies(resourceType string) bool {
+	for _, t := range p.Resources {
+		if t == "*" || t == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// Violation is a resource failing a policy.
+type Violation struct {
+	Policy       string
+	Severity     string
+	ResourceID   string
+	ResourceName string
+	ResourceType string
+	Message      string
+}
+
+// Parse parses a policy document, which holds one or more policy blocks:
+//
+//	policy "dev-instance-size" {
+//	  description "No EC2 instance larger than m5.xlarge in dev"
+//	  severity    "error"
+//	  resource    "EC2"
+//	  when        tags.Environment == "dev"
+//	  require     size(config.instanceType) <= size("m5.xlarge")
+//	  message     "{name} is {config.instanceType}, larger than m5.xlarge"
+//	}
+//
+// See the package README for the expression language.
+func Parse(src string) ([]*Policy, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	var policies []*Policy
+	names := make(map[string]bool)
+	for {
+		p.skipNewlines()
+		if p.peek().kind == tokEOF {
+			break
+		}
+		pol, err := p.parsePolicy()
+		if err != nil {
+			return nil, err
+		}
+		if names[pol.Name] {
+			return nil, p.errorf(p.prev(), "duplicate policy %q", pol.Name)
+		}
+		names[pol.Name] = true
+		policies = append(policies, pol)
+	}
+	if len(policies) == 0 {
+		return nil, &ParseError{Line: 1, Col: 1, Msg: "document defines no policies"}
+	}
+	return policies, nil
+}
+
+func (p *parser) parsePolicy() (*Policy, error) {
+	kw := p.next()
+	if kw.kind != tokIdent || kw.text != "policy" {
+		return nil, p.errorf(kw, "expected \"policy\", found %s", kw)
+	}
+	name := p.next()
+	if name.kind != tokString || strings.TrimSpace(name.text) == "" {
+		return nil, p.errorf(name, "expected a policy name in quotes, found %s", name)
+	}
+	if t := p.next(); t.kind != tokLBrace {
+		return nil, p.errorf(t, "expected \"{\", found %s", t)
+	}
+
+	pol := &Policy{Name: name.text, Severity: SeverityError}
+	seen := make(map[string]bool)
+	for {
+		p.skipNewlines()
+		field := p.next()
+		if field.kind == tokRBrace {
+			break
+		}
+		if field.kind != tokIdent {
+			return nil, p.errorf(field, "expected a policy field or \"}\", found %s", field)
+		}
+		if seen[field.text] && field.text != "when" && field.text != "require" {
+			return nil, p.errorf(field, "%s is set twice", field.text)
+		}
+		seen[field.text] = true
+
+		switch field.text {
+		case "description":
+			s, err := p.expectString()
+			if err != nil {
+				return nil, err
+			}
+			pol.Description = s
+		case "severity":
+			s, err := p.expectString()
+			if err != nil {
+				return nil, err
+			}
+			if s != SeverityError && s != SeverityWarning {
+				return nil, p.errorf(p.prev(), "severity must be %q or %q", SeverityError, SeverityWarning)
+			}
+			pol.Severity = s
+		case "resource":
+			for {
+				s, err := p.expectString()
+				if err != nil {
+					return nil, err
+				}
+				pol.Resources = append(pol.Resources, s)
+				if p.peek().kind != tokComma {
+					break
+				}
+				p.next()
+			}
+		case "when", "require":
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if field.text == "when" {
+				pol.When = append(pol.When, e)
+			} else {
+				pol.Require = append(pol.Require, e)
+			}
+		case "message":
+			s, err := p.expectString()
+			if err != nil {
+				return nil, err
+			}
+			tmpl, err := parseTemplate(s)
+			if err != nil {
+				return nil, p.errorf(p.prev(), "message: %v", err)
+			}
+			pol.Message = tmpl
+		default:
+			return nil, p.errorf(field, "unknown policy field %q", field.text)
+		}
+
+		if t := p.peek(); t.kind != tokNewline && t.kind != tokRBrace {
+			return nil, p.errorf(t, "unexpected %s after %s", t, field.text)
+		}
+	}
+
+	if len(pol.Resources) == 0 {
+		return nil, p.errorf(p.prev(), "policy %q has no resource", pol.Name)
+	}
+	if len(pol.Require) == 0 {
+		return nil, p.errorf(p.prev(), "policy %q has no require", pol.Name)
+	}
+	return pol, nil
+}
+
+func (p *parser) expectString() (string, error) {
+	t := p.next()
+	if t.kind != tokString {
+		return "", p.errorf(t, "expected a string in quotes, found %s", t)
+	}
+	return t.text, nil
+}
+
+// Template is a violation message with "{expression}" placeholders.
+type Template struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	text string
+	expr Expr
+}
+
+func parseTemplate(s string) (*Template, error) {
+	tmpl := &Template{}
+	for s != "" {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			tmpl.parts = append(tmpl.parts, templatePart{text: s})
+			break
+		}
+		end := strings.IndexByte(s[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed \"{\"")
+		}
+		if open > 0 {
+			tmpl.parts = append(tmpl.parts, templatePart{text: s[:open]})
+		}
+		e, err := ParseExpr(s[open+1 : open+end])
+		if err != nil {
+			return nil, err
+		}
+		tmpl.parts = append(tmpl.parts, templatePart{expr: e})
+		s = s[open+end+1:]
+	}
+	return tmpl, nil
+}
+
+func (t *Template) render(env *resourceView) string {
+	var sb strings.Builder
+	for _, part := range t.parts {
+		if part.expr == nil {
+			sb.WriteString(part.text)
+			continue
+		}
+		v, err := part.expr.eval(env)
+		if err != nil {
+			sb.WriteString("?")
+			continue
+		}
+		sb.WriteString(display(v))
+	}
+	return sb.String()
+}
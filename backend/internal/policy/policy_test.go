@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+const companyPolicies = `
+# Buckets must be encrypted and versioned.
+policy "s3-encrypted-and-versioned" {
+  description "Every S3 bucket must have encryption and versioning"
+  resource    "S3"
+  require     has_child("S3BucketEncryption") and
+              has_child("S3BucketVersioning")
+}
+
+policy "dev-instance-size" {
+  severity "warning"
+  resource "EC2", "LaunchTemplate"
+  when     tags.Environment == "dev"
+  require  size(config.instanceType) <= size("m5.xlarge")
+  message  "{name} is {config.instanceType}, larger than m5.xlarge"
+}
+
+policy "instances-in-vpc" {
+  resource "EC2"
+  require  has_ancestor("VPC")
+  require  config.instanceType in ["t3.micro", "m5.large", "m5.4xlarge"]
+  require  not (name matches "^tmp-")
+}
+`
+
+func newResource(id, name, typeName string, metadata map[string]interface{}) *resource.Resource {
+	return &resource.Resource{ID: id, Name: name, Type: resource.ResourceType{Name: typeName}, Metadata: metadata}
+}
+
+func setParent(res *resource.Resource, parentID string) {
+	res.ParentID = &parentID
+}
+
+func TestParseAndEvaluate(t *testing.T) {
+	policies, err := Parse(companyPolicies)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(policies) != 3 {
+		t.Fatalf("policies = %d, want 3", len(policies))
+	}
+
+	vpc := newResource("vpc", "main", "VPC", map[string]interface{}{})
+	subnet := newResource("subnet", "app", "Subnet", map[string]interface{}{})
+	setParent(subnet, "vpc")
+	small := newResource("ec2-1", "web", "EC2", map[string]interface{}{
+		"instanceType": "t3.micro", "tags": map[string]interface{}{"Environment": "dev"},
+	})
+	setParent(small, "subnet")
+	big := newResource("ec2-2", "batch", "EC2", map[string]interface{}{
+		"instanceType": "m5.4xlarge", "tags": []interface{}{map[string]interface{}{"key": "Environment", "value": "dev"}},
+	})
+	setParent(big, "subnet")
+	stray := newResource("ec2-3", "tmp-debug", "EC2", map[string]interface{}{"instanceType": "m5.large"})
+	bucket := newResource("s3-1", "logs", "S3", map[string]interface{}{})
+	enc := newResource("s3-enc", "logs-encryption", "S3BucketEncryption", map[string]interface{}{})
+	setParent(enc, "s3-1")
+	bare := newResource("s3-2", "assets", "S3", map[string]interface{}{})
+	note := newResource("note", "todo", "S3", map[string]interface{}{"isVisualOnly": true})
+
+	arch := architecture.NewArchitecture()
+	arch.Resources = []*resource.Resource{vpc, subnet, small, big, stray, bucket, enc, bare, note}
+
+	got := make(map[string]Violation)
+	for _, v := range Evaluate(policies, arch) {
+		got[v.ResourceID+"/"+v.Policy] = v
+	}
+	if len(got) != 4 {
+		t.Errorf("violations = %+v, want 4", got)
+	}
+
+	for _, id := range []string{"s3-1", "s3-2"} {
+		v, ok := got[id+"/s3-encrypted-and-versioned"]
+		if !ok || v.Severity != SeverityError || !strings.Contains(v.Message, "Every S3 bucket must have encryption and versioning") {
+			t.Errorf("%s: violation = %+v, want the bucket policy with its description", id, v)
+		}
+	}
+	if v := got["ec2-2/dev-instance-size"]; v.Severity != SeverityWarning || v.Message != "batch is m5.4xlarge, larger than m5.xlarge" {
+		t.Errorf("ec2-2: violation = %+v, want the size warning", v)
+	}
+	if _, ok := got["ec2-3/instances-in-vpc"]; !ok {
+		t.Errorf("ec2-3: want the VPC policy violation, got %+v", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"empty", "# nothing here", "no policies"},
+		{"no require", `policy "p" { resource "EC2" }`, "has no require"},
+		{"no resource", "policy \"p\" {\n require true\n}", "has no resource"},
+		{"unknown field", "policy \"p\" {\n resource \"EC2\"\n owner \"me\"\n}", `unknown policy field "owner"`},
+		{"unknown name", "policy \"p\" {\n resource \"EC2\"\n require instance_type == \"t3\"\n}", `unknown name "instance_type"`},
+		{"unknown function", "policy \"p\" {\n resource \"EC2\"\n require has_tag(\"x\")\n}", `unknown function "has_tag"`},
+		{"arity", "policy \"p\" {\n resource \"EC2\"\n require has_child()\n}", "has_child takes 1 argument"},
+		{"bad pattern", "policy \"p\" {\n resource \"EC2\"\n require name matches \"(\"\n}", "invalid pattern"},
+		{"bad severity", "policy \"p\" {\n severity \"fatal\"\n resource \"EC2\"\n require true\n}", "severity must be"},
+		{"duplicate", "policy \"p\" {\n resource \"EC2\"\n require true\n}\npolicy \"p\" {\n resource \"S3\"\n require true\n}", `duplicate policy "p"`},
+		{"chained comparison", "policy \"p\" {\n resource \"EC2\"\n require 1 < 2 < 3\n}", "cannot be chained"},
+		{"unterminated string", "policy \"p", "unterminated string"},
+		{"trailing tokens", "policy \"p\" {\n resource \"EC2\" true\n require true\n}", "unexpected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressions(t *testing.T) {
+	vpc := newResource("vpc", "main", "VPC", map[string]interface{}{"cidr": "10.0.0.0/16"})
+	db := newResource("db", "orders", "RDS", map[string]interface{}{
+		"instanceClass": "db.r5.2xlarge", "allocatedStorage": 100.0, "multiAz": true,
+		"tags": map[string]interface{}{"cost-center": "42"}, "_originalIDToName": map[string]string{},
+	})
+	setParent(db, "vpc")
+	sg := newResource("sg", "db-sg", "SecurityGroup", map[string]interface{}{})
+	db.DependsOn = []string{"sg"}
+	arch := architecture.NewArchitecture()
+	arch.Resources = []*resource.Resource{vpc, db, sg}
+	view := buildViews(arch)["db"]
+
+	tests := map[string]interface{}{
+		`config.allocatedStorage >= 100`:             true,
+		`config.allocatedStorage > -1`:               true,
+		`config.multiAz`:                             true,
+		`config.missing == null`:                     true,
+		`config.missing > 3`:                         false,
+		`exists(config._originalIDToName)`:           false,
+		`tags["cost-center"]`:                        "42",
+		`parent.type`:                                "VPC",
+		`parent.config.cidr`:                         "10.0.0.0/16",
+		`parent.parent.name`:                         nil,
+		`count(dependencies("SecurityGroup"))`:       1.0,
+		`dependencies()[0].name`:                     "db-sg",
+		`has_dependency("SecurityGroup")`:            true,
+		`size(config.instanceClass)`:                 16.0,
+		`size("m5.metal")`:                           nil,
+		`"r5" in config.instanceClass`:               true,
+		`"eu-west-1" not in ["us-east-1"]`:           true,
+		`"tags" in config`:                           true,
+		`startswith(lower(name), "ord")`:             true,
+		`region == "" or type == "VPC"`:              true,
+		`name matches "^ord" and not has_child("X")`: true,
+	}
+	for src, want := range tests {
+		e, err := ParseExpr(src)
+		if err != nil {
+			t.Errorf("ParseExpr(%s): %v", src, err)
+			continue
+		}
+		got, err := e.eval(view)
+		if err != nil {
+			t.Errorf("eval(%s): %v", src, err)
+			continue
+		}
+		if !equal(got, want) {
+			t.Errorf("%s = %#v, want %#v", src, got, want)
+		}
+	}
+}
+
+func TestEvaluate_RuntimeError(t *testing.T) {
+	policies, err := Parse("policy \"p\" {\n resource \"*\"\n require has_child(config.kind)\n}")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	arch := architecture.NewArchitecture()
+	arch.Resources = []*resource.Resource{newResource("a", "a", "EC2", map[string]interface{}{})}
+	violations := Evaluate(policies, arch)
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, "could not be evaluated") {
+		t.Errorf("violations = %+v, want one evaluation error", violations)
+	}
+}
@@ -0,0 +1,174 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// BudgetAction is what happens to a version whose estimate exceeds the budget
+type BudgetAction string
+
+const (
+	// BudgetFlag saves the version and reports the overages
+	BudgetFlag BudgetAction = "flag"
+	// BudgetBlock refuses to save the version
+	BudgetBlock BudgetAction = "block"
+)
+
+// Budget is the monthly spending limit of a project, in USD. Limits of zero are not set.
+type Budget struct {
+	// MonthlyLimit is the limit of the whole architecture
+	MonthlyLimit float64 `json:"monthly_limit,omitempty"`
+	// CategoryLimits holds limits by resource category, e.g. "compute" or "networking"
+	CategoryLimits map[string]float64 `json:"category_limits,omitempty"`
+	// Action is flag (default) or block
+	Action BudgetAction `json:"action,omitempty"`
+}
+
+// Budget statuses
+const (
+	WithinBudget = "within_budget"
+	OverBudget   = "over_budget"
+)
+
+// TotalBudgetCategory is the category of the overage of the monthly limit
+const TotalBudgetCategory = "total"
+
+// BudgetOverage is a limit the estimate exceeds
+type BudgetOverage struct {
+	// Category is a resource category, or "total" for the monthly limit
+	Category string  `json:"category"`
+	Limit    float64 `json:"limit"`
+	Cost     float64 `json:"cost"`
+	Overage  float64 `json:"overage"`
+}
+
+// BudgetStatus is the comparison of a monthly estimate against a budget
+type BudgetStatus struct {
+	// Status is within_budget or over_budget
+	Status string       `json:"status"`
+	Action BudgetAction `json:"action"`
+	// Blocked is true when the estimate is over a budget that blocks versions, or leaves resources unpriced under one
+	Blocked       bool               `json:"blocked"`
+	MonthlyCost   float64            `json:"monthly_cost"`
+	MonthlyLimit  float64            `json:"monthly_limit,omitempty"`
+	CategoryCosts map[string]float64 `json:"category_costs"`
+	Overages      []BudgetOverage    `json:"overages,omitempty"`
+	// Unpriced lists resources left out of the estimate because their region has no pricing data
+	Unpriced []string `json:"unpriced,omitempty"`
+}
+
+// BudgetCategory returns the budget key of a resource category, e.g. "compute" for "Compute"
+func BudgetCategory(category string) string {
+	return strings.ToLower(category)
+}
+
+// Validate checks the limits are positive and name resource categories, and the action is known
+func (b *Budget) Validate() error {
+	if b.MonthlyLimit < 0 {
+		return fmt.Errorf("monthly_limit cannot be negative")
+	}
+	if b.MonthlyLimit == 0 && len(b.CategoryLimits) == 0 {
+		return fmt.Errorf("a monthly_limit or category_limits is required")
+	}
+	for category, limit := range b.CategoryLimits {
+		if !isBudgetCategory(category) {
+			return fmt.Errorf("unknown category %q in category_limits", category)
+		}
+		if limit <= 0 {
+			return fmt.Errorf("category %q: limit must be positive", category)
+		}
+	}
+	switch b.Action {
+	case "", BudgetFlag, BudgetBlock:
+	default:
+		return fmt.Errorf("unknown action %q (flag or block)", b.Action)
+	}
+	return nil
+}
+
+func isBudgetCategory(category string) bool {
+	for _, valid := range resource.ValidCategories() {
+		if BudgetCategory(valid) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate compares a monthly cost, and its split by budget category, against the budget
+func (b *Budget) Evaluate(monthlyCost float64, categoryCosts map[string]float64) *BudgetStatus {
+	action := b.Action
+	if action == "" {
+		action = BudgetFlag
+	}
+	status := &BudgetStatus{
+		Status:        WithinBudget,
+		Action:        action,
+		MonthlyCost:   monthlyCost,
+		MonthlyLimit:  b.MonthlyLimit,
+		CategoryCosts: categoryCosts,
+	}
+	if status.CategoryCosts == nil {
+		status.CategoryCosts = map[string]float64{}
+	}
+
+	if b.MonthlyLimit > 0 && monthlyCost > b.MonthlyLimit {
+		status.Overages = append(status.Overages, BudgetOverage{
+			Category: TotalBudgetCategory,
+			Limit:    b.MonthlyLimit,
+			Cost:     monthlyCost,
+			Overage:  monthlyCost - b.MonthlyLimit,
+		})
+	}
+
+	categories := make([]string, 0, len(b.CategoryLimits))
+	for category := range b.CategoryLimits {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		limit, cost := b.CategoryLimits[category], categoryCosts[category]
+		if limit > 0 && cost > limit {
+			status.Overages = append(status.Overages, BudgetOverage{
+				Category: category,
+				Limit:    limit,
+				Cost:     cost,
+				Overage:  cost - limit,
+			})
+		}
+	}
+
+	if len(status.Overages) > 0 {
+		status.Status = OverBudget
+		status.Blocked = action == BudgetBlock
+	}
+	return status
+}
+
+// SetUnpriced records the resources left out of the estimate. They could hide an overage,
+// so a budget that blocks versions blocks the estimate.
+func (s *BudgetStatus) SetUnpriced(resources []string) {
+	s.Unpriced = resources
+	if len(resources) > 0 && s.Action == BudgetBlock {
+		s.Blocked = true
+	}
+}
+
+// Summary describes the overages and unpriced resources, e.g. "total $1200.00 over $1000.00 limit"
+func (s *BudgetStatus) Summary() string {
+	var parts []string
+	for _, o := range s.Overages {
+		parts = append(parts, fmt.Sprintf("%s $%.2f over $%.2f limit", o.Category, o.Cost, o.Limit))
+	}
+	if len(s.Unpriced) > 0 {
+		parts = append(parts, "unpriced "+strings.Join(s.Unpriced, "; "))
+	}
+	if len(parts) == 0 {
+		return "within budget"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,73 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBudget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		budget  Budget
+		wantErr bool
+	}{
+		{name: "monthly limit", budget: Budget{MonthlyLimit: 1000}},
+		{name: "category limits", budget: Budget{CategoryLimits: map[string]float64{"compute": 500, "networking": 100}, Action: BudgetBlock}},
+		{name: "no limit", budget: Budget{Action: BudgetFlag}, wantErr: true},
+		{name: "negative limit", budget: Budget{MonthlyLimit: -1}, wantErr: true},
+		{name: "unknown category", budget: Budget{CategoryLimits: map[string]float64{"gpu": 100}}, wantErr: true},
+		{name: "capitalized category", budget: Budget{CategoryLimits: map[string]float64{"Compute": 100}}, wantErr: true},
+		{name: "zero category limit", budget: Budget{CategoryLimits: map[string]float64{"storage": 0}}, wantErr: true},
+		{name: "unknown action", budget: Budget{MonthlyLimit: 100, Action: "warn"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.budget.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBudget_Evaluate(t *testing.T) {
+	costs := map[string]float64{"compute": 700, "networking": 90, "storage": 30}
+
+	within := (&Budget{MonthlyLimit: 1000, CategoryLimits: map[string]float64{"networking": 100}}).Evaluate(820, costs)
+	if within.Status != WithinBudget || within.Action != BudgetFlag || within.Blocked || len(within.Overages) != 0 {
+		t.Errorf("Expected a status within budget, got %+v", within)
+	}
+
+	flagged := (&Budget{MonthlyLimit: 800, CategoryLimits: map[string]float64{"networking": 50, "compute": 1000}}).Evaluate(820, costs)
+	if flagged.Status != OverBudget || flagged.Blocked {
+		t.Fatalf("Expected a flagged status over budget, got %+v", flagged)
+	}
+	if len(flagged.Overages) != 2 || flagged.Overages[0].Category != TotalBudgetCategory || flagged.Overages[1].Category != "networking" {
+		t.Fatalf("Expected total and networking overages, got %+v", flagged.Overages)
+	}
+	if math.Abs(flagged.Overages[0].Overage-20) > 1e-9 || math.Abs(flagged.Overages[1].Overage-40) > 1e-9 {
+		t.Errorf("Unexpected overage amounts: %+v", flagged.Overages)
+	}
+
+	blocked := (&Budget{CategoryLimits: map[string]float64{"compute": 500}, Action: BudgetBlock}).Evaluate(820, costs)
+	if !blocked.Blocked || blocked.Summary() != "compute $700.00 over $500.00 limit" {
+		t.Errorf("Expected a blocked compute overage, got %+v (%s)", blocked, blocked.Summary())
+	}
+}
+
+func TestBudgetStatus_SetUnpriced(t *testing.T) {
+	unpriced := []string{"nat-eu (NATGateway): no pricing data for eu-west-1"}
+
+	flagged := (&Budget{MonthlyLimit: 1000}).Evaluate(820, nil)
+	flagged.SetUnpriced(unpriced)
+	if flagged.Status != WithinBudget || flagged.Blocked || len(flagged.Unpriced) != 1 {
+		t.Errorf("Expected a flag budget to report unpriced resources without blocking, got %+v", flagged)
+	}
+
+	blocked := (&Budget{MonthlyLimit: 1000, Action: BudgetBlock}).Evaluate(820, nil)
+	blocked.SetUnpriced(unpriced)
+	if !blocked.Blocked || blocked.Summary() != "unpriced "+unpriced[0] {
+		t.Errorf("Expected unpriced resources to block, got %+v (%s)", blocked, blocked.Summary())
+	}
+}
@@ -0,0 +1,137 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// Forecast horizon limits, in months
+const (
+	MinForecastMonths = 12
+	MaxForecastMonths = 36
+)
+
+// GrowthModel is how a cost driver grows month over month
+type GrowthModel string
+
+const (
+	// LinearGrowth adds Rate percent of the first month's amount every month
+	LinearGrowth GrowthModel = "linear"
+	// PercentageGrowth grows the amount by Rate percent every month, compounded
+	PercentageGrowth GrowthModel = "percentage"
+)
+
+// Growth is the monthly growth of a cost driver. Rate is a percentage and may be
+// negative for a shrinking workload.
+type Growth struct {
+	Model GrowthModel `json:"model"`
+	Rate  float64     `json:"rate"`
+}
+
+// Factor returns the amount in a month relative to the first month (month 0).
+// A nil growth is flat; amounts never drop below zero.
+func (g *Growth) Factor(month int) float64 {
+	if g == nil || month <= 0 {
+		return 1
+	}
+	var factor float64
+	switch g.Model {
+	case PercentageGrowth:
+		factor = math.Pow(1+g.Rate/100, float64(month))
+	default:
+		factor = 1 + g.Rate/100*float64(month)
+	}
+	return math.Max(factor, 0)
+}
+
+// Validate checks the model is known and the rate does not shrink by 100% or more
+func (g *Growth) Validate() error {
+	switch g.Model {
+	case LinearGrowth, PercentageGrowth:
+	default:
+		return fmt.Errorf("unknown growth model %q (linear or percentage)", g.Model)
+	}
+	if g.Rate <= -100 {
+		return fmt.Errorf("growth rate must be greater than -100%%, got %v", g.Rate)
+	}
+	return nil
+}
+
+// GrowthAssumptions holds the growth of each cost driver; drivers without growth stay flat
+type GrowthAssumptions struct {
+	// Instances grows the hours of instances: EC2, Auto Scaling groups, containers and databases
+	Instances *Growth `json:"instances,omitempty"`
+	// Storage grows stored data: volumes, buckets and database storage
+	Storage *Growth `json:"storage,omitempty"`
+	// Traffic grows requests, data transfer, data processing and load balancer capacity units
+	Traffic *Growth `json:"traffic,omitempty"`
+}
+
+// Validate checks every growth that is set
+func (a GrowthAssumptions) Validate() error {
+	for name, g := range map[string]*Growth{"instances": a.Instances, "storage": a.Storage, "traffic": a.Traffic} {
+		if g == nil {
+			continue
+		}
+		if err := g.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// CostDriver is what makes a cost component grow
+type CostDriver string
+
+const (
+	DriverInstances CostDriver = "instances"
+	DriverStorage   CostDriver = "storage"
+	DriverTraffic   CostDriver = "traffic"
+	// DriverFixed is a cost that does not grow with the workload, e.g. a NAT gateway's hours
+	DriverFixed CostDriver = "fixed"
+)
+
+// Factor returns the growth factor of a driver in a month (month 0 is the first month)
+func (a GrowthAssumptions) Factor(driver CostDriver, month int) float64 {
+	switch driver {
+	case DriverInstances:
+		return a.Instances.Factor(month)
+	case DriverStorage:
+		return a.Storage.Factor(month)
+	case DriverTraffic:
+		return a.Traffic.Factor(month)
+	}
+	return 1
+}
+
+// ComponentDriver classifies a cost component of a resource in a category. Requests,
+// transferred or processed data and load balancer capacity units are traffic; stored
+// data is storage; hourly charges of compute, container and database resources are
+// instances. Other charges, such as the hours of load balancers and networking
+// resources, are fixed.
+func ComponentDriver(category, componentName string, model PricingModel) CostDriver {
+	switch model {
+	case PerRequest:
+		return DriverTraffic
+	case PerGB:
+		if strings.Contains(componentName, "Storage") {
+			return DriverStorage
+		}
+		return DriverTraffic
+	case PerHour, Reserved, SavingsPlan:
+		if strings.Contains(componentName, "LCU") {
+			return DriverTraffic
+		}
+		if strings.HasPrefix(componentName, "Load Balancer") {
+			return DriverFixed
+		}
+		switch category {
+		case resource.CategoryCompute, resource.CategoryContainers, resource.CategoryDatabase:
+			return DriverInstances
+		}
+	}
+	return DriverFixed
+}
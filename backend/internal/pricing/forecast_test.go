@@ -0,0 +1,68 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestGrowth_Factor(t *testing.T) {
+	tests := []struct {
+		name   string
+		growth *Growth
+		month  int
+		want   float64
+	}{
+		{name: "no growth", growth: nil, month: 11, want: 1},
+		{name: "first month", growth: &Growth{Model: PercentageGrowth, Rate: 10}, month: 0, want: 1},
+		{name: "linear", growth: &Growth{Model: LinearGrowth, Rate: 10}, month: 12, want: 2.2},
+		{name: "percentage", growth: &Growth{Model: PercentageGrowth, Rate: 10}, month: 2, want: 1.21},
+		{name: "shrinking", growth: &Growth{Model: LinearGrowth, Rate: -50}, month: 3, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.growth.Factor(tt.month); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Factor(%d) = %v, want %v", tt.month, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrowthAssumptions_Validate(t *testing.T) {
+	if err := (GrowthAssumptions{Instances: &Growth{Model: LinearGrowth, Rate: 5}}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (GrowthAssumptions{Storage: &Growth{Model: "exponential", Rate: 5}}).Validate(); err == nil {
+		t.Error("expected an unknown growth model to be rejected")
+	}
+	if err := (GrowthAssumptions{Traffic: &Growth{Model: PercentageGrowth, Rate: -100}}).Validate(); err == nil {
+		t.Error("expected a rate of -100% to be rejected")
+	}
+}
+
+func TestComponentDriver(t *testing.T) {
+	tests := []struct {
+		category  string
+		component string
+		model     PricingModel
+		want      CostDriver
+	}{
+		{resource.CategoryCompute, "EC2 Instance Hourly", PerHour, DriverInstances},
+		{resource.CategoryCompute, "EC2 Instance Reserved 1yr No Upfront", Reserved, DriverInstances},
+		{resource.CategoryDatabase, "RDS Instance Hourly", PerHour, DriverInstances},
+		{resource.CategoryStorage, "EBS Volume Storage", PerGB, DriverStorage},
+		{resource.CategoryStorage, "S3 GET Requests", PerRequest, DriverTraffic},
+		{resource.CategoryNetworking, "NAT Gateway Data Processing", PerGB, DriverTraffic},
+		{resource.CategoryNetworking, "NAT Gateway Hourly", PerHour, DriverFixed},
+		{resource.CategoryCompute, "Load Balancer Hourly", PerHour, DriverFixed},
+		{resource.CategoryCompute, "Load Balancer LCU", PerHour, DriverTraffic},
+	}
+
+	for _, tt := range tests {
+		if got := ComponentDriver(tt.category, tt.component, tt.model); got != tt.want {
+			t.Errorf("ComponentDriver(%q, %q) = %s, want %s", tt.category, tt.component, got, tt.want)
+		}
+	}
+}
@@ -73,6 +73,8 @@ type CostEstimate struct {
 	Provider CloudProvider `json:"provider"`
 	// Region is the region (if applicable)
 	Region *string `json:"region,omitempty"`
+	// PurchaseOption is how compute is paid for in this estimate (on-demand when empty)
+	PurchaseOption PurchaseOption `json:"purchase_option,omitempty"`
 }
 
 // HiddenDependencyCost represents the cost of a hidden/implicit dependency
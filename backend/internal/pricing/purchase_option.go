@@ -0,0 +1,124 @@
+package pricing
+
+import "fmt"
+
+// PurchaseOption is how compute capacity is paid for: On-Demand, or committed for a
+// term with a Reserved Instance or a Compute Savings Plan
+type PurchaseOption string
+
+const (
+	// OnDemand pays the hourly rate with no commitment
+	OnDemand PurchaseOption = "on_demand"
+
+	// Reserved1YrNoUpfront is a 1 year Standard Reserved Instance paid monthly
+	Reserved1YrNoUpfront PurchaseOption = "reserved_1yr_no_upfront"
+	// Reserved1YrPartialUpfront is a 1 year Standard Reserved Instance paid partly upfront
+	Reserved1YrPartialUpfront PurchaseOption = "reserved_1yr_partial_upfront"
+	// Reserved1YrAllUpfront is a 1 year Standard Reserved Instance paid upfront
+	Reserved1YrAllUpfront PurchaseOption = "reserved_1yr_all_upfront"
+	// Reserved3YrNoUpfront is a 3 year Standard Reserved Instance paid monthly
+	Reserved3YrNoUpfront PurchaseOption = "reserved_3yr_no_upfront"
+	// Reserved3YrPartialUpfront is a 3 year Standard Reserved Instance paid partly upfront
+	Reserved3YrPartialUpfront PurchaseOption = "reserved_3yr_partial_upfront"
+	// Reserved3YrAllUpfront is a 3 year Standard Reserved Instance paid upfront
+	Reserved3YrAllUpfront PurchaseOption = "reserved_3yr_all_upfront"
+
+	// SavingsPlan1YrNoUpfront is a 1 year Compute Savings Plan paid monthly
+	SavingsPlan1YrNoUpfront PurchaseOption = "savings_plan_1yr_no_upfront"
+	// SavingsPlan1YrPartialUpfront is a 1 year Compute Savings Plan paid partly upfront
+	SavingsPlan1YrPartialUpfront PurchaseOption = "savings_plan_1yr_partial_upfront"
+	// SavingsPlan1YrAllUpfront is a 1 year Compute Savings Plan paid upfront
+	SavingsPlan1YrAllUpfront PurchaseOption = "savings_plan_1yr_all_upfront"
+	// SavingsPlan3YrNoUpfront is a 3 year Compute Savings Plan paid monthly
+	SavingsPlan3YrNoUpfront PurchaseOption = "savings_plan_3yr_no_upfront"
+	// SavingsPlan3YrPartialUpfront is a 3 year Compute Savings Plan paid partly upfront
+	SavingsPlan3YrPartialUpfront PurchaseOption = "savings_plan_3yr_partial_upfront"
+	// SavingsPlan3YrAllUpfront is a 3 year Compute Savings Plan paid upfront
+	SavingsPlan3YrAllUpfront PurchaseOption = "savings_plan_3yr_all_upfront"
+)
+
+// Payment options of commitments
+const (
+	NoUpfront      = "no_upfront"
+	PartialUpfront = "partial_upfront"
+	AllUpfront     = "all_upfront"
+)
+
+type purchaseOptionInfo struct {
+	model   PricingModel
+	term    string
+	payment string
+	name    string
+}
+
+var purchaseOptions = map[PurchaseOption]purchaseOptionInfo{
+	OnDemand:                     {PerHour, "", "", "On-Demand"},
+	Reserved1YrNoUpfront:         {Reserved, "1yr", NoUpfront, "Reserved 1yr No Upfront"},
+	Reserved1YrPartialUpfront:    {Reserved, "1yr", PartialUpfront, "Reserved 1yr Partial Upfront"},
+	Reserved1YrAllUpfront:        {Reserved, "1yr", AllUpfront, "Reserved 1yr All Upfront"},
+	Reserved3YrNoUpfront:         {Reserved, "3yr", NoUpfront, "Reserved 3yr No Upfront"},
+	Reserved3YrPartialUpfront:    {Reserved, "3yr", PartialUpfront, "Reserved 3yr Partial Upfront"},
+	Reserved3YrAllUpfront:        {Reserved, "3yr", AllUpfront, "Reserved 3yr All Upfront"},
+	SavingsPlan1YrNoUpfront:      {SavingsPlan, "1yr", NoUpfront, "Savings Plan 1yr No Upfront"},
+	SavingsPlan1YrPartialUpfront: {SavingsPlan, "1yr", PartialUpfront, "Savings Plan 1yr Partial Upfront"},
+	SavingsPlan1YrAllUpfront:     {SavingsPlan, "1yr", AllUpfront, "Savings Plan 1yr All Upfront"},
+	SavingsPlan3YrNoUpfront:      {SavingsPlan, "3yr", NoUpfront, "Savings Plan 3yr No Upfront"},
+	SavingsPlan3YrPartialUpfront: {SavingsPlan, "3yr", PartialUpfront, "Savings Plan 3yr Partial Upfront"},
+	SavingsPlan3YrAllUpfront:     {SavingsPlan, "3yr", AllUpfront, "Savings Plan 3yr All Upfront"},
+}
+
+// PurchaseOptions lists every purchase option, On-Demand first
+var PurchaseOptions = []PurchaseOption{
+	OnDemand,
+	Reserved1YrNoUpfront, Reserved1YrPartialUpfront, Reserved1YrAllUpfront,
+	Reserved3YrNoUpfront, Reserved3YrPartialUpfront, Reserved3YrAllUpfront,
+	SavingsPlan1YrNoUpfront, SavingsPlan1YrPartialUpfront, SavingsPlan1YrAllUpfront,
+	SavingsPlan3YrNoUpfront, SavingsPlan3YrPartialUpfront, SavingsPlan3YrAllUpfront,
+}
+
+// ParsePurchaseOption parses a purchase option, defaulting to On-Demand when empty
+func ParsePurchaseOption(s string) (PurchaseOption, error) {
+	if s == "" {
+		return OnDemand, nil
+	}
+	if _, ok := purchaseOptions[PurchaseOption(s)]; !ok {
+		return "", fmt.Errorf("unknown purchase option %q", s)
+	}
+	return PurchaseOption(s), nil
+}
+
+// PurchaseOptionFor returns the commitment purchase option with the model, term and
+// payment option, as recorded on imported pricing rates
+func PurchaseOptionFor(model PricingModel, term, payment string) (PurchaseOption, bool) {
+	for opt, info := range purchaseOptions {
+		if opt != OnDemand && info.model == model && info.term == term && info.payment == payment {
+			return opt, true
+		}
+	}
+	return "", false
+}
+
+// Model returns the pricing model of rates for the purchase option
+func (o PurchaseOption) Model() PricingModel {
+	return purchaseOptions[o].model
+}
+
+// Term returns the commitment term ("1yr" or "3yr"), empty for On-Demand
+func (o PurchaseOption) Term() string {
+	return purchaseOptions[o].term
+}
+
+// PaymentOption returns how the commitment is paid (no_upfront, partial_upfront or all_upfront), empty for On-Demand
+func (o PurchaseOption) PaymentOption() string {
+	return purchaseOptions[o].payment
+}
+
+// DisplayName returns a human readable name (e.g., "Reserved 1yr No Upfront")
+func (o PurchaseOption) DisplayName() string {
+	return purchaseOptions[o].name
+}
+
+// IsCommitment reports whether the purchase option commits to a term
+func (o PurchaseOption) IsCommitment() bool {
+	return o != OnDemand && o != ""
+}
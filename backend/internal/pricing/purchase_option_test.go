@@ -0,0 +1,48 @@
+package pricing
+
+import "testing"
+
+func TestParsePurchaseOption(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    PurchaseOption
+		wantErr bool
+	}{
+		{input: "", want: OnDemand},
+		{input: "on_demand", want: OnDemand},
+		{input: "reserved_3yr_partial_upfront", want: Reserved3YrPartialUpfront},
+		{input: "savings_plan_1yr_no_upfront", want: SavingsPlan1YrNoUpfront},
+		{input: "reserved_5yr_no_upfront", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePurchaseOption(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePurchaseOption(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePurchaseOption(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPurchaseOptions(t *testing.T) {
+	if PurchaseOptions[0] != OnDemand {
+		t.Errorf("Expected on-demand first, got %q", PurchaseOptions[0])
+	}
+	if len(PurchaseOptions) != len(purchaseOptions) {
+		t.Errorf("PurchaseOptions lists %d options, %d are defined", len(PurchaseOptions), len(purchaseOptions))
+	}
+
+	for _, option := range PurchaseOptions[1:] {
+		if !option.IsCommitment() {
+			t.Errorf("%s: expected a commitment", option)
+		}
+		got, ok := PurchaseOptionFor(option.Model(), option.Term(), option.PaymentOption())
+		if !ok || got != option {
+			t.Errorf("PurchaseOptionFor(%s, %s, %s) = %q, want %q", option.Model(), option.Term(), option.PaymentOption(), got, option)
+		}
+	}
+}
@@ -16,6 +16,10 @@ const (
 	Tiered PricingModel = "tiered"
 	// Percentage pricing model - percentage-based (e.g., 2% of resource cost)
 	Percentage PricingModel = "percentage"
+	// Reserved pricing model - effective hourly rate of a Reserved Instance, upfront payment amortized over the term
+	Reserved PricingModel = "reserved"
+	// SavingsPlan pricing model - effective hourly rate under a Compute Savings Plan, upfront payment amortized over the term
+	SavingsPlan PricingModel = "savings_plan"
 )
 
 // Currency represents the currency type
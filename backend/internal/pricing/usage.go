@@ -0,0 +1,272 @@
+package pricing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+// UsageProfile describes how an architecture is used, so estimates price traffic,
+// requests and uptime instead of assuming 24/7 operation with no traffic. Resources
+// are referred to by name. Amounts are per month.
+type UsageProfile struct {
+	// Schedule is the uptime of every hourly billed resource without its own schedule
+	Schedule *UptimeSchedule `json:"schedule,omitempty"`
+	// Resources holds the usage of resources by resource name
+	Resources map[string]ResourceUsage `json:"resources,omitempty"`
+	// Edges is the traffic between resources, or from a resource to the internet
+	Edges []EdgeTraffic `json:"edges,omitempty"`
+}
+
+// Uptime schedule presets
+const (
+	AlwaysOn      = "always_on"      // 168 hours per week
+	BusinessHours = "business_hours" // Monday to Friday, 08:00 to 18:00
+	Weekdays      = "weekdays"       // Monday to Friday, all day
+)
+
+// HoursPerWeek is the number of hours in a week
+const HoursPerWeek = 168.0
+
+var schedulePresets = map[string]float64{
+	AlwaysOn:      HoursPerWeek,
+	BusinessHours: 50,
+	Weekdays:      120,
+}
+
+// UptimeSchedule is when a resource runs: a preset, or a number of hours per week
+type UptimeSchedule struct {
+	Preset       string  `json:"preset,omitempty"`
+	HoursPerWeek float64 `json:"hours_per_week,omitempty"`
+}
+
+// Fraction returns the share of the time the resource runs, between 0 and 1
+func (s *UptimeSchedule) Fraction() float64 {
+	if s == nil {
+		return 1
+	}
+	hours := s.HoursPerWeek
+	if preset, ok := schedulePresets[s.Preset]; ok {
+		hours = preset
+	}
+	if hours <= 0 || hours > HoursPerWeek {
+		return 1
+	}
+	return hours / HoursPerWeek
+}
+
+// Validate checks the schedule has a known preset or hours within a week
+func (s *UptimeSchedule) Validate() error {
+	if s.Preset != "" {
+		if _, ok := schedulePresets[s.Preset]; !ok {
+			return fmt.Errorf("unknown schedule preset %q (always_on, business_hours or weekdays)", s.Preset)
+		}
+		return nil
+	}
+	if s.HoursPerWeek <= 0 || s.HoursPerWeek > HoursPerWeek {
+		return fmt.Errorf("schedule hours_per_week must be between 0 and 168, got %v", s.HoursPerWeek)
+	}
+	return nil
+}
+
+// ResourceUsage is the monthly usage of one resource. Zero values are not set.
+type ResourceUsage struct {
+	// Schedule is the resource's uptime, overriding the profile schedule
+	Schedule *UptimeSchedule `json:"schedule,omitempty"`
+	// RequestsPerMonth is the number of Lambda invocations
+	RequestsPerMonth float64 `json:"requests_per_month,omitempty"`
+	// AverageDurationMs is the average Lambda invocation duration
+	AverageDurationMs float64 `json:"average_duration_ms,omitempty"`
+	// LCUs is the average number of load balancer capacity units used per hour
+	LCUs float64 `json:"lcus,omitempty"`
+	// PutRequestsPerMonth and GetRequestsPerMonth are S3 request counts
+	PutRequestsPerMonth float64 `json:"put_requests_per_month,omitempty"`
+	GetRequestsPerMonth float64 `json:"get_requests_per_month,omitempty"`
+	// ReadRequestsPerMonth and WriteRequestsPerMonth are the request units of an on-demand DynamoDB table
+	ReadRequestsPerMonth  float64 `json:"read_requests_per_month,omitempty"`
+	WriteRequestsPerMonth float64 `json:"write_requests_per_month,omitempty"`
+	// StorageGB is the average amount of data stored in an S3 bucket or DynamoDB table
+	StorageGB float64 `json:"storage_gb,omitempty"`
+	// DataTransferOutGB is the data sent to the internet by an S3 bucket or Lambda function
+	DataTransferOutGB float64 `json:"data_transfer_out_gb,omitempty"`
+	// DataProcessedGB is the data processed by a NAT gateway or VPC endpoint
+	DataProcessedGB float64 `json:"data_processed_gb,omitempty"`
+	// AverageCapacity is the average number of instances of an Auto Scaling group
+	AverageCapacity float64 `json:"average_capacity,omitempty"`
+}
+
+// Edge traffic transfer types
+const (
+	TransferInternet = "internet" // out of AWS to the internet
+	TransferInterAZ  = "inter_az" // between availability zones of a region
+	TransferSameAZ   = "same_az"  // within an availability zone, free
+)
+
+// EdgeTraffic is the data sent along an edge of the diagram each month
+type EdgeTraffic struct {
+	// Source is the name of the sending resource
+	Source string `json:"source"`
+	// Target is the name of the receiving resource, empty for the internet
+	Target string `json:"target,omitempty"`
+	// GBPerMonth is the data sent each month
+	GBPerMonth float64 `json:"gb_per_month"`
+	// TransferType is internet, inter_az or same_az. When empty it is internet without
+	// a target, otherwise inferred from the availability zones of the resources.
+	TransferType string `json:"transfer_type,omitempty"`
+}
+
+// Validate checks schedules, amounts and edges of the profile
+func (p *UsageProfile) Validate() error {
+	if p.Schedule != nil {
+		if err := p.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	for name, usage := range p.Resources {
+		if usage.Schedule != nil {
+			if err := usage.Schedule.Validate(); err != nil {
+				return fmt.Errorf("resource %q: %w", name, err)
+			}
+		}
+		for _, v := range []float64{usage.RequestsPerMonth, usage.AverageDurationMs, usage.LCUs, usage.PutRequestsPerMonth,
+			usage.GetRequestsPerMonth, usage.ReadRequestsPerMonth, usage.WriteRequestsPerMonth, usage.StorageGB, usage.DataTransferOutGB, usage.DataProcessedGB, usage.AverageCapacity} {
+			if v < 0 {
+				return fmt.Errorf("resource %q: usage amounts cannot be negative", name)
+			}
+		}
+	}
+	for i, edge := range p.Edges {
+		if edge.Source == "" {
+			return fmt.Errorf("edge %d: source is required", i)
+		}
+		if edge.GBPerMonth < 0 {
+			return fmt.Errorf("edge %d: gb_per_month cannot be negative", i)
+		}
+		switch edge.TransferType {
+		case "", TransferInternet, TransferInterAZ, TransferSameAZ:
+		default:
+			return fmt.Errorf("edge %d: unknown transfer_type %q (internet, inter_az or same_az)", i, edge.TransferType)
+		}
+	}
+	return nil
+}
+
+// DataTransferResourceType is the resource type of the edge traffic resources added by ApplyUsageProfile
+const DataTransferResourceType = "data_transfer"
+
+// ApplyUsageProfile returns the resources with the profile's usage written to the
+// configuration keys the pricing calculators read, scaled to the duration, plus one
+// DataTransferResourceType resource per edge with traffic. Resources without usage are
+// returned as is; a nil profile returns the resources unchanged.
+//
+// Keys written: request_count, average_duration_ms, lcus, put_requests, get_requests,
+// read_requests, write_requests, size_gb, data_transfer_gb, data_processed_gb, average_capacity and uptime_fraction.
+// Edge resources carry direction ("outbound" or "inter_az") and data_transfer_gb.
+func ApplyUsageProfile(resources []*resource.Resource, profile *UsageProfile, duration time.Duration) []*resource.Resource {
+	if profile == nil {
+		return resources
+	}
+	months := duration.Hours() / 720.0
+	byName := make(map[string]*resource.Resource, len(resources))
+	out := make([]*resource.Resource, 0, len(resources)+len(profile.Edges))
+
+	for _, res := range resources {
+		byName[res.Name] = res
+		usage, hasUsage := profile.Resources[res.Name]
+		schedule := profile.Schedule
+		if hasUsage && usage.Schedule != nil {
+			schedule = usage.Schedule
+		}
+		if !hasUsage && schedule.Fraction() == 1 {
+			out = append(out, res)
+			continue
+		}
+
+		copied := *res
+		copied.Metadata = make(map[string]interface{}, len(res.Metadata)+4)
+		for k, v := range res.Metadata {
+			copied.Metadata[k] = v
+		}
+		set := func(key string, v float64) {
+			if v > 0 {
+				copied.Metadata[key] = v
+			}
+		}
+		set("request_count", usage.RequestsPerMonth*months)
+		set("average_duration_ms", usage.AverageDurationMs)
+		set("lcus", usage.LCUs)
+		set("put_requests", usage.PutRequestsPerMonth*months)
+		set("get_requests", usage.GetRequestsPerMonth*months)
+		set("read_requests", usage.ReadRequestsPerMonth*months)
+		set("write_requests", usage.WriteRequestsPerMonth*months)
+		set("size_gb", usage.StorageGB)
+		set("data_transfer_gb", usage.DataTransferOutGB*months)
+		set("data_processed_gb", usage.DataProcessedGB*months)
+		set("average_capacity", usage.AverageCapacity)
+		if fraction := schedule.Fraction(); fraction < 1 {
+			copied.Metadata["uptime_fraction"] = fraction
+		}
+		out = append(out, &copied)
+	}
+
+	for _, edge := range profile.Edges {
+		source, ok := byName[edge.Source]
+		if !ok || edge.GBPerMonth <= 0 {
+			continue
+		}
+		target := byName[edge.Target]
+		direction := "outbound"
+		switch transferType(edge, source, target) {
+		case TransferSameAZ:
+			continue
+		case TransferInterAZ:
+			direction = "inter_az"
+		}
+		name := edge.Source + " → internet"
+		if edge.Target != "" {
+			name = edge.Source + " → " + edge.Target
+		}
+		out = append(out, &resource.Resource{
+			ID:       "edge:" + edge.Source + "->" + edge.Target,
+			Name:     name,
+			Type:     resource.ResourceType{ID: DataTransferResourceType, Name: DataTransferResourceType},
+			Provider: source.Provider,
+			Region:   source.Region,
+			Metadata: map[string]interface{}{
+				"direction":        direction,
+				"data_transfer_gb": edge.GBPerMonth * months,
+			},
+		})
+	}
+	return out
+}
+
+// transferType returns the transfer type of an edge, inferring it from the availability
+// zones of its resources. Traffic between resources in unknown zones is priced as
+// crossing zones.
+func transferType(edge EdgeTraffic, source, target *resource.Resource) string {
+	if edge.TransferType != "" {
+		return edge.TransferType
+	}
+	if edge.Target == "" {
+		return TransferInternet
+	}
+	if target == nil {
+		return TransferInterAZ
+	}
+	sourceAZ, targetAZ := availabilityZone(source), availabilityZone(target)
+	if sourceAZ != "" && sourceAZ == targetAZ {
+		return TransferSameAZ
+	}
+	return TransferInterAZ
+}
+
+func availabilityZone(res *resource.Resource) string {
+	for _, key := range []string{"availabilityZone", "availability_zone"} {
+		if az, ok := res.Metadata[key].(string); ok && az != "" {
+			return az
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
+)
+
+func TestUsageProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile UsageProfile
+		wantErr bool
+	}{
+		{name: "empty", profile: UsageProfile{}},
+		{name: "preset schedule", profile: UsageProfile{Schedule: &UptimeSchedule{Preset: BusinessHours}}},
+		{name: "hours schedule", profile: UsageProfile{Schedule: &UptimeSchedule{HoursPerWeek: 84}}},
+		{name: "unknown preset", profile: UsageProfile{Schedule: &UptimeSchedule{Preset: "weekends"}}, wantErr: true},
+		{name: "too many hours", profile: UsageProfile{Schedule: &UptimeSchedule{HoursPerWeek: 200}}, wantErr: true},
+		{name: "negative usage", profile: UsageProfile{Resources: map[string]ResourceUsage{"fn": {RequestsPerMonth: -1}}}, wantErr: true},
+		{name: "edge without source", profile: UsageProfile{Edges: []EdgeTraffic{{GBPerMonth: 10}}}, wantErr: true},
+		{name: "unknown transfer type", profile: UsageProfile{Edges: []EdgeTraffic{{Source: "a", TransferType: "cross_region"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyUsageProfile(t *testing.T) {
+	resources := []*resource.Resource{
+		{ID: "1", Name: "fn", Provider: "aws", Region: "us-east-1", Metadata: map[string]interface{}{"memory_size_mb": 512}},
+		{ID: "2", Name: "web", Provider: "aws", Region: "us-east-1", Metadata: map[string]interface{}{"availabilityZone": "us-east-1a"}},
+		{ID: "3", Name: "db", Provider: "aws", Region: "us-east-1", Metadata: map[string]interface{}{"availability_zone": "us-east-1a"}},
+		{ID: "4", Name: "cache", Provider: "aws", Region: "us-east-1"},
+	}
+	profile := &UsageProfile{
+		Schedule: &UptimeSchedule{Preset: Weekdays},
+		Resources: map[string]ResourceUsage{
+			"fn":    {RequestsPerMonth: 1000000, AverageDurationMs: 250},
+			"cache": {Schedule: &UptimeSchedule{Preset: AlwaysOn}},
+		},
+		Edges: []EdgeTraffic{
+			{Source: "fn", GBPerMonth: 10},
+			{Source: "web", Target: "db", GBPerMonth: 20},
+			{Source: "web", Target: "cache", GBPerMonth: 30},
+			{Source: "missing", GBPerMonth: 40},
+		},
+	}
+
+	got := ApplyUsageProfile(resources, profile, 2*720*time.Hour)
+
+	if len(got) != 6 {
+		t.Fatalf("Expected 4 resources and 2 edges, got %d", len(got))
+	}
+	fn := got[0]
+	if fn.Metadata["request_count"] != 2000000.0 || fn.Metadata["average_duration_ms"] != 250.0 || fn.Metadata["memory_size_mb"] != 512 {
+		t.Errorf("Unexpected function metadata: %v", fn.Metadata)
+	}
+	if _, ok := resources[0].Metadata["request_count"]; ok {
+		t.Error("Expected the original resource to be unchanged")
+	}
+	if fraction, ok := got[1].Metadata["uptime_fraction"].(float64); !ok || math.Abs(fraction-120.0/168.0) > 1e-9 {
+		t.Errorf("Expected the profile schedule on web, got %v", got[1].Metadata["uptime_fraction"])
+	}
+	if _, ok := got[3].Metadata["uptime_fraction"]; ok {
+		t.Error("Expected the resource schedule to override the profile schedule")
+	}
+
+	// Same zone traffic is free, traffic from unknown resources is ignored
+	outbound, interAZ := got[4], got[5]
+	if outbound.Type.Name != DataTransferResourceType || outbound.Metadata["direction"] != "outbound" || outbound.Metadata["data_transfer_gb"] != 20.0 {
+		t.Errorf("Unexpected internet edge: %+v", outbound)
+	}
+	if interAZ.Name != "web → cache" || interAZ.Metadata["direction"] != "inter_az" || interAZ.Metadata["data_transfer_gb"] != 60.0 {
+		t.Errorf("Unexpected inter-AZ edge: %+v", interAZ)
+	}
+
+	if unchanged := ApplyUsageProfile(resources, nil, time.Hour); len(unchanged) != len(resources) || unchanged[0] != resources[0] {
+		t.Error("Expected a nil profile to return the resources unchanged")
+	}
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS backend_config JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects DROP COLUMN IF EXISTS backend_config;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Reserved Instance and Savings Plan rates are imported next to On-Demand EC2 rates
+ALTER TABLE pricing_rates DROP CONSTRAINT IF EXISTS pricing_rates_pricing_model_check;
+ALTER TABLE pricing_rates ADD CONSTRAINT pricing_rates_pricing_model_check
+    CHECK (pricing_model IN ('per_hour', 'per_gb', 'per_request', 'one_time', 'tiered', 'percentage', 'reserved', 'savings_plan'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM pricing_rates WHERE pricing_model IN ('reserved', 'savings_plan');
+ALTER TABLE pricing_rates DROP CONSTRAINT IF EXISTS pricing_rates_pricing_model_check;
+ALTER TABLE pricing_rates ADD CONSTRAINT pricing_rates_pricing_model_check
+    CHECK (pricing_model IN ('per_hour', 'per_gb', 'per_request', 'one_time', 'tiered', 'percentage'));
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Region catalog rates include per-request and per GB-second prices below a millionth of a dollar
+ALTER TABLE pricing_rates ALTER COLUMN rate TYPE NUMERIC(20, 10);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pricing_rates ALTER COLUMN rate TYPE NUMERIC(14, 6);
+
+-- +goose StatementEnd
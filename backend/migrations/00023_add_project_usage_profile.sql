@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS usage_profile JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE projects DROP COLUMN IF EXISTS usage_profile;
+
+-- +goose StatementEnd
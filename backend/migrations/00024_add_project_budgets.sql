@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget JSONB;
+ALTER TABLE project_pricing ADD COLUMN IF NOT EXISTS budget_status JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE project_pricing DROP COLUMN IF EXISTS budget_status;
+ALTER TABLE projects DROP COLUMN IF EXISTS budget;
+
+-- +goose StatementEnd
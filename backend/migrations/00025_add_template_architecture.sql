@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS architecture JSONB;
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS source_version_id UUID REFERENCES project_versions(id) ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE templates DROP COLUMN IF EXISTS source_version_id;
+ALTER TABLE templates DROP COLUMN IF EXISTS architecture;
+
+-- +goose StatementEnd
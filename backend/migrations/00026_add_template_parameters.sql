@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS parameters JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE templates DROP COLUMN IF EXISTS parameters;
+
+-- +goose StatementEnd
@@ -0,0 +1,90 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Template ratings are recalculated by the application, which leaves hidden reviews out.
+DROP TRIGGER IF EXISTS trigger_update_template_rating ON reviews;
+DROP FUNCTION IF EXISTS update_template_rating();
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS verified_deployment BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS flag_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One review per user per template: keep the latest one
+DELETE FROM reviews a
+USING reviews b
+WHERE a.template_id = b.template_id
+  AND a.user_id = b.user_id
+  AND (a.created_at, a.id) < (b.created_at, b.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_template_user ON reviews(template_id, user_id);
+
+CREATE TABLE IF NOT EXISTS review_flags (
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- project_id is the root project created from the template; it has no foreign key so
+-- that deleting the project does not take away the right to review
+CREATE TABLE IF NOT EXISTS template_instantiations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template_id UUID NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL,
+    code_generated_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_instantiations_template_user ON template_instantiations(template_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_template_instantiations_project ON template_instantiations(project_id);
+
+UPDATE templates t
+SET
+    rating = COALESCE((SELECT AVG(r.rating) FROM reviews r WHERE r.template_id = t.id AND NOT r.hidden), 0),
+    review_count = (SELECT COUNT(*) FROM reviews r WHERE r.template_id = t.id AND NOT r.hidden);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_template_instantiations_project;
+DROP INDEX IF EXISTS idx_template_instantiations_template_user;
+DROP TABLE IF EXISTS template_instantiations;
+DROP TABLE IF EXISTS review_flags;
+DROP INDEX IF EXISTS idx_reviews_template_user;
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS hidden;
+ALTER TABLE reviews DROP COLUMN IF EXISTS flag_count;
+ALTER TABLE reviews DROP COLUMN IF EXISTS verified_deployment;
+
+CREATE OR REPLACE FUNCTION update_template_rating()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE templates
+    SET
+        rating = (
+            SELECT COALESCE(AVG(rating), 0)
+            FROM reviews
+            WHERE template_id = COALESCE(NEW.template_id, OLD.template_id)
+        ),
+        review_count = (
+            SELECT COUNT(*)
+            FROM reviews
+            WHERE template_id = COALESCE(NEW.template_id, OLD.template_id)
+        ),
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = COALESCE(NEW.template_id, OLD.template_id);
+
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_update_template_rating
+AFTER INSERT OR UPDATE OR DELETE ON reviews
+FOR EACH ROW
+EXECUTE FUNCTION update_template_rating();
+
+-- +goose StatementEnd
@@ -0,0 +1,87 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Azure resource types for the azurerm provider adapter
+DO $$
+DECLARE
+    compute_category_id INTEGER;
+    networking_category_id INTEGER;
+    storage_category_id INTEGER;
+    database_category_id INTEGER;
+    vm_kind_id INTEGER;
+    network_kind_id INTEGER;
+    lb_kind_id INTEGER;
+    storage_kind_id INTEGER;
+    database_kind_id INTEGER;
+    configuration_kind_id INTEGER;
+BEGIN
+    SELECT id INTO compute_category_id FROM resource_categories WHERE name = 'Compute';
+    SELECT id INTO networking_category_id FROM resource_categories WHERE name = 'Networking';
+    SELECT id INTO storage_category_id FROM resource_categories WHERE name = 'Storage';
+    SELECT id INTO database_category_id FROM resource_categories WHERE name = 'Database';
+    SELECT id INTO vm_kind_id FROM resource_kinds WHERE name = 'VirtualMachine';
+    SELECT id INTO network_kind_id FROM resource_kinds WHERE name = 'Network';
+    SELECT id INTO lb_kind_id FROM resource_kinds WHERE name = 'LoadBalancer';
+    SELECT id INTO storage_kind_id FROM resource_kinds WHERE name = 'Storage';
+    SELECT id INTO database_kind_id FROM resource_kinds WHERE name = 'Database';
+    SELECT id INTO configuration_kind_id FROM resource_kinds WHERE name = 'Configuration';
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'ResourceGroup' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('ResourceGroup', 'azure', networking_category_id, configuration_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'VirtualNetwork' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('VirtualNetwork', 'azure', networking_category_id, network_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'Subnet' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('Subnet', 'azure', networking_category_id, network_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'NetworkSecurityGroup' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('NetworkSecurityGroup', 'azure', networking_category_id, network_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'VirtualMachine' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('VirtualMachine', 'azure', compute_category_id, vm_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'LoadBalancer' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('LoadBalancer', 'azure', compute_category_id, lb_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'StorageAccount' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('StorageAccount', 'azure', storage_category_id, storage_kind_id, true, false);
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM resource_types WHERE name = 'SQLDatabase' AND cloud_provider = 'azure') THEN
+        INSERT INTO resource_types (name, cloud_provider, category_id, kind_id, is_regional, is_global)
+        VALUES ('SQLDatabase', 'azure', database_category_id, database_kind_id, true, false);
+    END IF;
+END $$;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM resource_types
+WHERE
+    cloud_provider = 'azure'
+    AND name IN (
+        'ResourceGroup',
+        'VirtualNetwork',
+        'Subnet',
+        'NetworkSecurityGroup',
+        'VirtualMachine',
+        'LoadBalancer',
+        'StorageAccount',
+        'SQLDatabase'
+    );
+-- +goose StatementEnd
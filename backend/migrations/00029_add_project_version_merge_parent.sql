@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A merge version's parent is the version merged into; the version merged in is its
+-- second parent, so later merges of the same lines find the merge as their base
+ALTER TABLE project_versions ADD COLUMN IF NOT EXISTS merge_parent_version_id UUID REFERENCES project_versions (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_project_versions_merge_parent_version_id ON project_versions (merge_parent_version_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_project_versions_merge_parent_version_id;
+ALTER TABLE project_versions DROP COLUMN IF EXISTS merge_parent_version_id;
+
+-- +goose StatementEnd
@@ -40,13 +40,13 @@ func Run(ctx context.Context) error {
 	// Step 2: Create User
 	fmt.Println("\n[Step 2] Creating a new user...")
 	createUserReq := request.CreateUserRequest{
-		Name:    "API Simulator User",
-		Email:   fmt.Sprintf("simulator-%d@example.com", time.Now().Unix()),
-		Auth0ID: fmt.Sprintf("auth0|simulator-%d", time.Now().Unix()),
+		Name:     "API Simulator User",
+		Email:    fmt.Sprintf("simulator-%d@example.com", time.Now().Unix()),
+		Password: "simulator-password",
 	}
 
 	var userResp map[string]interface{}
-	if err := performRequest(router, "POST", "/api/v1/users", createUserReq, &userResp, http.StatusCreated); err != nil {
+	if err := performRequest(router, "POST", "/api/v1/users", "", createUserReq, &userResp, http.StatusCreated); err != nil {
 		return err
 	}
 
@@ -56,10 +56,22 @@ func Run(ctx context.Context) error {
 	}
 	fmt.Printf("✓ User created with ID: %s\n", userID)
 
+	// Log in to authenticate the remaining requests
+	loginReq := request.LoginRequest{Email: createUserReq.Email, Password: createUserReq.Password}
+	var loginResp map[string]interface{}
+	if err := performRequest(router, "POST", "/api/v1/auth/login", "", loginReq, &loginResp, http.StatusOK); err != nil {
+		return err
+	}
+	token, ok := loginResp["access_token"].(string)
+	if !ok {
+		return fmt.Errorf("failed to extract access token from login response")
+	}
+	fmt.Println("✓ Logged in")
+
 	// Step 3: List Providers (Static Data)
 	fmt.Println("\n[Step 3] Listing supported cloud providers...")
 	var providersResp []interface{}
-	if err := performRequest(router, "GET", "/api/v1/static/providers", nil, &providersResp, http.StatusOK); err != nil {
+	if err := performRequest(router, "GET", "/api/v1/static/providers", "", nil, &providersResp, http.StatusOK); err != nil {
 		return err
 	}
 	fmt.Printf("✓ Retrieved %d providers\n", len(providersResp))
@@ -71,11 +83,10 @@ func Run(ctx context.Context) error {
 		CloudProvider: "aws",
 		Region:        "us-east-1",
 		IACToolID:     1,
-		UserID:        userID,
 	}
 
 	var projectResp map[string]interface{}
-	if err := performRequest(router, "POST", "/api/v1/projects", createProjectReq, &projectResp, http.StatusCreated); err != nil {
+	if err := performRequest(router, "POST", "/api/v1/projects", token, createProjectReq, &projectResp, http.StatusCreated); err != nil {
 		return err
 	}
 
@@ -93,11 +104,11 @@ func Run(ctx context.Context) error {
 		"edges": []interface{}{},
 	}
 
-	// Query params: project_name, user_id
-	path := fmt.Sprintf("/api/v1/diagrams/process?project_name=Diagram%%20Project&user_id=%s&iac_tool_id=1", userID)
+	// Query params: project_name, iac_tool_id
+	path := "/api/v1/diagrams/process?project_name=Diagram%20Project&iac_tool_id=1"
 
 	var diagramResp map[string]interface{}
-	if err := performRequest(router, "POST", path, diagramJSON, &diagramResp, http.StatusOK); err != nil {
+	if err := performRequest(router, "POST", path, token, diagramJSON, &diagramResp, http.StatusOK); err != nil {
 		return err
 	}
 
@@ -111,7 +122,7 @@ func Run(ctx context.Context) error {
 	fmt.Println("\n[Step 6] Retrieving project details...")
 	path = fmt.Sprintf("/api/v1/projects/%s", projectID)
 	var getProjectResp map[string]interface{}
-	if err := performRequest(router, "GET", path, nil, &getProjectResp, http.StatusOK); err != nil {
+	if err := performRequest(router, "GET", path, token, nil, &getProjectResp, http.StatusOK); err != nil {
 		return err
 	}
 	fmt.Printf("✓ Retrieved project: %s\n", getProjectResp["name"])
@@ -120,7 +131,7 @@ func Run(ctx context.Context) error {
 	fmt.Println("\n[Step 7] Listing user projects...")
 	path = fmt.Sprintf("/api/v1/users/%s/projects", userID)
 	var listProjectsResp map[string]interface{}
-	if err := performRequest(router, "GET", path, nil, &listProjectsResp, http.StatusOK); err != nil {
+	if err := performRequest(router, "GET", path, token, nil, &listProjectsResp, http.StatusOK); err != nil {
 		return err
 	}
 	projectsList, ok := listProjectsResp["projects"].([]interface{})
@@ -136,7 +147,7 @@ func Run(ctx context.Context) error {
 	return nil
 }
 
-func performRequest(r *gin.Engine, method, path string, body interface{}, target interface{}, expectedStatus int) error {
+func performRequest(r *gin.Engine, method, path, token string, body interface{}, target interface{}, expectedStatus int) error {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBytes, err := json.Marshal(body)
@@ -150,6 +161,9 @@ func performRequest(r *gin.Engine, method, path string, body interface{}, target
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -102,7 +102,7 @@ func generateTerraform(ctx context.Context, arch *architecture.Architecture, sor
 	}
 
 	engine := tfgen.NewEngine(mapperRegistry)
-	output, err := engine.Generate(ctx, arch, sorted)
+	output, err := engine.Generate(ctx, arch, sorted, iac.Options{})
 	if err != nil {
 		return nil, fmt.Errorf("terraform engine generate: %w", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/architecture"
 	_ "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/iam" // Register IAM mappers
 	awsmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/mapper/terraform"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/generator"
 	tfmapper "github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac/terraform/mapper"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/resource"
@@ -62,7 +63,7 @@ func run() error {
 	_ = os.RemoveAll(outputDir) // Clean up
 
 	// Note: Generator expects sorted list, we just pass the slice
-	output, err := tfGenerator.Generate(context.Background(), arch, arch.Resources)
+	output, err := tfGenerator.Generate(context.Background(), arch, arch.Resources, iac.Options{})
 	if err != nil {
 		return fmt.Errorf("generate failed: %w", err)
 	}
@@ -81,14 +82,18 @@ func run() error {
 	fmt.Printf("Generated %d files in %s\n", len(output.Files), outputDir)
 
 	// 4. Validate output
-	mainTfPath := outputDir + "/main.tf"
-	contentBytes, err := os.ReadFile(mainTfPath)
-	if err != nil {
-		return fmt.Errorf("read main.tf failed: %w", err)
+	// Resources are split across per-category files (iam.tf, ...), so check them all.
+	var sb strings.Builder
+	for _, file := range output.Files {
+		contentBytes, err := os.ReadFile(outputDir + "/" + file.Path)
+		if err != nil {
+			return fmt.Errorf("read %s failed: %w", file.Path, err)
+		}
+		sb.Write(contentBytes)
 	}
-	content := string(contentBytes)
+	content := sb.String()
 
-	fmt.Println("\n--- Generated main.tf Content ---")
+	fmt.Println("\n--- Generated Terraform Content ---")
 	fmt.Println(content)
 	fmt.Println("-------------------------------")
 
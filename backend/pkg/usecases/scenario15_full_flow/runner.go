@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"log/slog"
@@ -264,16 +265,17 @@ func run() error {
 	fmt.Printf("✓ Code generated. Files: %d\n", len(output.Files))
 
 	// 10. Validate Output
-	var mainTfContent string
+	// Resources are split across per-category files, so validate their combined content.
+	var sb strings.Builder
 	for _, f := range output.Files {
-		if f.Path == "main.tf" {
-			mainTfContent = f.Content
-			break
+		if strings.HasSuffix(f.Path, ".tf") {
+			sb.WriteString(f.Content)
 		}
 	}
+	mainTfContent := sb.String()
 
 	if mainTfContent == "" {
-		return fmt.Errorf("main.tf not found in output")
+		return fmt.Errorf("no Terraform files found in output")
 	}
 
 	fmt.Println("\n--- Validating Generated Terraform ---")
@@ -301,9 +303,16 @@ func run() error {
 
 	// Save to file for inspection
 	outDir := "terraform_output_scenario15"
-	os.MkdirAll(outDir, 0755)
-	os.WriteFile(outDir+"/main.tf", []byte(mainTfContent), 0644)
-	fmt.Printf("Saved main.tf to %s/main.tf\n", outDir)
+	for _, f := range output.Files {
+		path := filepath.Join(outDir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	fmt.Printf("Saved %d files to %s\n", len(output.Files), outDir)
 
 	if !allPassed {
 		return fmt.Errorf("simulation verification failed")
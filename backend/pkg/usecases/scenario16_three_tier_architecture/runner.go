@@ -7,9 +7,11 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/google/uuid"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/cloud/aws/architecture"
+	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/iac"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/database"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/models"
 	"github.com/mo7amedgom3a/arch-visualizer/backend/internal/platform/server/services"
@@ -263,7 +265,7 @@ func run() error {
 
 	// 8. Generate Terraform (Tests Generation layer)
 	fmt.Println("Generating Terraform...")
-	output, err := codegenService.Generate(ctx, archDomain, "terraform")
+	output, err := codegenService.Generate(ctx, archDomain, "terraform", iac.Options{})
 	if err != nil {
 		return fmt.Errorf("Codegen failed: %w", err)
 	}
@@ -273,14 +275,17 @@ func run() error {
 	os.MkdirAll(outDir, 0755)
 
 	for _, f := range output.Files {
-		path := fmt.Sprintf("%s/%s", outDir, f.Path)
+		path := filepath.Join(outDir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
 		if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
 			return err
 		}
 		fmt.Printf("✓ Wrote %s\n", path)
-		if f.Path == "main.tf" {
+		if f.Path == "networking.tf" {
 			// Print snippet
-			fmt.Println("\n--- main.tf snippet ---")
+			fmt.Println("\n--- networking.tf snippet ---")
 			if len(f.Content) > 500 {
 				fmt.Println(f.Content[:500] + "...\n(truncated)")
 			} else {
@@ -184,7 +184,7 @@ func Run(ctx context.Context) error {
 	}
 
 	engine := tfgen.NewEngine(mapperRegistry)
-	output, err := engine.Generate(ctx, arch, sortedResources)
+	output, err := engine.Generate(ctx, arch, sortedResources, iac.Options{})
 	if err != nil {
 		return fmt.Errorf("terraform engine generate: %w", err)
 	}
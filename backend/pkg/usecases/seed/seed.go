@@ -162,6 +162,15 @@ func seedReferenceData(ctx context.Context) error {
 		// Additional Networking
 		{Name: "NetworkInterface", CloudProvider: "aws", CategoryID: &networkCat.ID, KindID: &networkKind.ID, IsRegional: true, IsGlobal: false},
 		{Name: "NetworkACL", CloudProvider: "aws", CategoryID: &networkCat.ID, KindID: &configKind.ID, IsRegional: true, IsGlobal: false},
+		// Azure
+		{Name: "ResourceGroup", CloudProvider: "azure", CategoryID: &networkCat.ID, KindID: &configKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "VirtualNetwork", CloudProvider: "azure", CategoryID: &networkCat.ID, KindID: &networkKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "Subnet", CloudProvider: "azure", CategoryID: &networkCat.ID, KindID: &networkKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "NetworkSecurityGroup", CloudProvider: "azure", CategoryID: &networkCat.ID, KindID: &networkKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "VirtualMachine", CloudProvider: "azure", CategoryID: &computeCat.ID, KindID: &vmKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "LoadBalancer", CloudProvider: "azure", CategoryID: &computeCat.ID, KindID: &lbKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "StorageAccount", CloudProvider: "azure", CategoryID: &storageCat.ID, KindID: &storageKind.ID, IsRegional: true, IsGlobal: false},
+		{Name: "SQLDatabase", CloudProvider: "azure", CategoryID: &dbCat.ID, KindID: &dbKind.ID, IsRegional: true, IsGlobal: false},
 	}
 	for _, rt := range resourceTypes {
 		if err := db.WithContext(ctx).FirstOrCreate(&rt, models.ResourceType{Name: rt.Name, CloudProvider: rt.CloudProvider}).Error; err != nil {
@@ -291,6 +300,7 @@ func seedMarketplaceData(ctx context.Context, users []*models.User) error {
 	complianceStandards := []models.ComplianceStandard{
 		{Name: "SOC 2", Slug: "soc-2"},
 		{Name: "HIPAA", Slug: "hipaa"},
+		{Name: "CIS AWS Foundations", Slug: "cis-aws-foundations"},
 	}
 	for _, compliance := range complianceStandards {
 		if err := db.WithContext(ctx).FirstOrCreate(&compliance, models.ComplianceStandard{Slug: compliance.Slug}).Error; err != nil {